@@ -0,0 +1,579 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/alert"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// alertCheckInterval is how often the heartbeat daemon evaluates alert rules.
+const alertCheckInterval = time.Minute
+
+var (
+	alertsService  string
+	alertsWhen     string
+	alertsFor      string
+	alertsSeverity string
+	alertsName     string
+	alertsSilence  time.Duration
+	alertsClear    bool
+	alertsFormat   string
+)
+
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Manage alert rules on service status, metrics and deploys",
+	Long: `Alert rules watch one service each and notify through the configured
+channels when their condition holds, and again when it clears.
+
+  orbit alerts add myshop --service api --when "status != healthy" --for 5m
+  orbit alerts add myshop --service api --when "response_time > 800" --for 10m
+  orbit alerts add myshop --service api --when cpu
+  orbit alerts add myshop --service web --when "deploy failed"
+  orbit alerts list myshop
+  orbit alerts silence myshop api-status --for 2h
+  orbit alerts remove myshop api-status
+
+Conditions test status (== or !=), response_time (ms), cpu or memory (%)
+with >, >=, < or <=; a metric on its own uses its global threshold.
+"deploy failed" fires once per failed deployment.
+
+Rules are evaluated every minute by the project's heartbeat daemon
+(orbit heartbeat start), or once with orbit alerts check. Alerts are sent as
+"alert" and "alert_resolved" events, so routes can match them.`,
+}
+
+var alertsListCmd = &cobra.Command{
+	Use:   "list [project]",
+	Short: "List alert rules and whether they are firing",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runAlertsList,
+}
+
+var alertsAddCmd = &cobra.Command{
+	Use:   "add <project>",
+	Short: "Add an alert rule",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAlertsAdd,
+}
+
+var alertsRemoveCmd = &cobra.Command{
+	Use:   "remove <project> <name>",
+	Short: "Remove an alert rule",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runAlertsRemove,
+}
+
+var alertsSilenceCmd = &cobra.Command{
+	Use:   "silence <project> <name>",
+	Short: "Hold back an alert's notifications for a while",
+	Long: `Hold back an alert's notifications for a while. The rule is still
+evaluated and its state shown by orbit alerts list; transitions while it is
+silenced are recorded in orbit events but not sent.
+
+  orbit alerts silence myshop api-status --for 2h
+  orbit alerts silence myshop api-status --clear`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAlertsSilence,
+}
+
+var alertsCheckCmd = &cobra.Command{
+	Use:   "check [project]",
+	Short: "Evaluate alert rules now",
+	Long: `Fetch the status of every service with an alert rule, evaluate the
+rules and send notifications for alerts that start firing or resolve, as the
+heartbeat daemon does every minute.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAlertsCheck,
+}
+
+func init() {
+	alertsAddCmd.Flags().StringVar(&alertsService, "service", "", "Service the rule watches (required)")
+	alertsAddCmd.Flags().StringVar(&alertsWhen, "when", "", `Condition, e.g. "status != healthy" or "response_time > 800" (required)`)
+	alertsAddCmd.Flags().StringVar(&alertsFor, "for", "", "How long the condition must hold before firing (e.g. 5m)")
+	alertsAddCmd.Flags().StringVar(&alertsSeverity, "severity", "", "Severity (info, warning, critical)")
+	alertsAddCmd.Flags().StringVar(&alertsName, "name", "", "Rule name (default <service>-<metric>)")
+	alertsAddCmd.MarkFlagRequired("service")
+	alertsAddCmd.MarkFlagRequired("when")
+	alertsSilenceCmd.Flags().DurationVar(&alertsSilence, "for", time.Hour, "How long to silence the alert")
+	alertsSilenceCmd.Flags().BoolVar(&alertsClear, "clear", false, "End the silence now")
+	alertsListCmd.Flags().StringVar(&alertsFormat, "format", "", "Output format (json)")
+
+	alertsCmd.AddCommand(alertsListCmd, alertsAddCmd, alertsRemoveCmd, alertsSilenceCmd, alertsCheckCmd)
+	rootCmd.AddCommand(alertsCmd)
+}
+
+// parseAlertRules validates a project's alert rules, checking that every
+// rule names one of its services.
+func parseAlertRules(cfg *config.Config, projectName string, proj *config.ProjectConfig) ([]alert.Rule, error) {
+	services := make(map[string]bool, len(proj.Topology))
+	for _, e := range proj.Topology {
+		services[e.Name] = true
+	}
+	rules := make([]alert.Rule, 0, len(proj.Alerts))
+	for _, ar := range proj.Alerts {
+		r, err := alert.Parse(ar, cfg.Thresholds)
+		if err != nil {
+			return nil, fmt.Errorf("project %q: %w", projectName, err)
+		}
+		if !services[r.Service] {
+			return nil, fmt.Errorf("project %q: alert %q watches unknown service %q", projectName, r.Name, r.Service)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// alertResult is one rule after an evaluation.
+type alertResult struct {
+	Rule       alert.Rule
+	State      alert.RuleState
+	Transition alert.Transition
+}
+
+// evaluateAlerts fetches the status of the services rules watch, updates
+// the rules' state and notifies about alerts that fired or resolved.
+// Events are recorded in history even while an alert is silenced.
+func evaluateAlerts(ctx context.Context, cfg *config.Config, key []byte, projectName string, rules []alert.Rule) ([]alertResult, error) {
+	proj := cfg.Projects[projectName]
+	watched := make(map[string]bool)
+	for _, r := range rules {
+		watched[r.Service] = true
+	}
+	var entries []config.ServiceEntry
+	for _, e := range proj.Topology {
+		if watched[e.Name] {
+			entries = append(entries, e)
+		}
+	}
+	byName := make(map[string]ui.ServiceResult)
+	for _, res := range fetchStatuses(ctx, entries, cfg, key) {
+		byName[res.Entry.Name] = res
+	}
+
+	path, err := alert.Path()
+	if err != nil {
+		return nil, err
+	}
+	state, err := alert.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	results := make([]alertResult, 0, len(rules))
+	for _, r := range rules {
+		res := byName[r.Service]
+		st := res.Status
+		if res.Err != nil {
+			st = nil
+		}
+		tr := state.Observe(projectName, r, st, now)
+		rs := state.Get(projectName, r.Name)
+		results = append(results, alertResult{Rule: r, State: *rs, Transition: tr})
+		if tr == alert.Unchanged {
+			continue
+		}
+
+		ev := notify.Event{
+			Type:     "alert",
+			Severity: r.Severity,
+			Project:  projectName,
+			Service:  r.Service,
+			Tags:     res.Entry.Tags,
+			Owner:    res.Entry.Owner,
+			Title:    fmt.Sprintf("alert %s: %s", r.Name, r.Condition()),
+			Message:  rs.Value,
+			Time:     now,
+		}
+		if r.For > 0 {
+			ev.Message += " for " + ui.FormatGap(r.For)
+		}
+		if st != nil && st.LastDeploy != nil {
+			ev.Commit, ev.URL = st.LastDeploy.Commit, st.LastDeploy.URL
+		}
+		if tr == alert.Resolved {
+			ev.Type, ev.Severity, ev.Title, ev.Message = "alert_resolved", notify.SeverityInfo, "alert "+r.Name+" resolved", rs.Value
+		}
+		recordEvents(ev)
+		if len(cfg.Notifications.Channels) > 0 && !rs.Silenced(now) {
+			printNotifyErrors(notify.Notify(cfg.Notifications, ev))
+		}
+	}
+	if err := state.Save(path); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// runAlerts evaluates rules every alertCheckInterval until ctx is done,
+// printing alerts that fire or resolve to the daemon's log.
+func runAlerts(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config, key []byte, projectName string, rules []alert.Rule) {
+	if len(rules) == 0 {
+		return
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			results, err := evaluateAlerts(ctx, cfg, key, projectName, rules)
+			now := time.Now().Format("15:04:05")
+			if err != nil {
+				fmt.Printf("  [%s] %s alerts: %v\n", now, ui.WarningStyle.Render(ui.IconWarning), err)
+			}
+			for _, res := range results {
+				switch res.Transition {
+				case alert.Fired:
+					fmt.Printf("  [%s] %-12s  %s alert %s firing (%s)\n", now, res.Rule.Service,
+						ui.ErrorStyle.Render(ui.IconError), res.Rule.Name, res.State.Value)
+				case alert.Resolved:
+					fmt.Printf("  [%s] %-12s  %s alert %s resolved\n", now, res.Rule.Service,
+						ui.HealthyStyle.Render(ui.IconHealthy), res.Rule.Name)
+				}
+			}
+			select {
+			case <-time.After(alertCheckInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// alertProjects returns the named project, or every project with alert
+// rules when name is empty.
+func alertProjects(cfg *config.Config, name string) ([]string, error) {
+	if name != "" {
+		if _, err := resolveProject(cfg, name); err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	}
+	var names []string
+	for n, p := range cfg.Projects {
+		if len(p.Alerts) > 0 {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// alertStateLabel describes a rule's state, ok, pending or firing, and since
+// when.
+func alertStateLabel(rs *alert.RuleState) (string, *time.Time) {
+	switch {
+	case rs == nil:
+		return "ok", nil
+	case rs.Firing():
+		return "firing", rs.FiringSince
+	case rs.PendingSince != nil:
+		return "pending", rs.PendingSince
+	}
+	return "ok", nil
+}
+
+func runAlertsList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	projects, err := alertProjects(cfg, name)
+	if err != nil {
+		return err
+	}
+	path, err := alert.Path()
+	if err != nil {
+		return err
+	}
+	state, err := alert.Load(path)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if alertsFormat == "json" {
+		type jsonAlert struct {
+			Project       string     `json:"project"`
+			Name          string     `json:"name"`
+			Service       string     `json:"service"`
+			When          string     `json:"when"`
+			For           string     `json:"for,omitempty"`
+			Severity      string     `json:"severity"`
+			State         string     `json:"state"`
+			Since         *time.Time `json:"since,omitempty"`
+			Value         string     `json:"value,omitempty"`
+			SilencedUntil *time.Time `json:"silenced_until,omitempty"`
+		}
+		out := []jsonAlert{}
+		for _, pn := range projects {
+			proj := cfg.Projects[pn]
+			rules, err := parseAlertRules(cfg, pn, &proj)
+			if err != nil {
+				return err
+			}
+			for _, r := range rules {
+				rs := state.Lookup(pn, r.Name)
+				label, since := alertStateLabel(rs)
+				a := jsonAlert{Project: pn, Name: r.Name, Service: r.Service, When: r.Condition(),
+					Severity: r.Severity, State: label, Since: since}
+				if r.For > 0 {
+					a.For = r.For.String()
+				}
+				if rs != nil {
+					a.Value = rs.Value
+					if rs.Silenced(now) {
+						a.SilencedUntil = rs.SilencedUntil
+					}
+				}
+				out = append(out, a)
+			}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(projects) == 0 {
+		fmt.Println(ui.MutedStyle.Render("No alert rules configured."))
+		fmt.Println(ui.MutedStyle.Render(`Add one: orbit alerts add <project> --service <name> --when "status != healthy" --for 5m`))
+		return nil
+	}
+	for _, pn := range projects {
+		proj := cfg.Projects[pn]
+		rules, err := parseAlertRules(cfg, pn, &proj)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(pn), ui.MutedStyle.Render("alerts"))
+		if len(rules) == 0 {
+			fmt.Println(ui.MutedStyle.Render("  No alert rules."))
+			continue
+		}
+		for _, r := range rules {
+			printAlertRule(r, state.Lookup(pn, r.Name), now)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func printAlertRule(r alert.Rule, rs *alert.RuleState, now time.Time) {
+	cond := r.Condition()
+	if r.For > 0 {
+		cond += " for " + ui.FormatGap(r.For)
+	}
+	label, since := alertStateLabel(rs)
+	status := ui.HealthyStyle.Render(ui.IconHealthy + " ok")
+	switch label {
+	case "firing":
+		status = ui.ErrorStyle.Render(fmt.Sprintf("%s firing %s", ui.IconError, ui.FormatGap(now.Sub(*since))))
+	case "pending":
+		status = ui.WarningStyle.Render(fmt.Sprintf("%s pending %s", ui.IconWarning, ui.FormatGap(now.Sub(*since))))
+	}
+	if rs != nil && rs.Value != "" && label != "ok" {
+		status += ui.MutedStyle.Render(" (" + rs.Value + ")")
+	}
+	if rs != nil && rs.Silenced(now) {
+		status += ui.MutedStyle.Render(" silenced until " + rs.SilencedUntil.Local().Format("Jan 2 15:04"))
+	}
+	fmt.Printf("  %s %s %s %s %s\n", ui.Pad(r.Name, 18), ui.Pad(r.Service, 12),
+		ui.Pad(cond, 32), ui.Pad(ui.MutedStyle.Render(r.Severity), 9), status)
+}
+
+func runAlertsAdd(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+
+	ar := config.AlertRule{Name: alertsName, Service: alertsService, When: alertsWhen, For: alertsFor, Severity: alertsSeverity}
+	if fields := strings.Fields(alertsWhen); ar.Name == "" && len(fields) > 0 {
+		ar.Name = alertsService + "-" + fields[0]
+	}
+	if _, err := alert.Parse(ar, cfg.Thresholds); err != nil {
+		return err
+	}
+	var svcNames []string
+	found := false
+	for _, e := range proj.Topology {
+		svcNames = append(svcNames, e.Name)
+		found = found || e.Name == ar.Service
+	}
+	if !found {
+		return fmt.Errorf("service %q not found in project %q\nAvailable services: %s", ar.Service, projectName, joinNames(svcNames))
+	}
+	for _, existing := range proj.Alerts {
+		if existing.Name == ar.Name {
+			return fmt.Errorf("alert %q already exists in project %q\nPick another with --name, or remove it first: orbit alerts remove %s %s",
+				ar.Name, projectName, projectName, ar.Name)
+		}
+	}
+
+	proj.Alerts = append(proj.Alerts, ar)
+	cfg.Projects[projectName] = *proj
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	fmt.Printf("  %s Alert %s added to %s\n", ui.IconSuccess, ar.Name, ui.ProjectTitleStyle.Render(projectName))
+	fmt.Println(ui.MutedStyle.Render("  Evaluated by the heartbeat daemon (orbit heartbeat start " + projectName + ") or orbit alerts check " + projectName))
+	return nil
+}
+
+func runAlertsRemove(cmd *cobra.Command, args []string) error {
+	projectName, name := args[0], args[1]
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	if !removeAlertRule(proj, name) {
+		return fmt.Errorf("alert %q not found in project %q", name, projectName)
+	}
+	cfg.Projects[projectName] = *proj
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	if path, err := alert.Path(); err == nil {
+		if state, err := alert.Load(path); err == nil {
+			state.Forget(projectName, name)
+			if err := state.Save(path); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+			}
+		}
+	}
+	fmt.Printf("  %s Alert %s removed from %s\n", ui.IconSuccess, name, ui.ProjectTitleStyle.Render(projectName))
+	return nil
+}
+
+// removeAlertRule deletes the named rule and reports whether it existed.
+func removeAlertRule(proj *config.ProjectConfig, name string) bool {
+	for i, r := range proj.Alerts {
+		if r.Name == name {
+			proj.Alerts = append(proj.Alerts[:i], proj.Alerts[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func runAlertsSilence(cmd *cobra.Command, args []string) error {
+	projectName, name := args[0], args[1]
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, r := range proj.Alerts {
+		found = found || r.Name == name
+	}
+	if !found {
+		return fmt.Errorf("alert %q not found in project %q", name, projectName)
+	}
+	if !alertsClear && alertsSilence <= 0 {
+		return fmt.Errorf("--for must be positive")
+	}
+
+	path, err := alert.Path()
+	if err != nil {
+		return err
+	}
+	state, err := alert.Load(path)
+	if err != nil {
+		return err
+	}
+	rs := state.Get(projectName, name)
+	if alertsClear {
+		rs.SilencedUntil = nil
+	} else {
+		until := time.Now().Add(alertsSilence)
+		rs.SilencedUntil = &until
+	}
+	if err := state.Save(path); err != nil {
+		return err
+	}
+
+	if alertsClear {
+		fmt.Printf("  %s Alert %s is no longer silenced\n", ui.IconSuccess, name)
+		return nil
+	}
+	fmt.Printf("  %s Alert %s silenced until %s\n", ui.IconSuccess, name, rs.SilencedUntil.Local().Format("Jan 2 15:04"))
+	return nil
+}
+
+func runAlertsCheck(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	projects, err := alertProjects(cfg, name)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		fmt.Println(ui.MutedStyle.Render("No alert rules configured."))
+		return nil
+	}
+
+	now := time.Now()
+	for _, pn := range projects {
+		proj := cfg.Projects[pn]
+		rules, err := parseAlertRules(cfg, pn, &proj)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(pn), ui.MutedStyle.Render("alerts"))
+		if len(rules) == 0 {
+			fmt.Println(ui.MutedStyle.Render("  No alert rules."))
+			continue
+		}
+		results, err := evaluateAlerts(cmd.Context(), cfg, key, pn, rules)
+		if err != nil {
+			return err
+		}
+		for _, res := range results {
+			printAlertRule(res.Rule, &res.State, now)
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/humanetools/orbit/internal/history"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// sizeBaselineDeploys is how many recorded deploys the size of a new build is
+// compared against.
+const sizeBaselineDeploys = 5
+
+// checkArtifactSize looks up the build size of a successful deploy and warns
+// when it grew by at least growthPct percent over the median of the service's
+// last recorded deploy sizes, or, before any are recorded, over the deploy it
+// replaced. Platforms without size information are skipped silently.
+func checkArtifactSize(ctx context.Context, projectName string, resolved *resolvedService, r *watchResult, growthPct int) {
+	if r.ExitCode != exitSuccess || r.DeployID == "" {
+		return
+	}
+	sizer, ok := resolved.Platform.(platform.ArtifactSizer)
+	if !ok {
+		return
+	}
+
+	size, err := sizer.ArtifactSize(ctx, r.DeployID)
+	if err != nil || size == 0 {
+		return
+	}
+	r.Size = size
+
+	basis := "previous deploy"
+	prev, n := recordedSizeBaseline(projectName, r.ServiceName, r.DeployID)
+	if n > 1 {
+		basis = fmt.Sprintf("median of last %d deploys", n)
+	}
+	if n == 0 {
+		if r.PrevDeployID == "" || r.PrevDeployID == r.DeployID {
+			return
+		}
+		if prev, err = sizer.ArtifactSize(ctx, r.PrevDeployID); err != nil || prev == 0 {
+			return
+		}
+	}
+	r.PrevSize = prev
+
+	change := sizeChangePct(prev, size)
+	if growthPct > 0 && change >= float64(growthPct) {
+		fmt.Fprintf(os.Stderr, "%s %s: build size grew %.0f%% over the %s (%s → %s)\n",
+			ui.IconWarning, r.ServiceName, change, basis, ui.FormatBytes(prev), ui.FormatBytes(size))
+	}
+}
+
+// recordedSizeBaseline returns the median build size of the service's last
+// sizeBaselineDeploys deploys in history other than deployID, and how many
+// there were.
+func recordedSizeBaseline(projectName, service, deployID string) (int64, int) {
+	path, err := history.Path()
+	if err != nil {
+		return 0, 0
+	}
+	store, err := history.Open(path)
+	if err != nil {
+		return 0, 0
+	}
+	defer store.Close()
+	entries, err := store.Query(history.Query{Project: projectName, Service: service, Kind: history.KindDeploy})
+	if err != nil {
+		return 0, 0
+	}
+
+	var sizes []int64
+	seen := map[string]bool{deployID: true}
+	for i := len(entries) - 1; i >= 0 && len(sizes) < sizeBaselineDeploys; i-- {
+		e := entries[i]
+		if e.Size > 0 && !seen[e.DeployID] {
+			seen[e.DeployID] = true
+			sizes = append(sizes, e.Size)
+		}
+	}
+	if len(sizes) == 0 {
+		return 0, 0
+	}
+	slices.Sort(sizes)
+	return sizes[len(sizes)/2], len(sizes)
+}
+
+// sizeChangePct returns the relative change from prev to cur in percent.
+func sizeChangePct(prev, cur int64) float64 {
+	return float64(cur-prev) / float64(prev) * 100
+}
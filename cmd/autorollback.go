@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// defaultRollbackGrace is how long a deploy may take to become healthy once
+// it has built before --auto-rollback gives up on it.
+const defaultRollbackGrace = 3 * time.Minute
+
+// watchRollback is the outcome of rolling back a service with --auto-rollback.
+type watchRollback struct {
+	ToDeployID string // the healthy deployment rolled back to
+	DeployID   string // the deployment serving after the rollback
+	ExitCode   int    // exitSuccess once it is healthy again
+	// Skipped is set when the deploy was still in progress and the
+	// platform can't cancel it, so rolling back wouldn't stick.
+	Skipped  bool
+	Error    string
+	Duration time.Duration
+}
+
+// watchRollbackJSON is watchRollback in the JSON result.
+type watchRollbackJSON struct {
+	Result      string `json:"result"`
+	ToDeployID  string `json:"to_deploy_id,omitempty"`
+	DeployID    string `json:"deploy_id,omitempty"`
+	DurationSec int    `json:"duration_sec"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+func (rb *watchRollback) toJSON() *watchRollbackJSON {
+	if rb == nil {
+		return nil
+	}
+	result := exitCodeName(rb.ExitCode)
+	if rb.Skipped {
+		result = "skipped"
+	}
+	return &watchRollbackJSON{
+		Result:      result,
+		ToDeployID:  rb.ToDeployID,
+		DeployID:    rb.DeployID,
+		DurationSec: int(rb.Duration.Seconds()),
+		Reason:      rb.Error,
+	}
+}
+
+// healthGrace starts the --rollback-grace period of a deploy that has
+// built. It returns nil, which never fires, without --auto-rollback.
+func healthGrace() <-chan time.Time {
+	if !watchAutoRollback {
+		return nil
+	}
+	return time.After(watchRollbackGrace)
+}
+
+// healthGraceError is the error of a deploy that outlived --rollback-grace.
+func healthGraceError() string {
+	return fmt.Sprintf("Not healthy %s after the build finished", watchRollbackGrace)
+}
+
+// autoRollback rolls the service back to its last healthy deployment when
+// its watched deploy failed, didn't become healthy in time or failed its
+// smoke tests, and waits for that deployment to serve again. A deploy still
+// in progress is cancelled first, so it can't take over after the rollback;
+// where the platform can't cancel it, the rollback is skipped. The watch
+// result keeps its exit code; the rollback's outcome goes in r.Rollback.
+func autoRollback(ctx context.Context, projectName string, resolved *resolvedService, r *watchResult, timeout time.Duration) {
+	if r.DeployID == "" || r.Cancelled || (r.ExitCode != exitFailed && r.ExitCode != exitTimeout) {
+		return
+	}
+	if ctx.Err() != nil {
+		// Interrupted: the deploy may be fine, so leave it alone.
+		return
+	}
+
+	w := io.Writer(os.Stdout)
+	if machineFormat() {
+		w = os.Stderr
+	}
+	start := time.Now()
+	rb := &watchRollback{ExitCode: exitFailed}
+	r.Rollback = rb
+	inProgress := r.ExitCode == exitTimeout
+	if inProgress && !resolved.Platform.Capabilities().Has(platform.CapCancel) {
+		rb.Skipped = true
+		rb.Error = fmt.Sprintf("%s can't cancel deployment %s, which is still in progress; not rolled back", resolved.Entry.Platform, shortID(r.DeployID))
+		fmt.Fprintf(w, "  %s %s\n", ui.IconWarning, ui.WarningStyle.Render(fmt.Sprintf("%s: %s", r.ServiceName, rb.Error)))
+		return
+	}
+	fail := func(msg string) {
+		rb.Error = msg
+		rb.Duration = time.Since(start)
+		fmt.Fprintf(w, "  %s %s\n", ui.IconFailed, ui.ErrorStyle.Render(fmt.Sprintf("Rollback of %s failed: %s", r.ServiceName, msg)))
+		recordRollback(projectName, r, notify.SeverityCritical, "rollback failed", msg)
+	}
+
+	deploys, err := resolved.Platform.ListDeployments(ctx, resolved.Entry.ID, 10)
+	if err != nil {
+		fail(fmt.Sprintf("list deployments: %s", err))
+		return
+	}
+	for _, d := range deploys {
+		if d.ID != r.DeployID && d.Status == "healthy" {
+			rb.ToDeployID = d.ID
+			break
+		}
+	}
+	if rb.ToDeployID == "" {
+		fail("no earlier healthy deployment")
+		return
+	}
+
+	if inProgress {
+		if err := resolved.Platform.CancelDeployment(ctx, r.DeployID); err != nil {
+			fail(fmt.Sprintf("cancel deployment %s: %s", shortID(r.DeployID), err))
+			return
+		}
+		fmt.Fprintf(w, "  %s Cancelled deployment %s of %s\n", ui.IconWarning, shortID(r.DeployID), r.ServiceName)
+	}
+	fmt.Fprintf(w, "  %s Rolling back %s to %s...\n", ui.IconDeploy, r.ServiceName, shortID(rb.ToDeployID))
+	deploy, err := resolved.Platform.RollbackTo(ctx, resolved.Entry.ID, rb.ToDeployID)
+	if err != nil {
+		fail(err.Error())
+		return
+	}
+	rb.DeployID = deploy.ID
+
+	// Platforms that promote the target serve it right away; the others
+	// start a new deployment of it, which has to become healthy first.
+	if deploy.ID != rb.ToDeployID {
+		code, msg := waitForRollback(ctx, resolved, deploy.ID, timeout)
+		if code != exitSuccess {
+			rb.ExitCode = code
+			fail(msg)
+			return
+		}
+	}
+	rb.ExitCode = exitSuccess
+	rb.Duration = time.Since(start)
+	detail := fmt.Sprintf("rolled back to %s", shortID(rb.ToDeployID))
+	fmt.Fprintf(w, "  %s %s %s\n", ui.IconSuccess, ui.HealthyStyle.Render(fmt.Sprintf("%s %s", r.ServiceName, detail)),
+		ui.MutedStyle.Render(fmt.Sprintf("(%s healthy in %ds)", shortID(rb.DeployID), int(rb.Duration.Seconds()))))
+	recordRollback(projectName, r, notify.SeverityWarning, "deploy rolled back", detail)
+}
+
+// waitForRollback follows the deployment a rollback started until it is
+// healthy, returning the exit code and error of the watch it amounts to.
+func waitForRollback(ctx context.Context, resolved *resolvedService, deployID string, timeout time.Duration) (int, string) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for ev := range platform.TrackDeployment(ctx, resolved.Platform, deployID, watchPollInterval) {
+		switch ev.Phase {
+		case "done":
+			return exitSuccess, ""
+		case "failed":
+			if ev.Error != nil {
+				return exitFailed, ev.Error.Error()
+			}
+			return exitFailed, fmt.Sprintf("deployment %s failed", shortID(deployID))
+		}
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return exitTimeout, fmt.Sprintf("deployment %s still in progress after %ds", shortID(deployID), int(timeout.Seconds()))
+	}
+	return exitFailed, "watch interrupted"
+}
+
+func recordRollback(projectName string, r *watchResult, severity, title, detail string) {
+	recordEvents(notify.Event{
+		Type: "deploy_rolled_back", Severity: severity, Project: projectName, Service: r.ServiceName,
+		Title: title, Message: detail, DeployID: r.DeployID, Commit: r.Commit, Time: time.Now(),
+	})
+}
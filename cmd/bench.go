@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/bench"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchPlatform   string
+	benchIterations int
+	benchFormat     string
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench [project]",
+	Short: "Measure the latency of platform API calls",
+	Long: `Time the API calls Orbit makes — service status, deployment list and logs —
+against each connected platform and report latency percentiles. Use it to
+tell a slow platform API from a slow Orbit.
+
+  orbit bench
+  orbit bench --platform koyeb --iterations 30
+  orbit bench myshop --format json
+
+Each platform is measured against the first of your services that runs on
+it (from the given project, or from any project). Calls bypass the response
+cache; calls a platform doesn't support are skipped.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBench,
+}
+
+func init() {
+	benchCmd.Flags().StringVar(&benchPlatform, "platform", "", "Only measure this platform")
+	benchCmd.Flags().IntVarP(&benchIterations, "iterations", "n", 10, "Calls per API endpoint")
+	benchCmd.Flags().StringVar(&benchFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(benchCmd)
+}
+
+type benchTarget struct {
+	Project string
+	Entry   config.ServiceEntry
+	Results []bench.Result
+	Err     error
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	if benchIterations < 1 {
+		return fmt.Errorf("--iterations must be at least 1")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projects := make([]string, 0, len(cfg.Projects))
+	if len(args) > 0 {
+		if _, err := resolveProject(cfg, args[0]); err != nil {
+			return err
+		}
+		projects = append(projects, args[0])
+	} else {
+		for name := range cfg.Projects {
+			projects = append(projects, name)
+		}
+		sort.Strings(projects)
+	}
+
+	targets := benchTargets(cfg, projects, strings.ToLower(benchPlatform))
+	if len(targets) == 0 {
+		if benchPlatform != "" {
+			return fmt.Errorf("no service on %s to measure\nRun: orbit service add <project> --platform %s ...", benchPlatform, benchPlatform)
+		}
+		return fmt.Errorf("no services to measure\nRun: orbit service add <project> ...")
+	}
+
+	prog := newProgress(benchFormat == "json")
+	prog.printf("  Measuring %d platform(s), %d calls per endpoint...\n", len(targets), benchIterations)
+
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		wg.Add(1)
+		go func(t *benchTarget) {
+			defer wg.Done()
+			p, err := entryPlatform(cfg, key, t.Entry)
+			if err != nil {
+				t.Err = err
+				return
+			}
+			t.Results = benchPlatformCalls(cmd.Context(), p, t.Entry.ID, benchIterations)
+		}(t)
+	}
+	wg.Wait()
+
+	if benchFormat == "json" {
+		return renderBenchJSON(targets)
+	}
+	renderBenchTable(targets)
+	return nil
+}
+
+// benchTargets picks one service per platform, in project order.
+func benchTargets(cfg *config.Config, projects []string, only string) []*benchTarget {
+	seen := make(map[string]bool)
+	var targets []*benchTarget
+	for _, name := range projects {
+		for _, e := range cfg.Projects[name].Topology {
+			if seen[e.Platform] || (only != "" && e.Platform != only) {
+				continue
+			}
+			seen[e.Platform] = true
+			targets = append(targets, &benchTarget{Project: name, Entry: e})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Entry.Platform < targets[j].Entry.Platform })
+	return targets
+}
+
+// benchPlatformCalls measures the calls p supports, one endpoint at a time.
+func benchPlatformCalls(ctx context.Context, p platform.Platform, serviceID string, n int) []bench.Result {
+	caps := p.Capabilities()
+	results := []bench.Result{
+		bench.Measure(ctx, "status", n, func(ctx context.Context) error {
+			_, err := p.GetServiceStatus(ctx, serviceID)
+			return err
+		}),
+	}
+	if caps.Has(platform.CapDeployments) {
+		results = append(results, bench.Measure(ctx, "deployments", n, func(ctx context.Context) error {
+			_, err := p.ListDeployments(ctx, serviceID, 10)
+			return err
+		}))
+	}
+	if caps.Has(platform.CapLogs) {
+		results = append(results, bench.Measure(ctx, "logs", n, func(ctx context.Context) error {
+			_, err := p.GetLogs(ctx, serviceID, platform.LogOptions{Tail: 50})
+			return err
+		}))
+	}
+	return results
+}
+
+func renderBenchTable(targets []*benchTarget) {
+	fmt.Printf("\n  %s %s %s %s %s %s %s\n",
+		ui.Pad(ui.HeaderStyle.Render("Platform"), 22),
+		ui.Pad(ui.HeaderStyle.Render("Call"), 12),
+		ui.Pad(ui.HeaderStyle.Render("p50"), 8),
+		ui.Pad(ui.HeaderStyle.Render("p90"), 8),
+		ui.Pad(ui.HeaderStyle.Render("p99"), 8),
+		ui.Pad(ui.HeaderStyle.Render("max"), 8),
+		ui.HeaderStyle.Render("Errors"))
+
+	for _, t := range targets {
+		label := t.Entry.Platform + " (" + t.Entry.Name + ")"
+		if t.Err != nil {
+			fmt.Printf("  %s %s\n", ui.Pad(label, 22), ui.ErrorStyle.Render(t.Err.Error()))
+			continue
+		}
+		for i, r := range t.Results {
+			if i > 0 {
+				label = ""
+			}
+			errs := ui.MutedStyle.Render("0")
+			if r.Errors > 0 {
+				errs = ui.ErrorStyle.Render(fmt.Sprintf("%d/%d", r.Errors, r.N))
+			}
+			if r.Errors == r.N {
+				fmt.Printf("  %s %s %s %s\n", ui.Pad(label, 22), ui.Pad(r.Call, 12),
+					ui.Pad(ui.MutedStyle.Render("—"), 35), errs)
+				continue
+			}
+			fmt.Printf("  %s %s %s %s %s %s %s\n",
+				ui.Pad(label, 22), ui.Pad(r.Call, 12),
+				ui.Pad(formatLatency(r.P50), 8), ui.Pad(formatLatency(r.P90), 8),
+				ui.Pad(formatLatency(r.P99), 8), ui.Pad(formatLatency(r.Max), 8), errs)
+		}
+	}
+
+	for _, t := range targets {
+		for _, r := range t.Results {
+			if r.LastErr != "" {
+				fmt.Printf("\n  %s %s %s: %s", ui.IconWarning, t.Entry.Platform, r.Call, r.LastErr)
+			}
+		}
+	}
+	fmt.Println()
+	fmt.Println()
+}
+
+func formatLatency(d time.Duration) string {
+	if d >= 10*time.Second {
+		return fmt.Sprintf("%.0fs", d.Seconds())
+	}
+	if d >= time.Second {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
+type benchCallJSON struct {
+	Call   string `json:"call"`
+	N      int    `json:"iterations"`
+	Errors int    `json:"errors"`
+	MinMs  int64  `json:"min_ms"`
+	P50Ms  int64  `json:"p50_ms"`
+	P90Ms  int64  `json:"p90_ms"`
+	P99Ms  int64  `json:"p99_ms"`
+	MaxMs  int64  `json:"max_ms"`
+	Error  string `json:"last_error,omitempty"`
+}
+
+type benchPlatformJSON struct {
+	Platform string          `json:"platform"`
+	Project  string          `json:"project"`
+	Service  string          `json:"service"`
+	Calls    []benchCallJSON `json:"calls,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+func renderBenchJSON(targets []*benchTarget) error {
+	out := make([]benchPlatformJSON, 0, len(targets))
+	for _, t := range targets {
+		pj := benchPlatformJSON{Platform: t.Entry.Platform, Project: t.Project, Service: t.Entry.Name}
+		if t.Err != nil {
+			pj.Error = t.Err.Error()
+		}
+		for _, r := range t.Results {
+			pj.Calls = append(pj.Calls, benchCallJSON{
+				Call:   r.Call,
+				N:      r.N,
+				Errors: r.Errors,
+				MinMs:  r.Min.Milliseconds(),
+				P50Ms:  r.P50.Milliseconds(),
+				P90Ms:  r.P90.Milliseconds(),
+				P99Ms:  r.P99.Milliseconds(),
+				MaxMs:  r.Max.Milliseconds(),
+				Error:  r.LastErr,
+			})
+		}
+		out = append(out, pj)
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
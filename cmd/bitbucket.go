@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/integration"
+)
+
+// bitbucketToken returns the decrypted Bitbucket token from config,
+// falling back to $BITBUCKET_TOKEN.
+func bitbucketToken(cfg *config.Config, key []byte) (string, error) {
+	if enc := cfg.Integrations.Bitbucket.Token; enc != "" {
+		token, err := config.Decrypt(key, enc)
+		if err != nil {
+			return "", fmt.Errorf("decrypt bitbucket token: %w", err)
+		}
+		return token, nil
+	}
+	if token := os.Getenv("BITBUCKET_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("no Bitbucket token configured\nRun: orbit config set bitbucket.token <token> (or set $BITBUCKET_TOKEN)")
+}
+
+// bitbucketRepo returns the workspace/slug repository,
+// falling back to $BITBUCKET_REPO_FULL_NAME (set automatically in Bitbucket Pipelines).
+func bitbucketRepo(cfg *config.Config) (string, error) {
+	if cfg.Integrations.Bitbucket.Repo != "" {
+		return cfg.Integrations.Bitbucket.Repo, nil
+	}
+	if repo := os.Getenv("BITBUCKET_REPO_FULL_NAME"); repo != "" {
+		return repo, nil
+	}
+	return "", fmt.Errorf("no Bitbucket repository configured\nRun: orbit config set bitbucket.repo <workspace/slug> (or set $BITBUCKET_REPO_FULL_NAME)")
+}
+
+// setWatchBitbucketStatus sets a build status per watched service.
+// The deployed commit is used when known, otherwise $BITBUCKET_COMMIT.
+func setWatchBitbucketStatus(cfg *config.Config, key []byte, projectName string, results []watchResult) error {
+	token, err := bitbucketToken(cfg, key)
+	if err != nil {
+		return err
+	}
+	repo, err := bitbucketRepo(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Bitbucket requires a link on every status; prefer the deploy URL,
+	// then the running pipeline, then the repository itself.
+	fallbackURL := "https://bitbucket.org/" + repo
+	if build := os.Getenv("BITBUCKET_BUILD_NUMBER"); build != "" {
+		fallbackURL = fmt.Sprintf("https://bitbucket.org/%s/pipelines/results/%s", repo, build)
+	}
+
+	bb := integration.NewBitbucket(cfg.Integrations.Bitbucket.Username, token)
+	for _, r := range results {
+		sha := r.Commit
+		if sha == "" {
+			sha = os.Getenv("BITBUCKET_COMMIT")
+		}
+		if sha == "" {
+			return fmt.Errorf("%s: no commit SHA to report status for", r.ServiceName)
+		}
+
+		st := integration.BuildStatus{
+			Key:         bitbucketStatusKey(projectName, r.ServiceName),
+			Name:        fmt.Sprintf("orbit/%s/%s", projectName, r.ServiceName),
+			Description: watchStatusDescription(r),
+			URL:         r.URL,
+			State:       "SUCCESSFUL",
+		}
+		if st.URL == "" {
+			st.URL = fallbackURL
+		}
+		switch r.ExitCode {
+		case exitFailed:
+			st.State = "FAILED"
+		case exitNoDeployment, exitTimeout:
+			st.State = "STOPPED"
+		}
+
+		if err := bb.SetBuildStatus(repo, sha, st); err != nil {
+			return fmt.Errorf("%s: %w", r.ServiceName, err)
+		}
+	}
+	return nil
+}
+
+// bitbucketKeyMax is the longest build status key Bitbucket accepts.
+const bitbucketKeyMax = 40
+
+// bitbucketStatusKey returns the build status key of a service,
+// orbit-<project>-<service>. Keys over Bitbucket's limit are cut short and
+// end in a hash of the full key, so they stay unique and stable across runs.
+func bitbucketStatusKey(projectName, serviceName string) string {
+	key := fmt.Sprintf("orbit-%s-%s", projectName, serviceName)
+	if len(key) <= bitbucketKeyMax {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	hash := hex.EncodeToString(sum[:4])
+	return key[:bitbucketKeyMax-len(hash)-1] + "-" + hash
+}
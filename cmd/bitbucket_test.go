@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBitbucketStatusKey(t *testing.T) {
+	if got := bitbucketStatusKey("shop", "api"); got != "orbit-shop-api" {
+		t.Errorf("short key = %q, want orbit-shop-api", got)
+	}
+
+	long := bitbucketStatusKey("customer-onboarding-platform", "notification-dispatcher")
+	if len(long) > bitbucketKeyMax {
+		t.Errorf("key %q is %d characters, over Bitbucket's %d", long, len(long), bitbucketKeyMax)
+	}
+	if !strings.HasPrefix(long, "orbit-customer-onboarding") {
+		t.Errorf("key %q lost its readable prefix", long)
+	}
+	if again := bitbucketStatusKey("customer-onboarding-platform", "notification-dispatcher"); again != long {
+		t.Errorf("key changed between runs: %q, then %q", long, again)
+	}
+	if other := bitbucketStatusKey("customer-onboarding-platform", "notification-scheduler"); other == long {
+		t.Errorf("services sharing a long prefix got the same key %q", long)
+	}
+}
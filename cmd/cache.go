@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/humanetools/orbit/internal/cache"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var noCache bool
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local response cache",
+	Long: `Status, deployment lists and service discovery are cached in ~/.orbit/cache
+for a few seconds to minutes, so repeated commands are fast and spare API
+quotas. Pass --no-cache to any command to fetch fresh data.`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all cached responses",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := cache.Dir()
+		if err != nil {
+			return err
+		}
+		if err := cache.New(dir).Clear(); err != nil {
+			return fmt.Errorf("clear cache: %w", err)
+		}
+		fmt.Printf("%s Cache cleared\n", ui.IconSuccess)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Fetch fresh data instead of using cached responses")
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// responseCache returns the on-disk response cache, or nil (which never
+// hits) if there is no home directory. With --no-cache it only stores.
+func responseCache() *cache.Cache {
+	dir, err := cache.Dir()
+	if err != nil {
+		return nil
+	}
+	c := cache.New(dir)
+	c.Refresh = noCache
+	return c
+}
+
+// serviceCacheKey identifies a cached response for one service. Everything
+// that changes what the platform returns is part of the key.
+func serviceCacheKey(kind string, e config.ServiceEntry, pc config.PlatformConfig, token string, limit int) string {
+	return cache.Key(kind, e.Platform, token, pc.TeamID, pc.Endpoint, e.Target, e.ID, strconv.Itoa(limit))
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cancelService string
+	cancelDeploy  string
+)
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel <project>",
+	Short: "Cancel a deployment that is still in progress",
+	Long: `Cancel a deployment that is still building or deploying. The previous
+deployment keeps serving.
+
+  orbit cancel myshop --service api
+  orbit cancel myshop --service api --deploy 3f2a    # any unique prefix of a recent deploy
+
+Without --deploy, cancels the most recent deployment in progress.
+
+Supported on Koyeb, Vercel, Render and GitHub Actions.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCancel,
+}
+
+func init() {
+	cancelCmd.Flags().StringVar(&cancelService, "service", "", "Service name (required)")
+	cancelCmd.Flags().StringVar(&cancelDeploy, "deploy", "", "Deployment ID (or a unique prefix) to cancel")
+	cancelCmd.MarkFlagRequired("service")
+	rootCmd.AddCommand(cancelCmd)
+}
+
+func runCancel(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+
+	resolved, err := resolveService(cfg, key, projectName, cancelService)
+	if err != nil {
+		return err
+	}
+	if err := requireCapability(resolved, platform.CapDeployments|platform.CapCancel); err != nil {
+		return err
+	}
+
+	var target *platform.Deployment
+	if cancelDeploy != "" {
+		id, err := resolveDeployID(cmd.Context(), resolved, cancelDeploy)
+		if err != nil {
+			return err
+		}
+		target, err = resolved.Platform.GetDeployment(cmd.Context(), id)
+		if err != nil {
+			return fmt.Errorf("get deployment: %w", err)
+		}
+		if !platform.IsInProgress(target.Status) {
+			return fmt.Errorf("deployment %s has already finished (%s)", shortID(target.ID), target.Status)
+		}
+	} else {
+		deploys, err := resolved.Platform.ListDeployments(cmd.Context(), resolved.Entry.ID, 5)
+		if err != nil {
+			return fmt.Errorf("list deployments: %w", err)
+		}
+		for i := range deploys {
+			if platform.IsInProgress(deploys[i].Status) {
+				target = &deploys[i]
+				break
+			}
+		}
+		if target == nil {
+			if len(deploys) == 0 {
+				return fmt.Errorf("no deployments found for %s", resolved.Entry.Name)
+			}
+			return fmt.Errorf("no deployment in progress for %s (latest %s is %s)",
+				resolved.Entry.Name, shortID(deploys[0].ID), deploys[0].Status)
+		}
+	}
+
+	fmt.Printf("\n  %s Cancelling %s/%s\n", ui.IconDeploy, projectName, resolved.Entry.Name)
+	fmt.Printf("  Deploy:  %s", target.ID)
+	if target.Commit != "" {
+		fmt.Printf(" (%s)", ui.FormatCommit(target.Commit))
+	}
+	fmt.Println()
+	fmt.Printf("  Status:  %s, started %s\n\n", target.Status, ui.TimeAgo(target.CreatedAt))
+
+	fmt.Printf("  Cancelling... ")
+	if err := resolved.Platform.CancelDeployment(cmd.Context(), target.ID); err != nil {
+		fmt.Println(ui.ErrorStyle.Render("failed"))
+		return fmt.Errorf("cancel failed: %w", err)
+	}
+	fmt.Println(ui.HealthyStyle.Render("done"))
+	fmt.Printf("  The previous deployment keeps serving.\n")
+	return nil
+}
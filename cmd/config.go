@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 
@@ -16,10 +23,26 @@ var configCmd = &cobra.Command{
 	Long: `View or modify Orbit configuration.
 
   orbit config                                    Show current config
+  orbit config edit                                Edit config.yaml in $EDITOR, checked before saving
   orbit config set default-project myshop          Set default project
   orbit config set threshold.response-time 500     Set response time threshold (ms)
   orbit config set threshold.cpu 80                Set CPU threshold (%)
-  orbit config set threshold.memory 85             Set memory threshold (%)`,
+  orbit config set threshold.memory 85             Set memory threshold (%)
+  orbit config set threshold.size-growth 40        Warn when a build grows by this much (%)
+  orbit config set github.token <token>            Set GitHub token (stored encrypted)
+  orbit config set github.repo owner/name          Set GitHub repository for PR comments
+  orbit config set gitlab.token <token>            Set GitLab token (stored encrypted)
+  orbit config set gitlab.project <id|group/name>  Set GitLab project for commit statuses
+  orbit config set gitlab.url <api-url>            Set GitLab API URL (self-managed instances)
+  orbit config set bitbucket.token <token>         Set Bitbucket access token or app password (stored encrypted)
+  orbit config set bitbucket.username <user>       Set Bitbucket username (app passwords only)
+  orbit config set bitbucket.repo workspace/slug   Set Bitbucket repository for build statuses
+  orbit config set theme light                     Use a color theme (dark, light, monochrome, solarized)
+  orbit config set theme.primary "#0369a1"         Override one theme color (healthy, warning, error, sleeping, primary, muted)
+  orbit config set icons ascii                     Use a status icon set (auto, emoji, ascii)
+  orbit config set icons.healthy "OK"              Override one icon (healthy, warning, error, sleeping, building, ...)
+  orbit config set hints false                     Stop printing next-step hints after commands
+  orbit config set trace-header true               Send each invocation's trace ID to platform APIs in User-Agent`,
 	RunE: runConfigShow,
 }
 
@@ -30,8 +53,22 @@ var configSetCmd = &cobra.Command{
 	RunE:  runConfigSet,
 }
 
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the config file in $EDITOR",
+	Long: `Open ~/.orbit/config.yaml in $VISUAL or $EDITOR (vi by default) and check
+the result before saving it: the YAML must parse, keys must be ones Orbit
+knows, values must have the right type, and services, views, alert rules and
+notification channels must refer to things that exist.
+
+If the edit doesn't pass, the problems are listed and you can edit again or
+discard it; config.yaml is only replaced by a valid file.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEdit,
+}
+
 func init() {
-	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configSetCmd, configEditCmd)
 	rootCmd.AddCommand(configCmd)
 }
 
@@ -63,6 +100,57 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Response time:   %dms\n", cfg.Thresholds.ResponseTimeMs)
 	fmt.Printf("  CPU:             %d%%\n", cfg.Thresholds.CPUPercent)
 	fmt.Printf("  Memory:          %d%%\n", cfg.Thresholds.MemoryPercent)
+	fmt.Printf("  Build growth:    %d%%\n", cfg.Thresholds.SizeGrowthPercent)
+
+	theme := cfg.Theme.Preset
+	if theme == "" {
+		theme = ui.DefaultTheme
+	}
+	fmt.Printf("  Theme:           %s\n", theme)
+	icons := cfg.Icons.Set
+	if icons == "" {
+		icons = "auto"
+	}
+	fmt.Printf("  Icons:           %s\n", icons)
+	hints := "on"
+	if !cfg.HintsEnabled() {
+		hints = "off"
+	}
+	fmt.Printf("  Hints:           %s\n", hints)
+	traceHeader := "off"
+	if cfg.TraceHeader {
+		traceHeader = "on"
+	}
+	fmt.Printf("  Trace header:    %s\n", traceHeader)
+
+	fmt.Printf("\n  %s\n", ui.ProjectTitleStyle.Render("Integrations"))
+	if cfg.Integrations.GitHub.Token != "" {
+		fmt.Printf("  GitHub token:    %s\n", ui.HealthyStyle.Render("configured"))
+	} else {
+		fmt.Printf("  GitHub token:    %s\n", ui.MutedStyle.Render("(not set)"))
+	}
+	if cfg.Integrations.GitHub.Repo != "" {
+		fmt.Printf("  GitHub repo:     %s\n", cfg.Integrations.GitHub.Repo)
+	}
+	if cfg.Integrations.GitLab.Token != "" {
+		fmt.Printf("  GitLab token:    %s\n", ui.HealthyStyle.Render("configured"))
+	} else {
+		fmt.Printf("  GitLab token:    %s\n", ui.MutedStyle.Render("(not set)"))
+	}
+	if cfg.Integrations.GitLab.Project != "" {
+		fmt.Printf("  GitLab project:  %s\n", cfg.Integrations.GitLab.Project)
+	}
+	if cfg.Integrations.GitLab.URL != "" {
+		fmt.Printf("  GitLab URL:      %s\n", cfg.Integrations.GitLab.URL)
+	}
+	if cfg.Integrations.Bitbucket.Token != "" {
+		fmt.Printf("  Bitbucket token: %s\n", ui.HealthyStyle.Render("configured"))
+	} else {
+		fmt.Printf("  Bitbucket token: %s\n", ui.MutedStyle.Render("(not set)"))
+	}
+	if cfg.Integrations.Bitbucket.Repo != "" {
+		fmt.Printf("  Bitbucket repo:  %s\n", cfg.Integrations.Bitbucket.Repo)
+	}
 
 	fmt.Println()
 	return nil
@@ -107,8 +195,136 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		}
 		cfg.Thresholds.MemoryPercent = v
 
+	case "threshold.size-growth", "threshold.size_growth_percent":
+		v, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil {
+			return fmt.Errorf("invalid value %q: expected integer (%%)", value)
+		}
+		cfg.Thresholds.SizeGrowthPercent = v
+
+	case "github.token":
+		enc, err := encryptConfigValue(value)
+		if err != nil {
+			return err
+		}
+		cfg.Integrations.GitHub.Token = enc
+		value = "********"
+
+	case "github.repo":
+		if value != "" && !strings.Contains(value, "/") {
+			return fmt.Errorf("invalid value %q: expected owner/name", value)
+		}
+		cfg.Integrations.GitHub.Repo = value
+
+	case "gitlab.token":
+		enc, err := encryptConfigValue(value)
+		if err != nil {
+			return err
+		}
+		cfg.Integrations.GitLab.Token = enc
+		value = "********"
+
+	case "gitlab.project":
+		cfg.Integrations.GitLab.Project = value
+
+	case "gitlab.url":
+		cfg.Integrations.GitLab.URL = value
+
+	case "bitbucket.token":
+		enc, err := encryptConfigValue(value)
+		if err != nil {
+			return err
+		}
+		cfg.Integrations.Bitbucket.Token = enc
+		value = "********"
+
+	case "bitbucket.username":
+		cfg.Integrations.Bitbucket.Username = value
+
+	case "bitbucket.repo":
+		if value != "" && !strings.Contains(value, "/") {
+			return fmt.Errorf("invalid value %q: expected workspace/slug", value)
+		}
+		cfg.Integrations.Bitbucket.Repo = value
+
+	case "theme", "theme.preset":
+		value = strings.ToLower(value)
+		if _, ok := ui.Themes[value]; !ok && value != "" {
+			return fmt.Errorf("unknown theme %q\nAvailable themes: %s", value, strings.Join(ui.ThemeNames(), ", "))
+		}
+		cfg.Theme.Preset = value
+
+	case "theme.healthy", "theme.warning", "theme.error", "theme.sleeping", "theme.primary", "theme.muted":
+		if value != "" && !validColor(value) {
+			return fmt.Errorf("invalid color %q: expected hex (#22c55e) or an ANSI color number (0-255)", value)
+		}
+		switch strings.TrimPrefix(key, "theme.") {
+		case "healthy":
+			cfg.Theme.Healthy = value
+		case "warning":
+			cfg.Theme.Warning = value
+		case "error":
+			cfg.Theme.Error = value
+		case "sleeping":
+			cfg.Theme.Sleeping = value
+		case "primary":
+			cfg.Theme.Primary = value
+		case "muted":
+			cfg.Theme.Muted = value
+		}
+
+	case "icons", "icons.set":
+		value = strings.ToLower(value)
+		if _, ok := ui.IconSets[value]; !ok && value != "" && value != "auto" {
+			return fmt.Errorf("unknown icon set %q\nAvailable icon sets: auto, %s", value, strings.Join(ui.IconSetNames(), ", "))
+		}
+		cfg.Icons.Set = value
+
+	case "icons.healthy", "icons.warning", "icons.error", "icons.sleeping", "icons.building", "icons.deploy",
+		"icons.watch", "icons.timeout", "icons.success", "icons.failed", "icons.rocket", "icons.health":
+		switch strings.TrimPrefix(key, "icons.") {
+		case "healthy":
+			cfg.Icons.Healthy = value
+		case "warning":
+			cfg.Icons.Warning = value
+		case "error":
+			cfg.Icons.Error = value
+		case "sleeping":
+			cfg.Icons.Sleeping = value
+		case "building":
+			cfg.Icons.Building = value
+		case "deploy":
+			cfg.Icons.Deploy = value
+		case "watch":
+			cfg.Icons.Watch = value
+		case "timeout":
+			cfg.Icons.Timeout = value
+		case "success":
+			cfg.Icons.Success = value
+		case "failed":
+			cfg.Icons.Failed = value
+		case "rocket":
+			cfg.Icons.Rocket = value
+		case "health":
+			cfg.Icons.Health = value
+		}
+
+	case "hints":
+		on, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q: expected true or false", value)
+		}
+		cfg.Hints = &on
+
+	case "trace-header", "trace_header":
+		on, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q: expected true or false", value)
+		}
+		cfg.TraceHeader = on
+
 	default:
-		return fmt.Errorf("unknown config key: %s\nValid keys: default-project, threshold.response-time, threshold.cpu, threshold.memory", key)
+		return fmt.Errorf("unknown config key: %s\nValid keys: default-project, threshold.response-time, threshold.cpu, threshold.memory, github.token, github.repo, gitlab.token, gitlab.project, gitlab.url, bitbucket.token, bitbucket.username, bitbucket.repo, theme, theme.<color>, icons, icons.<icon>, hints, trace-header", key)
 	}
 
 	if err := config.Save(cfg); err != nil {
@@ -118,3 +334,151 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  %s %s = %s\n", ui.IconSuccess, key, value)
 	return nil
 }
+
+// validColor accepts "#rgb", "#rrggbb" or an ANSI color number.
+func validColor(s string) bool {
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// encryptConfigValue encrypts a secret config value with the local key.
+func encryptConfigValue(value string) (string, error) {
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return "", fmt.Errorf("load encryption key: %w", err)
+	}
+	enc, err := config.Encrypt(key, value)
+	if err != nil {
+		return "", fmt.Errorf("encrypt value: %w", err)
+	}
+	return enc, nil
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	if _, err := config.EnsureDir(); err != nil {
+		return err
+	}
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	orig, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-edit-*.yaml")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(orig)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		if err := runEditor(tmp.Name()); err != nil {
+			return err
+		}
+		edited, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return fmt.Errorf("read edited config: %w", err)
+		}
+		if bytes.Equal(edited, orig) {
+			fmt.Println("  No changes.")
+			return nil
+		}
+
+		problems := checkConfig(edited)
+		if len(problems) == 0 {
+			if err := writeConfigFile(path, edited); err != nil {
+				return err
+			}
+			fmt.Printf("  %s Saved %s\n", ui.IconSuccess, path)
+			return nil
+		}
+
+		fmt.Printf("\n  %s The edited config has problems:\n", ui.IconError)
+		for _, p := range problems {
+			fmt.Printf("    %s\n", ui.ErrorStyle.Render(p))
+		}
+		fmt.Printf("\n  Edit again? (no discards your changes) [Y/n] ")
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer == "n" || answer == "no" {
+			fmt.Println("  Discarded; config.yaml is unchanged.")
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &ExitCodeError{Code: exitFailed, Msg: ""}
+		}
+	}
+}
+
+// checkConfig returns what is wrong with config YAML, one problem per line,
+// or nil if it can be saved.
+func checkConfig(data []byte) []string {
+	cfg, err := config.Parse(data)
+	if err != nil {
+		return strings.Split(err.Error(), "\n")
+	}
+	var problems []string
+	for _, name := range sortedProjectNames(cfg) {
+		proj := cfg.Projects[name]
+		if _, err := parseAlertRules(cfg, name, &proj); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems
+}
+
+// runEditor opens path in the user's editor and waits for it to exit.
+// $VISUAL and $EDITOR may include arguments, e.g. "code --wait".
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("run editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+// writeConfigFile replaces the config file with data atomically, so a
+// crash never leaves a half-written config.
+func writeConfigFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform/platformtest"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	conformanceService        string
+	conformanceMinDeployments int
+	conformanceMissingService string
+	conformanceMissingDeploy  string
+	conformanceRedeploy       bool
+	conformanceFormat         string
+)
+
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance <platform>",
+	Short: "Check that a platform adapter follows Orbit's adapter contract",
+	Long: `Run the adapter conformance suite against a connected platform — built in or
+a plugin — using one of its services as the fixture:
+
+  orbit conformance netlify --service site-123
+  orbit conformance netlify --service site-123 --min-deployments 40 \
+    --missing-service site-000 --missing-deploy dep-000
+  orbit conformance netlify --service site-123 --redeploy
+
+Checks that statuses use Orbit's vocabulary, deployment lists honor their
+limit across pages and come newest first, listed deployments can be
+fetched, bad tokens, missing resources and unsupported calls fail with the
+right error kind, and watches report phases in order and stop when
+cancelled. --redeploy also redeploys the service and follows the
+deployment to the end. Exits 1 if any check fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConformance,
+}
+
+func init() {
+	conformanceCmd.Flags().StringVar(&conformanceService, "service", "", "Platform ID of a service with at least one deployment (required)")
+	conformanceCmd.Flags().IntVar(&conformanceMinDeployments, "min-deployments", 1, "Deployments the service is known to have; more than a page checks pagination")
+	conformanceCmd.Flags().StringVar(&conformanceMissingService, "missing-service", "", "A service ID that does not exist, to check not_found errors")
+	conformanceCmd.Flags().StringVar(&conformanceMissingDeploy, "missing-deploy", "", "A deployment ID that does not exist, to check not_found errors")
+	conformanceCmd.Flags().BoolVar(&conformanceRedeploy, "redeploy", false, "Redeploy the service and watch the deployment")
+	conformanceCmd.Flags().StringVar(&conformanceFormat, "format", "", "Output format (json)")
+	conformanceCmd.MarkFlagRequired("service")
+	rootCmd.AddCommand(conformanceCmd)
+}
+
+func runConformance(cmd *cobra.Command, args []string) error {
+	pName := args[0]
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+	pc, ok := cfg.Platforms[pName]
+	if !ok {
+		return fmt.Errorf("platform %q not connected\nRun: orbit connect %s", pName, pName)
+	}
+	token, err := config.Decrypt(key, pc.Token)
+	if err != nil {
+		return fmt.Errorf("decrypt token: %w", err)
+	}
+	p, err := newPlatform(pName, pc, token)
+	if err != nil {
+		return err
+	}
+
+	prog := newProgress(conformanceFormat == "json")
+	prog.printf("  Running %d conformance checks against %s...\n", len(platformtest.Checks()), pName)
+	results := platformtest.Check(cmd.Context(), p, platformtest.Fixture{
+		ServiceID:        conformanceService,
+		MinDeployments:   conformanceMinDeployments,
+		MissingServiceID: conformanceMissingService,
+		MissingDeployID:  conformanceMissingDeploy,
+		Redeploy:         conformanceRedeploy,
+	})
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+
+	if conformanceFormat == "json" {
+		if err := renderConformanceJSON(pName, results); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n  %s\n\n", ui.ProjectTitleStyle.Render(pName+" conformance"))
+		for _, r := range results {
+			switch {
+			case r.Skipped:
+				fmt.Printf("  %s %s %s\n", ui.MutedStyle.Render("-"), ui.Pad(r.Check, 16), ui.MutedStyle.Render("skipped: "+r.Detail))
+			case r.Err != nil:
+				fmt.Printf("  %s %s %s\n", ui.IconError, ui.Pad(r.Check, 16), ui.ErrorStyle.Render(r.Err.Error()))
+			default:
+				fmt.Printf("  %s %s %s\n", ui.IconSuccess, ui.Pad(r.Check, 16), ui.MutedStyle.Render(r.Detail))
+			}
+		}
+		fmt.Println()
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d conformance checks failed", failed, len(results))
+	}
+	return nil
+}
+
+type conformanceJSON struct {
+	Check  string `json:"check"`
+	Result string `json:"result"` // pass, fail, skip
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func renderConformanceJSON(pName string, results []platformtest.Result) error {
+	out := struct {
+		Platform string            `json:"platform"`
+		Checks   []conformanceJSON `json:"checks"`
+	}{Platform: pName}
+	for _, r := range results {
+		c := conformanceJSON{Check: r.Check, Result: "pass", Detail: r.Detail}
+		switch {
+		case r.Skipped:
+			c.Result = "skip"
+		case r.Err != nil:
+			c.Result, c.Error = "fail", r.Err.Error()
+		}
+		out.Checks = append(out.Checks, c)
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
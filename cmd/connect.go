@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"runtime"
 	"strings"
 
 	"github.com/humanetools/orbit/internal/config"
@@ -13,24 +17,53 @@ import (
 )
 
 var (
-	connectToken  string
-	connectTeamID string
+	connectToken    string
+	connectTeam     string
+	connectEndpoint string
+	connectFromCLI  bool
+	connectOpen     bool
 )
 
 var connectCmd = &cobra.Command{
 	Use:   "connect <platform>",
 	Short: "Connect a cloud platform with an API token",
 	Long: `Connect a cloud platform by providing an API token.
-Supported platforms: vercel, koyeb, supabase, render.
+Supported platforms: vercel, koyeb, supabase, neon, planetscale, render,
+kubernetes, docker, github, plus any external adapters declared under plugins: in ~/.orbit/config.yaml.
 
-The token is validated against the platform API, then encrypted and stored locally.`,
+The token is validated against the platform API, then encrypted and stored locally.
+
+For kubernetes, pass a service account token with --endpoint, or use
+--token kubeconfig (or kubeconfig:<context>) to read ~/.kube/config.
+For docker, the token is the daemon address, or "local" for $DOCKER_HOST
+or the default socket.
+
+Vercel tokens often reach several teams; pass --team <slug> (or the team ID)
+to scope every call to one of them. On Koyeb, where tokens belong to a
+single organization, --team checks that the token is for the one you expect.
+
+If you are already logged in with the platform's own CLI (vercel, koyeb,
+supabase or fly), --from-cli offers to use the token it saved instead of
+asking for one:
+
+  orbit connect vercel --from-cli
+
+When asked for a token, connect shows where to create one and which scopes
+to pick so it has no more access than Orbit needs (--open opens the page in
+a browser). After validation, tokens that grant more than that on Vercel
+and GitHub are flagged; the token is still saved.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConnect,
 }
 
 func init() {
 	connectCmd.Flags().StringVar(&connectToken, "token", "", "API token (non-interactive mode)")
-	connectCmd.Flags().StringVar(&connectTeamID, "team-id", "", "Team/org ID (Vercel)")
+	connectCmd.Flags().StringVar(&connectTeam, "team", "", "Team slug or ID (Vercel), organization name (Koyeb)")
+	connectCmd.Flags().StringVar(&connectTeam, "team-id", "", "Team ID (Vercel)")
+	connectCmd.Flags().MarkHidden("team-id")
+	connectCmd.Flags().StringVar(&connectEndpoint, "endpoint", "", "API server URL (Kubernetes)")
+	connectCmd.Flags().BoolVar(&connectFromCLI, "from-cli", false, "Use the token saved by the platform's own CLI (Vercel, Koyeb, Supabase, Fly.io)")
+	connectCmd.Flags().BoolVar(&connectOpen, "open", false, "Open the token-creation page in a browser")
 	rootCmd.AddCommand(connectCmd)
 }
 
@@ -38,17 +71,27 @@ func runConnect(cmd *cobra.Command, args []string) error {
 	name := strings.ToLower(args[0])
 
 	if !platform.IsSupported(name) {
-		return fmt.Errorf("unsupported platform: %s\nSupported: vercel, koyeb, supabase, render", name)
+		return fmt.Errorf("unsupported platform: %s\nSupported: %s", name, supportedPlatforms())
 	}
 
 	token := connectToken
+	if connectFromCLI {
+		if token != "" {
+			return fmt.Errorf("--from-cli and --token can't be combined")
+		}
+		var err error
+		if token, err = cliToken(name); err != nil {
+			return err
+		}
+		if token == "" {
+			fmt.Println("  Cancelled.")
+			return nil
+		}
+	}
 
 	// Interactive mode: prompt for token
 	if token == "" {
-		tokenURL := platform.TokenURL(name)
-		if tokenURL != "" {
-			fmt.Printf("  Get your token at: %s\n", ui.MutedStyle.Render(tokenURL))
-		}
+		printTokenGuide(name)
 		fmt.Printf("%s API Token: ", strings.Title(name))
 
 		// Read token with echo disabled
@@ -64,19 +107,46 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("token cannot be empty")
 	}
 
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	// Keep settings that live next to the token (e.g. custom endpoints)
+	pc := cfg.Platforms[name]
+	pc.TeamID = ""
+	if connectEndpoint != "" {
+		pc.Endpoint = connectEndpoint
+	}
+
 	// Validate token against the platform API
-	p, err := platform.Get(name, token)
+	p, err := newPlatform(name, pc, token)
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("  Validating token... ")
-	if err := p.Validate(token); err != nil {
+	if err := p.Validate(cmd.Context(), token); err != nil {
 		fmt.Println(ui.ErrorStyle.Render("failed"))
 		return fmt.Errorf("token validation failed: %w", err)
 	}
 	fmt.Println(ui.HealthyStyle.Render("valid"))
 
+	if connectTeam != "" {
+		team, err := resolveTeam(cmd.Context(), p, name, connectTeam)
+		if err != nil {
+			return err
+		}
+		if _, ok := p.(platform.TeamConfigurable); ok {
+			pc.TeamID = team.ID
+		}
+		fmt.Printf("  Team:    %s\n", team.Name)
+		if tc, ok := p.(platform.TeamConfigurable); ok {
+			tc.SetTeamID(team.ID)
+		}
+	}
+	printTokenScopes(cmd.Context(), p, name)
+
 	// Encrypt and save
 	key, err := config.LoadOrCreateKey()
 	if err != nil {
@@ -88,17 +158,122 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("encrypt token: %w", err)
 	}
 
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("load config: %w", err)
-	}
-
-	cfg.Platforms[name] = config.PlatformConfig{Token: encrypted, TeamID: connectTeamID}
+	pc.Token = encrypted
+	cfg.Platforms[name] = pc
 
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("save config: %w", err)
 	}
 
 	fmt.Printf("\n%s %s connected successfully!\n", ui.IconSuccess, strings.Title(name))
+	if len(cfg.Projects) == 0 {
+		printHints(cfg, "orbit project create <name> --auto   # discover services on connected platforms")
+	} else {
+		printHints(cfg, fmt.Sprintf("orbit service add <project> --platform %s --name <name> --id <id>", name))
+	}
 	return nil
 }
+
+// printTokenGuide shows where to create a token and which scopes to pick,
+// opening the page when --open is set.
+func printTokenGuide(name string) {
+	tokenURL := platform.TokenURL(name)
+	if tokenURL == "" {
+		return
+	}
+	fmt.Printf("  Get your token at: %s\n", ui.MutedStyle.Render(tokenURL))
+	for _, step := range platform.TokenSteps(name) {
+		fmt.Printf("    %s\n", ui.MutedStyle.Render(step))
+	}
+	if connectOpen {
+		if err := openBrowser(tokenURL); err != nil {
+			fmt.Printf("  %s Could not open a browser: %v\n", ui.IconWarning, err)
+		}
+	}
+	fmt.Println()
+}
+
+// printTokenScopes warns when the token can do more than Orbit needs, or
+// lacks access it does need. Platforms that can't report scopes are
+// skipped silently.
+func printTokenScopes(ctx context.Context, p platform.Platform, name string) {
+	sc, ok := p.(platform.ScopeChecker)
+	if !ok {
+		return
+	}
+	scopes, err := sc.TokenScopes(ctx)
+	if err != nil {
+		fmt.Printf("  %s Could not check token scopes: %v\n", ui.IconWarning, err)
+		return
+	}
+	if len(scopes.Granted) > 0 {
+		fmt.Printf("  Scopes:  %s\n", strings.Join(scopes.Granted, ", "))
+	}
+	if scopes.OverPrivileged() {
+		fmt.Printf("  %s Token has more access than Orbit needs: %s\n", ui.IconWarning, ui.WarningStyle.Render(strings.Join(scopes.Excess, ", ")))
+		fmt.Printf("    Consider a narrower token: %s\n", ui.MutedStyle.Render(platform.TokenURL(name)))
+	}
+	if len(scopes.Missing) > 0 {
+		fmt.Printf("  %s Token lacks access Orbit needs: %s\n", ui.IconWarning, ui.WarningStyle.Render(strings.Join(scopes.Missing, ", ")))
+	}
+}
+
+// openBrowser opens url with the desktop's default handler.
+func openBrowser(url string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", url)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	return c.Start()
+}
+
+// cliToken reads the token the platform's CLI saved and asks before using
+// it. It returns "" if the user declines.
+func cliToken(name string) (string, error) {
+	token, path, err := platform.CLIToken(name)
+	if err != nil {
+		return "", err
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, home) {
+		path = "~" + strings.TrimPrefix(path, home)
+	}
+	fmt.Printf("  Found a %s CLI login in %s\n", strings.Title(name), ui.MutedStyle.Render(path))
+	fmt.Printf("  Copy its token into orbit (stored encrypted)? [y/N] ")
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	if answer != "y" && answer != "yes" {
+		return "", nil
+	}
+	return token, nil
+}
+
+// resolveTeam looks up ref (a slug or ID) among the teams the token can
+// reach.
+func resolveTeam(ctx context.Context, p platform.Platform, name, ref string) (platform.Team, error) {
+	tl, ok := p.(platform.TeamLister)
+	if !ok {
+		return platform.Team{}, fmt.Errorf("%s has no teams or organizations; drop --team", name)
+	}
+	teams, err := tl.ListTeams(ctx)
+	if err != nil {
+		return platform.Team{}, fmt.Errorf("list teams: %w", err)
+	}
+	team, ok := platform.FindTeam(teams, ref)
+	if !ok {
+		slugs := make([]string, len(teams))
+		for i, t := range teams {
+			slugs[i] = t.Slug
+		}
+		if len(slugs) == 0 {
+			return platform.Team{}, fmt.Errorf("team %q not found: the token has no teams", ref)
+		}
+		return platform.Team{}, fmt.Errorf("team %q not found\nAvailable: %s", ref, strings.Join(slugs, ", "))
+	}
+	return team, nil
+}
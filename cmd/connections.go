@@ -1,15 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/humanetools/orbit/internal/config"
-	"github.com/humanetools/orbit/internal/platform"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	connectionsSkipValidate bool
+	connectionsTimeout      int
+)
+
 var connectionsCmd = &cobra.Command{
 	Use:   "connections",
 	Short: "List all connected platforms and their status",
@@ -17,6 +24,8 @@ var connectionsCmd = &cobra.Command{
 }
 
 func init() {
+	connectionsCmd.Flags().BoolVar(&connectionsSkipValidate, "skip-validate", false, "List connections from config without live API checks")
+	connectionsCmd.Flags().IntVar(&connectionsTimeout, "timeout", 10, "Per-platform validation timeout in seconds")
 	rootCmd.AddCommand(connectionsCmd)
 }
 
@@ -44,46 +53,84 @@ func runConnections(cmd *cobra.Command, args []string) error {
 	}
 	sort.Strings(names)
 
+	// Validate all platforms concurrently; results keep the sorted order.
+	rows := make([]connectionRow, len(names))
+	if connectionsSkipValidate {
+		for i, name := range names {
+			rows[i] = connectionRow{status: ui.MutedStyle.Render("not validated")}
+			if teamID := cfg.Platforms[name].TeamID; teamID != "" {
+				rows[i].info = ui.MutedStyle.Render("team " + teamID)
+			}
+		}
+	} else {
+		timeout := time.Duration(connectionsTimeout) * time.Second
+		var wg sync.WaitGroup
+		for i, name := range names {
+			wg.Add(1)
+			go func(i int, name string) {
+				defer wg.Done()
+				rows[i] = validateConnection(cmd.Context(), name, cfg.Platforms[name], key, timeout)
+			}(i, name)
+		}
+		wg.Wait()
+	}
+
 	fmt.Println(ui.HeaderStyle.Render("Platform") +
 		ui.HeaderStyle.Render("Status") +
 		ui.HeaderStyle.Render("Info"))
 	fmt.Println("─────────────────────────────────────────────")
 
-	for _, name := range names {
-		pc := cfg.Platforms[name]
-		token, err := config.Decrypt(key, pc.Token)
-		if err != nil {
-			fmt.Printf("%-12s %s  %s\n",
-				ui.CellStyle.Render(name),
-				ui.ErrorStyle.Render(ui.IconError+" error"),
-				ui.MutedStyle.Render("decrypt failed"),
-			)
-			continue
+	for i, name := range names {
+		row := rows[i]
+		if row.info != "" {
+			fmt.Printf("%s %s  %s\n", ui.Pad(ui.CellStyle.Render(name), 12), row.status, row.info)
+		} else {
+			fmt.Printf("%s %s\n", ui.Pad(ui.CellStyle.Render(name), 12), row.status)
 		}
+	}
+
+	return nil
+}
 
-		p, err := platform.Get(name, token)
-		if err != nil {
-			fmt.Printf("%-12s %s  %s\n",
-				ui.CellStyle.Render(name),
-				ui.ErrorStyle.Render(ui.IconError+" error"),
-				ui.MutedStyle.Render("unknown platform"),
-			)
-			continue
+// connectionRow is the rendered status and info columns for one platform.
+type connectionRow struct {
+	status string
+	info   string
+}
+
+// validateConnection checks a platform token with a live API call, giving up
+// after timeout so one slow platform doesn't hold up the whole listing.
+func validateConnection(ctx context.Context, name string, pc config.PlatformConfig, key []byte, timeout time.Duration) connectionRow {
+	token, err := config.Decrypt(key, pc.Token)
+	if err != nil {
+		return connectionRow{
+			status: ui.ErrorStyle.Render(ui.IconError + " error"),
+			info:   ui.MutedStyle.Render("decrypt failed"),
 		}
+	}
 
-		if err := p.Validate(token); err != nil {
-			fmt.Printf("%-12s %s  %s\n",
-				ui.CellStyle.Render(name),
-				ui.ErrorStyle.Render(ui.IconError+" invalid"),
-				ui.MutedStyle.Render(err.Error()),
-			)
-		} else {
-			fmt.Printf("%-12s %s\n",
-				ui.CellStyle.Render(name),
-				ui.HealthyStyle.Render(ui.IconHealthy+" connected"),
-			)
+	p, err := newPlatform(name, pc, token)
+	if err != nil {
+		return connectionRow{
+			status: ui.ErrorStyle.Render(ui.IconError + " error"),
+			info:   ui.MutedStyle.Render("unknown platform"),
 		}
 	}
 
-	return nil
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := p.Validate(ctx, token); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return connectionRow{
+				status: ui.WarningStyle.Render(ui.IconWarning + " timeout"),
+				info:   ui.MutedStyle.Render(fmt.Sprintf("no response after %s", timeout)),
+			}
+		}
+		return connectionRow{
+			status: ui.ErrorStyle.Render(ui.IconError + " invalid"),
+			info:   ui.MutedStyle.Render(err.Error()),
+		}
+	}
+	return connectionRow{status: ui.HealthyStyle.Render(ui.IconHealthy + " connected")}
 }
@@ -0,0 +1,6 @@
+//go:build !windows
+
+package cmd
+
+// setupConsole is a no-op: Unix terminals handle UTF-8 and ANSI natively.
+func setupConsole() {}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/sys/windows"
+)
+
+// setupConsole switches the console to UTF-8 and turns on ANSI escape
+// handling. Consoles that predate VT support (before Windows 10) get
+// uncolored output instead of raw escape codes.
+func setupConsole() {
+	windows.SetConsoleOutputCP(65001) // UTF-8
+
+	ok := true
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		h := windows.Handle(f.Fd())
+		var mode uint32
+		if windows.GetConsoleMode(h, &mode) != nil {
+			continue // redirected to a file or pipe
+		}
+		if windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) != nil {
+			ok = false
+		}
+	}
+	if !ok {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
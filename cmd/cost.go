@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/cost"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var costFormat string
+
+var costCmd = &cobra.Command{
+	Use:   "cost [project]",
+	Short: "Estimate monthly compute spend per service and project",
+	Long: `Estimate what each service costs per month from its instance type and
+instance count, and total it per project. Without a project, every project
+is estimated.
+
+  orbit cost
+  orbit cost myshop
+  orbit cost myshop --format json
+
+Prices come from a table bundled with Orbit and cover compute only:
+bandwidth, storage, build minutes and plan fees are not included. Autoscaled
+services show a range from their minimum to their maximum instance count.
+Override or add prices (monthly USD per instance) in config:
+
+  pricing:
+    koyeb:
+      small: 10.71
+    render:
+      standard: 25`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCost,
+}
+
+func init() {
+	costCmd.Flags().StringVar(&costFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(costCmd)
+}
+
+type costResult struct {
+	Entry    config.ServiceEntry
+	Estimate cost.Estimate
+	Priced   bool
+	Note     string
+}
+
+func runCost(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	topologies := map[string][]config.ServiceEntry{}
+	if len(args) > 0 {
+		proj, err := resolveProject(cfg, args[0])
+		if err != nil {
+			return err
+		}
+		topologies[args[0]] = proj.Topology
+	} else {
+		for name, proj := range cfg.Projects {
+			topologies[name] = proj.Topology
+		}
+	}
+	if len(topologies) == 0 {
+		return fmt.Errorf("no projects configured\nCreate one with: orbit project create <name>")
+	}
+
+	names := make([]string, 0, len(topologies))
+	projects := make(map[string][]costResult, len(topologies))
+	for name, topology := range topologies {
+		names = append(names, name)
+		projects[name] = estimateCosts(cmd.Context(), cfg, key, topology)
+	}
+	sort.Strings(names)
+	if costFormat == "json" {
+		return renderCostJSON(names, projects)
+	}
+	renderCostTable(names, projects)
+	return nil
+}
+
+// estimateCosts reads each service's instance type and count concurrently
+// and prices them.
+func estimateCosts(ctx context.Context, cfg *config.Config, key []byte, entries []config.ServiceEntry) []costResult {
+	overrides := cost.Overrides(cfg.Pricing)
+	results := make([]costResult, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		results[i].Entry = e
+		wg.Add(1)
+		go func(r *costResult) {
+			defer wg.Done()
+			p, err := entryPlatform(cfg, key, r.Entry)
+			if err != nil {
+				r.Note = err.Error()
+				return
+			}
+			provider, ok := p.(platform.ScaleInfoProvider)
+			if !ok {
+				r.Note = "plan-based pricing; not estimated"
+				return
+			}
+			minInstances, maxInstances, instanceType, err := provider.GetCurrentScale(ctx, r.Entry.ID)
+			if err != nil {
+				r.Note = err.Error()
+				return
+			}
+			if instanceType == "" {
+				r.Note = "instance type unknown"
+				return
+			}
+			r.Estimate, r.Priced = cost.EstimateService(r.Entry.Platform, instanceType, minInstances, maxInstances, overrides)
+			if !r.Priced {
+				r.Estimate = cost.Estimate{InstanceType: instanceType, Min: minInstances, Max: max(minInstances, maxInstances)}
+				r.Note = "no price for this type; add one under pricing: in config"
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// costTotal sums the priced services' minimum and maximum monthly cost.
+func costTotal(results []costResult) (monthly, monthlyMax float64) {
+	for _, r := range results {
+		if r.Priced {
+			monthly += r.Estimate.Monthly
+			monthlyMax += r.Estimate.MonthlyMax
+		}
+	}
+	return roundCents(monthly), roundCents(monthlyMax)
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func formatDollars(v, vmax float64) string {
+	if vmax != v {
+		return fmt.Sprintf("$%.2f–%.2f", v, vmax)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
+
+func renderCostTable(names []string, projects map[string][]costResult) {
+	var total, totalMax float64
+	for _, name := range names {
+		results := projects[name]
+		fmt.Println(ui.ProjectTitleStyle.Render(name))
+		fmt.Printf("  %s %s %s %s %s %s\n",
+			ui.Pad(ui.HeaderStyle.Render("Service"), 16),
+			ui.Pad(ui.HeaderStyle.Render("Platform"), 10),
+			ui.Pad(ui.HeaderStyle.Render("Type"), 24),
+			ui.Pad(ui.HeaderStyle.Render("Instances"), 10),
+			ui.Pad(ui.HeaderStyle.Render("Per inst."), 10),
+			ui.HeaderStyle.Render("Monthly"),
+		)
+		for _, r := range results {
+			e := r.Estimate
+			if e.InstanceType == "" {
+				fmt.Printf("  %s %s %s\n", ui.Pad(r.Entry.Name, 16), ui.Pad(r.Entry.Platform, 10), ui.MutedStyle.Render(r.Note))
+				continue
+			}
+			instances := fmt.Sprint(e.Min)
+			if e.Max != e.Min {
+				instances = fmt.Sprintf("%d–%d", e.Min, e.Max)
+			}
+			perInstance, monthly := ui.MutedStyle.Render(ui.Dash), ui.MutedStyle.Render(r.Note)
+			if r.Priced {
+				perInstance = fmt.Sprintf("$%.2f", e.PerInstance)
+				monthly = formatDollars(e.Monthly, e.MonthlyMax)
+			}
+			fmt.Printf("  %s %s %s %s %s %s\n",
+				ui.Pad(r.Entry.Name, 16), ui.Pad(r.Entry.Platform, 10), ui.Pad(ui.Truncate(e.InstanceType, 24), 24),
+				ui.Pad(instances, 10), ui.Pad(perInstance, 10), monthly)
+		}
+		monthly, monthlyMax := costTotal(results)
+		total += monthly
+		totalMax += monthlyMax
+		fmt.Printf("  %s %s\n\n", ui.Pad(ui.HeaderStyle.Render("Total"), 74), formatDollars(monthly, monthlyMax))
+	}
+	if len(names) > 1 {
+		fmt.Printf("All projects: %s per month\n", formatDollars(roundCents(total), roundCents(totalMax)))
+	}
+	fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("Compute only, at prices as of %s; override them under pricing: in config.", cost.PricesAsOf)))
+}
+
+type jsonCostService struct {
+	Service      string   `json:"service"`
+	Platform     string   `json:"platform"`
+	InstanceType string   `json:"instance_type,omitempty"`
+	Min          int      `json:"min_instances,omitempty"`
+	Max          int      `json:"max_instances,omitempty"`
+	PerInstance  *float64 `json:"per_instance,omitempty"`
+	Monthly      *float64 `json:"monthly,omitempty"`
+	MonthlyMax   *float64 `json:"monthly_max,omitempty"`
+	Note         string   `json:"note,omitempty"`
+}
+
+type jsonCostProject struct {
+	Project    string            `json:"project"`
+	Services   []jsonCostService `json:"services"`
+	Monthly    float64           `json:"monthly"`
+	MonthlyMax float64           `json:"monthly_max"`
+}
+
+type jsonCost struct {
+	Projects   []jsonCostProject `json:"projects"`
+	Monthly    float64           `json:"monthly"`
+	MonthlyMax float64           `json:"monthly_max"`
+	Currency   string            `json:"currency"`
+	PricesAsOf string            `json:"prices_as_of"`
+}
+
+func renderCostJSON(names []string, projects map[string][]costResult) error {
+	out := jsonCost{Projects: []jsonCostProject{}, Currency: "USD", PricesAsOf: cost.PricesAsOf}
+	for _, name := range names {
+		jp := jsonCostProject{Project: name, Services: []jsonCostService{}}
+		for _, r := range projects[name] {
+			e := r.Estimate
+			js := jsonCostService{Service: r.Entry.Name, Platform: r.Entry.Platform, InstanceType: e.InstanceType, Min: e.Min, Max: e.Max, Note: r.Note}
+			if r.Priced {
+				js.PerInstance, js.Monthly, js.MonthlyMax = &e.PerInstance, &e.Monthly, &e.MonthlyMax
+			}
+			jp.Services = append(jp.Services, js)
+		}
+		jp.Monthly, jp.MonthlyMax = costTotal(projects[name])
+		out.Monthly += jp.Monthly
+		out.MonthlyMax += jp.MonthlyMax
+		out.Projects = append(out.Projects, jp)
+	}
+	out.Monthly, out.MonthlyMax = roundCents(out.Monthly), roundCents(out.MonthlyMax)
+	return printJSON(out)
+}
@@ -0,0 +1,430 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/cron"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cronService string
+	cronFormat  string
+)
+
+var cronCmd = &cobra.Command{
+	Use:   "cron [project]",
+	Short: "List scheduled jobs and when they last ran",
+	Long: `List the scheduled jobs of each service with their schedule, when they
+last ran and whether that run succeeded. A job is flagged as missed when it
+has not run within its schedule's interval.
+
+  orbit cron myshop
+  orbit cron myshop --service web --format json
+  orbit cron check myshop
+
+Supported on Vercel (crons from vercel.json). Last runs come from the
+production deployment's runtime logs, so a job whose last run is older than
+the log retention shows no last run and is never flagged.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCron,
+}
+
+var cronCheckCmd = &cobra.Command{
+	Use:   "check [project]",
+	Short: "Notify about missed or failed scheduled jobs",
+	Long: `Check every scheduled job and send a notification when one misses a run
+or its last run failed, and again when it recovers. Each problem is sent
+once; state is kept in ~/.orbit/cron-alerts.json. Exits 1 while any job has
+a problem, so it can run from a scheduler or CI:
+
+  */15 * * * *  orbit cron check myshop
+
+Without a project, every project is checked. Notifications are "cron_missed",
+"cron_failed" and "cron_recovered" events, so routes can match them.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCronCheck,
+}
+
+func init() {
+	cronCmd.Flags().StringVar(&cronService, "service", "", "Show jobs of a specific service")
+	cronCmd.Flags().StringVar(&cronFormat, "format", "", "Output format (json)")
+	cronCheckCmd.Flags().StringVar(&cronService, "service", "", "Check jobs of a specific service")
+	cronCmd.AddCommand(cronCheckCmd)
+	rootCmd.AddCommand(cronCmd)
+}
+
+var errCronUnsupported = errors.New("scheduled jobs not supported")
+
+// cronJob is a platform job with its schedule evaluated at one time.
+type cronJob struct {
+	platform.CronJob
+	State string    // ok, missed, failed, unknown, disabled or invalid
+	Due   time.Time // the missed run, when State is missed
+	Note  string
+}
+
+type cronResult struct {
+	Entry config.ServiceEntry
+	Jobs  []cronJob
+	Err   error
+}
+
+// evaluateCronJob decides a job's state at now.
+func evaluateCronJob(j platform.CronJob, now time.Time) cronJob {
+	job := cronJob{CronJob: j, State: "ok"}
+	sched, err := cron.Parse(j.Schedule)
+	switch {
+	case err != nil:
+		job.State, job.Note = "invalid", err.Error()
+	case !j.Enabled:
+		job.State = "disabled"
+	case j.LastRun.IsZero():
+		job.State, job.Note = "unknown", "no run in recent logs"
+	default:
+		if due, missed := sched.Missed(j.LastRun, now); missed {
+			job.State, job.Due = "missed", due
+			job.Note = "was due " + ui.TimeAgo(due)
+		} else if j.LastStatus == "failed" {
+			job.State, job.Note = "failed", j.Detail
+		}
+	}
+	return job
+}
+
+// fetchCronJobs lists the scheduled jobs of each service concurrently.
+func fetchCronJobs(ctx context.Context, cfg *config.Config, key []byte, entries []config.ServiceEntry, now time.Time) []cronResult {
+	results := make([]cronResult, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		results[i].Entry = e
+		wg.Add(1)
+		go func(r *cronResult) {
+			defer wg.Done()
+			p, err := entryPlatform(cfg, key, r.Entry)
+			if err != nil {
+				r.Err = err
+				return
+			}
+			cl, ok := p.(platform.CronLister)
+			if !ok {
+				r.Err = errCronUnsupported
+				return
+			}
+			jobs, err := cl.ListCronJobs(ctx, r.Entry.ID)
+			for _, j := range jobs {
+				r.Jobs = append(r.Jobs, evaluateCronJob(j, now))
+			}
+			r.Err = err
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// cronEntries returns the project's services, or just the one named by
+// --service.
+func cronEntries(proj *config.ProjectConfig, projectName string) ([]config.ServiceEntry, error) {
+	if cronService == "" {
+		return proj.Topology, nil
+	}
+	for _, e := range proj.Topology {
+		if e.Name == cronService {
+			return []config.ServiceEntry{e}, nil
+		}
+	}
+	return nil, fmt.Errorf("service %q not found in project %q", cronService, projectName)
+}
+
+func runCron(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	entries, err := cronEntries(proj, projectName)
+	if err != nil {
+		return err
+	}
+
+	results := fetchCronJobs(cmd.Context(), cfg, key, entries, time.Now())
+	if cronFormat == "json" {
+		return renderCronJSON(results)
+	}
+	renderCronTable(projectName, results)
+	return nil
+}
+
+func cronStateLabel(job cronJob) string {
+	switch job.State {
+	case "ok":
+		return ui.HealthyStyle.Render(ui.IconHealthy + " ok")
+	case "missed":
+		return ui.ErrorStyle.Render(ui.IconError + " missed")
+	case "failed":
+		return ui.ErrorStyle.Render(ui.IconError + " failed")
+	case "invalid":
+		return ui.WarningStyle.Render(ui.IconWarning + " invalid")
+	}
+	return ui.MutedStyle.Render(job.State)
+}
+
+func renderCronTable(projectName string, results []cronResult) {
+	fmt.Println(ui.ProjectTitleStyle.Render(projectName))
+	fmt.Printf("  %s %s %s %s %s %s\n",
+		ui.Pad(ui.HeaderStyle.Render("Service"), 16),
+		ui.Pad(ui.HeaderStyle.Render("Job"), 28),
+		ui.Pad(ui.HeaderStyle.Render("Schedule"), 16),
+		ui.Pad(ui.HeaderStyle.Render("Last run"), 12),
+		ui.Pad(ui.HeaderStyle.Render("State"), 12),
+		ui.HeaderStyle.Render("Notes"),
+	)
+
+	for _, r := range results {
+		service := fmt.Sprintf("%s (%s)", r.Entry.Name, r.Entry.Platform)
+		if errors.Is(r.Err, errCronUnsupported) {
+			fmt.Printf("  %s %s %s\n", ui.Pad(service, 16), ui.Pad(ui.MutedStyle.Render(ui.Dash), 28), ui.MutedStyle.Render("not supported on "+r.Entry.Platform))
+			continue
+		}
+		if len(r.Jobs) == 0 {
+			msg := "no scheduled jobs"
+			if r.Err != nil {
+				msg = r.Err.Error()
+			}
+			fmt.Printf("  %s %s %s\n", ui.Pad(service, 16), ui.Pad(ui.MutedStyle.Render(ui.Dash), 28), ui.MutedStyle.Render(msg))
+			continue
+		}
+		for _, job := range r.Jobs {
+			lastRun := ui.Dash
+			if !job.LastRun.IsZero() {
+				lastRun = ui.TimeAgo(job.LastRun)
+			}
+			fmt.Printf("  %s %s %s %s %s %s\n",
+				ui.Pad(service, 16), ui.Pad(ui.Truncate(job.Name, 28), 28), ui.Pad(job.Schedule, 16),
+				ui.Pad(lastRun, 12), ui.Pad(cronStateLabel(job), 12), ui.MutedStyle.Render(job.Note))
+		}
+		if r.Err != nil {
+			fmt.Printf("  %s %s %s\n", ui.Pad("", 16), ui.WarningStyle.Render(ui.IconWarning), ui.MutedStyle.Render("last runs unavailable: "+r.Err.Error()))
+		}
+	}
+	fmt.Println()
+}
+
+type jsonCronJob struct {
+	Name       string     `json:"name"`
+	Schedule   string     `json:"schedule"`
+	Enabled    bool       `json:"enabled"`
+	State      string     `json:"state"`
+	LastRun    *time.Time `json:"last_run,omitempty"`
+	LastStatus string     `json:"last_status,omitempty"`
+	Due        *time.Time `json:"due,omitempty"`
+	Note       string     `json:"note,omitempty"`
+}
+
+type jsonCronResult struct {
+	Service   string        `json:"service"`
+	Platform  string        `json:"platform"`
+	Jobs      []jsonCronJob `json:"jobs"`
+	Error     string        `json:"error,omitempty"`
+	ErrorKind string        `json:"error_kind,omitempty"`
+}
+
+func renderCronJSON(results []cronResult) error {
+	out := make([]jsonCronResult, len(results))
+	for i, r := range results {
+		out[i] = jsonCronResult{Service: r.Entry.Name, Platform: r.Entry.Platform, Jobs: []jsonCronJob{}}
+		if r.Err != nil {
+			out[i].Error = r.Err.Error()
+			out[i].ErrorKind = platform.ErrorKind(r.Err)
+		}
+		for _, job := range r.Jobs {
+			jj := jsonCronJob{Name: job.Name, Schedule: job.Schedule, Enabled: job.Enabled, State: job.State, LastStatus: job.LastStatus, Note: job.Note}
+			if !job.LastRun.IsZero() {
+				lastRun := job.LastRun
+				jj.LastRun = &lastRun
+			}
+			if !job.Due.IsZero() {
+				due := job.Due
+				jj.Due = &due
+			}
+			out[i].Jobs = append(out[i].Jobs, jj)
+		}
+	}
+	return printJSON(out)
+}
+
+// cronAlert is a problem that was notified, keyed by project/service/job
+// in ~/.orbit/cron-alerts.json.
+type cronAlert struct {
+	State string    `json:"state"` // missed or failed
+	At    time.Time `json:"at"`    // the missed run, or the failed one
+}
+
+func cronAlertsPath() string {
+	dir, _ := config.Dir()
+	return filepath.Join(dir, "cron-alerts.json")
+}
+
+func loadCronAlerts() (map[string]cronAlert, error) {
+	alerts := map[string]cronAlert{}
+	data, err := os.ReadFile(cronAlertsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return alerts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cron alerts: %w", err)
+	}
+	if err := json.Unmarshal(data, &alerts); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", cronAlertsPath(), err)
+	}
+	return alerts, nil
+}
+
+func saveCronAlerts(alerts map[string]cronAlert) error {
+	data, err := json.MarshalIndent(alerts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cron alerts: %w", err)
+	}
+	if _, err := config.EnsureDir(); err != nil {
+		return err
+	}
+	return os.WriteFile(cronAlertsPath(), append(data, '\n'), 0600)
+}
+
+// cronEvent builds the notification for a job's problem, or its recovery
+// when state is "ok".
+func cronEvent(projectName string, e config.ServiceEntry, job cronJob, now time.Time) notify.Event {
+	ev := notify.Event{
+		Project: projectName,
+		Service: e.Name,
+		Tags:    e.Tags,
+		Owner:   e.Owner,
+		Time:    now,
+	}
+	switch job.State {
+	case "missed":
+		ev.Type, ev.Severity = "cron_missed", notify.SeverityCritical
+		ev.Title = "scheduled job missed a run"
+		ev.Message = fmt.Sprintf("%s (%s) was due at %s", job.Name, job.Schedule, job.Due.Local().Format("Jan 2 15:04"))
+	case "failed":
+		ev.Type, ev.Severity = "cron_failed", notify.SeverityWarning
+		ev.Title = "scheduled job failed"
+		ev.Message = fmt.Sprintf("%s: %s at %s", job.Name, job.Detail, job.LastRun.Local().Format("Jan 2 15:04"))
+	default:
+		ev.Type, ev.Severity = "cron_recovered", notify.SeverityInfo
+		ev.Title = "scheduled job recovered"
+		ev.Message = job.Name + " ran successfully"
+	}
+	return ev
+}
+
+func runCronCheck(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	var projects []string
+	if len(args) > 0 {
+		if _, err := resolveProject(cfg, args[0]); err != nil {
+			return err
+		}
+		projects = []string{args[0]}
+	} else {
+		for name := range cfg.Projects {
+			projects = append(projects, name)
+		}
+		sort.Strings(projects)
+	}
+	alerts, err := loadCronAlerts()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	problems := 0
+	for _, pn := range projects {
+		proj := cfg.Projects[pn]
+		entries, err := cronEntries(&proj, pn)
+		if err != nil {
+			return err
+		}
+		var jobs int
+		for _, r := range fetchCronJobs(cmd.Context(), cfg, key, entries, now) {
+			if r.Err != nil && !errors.Is(r.Err, errCronUnsupported) {
+				fmt.Fprintf(os.Stderr, "  %s %s/%s: %v\n", ui.WarningStyle.Render(ui.IconWarning), pn, r.Entry.Name, r.Err)
+			}
+			for _, job := range r.Jobs {
+				jobs++
+				id := pn + "/" + r.Entry.Name + "/" + job.Name
+				prev, notified := alerts[id]
+				switch job.State {
+				case "missed", "failed":
+					problems++
+					at := job.Due
+					if job.State == "failed" {
+						at = job.LastRun
+					}
+					fmt.Printf("  %s %s/%s %s %s\n", ui.ErrorStyle.Render(ui.IconError), pn, r.Entry.Name, job.Name, ui.MutedStyle.Render(job.State+", "+job.Note))
+					if notified && prev.State == job.State && prev.At.Equal(at) {
+						continue
+					}
+					alerts[id] = cronAlert{State: job.State, At: at}
+				case "ok":
+					if !notified {
+						continue
+					}
+					delete(alerts, id)
+				default:
+					continue
+				}
+				ev := cronEvent(pn, r.Entry, job, now)
+				recordEvents(ev)
+				if len(cfg.Notifications.Channels) > 0 {
+					printNotifyErrors(notify.Notify(cfg.Notifications, ev))
+				}
+			}
+		}
+		if jobs > 0 {
+			fmt.Printf("  %s %s: %d scheduled jobs checked\n", ui.MutedStyle.Render("-"), pn, jobs)
+		}
+	}
+	if err := saveCronAlerts(alerts); err != nil {
+		return err
+	}
+
+	if problems > 0 {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: exitFailed, Msg: ""}
+	}
+	return nil
+}
@@ -3,6 +3,8 @@
 package cmd
 
 import (
+	"errors"
+	"os"
 	"os/exec"
 	"syscall"
 )
@@ -10,3 +12,18 @@ import (
 func setSysProcAttr(cmd *exec.Cmd) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
 }
+
+// stopProcess asks a detached daemon to shut down.
+func stopProcess(proc *os.Process) error {
+	return proc.Signal(syscall.SIGTERM)
+}
+
+// processAlive reports whether a process with pid is running.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dashboardInterval time.Duration
+	dashboardView     string
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard [project]",
+	Short: "Full-screen live view of a project's services",
+	Long: `Open a full-screen dashboard that refreshes service statuses, and shows
+the selected service's recent deploys and latest logs:
+
+  orbit dashboard
+  orbit dashboard myshop --interval 30s
+  orbit dashboard myshop --view user-facing
+
+Keys: ↑/↓ (or j/k) select a service, r refreshes now, q quits.
+The dashboard always fetches live data instead of cached responses.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDashboard,
+}
+
+func init() {
+	dashboardCmd.Flags().DurationVar(&dashboardInterval, "interval", 15*time.Second, "How often to refresh statuses")
+	dashboardCmd.Flags().StringVar(&dashboardView, "view", "", "Only show the services in this topology view")
+	rootCmd.AddCommand(dashboardCmd)
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	if dashboardInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	proj, err := resolveProject(cfg, name)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = cfg.DefaultProject
+	}
+	entries := proj.Topology
+	if dashboardView != "" {
+		if entries, err = proj.ViewEntries(dashboardView); err != nil {
+			return err
+		}
+	}
+
+	// A live view should never show a response cached by an earlier
+	// refresh; responses are still stored for other commands.
+	noCache = true
+
+	src := ui.DashboardSource{
+		Statuses: func(ctx context.Context) []ui.ServiceResult {
+			results := fetchStatuses(ctx, entries, cfg, key)
+			applyScriptHealth(name, results)
+			recordStatuses(name, results)
+			return results
+		},
+		Deploys: func(ctx context.Context, e config.ServiceEntry, limit int) ([]platform.Deployment, error) {
+			p, err := entryPlatform(cfg, key, e)
+			if err != nil {
+				return nil, err
+			}
+			return p.ListDeployments(ctx, e.ID, limit)
+		},
+		Logs: func(ctx context.Context, e config.ServiceEntry, tail int) ([]platform.LogEntry, error) {
+			p, err := entryPlatform(cfg, key, e)
+			if err != nil {
+				return nil, err
+			}
+			return p.GetLogs(ctx, e.ID, platform.LogOptions{Tail: tail})
+		},
+	}
+
+	p := tea.NewProgram(ui.NewDashboardModel(cmd.Context(), name, src, dashboardInterval), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("dashboard error: %w", err)
+	}
+	return nil
+}
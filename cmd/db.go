@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Connection pool and storage use above these percentages is flagged.
+const (
+	dbWarnPercent     = 80
+	dbCriticalPercent = 95
+)
+
+var (
+	dbService string
+	dbFormat  string
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db [project]",
+	Short: "Show database health: connections, storage, branches, migrations",
+	Long: `Show the database services of a project with what the status table
+can't: connection pool saturation, storage against the plan limit, branches
+and the state of schema migrations.
+
+  orbit db myshop
+  orbit db myshop --service db --format json
+
+Supported on Supabase (connections, storage and applied migrations through
+the Management API's SQL endpoint; branches when branching is on), Neon
+(storage per branch limit, branches with their compute state) and
+PlanetScale (branches, open deploy requests). Connection pool and storage
+use over 80% is flagged, over 95% shown as critical. Other services in the
+project are left out.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDB,
+}
+
+func init() {
+	dbCmd.Flags().StringVar(&dbService, "service", "", "Show a specific database service")
+	dbCmd.Flags().StringVar(&dbFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(dbCmd)
+}
+
+var errNotDatabase = errors.New("not a database platform")
+
+type dbResult struct {
+	Entry config.ServiceEntry
+	Info  *platform.DatabaseInfo
+	Err   error
+}
+
+func runDB(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+
+	entries := proj.Topology
+	if dbService != "" {
+		entries = nil
+		for _, e := range proj.Topology {
+			if e.Name == dbService {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("service %q not found in project %q", dbService, projectName)
+		}
+	}
+
+	var results []dbResult
+	for _, r := range fetchDatabaseInfo(cmd.Context(), cfg, key, entries) {
+		if !errors.Is(r.Err, errNotDatabase) {
+			results = append(results, r)
+		}
+	}
+	if len(results) == 0 {
+		if dbService != "" {
+			return fmt.Errorf("service %q is not a database\nSupported on: supabase, neon, planetscale", dbService)
+		}
+		return fmt.Errorf("no database services in project %q\nSupported on: supabase, neon, planetscale", projectName)
+	}
+
+	if dbFormat == "json" {
+		return renderDBJSON(results)
+	}
+	renderDB(projectName, results)
+	return nil
+}
+
+// fetchDatabaseInfo queries each database service concurrently; services
+// on other platforms get errNotDatabase.
+func fetchDatabaseInfo(ctx context.Context, cfg *config.Config, key []byte, entries []config.ServiceEntry) []dbResult {
+	results := make([]dbResult, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		results[i].Entry = e
+		wg.Add(1)
+		go func(r *dbResult) {
+			defer wg.Done()
+			p, err := entryPlatform(cfg, key, r.Entry)
+			if err != nil {
+				r.Err = err
+				return
+			}
+			inspector, ok := p.(platform.DatabaseInspector)
+			if !ok {
+				r.Err = errNotDatabase
+				return
+			}
+			r.Info, r.Err = inspector.DatabaseInfo(ctx, r.Entry.ID)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// usageStyle renders s in the style its percentage of a limit calls for.
+func usageStyle(pct float64, s string) string {
+	switch {
+	case pct >= dbCriticalPercent:
+		return ui.ErrorStyle.Render(s)
+	case pct >= dbWarnPercent:
+		return ui.WarningStyle.Render(s)
+	}
+	return s
+}
+
+func renderDB(projectName string, results []dbResult) {
+	fmt.Printf("\n  %s %s\n", ui.ProjectTitleStyle.Render(projectName), ui.MutedStyle.Render("databases"))
+	for _, r := range results {
+		fmt.Printf("\n  %s %s", ui.HealthyStyle.Render(r.Entry.Name), ui.MutedStyle.Render("("+r.Entry.Platform+")"))
+		if r.Err != nil {
+			fmt.Printf("  %s\n", ui.ErrorStyle.Render(ui.IconError+" "+r.Err.Error()))
+			continue
+		}
+		info := r.Info
+		fmt.Printf("  %s\n", ui.MutedStyle.Render(joinNonEmpty(" · ", info.Engine, info.Region)))
+
+		label := func(s string) string { return "    " + ui.Pad(ui.MutedStyle.Render(s), 12) + " " }
+		if pct := info.PoolUsage(); pct >= 0 {
+			fmt.Printf("%s%s %s\n", label("Connections"), usageStyle(pct, ui.ProgressBar(pct/100, 20)),
+				usageStyle(pct, fmt.Sprintf("%d/%d (%.0f%%)", info.Connections, info.MaxConnections, pct)))
+		} else {
+			fmt.Printf("%s%s\n", label("Connections"), ui.MutedStyle.Render("not reported"))
+		}
+		switch pct := info.StorageUsage(); {
+		case pct >= 0:
+			fmt.Printf("%s%s %s\n", label("Storage"), usageStyle(pct, ui.ProgressBar(pct/100, 20)),
+				usageStyle(pct, fmt.Sprintf("%s of %s (%.0f%%)", ui.FormatBytes(info.StorageBytes), ui.FormatBytes(info.StorageLimit), pct)))
+		case info.StorageBytes > 0:
+			fmt.Printf("%s%s\n", label("Storage"), ui.FormatBytes(info.StorageBytes))
+		default:
+			fmt.Printf("%s%s\n", label("Storage"), ui.MutedStyle.Render("not reported"))
+		}
+
+		for i, b := range info.Branches {
+			name := b.Name
+			if b.Primary {
+				name += " (primary)"
+			}
+			if b.Parent != "" {
+				name += ui.MutedStyle.Render(" from " + b.Parent)
+			}
+			heading := label("")
+			if i == 0 {
+				heading = label("Branches")
+			}
+			fmt.Printf("%s%s %s\n", heading, ui.Pad(ui.FormatStatus(b.State), 12), name)
+		}
+
+		if len(info.Migrations) == 0 {
+			fmt.Printf("%s%s\n", label("Migrations"), ui.MutedStyle.Render("none"))
+		}
+		for i, m := range info.Migrations {
+			heading := label("")
+			if i == 0 {
+				heading = label("Migrations")
+			}
+			when := ""
+			if !m.CreatedAt.IsZero() {
+				when = ui.MutedStyle.Render(ui.TimeAgo(m.CreatedAt))
+			}
+			status := m.Status
+			if status != "applied" && status != "complete" {
+				status = ui.WarningStyle.Render(status)
+			}
+			fmt.Printf("%s%s %s %s\n", heading, ui.Pad(status, 12), ui.Truncate(m.Name, 48), when)
+		}
+	}
+	fmt.Println()
+}
+
+// joinNonEmpty joins the non-empty parts with sep.
+func joinNonEmpty(sep string, parts ...string) string {
+	out := ""
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		if out != "" {
+			out += sep
+		}
+		out += p
+	}
+	return out
+}
+
+type jsonDBBranch struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Primary bool   `json:"primary,omitempty"`
+	Parent  string `json:"parent,omitempty"`
+}
+
+type jsonDBMigration struct {
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	Branch    string     `json:"branch,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type jsonDBResult struct {
+	Service        string            `json:"service"`
+	Platform       string            `json:"platform"`
+	Engine         string            `json:"engine,omitempty"`
+	Region         string            `json:"region,omitempty"`
+	Connections    *int              `json:"connections,omitempty"`
+	MaxConnections int               `json:"max_connections,omitempty"`
+	PoolPercent    *float64          `json:"pool_percent,omitempty"`
+	StorageBytes   int64             `json:"storage_bytes,omitempty"`
+	StorageLimit   int64             `json:"storage_limit_bytes,omitempty"`
+	StoragePercent *float64          `json:"storage_percent,omitempty"`
+	Branches       []jsonDBBranch    `json:"branches"`
+	Migrations     []jsonDBMigration `json:"migrations"`
+	Error          string            `json:"error,omitempty"`
+	ErrorKind      string            `json:"error_kind,omitempty"`
+}
+
+func renderDBJSON(results []dbResult) error {
+	out := make([]jsonDBResult, len(results))
+	for i, r := range results {
+		out[i] = jsonDBResult{Service: r.Entry.Name, Platform: r.Entry.Platform, Branches: []jsonDBBranch{}, Migrations: []jsonDBMigration{}}
+		if r.Err != nil {
+			out[i].Error = r.Err.Error()
+			out[i].ErrorKind = platform.ErrorKind(r.Err)
+			continue
+		}
+		info := r.Info
+		out[i].Engine, out[i].Region = info.Engine, info.Region
+		out[i].MaxConnections, out[i].StorageBytes, out[i].StorageLimit = info.MaxConnections, info.StorageBytes, info.StorageLimit
+		if info.Connections >= 0 {
+			out[i].Connections = &info.Connections
+		}
+		if pct := info.PoolUsage(); pct >= 0 {
+			out[i].PoolPercent = &pct
+		}
+		if pct := info.StorageUsage(); pct >= 0 {
+			out[i].StoragePercent = &pct
+		}
+		for _, b := range info.Branches {
+			out[i].Branches = append(out[i].Branches, jsonDBBranch{Name: b.Name, State: b.State, Primary: b.Primary, Parent: b.Parent})
+		}
+		for _, m := range info.Migrations {
+			jm := jsonDBMigration{Name: m.Name, Status: m.Status, Branch: m.Branch}
+			if !m.CreatedAt.IsZero() {
+				created := m.CreatedAt
+				jm.CreatedAt = &created
+			}
+			out[i].Migrations = append(out[i].Migrations, jm)
+		}
+	}
+	return printJSON(out)
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/demo"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// envDemo marks the processes of an orbit demo session, which may use the
+// demo platform.
+const envDemo = "ORBIT_DEMO"
+
+var (
+	demoDuration time.Duration
+	demoPort     int
+)
+
+var demoCmd = &cobra.Command{
+	Use:   "demo [-- command [args...]]",
+	Short: "Try Orbit on synthetic projects, without a platform account",
+	Long: `Start a demo platform with two synthetic projects — shop (web, api, worker)
+and blog (site) — with deploy histories, live metrics and log streams, and
+open a shell where every orbit command uses them. A new deployment of
+shop/api starts every 90 seconds, so orbit watch has something to follow.
+
+The demo uses a throwaway config ($ORBIT_HOME points to it); your own config
+and tokens are not touched. The session ends when the shell exits or after
+--duration, and the config is deleted.
+
+With a command after --, run it in the session instead of a shell, e.g.
+for screenshots or to test tooling built on orbit in CI:
+
+  orbit demo
+  orbit demo --duration 10m
+  orbit demo -- orbit status shop
+  orbit demo -- ./scripts/check-dashboards.sh`,
+	RunE: runDemo,
+}
+
+func init() {
+	demoCmd.Flags().DurationVar(&demoDuration, "duration", 30*time.Minute, "End the session after this long")
+	demoCmd.Flags().IntVar(&demoPort, "port", 0, "Port for the demo platform API (default: any free port)")
+	rootCmd.AddCommand(demoCmd)
+
+	if os.Getenv(envDemo) != "" {
+		platform.RegisterDemo()
+	}
+}
+
+func runDemo(cmd *cobra.Command, args []string) error {
+	if os.Getenv(envDemo) != "" {
+		return fmt.Errorf("already in a demo session; exit its shell first")
+	}
+	cmd.SilenceUsage = true
+
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", demoPort))
+	if err != nil {
+		return fmt.Errorf("start demo platform: %w", err)
+	}
+	srv := &http.Server{Handler: demo.NewServer(time.Now())}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	dir, err := os.MkdirTemp("", "orbit-demo-")
+	if err != nil {
+		return fmt.Errorf("create demo config: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := writeDemoConfig(dir, "http://"+ln.Addr().String()); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), demoDuration)
+	defer cancel()
+	env := append(os.Environ(), config.EnvDir+"="+dir, envDemo+"=1")
+
+	var c *exec.Cmd
+	if len(args) > 0 {
+		name := args[0]
+		if name == "orbit" {
+			if self, err := os.Executable(); err == nil {
+				name = self // this orbit, not another one on PATH
+			}
+		}
+		c = exec.CommandContext(ctx, name, args[1:]...)
+	} else {
+		c = exec.CommandContext(ctx, demoShell())
+		printDemoBanner()
+	}
+	c.Env = env
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	// The shell handles Ctrl+C itself; it must not end the session.
+	passInterrupts.Store(true)
+	defer passInterrupts.Store(false)
+	err = c.Run()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		fmt.Fprintf(os.Stderr, "\n%s Demo session ended after %s\n", ui.IconTimeout, demoDuration)
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: exitTimeout, Msg: ""}
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if len(args) == 0 {
+			return nil // the shell's last command failed; the session is over all the same
+		}
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: exitErr.ExitCode(), Msg: ""}
+	}
+	if err != nil {
+		return fmt.Errorf("run %s: %w", c.Path, err)
+	}
+	if len(args) == 0 {
+		fmt.Println("Demo session ended.")
+	}
+	return nil
+}
+
+// writeDemoConfig writes a config connecting the demo platform at url and
+// listing its projects into dir.
+func writeDemoConfig(dir, url string) error {
+	// Save and the key go wherever Dir points.
+	prev, had := os.LookupEnv(config.EnvDir)
+	os.Setenv(config.EnvDir, dir)
+	defer func() {
+		if had {
+			os.Setenv(config.EnvDir, prev)
+		} else {
+			os.Unsetenv(config.EnvDir)
+		}
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("create demo config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("create demo config: %w", err)
+	}
+	token, err := config.Encrypt(key, demo.Token)
+	if err != nil {
+		return fmt.Errorf("create demo config: %w", err)
+	}
+	cfg.Platforms[platform.DemoPlatform] = config.PlatformConfig{Token: token, Endpoint: url}
+	for _, svc := range demo.Services {
+		proj := cfg.Projects[svc.Project]
+		proj.Topology = append(proj.Topology, config.ServiceEntry{
+			Name:     svc.Name,
+			Platform: platform.DemoPlatform,
+			ID:       svc.ID,
+			URL:      svc.URL,
+		})
+		cfg.Projects[svc.Project] = proj
+	}
+	cfg.DefaultProject = "shop"
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("create demo config: %w", err)
+	}
+	return nil
+}
+
+// demoShell returns the user's shell.
+func demoShell() string {
+	if runtime.GOOS == "windows" {
+		if sh := os.Getenv("COMSPEC"); sh != "" {
+			return sh
+		}
+		return "cmd.exe"
+	}
+	if sh := os.Getenv("SHELL"); sh != "" {
+		return sh
+	}
+	return "/bin/sh"
+}
+
+func printDemoBanner() {
+	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render("Orbit demo"), ui.MutedStyle.Render(fmt.Sprintf("ends in %s", demoDuration)))
+	fmt.Println("  Projects shop (web, api, worker) and blog (site) run on a synthetic platform.")
+	fmt.Println("  Try:")
+	for _, c := range []string{
+		"orbit status",
+		"orbit deploys shop --service api",
+		"orbit logs shop --service api -f",
+		"orbit watch shop --service api      # a new deploy starts every 90s",
+		"orbit redeploy shop --service web",
+	} {
+		fmt.Printf("    %s\n", c)
+	}
+	fmt.Printf("\n  %s\n\n", ui.MutedStyle.Render("Type exit to end the session."))
+}
@@ -1,32 +1,51 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/incident"
+	"github.com/humanetools/orbit/internal/platform"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	deployID      string
-	deployService string
-	deployFormat  string
+	deployID       string
+	deployService  string
+	deployFormat   string
+	deployPrevious int
+	deployLogs     bool
+	deployTail     int
 )
 
 var deployCmd = &cobra.Command{
 	Use:   "deploy <project>",
 	Short: "Show details of a specific deployment",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDeploy,
+	Long: `Show details of a specific deployment. Without --id, shows the latest one.
+
+  orbit deploy myshop --service api                 # latest deployment
+  orbit deploy myshop --service api --previous 1    # the one before it
+  orbit deploy myshop --service api --id 3f2a9c1e-...
+  orbit deploy myshop --service api --id 3f2a       # any unique prefix of a recent deploy
+  orbit deploy myshop --service api --logs          # with the build log tail
+
+With --logs, failed deployments show their error lines instead of the tail.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeploy,
 }
 
 func init() {
-	deployCmd.Flags().StringVar(&deployID, "id", "", "Deployment ID (required)")
+	deployCmd.Flags().StringVar(&deployID, "id", "", "Deployment ID or a unique prefix (default: latest)")
+	deployCmd.Flags().IntVar(&deployPrevious, "previous", 0, "Show the Nth deployment before the latest")
 	deployCmd.Flags().StringVar(&deployService, "service", "", "Service name (required)")
+	deployCmd.Flags().BoolVar(&deployLogs, "logs", false, "Append the build log tail (errors only for failed deploys)")
+	deployCmd.Flags().IntVar(&deployTail, "tail", 20, "Number of log lines shown with --logs")
 	deployCmd.Flags().StringVar(&deployFormat, "format", "", "Output format (json)")
-	deployCmd.MarkFlagRequired("id")
 	deployCmd.MarkFlagRequired("service")
 	rootCmd.AddCommand(deployCmd)
 }
@@ -42,18 +61,65 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("load encryption key: %w", err)
 	}
 
+	if deployID != "" && deployPrevious > 0 {
+		return fmt.Errorf("--id and --previous cannot be used together")
+	}
+	if deployPrevious < 0 {
+		return fmt.Errorf("--previous must be 0 or more")
+	}
+
 	resolved, err := resolveService(cfg, key, args[0], deployService)
 	if err != nil {
 		return err
 	}
+	if err := requireCapability(resolved, platform.CapDeployments); err != nil {
+		return err
+	}
 
-	deploy, err := resolved.Platform.GetDeployment(deployID)
+	id := deployID
+	var until time.Time // when the deployment was replaced, if known
+	if id == "" {
+		deploys, err := resolved.Platform.ListDeployments(cmd.Context(), resolved.Entry.ID, deployPrevious+1)
+		if err != nil {
+			return fmt.Errorf("list deployments: %w", err)
+		}
+		if len(deploys) <= deployPrevious {
+			if len(deploys) == 0 {
+				return fmt.Errorf("no deployments found for %s", deployService)
+			}
+			return fmt.Errorf("only %d deployments found for %s", len(deploys), deployService)
+		}
+		id = deploys[deployPrevious].ID
+		until = nextDeployTime(deploys, deployPrevious)
+	} else {
+		id, err = resolveDeployID(cmd.Context(), resolved, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	deploy, err := resolved.Platform.GetDeployment(cmd.Context(), id)
 	if err != nil {
 		return fmt.Errorf("get deployment: %w", err)
 	}
 
+	var incidents []incident.Incident
+	if !deploy.CreatedAt.IsZero() {
+		incidents = incident.After(loadIncidents().ForService(args[0], deployService), deploy.CreatedAt, until)
+	}
+
+	var logs []platform.LogEntry
+	logsErr := errDeployLogsUnsupported
+	if deployLogs {
+		logs, logsErr = fetchDeployLogs(cmd.Context(), resolved, deploy, deployTail)
+	}
+
 	if deployFormat == "json" {
-		data, err := json.MarshalIndent(deploy, "", "  ")
+		data, err := json.MarshalIndent(struct {
+			*platform.Deployment
+			Incidents []incident.Incident `json:"Incidents,omitempty"`
+			Logs      []platform.LogEntry `json:"Logs,omitempty"`
+		}{deploy, incidents, logs}, "", "  ")
 		if err != nil {
 			return err
 		}
@@ -71,13 +137,88 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	if deploy.Message != "" {
 		fmt.Printf("  Message:    %s\n", deploy.Message)
 	}
+	if deploy.Branch != "" || deploy.PullRequest != 0 {
+		fmt.Printf("  Branch:     %s\n", ui.FormatBranch(deploy.Branch, deploy.PullRequest))
+	}
+	if deploy.Author != "" {
+		fmt.Printf("  Author:     %s\n", deploy.Author)
+	}
 	if !deploy.CreatedAt.IsZero() {
 		fmt.Printf("  Created:    %s (%s)\n", deploy.CreatedAt.Format("2006-01-02 15:04:05"), ui.TimeAgo(deploy.CreatedAt))
 	}
 	if deploy.URL != "" {
 		fmt.Printf("  URL:        %s\n", deploy.URL)
 	}
+	if notes := incidentNotes(incidents, deploy.ID, deploy.CreatedAt, until); len(notes) > 0 {
+		fmt.Println()
+		for _, note := range notes {
+			fmt.Printf("  %s %s\n", ui.IconWarning, ui.WarningStyle.Render(note))
+		}
+	}
 	fmt.Println()
 
+	if deployLogs {
+		printDeployLogs(resolved, deploy, logs, logsErr)
+	}
+
 	return nil
 }
+
+// deployLogScan is how many lines are searched for errors in a failed deploy.
+const deployLogScan = 500
+
+var errDeployLogsUnsupported = errors.New("build logs not supported")
+
+// fetchDeployLogs returns the last tail build log lines of deploy, or for a
+// failed deploy its last tail error lines, falling back to the plain tail
+// when none were flagged as errors.
+func fetchDeployLogs(ctx context.Context, resolved *resolvedService, deploy *platform.Deployment, tail int) ([]platform.LogEntry, error) {
+	logger, ok := resolved.Platform.(platform.DeploymentLogger)
+	if !ok {
+		return nil, errDeployLogsUnsupported
+	}
+	if deploy.Status != "failed" {
+		return logger.DeploymentLogs(ctx, deploy.ID, tail)
+	}
+
+	entries, err := logger.DeploymentLogs(ctx, deploy.ID, deployLogScan)
+	if err != nil {
+		return nil, err
+	}
+	var errs []platform.LogEntry
+	for _, e := range entries {
+		if platform.MatchesLevel(entryLevel(e), "error") {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) > 0 {
+		entries = errs
+	}
+	if tail > 0 && len(entries) > tail {
+		entries = entries[len(entries)-tail:]
+	}
+	return entries, nil
+}
+
+func printDeployLogs(resolved *resolvedService, deploy *platform.Deployment, logs []platform.LogEntry, err error) {
+	title := "Build log"
+	if deploy.Status == "failed" {
+		title = "Errors"
+	}
+	fmt.Println("  " + ui.ProjectTitleStyle.Render(title))
+
+	switch {
+	case errors.Is(err, errDeployLogsUnsupported):
+		fmt.Println(ui.MutedStyle.Render(fmt.Sprintf("  Build logs are not available for %s", resolved.Entry.Platform)))
+	case err != nil:
+		fmt.Printf("  %s could not fetch logs: %v\n", ui.IconWarning, err)
+	case len(logs) == 0:
+		fmt.Println(ui.MutedStyle.Render("  No log output"))
+	default:
+		for _, e := range logs {
+			fmt.Print("  ")
+			printLogEntry(e)
+		}
+	}
+	fmt.Println()
+}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/humanetools/orbit/internal/cache"
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/incident"
 	"github.com/humanetools/orbit/internal/platform"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
@@ -85,7 +89,19 @@ func runDeploys(cmd *cobra.Command, args []string) error {
 		entries = filtered
 	}
 
-	// Fetch deployments concurrently
+	results := fetchDeploys(cmd.Context(), cfg, key, entries, deploysLimit)
+	incidents := loadIncidents()
+
+	if deploysFormat == "json" {
+		return renderDeploysJSON(projectName, results, incidents)
+	}
+
+	return renderDeploysTable(projectName, results, incidents)
+}
+
+// fetchDeploys lists the latest deployments of each service concurrently,
+// serving recent listings from the response cache.
+func fetchDeploys(ctx context.Context, cfg *config.Config, key []byte, entries []config.ServiceEntry, limit int) []deployResult {
 	results := make([]deployResult, len(entries))
 	var wg sync.WaitGroup
 	for i, entry := range entries {
@@ -103,31 +119,42 @@ func runDeploys(cmd *cobra.Command, args []string) error {
 				results[idx].Err = fmt.Errorf("decrypt token: %w", err)
 				return
 			}
-			p, err := platform.Get(e.Platform, token)
+			p, err := newPlatform(e.Platform, pc, token)
 			if err != nil {
 				results[idx].Err = err
 				return
 			}
-			if pc.TeamID != "" {
-				if tc, ok := p.(platform.TeamConfigurable); ok {
-					tc.SetTeamID(pc.TeamID)
-				}
+			if !p.Capabilities().Has(platform.CapDeployments) {
+				results[idx].Err = fmt.Errorf("%s does not support deployment history", e.Platform)
+				return
+			}
+			rc := responseCache()
+			ck := serviceCacheKey("deploys", e, pc, token, limit)
+			if rc.Get(ck, cache.DeploysTTL, &results[idx].Deployments) {
+				return
+			}
+			deploys, err := p.ListDeployments(ctx, e.ID, limit)
+			if err == nil {
+				rc.Put(ck, deploys)
 			}
-			deploys, err := p.ListDeployments(e.ID, deploysLimit)
 			results[idx].Deployments = deploys
 			results[idx].Err = err
 		}(i, entry)
 	}
 	wg.Wait()
+	return results
+}
 
-	if deploysFormat == "json" {
-		return renderDeploysJSON(projectName, results)
+// nextDeployTime returns when the deployment after deploys[i] was made, or
+// the zero time for the newest one. deploys is ordered newest first.
+func nextDeployTime(deploys []platform.Deployment, i int) time.Time {
+	if i == 0 {
+		return time.Time{}
 	}
-
-	return renderDeploysTable(projectName, results)
+	return deploys[i-1].CreatedAt
 }
 
-func renderDeploysTable(projectName string, results []deployResult) error {
+func renderDeploysTable(projectName string, results []deployResult, incidents *incident.Log) error {
 	for i, r := range results {
 		if i > 0 {
 			fmt.Println()
@@ -147,15 +174,17 @@ func renderDeploysTable(projectName string, results []deployResult) error {
 		}
 
 		// Header
-		fmt.Printf("  %-14s %-12s %-12s %-9s %s\n",
-			ui.HeaderStyle.Render("Status"),
-			ui.HeaderStyle.Render("Deployed"),
-			ui.HeaderStyle.Render("Duration"),
-			ui.HeaderStyle.Render("Commit"),
+		fmt.Printf("  %s %s %s %s %s %s\n",
+			ui.Pad(ui.HeaderStyle.Render("Status"), 14),
+			ui.Pad(ui.HeaderStyle.Render("Deployed"), 12),
+			ui.Pad(ui.HeaderStyle.Render("Duration"), 12),
+			ui.Pad(ui.HeaderStyle.Render("Commit"), 9),
+			ui.Pad(ui.HeaderStyle.Render("Branch"), 20),
 			ui.HeaderStyle.Render("Message"),
 		)
 
-		for _, d := range r.Deployments {
+		serviceIncidents := incidents.ForService(projectName, r.Entry.Name)
+		for j, d := range r.Deployments {
 			status := ui.FormatStatus(d.Status)
 			when := ui.TimeAgo(d.CreatedAt)
 			dur := ui.Dash
@@ -163,16 +192,21 @@ func renderDeploysTable(projectName string, results []deployResult) error {
 				dur = d.Duration.Truncate(1e9).String()
 			}
 			commit := ui.FormatCommit(d.Commit)
-			msg := d.Message
-			if len(msg) > 40 {
-				msg = msg[:37] + "..."
-			}
+			branch := ui.Truncate(ui.FormatBranch(d.Branch, d.PullRequest), 20)
+			msg := ui.Truncate(d.Message, 40)
 			if msg == "" {
 				msg = ui.Dash
 			}
+			if d.Author != "" {
+				msg += " — " + d.Author
+			}
 
-			fmt.Printf("  %-14s %-12s %-12s %-9s %s\n",
-				status, when, dur, commit, ui.MutedStyle.Render(msg))
+			fmt.Printf("  %s %s %s %s %s %s\n",
+				ui.Pad(status, 14), ui.Pad(when, 12), ui.Pad(dur, 12), ui.Pad(commit, 9),
+				ui.Pad(branch, 20), ui.MutedStyle.Render(msg))
+			for _, note := range incidentNotes(serviceIncidents, d.ID, d.CreatedAt, nextDeployTime(r.Deployments, j)) {
+				fmt.Printf("    %s %s\n", ui.IconWarning, ui.WarningStyle.Render(note))
+			}
 		}
 	}
 	fmt.Println()
@@ -184,9 +218,13 @@ type jsonDeployEntry struct {
 	Status    string `json:"status"`
 	Commit    string `json:"commit,omitempty"`
 	Message   string `json:"message,omitempty"`
+	Branch    string `json:"branch,omitempty"`
+	Author    string `json:"author,omitempty"`
+	PR        int    `json:"pull_request,omitempty"`
 	CreatedAt string `json:"created_at,omitempty"`
 	Duration  string `json:"duration,omitempty"`
 	URL       string `json:"url,omitempty"`
+	Incidents []int  `json:"incidents,omitempty"` // IDs of incidents that started while it was live
 }
 
 type jsonDeployResult struct {
@@ -194,9 +232,19 @@ type jsonDeployResult struct {
 	Platform    string            `json:"platform"`
 	Deployments []jsonDeployEntry `json:"deployments,omitempty"`
 	Error       string            `json:"error,omitempty"`
+	ErrorKind   string            `json:"error_kind,omitempty"`
+}
+
+func renderDeploysJSON(projectName string, results []deployResult, incidents *incident.Log) error {
+	data, err := json.MarshalIndent(toJSONDeploys(projectName, results, incidents), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
 }
 
-func renderDeploysJSON(projectName string, results []deployResult) error {
+func toJSONDeploys(projectName string, results []deployResult, incidents *incident.Log) []jsonDeployResult {
 	out := make([]jsonDeployResult, len(results))
 	for i, r := range results {
 		out[i] = jsonDeployResult{
@@ -205,14 +253,20 @@ func renderDeploysJSON(projectName string, results []deployResult) error {
 		}
 		if r.Err != nil {
 			out[i].Error = r.Err.Error()
+			out[i].ErrorKind = platform.ErrorKind(r.Err)
 			continue
 		}
-		for _, d := range r.Deployments {
+		serviceIncidents := incidents.ForService(projectName, r.Entry.Name)
+		for j, d := range r.Deployments {
 			entry := jsonDeployEntry{
-				ID:     d.ID,
-				Status: d.Status,
-				Commit: d.Commit,
-				URL:    d.URL,
+				ID:        d.ID,
+				Status:    d.Status,
+				Commit:    d.Commit,
+				Branch:    d.Branch,
+				Author:    d.Author,
+				PR:        d.PullRequest,
+				URL:       d.URL,
+				Incidents: incidentIDs(serviceIncidents, d.CreatedAt, nextDeployTime(r.Deployments, j)),
 			}
 			if d.Message != "" {
 				entry.Message = d.Message
@@ -226,11 +280,5 @@ func renderDeploysJSON(projectName string, results []deployResult) error {
 			out[i].Deployments = append(out[i].Deployments, entry)
 		}
 	}
-
-	data, err := json.MarshalIndent(out, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal JSON: %w", err)
-	}
-	fmt.Println(string(data))
-	return nil
+	return out
 }
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffService string
+	diffFrom    string
+	diffTo      string
+	diffRepo    string
+	diffFormat  string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <project>",
+	Short: "Show what changed between two deployments",
+	Long: `Compare two deployments of a service: the commits between them, and on
+platforms that keep each deployment's configuration (Koyeb), environment
+variables, scaling and instance type.
+
+Without --to, the latest deployment is compared; without --from, the one
+before --to. IDs may be unique prefixes, as shown by orbit deploys.
+
+Commits are listed with git log when the service has a local checkout
+configured (orbit service add --repo, or repo: in the service's config) or
+--repo is given; otherwise only the two commits are shown.
+
+  orbit diff myshop --service api
+  orbit diff myshop --service api --from 3f2a --to 9c1e
+  orbit diff myshop --service api --repo ~/src/api`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffService, "service", "", "Service name (required)")
+	diffCmd.Flags().StringVar(&diffFrom, "from", "", "Older deployment ID or prefix (default: the one before --to)")
+	diffCmd.Flags().StringVar(&diffTo, "to", "", "Newer deployment ID or prefix (default: latest)")
+	diffCmd.Flags().StringVar(&diffRepo, "repo", "", "Local git checkout to list commits from (default: the service's repo setting)")
+	diffCmd.Flags().StringVar(&diffFormat, "format", "", "Output format (json)")
+	diffCmd.MarkFlagRequired("service")
+	rootCmd.AddCommand(diffCmd)
+}
+
+// deployDiff is what changed between two deployments.
+type deployDiff struct {
+	From    *platform.Deployment    `json:"from"`
+	To      *platform.Deployment    `json:"to"`
+	Commits []string                `json:"commits,omitempty"` // git log --oneline, newest first
+	Changes []platform.ConfigChange `json:"changes"`
+	// Compared is false when the platform can't report the deployments'
+	// configuration, so an empty Changes means nothing.
+	Compared bool     `json:"config_compared"`
+	Notes    []string `json:"notes,omitempty"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+	resolved, err := resolveService(cfg, key, args[0], diffService)
+	if err != nil {
+		return err
+	}
+	if err := requireCapability(resolved, platform.CapDeployments); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+	ctx := cmd.Context()
+
+	from, to, err := diffDeployIDs(ctx, resolved)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("--from and --to are the same deployment")
+	}
+
+	d := deployDiff{Changes: []platform.ConfigChange{}}
+	if d.From, err = resolved.Platform.GetDeployment(ctx, from); err != nil {
+		return fmt.Errorf("get deployment %s: %w", shortID(from), err)
+	}
+	if d.To, err = resolved.Platform.GetDeployment(ctx, to); err != nil {
+		return fmt.Errorf("get deployment %s: %w", shortID(to), err)
+	}
+
+	repo := diffRepo
+	if repo == "" {
+		repo = resolved.Entry.Repo
+	}
+	switch {
+	case d.From.Commit == "" || d.To.Commit == "":
+		d.Notes = append(d.Notes, fmt.Sprintf("%s doesn't report the commits of both deployments", resolved.Entry.Platform))
+	case d.From.Commit == d.To.Commit:
+		d.Notes = append(d.Notes, "both deployments run the same commit")
+	case repo == "":
+		d.Notes = append(d.Notes, "set a local checkout to list the commits between them (--repo, or repo: on the service)")
+	default:
+		d.Commits, err = gitLog(ctx, repo, d.From.Commit, d.To.Commit)
+		switch {
+		case err != nil:
+			d.Notes = append(d.Notes, err.Error())
+		case len(d.Commits) == 0:
+			d.Notes = append(d.Notes, fmt.Sprintf("no commits in %s..%s; --to may be older than --from", shortID(d.From.Commit), shortID(d.To.Commit)))
+		}
+	}
+
+	if dcp, ok := resolved.Platform.(platform.DeploymentConfigProvider); !ok {
+		d.Notes = append(d.Notes, fmt.Sprintf("%s doesn't keep each deployment's configuration, so env and scaling aren't compared", resolved.Entry.Platform))
+	} else {
+		fromCfg, err := dcp.DeploymentConfig(ctx, from)
+		if err != nil {
+			return fmt.Errorf("get configuration of %s: %w", shortID(from), err)
+		}
+		toCfg, err := dcp.DeploymentConfig(ctx, to)
+		if err != nil {
+			return fmt.Errorf("get configuration of %s: %w", shortID(to), err)
+		}
+		d.Changes = append(d.Changes, fromCfg.Diff(toCfg)...)
+		d.Compared = true
+	}
+
+	if diffFormat == "json" {
+		return printJSON(d)
+	}
+	printDeployDiff(args[0], diffService, d)
+	return nil
+}
+
+// diffDeployIDs resolves --from and --to to full deploy IDs, defaulting to
+// the latest deployment and the one before it.
+func diffDeployIDs(ctx context.Context, r *resolvedService) (from, to string, err error) {
+	if to, err = resolveDeployID(ctx, r, diffTo); err != nil {
+		return "", "", err
+	}
+	if from, err = resolveDeployID(ctx, r, diffFrom); err != nil {
+		return "", "", err
+	}
+	if from != "" && to != "" {
+		return from, to, nil
+	}
+
+	deploys, err := r.Platform.ListDeployments(ctx, r.Entry.ID, deployPrefixSearch)
+	if err != nil {
+		return "", "", fmt.Errorf("list deployments: %w", err)
+	}
+	toIdx := 0
+	if to == "" {
+		if len(deploys) == 0 {
+			return "", "", fmt.Errorf("no deployments found for %s", r.Entry.Name)
+		}
+		to = deploys[0].ID
+	} else {
+		toIdx = -1
+		for i, d := range deploys {
+			if d.ID == to {
+				toIdx = i
+				break
+			}
+		}
+		if toIdx < 0 {
+			return "", "", fmt.Errorf("deployment %s is not among the %d most recent; pass --from as well", shortID(to), deployPrefixSearch)
+		}
+	}
+	if from == "" {
+		if toIdx+1 >= len(deploys) {
+			return "", "", fmt.Errorf("no deployment before %s to compare with", shortID(to))
+		}
+		from = deploys[toIdx+1].ID
+	}
+	return from, to, nil
+}
+
+// gitLog lists the commits reachable from to but not from, newest first,
+// in a local checkout.
+func gitLog(ctx context.Context, repo, from, to string) ([]string, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", repo, "log", "--oneline", "--no-decorate", from+".."+to).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git log in %s: %s (fetch the repository?)", repo, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git log in %s: %w", repo, err)
+	}
+	var commits []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits, nil
+}
+
+func printDeployDiff(projectName, service string, d deployDiff) {
+	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName+"/"+service), ui.MutedStyle.Render("deployment diff"))
+	for _, side := range []struct {
+		label string
+		dep   *platform.Deployment
+	}{{"From", d.From}, {"To", d.To}} {
+		line := fmt.Sprintf("  %-6s %s  %s", side.label, shortID(side.dep.ID), ui.FormatStatus(side.dep.Status))
+		if side.dep.Commit != "" {
+			line += "  " + ui.FormatCommit(side.dep.Commit)
+		}
+		if !side.dep.CreatedAt.IsZero() {
+			line += "  " + ui.MutedStyle.Render(ui.TimeAgo(side.dep.CreatedAt))
+		}
+		fmt.Println(line)
+	}
+
+	if len(d.Commits) > 0 {
+		fmt.Printf("\n  %s\n", ui.ProjectTitleStyle.Render(fmt.Sprintf("Commits (%d)", len(d.Commits))))
+		for _, c := range d.Commits {
+			fmt.Printf("  %s\n", c)
+		}
+	}
+
+	if len(d.Changes) > 0 {
+		fmt.Printf("\n  %s\n", ui.ProjectTitleStyle.Render("Configuration"))
+		for _, c := range d.Changes {
+			switch {
+			case c.From == "":
+				fmt.Printf("  %s %s = %s\n", ui.HealthyStyle.Render("+"), c.Setting, c.To)
+			case c.To == "":
+				fmt.Printf("  %s %s (was %s)\n", ui.ErrorStyle.Render("-"), c.Setting, c.From)
+			default:
+				fmt.Printf("  %s %s: %s → %s\n", ui.WarningStyle.Render("~"), c.Setting, c.From, c.To)
+			}
+		}
+	} else if d.Compared {
+		fmt.Printf("\n  %s\n", ui.MutedStyle.Render("No configuration changes"))
+	}
+
+	if len(d.Notes) > 0 {
+		fmt.Println()
+	}
+	for _, n := range d.Notes {
+		fmt.Printf("  %s %s\n", ui.MutedStyle.Render("-"), ui.MutedStyle.Render(n))
+	}
+	fmt.Println()
+}
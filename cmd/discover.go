@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/humanetools/orbit/internal/cache"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+var errDiscoveryUnsupported = errors.New("service discovery not supported")
+
+// discoverServices lists the services on a connected platform. Results are
+// cached for cache.DiscoveryTTL, since discovery on large Vercel teams takes
+// seconds; refresh (or --no-cache) forces a fresh listing. cached reports
+// whether the result came from the cache.
+func discoverServices(ctx context.Context, cfg *config.Config, key []byte, pName string, refresh bool) (services []platform.DiscoveredService, cached bool, err error) {
+	pc, ok := cfg.Platforms[pName]
+	if !ok {
+		return nil, false, fmt.Errorf("platform %q not connected", pName)
+	}
+	token, err := config.Decrypt(key, pc.Token)
+	if err != nil {
+		return nil, false, fmt.Errorf("decrypt token: %w", err)
+	}
+	p, err := newPlatform(pName, pc, token)
+	if err != nil {
+		return nil, false, err
+	}
+	disc, ok := p.(platform.Discoverer)
+	if !ok {
+		return nil, false, errDiscoveryUnsupported
+	}
+
+	rc := responseCache()
+	if rc != nil && refresh {
+		rc.Refresh = true
+	}
+	ck := cache.DiscoveryKey(pName, token, pc.TeamID, pc.Endpoint)
+	if rc.Get(ck, cache.DiscoveryTTL, &services) {
+		return services, true, nil
+	}
+	services, err = disc.DiscoverServices(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	rc.Put(ck, services)
+	return services, false, nil
+}
+
+// discovery is the combined result of discoverAll.
+type discovery struct {
+	Services []platform.DiscoveredService
+	Cached   bool             // some platform's services came from the cache
+	Listed   map[string]bool  // platforms whose services were listed
+	Errs     map[string]error // platforms whose discovery failed
+}
+
+// discoverAll runs discoverServices on each platform in parallel. Platforms
+// without discovery are neither listed nor failed.
+func discoverAll(ctx context.Context, cfg *config.Config, key []byte, platforms []string, refresh bool) discovery {
+	sort.Strings(platforms)
+	results := make([][]platform.DiscoveredService, len(platforms))
+	fromCache := make([]bool, len(platforms))
+	failures := make([]error, len(platforms))
+
+	var wg sync.WaitGroup
+	for i, pName := range platforms {
+		wg.Add(1)
+		go func(i int, pName string) {
+			defer wg.Done()
+			results[i], fromCache[i], failures[i] = discoverServices(ctx, cfg, key, pName, refresh)
+		}(i, pName)
+	}
+	wg.Wait()
+
+	d := discovery{Listed: make(map[string]bool), Errs: make(map[string]error)}
+	for i, pName := range platforms {
+		switch {
+		case errors.Is(failures[i], errDiscoveryUnsupported):
+		case failures[i] != nil:
+			d.Errs[pName] = failures[i]
+		default:
+			d.Services = append(d.Services, results[i]...)
+			d.Listed[pName] = true
+			d.Cached = d.Cached || fromCache[i]
+		}
+	}
+	return d
+}
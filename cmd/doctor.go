@@ -0,0 +1,517 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorFix     bool
+	doctorFormat  string
+	doctorTimeout int
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose config, credentials and connectivity problems",
+	Long: `Check everything Orbit depends on and say how to fix what's wrong:
+
+  config     config.yaml parses, with no unknown keys or dangling references
+  key        the encryption key exists, decodes, and only you can read it
+  network    each connected platform's API answers
+  clock      the system clock agrees with the platforms' (skew breaks TLS
+             and signed requests)
+  token      each platform accepts its saved token
+  service    each service's ID still exists on its platform
+
+  orbit doctor
+  orbit doctor --fix          # also tighten file permissions
+  orbit doctor --format json  # for CI logs and support requests
+
+Exits 1 if any check fails; warnings don't change the exit code.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Apply fixes that don't need input (file permissions)")
+	doctorCmd.Flags().StringVar(&doctorFormat, "format", "", "Output format (json)")
+	doctorCmd.Flags().IntVar(&doctorTimeout, "timeout", 10, "Per-request timeout in seconds")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// Check results. Only failures make doctor exit non-zero.
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// Clock skew beyond these is reported as a warning or failure.
+const (
+	clockSkewWarn = 30 * time.Second
+	clockSkewFail = 5 * time.Minute
+)
+
+// doctorCheck is the outcome of one diagnostic.
+type doctorCheck struct {
+	Check  string `json:"check"` // config, config dir, key, network, clock, token, service
+	Target string `json:"target,omitempty"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+	Fixed  bool   `json:"fixed,omitempty"`
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	ctx := cmd.Context()
+	timeout := time.Duration(doctorTimeout) * time.Second
+
+	prog := newProgress(doctorFormat == "json")
+	prog.printf("  Running diagnostics...\n")
+
+	var checks []doctorCheck
+	configCheck, cfg := doctorConfig()
+	checks = append(checks, configCheck)
+	keyChecks, key := doctorKey(cfg)
+	checks = append(checks, keyChecks...)
+	if cfg != nil {
+		checks = append(checks, doctorNetwork(ctx, cfg, timeout)...)
+		tokenChecks, valid := doctorTokens(ctx, cfg, key, timeout)
+		checks = append(checks, tokenChecks...)
+		checks = append(checks, doctorServices(ctx, cfg, key, valid, timeout)...)
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if c.Status == doctorFail {
+			failed++
+		}
+	}
+	if doctorFormat == "json" {
+		if err := printJSON(checks); err != nil {
+			return err
+		}
+	} else {
+		printDoctorChecks(checks)
+	}
+	if failed > 0 {
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: exitFailed, Msg: ""}
+	}
+	return nil
+}
+
+// doctorConfig checks config.yaml as orbit config edit does. The config is
+// returned as Load reads it, so the remaining checks run even when only the
+// strict checks fail.
+func doctorConfig() (doctorCheck, *config.Config) {
+	c := doctorCheck{Check: "config"}
+	path, err := config.Path()
+	if err != nil {
+		c.Status, c.Detail = doctorFail, err.Error()
+		return c, nil
+	}
+	c.Target = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		c.Status, c.Detail = doctorOK, "not created yet; orbit init creates it"
+		cfg, _ := config.Load()
+		return c, cfg
+	}
+	if err != nil {
+		c.Status, c.Detail = doctorFail, err.Error()
+		return c, nil
+	}
+
+	if problems := checkConfig(data); len(problems) > 0 {
+		var lines []string
+		for _, p := range problems {
+			// Skip headers such as "yaml: unmarshal errors:".
+			if p = strings.TrimSpace(p); p != "" && !strings.HasSuffix(p, ":") {
+				lines = append(lines, p)
+			}
+		}
+		c.Status = doctorFail
+		c.Detail = strings.Join(lines, "; ")
+		c.Fix = "orbit config edit"
+	} else {
+		c.Status, c.Detail = doctorOK, "valid"
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return c, nil
+	}
+	return c, cfg
+}
+
+// doctorKey checks the encryption key and the permissions of it and the
+// config directory. It returns the key if it could be read.
+func doctorKey(cfg *config.Config) ([]doctorCheck, []byte) {
+	var checks []doctorCheck
+	if runtime.GOOS != "windows" {
+		if dir, err := config.Dir(); err == nil {
+			if c, ok := doctorPerm("config dir", dir, 0700); ok {
+				checks = append(checks, c)
+			}
+		}
+	}
+
+	c := doctorCheck{Check: "key"}
+	path, err := config.KeyPath()
+	if err != nil {
+		c.Status, c.Detail = doctorFail, err.Error()
+		return append(checks, c), nil
+	}
+	c.Target = path
+	key, err := config.ReadKey()
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if cfg != nil && len(cfg.Platforms) > 0 {
+			c.Status, c.Detail = doctorFail, "missing, so saved tokens can't be decrypted"
+			c.Fix = "restore it from a backup, or reconnect each platform with orbit connect"
+		} else {
+			c.Status, c.Detail = doctorOK, "not created yet; orbit connect creates it"
+		}
+		return append(checks, c), nil
+	case err != nil:
+		c.Status, c.Detail = doctorFail, err.Error()
+		c.Fix = "restore it from a backup, or delete it and reconnect each platform with orbit connect"
+		return append(checks, c), nil
+	}
+	if runtime.GOOS != "windows" {
+		if pc, ok := doctorPerm("key", path, 0600); ok {
+			return append(checks, pc), key
+		}
+	}
+	c.Status, c.Detail = doctorOK, "readable by you only"
+	return append(checks, c), key
+}
+
+// doctorPerm reports a file or directory that group or others can access,
+// tightening it to want with --fix. ok is false when access is already
+// restricted.
+func doctorPerm(check, path string, want os.FileMode) (c doctorCheck, ok bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm()&0077 == 0 {
+		return doctorCheck{}, false
+	}
+	c = doctorCheck{Check: check, Target: path, Status: doctorFail}
+	have := info.Mode().Perm()
+	if doctorFix {
+		if err := os.Chmod(path, want); err != nil {
+			c.Detail = fmt.Sprintf("mode %04o lets others read it; chmod failed: %v", have, err)
+			return c, true
+		}
+		c.Status, c.Fixed = doctorOK, true
+		c.Detail = fmt.Sprintf("was mode %04o, now %04o", have, want)
+		return c, true
+	}
+	c.Detail = fmt.Sprintf("mode %04o lets others read it", have)
+	c.Fix = fmt.Sprintf("chmod %o %s (or orbit doctor --fix)", want, path)
+	return c, true
+}
+
+// doctorNetwork checks that each connected platform's API answers, and
+// measures clock skew from the Date headers of the responses. Without
+// connected platforms, GitHub's API is used for both.
+func doctorNetwork(ctx context.Context, cfg *config.Config, timeout time.Duration) []doctorCheck {
+	var names []string
+	for name := range cfg.Platforms {
+		if platform.APIURL(name) != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		names = []string{"github"}
+	}
+	sort.Strings(names)
+
+	checks := make([]doctorCheck, len(names))
+	skews := make([]*time.Duration, len(names))
+	client := &http.Client{Timeout: timeout}
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			checks[i], skews[i] = doctorReach(ctx, client, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	clock := doctorCheck{Check: "clock", Status: doctorWarn, Detail: "couldn't measure: no platform API answered"}
+	for i, skew := range skews {
+		if skew == nil {
+			continue
+		}
+		clock = doctorClock(*skew)
+		clock.Target = names[i]
+		break
+	}
+	return append(checks, clock)
+}
+
+// doctorReach requests a platform's API root. Any HTTP response, even an
+// error status, means the API is reachable. skew is the local clock's lead
+// over the server's, nil if the response had no Date.
+func doctorReach(ctx context.Context, client *http.Client, name string) (c doctorCheck, skew *time.Duration) {
+	apiURL := platform.APIURL(name)
+	c = doctorCheck{Check: "network", Target: name}
+	host := apiURL
+	if u, err := url.Parse(apiURL); err == nil {
+		host = u.Host
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		c.Status, c.Detail = doctorFail, err.Error()
+		return c, nil
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = fmt.Sprintf("can't reach %s: %v", host, err)
+		c.Fix = "check your connection, DNS and proxy settings (HTTPS_PROXY)"
+		return c, nil
+	}
+	resp.Body.Close()
+	end := time.Now()
+	c.Status, c.Detail = doctorOK, fmt.Sprintf("%s answered in %s", host, end.Sub(start).Round(time.Millisecond))
+
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		// The server stamped the response somewhere between start and end.
+		d := start.Add(end.Sub(start) / 2).Sub(date)
+		skew = &d
+	}
+	return c, skew
+}
+
+// doctorClock rates a measured clock skew. Date headers have one-second
+// resolution, so skew under that isn't reported.
+func doctorClock(skew time.Duration) doctorCheck {
+	c := doctorCheck{Check: "clock", Status: doctorOK, Detail: "in sync"}
+	abs := skew
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs < time.Second {
+		return c
+	}
+	dir := "ahead"
+	if skew < 0 {
+		dir = "behind"
+	}
+	c.Detail = fmt.Sprintf("%s %s", abs.Round(time.Second), dir)
+	switch {
+	case abs >= clockSkewFail:
+		c.Status = doctorFail
+	case abs >= clockSkewWarn:
+		c.Status = doctorWarn
+	default:
+		return c
+	}
+	c.Fix = "sync the system clock, e.g. enable NTP"
+	return c
+}
+
+// doctorTokens validates every saved token. valid holds the platforms
+// whose token was accepted.
+func doctorTokens(ctx context.Context, cfg *config.Config, key []byte, timeout time.Duration) (checks []doctorCheck, valid map[string]bool) {
+	names := make([]string, 0, len(cfg.Platforms))
+	for name := range cfg.Platforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checks = make([]doctorCheck, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			checks[i] = doctorToken(ctx, name, cfg.Platforms[name], key, timeout)
+		}(i, name)
+	}
+	wg.Wait()
+
+	valid = make(map[string]bool)
+	for i, c := range checks {
+		if c.Status == doctorOK {
+			valid[names[i]] = true
+		}
+	}
+	return checks, valid
+}
+
+func doctorToken(ctx context.Context, name string, pc config.PlatformConfig, key []byte, timeout time.Duration) doctorCheck {
+	c := doctorCheck{Check: "token", Target: name, Status: doctorFail}
+	reconnect := "orbit connect " + name
+	if key == nil {
+		c.Detail = "not checked: no usable encryption key"
+		c.Fix = reconnect
+		return c
+	}
+	token, err := config.Decrypt(key, pc.Token)
+	if err != nil {
+		c.Detail = "can't decrypt the saved token; was the key file replaced?"
+		c.Fix = reconnect
+		return c
+	}
+	p, err := newPlatform(name, pc, token)
+	if err != nil {
+		c.Detail = err.Error()
+		return c
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err = p.Validate(ctx, token)
+	switch {
+	case err == nil:
+		c.Status, c.Detail = doctorOK, "accepted"
+	case ctx.Err() == context.DeadlineExceeded:
+		c.Status, c.Detail = doctorWarn, fmt.Sprintf("no response after %s", timeout)
+	case errors.Is(err, platform.ErrUnauthorized):
+		c.Detail = "rejected: expired, revoked or missing scopes"
+		c.Fix = reconnect
+		if u := platform.TokenURL(name); u != "" {
+			c.Fix += " with a new token from " + u
+		}
+	default:
+		c.Detail = err.Error()
+	}
+	return c
+}
+
+// doctorServiceWorkers bounds concurrent service lookups, so large configs
+// stay inside platform rate limits.
+const doctorServiceWorkers = 8
+
+// doctorServices looks up every service on its platform. Services on
+// platforms whose token failed are skipped: that problem is reported once.
+func doctorServices(ctx context.Context, cfg *config.Config, key []byte, valid map[string]bool, timeout time.Duration) []doctorCheck {
+	projects := make([]string, 0, len(cfg.Projects))
+	for name := range cfg.Projects {
+		projects = append(projects, name)
+	}
+	sort.Strings(projects)
+
+	var checks []doctorCheck
+	type lookup struct {
+		idx     int
+		project string
+		entry   config.ServiceEntry
+	}
+	var lookups []lookup
+	for _, proj := range projects {
+		for _, e := range cfg.Projects[proj].Topology {
+			c := doctorCheck{Check: "service", Target: proj + "/" + e.Name}
+			if _, ok := cfg.Platforms[e.Platform]; !ok {
+				c.Status, c.Detail = doctorFail, fmt.Sprintf("platform %s is not connected", e.Platform)
+				c.Fix = "orbit connect " + e.Platform
+			} else if valid[e.Platform] {
+				lookups = append(lookups, lookup{len(checks), proj, e})
+			} else {
+				continue
+			}
+			checks = append(checks, c)
+		}
+	}
+
+	sem := make(chan struct{}, doctorServiceWorkers)
+	var wg sync.WaitGroup
+	for _, l := range lookups {
+		wg.Add(1)
+		go func(l lookup) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			c := &checks[l.idx]
+			p, err := entryPlatform(cfg, key, l.entry)
+			if err != nil {
+				c.Status, c.Detail = doctorFail, err.Error()
+				return
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			_, err = p.GetServiceStatus(ctx, l.entry.ID)
+			switch {
+			case err == nil:
+				c.Status, c.Detail = doctorOK, fmt.Sprintf("%s %s exists", l.entry.Platform, l.entry.ID)
+			case errors.Is(err, platform.ErrNotFound):
+				c.Status = doctorFail
+				c.Detail = fmt.Sprintf("%s has no service %s; deleted or moved to another team?", l.entry.Platform, l.entry.ID)
+				c.Fix = fmt.Sprintf("orbit service remove %s --name %s, then orbit service add %s", l.project, l.entry.Name, l.project)
+			case ctx.Err() == context.DeadlineExceeded:
+				c.Status, c.Detail = doctorWarn, fmt.Sprintf("no response after %s", timeout)
+			default:
+				c.Status, c.Detail = doctorWarn, err.Error()
+			}
+		}(l)
+	}
+	wg.Wait()
+	return checks
+}
+
+// label names the check for display. File checks leave their path to the
+// JSON output.
+func (c doctorCheck) label() string {
+	switch c.Check {
+	case "config", "config dir", "key":
+		return c.Check
+	}
+	if c.Target == "" {
+		return c.Check
+	}
+	return c.Check + " " + c.Target
+}
+
+func printDoctorChecks(checks []doctorCheck) {
+	fmt.Println()
+	failed, warned := 0, 0
+	for _, c := range checks {
+		var mark string
+		switch c.Status {
+		case doctorOK:
+			mark = ui.HealthyStyle.Render(ui.IconSuccess)
+		case doctorWarn:
+			mark = ui.WarningStyle.Render(ui.IconWarning)
+			warned++
+		default:
+			mark = ui.ErrorStyle.Render(ui.IconError)
+			failed++
+		}
+		detail := c.Detail
+		if c.Fixed {
+			detail += " " + ui.HealthyStyle.Render("(fixed)")
+		}
+		fmt.Printf("  %s %s %s\n", mark, ui.Pad(c.label(), 28), detail)
+		if c.Fix != "" && c.Status != doctorOK {
+			fmt.Printf("    %s %s\n", ui.Pad("", 28), ui.MutedStyle.Render("fix: "+c.Fix))
+		}
+	}
+
+	fmt.Println()
+	if failed == 0 && warned == 0 {
+		fmt.Printf("  %s All %d checks passed\n", ui.IconSuccess, len(checks))
+	} else {
+		fmt.Printf("  %d failed, %d warning(s) of %d checks\n", failed, warned, len(checks))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	domainsService string
+	domainsFormat  string
+)
+
+var domainsCmd = &cobra.Command{
+	Use:   "domains [project]",
+	Short: "List the domains routed to each service",
+	Long: `List the domains of every service in a project: platform-assigned and
+custom domains, whether they are verified, and their certificate state.
+
+  orbit domains myshop
+  orbit domains myshop --service web
+  orbit domains myshop --format json
+
+Supported on Koyeb, Vercel and Render. Koyeb domains belong to an app and
+are shared by its services, so they are listed with the route paths each
+service serves.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDomains,
+}
+
+func init() {
+	domainsCmd.Flags().StringVar(&domainsService, "service", "", "Show domains of a specific service")
+	domainsCmd.Flags().StringVar(&domainsFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(domainsCmd)
+}
+
+var errDomainsUnsupported = errors.New("domain listing not supported")
+
+type domainResult struct {
+	Entry   config.ServiceEntry
+	Domains []platform.Domain
+	Err     error
+}
+
+func runDomains(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+
+	entries := proj.Topology
+	if domainsService != "" {
+		entries = nil
+		for _, e := range proj.Topology {
+			if e.Name == domainsService {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("service %q not found in project %q", domainsService, projectName)
+		}
+	}
+
+	results := fetchDomains(cmd.Context(), cfg, key, entries)
+	if domainsFormat == "json" {
+		return renderDomainsJSON(results)
+	}
+	renderDomainsTable(projectName, results)
+	return nil
+}
+
+func fetchDomains(ctx context.Context, cfg *config.Config, key []byte, entries []config.ServiceEntry) []domainResult {
+	results := make([]domainResult, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		results[i].Entry = e
+		wg.Add(1)
+		go func(r *domainResult) {
+			defer wg.Done()
+			p, err := entryPlatform(cfg, key, r.Entry)
+			if err != nil {
+				r.Err = err
+				return
+			}
+			dl, ok := p.(platform.DomainLister)
+			if !ok {
+				r.Err = errDomainsUnsupported
+				return
+			}
+			r.Domains, r.Err = dl.ListDomains(ctx, r.Entry.ID)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+func renderDomainsTable(projectName string, results []domainResult) {
+	fmt.Println(ui.ProjectTitleStyle.Render(projectName))
+	fmt.Printf("  %s %s %s %s %s\n",
+		ui.Pad(ui.HeaderStyle.Render("Domain"), 36),
+		ui.Pad(ui.HeaderStyle.Render("Service"), 16),
+		ui.Pad(ui.HeaderStyle.Render("Verified"), 10),
+		ui.Pad(ui.HeaderStyle.Render("SSL"), 10),
+		ui.HeaderStyle.Render("Notes"),
+	)
+
+	for _, r := range results {
+		service := fmt.Sprintf("%s (%s)", r.Entry.Name, r.Entry.Platform)
+		if r.Err != nil {
+			msg := r.Err.Error()
+			if errors.Is(r.Err, errDomainsUnsupported) {
+				msg = "not supported on " + r.Entry.Platform
+			}
+			fmt.Printf("  %s %s %s\n", ui.Pad(ui.MutedStyle.Render(ui.Dash), 36), ui.Pad(service, 16), ui.MutedStyle.Render(msg))
+			continue
+		}
+		if len(r.Domains) == 0 {
+			fmt.Printf("  %s %s %s\n", ui.Pad(ui.MutedStyle.Render(ui.Dash), 36), ui.Pad(service, 16), ui.MutedStyle.Render("no domains"))
+			continue
+		}
+		for _, d := range r.Domains {
+			name := d.Name
+			if !d.Custom {
+				name = ui.MutedStyle.Render(d.Name)
+			}
+			verified := ui.WarningStyle.Render("no")
+			if d.Verified {
+				verified = ui.HealthyStyle.Render("yes")
+			}
+			note := d.Note
+			if d.Redirect != "" {
+				note = "redirects to " + d.Redirect
+			}
+			fmt.Printf("  %s %s %s %s %s\n",
+				ui.Pad(name, 36), ui.Pad(service, 16), ui.Pad(verified, 10), ui.Pad(formatSSL(d.SSL), 10),
+				ui.MutedStyle.Render(note))
+		}
+	}
+	fmt.Println()
+}
+
+func formatSSL(state string) string {
+	switch state {
+	case "active":
+		return ui.HealthyStyle.Render(state)
+	case "pending":
+		return ui.WarningStyle.Render(state)
+	case "error":
+		return ui.ErrorStyle.Render(state)
+	default:
+		return ui.Dash
+	}
+}
+
+type jsonDomain struct {
+	Name     string `json:"name"`
+	Custom   bool   `json:"custom"`
+	Verified bool   `json:"verified"`
+	SSL      string `json:"ssl,omitempty"`
+	Redirect string `json:"redirect,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+type jsonDomainResult struct {
+	Service   string       `json:"service"`
+	Platform  string       `json:"platform"`
+	Domains   []jsonDomain `json:"domains"`
+	Error     string       `json:"error,omitempty"`
+	ErrorKind string       `json:"error_kind,omitempty"`
+}
+
+func renderDomainsJSON(results []domainResult) error {
+	out := make([]jsonDomainResult, len(results))
+	for i, r := range results {
+		out[i] = jsonDomainResult{Service: r.Entry.Name, Platform: r.Entry.Platform, Domains: []jsonDomain{}}
+		if r.Err != nil {
+			out[i].Error = r.Err.Error()
+			out[i].ErrorKind = platform.ErrorKind(r.Err)
+			continue
+		}
+		for _, d := range r.Domains {
+			out[i].Domains = append(out[i].Domains, jsonDomain(d))
+		}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
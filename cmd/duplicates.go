@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+	"golang.org/x/term"
+)
+
+// resolveDuplicateNames makes the names of added entries unique within a
+// project that already holds existing. Entries already in the project
+// (same platform and ID) are dropped. Name conflicts are resolved
+// interactively when stdin is a terminal; otherwise conflicting entries are
+// suffixed with their platform (api-koyeb, api-flyio).
+func resolveDuplicateNames(existing, added []config.ServiceEntry) []config.ServiceEntry {
+	known := make(map[string]bool)
+	taken := make(map[string]bool)
+	for _, e := range existing {
+		known[e.Platform+"/"+e.ID] = true
+		taken[e.Name] = true
+	}
+
+	var entries []config.ServiceEntry
+	for _, e := range added {
+		if known[e.Platform+"/"+e.ID] {
+			fmt.Printf("  %s %s (%s) is already in the project, skipping\n", ui.IconWarning, e.Name, e.Platform)
+			continue
+		}
+		known[e.Platform+"/"+e.ID] = true
+		entries = append(entries, e)
+	}
+
+	groups := make(map[string][]int)
+	var order []string
+	for i, e := range entries {
+		if _, ok := groups[e.Name]; !ok {
+			order = append(order, e.Name)
+		}
+		groups[e.Name] = append(groups[e.Name], i)
+	}
+
+	var conflicts []string
+	for _, name := range order {
+		if len(groups[name]) > 1 || taken[name] {
+			conflicts = append(conflicts, name)
+		}
+		// Bare conflicting names stay reserved so renames don't reuse them.
+		taken[name] = true
+	}
+	if len(conflicts) == 0 {
+		return entries
+	}
+
+	interactive := term.IsTerminal(int(os.Stdin.Fd()))
+	reader := bufio.NewReader(os.Stdin)
+	drop := make(map[int]bool)
+
+	for _, name := range conflicts {
+		idx := groups[name]
+		inProject := isExistingName(existing, name)
+
+		choice := "s"
+		if interactive {
+			users := len(idx)
+			if inProject {
+				users++
+			}
+			fmt.Printf("\n  %s Name %q is used by %d services:\n", ui.IconWarning, name, users)
+			if inProject {
+				fmt.Printf("    %s %s\n", name, ui.MutedStyle.Render("(already in project, keeps its name)"))
+			}
+			for _, i := range idx {
+				fmt.Printf("    %s %s\n", name, ui.MutedStyle.Render(fmt.Sprintf("(%s, %s)", entries[i].Platform, entries[i].ID)))
+			}
+			fmt.Printf("  [S]uffix with platform, [r]ename each, or [k]eep only the first? ")
+			answer, _ := reader.ReadString('\n')
+			choice = strings.ToLower(strings.TrimSpace(answer))
+		}
+
+		switch choice {
+		case "k", "keep":
+			start := 1
+			if inProject {
+				start = 0
+			}
+			for _, i := range idx[start:] {
+				drop[i] = true
+			}
+			continue
+		case "r", "rename":
+			// One entry may keep the bare name if the project doesn't use it.
+			bareFree := !inProject
+			for _, i := range idx {
+				e := &entries[i]
+				suggested := config.UniqueServiceName(name, e.Platform, taken)
+				for {
+					fmt.Printf("  Name for %s (%s, %s) [%s]: ", name, e.Platform, e.ID, suggested)
+					answer, _ := reader.ReadString('\n')
+					newName := strings.TrimSpace(answer)
+					if newName == "" {
+						newName = suggested
+					}
+					if newName == name && bareFree {
+						bareFree = false
+					} else if taken[newName] {
+						fmt.Printf("  %s %q is already taken\n", ui.IconWarning, newName)
+						continue
+					}
+					e.Name = newName
+					taken[newName] = true
+					break
+				}
+			}
+			continue
+		}
+
+		// Default: suffix every conflicting entry with its platform.
+		for _, i := range idx {
+			if drop[i] {
+				continue
+			}
+			e := &entries[i]
+			e.Name = config.UniqueServiceName(name, e.Platform, taken)
+			taken[e.Name] = true
+			if !interactive {
+				fmt.Printf("  %s Duplicate name %q: using %s for %s service %s\n", ui.IconWarning, name, e.Name, e.Platform, e.ID)
+			}
+		}
+	}
+
+	resolved := make([]config.ServiceEntry, 0, len(entries))
+	for i, e := range entries {
+		if !drop[i] {
+			resolved = append(resolved, e)
+		}
+	}
+	return resolved
+}
+
+func isExistingName(existing []config.ServiceEntry, name string) bool {
+	for _, e := range existing {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
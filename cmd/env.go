@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envService string
+	envReveal  bool
+	envFormat  string
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "View or edit service environment variables",
+	Long: `Inspect and edit a service's environment variables.
+
+  orbit env list myshop --service api
+  orbit env list myshop --service api --reveal
+  orbit env set myshop --service api LOG_LEVEL=debug FEATURE_X=1
+  orbit env unset myshop --service api FEATURE_X
+
+Supported on Koyeb and Vercel. Koyeb redeploys the service with the new
+environment right away; on Vercel changes apply from the next deployment.
+Vercel services with a deployment target only see and change variables of
+that target.`,
+}
+
+var envListCmd = &cobra.Command{
+	Use:   "list [project]",
+	Short: "List environment variables (values masked)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runEnvList,
+}
+
+var envSetCmd = &cobra.Command{
+	Use:   "set <project> KEY=VALUE...",
+	Short: "Add or change environment variables",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runEnvSet,
+}
+
+var envUnsetCmd = &cobra.Command{
+	Use:   "unset <project> KEY...",
+	Short: "Remove environment variables",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runEnvUnset,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{envListCmd, envSetCmd, envUnsetCmd} {
+		c.Flags().StringVar(&envService, "service", "", "Service name (required)")
+		c.MarkFlagRequired("service")
+		envCmd.AddCommand(c)
+	}
+	envListCmd.Flags().BoolVar(&envReveal, "reveal", false, "Show values instead of masking them")
+	envListCmd.Flags().StringVar(&envFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(envCmd)
+}
+
+// resolveEnvManager resolves the service and checks that its platform can
+// manage environment variables.
+func resolveEnvManager(projectName string) (*resolvedService, platform.EnvManager, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load encryption key: %w", err)
+	}
+	if projectName == "" {
+		projectName = cfg.DefaultProject
+	}
+
+	resolved, err := resolveService(cfg, key, projectName, envService)
+	if err != nil {
+		return nil, nil, err
+	}
+	em, ok := resolved.Platform.(platform.EnvManager)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s does not support environment variables (service %s)", resolved.Entry.Platform, resolved.Entry.Name)
+	}
+	return resolved, em, nil
+}
+
+func runEnvList(cmd *cobra.Command, args []string) error {
+	projectName := ""
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	resolved, em, err := resolveEnvManager(projectName)
+	if err != nil {
+		return err
+	}
+
+	vars, err := em.GetEnvVars(cmd.Context(), resolved.Entry.ID)
+	if err != nil {
+		return fmt.Errorf("get environment: %w", err)
+	}
+	sort.SliceStable(vars, func(i, j int) bool { return vars[i].Key < vars[j].Key })
+
+	if envFormat == "json" {
+		type jsonEnvVar struct {
+			Key       string   `json:"key"`
+			Value     string   `json:"value,omitempty"`
+			SecretRef string   `json:"secret,omitempty"`
+			Sensitive bool     `json:"sensitive,omitempty"`
+			Targets   []string `json:"targets,omitempty"`
+		}
+		out := make([]jsonEnvVar, len(vars))
+		for i, v := range vars {
+			out[i] = jsonEnvVar{Key: v.Key, Value: v.Value, SecretRef: v.SecretRef, Sensitive: v.Sensitive, Targets: v.Targets}
+			if !envReveal && v.Value != "" {
+				out[i].Value = maskValue(v.Value)
+			}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println(ui.ProjectTitleStyle.Render(fmt.Sprintf("%s (%s)", resolved.Entry.Name, resolved.Entry.Platform)))
+	if len(vars) == 0 {
+		fmt.Printf("  %s\n\n", ui.MutedStyle.Render("No environment variables."))
+		return nil
+	}
+
+	width := 0
+	for _, v := range vars {
+		width = max(width, len(v.Key))
+	}
+	for _, v := range vars {
+		var value string
+		switch {
+		case v.SecretRef != "":
+			value = ui.MutedStyle.Render("secret " + v.SecretRef)
+		case v.Sensitive:
+			value = ui.MutedStyle.Render("(sensitive)")
+		case envReveal:
+			value = v.Value
+		default:
+			value = ui.MutedStyle.Render(maskValue(v.Value))
+		}
+		line := fmt.Sprintf("  %s  %s", ui.Pad(ui.HeaderStyle.Render(v.Key), width), value)
+		if len(v.Targets) > 0 {
+			line += "  " + ui.MutedStyle.Render(strings.Join(v.Targets, ", "))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	return nil
+}
+
+// maskValue hides a value while showing whether it is set and roughly how
+// long it is.
+func maskValue(v string) string {
+	if v == "" {
+		return ui.Dash
+	}
+	return strings.Repeat("•", min(len(v), 8))
+}
+
+func runEnvSet(cmd *cobra.Command, args []string) error {
+	vars := make(map[string]string)
+	for _, arg := range args[1:] {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid assignment %q (want KEY=VALUE)", arg)
+		}
+		if err := platform.ValidateEnvKey(k); err != nil {
+			return err
+		}
+		vars[k] = v
+	}
+
+	resolved, em, err := resolveEnvManager(args[0])
+	if err != nil {
+		return err
+	}
+	if err := em.SetEnvVars(cmd.Context(), resolved.Entry.ID, vars); err != nil {
+		return fmt.Errorf("set environment: %w", err)
+	}
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Printf("  %s Set %s on %s\n", ui.IconSuccess, strings.Join(keys, ", "), resolved.Entry.Name)
+	return nil
+}
+
+func runEnvUnset(cmd *cobra.Command, args []string) error {
+	resolved, em, err := resolveEnvManager(args[0])
+	if err != nil {
+		return err
+	}
+	keys := args[1:]
+	if err := em.DeleteEnvVars(cmd.Context(), resolved.Entry.ID, keys); err != nil {
+		return fmt.Errorf("unset environment: %w", err)
+	}
+	fmt.Printf("  %s Removed %s from %s\n", ui.IconSuccess, strings.Join(keys, ", "), resolved.Entry.Name)
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// etaSamples is how many recent deployments are used to estimate build time.
+const etaSamples = 10
+
+// progressInterval is how often watch prints a progress line while a deploy runs.
+const progressInterval = 15 * time.Second
+
+// buildEstimate is the typical duration of recent successful deployments.
+type buildEstimate struct {
+	typical time.Duration
+	samples int
+}
+
+// estimateBuildDuration returns the median duration of finished, non-failed
+// deployments that report one. Deployments that have since been replaced count
+// too: Koyeb reports those as stopped ("sleeping"). Platforms that don't expose
+// durations yield no estimate.
+func estimateBuildDuration(deploys []platform.Deployment) buildEstimate {
+	var durations []time.Duration
+	for _, d := range deploys {
+		switch d.Status {
+		case "healthy", "sleeping", "degraded":
+			if d.Duration > 0 {
+				durations = append(durations, d.Duration)
+			}
+		}
+	}
+	if len(durations) == 0 {
+		return buildEstimate{}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return buildEstimate{typical: durations[len(durations)/2], samples: len(durations)}
+}
+
+// progress renders a bar and remaining-time hint for a deploy running for elapsed,
+// or "" without an estimate.
+func (e buildEstimate) progress(elapsed time.Duration) string {
+	if e.samples == 0 {
+		return ""
+	}
+	basis := fmt.Sprintf("based on last %d builds", e.samples)
+	if e.samples == 1 {
+		basis = "based on last build"
+	}
+
+	remaining := e.typical - elapsed
+	if remaining <= 0 {
+		return fmt.Sprintf("%s taking longer than usual (typically %s)",
+			ui.ProgressBar(1, 20), e.typical.Round(time.Second))
+	}
+	return fmt.Sprintf("%s ~%s remaining (%s)",
+		ui.ProgressBar(float64(elapsed)/float64(e.typical), 20), remaining.Round(time.Second), basis)
+}
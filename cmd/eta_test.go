@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+func TestEstimateBuildDuration(t *testing.T) {
+	deploys := []platform.Deployment{
+		{Status: "healthy", Duration: 90 * time.Second},
+		{Status: "sleeping", Duration: 60 * time.Second}, // replaced Koyeb deployment
+		{Status: "sleeping", Duration: 120 * time.Second},
+		{Status: "degraded", Duration: 100 * time.Second},
+		{Status: "failed", Duration: 5 * time.Second},
+		{Status: "building", Duration: 10 * time.Second},
+		{Status: "healthy"}, // no duration reported
+	}
+	e := estimateBuildDuration(deploys)
+	if e.samples != 4 || e.typical != 100*time.Second {
+		t.Errorf("estimate = %v from %d samples, want 1m40s from 4", e.typical, e.samples)
+	}
+
+	if got := estimateBuildDuration(nil).progress(time.Minute); got != "" {
+		t.Errorf("progress without estimate = %q, want empty", got)
+	}
+	if got := e.progress(40 * time.Second); !strings.Contains(got, "~1m0s remaining (based on last 4 builds)") {
+		t.Errorf("progress = %q", got)
+	}
+	if got := e.progress(3 * time.Minute); !strings.Contains(got, "taking longer than usual (typically 1m40s)") {
+		t.Errorf("overdue progress = %q", got)
+	}
+	one := buildEstimate{typical: time.Minute, samples: 1}
+	if got := one.progress(30 * time.Second); !strings.Contains(got, "(based on last build)") {
+		t.Errorf("single-sample progress = %q", got)
+	}
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/history"
+	"github.com/humanetools/orbit/internal/incident"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// eventsPollInterval is how often orbit events --follow checks for new
+// activity.
+const eventsPollInterval = 2 * time.Second
+
+var (
+	eventsProject string
+	eventsSince   string
+	eventsLimit   int
+	eventsFollow  bool
+	eventsFormat  string
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events [project]",
+	Short: "Activity feed of deploys, scaling, incidents and heartbeat failures",
+	Long: `Show one chronological feed of what happened across the services of a
+project, or of all projects: deployments seen by status, watch or the
+dashboard, scaling changes, incidents opening and resolving, threshold
+violations, failed heartbeat checks and automatic restarts by the daemon.
+
+  orbit events
+  orbit events myshop --since 24h
+  orbit events myshop --follow
+  orbit events --since 7d --format json
+
+Scaling changes are those made with orbit scale and instance counts that
+changed between status polls, as autoscaling does, on platforms that report
+instances. --follow keeps printing activity as other orbit commands and
+heartbeat daemons record it. JSON output is one event per line.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEvents,
+}
+
+func init() {
+	eventsCmd.Flags().StringVar(&eventsProject, "project", "", "Only show this project")
+	eventsCmd.Flags().MarkDeprecated("project", "pass the project as an argument instead")
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "24h", "How far back to start (e.g. 12h, 7d)")
+	eventsCmd.Flags().IntVar(&eventsLimit, "limit", 50, "Show at most this many past events (0 for all)")
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep printing new events")
+	eventsCmd.Flags().StringVar(&eventsFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	since, err := parseSince(eventsSince)
+	if err != nil {
+		return err
+	}
+	projectName := eventsProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	if projectName != "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		if _, err := resolveProject(cfg, projectName); err != nil {
+			return err
+		}
+	}
+	path, err := history.Path()
+	if err != nil {
+		return err
+	}
+
+	feed := newActivityFeed(path, projectName)
+	past, err := feed.poll(time.Now().Add(-since))
+	if err != nil {
+		return err
+	}
+	if eventsLimit > 0 && len(past) > eventsLimit {
+		past = past[len(past)-eventsLimit:]
+	}
+	if eventsFormat != "json" && len(past) == 0 && !eventsFollow {
+		fmt.Println(ui.MutedStyle.Render("No activity in the last " + eventsSince + "."))
+		return nil
+	}
+	for _, e := range past {
+		printActivity(e)
+	}
+	if !eventsFollow {
+		return nil
+	}
+
+	cmd.SilenceUsage = true
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(eventsPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		// Entries carry the time they were observed, which can be a little
+		// before they are written, so look back a while; the feed skips
+		// what was printed already.
+		entries, err := feed.poll(time.Now().Add(-2 * time.Minute))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+			continue
+		}
+		for _, e := range entries {
+			printActivity(e)
+		}
+	}
+}
+
+// activityFeed merges history entries and the incident log into one feed,
+// remembering what it returned so repeated polls only return new activity.
+type activityFeed struct {
+	path      string
+	project   string
+	last      uint64          // Seq of the newest history entry seen
+	instances map[string]int  // project/service → instances at the last poll
+	incidents map[string]bool // incident transitions returned, e.g. "12 opened"
+}
+
+func newActivityFeed(path, project string) *activityFeed {
+	return &activityFeed{path: path, project: project, instances: map[string]int{}, incidents: map[string]bool{}}
+}
+
+// poll returns the activity since the given time that earlier polls didn't
+// return, oldest first.
+func (f *activityFeed) poll(since time.Time) ([]history.Entry, error) {
+	store, err := history.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := store.Query(history.Query{Project: f.project, Since: since, After: f.last})
+	store.Close()
+	if err != nil {
+		return nil, err
+	}
+	var out []history.Entry
+	for _, e := range entries {
+		f.last = max(f.last, e.Seq)
+		if e.Kind == history.KindStatus {
+			if ev, ok := f.instanceChange(e); ok {
+				out = append(out, ev)
+			}
+			continue
+		}
+		if isActivity(e) {
+			out = append(out, e)
+		}
+	}
+	out = append(out, f.incidentActivity(since)...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out, nil
+}
+
+// instanceChange turns a status poll whose instance count differs from the
+// service's previous poll into a scaling event. The first poll of a service
+// only sets the baseline.
+func (f *activityFeed) instanceChange(e history.Entry) (history.Entry, bool) {
+	if e.Instances == nil {
+		return history.Entry{}, false
+	}
+	key := e.Project + "/" + e.Service
+	prev, seen := f.instances[key]
+	f.instances[key] = *e.Instances
+	if !seen || prev == *e.Instances {
+		return history.Entry{}, false
+	}
+	return history.Entry{
+		Seq: e.Seq, Time: e.Time, Kind: history.KindEvent, Project: e.Project, Service: e.Service,
+		Type: "instances_changed", Severity: notify.SeverityInfo,
+		Title: fmt.Sprintf("instances %d → %d", prev, *e.Instances),
+	}, true
+}
+
+// incidentActivity returns incidents that opened or resolved since the
+// given time, each transition once.
+func (f *activityFeed) incidentActivity(since time.Time) []history.Entry {
+	var out []history.Entry
+	add := func(inc incident.Incident, transition string, at time.Time, severity string) {
+		key := fmt.Sprintf("%d %s", inc.ID, transition)
+		if at.Before(since) || f.incidents[key] {
+			return
+		}
+		f.incidents[key] = true
+		e := history.Entry{
+			Time: at, Kind: history.KindEvent, Project: inc.Project, Service: inc.Service,
+			Type: "incident_" + transition, Severity: severity,
+			Title: fmt.Sprintf("incident #%d %s", inc.ID, transition), Detail: inc.Title,
+		}
+		if transition == "resolved" && inc.Resolution != "" {
+			e.Detail = inc.Resolution
+		}
+		out = append(out, e)
+	}
+	for _, inc := range loadIncidents().Incidents {
+		if f.project != "" && inc.Project != f.project {
+			continue
+		}
+		add(inc, "opened", inc.StartedAt, notify.SeverityCritical)
+		if inc.ResolvedAt != nil {
+			add(inc, "resolved", *inc.ResolvedAt, notify.SeverityInfo)
+		}
+	}
+	return out
+}
+
+func isActivity(e history.Entry) bool {
+	switch e.Kind {
+	case history.KindEvent, history.KindDeploy:
+		return true
+	case history.KindHeartbeat:
+		return e.Status != "healthy"
+	}
+	return false
+}
+
+func printActivity(e history.Entry) {
+	if eventsFormat == "json" {
+		data, _ := json.Marshal(e)
+		fmt.Println(string(data))
+		return
+	}
+
+	subject := e.Project + "/" + e.Service
+	if e.Service == "" {
+		subject = e.Project
+	}
+	var icon, text string
+	switch e.Kind {
+	case history.KindDeploy:
+		icon, text = ui.IconBuilding, fmt.Sprintf("deploy %s %s", shortID(e.DeployID), e.Status)
+		switch e.Status {
+		case "failed", "error", "canceled":
+			icon, text = ui.ErrorStyle.Render(ui.IconFailed), ui.ErrorStyle.Render(text)
+		case "live", "healthy", "ready", "succeeded", "success":
+			icon = ui.HealthyStyle.Render(ui.IconSuccess)
+		}
+		if e.Commit != "" {
+			text += "  " + ui.FormatCommit(e.Commit)
+		}
+	case history.KindHeartbeat:
+		icon, text = ui.ErrorStyle.Render(ui.IconError), ui.ErrorStyle.Render("heartbeat failed")
+	default:
+		icon, text = ui.MutedStyle.Render("•"), e.Title
+		switch e.Severity {
+		case notify.SeverityCritical:
+			icon, text = ui.ErrorStyle.Render(ui.IconError), ui.ErrorStyle.Render(e.Title)
+		case notify.SeverityWarning:
+			icon, text = ui.WarningStyle.Render(ui.IconWarning), ui.WarningStyle.Render(e.Title)
+		}
+	}
+	if e.Detail != "" {
+		text += ui.MutedStyle.Render(": " + ui.Truncate(e.Detail, 60))
+	}
+	fmt.Printf("%s  %s %s %s\n", ui.MutedStyle.Render(e.Time.Local().Format("Jan 2 15:04:05")),
+		ui.Pad(subject, 20), icon, text)
+}
+
+// recordEvents adds notification events to history for the activity feed.
+func recordEvents(events ...notify.Event) {
+	entries := make([]history.Entry, len(events))
+	for i, ev := range events {
+		entries[i] = history.Entry{
+			Time: ev.Time, Kind: history.KindEvent, Project: ev.Project, Service: ev.Service,
+			Type: ev.Type, Severity: ev.Severity, Title: ev.Title, Detail: ev.Message, Commit: ev.Commit,
+		}
+	}
+	recordHistory(entries...)
+}
+
+// recordDeployDetected records a deployment orbit watch has just picked up.
+func recordDeployDetected(projectName, service string, d *platform.Deployment) {
+	status := d.Status
+	if status == "" {
+		status = "detected"
+	}
+	recordHistory(history.Entry{
+		Time: time.Now(), Kind: history.KindDeploy, Project: projectName, Service: service,
+		Status: status, DeployID: d.ID, Commit: d.Commit, Detail: d.Message,
+	})
+}
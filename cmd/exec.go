@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execService  string
+	execInstance string
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec <project> --service <name> [-- command [args...]]",
+	Short: "Open a shell or run a command in a service's instance",
+	Long: `Open an interactive shell in a running instance of a service, or run one
+command there. The command goes after --; without one you get a shell.
+
+  orbit exec myshop --service api
+  orbit exec myshop --service api -- rails db:migrate:status
+  orbit exec myshop --service api --instance 5a3c9f -- env
+
+The platform's own CLI does the work, authenticated with Orbit's saved
+token, so it must be installed: koyeb (instances exec) for Koyeb, fly (ssh
+console) for Fly.io. Without --instance, the first healthy instance is used;
+orbit instances lists them. The command's exit code is Orbit's.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		project := args
+		if n := cmd.ArgsLenAtDash(); n >= 0 {
+			project = args[:n]
+		}
+		if len(project) != 1 {
+			return fmt.Errorf("accepts 1 project before --, received %d", len(project))
+		}
+		return nil
+	},
+	RunE: runExec,
+}
+
+func init() {
+	execCmd.Flags().StringVar(&execService, "service", "", "Service name (required)")
+	execCmd.Flags().StringVar(&execInstance, "instance", "", "Instance ID (default: the first healthy instance)")
+	execCmd.MarkFlagRequired("service")
+	rootCmd.AddCommand(execCmd)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	var command []string
+	if n := cmd.ArgsLenAtDash(); n >= 0 {
+		command = args[n:]
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+	resolved, err := resolveService(cfg, key, args[0], execService)
+	if err != nil {
+		return err
+	}
+	ex, ok := resolved.Platform.(platform.Executor)
+	if !ok {
+		return fmt.Errorf("%s does not support exec (service %s)\nSupported on: koyeb, flyio", resolved.Entry.Platform, resolved.Entry.Name)
+	}
+	cmd.SilenceUsage = true
+
+	c, err := ex.ExecCommand(cmd.Context(), resolved.Entry.ID, platform.ExecOptions{Instance: execInstance, Command: command})
+	if err != nil {
+		return fmt.Errorf("exec into %s: %w", resolved.Entry.Name, err)
+	}
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if len(command) == 0 {
+		fmt.Fprintf(os.Stderr, "%s Opening a shell in %s/%s (%s)...\n", ui.IconRocket, args[0], resolved.Entry.Name, resolved.Entry.Platform)
+	}
+
+	// Ctrl+C belongs to the remote command.
+	passInterrupts.Store(true)
+	defer passInterrupts.Store(false)
+	err = c.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: exitErr.ExitCode(), Msg: ""}
+	}
+	if err != nil {
+		return fmt.Errorf("exec into %s: %w", resolved.Entry.Name, err)
+	}
+	return nil
+}
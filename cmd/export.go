@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	exportOut      string
+	exportFormat   string
+	exportNoTokens bool
+
+	importOverwrite bool
+	importDryRun    bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [project...]",
+	Short: "Export projects, topologies, thresholds and heartbeats to a bundle",
+	Long: `Write the configuration to a portable YAML or JSON bundle that orbit
+import restores on another machine.
+
+  orbit export --out orbit.yaml                 Everything, to move machines
+  orbit export myshop --no-tokens -o myshop.yaml  One project, to share with a team
+
+A full export holds the connected platforms, every project with its
+topology, views, alerts and heartbeats, and the settings: thresholds, SLOs,
+watch exit policy, integrations, notifications and hooks. Naming projects
+exports only those and the platforms their services use.
+
+Tokens and passwords are written in plain text, since the key that encrypts
+them stays on this machine; --no-tokens leaves them out, and whoever imports
+the bundle connects the platforms themselves. Bundles with tokens are
+written readable by you only.`,
+	RunE: runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Restore a bundle written by orbit export",
+	Long: `Restore projects, platforms and settings from a bundle written by orbit
+export ("-" reads standard input).
+
+  orbit import orbit.yaml
+  orbit import myshop.yaml --dry-run
+  orbit import orbit.yaml --overwrite
+
+Projects and platforms already configured here are kept unless --overwrite
+is given. Settings from a full export are restored on a new config, or with
+--overwrite. A platform the bundle has no token for keeps its token here; if
+it isn't connected yet, connect it with orbit connect. To add services from
+a manifest to an existing project, see orbit service import.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "", "Write the bundle to this file instead of standard output")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Bundle format: yaml (default) or json; a .json --out implies json")
+	exportCmd.Flags().BoolVar(&exportNoTokens, "no-tokens", false, "Leave tokens and passwords out of the bundle")
+	importCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Replace projects, platforms and settings already configured")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would change without saving")
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format := exportFormat
+	if format == "" && strings.EqualFold(filepath.Ext(exportOut), ".json") {
+		format = "json"
+	}
+	if format != "" && format != "yaml" && format != "json" {
+		return fmt.Errorf("unknown format %q (want yaml or json)", format)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	var key []byte
+	if !exportNoTokens {
+		if key, err = config.LoadOrCreateKey(); err != nil {
+			return fmt.Errorf("load encryption key: %w", err)
+		}
+	}
+	for _, name := range args {
+		if _, err := resolveProject(cfg, name); err != nil {
+			return err
+		}
+	}
+
+	bundle, err := config.NewBundle(cfg, key, args, !exportNoTokens, time.Now())
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(bundle); err != nil {
+		return fmt.Errorf("marshal bundle: %w", err)
+	}
+	data := buf.Bytes()
+	if format == "json" {
+		// Config structs only carry YAML field names, so convert through
+		// YAML to keep them in JSON.
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("marshal bundle: %w", err)
+		}
+		if data, err = json.MarshalIndent(doc, "", "  "); err != nil {
+			return fmt.Errorf("marshal bundle: %w", err)
+		}
+		data = append(data, '\n')
+	}
+
+	if exportOut == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	perm := os.FileMode(0644)
+	if !exportNoTokens {
+		perm = 0600
+	}
+	if err := os.WriteFile(exportOut, data, perm); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	fmt.Printf("%s Exported %d project(s) and %d platform(s) to %s\n", ui.IconSuccess, len(bundle.Projects), len(bundle.Platforms), exportOut)
+	if !exportNoTokens && len(bundle.Platforms) > 0 {
+		fmt.Printf("  %s %s\n", ui.IconWarning, ui.WarningStyle.Render("The bundle holds tokens in plain text; use --no-tokens to share it"))
+	}
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("read bundle: %w", err)
+	}
+	// JSON is YAML, so one decoder reads both formats.
+	var bundle config.Bundle
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&bundle); err != nil {
+		return fmt.Errorf("parse bundle %s: %w", args[0], err)
+	}
+	if bundle.Version == 0 {
+		return fmt.Errorf("%s is not an orbit export bundle (no version)", args[0])
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+	cmd.SilenceUsage = true
+
+	sum, err := config.Import(cfg, &bundle, key, importOverwrite)
+	if err != nil {
+		return err
+	}
+	for _, s := range sum.Added {
+		fmt.Printf("  %s %s\n", ui.IconHealthy, s)
+	}
+	for _, s := range sum.Replaced {
+		fmt.Printf("  %s %s %s\n", ui.IconHealthy, s, ui.MutedStyle.Render("(replaced)"))
+	}
+	for _, s := range sum.Skipped {
+		fmt.Printf("  %s\n", ui.MutedStyle.Render("- "+s+" already configured, kept (--overwrite replaces it)"))
+	}
+	for _, name := range sum.NeedToken {
+		fmt.Printf("  %s platform %s has no token in the bundle; run: orbit connect %s\n", ui.IconWarning, name, name)
+	}
+
+	if len(sum.Added)+len(sum.Replaced) == 0 {
+		fmt.Println("  Nothing to import.")
+		return nil
+	}
+	if importDryRun {
+		fmt.Printf("\n  %s\n", ui.MutedStyle.Render("Dry run, nothing saved."))
+		return nil
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	fmt.Printf("\n%s Imported bundle from %s\n", ui.IconSuccess, bundle.ExportedAt.Local().Format("Jan 2 2006 15:04"))
+	return nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/integration"
+)
+
+// githubToken returns the decrypted GitHub token from config,
+// falling back to $GITHUB_TOKEN (set automatically in GitHub Actions).
+func githubToken(cfg *config.Config, key []byte) (string, error) {
+	if enc := cfg.Integrations.GitHub.Token; enc != "" {
+		token, err := config.Decrypt(key, enc)
+		if err != nil {
+			return "", fmt.Errorf("decrypt github token: %w", err)
+		}
+		return token, nil
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("no GitHub token configured\nRun: orbit config set github.token <token> (or set $GITHUB_TOKEN)")
+}
+
+// githubRepo returns the owner/name repository to comment on,
+// falling back to $GITHUB_REPOSITORY (set automatically in GitHub Actions).
+func githubRepo(cfg *config.Config) (string, error) {
+	if cfg.Integrations.GitHub.Repo != "" {
+		return cfg.Integrations.GitHub.Repo, nil
+	}
+	if repo := os.Getenv("GITHUB_REPOSITORY"); repo != "" {
+		return repo, nil
+	}
+	return "", fmt.Errorf("no GitHub repository configured\nRun: orbit config set github.repo <owner/name> (or set $GITHUB_REPOSITORY)")
+}
+
+// postWatchPRComment posts or updates a PR comment summarizing watch results.
+func postWatchPRComment(cfg *config.Config, key []byte, projectName string, pr int, results []watchResult) error {
+	token, err := githubToken(cfg, key)
+	if err != nil {
+		return err
+	}
+	repo, err := githubRepo(cfg)
+	if err != nil {
+		return err
+	}
+
+	marker := fmt.Sprintf("<!-- orbit:watch:%s -->", projectName)
+	gh := integration.NewGitHub(token)
+	return gh.UpsertPRComment(repo, pr, marker, renderWatchMarkdown(projectName, results))
+}
+
+// renderWatchMarkdown renders watch results as a Markdown table for PR comments.
+func renderWatchMarkdown(projectName string, results []watchResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Orbit deploy: %s\n\n", projectName)
+	b.WriteString("| Service | Platform | Result | Deploy | Duration | Preview |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+
+	for _, r := range results {
+		j := resultToJSON(r)
+
+		icon := "✅"
+		switch j.Result {
+		case "failed":
+			icon = "❌"
+		case "no_deployment", "timeout", "cancelled":
+			icon = "⚠️"
+		}
+
+		deploy := "—"
+		if r.DeployID != "" {
+			deploy = "`" + shortID(r.DeployID) + "`"
+		}
+		duration := "—"
+		if r.Duration > 0 {
+			duration = fmt.Sprintf("%ds", int(r.Duration.Seconds()))
+		}
+		preview := "—"
+		if r.URL != "" {
+			preview = r.URL
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s %s | %s | %s | %s |\n",
+			r.ServiceName, r.Platform, icon, j.Result, deploy, duration, preview)
+	}
+
+	for _, r := range results {
+		if r.ExitCode == exitFailed && r.Error != "" {
+			fmt.Fprintf(&b, "\n**%s:** %s\n", r.ServiceName, r.Error)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/integration"
+)
+
+// gitlabToken returns the decrypted GitLab token from config,
+// falling back to $GITLAB_TOKEN.
+func gitlabToken(cfg *config.Config, key []byte) (string, error) {
+	if enc := cfg.Integrations.GitLab.Token; enc != "" {
+		token, err := config.Decrypt(key, enc)
+		if err != nil {
+			return "", fmt.Errorf("decrypt gitlab token: %w", err)
+		}
+		return token, nil
+	}
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("no GitLab token configured\nRun: orbit config set gitlab.token <token> (or set $GITLAB_TOKEN)")
+}
+
+// gitlabProject returns the GitLab project ID or path,
+// falling back to $CI_PROJECT_ID (set automatically in GitLab CI).
+func gitlabProject(cfg *config.Config) (string, error) {
+	if cfg.Integrations.GitLab.Project != "" {
+		return cfg.Integrations.GitLab.Project, nil
+	}
+	if id := os.Getenv("CI_PROJECT_ID"); id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("no GitLab project configured\nRun: orbit config set gitlab.project <id or group/name> (or set $CI_PROJECT_ID)")
+}
+
+// gitlabURL returns the GitLab API base URL, falling back to $CI_API_V4_URL.
+func gitlabURL(cfg *config.Config) string {
+	if cfg.Integrations.GitLab.URL != "" {
+		return cfg.Integrations.GitLab.URL
+	}
+	return os.Getenv("CI_API_V4_URL")
+}
+
+// setWatchGitLabStatus sets a commit status per watched service.
+// The deployed commit is used when known, otherwise $CI_COMMIT_SHA.
+func setWatchGitLabStatus(cfg *config.Config, key []byte, projectName string, results []watchResult) error {
+	token, err := gitlabToken(cfg, key)
+	if err != nil {
+		return err
+	}
+	project, err := gitlabProject(cfg)
+	if err != nil {
+		return err
+	}
+
+	gl := integration.NewGitLab(token, gitlabURL(cfg))
+	for _, r := range results {
+		sha := r.Commit
+		if sha == "" {
+			sha = os.Getenv("CI_COMMIT_SHA")
+		}
+		if sha == "" {
+			return fmt.Errorf("%s: no commit SHA to report status for", r.ServiceName)
+		}
+
+		st := integration.CommitStatus{
+			Name:        fmt.Sprintf("orbit/%s/%s", projectName, r.ServiceName),
+			Description: watchStatusDescription(r),
+			TargetURL:   r.URL,
+			State:       "success",
+		}
+		switch r.ExitCode {
+		case exitFailed:
+			st.State = "failed"
+		case exitNoDeployment, exitTimeout:
+			st.State = "canceled"
+		}
+
+		if err := gl.SetCommitStatus(project, sha, st); err != nil {
+			return fmt.Errorf("%s: %w", r.ServiceName, err)
+		}
+	}
+	return nil
+}
+
+// watchStatusDescription returns a one-line summary of a watch result for commit statuses.
+func watchStatusDescription(r watchResult) string {
+	if r.Cancelled {
+		return r.Error
+	}
+	switch r.ExitCode {
+	case exitSuccess:
+		return fmt.Sprintf("Deployed in %ds", int(r.Duration.Seconds()))
+	case exitFailed:
+		if r.Error != "" {
+			return "Deploy failed: " + r.Error
+		}
+		return "Deploy failed"
+	case exitNoDeployment:
+		return "No new deployment detected"
+	default:
+		return fmt.Sprintf("Deploy still in progress (%s)", r.Phase)
+	}
+}
+
+var dotenvKeyRe = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// writeWatchDotenv writes watch results as a GitLab dotenv report artifact.
+// Per-service keys are prefixed with ORBIT_<SERVICE>_; a single-service watch
+// also gets unprefixed ORBIT_* keys for convenience.
+func writeWatchDotenv(path string, results []watchResult, code int) error {
+	vars := map[string]string{
+		"ORBIT_RESULT":    exitCodeName(code),
+		"ORBIT_EXIT_CODE": fmt.Sprintf("%d", code),
+	}
+
+	add := func(prefix string, r watchResult) {
+		j := resultToJSON(r)
+		vars[prefix+"RESULT"] = j.Result
+		if r.DeployID != "" {
+			vars[prefix+"DEPLOY_ID"] = r.DeployID
+		}
+		if r.Commit != "" {
+			vars[prefix+"COMMIT"] = r.Commit
+		}
+		if r.URL != "" {
+			vars[prefix+"URL"] = r.URL
+		}
+		if r.Duration > 0 {
+			vars[prefix+"DURATION_SEC"] = fmt.Sprintf("%d", int(r.Duration.Seconds()))
+		}
+	}
+
+	for _, r := range results {
+		name := strings.Trim(dotenvKeyRe.ReplaceAllString(strings.ToUpper(r.ServiceName), "_"), "_")
+		add("ORBIT_"+name+"_", r)
+	}
+	if len(results) == 1 {
+		add("ORBIT_", results[0])
+	}
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		// dotenv reports don't support quoting or multi-line values
+		v := strings.ReplaceAll(vars[k], "\n", " ")
+		fmt.Fprintf(&b, "%s=%s\n", k, v)
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("write dotenv report: %w", err)
+	}
+	return nil
+}
+
+// exitCodeName maps a watch exit code to its JSON result name.
+func exitCodeName(code int) string {
+	switch code {
+	case exitSuccess:
+		return "success"
+	case exitFailed:
+		return "failed"
+	case exitNoDeployment:
+		return "no_deployment"
+	case exitTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
+var gitlabSectionRe = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// gitlabSections emits GitLab job log section markers as watch phases change,
+// so each phase is collapsible in the job log.
+type gitlabSections struct {
+	enabled bool
+	prefix  string
+	current string
+}
+
+func newGitLabSections(enabled bool, serviceName string) *gitlabSections {
+	return &gitlabSections{
+		enabled: enabled,
+		prefix:  "orbit_" + gitlabSectionRe.ReplaceAllString(serviceName, "_"),
+	}
+}
+
+// enter closes the open section (if any) and opens one for phase.
+func (s *gitlabSections) enter(phase, header string) {
+	if !s.enabled || phase == s.current {
+		return
+	}
+	s.close()
+	s.current = phase
+	fmt.Println(integration.GitLabSectionStart(s.prefix+"_"+phase, header, false))
+}
+
+// close ends the currently open section.
+func (s *gitlabSections) close() {
+	if !s.enabled || s.current == "" {
+		return
+	}
+	fmt.Println(integration.GitLabSectionEnd(s.prefix + "_" + s.current))
+	s.current = ""
+}
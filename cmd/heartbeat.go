@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -9,6 +11,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,17 +19,20 @@ import (
 	"time"
 
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/heartbeat"
+	"github.com/humanetools/orbit/internal/history"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	heartbeatService  string
-	heartbeatURL      string
-	heartbeatInterval string
-	heartbeatRemove   bool
-	heartbeatRunSvc   string
-	heartbeatDaemon   bool
+	heartbeatService      string
+	heartbeatURL          string
+	heartbeatInterval     string
+	heartbeatRemove       bool
+	heartbeatRunSvc       string
+	heartbeatDaemon       bool
+	heartbeatStatusFormat string
 )
 
 var heartbeatCmd = &cobra.Command{
@@ -51,13 +57,43 @@ var heartbeatRunCmd = &cobra.Command{
 
   orbit heartbeat run myshop                  Ping all services
   orbit heartbeat run myshop --service api    Ping specific service only
-  orbit heartbeat run myshop --daemon         Run in background
+  orbit heartbeat run myshop --daemon         Run in background (same as start)
 
-Interval supports random ranges (e.g. 10s-40s) for bot detection avoidance.`,
+Interval supports random ranges (e.g. 10s-40s) for bot detection avoidance.
+
+Services with a remediation policy in config are restarted after repeated
+failed checks (see "remediation" in the README). Services with a policy but
+no heartbeat URL are checked through the platform's status every minute.
+Every restart is recorded in ~/.orbit/remediation.log.
+
+Reports configured under notifications.reports are sent from the daemon on
+their daily or weekly schedule, with deploy counts, uptime from the daemon's
+own checks, and thresholds exceeded at report time.
+
+Alert rules of the project (see orbit alerts) are evaluated every minute.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runHeartbeatDaemon,
 }
 
+var heartbeatStartCmd = &cobra.Command{
+	Use:   "start <project>",
+	Short: "Start the heartbeat daemon in the background",
+	Long: `Start the heartbeat daemon detached from the terminal. It keeps pinging
+registered heartbeat URLs on their intervals until stopped.
+
+  orbit heartbeat start myshop
+  orbit heartbeat status myshop
+  orbit heartbeat stop myshop
+
+Output goes to ~/.orbit/heartbeat-<project>.log and the daemon's PID to
+~/.orbit/heartbeat-<project>.pid. Only one daemon runs per project.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return startHeartbeatDaemon(args[0])
+	},
+}
+
 var heartbeatStopCmd = &cobra.Command{
 	Use:   "stop <project>",
 	Short: "Stop a background heartbeat daemon",
@@ -65,6 +101,19 @@ var heartbeatStopCmd = &cobra.Command{
 	RunE:  stopHeartbeatDaemon,
 }
 
+var heartbeatStatusCmd = &cobra.Command{
+	Use:   "status <project>",
+	Short: "Show whether the heartbeat daemon runs and what it has seen",
+	Long: `Show whether a heartbeat daemon is running for the project and the
+latest check of each service it watches: result, when it ran, how many checks
+passed since the daemon started, and how long a service has been failing.
+
+The daemon records every check in ~/.orbit/heartbeat-<project>.json; after it
+stops, status shows its last run.`,
+	Args: cobra.ExactArgs(1),
+	RunE: showHeartbeatDaemon,
+}
+
 func init() {
 	heartbeatCmd.Flags().StringVar(&heartbeatService, "service", "", "Service name")
 	heartbeatCmd.Flags().StringVar(&heartbeatURL, "url", "", "Health check URL")
@@ -73,8 +122,12 @@ func init() {
 
 	heartbeatRunCmd.Flags().StringVar(&heartbeatRunSvc, "service", "", "Ping specific service only")
 	heartbeatRunCmd.Flags().BoolVarP(&heartbeatDaemon, "daemon", "d", false, "Run in background")
+	heartbeatStartCmd.Flags().StringVar(&heartbeatRunSvc, "service", "", "Ping specific service only")
+	heartbeatStatusCmd.Flags().StringVar(&heartbeatStatusFormat, "format", "", "Output format (json)")
 	heartbeatCmd.AddCommand(heartbeatRunCmd)
+	heartbeatCmd.AddCommand(heartbeatStartCmd)
 	heartbeatCmd.AddCommand(heartbeatStopCmd)
+	heartbeatCmd.AddCommand(heartbeatStatusCmd)
 
 	rootCmd.AddCommand(heartbeatCmd)
 }
@@ -198,9 +251,9 @@ func showHeartbeats(projectName string, proj *config.ProjectConfig) error {
 
 		statusStr := ""
 		if err != nil {
-			statusStr = ui.ErrorStyle.Render(fmt.Sprintf("✗ %s", err))
+			statusStr = ui.ErrorStyle.Render(fmt.Sprintf("%s %s", ui.IconError, err))
 		} else {
-			statusStr = ui.HealthyStyle.Render(fmt.Sprintf("✓ %dms", respTime))
+			statusStr = ui.HealthyStyle.Render(fmt.Sprintf("%s %dms", ui.IconHealthy, respTime))
 		}
 
 		fmt.Printf("  %-12s  %-40s  %s  %s\n",
@@ -253,13 +306,32 @@ func randomDuration(min, max time.Duration) time.Duration {
 }
 
 func heartbeatPidPath(project string) string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".orbit", fmt.Sprintf("heartbeat-%s.pid", project))
+	dir, _ := config.Dir()
+	return filepath.Join(dir, fmt.Sprintf("heartbeat-%s.pid", project))
 }
 
 func heartbeatLogPath(project string) string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".orbit", fmt.Sprintf("heartbeat-%s.log", project))
+	dir, _ := config.Dir()
+	return filepath.Join(dir, fmt.Sprintf("heartbeat-%s.log", project))
+}
+
+func heartbeatStatePath(project string) string {
+	dir, _ := config.Dir()
+	return filepath.Join(dir, fmt.Sprintf("heartbeat-%s.json", project))
+}
+
+// runningHeartbeat returns the PID of the project's heartbeat daemon if its
+// PID file names a live process.
+func runningHeartbeat(project string) (int, bool) {
+	data, err := os.ReadFile(heartbeatPidPath(project))
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, processAlive(pid)
 }
 
 func stopHeartbeatDaemon(cmd *cobra.Command, args []string) error {
@@ -283,7 +355,7 @@ func stopHeartbeatDaemon(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("process %d not found, removed stale PID file", pid)
 	}
 
-	if err := proc.Signal(syscall.SIGTERM); err != nil {
+	if err := stopProcess(proc); err != nil {
 		os.Remove(pidFile)
 		return fmt.Errorf("process %d not running, removed stale PID file", pid)
 	}
@@ -293,45 +365,61 @@ func stopHeartbeatDaemon(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runHeartbeatDaemon(cmd *cobra.Command, args []string) error {
-	projectName := args[0]
+// startHeartbeatDaemon runs "heartbeat run" as a detached child process.
+func startHeartbeatDaemon(projectName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if _, ok := cfg.Projects[projectName]; !ok {
+		return fmt.Errorf("project %q not found\nAvailable projects: %s", projectName, projectNames(cfg))
+	}
+	if pid, ok := runningHeartbeat(projectName); ok {
+		return fmt.Errorf("heartbeat daemon already running for %q (PID %d)\nStop it: orbit heartbeat stop %s", projectName, pid, projectName)
+	}
 
-	// --daemon: fork self in background
-	if heartbeatDaemon {
-		exePath, err := os.Executable()
-		if err != nil {
-			return fmt.Errorf("resolve executable: %w", err)
-		}
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolve executable: %w", err)
+	}
 
-		forkArgs := []string{"heartbeat", "run", projectName}
-		if heartbeatRunSvc != "" {
-			forkArgs = append(forkArgs, "--service", heartbeatRunSvc)
-		}
+	forkArgs := []string{"heartbeat", "run", projectName}
+	if heartbeatRunSvc != "" {
+		forkArgs = append(forkArgs, "--service", heartbeatRunSvc)
+	}
 
-		logFile, err := os.OpenFile(heartbeatLogPath(projectName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-		if err != nil {
-			return fmt.Errorf("open log file: %w", err)
-		}
+	logFile, err := os.OpenFile(heartbeatLogPath(projectName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
 
-		child := exec.Command(exePath, forkArgs...)
-		child.Stdout = logFile
-		child.Stderr = logFile
-		setSysProcAttr(child)
+	child := exec.Command(exePath, forkArgs...)
+	child.Stdout = logFile
+	child.Stderr = logFile
+	setSysProcAttr(child)
 
-		if err := child.Start(); err != nil {
-			logFile.Close()
-			return fmt.Errorf("start daemon: %w", err)
-		}
+	if err := child.Start(); err != nil {
 		logFile.Close()
+		return fmt.Errorf("start daemon: %w", err)
+	}
+	logFile.Close()
 
-		if err := os.WriteFile(heartbeatPidPath(projectName), []byte(strconv.Itoa(child.Process.Pid)), 0644); err != nil {
-			return fmt.Errorf("write PID file: %w", err)
-		}
+	if err := os.WriteFile(heartbeatPidPath(projectName), []byte(strconv.Itoa(child.Process.Pid)), 0644); err != nil {
+		return fmt.Errorf("write PID file: %w", err)
+	}
 
-		fmt.Printf("  %s Heartbeat daemon started in background (PID %d)\n", ui.IconSuccess, child.Process.Pid)
-		fmt.Printf("  Log: %s\n", heartbeatLogPath(projectName))
-		fmt.Printf("  Stop: orbit heartbeat stop %s\n", projectName)
-		return nil
+	fmt.Printf("  %s Heartbeat daemon started in background (PID %d)\n", ui.IconSuccess, child.Process.Pid)
+	fmt.Printf("  Log: %s\n", heartbeatLogPath(projectName))
+	fmt.Printf("  Status: orbit heartbeat status %s\n", projectName)
+	fmt.Printf("  Stop: orbit heartbeat stop %s\n", projectName)
+	return nil
+}
+
+func runHeartbeatDaemon(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+
+	if heartbeatDaemon {
+		return startHeartbeatDaemon(projectName)
 	}
 
 	cfg, err := config.Load()
@@ -346,57 +434,172 @@ func runHeartbeatDaemon(cmd *cobra.Command, args []string) error {
 
 	type target struct {
 		name     string
-		url      string
+		entry    config.ServiceEntry
+		url      string // empty: check platform status instead
 		min, max time.Duration
+		healer   *healer // nil without a remediation policy
 	}
 
+	var key []byte
 	var targets []target
 	for _, svc := range proj.Topology {
-		if svc.HeartbeatURL == "" {
+		if svc.HeartbeatURL == "" && svc.Remediation == nil {
 			continue
 		}
 		if heartbeatRunSvc != "" && svc.Name != heartbeatRunSvc {
 			continue
 		}
-		interval := svc.HeartbeatInterval
-		if interval == "" {
-			interval = "5m"
+		t := target{name: svc.Name, entry: svc, url: svc.HeartbeatURL, min: statusCheckInterval, max: statusCheckInterval}
+		if svc.HeartbeatURL != "" {
+			interval := svc.HeartbeatInterval
+			if interval == "" {
+				interval = "5m"
+			}
+			t.min, t.max, err = parseInterval(interval)
+			if err != nil {
+				return fmt.Errorf("service %q: %w", svc.Name, err)
+			}
 		}
-		mn, mx, err := parseInterval(interval)
-		if err != nil {
-			return fmt.Errorf("service %q: %w", svc.Name, err)
+		if svc.Remediation != nil {
+			if key == nil {
+				if key, err = config.LoadOrCreateKey(); err != nil {
+					return fmt.Errorf("load encryption key: %w", err)
+				}
+			}
+			if t.healer, err = newHealer(cfg, key, projectName, svc); err != nil {
+				return err
+			}
 		}
-		targets = append(targets, target{name: svc.Name, url: svc.HeartbeatURL, min: mn, max: mx})
+		targets = append(targets, t)
 	}
 
-	if len(targets) == 0 {
+	schedules, reports, err := projectReports(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	rules, err := parseAlertRules(cfg, projectName, &proj)
+	if err != nil {
+		return err
+	}
+	if heartbeatRunSvc != "" {
+		kept := rules[:0]
+		for _, r := range rules {
+			if r.Service == heartbeatRunSvc {
+				kept = append(kept, r)
+			}
+		}
+		rules = kept
+	}
+	if (len(schedules) > 0 || len(rules) > 0) && key == nil {
+		if key, err = config.LoadOrCreateKey(); err != nil {
+			return fmt.Errorf("load encryption key: %w", err)
+		}
+	}
+
+	if len(targets) == 0 && len(schedules) == 0 && len(rules) == 0 {
 		if heartbeatRunSvc != "" {
 			return fmt.Errorf("no heartbeat configured for service %q in project %q", heartbeatRunSvc, projectName)
 		}
 		return fmt.Errorf("no heartbeats configured in project %q\nRegister: orbit heartbeat %s --service <name> --url <health-url>", projectName, projectName)
 	}
 
+	if pid, ok := runningHeartbeat(projectName); ok && pid != os.Getpid() {
+		return fmt.Errorf("heartbeat daemon already running for %q (PID %d)\nStop it: orbit heartbeat stop %s", projectName, pid, projectName)
+	}
+	pidFile := heartbeatPidPath(projectName)
+	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return fmt.Errorf("write PID file: %w", err)
+	}
+	defer func() {
+		// Leave the file alone if another daemon has taken over since.
+		if pid, _ := runningHeartbeat(projectName); pid == os.Getpid() {
+			os.Remove(pidFile)
+		}
+	}()
+	rec, err := heartbeat.NewRecorder(heartbeatStatePath(projectName), os.Getpid(), time.Now())
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
 	fmt.Printf("\n  %s Heartbeat daemon started for %s (%d services)\n",
 		ui.IconSuccess, ui.ProjectTitleStyle.Render(projectName), len(targets))
+	for _, s := range schedules {
+		fmt.Printf("  Next %s report: %s\n", s.Every, s.Next(time.Now()).Format("Mon Jan 2 15:04 MST"))
+	}
+	if len(rules) > 0 {
+		fmt.Printf("  Evaluating %d alert rules every %s\n", len(rules), alertCheckInterval)
+	}
 	fmt.Printf("  Press Ctrl+C to stop.\n\n")
 
 	var wg sync.WaitGroup
+	counts := newCheckCounts()
+	runReports(ctx, &wg, cfg, key, projectName, counts, schedules, reports)
+	runAlerts(ctx, &wg, cfg, key, projectName, rules)
 	for _, t := range targets {
 		wg.Add(1)
 		go func(t target) {
 			defer wg.Done()
+			failing := false
 			for {
-				respTime, err := pingURL(t.url)
-				now := time.Now().Format("15:04:05")
-				if err != nil {
-					fmt.Printf("  [%s] %-12s  %s %s\n", now,
-						t.name, ui.ErrorStyle.Render("✗"), ui.ErrorStyle.Render(err.Error()))
+				at := time.Now()
+				now := at.Format("15:04:05")
+				record := func(c heartbeat.Check) {
+					c.Time = at
+					counts.record(t.name, c.OK)
+					if err := rec.Record(t.name, c); err != nil {
+						fmt.Printf("  [%s] %-12s  %s %v\n", now, t.name, ui.WarningStyle.Render(ui.IconWarning), err)
+					}
+					status := "healthy"
+					if !c.OK {
+						status = "unhealthy"
+					}
+					recordHistory(history.Entry{
+						Time: at, Kind: history.KindHeartbeat, Project: projectName, Service: t.name,
+						Status: status, ResponseMs: c.LatencyMs, Detail: c.Detail,
+					})
+					if c.OK == failing {
+						failing = !c.OK
+						notifyHeartbeat(cfg.Notifications, projectName, t.entry, c)
+						if err := recordHeartbeatIncident(projectName, t.name, c.OK, c.Detail); err != nil {
+							fmt.Printf("  [%s] %-12s  %s %v\n", now, t.name, ui.WarningStyle.Render(ui.IconWarning), err)
+						}
+					}
+				}
+				if t.url != "" {
+					respTime, err := pingURL(t.url)
+					if err != nil {
+						fmt.Printf("  [%s] %-12s  %s %s\n", now,
+							t.name, ui.ErrorStyle.Render(ui.IconError), ui.ErrorStyle.Render(err.Error()))
+					} else {
+						fmt.Printf("  [%s] %-12s  %s %dms\n", now,
+							t.name, ui.HealthyStyle.Render(ui.IconHealthy), respTime)
+					}
+					c := heartbeat.Check{OK: err == nil, LatencyMs: respTime}
+					if err != nil {
+						c.Detail = err.Error()
+					}
+					record(c)
+					if t.healer != nil {
+						reason := "heartbeat ok"
+						if err != nil {
+							reason = "heartbeat " + err.Error()
+						}
+						t.healer.observe(ctx, err == nil, reason)
+					}
+				} else if healthy, detail, ok := t.healer.checkStatus(ctx); !ok {
+					fmt.Printf("  [%s] %-12s  %s status check failed: %s\n", now,
+						t.name, ui.WarningStyle.Render(ui.IconWarning), detail)
 				} else {
-					fmt.Printf("  [%s] %-12s  %s %dms\n", now,
-						t.name, ui.HealthyStyle.Render("✓"), respTime)
+					icon := ui.HealthyStyle.Render(ui.IconHealthy)
+					if !healthy {
+						icon = ui.ErrorStyle.Render(ui.IconError)
+					}
+					fmt.Printf("  [%s] %-12s  %s %s\n", now, t.name, icon, detail)
+					record(heartbeat.Check{OK: healthy, Detail: detail})
+					t.healer.observe(ctx, healthy, "status "+detail)
 				}
 
 				wait := randomDuration(t.min, t.max)
@@ -430,3 +633,86 @@ func pingURL(url string) (int64, error) {
 	}
 	return elapsed, nil
 }
+
+func showHeartbeatDaemon(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+	pid, running := runningHeartbeat(projectName)
+	state, err := heartbeat.Load(heartbeatStatePath(projectName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	names := []string{}
+	if state != nil {
+		for name := range state.Services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	if heartbeatStatusFormat == "json" {
+		out := struct {
+			Project   string                        `json:"project"`
+			Running   bool                          `json:"running"`
+			PID       int                           `json:"pid,omitempty"`
+			StartedAt *time.Time                    `json:"started_at,omitempty"`
+			Log       string                        `json:"log"`
+			Services  map[string]*heartbeat.Service `json:"services"`
+		}{Project: projectName, Running: running, Log: heartbeatLogPath(projectName), Services: map[string]*heartbeat.Service{}}
+		if running {
+			out.PID = pid
+		}
+		if state != nil {
+			out.StartedAt = &state.StartedAt
+			out.Services = state.Services
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName), ui.MutedStyle.Render("heartbeat daemon"))
+	switch {
+	case running && state != nil && state.PID == pid:
+		fmt.Printf("  %s Running (PID %d) since %s\n", ui.IconSuccess, pid, state.StartedAt.Local().Format("Mon Jan 2 15:04"))
+	case running:
+		fmt.Printf("  %s Running (PID %d)\n", ui.IconSuccess, pid)
+	case state != nil:
+		fmt.Printf("  %s Not running %s\n", ui.MutedStyle.Render("-"),
+			ui.MutedStyle.Render("(last run started "+ui.TimeAgo(state.StartedAt)+")"))
+	default:
+		fmt.Printf("  %s Not running\n", ui.MutedStyle.Render("-"))
+	}
+
+	if len(names) == 0 {
+		fmt.Println(ui.MutedStyle.Render("\n  No checks recorded yet."))
+	} else {
+		fmt.Println()
+	}
+	for _, name := range names {
+		s := state.Services[name]
+		result := ui.HealthyStyle.Render(ui.IconHealthy + " " + s.Last.Detail)
+		if s.Last.LatencyMs > 0 {
+			result = ui.HealthyStyle.Render(fmt.Sprintf("%s %dms", ui.IconHealthy, s.Last.LatencyMs))
+		}
+		if !s.Last.OK {
+			result = ui.ErrorStyle.Render(ui.IconError + " " + s.Last.Detail)
+		}
+		summary := fmt.Sprintf("%d checks, %.1f%% up", s.Checks, s.Uptime())
+		if s.Streak > 1 {
+			summary += fmt.Sprintf(", failing for %d checks", s.Streak)
+		}
+		fmt.Printf("  %s %s %s %s\n", ui.Pad(name, 14), ui.Pad(result, 24),
+			ui.Pad(ui.TimeAgo(s.Last.Time), 10), ui.MutedStyle.Render(summary))
+	}
+
+	fmt.Printf("\n  Log: %s\n", heartbeatLogPath(projectName))
+	if !running {
+		fmt.Printf("  Start: orbit heartbeat start %s\n", projectName)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// printHints suggests what to run next after a setup command. Hints go to
+// stderr and only to a terminal, so scripts never see them; `orbit config
+// set hints false` turns them off for good.
+func printHints(cfg *config.Config, cmds ...string) {
+	if !cfg.HintsEnabled() || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n  %s\n", ui.MutedStyle.Render("Next:"))
+	for _, c := range cmds {
+		fmt.Fprintf(os.Stderr, "    %s\n", c)
+	}
+}
+
+// tip is one entry in `orbit tips`.
+type tip struct {
+	Command string
+	Summary string
+}
+
+var tips = []tip{
+	{"orbit config set default-project myshop", "Leave out the project name everywhere"},
+	{"orbit status myshop --require api,web", "Exit 1 unless these services are up; the rest are informational"},
+	{"orbit watch myshop --all --require api", "Gate a pipeline on the services that matter"},
+	{"orbit watch myshop --service api --format json | jq", "Results on stdout, progress on stderr"},
+	{"orbit status myshop --out status.json", "Keep the JSON result as a CI artifact"},
+	{"orbit logs myshop --service api --type build", "Build output instead of runtime logs"},
+	{"orbit logs myshop --service api -f --save-session s.log", "Record a session, then orbit logs replay s.log"},
+	{"orbit deploys myshop --service api --limit 20", "Deployment history with commits"},
+	{"orbit cancel myshop --service api", "Stop a deployment that is still building"},
+	{"orbit instances myshop --service api", "Per-instance state, region, uptime and restarts"},
+	{"orbit pause staging", "Stop paying for staging overnight; orbit resume brings it back"},
+	{"orbit search 3f2a", "Find a service, tag or deployment across projects"},
+	{"orbit service add myshop --tag payments --optional", "Route alerts by tag; optional services never fail a watch"},
+	{"orbit config set icons ascii", "Plain status markers for terminals without emoji"},
+	{"orbit config set hints false", "Stop printing next-step hints"},
+}
+
+var tipsCmd = &cobra.Command{
+	Use:   "tips",
+	Short: "Show lesser-known commands and flags",
+	Args:  cobra.NoArgs,
+	RunE:  runTips,
+}
+
+func init() {
+	rootCmd.AddCommand(tipsCmd)
+}
+
+func runTips(cmd *cobra.Command, args []string) error {
+	fmt.Printf("\n  %s\n\n", ui.ProjectTitleStyle.Render("Orbit tips"))
+	for _, t := range tips {
+		fmt.Printf("  %s\n", t.Command)
+		fmt.Printf("    %s\n", ui.MutedStyle.Render(t.Summary))
+	}
+	fmt.Println()
+	return nil
+}
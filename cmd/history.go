@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/history"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyService string
+	historyKind    string
+	historySince   string
+	historyLimit   int
+	historyFormat  string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [project]",
+	Short: "Show recorded status polls, deploys and heartbeat checks",
+	Long: `Show what orbit has recorded for a project: every status poll, every
+deployment state it has seen, every heartbeat daemon check, and the events
+(outages, threshold violations, restarts) orbit events shows.
+
+  orbit history
+  orbit history myshop --since 12h
+  orbit history myshop --service api --kind heartbeat --since 7d
+
+History is kept in ~/.orbit/history.db for 90 days.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyService, "service", "", "Only show this service")
+	historyCmd.Flags().StringVar(&historyKind, "kind", "", "Only show one kind of entry (status, deploy, heartbeat, event)")
+	historyCmd.Flags().StringVar(&historySince, "since", "24h", "How far back to look (e.g. 12h, 7d)")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 100, "Show at most this many of the newest entries (0 for all)")
+	historyCmd.Flags().StringVar(&historyFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	switch historyKind {
+	case "", history.KindStatus, history.KindDeploy, history.KindHeartbeat, history.KindEvent:
+	default:
+		return fmt.Errorf("unknown --kind %q (want status, deploy, heartbeat or event)", historyKind)
+	}
+	since, err := parseSince(historySince)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	if _, err := resolveProject(cfg, projectName); err != nil {
+		return err
+	}
+
+	path, err := history.Path()
+	if err != nil {
+		return err
+	}
+	store, err := history.Open(path)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	entries, err := store.Query(history.Query{
+		Project: projectName,
+		Service: historyService,
+		Kind:    historyKind,
+		Since:   time.Now().Add(-since),
+		Limit:   historyLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if historyFormat == "json" {
+		out := struct {
+			Project string          `json:"project"`
+			Entries []history.Entry `json:"entries"`
+		}{Project: projectName, Entries: entries}
+		if out.Entries == nil {
+			out.Entries = []history.Entry{}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName),
+		ui.MutedStyle.Render("history, last "+historySince))
+	if len(entries) == 0 {
+		fmt.Printf("  %s\n\n", ui.MutedStyle.Render("Nothing recorded yet. Entries are added by orbit status, watch, dashboard and heartbeat."))
+		return nil
+	}
+	fmt.Printf("  %s %s %s %s %s\n",
+		ui.Pad(ui.HeaderStyle.Render("Time"), 16),
+		ui.Pad(ui.HeaderStyle.Render("Service"), 14),
+		ui.Pad(ui.HeaderStyle.Render("Kind"), 10),
+		ui.Pad(ui.HeaderStyle.Render("Status"), 12),
+		ui.HeaderStyle.Render("Detail"),
+	)
+	for _, e := range entries {
+		fmt.Printf("  %s %s %s %s %s\n",
+			ui.Pad(e.Time.Local().Format("Jan 2 15:04:05"), 16),
+			ui.Pad(e.Service, 14),
+			ui.Pad(e.Kind, 10),
+			ui.Pad(historyStatus(e), 12),
+			ui.MutedStyle.Render(historyDetail(e)))
+	}
+	if historyLimit > 0 && len(entries) == historyLimit {
+		fmt.Printf("\n  %s\n", ui.MutedStyle.Render(fmt.Sprintf("Showing the newest %d entries; use --limit 0 for all.", historyLimit)))
+	}
+	fmt.Println()
+	return nil
+}
+
+// historyStatus is the status column: the status, or an event's severity.
+func historyStatus(e history.Entry) string {
+	if e.Kind == history.KindEvent {
+		return e.Severity
+	}
+	return ui.FormatStatus(e.Status)
+}
+
+// historyDetail summarizes an entry for the history table.
+func historyDetail(e history.Entry) string {
+	var parts []string
+	if e.Kind == history.KindDeploy {
+		parts = append(parts, shortID(e.DeployID))
+		if e.Commit != "" {
+			parts = append(parts, ui.FormatCommit(e.Commit))
+		}
+	}
+	if e.Title != "" {
+		parts = append(parts, e.Title)
+	}
+	if e.ResponseMs > 0 {
+		parts = append(parts, fmt.Sprintf("%dms", e.ResponseMs))
+	}
+	if e.Detail != "" {
+		parts = append(parts, ui.Truncate(e.Detail, 50))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// parseSince parses a look-back period: a Go duration such as 90m or 12h,
+// or a number of days such as 7d.
+func parseSince(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid --since %q (e.g. 12h, 7d)", s)
+}
+
+// recordHistory adds entries to the history store. History is a side record,
+// so failing to write it is only a warning.
+func recordHistory(entries ...history.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	path, err := history.Path()
+	if err == nil {
+		var store *history.Store
+		if store, err = history.Open(path); err == nil {
+			err = store.Add(entries...)
+			store.Close()
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+	}
+}
+
+// recordStatuses records a status poll of a project's services, along with
+// each service's latest deployment, and opens or resolves incidents on
+// services that went down or recovered. Cached statuses were recorded when
+// they were fetched, so they are skipped rather than counted as new samples.
+func recordStatuses(projectName string, results []ui.ServiceResult) {
+	now := time.Now()
+	var entries []history.Entry
+	for _, r := range results {
+		if r.Cached {
+			continue
+		}
+		e := history.Entry{Time: now, Kind: history.KindStatus, Project: projectName, Service: r.Entry.Name}
+		if r.Err != nil {
+			e.Status = "error"
+			e.Detail = r.Err.Error()
+			entries = append(entries, e)
+			continue
+		}
+		e.Status = r.Status.Status
+		e.ResponseMs = int64(r.Status.ResponseMs)
+		if r.Caps.Has(platform.CapInstances) && r.Status.Instances >= 0 {
+			n := r.Status.Instances
+			e.Instances = &n
+		}
+		if r.Status.Probe != nil && !r.Status.Probe.OK {
+			e.Detail = "probe: " + r.Status.Probe.Failure
+		}
+		entries = append(entries, e)
+		if d := r.Status.LastDeploy; d != nil && d.ID != "" {
+			entries = append(entries, history.Entry{
+				Time: now, Kind: history.KindDeploy, Project: projectName, Service: r.Entry.Name,
+				Status: d.Status, DeployID: d.ID, Commit: d.Commit, Detail: d.Message,
+			})
+		}
+	}
+	recordHistory(entries...)
+	recordStatusIncidents(projectName, results)
+}
+
+// recordWatchHistory records the final state of every watched deployment.
+func recordWatchHistory(projectName string, results []watchResult) {
+	now := time.Now()
+	var entries []history.Entry
+	for _, r := range results {
+		if r.DeployID == "" || r.Status == "" {
+			continue
+		}
+		detail := r.Message
+		if r.Error != "" {
+			detail = r.Error
+		}
+		entries = append(entries, history.Entry{
+			Time: now, Kind: history.KindDeploy, Project: projectName, Service: r.ServiceName,
+			Status: r.Status, DeployID: r.DeployID, Commit: r.Commit, Size: r.Size, Detail: detail,
+		})
+	}
+	recordHistory(entries...)
+}
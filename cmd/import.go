@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var (
+	serviceImportFile         string
+	serviceImportDryRun       bool
+	serviceImportSkipValidate bool
+)
+
+var serviceImportCmd = &cobra.Command{
+	Use:   "import <project>",
+	Short: "Add services to a project from a YAML or CSV manifest",
+	Long: `Add many services at once from a manifest file.
+
+YAML is a list of services (or a "services:" key holding one):
+
+  - name: api
+    platform: koyeb
+    id: svc_xxxx
+    tags: [backend]
+    optional: true      # see "orbit watch --help"
+
+CSV needs a header row with name, platform and id columns; an optional tags
+column holds tags separated by ";".
+
+Each row is checked against the services discovered on its platform; the
+id may also be the service's name there. Nothing is saved if any row fails.
+Services already in the project are skipped, and duplicate names can be
+renamed (api-koyeb, api-flyio) before saving.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runServiceImport,
+}
+
+func init() {
+	serviceImportCmd.Flags().StringVarP(&serviceImportFile, "file", "f", "", "Manifest file (.yaml, .yml or .csv)")
+	serviceImportCmd.Flags().BoolVar(&serviceImportDryRun, "dry-run", false, "Validate the manifest without saving")
+	serviceImportCmd.Flags().BoolVar(&serviceImportSkipValidate, "skip-validate", false, "Don't check rows against platform discovery")
+	serviceImportCmd.MarkFlagRequired("file")
+	serviceCmd.AddCommand(serviceImportCmd)
+}
+
+// importRow is one service in an import manifest.
+type importRow struct {
+	Name     string   `yaml:"name"`
+	Platform string   `yaml:"platform"`
+	ID       string   `yaml:"id"`
+	Tags     []string `yaml:"tags"`
+	Optional bool     `yaml:"optional"`
+
+	line int // position in the manifest, for error messages
+}
+
+func runServiceImport(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+
+	rows, err := readImportManifest(serviceImportFile)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no services found in %s", serviceImportFile)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	proj, ok := cfg.Projects[projectName]
+	if !ok {
+		return fmt.Errorf("project %q not found\nAvailable projects: %s", projectName, projectNames(cfg))
+	}
+
+	cmd.SilenceUsage = true
+
+	// Check every row before touching the config so a bad manifest
+	// doesn't leave a half-imported project.
+	var problems []string
+	for i := range rows {
+		r := &rows[i]
+		r.Platform = strings.ToLower(r.Platform)
+		switch {
+		case r.Name == "" || r.Platform == "" || r.ID == "":
+			problems = append(problems, fmt.Sprintf("row %d: name, platform and id are required", r.line))
+			continue
+		case !platform.IsSupported(r.Platform):
+			problems = append(problems, fmt.Sprintf("row %d (%s): unsupported platform %q", r.line, r.Name, r.Platform))
+			continue
+		}
+		if _, ok := cfg.Platforms[r.Platform]; !ok {
+			problems = append(problems, fmt.Sprintf("row %d (%s): platform %q not connected (run: orbit connect %s)", r.line, r.Name, r.Platform, r.Platform))
+		}
+	}
+	if len(problems) > 0 {
+		return importError(problems)
+	}
+
+	entries := make([]config.ServiceEntry, 0, len(rows))
+	for _, r := range rows {
+		entries = append(entries, config.ServiceEntry{
+			Name:     r.Name,
+			Platform: r.Platform,
+			ID:       r.ID,
+			Tags:     r.Tags,
+			Optional: r.Optional,
+		})
+	}
+
+	if !serviceImportSkipValidate {
+		if problems := validateImport(cmd.Context(), cfg, rows, entries); len(problems) > 0 {
+			return importError(problems)
+		}
+	}
+
+	// Names repeated in the manifest or already in the project get renamed.
+	entries = resolveDuplicateNames(proj.Topology, entries)
+	if len(entries) == 0 {
+		fmt.Println("  Nothing to import.")
+		return nil
+	}
+
+	for _, e := range entries {
+		fmt.Printf("  %s %-20s %-10s %s\n", ui.IconHealthy, e.Name, e.Platform, ui.MutedStyle.Render(e.ID))
+	}
+
+	if serviceImportDryRun {
+		fmt.Printf("\n  %d services valid (dry run, nothing saved)\n", len(entries))
+		return nil
+	}
+
+	proj.Topology = append(proj.Topology, entries...)
+	cfg.Projects[projectName] = proj
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	fmt.Printf("\n%s Imported %d services into %s\n",
+		ui.IconSuccess, len(entries), ui.ProjectTitleStyle.Render(projectName))
+	return nil
+}
+
+// validateImport checks each entry against its platform's discovered
+// services, replacing names with stable IDs. Platforms that can't list
+// services are skipped with a notice.
+func validateImport(ctx context.Context, cfg *config.Config, rows []importRow, entries []config.ServiceEntry) []string {
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return []string{fmt.Sprintf("load encryption key: %s", err)}
+	}
+
+	byPlatform := make(map[string][]int)
+	for i, e := range entries {
+		byPlatform[e.Platform] = append(byPlatform[e.Platform], i)
+	}
+	names := make([]string, 0, len(byPlatform))
+	for name := range byPlatform {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, pName := range names {
+		idx := byPlatform[pName]
+
+		fmt.Printf("  Discovering %s services... ", pName)
+		found, cached, err := discoverServices(ctx, cfg, key, pName, false)
+		if err == nil && cached && !discoveredAll(found, entries, idx) {
+			// Likely a service created since the cached listing.
+			found, _, err = discoverServices(ctx, cfg, key, pName, true)
+		}
+		if errors.Is(err, errDiscoveryUnsupported) {
+			fmt.Println(ui.MutedStyle.Render("not supported"))
+			fmt.Printf("  %s %s can't list services; %d rows not validated\n", ui.IconWarning, pName, len(idx))
+			continue
+		}
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("failed"))
+			problems = append(problems, fmt.Sprintf("%s: discovery failed: %s", pName, err))
+			continue
+		}
+		fmt.Println(ui.HealthyStyle.Render(fmt.Sprintf("%d found", len(found))))
+
+		byID := make(map[string]platform.DiscoveredService, len(found))
+		byName := make(map[string][]platform.DiscoveredService)
+		for _, svc := range found {
+			byID[svc.ID] = svc
+			byName[svc.Name] = append(byName[svc.Name], svc)
+		}
+
+		for _, i := range idx {
+			e := &entries[i]
+			if svc, ok := byID[e.ID]; ok {
+				e.RemoteName = svc.Name
+				continue
+			}
+			switch matches := byName[e.ID]; len(matches) {
+			case 0:
+				problems = append(problems, fmt.Sprintf("row %d (%s): %s has no service %q", rows[i].line, e.Name, pName, e.ID))
+			case 1:
+				e.ID, e.RemoteName = matches[0].ID, matches[0].Name
+			default:
+				problems = append(problems, fmt.Sprintf("row %d (%s): %q matches %d %s services; use the ID", rows[i].line, e.Name, e.ID, len(matches), pName))
+			}
+		}
+	}
+	return problems
+}
+
+func importError(problems []string) error {
+	return fmt.Errorf("manifest has %d problems, nothing imported:\n  %s", len(problems), strings.Join(problems, "\n  "))
+}
+
+// readImportManifest parses a YAML or CSV manifest, chosen by file extension.
+func readImportManifest(path string) ([]importRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseImportCSV(f)
+	case ".yaml", ".yml":
+		return parseImportYAML(f)
+	default:
+		return nil, fmt.Errorf("unsupported manifest type %q (use .yaml, .yml or .csv)", filepath.Ext(path))
+	}
+}
+
+func parseImportYAML(r io.Reader) ([]importRow, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	list := doc.Content[0]
+	if list.Kind == yaml.MappingNode {
+		var services *yaml.Node
+		for i := 0; i+1 < len(list.Content); i += 2 {
+			if list.Content[i].Value == "services" {
+				services = list.Content[i+1]
+			}
+		}
+		if services == nil {
+			return nil, fmt.Errorf("parse manifest: expected a list of services or a \"services:\" key")
+		}
+		list = services
+	}
+	if list.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("parse manifest: expected a list of services")
+	}
+
+	rows := make([]importRow, 0, len(list.Content))
+	for _, item := range list.Content {
+		var row importRow
+		if err := item.Decode(&row); err != nil {
+			return nil, fmt.Errorf("parse manifest line %d: %w", item.Line, err)
+		}
+		row.line = item.Line
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseImportCSV(r io.Reader) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	cols := make(map[string]int)
+	for i, h := range records[0] {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"name", "platform", "id"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("parse manifest: header is missing a %q column", required)
+		}
+	}
+
+	field := func(rec []string, col string) string {
+		i, ok := cols[col]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	var rows []importRow
+	for n, rec := range records[1:] {
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		row := importRow{
+			Name:     field(rec, "name"),
+			Platform: field(rec, "platform"),
+			ID:       field(rec, "id"),
+			line:     n + 2,
+		}
+		for _, tag := range strings.Split(field(rec, "tags"), ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				row.Tags = append(row.Tags, tag)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// discoveredAll reports whether every entry in idx names a discovered
+// service by ID or name.
+func discoveredAll(found []platform.DiscoveredService, entries []config.ServiceEntry, idx []int) bool {
+	known := make(map[string]bool, 2*len(found))
+	for _, svc := range found {
+		known[svc.ID] = true
+		known[svc.Name] = true
+	}
+	for _, i := range idx {
+		if !known[entries[i].ID] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/incident"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	incidentService string
+	incidentTitle   string
+	incidentNote    string
+	incidentAll     bool
+	incidentFormat  string
+)
+
+var incidentCmd = &cobra.Command{
+	Use:     "incident",
+	Aliases: []string{"incidents"},
+	Short:   "Open, close and list incidents on services",
+	Long: `Incidents record when a service was in trouble. Orbit opens one by itself
+when a watched deployment fails, a status check finds a service unhealthy or
+failed, or a heartbeat starts failing, and resolves it once the service is
+healthy again. Incidents opened by hand stay open until closed.
+
+Active incidents are shown under orbit status, and deploy history notes the
+incidents that followed each deployment.
+
+  orbit incident open myshop --service api --title "checkout errors"
+  orbit incident list
+  orbit incident close 12 --note "rolled back to abc1234"`,
+}
+
+var incidentOpenCmd = &cobra.Command{
+	Use:   "open <project>",
+	Short: "Open an incident on a service",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runIncidentOpen,
+}
+
+var incidentCloseCmd = &cobra.Command{
+	Use:   "close <id>",
+	Short: "Resolve an incident",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runIncidentClose,
+}
+
+var incidentListCmd = &cobra.Command{
+	Use:   "list [project]",
+	Short: "List active incidents (--all for resolved ones too)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runIncidentList,
+}
+
+func init() {
+	incidentOpenCmd.Flags().StringVar(&incidentService, "service", "", "Affected service (required)")
+	incidentOpenCmd.Flags().StringVar(&incidentTitle, "title", "", "What's wrong")
+	incidentOpenCmd.MarkFlagRequired("service")
+	incidentCloseCmd.Flags().StringVar(&incidentNote, "note", "", "How it was resolved")
+	incidentListCmd.Flags().BoolVar(&incidentAll, "all", false, "Include resolved incidents")
+	incidentListCmd.Flags().StringVar(&incidentFormat, "format", "", "Output format (json)")
+
+	incidentCmd.AddCommand(incidentOpenCmd, incidentCloseCmd, incidentListCmd)
+	rootCmd.AddCommand(incidentCmd)
+}
+
+func runIncidentOpen(cmd *cobra.Command, args []string) error {
+	projectName := args[0]
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	var svcNames []string
+	for _, e := range proj.Topology {
+		svcNames = append(svcNames, e.Name)
+	}
+	if !containsString(svcNames, incidentService) {
+		return fmt.Errorf("service %q not found in project %q\nAvailable services: %s", incidentService, projectName, joinNames(svcNames))
+	}
+
+	var inc incident.Incident
+	var created bool
+	err = updateIncidents(func(l *incident.Log) bool {
+		inc, created = l.Declare(projectName, incidentService, incidentTitle, time.Now())
+		return created
+	})
+	if err != nil {
+		return err
+	}
+	if !created {
+		return fmt.Errorf("%s already has incident #%d open since %s\nClose it first: orbit incident close %d",
+			incidentService, inc.ID, inc.StartedAt.Local().Format("Jan 2 15:04"), inc.ID)
+	}
+	fmt.Printf("  %s Opened incident #%d on %s/%s\n", ui.IconSuccess, inc.ID, projectName, incidentService)
+	return nil
+}
+
+func runIncidentClose(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil {
+		return fmt.Errorf("incident ID must be a number, got %q", args[0])
+	}
+	var inc incident.Incident
+	var closeErr error
+	err = updateIncidents(func(l *incident.Log) bool {
+		inc, closeErr = l.Close(id, incidentNote, time.Now())
+		return closeErr == nil
+	})
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	fmt.Printf("  %s Resolved incident #%d on %s/%s after %s\n", ui.IconSuccess, inc.ID, inc.Project, inc.Service,
+		ui.FormatGap(inc.ResolvedAt.Sub(inc.StartedAt)))
+	return nil
+}
+
+func runIncidentList(cmd *cobra.Command, args []string) error {
+	projectName := ""
+	if len(args) > 0 {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		if _, err := resolveProject(cfg, args[0]); err != nil {
+			return err
+		}
+		projectName = args[0]
+	}
+	path, err := incident.Path()
+	if err != nil {
+		return err
+	}
+	l, err := incident.Load(path)
+	if err != nil {
+		return err
+	}
+
+	incidents := []incident.Incident{}
+	for _, inc := range l.Incidents {
+		if (projectName == "" || inc.Project == projectName) && (incidentAll || inc.Active()) {
+			incidents = append(incidents, inc)
+		}
+	}
+	sort.SliceStable(incidents, func(i, j int) bool { return incidents[i].StartedAt.After(incidents[j].StartedAt) })
+
+	if incidentFormat == "json" {
+		return printJSON(incidents)
+	}
+	if len(incidents) == 0 {
+		if incidentAll {
+			fmt.Println(ui.MutedStyle.Render("No incidents recorded."))
+		} else {
+			fmt.Println(ui.MutedStyle.Render("No active incidents."))
+		}
+		return nil
+	}
+
+	now := time.Now()
+	fmt.Println()
+	for _, inc := range incidents {
+		state := ui.ErrorStyle.Render(fmt.Sprintf("%s open %s", ui.IconError, ui.FormatGap(now.Sub(inc.StartedAt))))
+		if !inc.Active() {
+			state = ui.HealthyStyle.Render(fmt.Sprintf("%s resolved after %s", ui.IconHealthy, ui.FormatGap(inc.ResolvedAt.Sub(inc.StartedAt))))
+		}
+		title := inc.Title
+		if inc.Resolution != "" {
+			title += ui.MutedStyle.Render(" (" + inc.Resolution + ")")
+		}
+		fmt.Printf("  %s %s %s %s %s\n", ui.Pad(fmt.Sprintf("#%d", inc.ID), 6),
+			ui.Pad(inc.Project+"/"+inc.Service, 24), ui.Pad(inc.StartedAt.Local().Format("Jan 2 15:04"), 13),
+			ui.Pad(state, 24), title)
+	}
+	fmt.Println()
+	return nil
+}
+
+// incidentMu serializes read-modify-write cycles of the incident log within
+// this process; the heartbeat daemon updates it from several goroutines.
+var incidentMu sync.Mutex
+
+// updateIncidents loads the incident log, applies fn and saves the log if
+// fn reports a change.
+func updateIncidents(fn func(l *incident.Log) bool) error {
+	incidentMu.Lock()
+	defer incidentMu.Unlock()
+	path, err := incident.Path()
+	if err != nil {
+		return err
+	}
+	l, err := incident.Load(path)
+	if err != nil {
+		return err
+	}
+	if !fn(l) {
+		return nil
+	}
+	return l.Save(path)
+}
+
+// incidentStatus reports whether a platform status means the service is
+// down, which opens an incident.
+func incidentStatus(status string) bool {
+	switch status {
+	case "unhealthy", "failed", "error":
+		return true
+	}
+	return false
+}
+
+// recordStatusIncidents opens an incident for every service a status poll
+// found down and resolves Orbit's incidents on services found healthy.
+// Cached results were already seen and fetch errors say nothing about the
+// service itself, so both are skipped.
+func recordStatusIncidents(projectName string, results []ui.ServiceResult) {
+	err := updateIncidents(func(l *incident.Log) bool {
+		now := time.Now()
+		changed := false
+		for _, r := range results {
+			if r.Cached || r.Err != nil {
+				continue
+			}
+			switch {
+			case incidentStatus(r.Status.Status):
+				if _, ok := l.ActiveFor(projectName, r.Entry.Name); ok {
+					continue
+				}
+				deployID := ""
+				if d := r.Status.LastDeploy; d != nil && d.Status == "failed" {
+					deployID = d.ID
+				}
+				l.Open(projectName, r.Entry.Name, r.Entry.Name+" "+r.Status.Status, deployID, now)
+				changed = true
+			case r.Status.Status == "healthy":
+				if l.Resolve(projectName, r.Entry.Name, now) > 0 {
+					changed = true
+				}
+			}
+		}
+		return changed
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+	}
+}
+
+// recordHeartbeatIncident opens an incident when a service's heartbeat
+// starts failing and resolves Orbit's incidents once it passes again.
+func recordHeartbeatIncident(projectName, service string, ok bool, detail string) error {
+	return updateIncidents(func(l *incident.Log) bool {
+		now := time.Now()
+		if ok {
+			return l.Resolve(projectName, service, now) > 0
+		}
+		if _, active := l.ActiveFor(projectName, service); active {
+			return false
+		}
+		title := "heartbeat failing"
+		if detail != "" {
+			title += ": " + detail
+		}
+		l.Open(projectName, service, title, "", now)
+		return true
+	})
+}
+
+// annotateIncidents attaches each service's active incident to its result,
+// for status output.
+func annotateIncidents(projectName string, results []ui.ServiceResult) {
+	l := loadIncidents()
+	for i := range results {
+		if inc, ok := l.ActiveFor(projectName, results[i].Entry.Name); ok {
+			results[i].Incident = &inc
+		}
+	}
+}
+
+// loadIncidents returns the incident log. Failing to read it only costs the
+// annotations, so the error is reported as a warning and an empty log is
+// returned.
+func loadIncidents() *incident.Log {
+	path, err := incident.Path()
+	if err == nil {
+		var l *incident.Log
+		if l, err = incident.Load(path); err == nil {
+			return l
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+	return &incident.Log{NextID: 1}
+}
+
+// incidentNotes describes the incidents that started while a deployment made
+// at deployedAt was live (until the next deployment, if known), e.g.
+// "incident #12 started 4m after this deploy: API down".
+func incidentNotes(incidents []incident.Incident, deployID string, deployedAt, until time.Time) []string {
+	var notes []string
+	for _, inc := range incident.After(incidents, deployedAt, until) {
+		note := fmt.Sprintf("incident #%d started %s after this deploy", inc.ID, ui.FormatGap(inc.StartedAt.Sub(deployedAt)))
+		if inc.DeployID == deployID {
+			note = fmt.Sprintf("incident #%d opened for this deploy", inc.ID)
+		}
+		if inc.Title != "" {
+			note += ": " + inc.Title
+		}
+		if !inc.Active() {
+			note += " (resolved)"
+		}
+		notes = append(notes, note)
+	}
+	return notes
+}
+
+// incidentIDs returns the IDs of the incidents incidentNotes would describe.
+func incidentIDs(incidents []incident.Incident, deployedAt, until time.Time) []int {
+	var ids []int
+	for _, inc := range incident.After(incidents, deployedAt, until) {
+		ids = append(ids, inc.ID)
+	}
+	return ids
+}
+
+// recordWatchIncidents opens an incident for every watched deployment that
+// failed and resolves a service's open incidents once a deployment succeeds.
+func recordWatchIncidents(projectName string, results []watchResult) {
+	err := updateIncidents(func(l *incident.Log) bool {
+		now := time.Now()
+		changed := false
+		for _, r := range results {
+			switch {
+			case r.ExitCode == exitFailed && r.DeployID != "":
+				l.Open(projectName, r.ServiceName, fmt.Sprintf("deploy %s failed", shortID(r.DeployID)), r.DeployID, now)
+				changed = true
+			case r.ExitCode == exitSuccess:
+				if l.Resolve(projectName, r.ServiceName, now) > 0 {
+					changed = true
+				}
+			}
+		}
+		return changed
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+	}
+}
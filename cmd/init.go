@@ -8,6 +8,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var initRefresh bool
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Interactive setup wizard for Orbit",
@@ -20,11 +22,12 @@ var initCmd = &cobra.Command{
 }
 
 func init() {
+	initCmd.Flags().BoolVar(&initRefresh, "refresh", false, "Re-discover services instead of using cached results")
 	rootCmd.AddCommand(initCmd)
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
-	p := tea.NewProgram(ui.NewWizardModel(), tea.WithAltScreen())
+	p := tea.NewProgram(ui.NewWizardModel(cmd.Context(), initRefresh || noCache), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("wizard error: %w", err)
 	}
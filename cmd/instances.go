@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	instancesService string
+	instancesFormat  string
+)
+
+var instancesCmd = &cobra.Command{
+	Use:   "instances [project]",
+	Short: "List the running instances of each service",
+	Long: `List the individual instances (replicas) behind each service in a
+project with their state, region and uptime.
+
+  orbit instances myshop
+  orbit instances myshop --service api
+  orbit instances myshop --format json
+
+Supported on Koyeb (instances), Fly.io (machines) and Kubernetes (pods;
+the region column shows the node).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runInstances,
+}
+
+func init() {
+	instancesCmd.Flags().StringVar(&instancesService, "service", "", "Show instances of a specific service")
+	instancesCmd.Flags().StringVar(&instancesFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(instancesCmd)
+}
+
+var errInstancesUnsupported = errors.New("instance listing not supported")
+
+type instanceResult struct {
+	Entry     config.ServiceEntry
+	Instances []platform.Instance
+	Err       error
+}
+
+func runInstances(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+
+	entries := proj.Topology
+	if instancesService != "" {
+		entries = nil
+		for _, e := range proj.Topology {
+			if e.Name == instancesService {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("service %q not found in project %q", instancesService, projectName)
+		}
+	}
+
+	results := fetchInstances(cmd.Context(), cfg, key, entries)
+	if instancesFormat == "json" {
+		return renderInstancesJSON(results)
+	}
+	renderInstancesTable(projectName, results)
+	return nil
+}
+
+func fetchInstances(ctx context.Context, cfg *config.Config, key []byte, entries []config.ServiceEntry) []instanceResult {
+	results := make([]instanceResult, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		results[i].Entry = e
+		wg.Add(1)
+		go func(r *instanceResult) {
+			defer wg.Done()
+			p, err := entryPlatform(cfg, key, r.Entry)
+			if err != nil {
+				r.Err = err
+				return
+			}
+			il, ok := p.(platform.InstanceLister)
+			if !ok {
+				r.Err = errInstancesUnsupported
+				return
+			}
+			r.Instances, r.Err = il.ListInstances(ctx, r.Entry.ID)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+func renderInstancesTable(projectName string, results []instanceResult) {
+	fmt.Println(ui.ProjectTitleStyle.Render(projectName))
+	fmt.Printf("  %s %s %s %s %s %s %s\n",
+		ui.Pad(ui.HeaderStyle.Render("Service"), 16),
+		ui.Pad(ui.HeaderStyle.Render("Instance"), 24),
+		ui.Pad(ui.HeaderStyle.Render("State"), 12),
+		ui.Pad(ui.HeaderStyle.Render("Region"), 10),
+		ui.Pad(ui.HeaderStyle.Render("Uptime"), 8),
+		ui.Pad(ui.HeaderStyle.Render("Restarts"), 9),
+		ui.HeaderStyle.Render("Notes"),
+	)
+
+	for _, r := range results {
+		service := fmt.Sprintf("%s (%s)", r.Entry.Name, r.Entry.Platform)
+		if r.Err != nil {
+			msg := r.Err.Error()
+			if errors.Is(r.Err, errInstancesUnsupported) {
+				msg = "not supported on " + r.Entry.Platform
+			}
+			fmt.Printf("  %s %s %s\n", ui.Pad(service, 16), ui.Pad(ui.MutedStyle.Render(ui.Dash), 24), ui.MutedStyle.Render(msg))
+			continue
+		}
+		if len(r.Instances) == 0 {
+			fmt.Printf("  %s %s %s\n", ui.Pad(service, 16), ui.Pad(ui.MutedStyle.Render(ui.Dash), 24), ui.MutedStyle.Render("no running instances"))
+			continue
+		}
+		for _, in := range r.Instances {
+			region := in.Region
+			if region == "" {
+				region = ui.Dash
+			}
+			fmt.Printf("  %s %s %s %s %s %s %s\n",
+				ui.Pad(service, 16), ui.Pad(ui.Truncate(in.ID, 24), 24), ui.Pad(ui.FormatStatus(in.State), 12),
+				ui.Pad(region, 10), ui.Pad(ui.FormatUptime(in.StartedAt), 8), ui.Pad(fmt.Sprint(in.Restarts), 9),
+				ui.MutedStyle.Render(ui.Truncate(in.Message, 60)))
+		}
+	}
+	fmt.Println()
+}
+
+type jsonInstance struct {
+	ID        string     `json:"id"`
+	State     string     `json:"state"`
+	Region    string     `json:"region,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	Restarts  int        `json:"restarts"`
+	Message   string     `json:"message,omitempty"`
+}
+
+type jsonInstanceResult struct {
+	Service   string         `json:"service"`
+	Platform  string         `json:"platform"`
+	Instances []jsonInstance `json:"instances"`
+	Error     string         `json:"error,omitempty"`
+	ErrorKind string         `json:"error_kind,omitempty"`
+}
+
+func renderInstancesJSON(results []instanceResult) error {
+	out := make([]jsonInstanceResult, len(results))
+	for i, r := range results {
+		out[i] = jsonInstanceResult{Service: r.Entry.Name, Platform: r.Entry.Platform, Instances: []jsonInstance{}}
+		if r.Err != nil {
+			out[i].Error = r.Err.Error()
+			out[i].ErrorKind = platform.ErrorKind(r.Err)
+			continue
+		}
+		for _, in := range r.Instances {
+			ji := jsonInstance{ID: in.ID, State: in.State, Region: in.Region, Restarts: in.Restarts, Message: in.Message}
+			if !in.StartedAt.IsZero() {
+				started := in.StartedAt
+				ji.StartedAt = &started
+			}
+			out[i].Instances = append(out[i].Instances, ji)
+		}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
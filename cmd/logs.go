@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/humanetools/orbit/internal/config"
@@ -16,6 +18,7 @@ var (
 	logsLevel   string
 	logsTail    int
 	logsSince   string
+	logsType    string
 )
 
 var logsCmd = &cobra.Command{
@@ -27,7 +30,13 @@ var logsCmd = &cobra.Command{
   orbit logs myshop --service api --follow
   orbit logs myshop --service api --level error
   orbit logs myshop --service api --tail 50
-  orbit logs myshop --service api --since 2h`,
+  orbit logs myshop --service api --since 2h
+  orbit logs myshop --service api --type build
+  orbit logs myshop --service api -f --save-session debug.ndjson
+  orbit logs replay debug.ndjson --speed 4x
+
+--type build shows build output instead of runtime logs, on platforms that
+keep it (Koyeb, Vercel, GitHub Actions).`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runLogs,
 }
@@ -35,9 +44,10 @@ var logsCmd = &cobra.Command{
 func init() {
 	logsCmd.Flags().StringVar(&logsService, "service", "", "Service name (required)")
 	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Stream logs in real time")
-	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Filter by log level (info, error)")
+	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Filter by log level (debug, info, warn, error)")
 	logsCmd.Flags().IntVar(&logsTail, "tail", 0, "Show last N log entries")
 	logsCmd.Flags().StringVar(&logsSince, "since", "", "Show logs since duration (e.g. 1h, 30m, 2h30m)")
+	logsCmd.Flags().StringVar(&logsType, "type", platform.LogTypeRuntime, "Log type (runtime, build)")
 	logsCmd.MarkFlagRequired("service")
 	rootCmd.AddCommand(logsCmd)
 }
@@ -64,11 +74,26 @@ func runLogs(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := requireCapability(resolved, platform.CapLogs); err != nil {
+		return err
+	}
+
+	switch logsType {
+	case platform.LogTypeRuntime:
+	case platform.LogTypeBuild:
+		// Platforms that keep build output expose it per deployment.
+		if _, ok := resolved.Platform.(platform.DeploymentLogger); !ok {
+			return fmt.Errorf("%s does not keep build logs (service %s)", resolved.Entry.Platform, resolved.Entry.Name)
+		}
+	default:
+		return fmt.Errorf("invalid --type %q (want runtime or build)", logsType)
+	}
 
 	opts := platform.LogOptions{
 		Follow: logsFollow,
 		Level:  logsLevel,
 		Tail:   logsTail,
+		Type:   logsType,
 	}
 
 	if logsSince != "" {
@@ -79,36 +104,79 @@ func runLogs(cmd *cobra.Command, args []string) error {
 		opts.Since = d
 	}
 
+	if logsSaveSession != "" && !logsFollow {
+		return fmt.Errorf("--save-session records follow mode; add --follow")
+	}
+
 	if logsFollow {
-		return runLogsFollow(resolved, opts)
+		var rec *sessionRecorder
+		if logsSaveSession != "" {
+			rec, err = newSessionRecorder(logsSaveSession, projectName, resolved)
+			if err != nil {
+				return err
+			}
+			defer rec.Close()
+		}
+		return runLogsFollow(cmd.Context(), resolved, opts, rec)
 	}
 
-	entries, err := resolved.Platform.GetLogs(resolved.Entry.ID, opts)
+	entries, err := resolved.Platform.GetLogs(cmd.Context(), resolved.Entry.ID, opts)
 	if err != nil {
 		return fmt.Errorf("get logs: %w", err)
 	}
 
 	if len(entries) == 0 {
-		fmt.Println(ui.MutedStyle.Render("No log entries found."))
+		fmt.Fprintln(os.Stderr, ui.MutedStyle.Render("No log entries found."))
 		return nil
 	}
 
 	for _, e := range entries {
+		if !platform.MatchesLevel(entryLevel(e), opts.Level) {
+			continue
+		}
 		printLogEntry(e)
 	}
 	return nil
 }
 
-func runLogsFollow(resolved *resolvedService, opts platform.LogOptions) error {
-	fmt.Printf("%s Streaming logs for %s/%s (%s)... press Ctrl+C to stop\n\n",
+func runLogsFollow(ctx context.Context, resolved *resolvedService, opts platform.LogOptions, rec *sessionRecorder) error {
+	// Log lines go to stdout; everything else to stderr, so the stream can
+	// be piped or redirected.
+	fmt.Fprintf(os.Stderr, "%s Streaming logs for %s/%s (%s)... press Ctrl+C to stop\n\n",
 		ui.IconWatch,
 		resolved.Entry.Platform,
 		resolved.Entry.Name,
 		resolved.Entry.ID,
 	)
+	if rec != nil {
+		fmt.Fprintf(os.Stderr, "%s Recording session to %s\n\n", ui.IconHealthy, logsSaveSession)
+	}
 
 	// Track the latest timestamp to avoid duplicates
 	var lastTimestamp time.Time
+	emit := func(e platform.LogEntry) {
+		if !e.Timestamp.After(lastTimestamp) {
+			return
+		}
+		lastTimestamp = e.Timestamp
+		if !platform.MatchesLevel(entryLevel(e), opts.Level) {
+			return
+		}
+		printLogEntry(e)
+		if rec != nil {
+			if err := rec.record(e); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, ui.ErrorStyle.Render("record session: "+err.Error()))
+			}
+		}
+	}
+
+	if streamer, ok := resolved.Platform.(platform.LogStreamer); ok {
+		err := followStream(ctx, streamer, resolved.Entry.ID, opts, &lastTimestamp, emit)
+		if err == nil {
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, ui.ErrorStyle.Render("log stream unavailable, polling instead: "+err.Error()))
+	}
 
 	for {
 		// Adjust since to only get new entries
@@ -117,34 +185,78 @@ func runLogsFollow(resolved *resolvedService, opts platform.LogOptions) error {
 		}
 		opts.Tail = 0 // Don't limit in follow mode after initial fetch
 
-		entries, err := resolved.Platform.GetLogs(resolved.Entry.ID, opts)
+		entries, err := resolved.Platform.GetLogs(ctx, resolved.Entry.ID, opts)
 		if err != nil {
-			fmt.Printf("%s %s\n", ui.IconWarning, ui.ErrorStyle.Render("error fetching logs: "+err.Error()))
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, ui.ErrorStyle.Render("error fetching logs: "+err.Error()))
 		}
 
 		for _, e := range entries {
-			if !e.Timestamp.After(lastTimestamp) {
-				continue
+			emit(e)
+		}
+
+		select {
+		case <-time.After(3 * time.Second):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// followStream emits entries from the platform's log stream until ctx is
+// done, reconnecting from the last entry seen whenever the platform ends the
+// stream. It returns an error only if the stream cannot be opened.
+func followStream(ctx context.Context, streamer platform.LogStreamer, serviceID string, opts platform.LogOptions,
+	last *time.Time, emit func(platform.LogEntry)) error {
+	for {
+		ch, err := streamer.StreamLogs(ctx, serviceID, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
 			}
-			printLogEntry(e)
-			lastTimestamp = e.Timestamp
+			return err
 		}
+		for e := range ch {
+			emit(e)
+		}
+
+		select {
+		case <-time.After(3 * time.Second):
+		case <-ctx.Done():
+			return nil
+		}
+		if !last.IsZero() {
+			opts.Since = time.Since(*last)
+		}
+		opts.Tail = 0
+	}
+}
 
-		time.Sleep(3 * time.Second)
+// entryLevel returns the entry's level, inferring one from the message
+// when the platform didn't report any.
+func entryLevel(e platform.LogEntry) string {
+	if e.Level != "" {
+		return e.Level
 	}
+	return platform.InferLevel(e.Message, "")
 }
 
 func printLogEntry(e platform.LogEntry) {
 	ts := e.Timestamp.Format("15:04:05")
 
-	levelStr := ui.MutedStyle.Render(e.Level)
-	switch e.Level {
-	case "error":
+	level := entryLevel(e)
+	levelStr := ui.MutedStyle.Render(level)
+	switch {
+	case platform.MatchesLevel(level, "error"):
 		levelStr = ui.ErrorStyle.Render("ERR")
-	case "warn", "warning":
+	case platform.MatchesLevel(level, "warn"):
 		levelStr = ui.WarningStyle.Render("WRN")
-	case "info":
+	case platform.MatchesLevel(level, "info"):
 		levelStr = ui.HealthyStyle.Render("INF")
+	case platform.MatchesLevel(level, "debug"):
+		levelStr = ui.MutedStyle.Render("DBG")
 	}
 
 	fmt.Printf("%s %s %s\n",
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsSaveSession string
+	replaySpeed     string
+	replayMaxGap    time.Duration
+)
+
+var logsReplayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Replay a saved log session",
+	Long: `Re-render a log session recorded with orbit logs --follow --save-session,
+keeping the original pacing between lines.
+
+  orbit logs replay session.ndjson
+  orbit logs replay session.ndjson --speed 4x
+  orbit logs replay session.ndjson --speed 0      # print everything at once`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogsReplay,
+}
+
+func init() {
+	logsCmd.Flags().StringVar(&logsSaveSession, "save-session", "", "Record the follow session to a file for orbit logs replay")
+	logsReplayCmd.Flags().StringVar(&replaySpeed, "speed", "1x", "Playback speed (e.g. 2x, 0.5x; 0 for no delay)")
+	logsReplayCmd.Flags().DurationVar(&replayMaxGap, "max-gap", 5*time.Second, "Longest pause between lines (0 for no limit)")
+	logsCmd.AddCommand(logsReplayCmd)
+}
+
+// Session files are NDJSON: a header line followed by one line per log
+// entry, stamped with the time it was received.
+type sessionHeader struct {
+	Type      string    `json:"type"` // "session"
+	Project   string    `json:"project"`
+	Service   string    `json:"service"`
+	Platform  string    `json:"platform"`
+	ServiceID string    `json:"service_id"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+type sessionLine struct {
+	Type       string    `json:"type"` // "log"
+	ReceivedAt time.Time `json:"received_at"`
+	Service    string    `json:"service"`
+	Timestamp  time.Time `json:"timestamp"`
+	Level      string    `json:"level,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	Message    string    `json:"message"`
+}
+
+// sessionRecorder appends log entries to a session file. Each line is
+// written immediately so a session stopped with Ctrl+C is still complete.
+type sessionRecorder struct {
+	f       *os.File
+	enc     *json.Encoder
+	service string
+}
+
+func newSessionRecorder(path, projectName string, resolved *resolvedService) (*sessionRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create session file: %w", err)
+	}
+	rec := &sessionRecorder{f: f, enc: json.NewEncoder(f), service: resolved.Entry.Name}
+	err = rec.enc.Encode(sessionHeader{
+		Type:      "session",
+		Project:   projectName,
+		Service:   resolved.Entry.Name,
+		Platform:  resolved.Entry.Platform,
+		ServiceID: resolved.Entry.ID,
+		StartedAt: time.Now(),
+	})
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("write session file: %w", err)
+	}
+	return rec, nil
+}
+
+func (r *sessionRecorder) record(e platform.LogEntry) error {
+	return r.enc.Encode(sessionLine{
+		Type:       "log",
+		ReceivedAt: time.Now(),
+		Service:    r.service,
+		Timestamp:  e.Timestamp,
+		Level:      e.Level,
+		Source:     e.Source,
+		Message:    e.Message,
+	})
+}
+
+func (r *sessionRecorder) Close() error {
+	return r.f.Close()
+}
+
+// parseSpeed accepts "4x", "4", "0.5x" or "0".
+func parseSpeed(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "x"), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid --speed %q (use e.g. 2x, 0.5x or 0)", s)
+	}
+	return v, nil
+}
+
+func runLogsReplay(cmd *cobra.Command, args []string) error {
+	speed, err := parseSpeed(replaySpeed)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("open session: %w", err)
+	}
+	defer f.Close()
+
+	cmd.SilenceUsage = true
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var last time.Time
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := scanner.Bytes()
+		if len(strings.TrimSpace(string(raw))) == 0 {
+			continue
+		}
+
+		var probe struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &probe); err != nil {
+			return fmt.Errorf("%s line %d: %w", args[0], lineNo, err)
+		}
+
+		switch probe.Type {
+		case "session":
+			var h sessionHeader
+			if err := json.Unmarshal(raw, &h); err != nil {
+				return fmt.Errorf("%s line %d: %w", args[0], lineNo, err)
+			}
+			fmt.Printf("%s Replaying %s/%s (%s, %s) recorded %s\n\n",
+				ui.IconWatch, h.Project, h.Service, h.Platform, h.ServiceID,
+				h.StartedAt.Local().Format("2006-01-02 15:04:05"))
+			last = h.StartedAt
+
+		case "log":
+			var l sessionLine
+			if err := json.Unmarshal(raw, &l); err != nil {
+				return fmt.Errorf("%s line %d: %w", args[0], lineNo, err)
+			}
+			if speed > 0 && !last.IsZero() {
+				gap := l.ReceivedAt.Sub(last)
+				if replayMaxGap > 0 && gap > replayMaxGap {
+					gap = replayMaxGap
+				}
+				if gap > 0 {
+					time.Sleep(time.Duration(float64(gap) / speed))
+				}
+			}
+			last = l.ReceivedAt
+
+			fmt.Printf("%s ", ui.MutedStyle.Render("["+l.Service+"]"))
+			printLogEntry(platform.LogEntry{
+				Timestamp: l.Timestamp,
+				Level:     l.Level,
+				Message:   l.Message,
+				Source:    l.Source,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read session: %w", err)
+	}
+	return nil
+}
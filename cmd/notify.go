@@ -0,0 +1,590 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/heartbeat"
+	"github.com/humanetools/orbit/internal/hook"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// notifyStatus sends a warning per threshold violation. Events are
+// recorded in history whether or not channels are configured. Statuses
+// served from the response cache produced their events when they were
+// fetched, so they are skipped instead of repeating them.
+// Delivery problems are printed as warnings; they never fail the command.
+func notifyStatus(cfg *config.Config, projectName string, results []ui.ServiceResult, violations []ui.ThresholdViolation) {
+	entries := make(map[string]config.ServiceEntry)
+	if proj, ok := cfg.Projects[projectName]; ok {
+		for _, e := range proj.Topology {
+			entries[e.Name] = e
+		}
+	}
+	cached := make(map[string]bool)
+	for _, r := range results {
+		cached[r.Entry.Name] = r.Cached
+	}
+
+	now := time.Now()
+	var events []notify.Event
+	for _, v := range violations {
+		if cached[v.ServiceName] {
+			continue
+		}
+		events = append(events, notify.Event{
+			Type:     "threshold_violation",
+			Severity: notify.SeverityWarning,
+			Project:  projectName,
+			Service:  v.ServiceName,
+			Tags:     entries[v.ServiceName].Tags,
+			Owner:    entries[v.ServiceName].Owner,
+			Title:    v.Metric + " over threshold",
+			Message:  fmt.Sprintf("%s (threshold: %s)", v.Value, v.Threshold),
+			Time:     now,
+		})
+	}
+	recordEvents(events...)
+	runHooks(cfg, events...)
+
+	nc := cfg.Notifications
+	if len(nc.Channels) == 0 {
+		return
+	}
+	for _, ev := range events {
+		printNotifyErrors(notify.Notify(nc, ev))
+	}
+
+	// Digests and events held by quiet hours only go out when something
+	// calls into notify, so flush on every check even when this one found nothing.
+	printNotifyErrors(notify.FlushDigests(nc.Channels))
+}
+
+// notifyWatchFailures sends a critical event for every watched deployment
+// that failed or timed out, and runs the on_deploy_failed hooks. Events are
+// recorded in history whether or not channels are configured.
+func notifyWatchFailures(cfg *config.Config, projectName string, results []watchResult) {
+	entries := make(map[string]config.ServiceEntry)
+	if proj, ok := cfg.Projects[projectName]; ok {
+		for _, e := range proj.Topology {
+			entries[e.Name] = e
+		}
+	}
+
+	now := time.Now()
+	var events []notify.Event
+	for _, r := range results {
+		ev := notify.Event{
+			Type:     "deploy_failed",
+			Severity: notify.SeverityCritical,
+			Project:  projectName,
+			Service:  r.ServiceName,
+			Tags:     entries[r.ServiceName].Tags,
+			Owner:    entries[r.ServiceName].Owner,
+			Message:  r.Error,
+			Phase:    r.Phase,
+			Commit:   r.Commit,
+			DeployID: r.DeployID,
+			URL:      r.URL,
+			Time:     now,
+		}
+		switch {
+		case r.Cancelled:
+			continue
+		case r.ExitCode == exitTimeout:
+			ev.Title = "deploy timed out"
+		case r.ExitCode == exitFailed && r.DeployID != "":
+			ev.Title = fmt.Sprintf("deploy %s failed", shortID(r.DeployID))
+		case r.ExitCode == exitFailed:
+			ev.Title = "watch failed"
+		default:
+			continue
+		}
+		events = append(events, ev)
+	}
+	recordEvents(events...)
+	runHooks(cfg, events...)
+
+	nc := cfg.Notifications
+	if len(nc.Channels) == 0 {
+		return
+	}
+	for _, ev := range events {
+		printNotifyErrors(notify.Notify(nc, ev))
+	}
+}
+
+// runDeploySuccessHooks runs the on_deploy_success hooks for every watched
+// deployment that went healthy. These events are neither recorded nor
+// sent; history already has the deploys.
+func runDeploySuccessHooks(cfg *config.Config, projectName string, results []watchResult) {
+	if len(cfg.Hooks.OnDeploySuccess) == 0 {
+		return
+	}
+	entries := make(map[string]config.ServiceEntry)
+	if proj, ok := cfg.Projects[projectName]; ok {
+		for _, e := range proj.Topology {
+			entries[e.Name] = e
+		}
+	}
+	now := time.Now()
+	var events []notify.Event
+	for _, r := range results {
+		if r.ExitCode != exitSuccess || r.DeployID == "" {
+			continue
+		}
+		events = append(events, notify.Event{
+			Type:     hook.DeploySucceeded,
+			Severity: notify.SeverityInfo,
+			Project:  projectName,
+			Service:  r.ServiceName,
+			Tags:     entries[r.ServiceName].Tags,
+			Owner:    entries[r.ServiceName].Owner,
+			Title:    fmt.Sprintf("deploy %s healthy", shortID(r.DeployID)),
+			Message:  fmt.Sprintf("healthy after %ds", int(r.Duration.Seconds())),
+			Commit:   r.Commit,
+			DeployID: r.DeployID,
+			URL:      r.URL,
+			Time:     now,
+		})
+	}
+	runHooks(cfg, events...)
+}
+
+// runHooks runs the hooks configured for each event's type, with their
+// output on stderr. Failures are printed as warnings; they never fail the
+// command.
+func runHooks(cfg *config.Config, events ...notify.Event) {
+	if cfg.Hooks.Empty() {
+		return
+	}
+	timeout, err := hook.Timeout(cfg.Hooks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using %s\n", err, hook.DefaultTimeout)
+		timeout = hook.DefaultTimeout
+	}
+	for _, ev := range events {
+		errs := hook.Run(context.Background(), hook.Commands(cfg.Hooks, ev.Type), ev, timeout, os.Stderr)
+		for command, err := range errs {
+			fmt.Fprintf(os.Stderr, "Warning: %s hook %q: %v\n", ev.Type, command, err)
+		}
+	}
+}
+
+// watchNotifier posts a watch's progress and results to the channels named
+// by watch.notify and to --notify-url. It bypasses routing, digests and
+// quiet hours, since progress is only worth anything while it's current.
+// Posts go out in order from a goroutine so a slow endpoint doesn't hold
+// up the watch.
+type watchNotifier struct {
+	project  string
+	entries  map[string]config.ServiceEntry
+	channels map[string]config.NotifyChannel
+	queue    chan notify.Event
+	done     chan struct{}
+}
+
+// newWatchNotifier returns nil when there is nowhere to post; the methods
+// of a nil notifier do nothing.
+func newWatchNotifier(cfg *config.Config, projectName, notifyURL string) (*watchNotifier, error) {
+	channels := make(map[string]config.NotifyChannel)
+	for _, name := range cfg.Watch.Notify {
+		ch, ok := cfg.Notifications.Channels[name]
+		if !ok {
+			return nil, fmt.Errorf("watch.notify: unknown notification channel %q\nConfigured: %s", name, joinNames(channelNames(cfg.Notifications.Channels)))
+		}
+		channels[name] = ch
+	}
+	if notifyURL == "" {
+		notifyURL = cfg.Watch.NotifyURL
+	}
+	if notifyURL != "" {
+		if !strings.HasPrefix(notifyURL, "https://") && !strings.HasPrefix(notifyURL, "http://") {
+			return nil, fmt.Errorf("notify URL %q is not an http(s) URL", notifyURL)
+		}
+		channels[notifyURL] = channelForURL(notifyURL)
+	}
+	if len(channels) == 0 {
+		return nil, nil
+	}
+
+	n := &watchNotifier{
+		project:  projectName,
+		entries:  make(map[string]config.ServiceEntry),
+		channels: channels,
+		queue:    make(chan notify.Event, 64),
+		done:     make(chan struct{}),
+	}
+	if proj, ok := cfg.Projects[projectName]; ok {
+		for _, e := range proj.Topology {
+			n.entries[e.Name] = e
+		}
+	}
+	go n.run()
+	return n, nil
+}
+
+// channelForURL picks the payload a --notify-url gets from its host: Slack
+// and Discord incoming webhooks get a message, anything else the event.
+func channelForURL(url string) config.NotifyChannel {
+	switch {
+	case strings.Contains(url, "hooks.slack.com/"):
+		return config.NotifyChannel{Type: "slack", URL: url}
+	case strings.Contains(url, "discord.com/api/webhooks/"), strings.Contains(url, "discordapp.com/api/webhooks/"):
+		return config.NotifyChannel{Type: "discord", URL: url}
+	}
+	return config.NotifyChannel{Type: "webhook", URL: url}
+}
+
+func (n *watchNotifier) run() {
+	defer close(n.done)
+	failed := make(map[string]bool) // channels already warned about
+	for ev := range n.queue {
+		for name, ch := range n.channels {
+			if err := notify.Send(ch, []notify.Event{ev}); err != nil && !failed[name] {
+				failed[name] = true
+				fmt.Fprintf(os.Stderr, "Warning: watch progress to %q: %v\n", name, err)
+			}
+		}
+	}
+}
+
+// phase posts a deployment reaching a new phase. Terminal phases are left
+// to result, which also covers timeouts and smoke test failures.
+func (n *watchNotifier) phase(svcName string, ev platform.DeployEvent) {
+	if n == nil || ev.Deploy == nil {
+		return
+	}
+	title := ""
+	switch ev.Phase {
+	case "detected":
+		title = fmt.Sprintf("deploy %s started", shortID(ev.Deploy.ID))
+	case "building", "deploying":
+		title = fmt.Sprintf("deploy %s %s", shortID(ev.Deploy.ID), ev.Phase)
+	case "healthcheck":
+		title = fmt.Sprintf("deploy %s health check", shortID(ev.Deploy.ID))
+	default:
+		return
+	}
+	progress := n.event(svcName, notify.Event{
+		Type:     "deploy_progress",
+		Severity: notify.SeverityInfo,
+		Title:    title,
+		Message:  ev.Deploy.Message,
+		Phase:    ev.Phase,
+		Commit:   ev.Deploy.Commit,
+		DeployID: ev.Deploy.ID,
+		URL:      ev.Deploy.URL,
+	})
+	// Behind a slow endpoint, skip progress rather than stall the watch.
+	select {
+	case n.queue <- progress:
+	default:
+	}
+}
+
+// result posts how each service's watch ended.
+func (n *watchNotifier) result(results []watchResult) {
+	if n == nil {
+		return
+	}
+	for _, r := range results {
+		ev := notify.Event{
+			Type:     "deploy_result",
+			Message:  r.Error,
+			Phase:    r.Phase,
+			Commit:   r.Commit,
+			DeployID: r.DeployID,
+			URL:      r.URL,
+		}
+		switch r.ExitCode {
+		case exitSuccess:
+			ev.Severity = notify.SeverityInfo
+			ev.Title = fmt.Sprintf("deploy %s healthy", shortID(r.DeployID))
+			ev.Message = fmt.Sprintf("healthy after %ds", int(r.Duration.Seconds()))
+		case exitFailed:
+			ev.Severity = notify.SeverityCritical
+			ev.Title = "watch failed"
+			if r.DeployID != "" {
+				ev.Title = fmt.Sprintf("deploy %s failed", shortID(r.DeployID))
+			}
+		case exitTimeout:
+			ev.Severity = notify.SeverityWarning
+			ev.Title = "deploy timed out"
+		default:
+			ev.Severity = notify.SeverityWarning
+			ev.Title = "no new deployment"
+		}
+		n.queue <- n.event(r.ServiceName, ev)
+	}
+}
+
+func (n *watchNotifier) event(svcName string, ev notify.Event) notify.Event {
+	ev.Project = n.project
+	ev.Service = svcName
+	ev.Tags = n.entries[svcName].Tags
+	ev.Owner = n.entries[svcName].Owner
+	ev.Time = time.Now()
+	return ev
+}
+
+// watchNotifyDrain bounds how long a finished watch waits for queued posts.
+const watchNotifyDrain = 15 * time.Second
+
+// close waits for queued posts to go out, up to watchNotifyDrain.
+func (n *watchNotifier) close() {
+	if n == nil {
+		return
+	}
+	close(n.queue)
+	select {
+	case <-n.done:
+	case <-time.After(watchNotifyDrain):
+		fmt.Fprintln(os.Stderr, "Warning: gave up waiting for watch progress notifications")
+	}
+}
+
+// notifyHeartbeat reports a service starting to fail its heartbeat checks,
+// or recovering. Individual failed checks are only recorded in history.
+func notifyHeartbeat(nc config.NotificationsConfig, projectName string, e config.ServiceEntry, c heartbeat.Check) {
+	ev := notify.Event{
+		Type:     "heartbeat_failed",
+		Severity: notify.SeverityCritical,
+		Project:  projectName,
+		Service:  e.Name,
+		Tags:     e.Tags,
+		Owner:    e.Owner,
+		Title:    "heartbeat failing",
+		Message:  c.Detail,
+		Time:     c.Time,
+	}
+	if c.OK {
+		ev.Type, ev.Severity, ev.Title, ev.Message = "heartbeat_recovered", notify.SeverityInfo, "heartbeat recovered", ""
+	}
+	recordEvents(ev)
+
+	if len(nc.Channels) == 0 {
+		return
+	}
+	printNotifyErrors(notify.Notify(nc, ev))
+}
+
+func printNotifyErrors(errMap map[string]error) {
+	for name, err := range errMap {
+		fmt.Fprintf(os.Stderr, "Warning: notification channel %q: %v\n", name, err)
+	}
+}
+
+var (
+	notifyTestChannel  string
+	notifyTestEvent    string
+	notifyTestProject  string
+	notifyTestService  string
+	notifyTestSeverity string
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Manage notifications",
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a synthetic event to verify notification channels",
+	Long: `Send a synthetic event through routing and formatting to check that
+channels are reachable and routing rules pick the channels you expect.
+
+Test events are always sent immediately, ignoring digests and quiet hours.
+
+  orbit notify test
+  orbit notify test --channel slack --event deploy_failed
+  orbit notify test --project myshop --service db --severity warning`,
+	RunE: runNotifyTest,
+}
+
+func init() {
+	notifyTestCmd.Flags().StringVar(&notifyTestChannel, "channel", "", "Only send to this channel (default: channels selected by routing)")
+	notifyTestCmd.Flags().StringVar(&notifyTestEvent, "event", "deploy_failed", "Event type (deploy_failed, service_down, threshold_violation, heartbeat_failed, remediation, report)")
+	notifyTestCmd.Flags().StringVar(&notifyTestProject, "project", "", "Project for the event (default: default project)")
+	notifyTestCmd.Flags().StringVar(&notifyTestService, "service", "", "Service for the event (default: first service in project)")
+	notifyTestCmd.Flags().StringVar(&notifyTestSeverity, "severity", "", "Severity (info, warning, critical; default depends on event)")
+
+	notifyCmd.AddCommand(notifyTestCmd)
+	rootCmd.AddCommand(notifyCmd)
+}
+
+func runNotifyTest(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	nc := cfg.Notifications
+	if len(nc.Channels) == 0 {
+		return fmt.Errorf("no notification channels configured\nAdd channels under notifications.channels in ~/.orbit/config.yaml")
+	}
+	if notifyTestChannel != "" {
+		if _, ok := nc.Channels[notifyTestChannel]; !ok {
+			return fmt.Errorf("channel %q not found\nAvailable channels: %s", notifyTestChannel, joinNames(channelNames(nc.Channels)))
+		}
+	}
+
+	ev, err := syntheticEvent(cfg, notifyTestEvent)
+	if err != nil {
+		return err
+	}
+
+	// From here on failures are delivery problems, not usage errors.
+	cmd.SilenceUsage = true
+
+	routed := notify.Route(nc, ev)
+	targets := routed
+	if notifyTestChannel != "" {
+		targets = []string{notifyTestChannel}
+	}
+
+	fmt.Printf("Event: %s\n", notify.FormatText([]notify.Event{ev}))
+	if len(routed) == 0 {
+		fmt.Println(ui.MutedStyle.Render("Routing: no channel matches this event"))
+	} else {
+		fmt.Printf("Routing: %s\n", joinNames(routed))
+	}
+	fmt.Println()
+
+	failed := 0
+	for _, name := range targets {
+		ch, ok := nc.Channels[name]
+		if !ok {
+			fmt.Printf("  %s %-12s %s\n", ui.IconError, name, ui.ErrorStyle.Render("routed to unknown channel"))
+			failed++
+			continue
+		}
+
+		note := ""
+		if !containsString(routed, name) {
+			note = ui.MutedStyle.Render(" (not selected by routing)")
+		}
+		if err := notify.Send(ch, []notify.Event{ev}); err != nil {
+			fmt.Printf("  %s %-12s %s%s\n", ui.IconError, name, ui.ErrorStyle.Render(err.Error()), note)
+			failed++
+			continue
+		}
+		fmt.Printf("  %s %-12s %s%s\n", ui.IconHealthy, name, ui.HealthyStyle.Render("sent"), note)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channels failed", failed, len(targets))
+	}
+	return nil
+}
+
+// syntheticEvent builds a realistic test event of the given type.
+func syntheticEvent(cfg *config.Config, eventType string) (notify.Event, error) {
+	ev := notify.Event{
+		Type:    eventType,
+		Project: notifyTestProject,
+		Service: notifyTestService,
+		Time:    time.Now(),
+	}
+	if ev.Project != "" {
+		if _, err := resolveProject(cfg, ev.Project); err != nil {
+			return ev, err
+		}
+	} else {
+		ev.Project = cfg.DefaultProject
+	}
+	if proj, ok := cfg.Projects[ev.Project]; ok && len(proj.Topology) > 0 {
+		entry := &proj.Topology[0]
+		if ev.Service != "" {
+			entry = nil
+			var names []string
+			for i, e := range proj.Topology {
+				names = append(names, e.Name)
+				if e.Name == ev.Service {
+					entry = &proj.Topology[i]
+				}
+			}
+			if entry == nil {
+				return ev, fmt.Errorf("service %q not found in project %q\nAvailable services: %s",
+					ev.Service, ev.Project, joinNames(names))
+			}
+		}
+		ev.Service = entry.Name
+		ev.Tags = entry.Tags
+		ev.Owner = entry.Owner
+	}
+	if ev.Project == "" {
+		ev.Project = "example"
+	}
+	if ev.Service == "" {
+		ev.Service = "api"
+	}
+
+	switch eventType {
+	case "deploy_failed":
+		ev.Severity = notify.SeverityCritical
+		ev.Title = "deploy failed"
+		ev.Message = "build exited with code 1 (test event)"
+		ev.Phase = "build"
+		ev.Commit = "3f2c9a1e8b7d6c5f4a3b2c1d0e9f8a7b6c5d4e3f"
+		ev.URL = "https://example.com/deployments/test"
+	case "service_down":
+		ev.Severity = notify.SeverityCritical
+		ev.Title = "service unhealthy"
+		ev.Message = "test event"
+	case "threshold_violation":
+		ev.Severity = notify.SeverityWarning
+		ev.Title = "response_time over threshold"
+		ev.Message = fmt.Sprintf("812ms (threshold: %dms) (test event)", cfg.Thresholds.ResponseTimeMs)
+	case "heartbeat_failed":
+		ev.Severity = notify.SeverityCritical
+		ev.Title = "heartbeat failing"
+		ev.Message = "HTTP 503 (test event)"
+	case "remediation":
+		ev.Severity = notify.SeverityWarning
+		ev.Title = "restarted automatically"
+		ev.Message = "heartbeat HTTP 503 after 3 failed checks (test event)"
+	case "report":
+		ev.Severity = notify.SeverityInfo
+		ev.Title = "daily report"
+		ev.Message = "4 deploys (1 failed), uptime 99.0%, 0 threshold violations (test event)"
+	default:
+		return ev, fmt.Errorf("unknown event type: %s\nSupported: deploy_failed, service_down, threshold_violation, heartbeat_failed, remediation, report", eventType)
+	}
+
+	if notifyTestSeverity != "" {
+		switch notifyTestSeverity {
+		case notify.SeverityInfo, notify.SeverityWarning, notify.SeverityCritical:
+			ev.Severity = notifyTestSeverity
+		default:
+			return ev, fmt.Errorf("invalid severity: %s (use info, warning or critical)", notifyTestSeverity)
+		}
+	}
+	return ev, nil
+}
+
+func channelNames(m map[string]config.NotifyChannel) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/term"
+)
+
+// progress writes human-readable progress to stderr, so stdout carries only
+// results and can be piped (orbit watch --format json | jq). Alongside
+// machine-readable output it stays quiet unless stderr is a terminal, which
+// keeps CI logs to the result.
+type progress struct {
+	w io.Writer
+}
+
+func newProgress(machine bool) progress {
+	if machine && !term.IsTerminal(int(os.Stderr.Fd())) {
+		return progress{w: io.Discard}
+	}
+	return progress{w: os.Stderr}
+}
+
+func (p progress) printf(format string, a ...interface{}) {
+	fmt.Fprintf(p.w, format, a...)
+}
+
+// writeJSONFile writes v as indented JSON to path atomically, so readers
+// never observe a partially written file.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it
+// into place.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
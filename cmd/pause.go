@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pauseService  string
+	resumeService string
+)
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause [project]",
+	Short: "Pause the services of a project to stop paying for idle compute",
+	Long: `Pause every service of a project, or one with --service. Paused services
+serve no traffic and keep their configuration; orbit resume brings them back.
+
+  orbit pause staging                 # e.g. from cron at night
+  orbit resume staging                # and again in the morning
+  orbit pause staging --service worker
+
+Supported on Koyeb (pause), Vercel (project pause) and Render (suspend).
+Services on other platforms are skipped.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPauseResume(cmd, args, pauseService, true)
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume [project]",
+	Short: "Resume the paused services of a project",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPauseResume(cmd, args, resumeService, false)
+	},
+}
+
+func init() {
+	pauseCmd.Flags().StringVar(&pauseService, "service", "", "Pause a single service")
+	resumeCmd.Flags().StringVar(&resumeService, "service", "", "Resume a single service")
+	rootCmd.AddCommand(pauseCmd)
+	rootCmd.AddCommand(resumeCmd)
+}
+
+var errPauseUnsupported = errors.New("pause not supported")
+
+func runPauseResume(cmd *cobra.Command, args []string, service string, pause bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+
+	entries := proj.Topology
+	if service != "" {
+		entries = nil
+		for _, e := range proj.Topology {
+			if e.Name == service {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("service %q not found in project %q", service, projectName)
+		}
+	}
+
+	verb, done := "Resuming", "resumed"
+	if pause {
+		verb, done = "Pausing", "paused"
+	}
+	fmt.Printf("\n  %s %s\n\n", verb, ui.ProjectTitleStyle.Render(projectName))
+
+	errs := setPaused(cmd.Context(), cfg, key, entries, pause)
+	failed, changed := 0, 0
+	for i, e := range entries {
+		name := ui.Pad(e.Name, 16)
+		switch {
+		case errors.Is(errs[i], errPauseUnsupported):
+			fmt.Printf("  %s %s\n", name, ui.MutedStyle.Render("skipped ("+e.Platform+" has no pause)"))
+		case errs[i] != nil:
+			failed++
+			fmt.Printf("  %s %s %s\n", name, ui.IconError, ui.ErrorStyle.Render(errs[i].Error()))
+		default:
+			changed++
+			fmt.Printf("  %s %s %s\n", name, ui.IconSuccess, done)
+		}
+	}
+	fmt.Println()
+
+	if service != "" && errors.Is(errs[0], errPauseUnsupported) {
+		return fmt.Errorf("%s does not support pausing services\nSupported: koyeb, vercel, render", entries[0].Platform)
+	}
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d services could not be %s", failed, failed+changed, done)
+	}
+	return nil
+}
+
+// setPaused pauses or resumes entries in parallel. The returned errors line
+// up with entries; errPauseUnsupported marks platforms without pause.
+func setPaused(ctx context.Context, cfg *config.Config, key []byte, entries []config.ServiceEntry, pause bool) []error {
+	errs := make([]error, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func(i int, e config.ServiceEntry) {
+			defer wg.Done()
+			p, err := entryPlatform(cfg, key, e)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			pr, ok := p.(platform.Pauser)
+			if !ok {
+				errs[i] = errPauseUnsupported
+				return
+			}
+			if pause {
+				errs[i] = pr.Pause(ctx, e.ID)
+			} else {
+				errs[i] = pr.Resume(ctx, e.ID)
+			}
+		}(i, e)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// registerPlugins adds the external adapters declared under plugins: in
+// the config to the platform registry, so every command can use them like
+// built-in platforms. Problems are reported on stderr and don't stop the
+// command.
+func registerPlugins(cfg *config.Config) {
+
+	names := make([]string, 0, len(cfg.Plugins))
+	for name := range cfg.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pc := cfg.Plugins[name]
+		env := make(map[string]string, len(pc.Env))
+		for k, v := range pc.Env {
+			// Config keys are lower-cased on load; environment names
+			// are conventionally upper case.
+			env[strings.ToUpper(k)] = v
+		}
+		var caps platform.Capabilities
+		for _, c := range pc.Capabilities {
+			cp, ok := platform.ParseCapability(c)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "%s plugin %s: unknown capability %q\n", ui.IconWarning, name, c)
+				continue
+			}
+			caps |= cp
+		}
+		err := platform.RegisterPlugin(name, platform.PluginConfig{
+			Command:      pc.Command,
+			Args:         pc.Args,
+			Env:          env,
+			Capabilities: caps,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s plugin %s ignored: %s\n", ui.IconWarning, name, err)
+		}
+	}
+}
+
+// supportedPlatforms lists built-in and plugin platform names for messages.
+func supportedPlatforms() string {
+	names := platform.Names()
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/humanetools/orbit/internal/config"
 	"github.com/humanetools/orbit/internal/platform"
@@ -13,7 +15,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var projectAutoDiscover bool
+var (
+	projectAutoDiscover bool
+	projectRefresh      bool
+	projectSyncAdd      bool
+)
 
 var projectCmd = &cobra.Command{
 	Use:   "project [name]",
@@ -23,7 +29,9 @@ var projectCmd = &cobra.Command{
   orbit project <name>                Show project details
   orbit project create <name>         Create a new project
   orbit project create <name> --auto  Create and auto-discover services
-  orbit project delete <name>         Delete a project`,
+  orbit project sync <name>           Check services against their platforms (--add to add new ones)
+  orbit project delete <name>         Move a project to the trash
+  orbit project restore [name]        Restore a deleted project (lists trash without a name)`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runProjectShow,
 }
@@ -35,17 +43,42 @@ var projectCreateCmd = &cobra.Command{
 	RunE:  runProjectCreate,
 }
 
+var projectSyncCmd = &cobra.Command{
+	Use:   "sync <name>",
+	Short: "Compare a project's services with what its platforms report",
+	Long: `Discover services on the platforms a project uses and compare them with its
+topology: renamed services get their new remote name, services gone from the
+platform are reported, and services not in the project are listed. Pass
+--add to add those to the project.
+
+Discovery results are cached for 30 minutes; --refresh lists again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectSync,
+}
+
 var projectDeleteCmd = &cobra.Command{
 	Use:   "delete <name>",
-	Short: "Delete a project",
+	Short: "Delete a project (restorable for 30 days)",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runProjectDelete,
 }
 
+var projectRestoreCmd = &cobra.Command{
+	Use:   "restore [name]",
+	Short: "Restore a deleted project from the trash",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProjectRestore,
+}
+
 func init() {
 	projectCreateCmd.Flags().BoolVar(&projectAutoDiscover, "auto", false, "Auto-discover services from connected platforms")
+	projectCreateCmd.Flags().BoolVar(&projectRefresh, "refresh", false, "Re-discover services instead of using cached results")
+	projectSyncCmd.Flags().BoolVar(&projectSyncAdd, "add", false, "Add discovered services that aren't in the project")
+	projectSyncCmd.Flags().BoolVar(&projectRefresh, "refresh", false, "Re-discover services instead of using cached results")
 	projectCmd.AddCommand(projectCreateCmd)
+	projectCmd.AddCommand(projectSyncCmd)
 	projectCmd.AddCommand(projectDeleteCmd)
+	projectCmd.AddCommand(projectRestoreCmd)
 	rootCmd.AddCommand(projectCmd)
 }
 
@@ -71,37 +104,40 @@ func runProjectCreate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("load encryption key: %w", err)
 		}
 
-		tokens := make(map[string]string)
-		for pName, pc := range cfg.Platforms {
-			token, err := config.Decrypt(key, pc.Token)
-			if err != nil {
-				fmt.Printf("  %s skipping %s: %s\n", ui.IconWarning, pName, err)
-				continue
-			}
-			tokens[pName] = token
-		}
-
-		if len(tokens) == 0 {
+		if len(cfg.Platforms) == 0 {
 			return fmt.Errorf("no connected platforms\nRun: orbit connect <platform>")
 		}
+		platforms := make([]string, 0, len(cfg.Platforms))
+		for pName := range cfg.Platforms {
+			platforms = append(platforms, pName)
+		}
 
 		fmt.Printf("  Discovering services... ")
-		discovered, errMap := platform.DiscoverAll(tokens)
-		for pName, dErr := range errMap {
+		d := discoverAll(cmd.Context(), cfg, key, platforms, projectRefresh)
+		discovered := d.Services
+		for pName, dErr := range d.Errs {
 			fmt.Printf("\n  %s %s: %s", ui.IconWarning, pName, dErr)
 		}
 
 		if len(discovered) == 0 {
 			fmt.Println(ui.MutedStyle.Render("none found"))
 		} else {
-			fmt.Println(ui.HealthyStyle.Render(fmt.Sprintf("%d found", len(discovered))))
+			found := fmt.Sprintf("%d found", len(discovered))
+			if d.Cached {
+				found += " (cached, --refresh to re-discover)"
+			}
+			fmt.Println(ui.HealthyStyle.Render(found))
+			var entries []config.ServiceEntry
 			for _, svc := range discovered {
-				proj.Topology = append(proj.Topology, config.ServiceEntry{
-					Name:     svc.Name,
-					Platform: svc.Platform,
-					ID:       svc.ID,
+				entries = append(entries, config.ServiceEntry{
+					Name:       svc.Name,
+					Platform:   svc.Platform,
+					ID:         svc.ID,
+					RemoteName: svc.Name,
+					URL:        svc.URL,
 				})
 			}
+			proj.Topology = resolveDuplicateNames(nil, entries)
 		}
 	}
 
@@ -122,6 +158,12 @@ func runProjectCreate(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	if len(proj.Topology) > 0 {
+		printHints(cfg, "orbit status "+name, "orbit watch "+name+" --all")
+	} else {
+		printHints(cfg, "orbit service add "+name+" --platform <platform> --name <name> --id <id>")
+	}
+
 	return nil
 }
 
@@ -186,6 +228,124 @@ func runProjectShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runProjectSync(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	proj, ok := cfg.Projects[name]
+	if !ok {
+		return fmt.Errorf("project %q not found\nAvailable projects: %s", name, projectNames(cfg))
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	// The project's own platforms, or every connected one for an empty project.
+	seen := make(map[string]bool)
+	var platforms []string
+	for _, e := range proj.Topology {
+		if _, connected := cfg.Platforms[e.Platform]; connected && !seen[e.Platform] {
+			seen[e.Platform] = true
+			platforms = append(platforms, e.Platform)
+		}
+	}
+	if len(proj.Topology) == 0 {
+		for pName := range cfg.Platforms {
+			platforms = append(platforms, pName)
+		}
+	}
+	if len(platforms) == 0 {
+		return fmt.Errorf("none of the platforms in %s are connected\nRun: orbit connect <platform>", name)
+	}
+
+	fmt.Printf("\n  Discovering services... ")
+	d := discoverAll(cmd.Context(), cfg, key, platforms, projectRefresh)
+	found := fmt.Sprintf("%d found", len(d.Services))
+	if d.Cached {
+		found += " (cached, --refresh to re-discover)"
+	}
+	fmt.Println(ui.HealthyStyle.Render(found))
+	for pName, dErr := range d.Errs {
+		fmt.Printf("  %s %s: %s\n", ui.IconWarning, pName, dErr)
+	}
+	fmt.Println()
+
+	byID := make(map[string]platform.DiscoveredService, len(d.Services))
+	for _, svc := range d.Services {
+		byID[svc.Platform+"/"+svc.ID] = svc
+	}
+
+	renamed, missing, relinked := 0, 0, 0
+	inProject := make(map[string]bool)
+	for i := range proj.Topology {
+		e := &proj.Topology[i]
+		inProject[e.Platform+"/"+e.ID] = true
+		if !d.Listed[e.Platform] {
+			continue
+		}
+		svc, ok := byID[e.Platform+"/"+e.ID]
+		if !ok {
+			missing++
+			fmt.Printf("  %s %s: no longer found on %s\n", ui.IconWarning, e.Name, e.Platform)
+			continue
+		}
+		if svc.Name != e.RemoteName {
+			if e.RemoteName != "" {
+				fmt.Printf("  %s %s: renamed on %s from %s to %s\n", ui.IconSuccess, e.Name, e.Platform, e.RemoteName, svc.Name)
+			}
+			e.RemoteName = svc.Name
+			renamed++
+		}
+		if svc.URL != "" && svc.URL != e.URL {
+			e.URL = svc.URL
+			relinked++
+		}
+	}
+
+	var added []config.ServiceEntry
+	for _, svc := range d.Services {
+		if inProject[svc.Platform+"/"+svc.ID] {
+			continue
+		}
+		added = append(added, config.ServiceEntry{
+			Name:       svc.Name,
+			Platform:   svc.Platform,
+			ID:         svc.ID,
+			RemoteName: svc.Name,
+			URL:        svc.URL,
+		})
+	}
+	if len(added) > 0 {
+		fmt.Printf("  Not in %s:\n", name)
+		for _, e := range added {
+			fmt.Printf("    %s %s\n", e.Name, ui.MutedStyle.Render("("+e.Platform+" "+e.ID+")"))
+		}
+		if projectSyncAdd {
+			proj.Topology = append(proj.Topology, resolveDuplicateNames(proj.Topology, added)...)
+		} else {
+			fmt.Printf("  %s\n", ui.MutedStyle.Render("Run with --add to add them."))
+		}
+	}
+
+	if renamed > 0 || relinked > 0 || (projectSyncAdd && len(added) > 0) {
+		cfg.Projects[name] = proj
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+	}
+
+	if missing == 0 && len(added) == 0 {
+		fmt.Printf("  %s %s is in sync with its platforms\n", ui.IconSuccess, ui.ProjectTitleStyle.Render(name))
+	} else if projectSyncAdd && len(added) > 0 {
+		fmt.Printf("\n  %s Added %d services to %s\n", ui.IconSuccess, len(added), ui.ProjectTitleStyle.Render(name))
+	}
+	return nil
+}
+
 func runProjectDelete(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
@@ -199,7 +359,8 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Confirmation prompt
-	fmt.Printf("  Delete project %s? This cannot be undone. [y/N] ", ui.ProjectTitleStyle.Render(name))
+	fmt.Printf("  Delete project %s? It can be restored for %d days. [y/N] ",
+		ui.ProjectTitleStyle.Render(name), int(config.TrashRetention.Hours()/24))
 	reader := bufio.NewReader(os.Stdin)
 	answer, _ := reader.ReadString('\n')
 	answer = strings.TrimSpace(strings.ToLower(answer))
@@ -209,10 +370,13 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	delete(cfg.Projects, name)
-
-	if cfg.DefaultProject == name {
-		cfg.DefaultProject = ""
+	now := time.Now()
+	config.PurgeTrash(cfg, now)
+	if err := config.TrashProject(cfg, name, now); err != nil {
+		return err
+	}
+	if err := moveProjectHistory(name, true); err != nil {
+		fmt.Printf("  %s move history to trash: %s\n", ui.IconWarning, err)
 	}
 
 	if err := config.Save(cfg); err != nil {
@@ -220,5 +384,104 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("  %s Project %s deleted.\n", ui.IconSuccess, name)
+	fmt.Printf("  Restore it with: orbit project restore %s\n", name)
+	return nil
+}
+
+func runProjectRestore(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	purged := config.PurgeTrash(cfg, time.Now())
+	if len(purged) > 0 {
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+	}
+
+	if len(args) == 0 {
+		if len(cfg.Trash) == 0 {
+			fmt.Println("  Trash is empty.")
+			return nil
+		}
+		names := make([]string, 0, len(cfg.Trash))
+		for name := range cfg.Trash {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println(ui.ProjectTitleStyle.Render("Deleted projects"))
+		for _, name := range names {
+			t := cfg.Trash[name]
+			fmt.Printf("  %-20s %d services  deleted %s  %s\n", name, len(t.Project.Topology),
+				ui.TimeAgo(t.DeletedAt),
+				ui.MutedStyle.Render("expires "+t.ExpiresAt().Format("2006-01-02")))
+		}
+		return nil
+	}
+
+	name := args[0]
+	if _, ok := cfg.Trash[name]; !ok {
+		for _, p := range purged {
+			if p == name {
+				return fmt.Errorf("project %q was deleted more than %d days ago and has been purged",
+					name, int(config.TrashRetention.Hours()/24))
+			}
+		}
+	}
+	if err := config.RestoreProject(cfg, name); err != nil {
+		return err
+	}
+	if err := moveProjectHistory(name, false); err != nil {
+		fmt.Printf("  %s restore history: %s\n", ui.IconWarning, err)
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	fmt.Printf("  %s Project %s restored (%d services).\n", ui.IconSuccess, name, len(cfg.Projects[name].Topology))
+	return nil
+}
+
+// projectHistoryFiles returns the per-project files kept alongside the config.
+func projectHistoryFiles(name string) []string {
+	return []string{heartbeatLogPath(name)}
+}
+
+// moveProjectHistory moves a project's history files into its trash
+// directory (toTrash) or back. Missing files are skipped.
+func moveProjectHistory(name string, toTrash bool) error {
+	trashDir, err := config.TrashDir(name)
+	if err != nil {
+		return err
+	}
+	if toTrash {
+		// A fresh delete replaces any older trashed copy.
+		os.RemoveAll(trashDir)
+	}
+
+	for _, live := range projectHistoryFiles(name) {
+		trashed := filepath.Join(trashDir, filepath.Base(live))
+		src, dst := live, trashed
+		if !toTrash {
+			src, dst = trashed, live
+		}
+		if _, err := os.Stat(src); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+			return err
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+	}
+
+	if !toTrash {
+		os.RemoveAll(trashDir)
+	}
 	return nil
 }
@@ -1,21 +1,38 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/hook"
+	"github.com/humanetools/orbit/internal/platform"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-var redeployService string
+var (
+	redeployService string
+	redeployFormat  string
+)
 
 var redeployCmd = &cobra.Command{
 	Use:   "redeploy <project>",
 	Short: "Redeploy a service",
 	Long: `Trigger a redeployment for a service.
 
-  orbit redeploy myshop --service api`,
+  orbit redeploy myshop --service api
+  orbit redeploy myshop --service api --format json
+
+The JSON output carries the new deployment's ID, which orbit watch --deploy
+follows:
+
+  id=$(orbit redeploy myshop --service api --format json | jq -r .deploy_id)
+  orbit watch myshop --service api --deploy "$id"`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runRedeploy,
 }
@@ -23,6 +40,7 @@ var redeployCmd = &cobra.Command{
 func init() {
 	redeployCmd.Flags().StringVar(&redeployService, "service", "", "Service name (required)")
 	redeployCmd.MarkFlagRequired("service")
+	redeployCmd.Flags().StringVar(&redeployFormat, "format", "", "Output format (json)")
 	rootCmd.AddCommand(redeployCmd)
 }
 
@@ -48,10 +66,26 @@ func runRedeploy(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := requireCapability(resolved, platform.CapRedeploy); err != nil {
+		return err
+	}
+
+	if redeployFormat == "json" {
+		deploy, err := resolved.Platform.Redeploy(cmd.Context(), resolved.Entry.ID)
+		if err != nil {
+			return fmt.Errorf("redeploy failed: %w", err)
+		}
+		return printJSON(struct {
+			Project  string `json:"project"`
+			Service  string `json:"service"`
+			DeployID string `json:"deploy_id"`
+			Status   string `json:"status"`
+		}{projectName, resolved.Entry.Name, deploy.ID, deploy.Status})
+	}
 
 	fmt.Printf("  Redeploying %s/%s (%s)... ", projectName, resolved.Entry.Name, resolved.Entry.Platform)
 
-	deploy, err := resolved.Platform.Redeploy(resolved.Entry.ID)
+	deploy, err := resolved.Platform.Redeploy(cmd.Context(), resolved.Entry.ID)
 	if err != nil {
 		fmt.Println(ui.ErrorStyle.Render("failed"))
 		return fmt.Errorf("redeploy failed: %w", err)
@@ -61,7 +95,54 @@ func runRedeploy(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\n  %s Redeployment started\n", ui.IconDeploy)
 	fmt.Printf("  Deploy ID: %s\n", deploy.ID)
 	fmt.Printf("  Status:    %s\n", ui.FormatStatus(deploy.Status))
-	fmt.Printf("\n  Track progress: orbit watch %s --service %s\n", projectName, redeployService)
+	fmt.Printf("\n  Track progress: orbit watch %s --service %s --deploy %s\n", projectName, redeployService, shortID(deploy.ID))
 
 	return nil
 }
+
+// deployHookTimeout bounds a service's deploy_hook.
+const deployHookTimeout = 2 * time.Minute
+
+// triggerDeploy starts a deployment of resolved for watch --redeploy:
+// through the service's deploy_hook when it has one, otherwise with the
+// platform's redeploy. It returns the new deployment's ID, or "" when a
+// hook started it and the ID isn't known.
+func triggerDeploy(ctx context.Context, resolved *resolvedService) (string, error) {
+	trigger := resolved.Entry.DeployHook
+	if trigger == "" {
+		d, err := resolved.Platform.Redeploy(ctx, resolved.Entry.ID)
+		if err != nil {
+			return "", fmt.Errorf("redeploy: %w", err)
+		}
+		if d == nil {
+			return "", nil
+		}
+		return d.ID, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deployHookTimeout)
+	defer cancel()
+	if strings.HasPrefix(trigger, "http://") || strings.HasPrefix(trigger, "https://") {
+		req, err := http.NewRequestWithContext(ctx, "POST", trigger, nil)
+		if err != nil {
+			return "", fmt.Errorf("deploy hook: %w", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("deploy hook: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return "", fmt.Errorf("deploy hook: HTTP %d", resp.StatusCode)
+		}
+		return "", nil
+	}
+	out, err := hook.Command(ctx, trigger).CombinedOutput()
+	if err != nil {
+		if lines := strings.Split(string(bytes.TrimSpace(out)), "\n"); lines[len(lines)-1] != "" {
+			return "", fmt.Errorf("deploy hook %q: %v: %s", trigger, err, lines[len(lines)-1])
+		}
+		return "", fmt.Errorf("deploy hook %q: %w", trigger, err)
+	}
+	return "", nil
+}
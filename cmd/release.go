@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseService    string
+	releaseTimeout    int
+	releaseNoRollback bool
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release <project>",
+	Short: "Redeploy a project's services in topology order",
+	Long: `Redeploy the services of a project one at a time, in topology order,
+waiting for each to become healthy before starting the next. When a service
+fails or times out, the services already released are rolled back to the
+deployments that were live before the release, last released first.
+
+  orbit release myshop
+  orbit release myshop --service api,web
+  orbit release myshop --timeout 600 --no-rollback
+
+Set the order with orbit topology myshop --set "db → api → web". Services on
+platforms that can't redeploy are skipped. Rollback pins the previous
+deployment and waits for it to be healthy again; services on platforms that
+can't pin a deployment are reported and left as released.
+
+Exit codes:
+  0  Every service released and healthy
+  1  A service failed to deploy
+  2  A redeploy was triggered but no new deployment showed up
+  3  Timeout (a deploy was still in progress)`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRelease,
+}
+
+func init() {
+	releaseCmd.Flags().StringVar(&releaseService, "service", "", "Only release these services (comma-separated), still in topology order")
+	releaseCmd.Flags().IntVar(&releaseTimeout, "timeout", 300, "Maximum wait time per service in seconds")
+	releaseCmd.Flags().BoolVar(&releaseNoRollback, "no-rollback", false, "Leave released services in place when a later one fails")
+	rootCmd.AddCommand(releaseCmd)
+}
+
+// releaseStep is one service of a release.
+type releaseStep struct {
+	resolved *resolvedService
+	// liveID is the last healthy deployment before the release, which a
+	// rollback returns to.
+	liveID string
+	result watchResult
+}
+
+func runRelease(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	if err := applyWatchTimings(cmd, cfg.Watch); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, e := range proj.Topology {
+		names = append(names, e.Name)
+	}
+	only, err := parseRequired(releaseService, names)
+	if err != nil {
+		return err
+	}
+
+	var steps []*releaseStep
+	for _, name := range names {
+		if only != nil && !only[name] {
+			continue
+		}
+		r, err := resolveService(cfg, key, projectName, name)
+		if err != nil {
+			return err
+		}
+		if err := requireCapability(r, platform.CapDeployments|platform.CapRedeploy|platform.CapWatch); err != nil {
+			if only != nil {
+				return err
+			}
+			fmt.Printf("  %s\n", ui.MutedStyle.Render(fmt.Sprintf("Skipping %s: %s", name, err)))
+			continue
+		}
+		setPollInterval(r)
+		steps = append(steps, &releaseStep{resolved: r})
+	}
+	if len(steps) == 0 {
+		return fmt.Errorf("no services in project %q can be redeployed", projectName)
+	}
+
+	order := make([]string, len(steps))
+	for i, s := range steps {
+		order[i] = s.resolved.Entry.Name
+	}
+	fmt.Printf("\n  %s Releasing %s: %s\n", ui.IconRocket, projectName, strings.Join(order, " → "))
+
+	ctx := cmd.Context()
+	timeout := time.Duration(releaseTimeout) * time.Second
+	var results []watchResult
+	failed := -1
+	for i, s := range steps {
+		s.result = releaseOne(ctx, projectName, s, timeout)
+		results = append(results, s.result)
+		printServiceResult(os.Stdout, projectName, s.resolved.Entry.Name, s.result)
+		if s.result.ExitCode != exitSuccess {
+			failed = i
+			break
+		}
+	}
+	recordWatchIncidents(projectName, results)
+	recordWatchHistory(projectName, results)
+	notifyWatchFailures(cfg, projectName, results)
+	runDeploySuccessHooks(cfg, projectName, results)
+
+	if failed < 0 {
+		fmt.Printf("\n  %s Released %d service(s)\n\n", ui.HealthyStyle.Render(ui.IconSuccess), len(steps))
+		return nil
+	}
+
+	if failed > 0 && !releaseNoRollback {
+		fmt.Printf("\n  %s Rolling back %d released service(s)\n", ui.IconWarning, failed)
+		for i := failed - 1; i >= 0; i-- {
+			rollbackRelease(ctx, projectName, steps[i], timeout)
+		}
+	}
+	if skipped := len(steps) - failed - 1; skipped > 0 {
+		fmt.Printf("\n  %s\n", ui.MutedStyle.Render(fmt.Sprintf("%d service(s) not released", skipped)))
+	}
+	fmt.Println()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return &ExitCodeError{Code: steps[failed].result.ExitCode, Msg: ""}
+}
+
+// releaseOne redeploys a service and follows the new deployment until it
+// is healthy or fails.
+func releaseOne(ctx context.Context, projectName string, s *releaseStep, timeout time.Duration) watchResult {
+	resolved := s.resolved
+	result := watchResult{
+		ServiceName: resolved.Entry.Name,
+		Platform:    resolved.Entry.Platform,
+		Optional:    resolved.Entry.Optional,
+	}
+
+	deploys, err := resolved.Platform.ListDeployments(ctx, resolved.Entry.ID, 10)
+	if err != nil {
+		result.ExitCode = exitFailed
+		result.Error = fmt.Sprintf("list deployments: %s", err)
+		result.ErrorKind = platform.ErrorKind(err)
+		return result
+	}
+	if len(deploys) > 0 {
+		result.PrevDeployID = deploys[0].ID
+	}
+	for _, d := range deploys {
+		if d.Status == "healthy" {
+			s.liveID = d.ID
+			break
+		}
+	}
+
+	fmt.Printf("\n  %s Redeploying %s...\n", ui.IconDeploy, resolved.Entry.Name)
+	d, err := resolved.Platform.Redeploy(ctx, resolved.Entry.ID)
+	if err != nil {
+		result.ExitCode = exitFailed
+		result.Error = fmt.Sprintf("redeploy: %s", err)
+		result.ErrorKind = platform.ErrorKind(err)
+		return result
+	}
+	deployID := ""
+	if d != nil {
+		deployID = d.ID
+	}
+	return followDeployment(ctx, resolved, projectName, result, deployID, timeout)
+}
+
+// rollbackRelease returns a released service to the deployment that was
+// live before the release, and waits for it to serve again.
+func rollbackRelease(ctx context.Context, projectName string, s *releaseStep, timeout time.Duration) {
+	resolved := s.resolved
+	name := resolved.Entry.Name
+	fmt.Printf("  %s ", ui.Pad(name, 20))
+	if s.liveID == "" {
+		fmt.Println(ui.WarningStyle.Render("no earlier healthy deployment, left as released"))
+		return
+	}
+	if !resolved.Platform.Capabilities().Has(platform.CapRollback) {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("cannot roll back: %s can't pin %s, left as released", resolved.Entry.Platform, shortID(s.liveID))))
+		return
+	}
+
+	deploy, err := resolved.Platform.RollbackTo(ctx, resolved.Entry.ID, s.liveID)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("rollback failed: " + err.Error()))
+		return
+	}
+	// Platforms that promote the target serve it right away; the others
+	// start a new deployment of it.
+	if deploy.ID != s.liveID {
+		if code, msg := waitForRollback(ctx, resolved, deploy.ID, timeout); code != exitSuccess {
+			fmt.Println(ui.ErrorStyle.Render("rollback failed: " + msg))
+			return
+		}
+	}
+
+	detail := "rolled back to " + shortID(s.liveID)
+	fmt.Printf("%s %s\n", ui.HealthyStyle.Render(detail), ui.MutedStyle.Render(shortID(deploy.ID)))
+	recordEvents(notify.Event{
+		Type: "release_rolled_back", Severity: notify.SeverityWarning, Project: projectName, Service: name,
+		Title: "release rolled back", Message: detail, Time: time.Now(),
+	})
+}
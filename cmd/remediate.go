@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/remediation"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// statusCheckInterval is how often the heartbeat daemon polls platform
+// status for services that have a remediation policy but no heartbeat URL.
+const statusCheckInterval = time.Minute
+
+// healer applies a service's remediation policy to the heartbeat daemon's
+// check results.
+type healer struct {
+	project   string
+	resolved  *resolvedService
+	policy    remediation.Policy
+	tracker   *remediation.Tracker
+	nc        config.NotificationsConfig
+	auditPath string
+}
+
+// newHealer resolves the service's platform and seeds the hourly limit from
+// the audit log, so restarting the daemon doesn't reset it.
+func newHealer(cfg *config.Config, key []byte, projectName string, entry config.ServiceEntry) (*healer, error) {
+	policy, err := remediation.ParsePolicy(*entry.Remediation)
+	if err != nil {
+		return nil, fmt.Errorf("service %q remediation: %w", entry.Name, err)
+	}
+	resolved, err := resolveService(cfg, key, projectName, entry.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCapability(resolved, platform.CapRedeploy); err != nil {
+		return nil, err
+	}
+
+	auditPath, err := remediation.AuditPath()
+	if err != nil {
+		return nil, err
+	}
+	records, err := remediation.Load(auditPath)
+	if err != nil {
+		return nil, err
+	}
+	past := remediation.ActionTimes(records, projectName, entry.Name, time.Now().Add(-time.Hour))
+
+	return &healer{
+		project:   projectName,
+		resolved:  resolved,
+		policy:    policy,
+		tracker:   remediation.NewTracker(policy, past),
+		nc:        cfg.Notifications,
+		auditPath: auditPath,
+	}, nil
+}
+
+// checkStatus reports whether the platform considers the service healthy.
+// ok is false when the status could not be fetched, which says nothing
+// about the service itself.
+func (h *healer) checkStatus(ctx context.Context) (healthy bool, detail string, ok bool) {
+	st, err := h.resolved.Platform.GetServiceStatus(ctx, h.resolved.Entry.ID)
+	if err != nil {
+		return false, err.Error(), false
+	}
+	return st.Status != "unhealthy", st.Status, true
+}
+
+// observe feeds one check result to the policy and runs the action when it
+// is due. reason describes the failed check for the audit log.
+func (h *healer) observe(ctx context.Context, healthy bool, reason string) {
+	name := h.resolved.Entry.Name
+	now := time.Now()
+
+	rec := remediation.Record{
+		Time:    now,
+		Project: h.project,
+		Service: name,
+		Action:  h.policy.Action,
+		Reason:  reason,
+	}
+	ev := notify.Event{
+		Type:    "remediation",
+		Project: h.project,
+		Service: name,
+		Tags:    h.resolved.Entry.Tags,
+		Owner:   h.resolved.Entry.Owner,
+		Time:    now,
+	}
+
+	switch h.tracker.Observe(healthy, now) {
+	case remediation.Act:
+		deploy, err := h.resolved.Platform.Redeploy(ctx, h.resolved.Entry.ID)
+		if err != nil {
+			rec.Result, rec.Error = "failed", err.Error()
+			ev.Severity = notify.SeverityCritical
+			ev.Title = "automatic restart failed"
+			ev.Message = err.Error()
+			fmt.Printf("  [%s] %-12s  %s restart failed: %s\n", now.Format("15:04:05"), name,
+				ui.ErrorStyle.Render(ui.IconFailed), err)
+		} else {
+			rec.Result, rec.DeployID = "triggered", deploy.ID
+			ev.Severity = notify.SeverityWarning
+			ev.Title = "restarted automatically"
+			ev.Message = fmt.Sprintf("%s after %d failed checks (deploy %s)", reason, h.policy.After, shortID(deploy.ID))
+			fmt.Printf("  [%s] %-12s  %s restarted (deploy %s)\n", now.Format("15:04:05"), name,
+				ui.WarningStyle.Render(ui.IconDeploy), shortID(deploy.ID))
+		}
+	case remediation.Limited:
+		rec.Result = "limited"
+		ev.Severity = notify.SeverityCritical
+		ev.Title = "still unhealthy, automatic restarts exhausted"
+		ev.Message = fmt.Sprintf("%s; limit of %d restarts per hour reached", reason, h.policy.MaxPerHour)
+		fmt.Printf("  [%s] %-12s  %s restart limit reached (%d/hour)\n", now.Format("15:04:05"), name,
+			ui.ErrorStyle.Render(ui.IconWarning), h.policy.MaxPerHour)
+	default:
+		return
+	}
+
+	if err := remediation.Append(h.auditPath, rec); err != nil {
+		fmt.Printf("  %s audit log: %s\n", ui.IconWarning, err)
+	}
+	recordEvents(ev)
+	if len(h.nc.Channels) > 0 {
+		printNotifyErrors(notify.Notify(h.nc, ev))
+	}
+}
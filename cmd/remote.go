@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// syncRemoteNames records the platform-side name and public URL reported in
+// each status and saves the config when either changed. Services are tracked
+// by their stable ID, so a rename only changes the last-known name.
+// Notices go to stderr to keep JSON output clean.
+func syncRemoteNames(cfg *config.Config, projectName string, results []ui.ServiceResult) {
+	proj, ok := cfg.Projects[projectName]
+	if !ok {
+		return
+	}
+
+	changed := false
+	for _, r := range results {
+		if r.Status == nil {
+			continue
+		}
+		for i := range proj.Topology {
+			e := &proj.Topology[i]
+			if e.Name != r.Entry.Name {
+				continue
+			}
+			if r.Status.URL != "" && e.URL != r.Status.URL {
+				e.URL = r.Status.URL
+				changed = true
+			}
+			if r.Status.Name == "" || e.RemoteName == r.Status.Name {
+				continue
+			}
+			if e.RemoteName != "" {
+				fmt.Fprintf(os.Stderr, "%s %s project for %s/%s was renamed: %s → %s (config updated)\n",
+					ui.IconWarning, e.Platform, projectName, e.Name, e.RemoteName, r.Status.Name)
+			}
+			e.RemoteName = r.Status.Name
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+
+	cfg.Projects[projectName] = proj
+	if err := config.Save(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "%s save config: %s\n", ui.IconWarning, err)
+	}
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/incident"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportHTML    string
+	reportDeploys int
+	reportDays    int
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report [project]",
+	Short: "Write a status report as a standalone HTML page",
+	Long: `Write a styled status report for a project: current status and metrics,
+threshold violations, uptime from the incident log, and recent deployments.
+The page has no external assets, so it can be mailed or archived as is.
+
+  orbit report myshop --html status.html
+  orbit report myshop --html status.html --days 30 --deploys 10
+  orbit report myshop --html - | mail -s "myshop status" team@example.com`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportHTML, "html", "", "Write the report to this file (- for stdout)")
+	reportCmd.Flags().IntVar(&reportDeploys, "deploys", 5, "Recent deployments to list per service")
+	reportCmd.Flags().IntVar(&reportDays, "days", 7, "Days of incident history uptime covers")
+	reportCmd.MarkFlagRequired("html")
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	if reportDays <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+
+	report := buildHTMLReport(cmd.Context(), cfg, key, projectName, proj, reportDays, reportDeploys)
+	if reportHTML == "-" {
+		return ui.RenderHTMLReport(os.Stdout, report)
+	}
+	f, err := os.Create(reportHTML)
+	if err != nil {
+		return fmt.Errorf("create report: %w", err)
+	}
+	if err := ui.RenderHTMLReport(f, report); err != nil {
+		f.Close()
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	fmt.Printf("  %s Report for %s written to %s\n", ui.IconSuccess, projectName, reportHTML)
+	return nil
+}
+
+// reportDeploySearch is how many recent deployments are scanned per service
+// when counting a report period's deploys.
+const reportDeploySearch = 100
+
+// checkCounts tallies the heartbeat daemon's checks per service between
+// reports.
+type checkCounts struct {
+	mu     sync.Mutex
+	checks map[string]int
+	failed map[string]int
+}
+
+func newCheckCounts() *checkCounts {
+	return &checkCounts{checks: map[string]int{}, failed: map[string]int{}}
+}
+
+func (c *checkCounts) record(service string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[service]++
+	if !ok {
+		c.failed[service]++
+	}
+}
+
+// take returns the counts so far and starts over.
+func (c *checkCounts) take() (checks, failed map[string]int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checks, failed = c.checks, c.failed
+	c.checks, c.failed = map[string]int{}, map[string]int{}
+	return checks, failed
+}
+
+// projectReports returns the parsed report schedules that apply to a project.
+func projectReports(cfg *config.Config, projectName string) ([]notify.Schedule, []config.ReportConfig, error) {
+	var schedules []notify.Schedule
+	var configs []config.ReportConfig
+	for _, rc := range cfg.Notifications.Reports {
+		if rc.Project != "" && rc.Project != projectName {
+			continue
+		}
+		s, err := notify.ParseSchedule(rc)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, name := range rc.Channels {
+			if _, ok := cfg.Notifications.Channels[name]; !ok {
+				return nil, nil, fmt.Errorf("report channel %q not found", name)
+			}
+		}
+		schedules = append(schedules, s)
+		configs = append(configs, rc)
+	}
+	return schedules, configs, nil
+}
+
+// runReports sends each scheduled report when it is due until ctx is done.
+// Check counts are shared, so with several schedules each report covers the
+// checks since the previous report of any schedule.
+func runReports(ctx context.Context, wg *sync.WaitGroup, cfg *config.Config, key []byte, projectName string, counts *checkCounts,
+	schedules []notify.Schedule, configs []config.ReportConfig) {
+	for i := range schedules {
+		wg.Add(1)
+		go func(s notify.Schedule, rc config.ReportConfig) {
+			defer wg.Done()
+			for {
+				next := s.Next(time.Now())
+				if !sleepUntil(ctx, next) {
+					return
+				}
+				report := buildReport(ctx, cfg, key, projectName, s, counts, next)
+				sendReport(cfg.Notifications, rc, report.Event())
+				fmt.Printf("  [%s] %s %s report sent\n", time.Now().Format("15:04:05"), ui.IconSuccess, s.Every)
+			}
+		}(schedules[i], configs[i])
+	}
+}
+
+func sleepUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// buildReport counts each service's deployments over the period ending at
+// to, adds the daemon's check counts and the thresholds exceeded right now.
+func buildReport(ctx context.Context, cfg *config.Config, key []byte, projectName string, s notify.Schedule, counts *checkCounts, to time.Time) notify.Report {
+	proj := cfg.Projects[projectName]
+	report := notify.Report{
+		Project: projectName,
+		Every:   s.Every,
+		From:    to.Add(-s.Period()),
+		To:      to,
+	}
+
+	checks, failed := counts.take()
+	statuses := fetchStatuses(ctx, proj.Topology, cfg, key)
+	for i, e := range proj.Topology {
+		svc := notify.ReportService{
+			Name:         e.Name,
+			Checks:       checks[e.Name],
+			FailedChecks: failed[e.Name],
+		}
+		if st := statuses[i]; st.Err == nil && st.Status != nil {
+			for _, v := range ui.CheckThresholds(e.Name, st.Status, cfg.Thresholds) {
+				svc.Violations = append(svc.Violations, v.Metric+" "+v.Value)
+			}
+		}
+
+		deploys, err := listServiceDeploys(ctx, cfg, key, e, reportDeploySearch)
+		if err != nil {
+			svc.Error = err.Error()
+		}
+		for _, d := range deploys {
+			if d.CreatedAt.Before(report.From) || !d.CreatedAt.Before(to) {
+				continue
+			}
+			svc.Deploys++
+			if d.Status == "failed" {
+				svc.FailedDeploys++
+			}
+		}
+		report.Services = append(report.Services, svc)
+	}
+	return report
+}
+
+// buildHTMLReport gathers a project's statuses, uptime over the last days
+// from the incident log, and up to deploys recent deployments per service.
+func buildHTMLReport(ctx context.Context, cfg *config.Config, key []byte, projectName string, proj *config.ProjectConfig, days, deploys int) ui.HTMLReport {
+	now := time.Now()
+	from := now.AddDate(0, 0, -days)
+	period := now.Sub(from)
+	incidents := loadIncidents()
+
+	report := ui.HTMLReport{
+		Project:     projectName,
+		GeneratedAt: now,
+		UptimeDays:  days,
+		Thresholds:  cfg.Thresholds,
+	}
+	results := fetchStatuses(ctx, proj.Topology, cfg, key)
+	for i, e := range proj.Topology {
+		svc := ui.HTMLService{Result: results[i]}
+
+		var overlapping []incident.Incident
+		for _, inc := range incidents.ForService(projectName, e.Name) {
+			if inc.StartedAt.Before(now) && (inc.Active() || inc.ResolvedAt.After(from)) {
+				overlapping = append(overlapping, inc)
+			}
+		}
+		svc.Incidents = len(overlapping)
+		svc.Uptime = 100 * (1 - float64(incident.Downtime(overlapping, from, now))/float64(period))
+
+		if deploys > 0 {
+			list, err := listServiceDeploys(ctx, cfg, key, e, deploys)
+			if err != nil {
+				svc.DeployErr = err
+			}
+			svc.Deploys = list
+		}
+		report.Services = append(report.Services, svc)
+	}
+	return report
+}
+
+func listServiceDeploys(ctx context.Context, cfg *config.Config, key []byte, e config.ServiceEntry, limit int) ([]platform.Deployment, error) {
+	p, err := entryPlatform(cfg, key, e)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Capabilities().Has(platform.CapDeployments) {
+		return nil, fmt.Errorf("no deployment history on %s", e.Platform)
+	}
+	return p.ListDeployments(ctx, e.ID, limit)
+}
+
+// sendReport delivers ev to the report's channels, or through routing when
+// it names none.
+func sendReport(nc config.NotificationsConfig, rc config.ReportConfig, ev notify.Event) {
+	if len(rc.Channels) == 0 {
+		printNotifyErrors(notify.Notify(nc, ev))
+		return
+	}
+	errMap := make(map[string]error)
+	for _, name := range rc.Channels {
+		if err := notify.Send(nc.Channels[name], []notify.Event{ev}); err != nil {
+			errMap[name] = err
+		}
+	}
+	printNotifyErrors(errMap)
+}
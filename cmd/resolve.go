@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"os/exec"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/humanetools/orbit/internal/config"
 	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
 )
 
 // resolvedService holds everything needed to interact with a specific service.
@@ -65,17 +72,11 @@ func resolveService(cfg *config.Config, key []byte, projectName, serviceName str
 		return nil, fmt.Errorf("decrypt token: %w", err)
 	}
 
-	p, err := platform.Get(entry.Platform, token)
+	p, err := newPlatform(entry.Platform, pc, token)
 	if err != nil {
 		return nil, err
 	}
 
-	if pc.TeamID != "" {
-		if tc, ok := p.(platform.TeamConfigurable); ok {
-			tc.SetTeamID(pc.TeamID)
-		}
-	}
-
 	if entry.Target != "" {
 		if tc, ok := p.(platform.TargetConfigurable); ok {
 			tc.SetTarget(entry.Target)
@@ -88,3 +89,166 @@ func resolveService(cfg *config.Config, key []byte, projectName, serviceName str
 		Token:    token,
 	}, nil
 }
+
+// entryPlatform creates the platform client for a topology entry, scoped to
+// its deployment target.
+func entryPlatform(cfg *config.Config, key []byte, e config.ServiceEntry) (platform.Platform, error) {
+	pc, ok := cfg.Platforms[e.Platform]
+	if !ok {
+		return nil, fmt.Errorf("platform %q not connected", e.Platform)
+	}
+	token, err := config.Decrypt(key, pc.Token)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt token: %w", err)
+	}
+	p, err := newPlatform(e.Platform, pc, token)
+	if err != nil {
+		return nil, err
+	}
+	if e.Target != "" {
+		if tc, ok := p.(platform.TargetConfigurable); ok {
+			tc.SetTarget(e.Target)
+		}
+	}
+	return p, nil
+}
+
+// newPlatform creates a platform client and applies per-platform settings
+// from config (rate limits, team scoping, API server endpoints, custom HTTP
+// endpoints).
+func newPlatform(name string, pc config.PlatformConfig, token string) (platform.Platform, error) {
+	p, err := platform.Get(name, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if pc.RateLimit != 0 {
+		burst := pc.RateBurst
+		if burst == 0 {
+			burst = int(math.Ceil(pc.RateLimit))
+		}
+		platform.SetRateLimit(name, platform.RateLimit{PerSecond: pc.RateLimit, Burst: burst})
+	}
+
+	if pc.TeamID != "" {
+		if tc, ok := p.(platform.TeamConfigurable); ok {
+			tc.SetTeamID(pc.TeamID)
+		}
+	}
+
+	if pc.Endpoint != "" {
+		if ec, ok := p.(platform.EndpointConfigurable); ok {
+			ec.SetEndpoint(pc.Endpoint)
+		}
+	}
+
+	if pc.HTTP != nil {
+		if hc, ok := p.(platform.HTTPConfigurable); ok {
+			h := pc.HTTP
+			hc.SetHTTPConfig(platform.HTTPConfig{
+				StatusURL:   h.StatusURL,
+				DeploysURL:  h.DeploysURL,
+				DeployURL:   h.DeployURL,
+				LogsURL:     h.LogsURL,
+				RedeployURL: h.RedeployURL,
+				AuthHeader:  h.AuthHeader,
+				Mappings:    platform.HTTPMappings(h.Mappings),
+			})
+		}
+	}
+
+	return p, nil
+}
+
+// requireCapability fails early when the service's platform does not
+// implement an operation, instead of surfacing "not supported" mid-command.
+func requireCapability(r *resolvedService, want platform.Capabilities) error {
+	if r.Platform.Capabilities().Has(want) {
+		return nil
+	}
+	return fmt.Errorf("%s does not support %s (service %s)\nSupported: %s",
+		r.Entry.Platform, want, r.Entry.Name, r.Platform.Capabilities())
+}
+
+// deployPrefixSearch is how many recent deployments a deploy ID prefix is
+// matched against.
+const deployPrefixSearch = 50
+
+// resolveDeployID expands a deploy ID prefix (e.g. the first few characters
+// shown by orbit deploys) to the full ID of one of the service's recent
+// deployments. IDs that match nothing recent are passed through unchanged
+// for the platform to look up.
+func resolveDeployID(ctx context.Context, r *resolvedService, id string) (string, error) {
+	if id == "" || !r.Platform.Capabilities().Has(platform.CapDeployments) {
+		return id, nil
+	}
+	deploys, err := r.Platform.ListDeployments(ctx, r.Entry.ID, deployPrefixSearch)
+	if err != nil {
+		return id, nil
+	}
+
+	var matches []platform.Deployment
+	for _, d := range deploys {
+		if d.ID == id {
+			return id, nil
+		}
+		// Also match after a type prefix such as Vercel's "dpl_".
+		_, rest, _ := strings.Cut(d.ID, "_")
+		if strings.HasPrefix(d.ID, id) || (rest != "" && strings.HasPrefix(rest, id)) {
+			matches = append(matches, d)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return id, nil
+	case 1:
+		return matches[0].ID, nil
+	}
+	var lines []string
+	for _, d := range matches {
+		lines = append(lines, fmt.Sprintf("  %s  %s  %s", d.ID, d.Status, ui.TimeAgo(d.CreatedAt)))
+	}
+	return "", fmt.Errorf("deploy ID %q is ambiguous, it matches %d deployments:\n%s\nUse more characters to pick one",
+		id, len(matches), strings.Join(lines, "\n"))
+}
+
+// resolveCommit turns a --commit value into the SHA deployments are matched
+// against. A hex SHA of 7 or more characters is used as given; anything
+// else, such as HEAD or a branch name, is resolved with git rev-parse in
+// the current directory.
+func resolveCommit(ctx context.Context, ref string) (string, error) {
+	if len(ref) >= 7 && len(ref) <= 40 && strings.Trim(strings.ToLower(ref), "0123456789abcdef") == "" {
+		return strings.ToLower(ref), nil
+	}
+	out, err := exec.CommandContext(ctx, "git", "rev-parse", "--verify", ref+"^{commit}").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("resolve commit %q: %s", ref, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("resolve commit %q: %w", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// parseRequired splits a comma-separated --require list and checks that
+// each name is one of services. It returns nil for an empty list, meaning
+// every service counts.
+func parseRequired(list string, services []string) (map[string]bool, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	required := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(services, name) {
+			return nil, fmt.Errorf("--require: %q is not one of the checked services\nChecked: %s", name, joinNames(services))
+		}
+		required[name] = true
+	}
+	return required, nil
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -20,15 +22,20 @@ var rollbackCmd = &cobra.Command{
 
   orbit rollback myshop --service api
   orbit rollback myshop --service api --to <deploy-id>
+  orbit rollback myshop --service api --to 3f2a      # any unique prefix of a recent deploy
 
-Without --to, rolls back to the most recent successful deployment before the current one.`,
+Without --to, rolls back to the most recent successful deployment before the current one.
+
+Koyeb redeploys the target's commit and settings; Vercel promotes the target
+deployment back to production. Platforms without rollback support are
+redeployed with their current configuration instead.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runRollback,
 }
 
 func init() {
 	rollbackCmd.Flags().StringVar(&rollbackService, "service", "", "Service name (required)")
-	rollbackCmd.Flags().StringVar(&rollbackTo, "to", "", "Target deployment ID to rollback to")
+	rollbackCmd.Flags().StringVar(&rollbackTo, "to", "", "Target deployment ID (or a unique prefix) to rollback to")
 	rollbackCmd.MarkFlagRequired("service")
 	rootCmd.AddCommand(rollbackCmd)
 }
@@ -55,11 +62,26 @@ func runRollback(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := requireCapability(resolved, platform.CapDeployments); err != nil {
+		return err
+	}
+	pinned := resolved.Platform.Capabilities().Has(platform.CapRollback)
+	if !pinned {
+		if err := requireCapability(resolved, platform.CapRedeploy); err != nil {
+			return err
+		}
+	}
 
 	// Find the target deployment to rollback to
-	if rollbackTo == "" {
+	var until time.Time // when the target was replaced, if known
+	if rollbackTo != "" {
+		rollbackTo, err = resolveDeployID(cmd.Context(), resolved, rollbackTo)
+		if err != nil {
+			return err
+		}
+	} else {
 		// Find the most recent successful deployment that's not the current one
-		deploys, err := resolved.Platform.ListDeployments(resolved.Entry.ID, 10)
+		deploys, err := resolved.Platform.ListDeployments(cmd.Context(), resolved.Entry.ID, 10)
 		if err != nil {
 			return fmt.Errorf("list deployments: %w", err)
 		}
@@ -69,20 +91,22 @@ func runRollback(cmd *cobra.Command, args []string) error {
 		}
 
 		// Skip the first (current) deployment, find the next healthy one
-		for _, d := range deploys[1:] {
+		for i, d := range deploys[1:] {
 			if d.Status == "healthy" || d.Status == "READY" {
 				rollbackTo = d.ID
+				until = deploys[i].CreatedAt
 				break
 			}
 		}
 		if rollbackTo == "" {
 			// Fall back to the immediately previous deployment
 			rollbackTo = deploys[1].ID
+			until = deploys[0].CreatedAt
 		}
 	}
 
 	// Show what we're rolling back to
-	target, err := resolved.Platform.GetDeployment(rollbackTo)
+	target, err := resolved.Platform.GetDeployment(cmd.Context(), rollbackTo)
 	if err != nil {
 		return fmt.Errorf("get target deployment: %w", err)
 	}
@@ -94,18 +118,37 @@ func runRollback(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 	fmt.Printf("  Created: %s\n", ui.TimeAgo(target.CreatedAt))
+	if !target.CreatedAt.IsZero() {
+		incidents := loadIncidents().ForService(projectName, resolved.Entry.Name)
+		for _, note := range incidentNotes(incidents, target.ID, target.CreatedAt, until) {
+			fmt.Printf("  %s %s\n", ui.IconWarning, ui.WarningStyle.Render(note))
+		}
+	}
 	fmt.Println()
 
-	// Trigger redeployment (the platform's Redeploy recreates from current config;
-	// full rollback to a specific deployment requires platform-specific support)
-	fmt.Printf("  Triggering redeployment... ")
-
-	deploy, err := resolved.Platform.Redeploy(resolved.Entry.ID)
+	var deploy *platform.Deployment
+	if pinned {
+		fmt.Printf("  Rolling back... ")
+		deploy, err = resolved.Platform.RollbackTo(cmd.Context(), resolved.Entry.ID, rollbackTo)
+	} else {
+		// Without platform support the best we can do is recreate the
+		// service from its current config, which does not pin the target.
+		fmt.Printf("  %s %s cannot pin a deployment; redeploying the current configuration instead.\n",
+			ui.IconWarning, resolved.Entry.Platform)
+		fmt.Printf("  Triggering redeployment... ")
+		deploy, err = resolved.Platform.Redeploy(cmd.Context(), resolved.Entry.ID)
+	}
 	if err != nil {
 		fmt.Println(ui.ErrorStyle.Render("failed"))
 		return fmt.Errorf("rollback failed: %w", err)
 	}
 
+	if deploy.ID == rollbackTo {
+		fmt.Println(ui.HealthyStyle.Render("done"))
+		fmt.Printf("  Live deploy: %s\n", deploy.ID)
+		return nil
+	}
+
 	fmt.Println(ui.HealthyStyle.Render("triggered"))
 	fmt.Printf("  New deploy: %s\n", deploy.ID)
 	fmt.Printf("\n  Track progress: orbit watch %s --service %s\n", projectName, rollbackService)
@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"sync/atomic"
+	"time"
 
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/trace"
 	"github.com/humanetools/orbit/internal/version"
 	"github.com/spf13/cobra"
 )
@@ -17,7 +24,17 @@ type ExitCodeError struct {
 
 func (e *ExitCodeError) Error() string { return e.Msg }
 
-var showVersion bool
+// Exit codes for platform errors, so scripts can tell a rejected token
+// from a transient failure worth retrying. watch uses 0-3 for its results.
+const (
+	exitUnauthorized = 4
+	exitRateLimited  = 5
+)
+
+var (
+	showVersion bool
+	verbose     bool
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "orbit",
@@ -37,14 +54,88 @@ Get a single-pane view of deployments, logs, health status, and more.`,
 
 func init() {
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "v", false, "Print version information")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", os.Getenv("ORBIT_VERBOSE") != "", "Log platform API calls to stderr, tagged with the trace ID (or set ORBIT_VERBOSE)")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		trace.SetVerbose(verbose)
+		trace.Logf("%s %s", cmd.CommandPath(), version.Version)
+		applyUserConfig()
+	}
+}
+
+// applyUserConfig applies config that affects every command: the trace
+// header, color theme, status icons, external platform adapters and the
+// policy script. A
+// config that fails to load is left for the command itself to report.
+func applyUserConfig() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	platform.SetTraceHeader(cfg.TraceHeader)
+	applyIcons(cfg.Icons)
+	applyTheme(cfg.Theme)
+	registerPlugins(cfg)
+	applyScript(cfg)
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	setupConsole()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interruptCancels(cancel)
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		var exitErr *ExitCodeError
 		if errors.As(err, &exitErr) {
+			if exitErr.Msg != "" {
+				printTraceHint()
+			}
 			os.Exit(exitErr.Code)
 		}
+		printTraceHint()
+		switch {
+		case errors.Is(err, platform.ErrUnauthorized):
+			os.Exit(exitUnauthorized)
+		case errors.Is(err, platform.ErrRateLimited):
+			os.Exit(exitRateLimited)
+		}
 		os.Exit(1)
 	}
 }
+
+// printTraceHint tells the user which trace ID to quote after a failure
+// that involved platform API calls, so the calls can be found in verbose
+// logs and by the platform's support team.
+func printTraceHint() {
+	if trace.Calls() == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "(include trace %s when reporting this)\n", trace.ID())
+}
+
+// passInterrupts is set while a child process that handles Ctrl+C itself
+// owns the terminal, such as orbit demo's shell.
+var passInterrupts atomic.Bool
+
+// interruptCancels makes Ctrl+C cancel the command context, aborting
+// in-flight platform calls and stopping watchers. A command that is still
+// running shortly after (e.g. blocked on a prompt), or a second Ctrl+C,
+// exits the process. Interrupts are ignored while passInterrupts is set.
+func interruptCancels(cancel context.CancelFunc) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		for range sig {
+			if !passInterrupts.Load() {
+				break
+			}
+		}
+		cancel()
+		select {
+		case <-sig:
+		case <-time.After(2 * time.Second):
+		}
+		os.Exit(130)
+	}()
+}
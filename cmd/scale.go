@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
 	"github.com/humanetools/orbit/internal/platform"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
@@ -69,7 +72,10 @@ func runScale(cmd *cobra.Command, args []string) error {
 
 	// No flags given → show current scale info
 	if scaleMin == 0 && scaleMax == 0 && scaleType == "" {
-		return showScaleInfo(resolved)
+		return showScaleInfo(cmd.Context(), resolved)
+	}
+	if err := requireCapability(resolved, platform.CapScale); err != nil {
+		return err
 	}
 
 	// Instance type change triggers a redeploy — confirm with user
@@ -78,7 +84,7 @@ func runScale(cmd *cobra.Command, args []string) error {
 
 		// Show current → new if we can
 		if provider, ok := resolved.Platform.(platform.ScaleInfoProvider); ok {
-			_, _, currentType, err := provider.GetCurrentScale(resolved.Entry.ID)
+			_, _, currentType, err := provider.GetCurrentScale(cmd.Context(), resolved.Entry.ID)
 			if err == nil && currentType != "" {
 				fmt.Printf("  Current: %s → New: %s\n", currentType, scaleType)
 			}
@@ -102,12 +108,13 @@ func runScale(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("  Scaling %s/%s... ", projectName, resolved.Entry.Name)
 
-	if err := resolved.Platform.Scale(resolved.Entry.ID, opts); err != nil {
+	if err := resolved.Platform.Scale(cmd.Context(), resolved.Entry.ID, opts); err != nil {
 		fmt.Println(ui.ErrorStyle.Render("failed"))
 		return fmt.Errorf("scale failed: %w", err)
 	}
 
 	fmt.Println(ui.HealthyStyle.Render("done"))
+	recordScaled(projectName, resolved.Entry.Name, opts)
 
 	// Show updated scale info
 	if scaleMin > 0 || scaleMax > 0 {
@@ -125,13 +132,13 @@ func runScale(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func showScaleInfo(resolved *resolvedService) error {
+func showScaleInfo(ctx context.Context, resolved *resolvedService) error {
 	provider, ok := resolved.Platform.(platform.ScaleInfoProvider)
 	if !ok {
 		return fmt.Errorf("scaling info not available for %s", resolved.Entry.Platform)
 	}
 
-	min, max, instanceType, err := provider.GetCurrentScale(resolved.Entry.ID)
+	min, max, instanceType, err := provider.GetCurrentScale(ctx, resolved.Entry.ID)
 	if err != nil {
 		return fmt.Errorf("get scale info: %w", err)
 	}
@@ -146,3 +153,21 @@ func showScaleInfo(resolved *resolvedService) error {
 
 	return nil
 }
+
+// recordScaled adds a scaling change to history for the activity feed.
+func recordScaled(projectName, service string, opts platform.ScaleOptions) {
+	var changes []string
+	if opts.MinInstances > 0 {
+		changes = append(changes, fmt.Sprintf("min=%d", opts.MinInstances))
+	}
+	if opts.MaxInstances > 0 {
+		changes = append(changes, fmt.Sprintf("max=%d", opts.MaxInstances))
+	}
+	if opts.InstanceType != "" {
+		changes = append(changes, "type="+opts.InstanceType)
+	}
+	recordEvents(notify.Event{
+		Type: "scaled", Severity: notify.SeverityInfo, Project: projectName, Service: service,
+		Title: "scaled", Message: strings.Join(changes, " "), Time: time.Now(),
+	})
+}
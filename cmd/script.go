@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/script"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// policy is the user's Starlark policy script, nil when none is configured
+// or it failed to load.
+var policy *script.Policy
+
+// applyScript loads the policy script named in config. A broken script is
+// reported and skipped so status and alerts keep working without it.
+func applyScript(cfg *config.Config) {
+	if cfg.Script == "" {
+		return
+	}
+	path := cfg.Script
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	p, err := script.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	policy = p
+	notify.SetPayloadFunc(p.Payload)
+}
+
+// applyScriptHealth lets the script's health hook override each service's
+// status before it is shown or alerted on.
+func applyScriptHealth(project string, results []ui.ServiceResult) {
+	if policy == nil {
+		return
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		status, err := policy.Health(project, r.Entry, r.Status)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: script health(%s): %v\n", r.Entry.Name, err)
+			continue
+		}
+		if status != "" {
+			r.Status.Status = status
+		}
+	}
+}
+
+// scriptViolations runs the script's thresholds hook for each service.
+func scriptViolations(project string, results []ui.ServiceResult) []ui.ThresholdViolation {
+	if policy == nil {
+		return nil
+	}
+	var violations []ui.ThresholdViolation
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		vs, err := policy.Violations(project, r.Entry, r.Status)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: script thresholds(%s): %v\n", r.Entry.Name, err)
+			continue
+		}
+		for _, v := range vs {
+			violations = append(violations, ui.ThresholdViolation{
+				ServiceName: r.Entry.Name,
+				Metric:      v.Metric,
+				Value:       v.Value,
+				Threshold:   v.Threshold,
+			})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchLocal   bool
+	searchLimit   int
+	searchDeploys int
+	searchFormat  string
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <term>",
+	Short: "Find projects, services, platforms, deployments and commits",
+	Long: `Fuzzy-search everything Orbit knows about and print where each match lives.
+
+Projects, services (names, platform IDs, tags) and platforms come from the
+config. Recent deployments (IDs, commits, messages) are fetched from each
+platform unless --local is set.
+
+  orbit search api
+  orbit search 3f2a9c1          # commit or deployment ID
+  orbit search checkout --local`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().BoolVar(&searchLocal, "local", false, "Search the config only (skip platform API calls)")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Maximum number of matches to show")
+	searchCmd.Flags().IntVar(&searchDeploys, "deploys", 10, "Recent deployments to search per service")
+	searchCmd.Flags().StringVar(&searchFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(searchCmd)
+}
+
+// searchMatch is one search hit and where it lives.
+type searchMatch struct {
+	Kind     string `json:"kind"` // project, service, platform, deployment
+	Project  string `json:"project,omitempty"`
+	Service  string `json:"service,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Field    string `json:"field"` // which field matched
+	Value    string `json:"value"`
+	Detail   string `json:"detail,omitempty"`
+	score    int
+}
+
+func (m searchMatch) location() string {
+	switch {
+	case m.Service != "":
+		return m.Project + "/" + m.Service
+	case m.Project != "":
+		return m.Project
+	default:
+		return m.Platform
+	}
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	term := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	var matches []searchMatch
+	add := func(m searchMatch) {
+		if score, ok := fuzzyScore(term, m.Value); ok {
+			m.score = score
+			matches = append(matches, m)
+		}
+	}
+
+	projNames := make([]string, 0, len(cfg.Projects))
+	for name := range cfg.Projects {
+		projNames = append(projNames, name)
+	}
+	sort.Strings(projNames)
+
+	for _, pName := range projNames {
+		proj := cfg.Projects[pName]
+		add(searchMatch{Kind: "project", Project: pName, Field: "name", Value: pName,
+			Detail: fmt.Sprintf("%d services", len(proj.Topology))})
+
+		for _, e := range proj.Topology {
+			base := searchMatch{Kind: "service", Project: pName, Service: e.Name, Platform: e.Platform}
+			for _, f := range []struct{ field, value string }{
+				{"name", e.Name},
+				{"id", e.ID},
+				{"remote name", e.RemoteName},
+			} {
+				if f.value == "" {
+					continue
+				}
+				m := base
+				m.Field, m.Value, m.Detail = f.field, f.value, e.Platform+" "+e.ID
+				add(m)
+			}
+			for _, tag := range e.Tags {
+				m := base
+				m.Field, m.Value, m.Detail = "tag", tag, e.Platform+" "+e.ID
+				add(m)
+			}
+		}
+	}
+
+	platNames := make([]string, 0, len(cfg.Platforms))
+	for name := range cfg.Platforms {
+		platNames = append(platNames, name)
+	}
+	sort.Strings(platNames)
+	for _, name := range platNames {
+		var used []string
+		for _, pName := range projNames {
+			for _, e := range cfg.Projects[pName].Topology {
+				if e.Platform == name {
+					used = append(used, pName+"/"+e.Name)
+				}
+			}
+		}
+		detail := "connected, no services"
+		if len(used) > 0 {
+			detail = "used by " + joinNames(used)
+		}
+		add(searchMatch{Kind: "platform", Platform: name, Field: "name", Value: name, Detail: detail})
+	}
+
+	var fetchErrs []string
+	if !searchLocal {
+		fetchErrs = searchDeployments(cmd.Context(), cfg, projNames, add)
+	}
+
+	// Best matches first; ties keep config order.
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	total := len(matches)
+	if searchLimit > 0 && len(matches) > searchLimit {
+		matches = matches[:searchLimit]
+	}
+
+	for _, e := range fetchErrs {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", ui.IconWarning, e)
+	}
+	if searchFormat == "json" {
+		if matches == nil {
+			matches = []searchMatch{}
+		}
+		return printJSON(matches)
+	}
+
+	if total == 0 {
+		fmt.Printf("  No matches for %q.\n", term)
+	}
+	for _, m := range matches {
+		fmt.Printf("  %s %s %s %s\n",
+			ui.MutedStyle.Render(ui.Pad(m.Kind, 10)),
+			ui.Pad(ui.ProjectTitleStyle.Render(m.location()), 28),
+			highlightField(m),
+			ui.MutedStyle.Render(m.Detail))
+	}
+	if total > len(matches) {
+		fmt.Printf("  %s\n", ui.MutedStyle.Render(fmt.Sprintf("… and %d more (use --limit)", total-len(matches))))
+	}
+	return nil
+}
+
+// searchDeployments fetches recent deployments for every service
+// concurrently and feeds their IDs, commits and messages to add.
+func searchDeployments(ctx context.Context, cfg *config.Config, projNames []string, add func(searchMatch)) []string {
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return []string{fmt.Sprintf("load encryption key: %s", err)}
+	}
+
+	type job struct {
+		project string
+		entry   config.ServiceEntry
+		deploys []platform.Deployment
+		err     error
+	}
+	var jobs []*job
+	for _, pName := range projNames {
+		for _, e := range cfg.Projects[pName].Topology {
+			jobs = append(jobs, &job{project: pName, entry: e})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j *job) {
+			defer wg.Done()
+			pc, ok := cfg.Platforms[j.entry.Platform]
+			if !ok {
+				j.err = fmt.Errorf("platform %q not connected", j.entry.Platform)
+				return
+			}
+			token, err := config.Decrypt(key, pc.Token)
+			if err != nil {
+				j.err = fmt.Errorf("decrypt token: %w", err)
+				return
+			}
+			p, err := newPlatform(j.entry.Platform, pc, token)
+			if err != nil {
+				j.err = err
+				return
+			}
+			j.deploys, j.err = p.ListDeployments(ctx, j.entry.ID, searchDeploys)
+		}(j)
+	}
+	wg.Wait()
+
+	var errs []string
+	for _, j := range jobs {
+		if j.err != nil {
+			errs = append(errs, fmt.Sprintf("%s/%s: %s", j.project, j.entry.Name, j.err))
+			continue
+		}
+		for _, d := range j.deploys {
+			base := searchMatch{
+				Kind:     "deployment",
+				Project:  j.project,
+				Service:  j.entry.Name,
+				Platform: j.entry.Platform,
+				Detail:   fmt.Sprintf("%s %s %s", d.ID, d.Status, ui.TimeAgo(d.CreatedAt)),
+			}
+			for _, f := range []struct{ field, value string }{
+				{"deploy", d.ID},
+				{"commit", d.Commit},
+				{"message", firstLine(d.Message)},
+			} {
+				if f.value == "" {
+					continue
+				}
+				m := base
+				m.Field, m.Value = f.field, f.value
+				add(m)
+			}
+		}
+	}
+	return errs
+}
+
+// fuzzyScore reports whether term matches s and how well: a case-insensitive
+// exact match scores highest, then prefix, then substring, then the term's
+// characters appearing in order (e.g. "chkout" matches "checkout").
+func fuzzyScore(term, s string) (int, bool) {
+	t := strings.ToLower(strings.TrimSpace(term))
+	v := strings.ToLower(s)
+	if t == "" || v == "" {
+		return 0, false
+	}
+
+	switch {
+	case v == t:
+		return 1000, true
+	case strings.HasPrefix(v, t):
+		return 800 - len(v), true
+	case strings.Contains(v, t):
+		return 600 - len(v), true
+	}
+
+	// Subsequence match; fewer gaps score higher.
+	ti, gaps, last := 0, 0, -1
+	tr := []rune(t)
+	for i, r := range []rune(v) {
+		if ti < len(tr) && r == tr[ti] {
+			if last >= 0 && i != last+1 {
+				gaps++
+			}
+			last = i
+			ti++
+		}
+	}
+	if ti < len(tr) {
+		return 0, false
+	}
+	// Require at least half the term to be contiguous-ish so short terms
+	// don't match everything.
+	if gaps > len(tr)/2 {
+		return 0, false
+	}
+	return 400 - gaps*20 - len(v), true
+}
+
+func highlightField(m searchMatch) string {
+	return fmt.Sprintf("%s=%s", m.Field, ui.HealthyStyle.Render(m.Value))
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if r := []rune(s); len(r) > 60 {
+		s = string(r[:57]) + "..."
+	}
+	return s
+}
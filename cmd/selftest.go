@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/selftest"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	selftestScenarios []string
+	selftestFormat    string
+)
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check how Orbit handles platform API failures",
+	Long: `Run the status, deployment, log and watch code paths against a built-in fake
+platform that injects failures — rate limits, server errors, timeouts,
+malformed JSON, auth errors — and report the paths that mishandled them.
+
+  orbit selftest
+  orbit selftest --scenario timeout --scenario malformed-json
+  orbit selftest --format json
+
+Meant for Orbit developers: run it after touching an adapter, the HTTP
+client or the watch loop. No platform account or network access is needed.
+Exits 1 if any path mishandled a fault.`,
+	Args: cobra.NoArgs,
+	RunE: runSelftest,
+}
+
+func init() {
+	selftestCmd.Flags().StringSliceVar(&selftestScenarios, "scenario", nil, "Only run these scenarios")
+	selftestCmd.Flags().StringVar(&selftestFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(selftestCmd)
+}
+
+func runSelftest(cmd *cobra.Command, args []string) error {
+	scenarios := selftest.Scenarios
+	if len(selftestScenarios) > 0 {
+		scenarios = nil
+		for _, name := range selftestScenarios {
+			sc, ok := selftest.Find(name)
+			if !ok {
+				var names []string
+				for _, sc := range selftest.Scenarios {
+					names = append(names, sc.Name)
+				}
+				return fmt.Errorf("unknown scenario %q\nAvailable scenarios: %s", name, strings.Join(names, ", "))
+			}
+			scenarios = append(scenarios, sc)
+		}
+	}
+
+	prog := newProgress(selftestFormat == "json")
+	prog.printf("  Running %d scenario(s) against the fake platform...\n", len(scenarios))
+	results := selftest.Run(cmd.Context(), scenarios)
+
+	failed := 0
+	for _, r := range results {
+		if !r.OK {
+			failed++
+		}
+	}
+
+	if selftestFormat == "json" {
+		if err := renderSelftestJSON(results); err != nil {
+			return err
+		}
+	} else {
+		renderSelftestTable(scenarios, results)
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d checks mishandled injected faults", failed, len(results))
+	}
+	return nil
+}
+
+func renderSelftestTable(scenarios []selftest.Scenario, results []selftest.Result) {
+	fmt.Printf("\n  %s", ui.Pad(ui.HeaderStyle.Render("Scenario"), 20))
+	for _, path := range selftest.Paths {
+		fmt.Printf(" %s", ui.Pad(ui.HeaderStyle.Render(path), 9))
+	}
+	fmt.Printf(" %s\n", ui.HeaderStyle.Render("Expectation"))
+
+	for i, sc := range scenarios {
+		fmt.Printf("  %s", ui.Pad(sc.Name, 20))
+		for _, r := range results[i*len(selftest.Paths) : (i+1)*len(selftest.Paths)] {
+			mark := ui.IconSuccess
+			if !r.OK {
+				mark = ui.IconError
+			}
+			fmt.Printf(" %s", ui.Pad(mark, 9))
+		}
+		fmt.Printf(" %s\n", ui.MutedStyle.Render(sc.Summary))
+	}
+
+	var mishandled []selftest.Result
+	for _, r := range results {
+		if !r.OK {
+			mishandled = append(mishandled, r)
+		}
+	}
+	if len(mishandled) > 0 {
+		fmt.Printf("\n  %s\n", ui.ErrorStyle.Render("Mishandled"))
+		for _, r := range mishandled {
+			fmt.Printf("  %s %s/%s: %s\n", ui.IconError, r.Scenario, r.Path, r.Detail)
+		}
+	} else {
+		fmt.Printf("\n  %s All %d checks handled their faults\n", ui.IconSuccess, len(results))
+	}
+	fmt.Println()
+}
+
+type selftestJSON struct {
+	Scenario  string `json:"scenario"`
+	Path      string `json:"path"`
+	OK        bool   `json:"ok"`
+	Detail    string `json:"detail,omitempty"`
+	ElapsedMs int64  `json:"elapsed_ms"`
+}
+
+func renderSelftestJSON(results []selftest.Result) error {
+	out := make([]selftestJSON, len(results))
+	for i, r := range results {
+		out[i] = selftestJSON{
+			Scenario:  r.Scenario,
+			Path:      r.Path,
+			OK:        r.OK,
+			Detail:    r.Detail,
+			ElapsedMs: r.Elapsed.Milliseconds(),
+		}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
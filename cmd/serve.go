@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveHost    string
+	servePort    int
+	serveRefresh time.Duration
+	serveDays    int
+	serveDeploys int
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a read-only JSON API and status page over HTTP",
+	Long: `Serve projects, statuses and deployments over HTTP, so a browser or
+other tooling can use one Orbit instance:
+
+  GET /                                  Status page of the default project
+  GET /projects/<project>                Status page of a project
+  GET /api/projects                      Projects and their services
+  GET /api/status                        Status of every project
+  GET /api/projects/<project>/status     Status of a project's services
+  GET /api/projects/<project>/deploys    Recent deployments (?service=api&limit=10)
+
+JSON matches "orbit status --format json" and "orbit deploys --format json",
+and the page matches "orbit report". Statuses come from the same response
+cache as the CLI, so frequent requests don't add platform API calls.
+
+The server listens on localhost only; pass --host 0.0.0.0 to share it. It
+never changes anything and has no authentication of its own.
+
+  orbit serve
+  orbit serve --port 9000 --host 0.0.0.0`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "Address to listen on")
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	serveCmd.Flags().DurationVar(&serveRefresh, "refresh", 30*time.Second, "How often the status page reloads (0 to never)")
+	serveCmd.Flags().IntVar(&serveDays, "days", 7, "Days of incident history the page's uptime covers")
+	serveCmd.Flags().IntVar(&serveDeploys, "deploys", 5, "Recent deployments per service on the page")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// serveDeploysLimit caps ?limit on the deploys endpoint.
+const serveDeploysLimit = 100
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if serveDays <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	if _, err := config.Load(); err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+
+	addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	cmd.SilenceUsage = true
+
+	srv := &http.Server{Handler: newServeMux(key), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-cmd.Context().Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+
+	fmt.Printf("\n  %s Serving on http://%s\n", ui.IconSuccess, ln.Addr())
+	fmt.Printf("  Press Ctrl+C to stop.\n\n")
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// newServeMux routes orbit serve's endpoints. The config is read on every
+// request, so projects added while the server runs show up.
+func newServeMux(key []byte) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		cfg, ok := serveConfig(w)
+		if !ok {
+			return
+		}
+		name := cfg.DefaultProject
+		if name == "" {
+			if names := sortedProjectNames(cfg); len(names) > 0 {
+				name = names[0]
+			}
+		}
+		if name == "" {
+			http.Error(w, "no projects configured", http.StatusNotFound)
+			return
+		}
+		http.Redirect(w, r, "/projects/"+url.PathEscape(name), http.StatusFound)
+	})
+
+	mux.HandleFunc("GET /projects/{project}", func(w http.ResponseWriter, r *http.Request) {
+		cfg, ok := serveConfig(w)
+		if !ok {
+			return
+		}
+		name := r.PathValue("project")
+		proj, ok := cfg.Projects[name]
+		if !ok {
+			http.Error(w, fmt.Sprintf("project %q not found", name), http.StatusNotFound)
+			return
+		}
+		report := buildHTMLReport(r.Context(), cfg, key, name, &proj, serveDays, serveDeploys)
+		report.Refresh = int(serveRefresh.Seconds())
+		for _, n := range sortedProjectNames(cfg) {
+			report.Nav = append(report.Nav, ui.HTMLLink{Label: n, URL: "/projects/" + url.PathEscape(n), Current: n == name})
+		}
+		var results []ui.ServiceResult
+		for _, s := range report.Services {
+			results = append(results, s.Result)
+		}
+		recordStatuses(name, results)
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		ui.RenderHTMLReport(w, report)
+	})
+
+	mux.HandleFunc("GET /api/projects", func(w http.ResponseWriter, r *http.Request) {
+		cfg, ok := serveConfig(w)
+		if !ok {
+			return
+		}
+		type jsonService struct {
+			Name     string   `json:"name"`
+			Platform string   `json:"platform"`
+			ID       string   `json:"id"`
+			URL      string   `json:"url,omitempty"`
+			Tags     []string `json:"tags,omitempty"`
+			Owner    string   `json:"owner,omitempty"`
+		}
+		type jsonProject struct {
+			Name     string              `json:"name"`
+			Default  bool                `json:"default,omitempty"`
+			Services []jsonService       `json:"services"`
+			Views    map[string][]string `json:"views,omitempty"`
+		}
+		out := []jsonProject{}
+		for _, name := range sortedProjectNames(cfg) {
+			proj := cfg.Projects[name]
+			jp := jsonProject{Name: name, Default: name == cfg.DefaultProject, Services: []jsonService{}, Views: proj.Views}
+			for _, e := range proj.Topology {
+				jp.Services = append(jp.Services, jsonService{e.Name, e.Platform, e.ID, e.URL, e.Tags, e.Owner})
+			}
+			out = append(out, jp)
+		}
+		writeServeJSON(w, http.StatusOK, out)
+	})
+
+	mux.HandleFunc("GET /api/status", func(w http.ResponseWriter, r *http.Request) {
+		cfg, ok := serveConfig(w)
+		if !ok {
+			return
+		}
+		out := make(map[string][]jsonServiceStatus)
+		for _, name := range sortedProjectNames(cfg) {
+			out[name] = serveStatuses(r.Context(), cfg, key, name)
+		}
+		writeServeJSON(w, http.StatusOK, out)
+	})
+
+	mux.HandleFunc("GET /api/projects/{project}/status", func(w http.ResponseWriter, r *http.Request) {
+		cfg, ok := serveConfig(w)
+		if !ok {
+			return
+		}
+		name := r.PathValue("project")
+		if _, ok := cfg.Projects[name]; !ok {
+			writeServeError(w, http.StatusNotFound, fmt.Errorf("project %q not found", name))
+			return
+		}
+		writeServeJSON(w, http.StatusOK, serveStatuses(r.Context(), cfg, key, name))
+	})
+
+	mux.HandleFunc("GET /api/projects/{project}/deploys", func(w http.ResponseWriter, r *http.Request) {
+		cfg, ok := serveConfig(w)
+		if !ok {
+			return
+		}
+		name := r.PathValue("project")
+		proj, ok := cfg.Projects[name]
+		if !ok {
+			writeServeError(w, http.StatusNotFound, fmt.Errorf("project %q not found", name))
+			return
+		}
+		limit := 10
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > serveDeploysLimit {
+				writeServeError(w, http.StatusBadRequest, fmt.Errorf("limit must be between 1 and %d", serveDeploysLimit))
+				return
+			}
+			limit = n
+		}
+		entries := proj.Topology
+		if svc := r.URL.Query().Get("service"); svc != "" {
+			entries = nil
+			for _, e := range proj.Topology {
+				if e.Name == svc {
+					entries = append(entries, e)
+				}
+			}
+			if len(entries) == 0 {
+				writeServeError(w, http.StatusNotFound, fmt.Errorf("service %q not found in project %q", svc, name))
+				return
+			}
+		}
+		results := fetchDeploys(r.Context(), cfg, key, entries, limit)
+		writeServeJSON(w, http.StatusOK, toJSONDeploys(name, results, loadIncidents()))
+	})
+
+	return mux
+}
+
+// serveConfig loads the config for a request, answering 500 if it can't.
+func serveConfig(w http.ResponseWriter) (*config.Config, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		writeServeError(w, http.StatusInternalServerError, fmt.Errorf("load config: %w", err))
+		return nil, false
+	}
+	return cfg, true
+}
+
+// serveStatuses fetches a project's statuses as orbit status would.
+func serveStatuses(ctx context.Context, cfg *config.Config, key []byte, name string) []jsonServiceStatus {
+	results := fetchStatuses(ctx, cfg.Projects[name].Topology, cfg, key)
+	applyScriptHealth(name, results)
+	recordStatuses(name, results)
+	annotateIncidents(name, results)
+	return toJSONServices(results)
+}
+
+func writeServeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeServeError(w http.ResponseWriter, code int, err error) {
+	writeServeJSON(w, code, map[string]string{"error": err.Error()})
+}
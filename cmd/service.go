@@ -1,19 +1,31 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/humanetools/orbit/internal/config"
 	"github.com/humanetools/orbit/internal/platform"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
 	serviceAddName     string
 	serviceAddPlatform string
 	serviceAddID       string
+	serviceAddTags     []string
+	serviceAddOptional bool
+	serviceAddRefresh  bool
+	serviceAddRepo     string
 	serviceRemoveName  string
 )
 
@@ -22,7 +34,9 @@ var serviceCmd = &cobra.Command{
 	Short: "Manage services within a project",
 	Long: `Add or remove services from a project.
 
-  orbit service add <project> --name X --platform Y --id Z
+  orbit service add <project> --name X --platform Y --id Z [--tag T] [--optional] [--repo path]
+  orbit service add <project> --platform Y        Pick from discovered services
+  orbit service import <project> --file services.yaml
   orbit service remove <project> --name X`,
 }
 
@@ -44,9 +58,11 @@ func init() {
 	serviceAddCmd.Flags().StringVar(&serviceAddName, "name", "", "Service name")
 	serviceAddCmd.Flags().StringVar(&serviceAddPlatform, "platform", "", "Platform (vercel, koyeb, supabase, render)")
 	serviceAddCmd.Flags().StringVar(&serviceAddID, "id", "", "Service ID on the platform")
-	serviceAddCmd.MarkFlagRequired("name")
+	serviceAddCmd.Flags().StringSliceVar(&serviceAddTags, "tag", nil, "Tag for notification routing (repeatable)")
+	serviceAddCmd.Flags().BoolVar(&serviceAddOptional, "optional", false, "Don't fail multi-service watches when this service has no new deployment")
+	serviceAddCmd.Flags().BoolVar(&serviceAddRefresh, "refresh", false, "Re-discover services for the picker instead of using cached results")
+	serviceAddCmd.Flags().StringVar(&serviceAddRepo, "repo", "", "Local git checkout of the service's code, for commit ranges in orbit diff")
 	serviceAddCmd.MarkFlagRequired("platform")
-	serviceAddCmd.MarkFlagRequired("id")
 
 	serviceRemoveCmd.Flags().StringVar(&serviceRemoveName, "name", "", "Service name to remove")
 	serviceRemoveCmd.MarkFlagRequired("name")
@@ -79,6 +95,30 @@ func runServiceAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("project %q not found\nAvailable projects: %s", projectName, projectNames(cfg))
 	}
 
+	// Without --id, pick from the platform's discovered services
+	var serviceURL string
+	if serviceAddID == "" {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("--id is required when not running interactively")
+		}
+		svc, err := pickDiscoveredService(cmd.Context(), cfg, platName, proj)
+		if err != nil {
+			return err
+		}
+		serviceAddID = svc.ID
+		serviceURL = svc.URL
+		if serviceAddName == "" {
+			taken := make(map[string]bool)
+			for _, e := range proj.Topology {
+				taken[e.Name] = true
+			}
+			serviceAddName = config.UniqueServiceName(svc.Name, platName, taken)
+		}
+	}
+	if serviceAddName == "" {
+		return fmt.Errorf("--name is required")
+	}
+
 	// Check for duplicate service name
 	for _, svc := range proj.Topology {
 		if svc.Name == serviceAddName {
@@ -86,11 +126,25 @@ func runServiceAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	proj.Topology = append(proj.Topology, config.ServiceEntry{
+	if serviceAddRepo != "" {
+		abs, err := filepath.Abs(serviceAddRepo)
+		if err != nil {
+			return fmt.Errorf("--repo: %w", err)
+		}
+		serviceAddRepo = abs
+	}
+
+	entry := config.ServiceEntry{
 		Name:     serviceAddName,
 		Platform: platName,
 		ID:       serviceAddID,
-	})
+		Tags:     serviceAddTags,
+		Optional: serviceAddOptional,
+		URL:      serviceURL,
+		Repo:     serviceAddRepo,
+	}
+	resolveStableID(cmd.Context(), cfg, &entry)
+	proj.Topology = append(proj.Topology, entry)
 
 	cfg.Projects[projectName] = proj
 
@@ -102,9 +156,62 @@ func runServiceAdd(cmd *cobra.Command, args []string) error {
 		ui.IconSuccess,
 		ui.HealthyStyle.Render(serviceAddName),
 		ui.ProjectTitleStyle.Render(projectName))
+	printHints(cfg, "orbit status "+projectName, "orbit watch "+projectName+" --service "+serviceAddName)
 	return nil
 }
 
+// pickDiscoveredService lists the platform's services that aren't in proj
+// yet and asks which one to add.
+func pickDiscoveredService(ctx context.Context, cfg *config.Config, platName string, proj config.ProjectConfig) (platform.DiscoveredService, error) {
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return platform.DiscoveredService{}, fmt.Errorf("load encryption key: %w", err)
+	}
+
+	fmt.Printf("  Discovering %s services... ", platName)
+	found, cached, err := discoverServices(ctx, cfg, key, platName, serviceAddRefresh)
+	if errors.Is(err, errDiscoveryUnsupported) {
+		fmt.Println()
+		return platform.DiscoveredService{}, fmt.Errorf("%s can't list services; pass --id", platName)
+	}
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("failed"))
+		return platform.DiscoveredService{}, fmt.Errorf("discover services: %w", err)
+	}
+
+	inProject := make(map[string]bool)
+	for _, e := range proj.Topology {
+		if e.Platform == platName {
+			inProject[e.ID] = true
+		}
+	}
+	var choices []platform.DiscoveredService
+	for _, svc := range found {
+		if !inProject[svc.ID] {
+			choices = append(choices, svc)
+		}
+	}
+	note := ""
+	if cached {
+		note = ui.MutedStyle.Render(" (cached, --refresh to re-discover)")
+	}
+	fmt.Printf("%s%s\n\n", ui.HealthyStyle.Render(fmt.Sprintf("%d found", len(found))), note)
+	if len(choices) == 0 {
+		return platform.DiscoveredService{}, fmt.Errorf("every %s service is already in the project", platName)
+	}
+
+	for i, svc := range choices {
+		fmt.Printf("  %3d  %s %s\n", i+1, svc.Name, ui.MutedStyle.Render(svc.ID))
+	}
+	fmt.Printf("\n  Service to add [1-%d]: ", len(choices))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 || n > len(choices) {
+		return platform.DiscoveredService{}, fmt.Errorf("no service selected")
+	}
+	return choices[n-1], nil
+}
+
 func runServiceRemove(cmd *cobra.Command, args []string) error {
 	projectName := args[0]
 
@@ -138,6 +245,8 @@ func runServiceRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	proj.Topology = filtered
+	proj.RemoveFromViews(serviceRemoveName)
+	proj.Alerts = slices.DeleteFunc(proj.Alerts, func(r config.AlertRule) bool { return r.Service == serviceRemoveName })
 	cfg.Projects[projectName] = proj
 
 	if err := config.Save(cfg); err != nil {
@@ -150,3 +259,37 @@ func runServiceRemove(cmd *cobra.Command, args []string) error {
 		ui.ProjectTitleStyle.Render(projectName))
 	return nil
 }
+
+// resolveStableID replaces a name given as --id with the platform's stable ID
+// (so later renames don't break the entry) and records the current name.
+// Lookup failures leave the entry as given.
+func resolveStableID(ctx context.Context, cfg *config.Config, entry *config.ServiceEntry) {
+	pc := cfg.Platforms[entry.Platform]
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return
+	}
+	token, err := config.Decrypt(key, pc.Token)
+	if err != nil {
+		return
+	}
+	p, err := newPlatform(entry.Platform, pc, token)
+	if err != nil {
+		return
+	}
+	si, ok := p.(platform.ServiceIdentifier)
+	if !ok {
+		return
+	}
+
+	svc, err := si.LookupService(ctx, entry.ID)
+	if err != nil {
+		fmt.Printf("  %s Could not look up %s on %s: %s\n", ui.IconWarning, entry.ID, entry.Platform, err)
+		return
+	}
+	if svc.ID != entry.ID {
+		fmt.Printf("  %s Using stable ID %s for %s\n", ui.IconHealthy, svc.ID, entry.ID)
+		entry.ID = svc.ID
+	}
+	entry.RemoteName = svc.Name
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/smoke"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// smokeRun holds the smoke test results of one service.
+type smokeRun struct {
+	Entry   config.ServiceEntry
+	Results []smoke.Result
+	Err     error
+}
+
+func (r smokeRun) passed() bool {
+	return r.Err == nil && smoke.Passed(r.Results)
+}
+
+// runSmokeVerify runs the smoke checks of a project's services, for orbit
+// verify <project>.
+func runSmokeVerify(cmd *cobra.Command, projectName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+
+	var entries []config.ServiceEntry
+	for _, e := range proj.Topology {
+		if verifyService != "" && e.Name != verifyService {
+			continue
+		}
+		if len(e.Smoke) > 0 {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		if verifyService != "" {
+			if _, err := findEntry(proj, verifyService); err != nil {
+				return fmt.Errorf("service %q not found in project %q", verifyService, projectName)
+			}
+			return fmt.Errorf("service %q has no smoke checks\nAdd them under smoke: in its topology entry (see orbit verify --help)", verifyService)
+		}
+		return fmt.Errorf("no service in project %q has smoke checks\nAdd them under smoke: in a topology entry (see orbit verify --help)", projectName)
+	}
+	cmd.SilenceUsage = true
+
+	runs := runSmokeChecks(cmd.Context(), entries)
+	if verifyFormat == "json" {
+		out := make([]jsonSmokeService, len(runs))
+		for i, r := range runs {
+			out[i] = smokeToJSON(r)
+		}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n  %s %s\n", ui.ProjectTitleStyle.Render(projectName), ui.MutedStyle.Render("smoke tests"))
+		for _, r := range runs {
+			fmt.Printf("\n  %s\n", r.Entry.Name)
+			printSmokeRun(os.Stdout, r)
+		}
+		fmt.Println()
+	}
+
+	for _, r := range runs {
+		if !r.passed() {
+			cmd.SilenceErrors = true
+			return &ExitCodeError{Code: exitFailed, Msg: ""}
+		}
+	}
+	return nil
+}
+
+// findEntry returns the topology entry of a service.
+func findEntry(proj *config.ProjectConfig, name string) (config.ServiceEntry, error) {
+	for _, e := range proj.Topology {
+		if e.Name == name {
+			return e, nil
+		}
+	}
+	return config.ServiceEntry{}, fmt.Errorf("service %q not found", name)
+}
+
+// runSmokeChecks runs each service's checks, services concurrently.
+func runSmokeChecks(ctx context.Context, entries []config.ServiceEntry) []smokeRun {
+	runs := make([]smokeRun, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		runs[i].Entry = e
+		wg.Add(1)
+		go func(r *smokeRun) {
+			defer wg.Done()
+			r.Results, r.Err = smoke.Run(ctx, r.Entry)
+		}(&runs[i])
+	}
+	wg.Wait()
+	return runs
+}
+
+// printSmokeRun prints one line per check, with what failed under it.
+func printSmokeRun(w io.Writer, r smokeRun) {
+	if r.Err != nil {
+		fmt.Fprintf(w, "    %s %s\n", ui.ErrorStyle.Render(ui.IconError), ui.ErrorStyle.Render(r.Err.Error()))
+		return
+	}
+	for _, res := range r.Results {
+		code := ui.Dash
+		if res.Code != 0 {
+			code = fmt.Sprint(res.Code)
+		}
+		icon := ui.HealthyStyle.Render(ui.IconHealthy)
+		if !res.OK {
+			icon = ui.ErrorStyle.Render(ui.IconError)
+		}
+		fmt.Fprintf(w, "    %s %s %s %s\n", icon, ui.Pad(ui.Truncate(res.Check, 32), 32), ui.Pad(code, 4),
+			ui.MutedStyle.Render(fmt.Sprintf("%dms", res.LatencyMs)))
+		for _, f := range res.Failures {
+			fmt.Fprintf(w, "      %s\n", ui.ErrorStyle.Render(f))
+		}
+	}
+}
+
+// smokeFailure summarizes why a service's smoke tests failed, e.g.
+// "GET /health: HTTP 503, want 2xx".
+func smokeFailure(r smokeRun) string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	var failed []string
+	for _, res := range r.Results {
+		if !res.OK {
+			failed = append(failed, res.Check+": "+strings.Join(res.Failures, ", "))
+		}
+	}
+	return strings.Join(failed, "; ")
+}
+
+type jsonSmokeCheck struct {
+	Check     string   `json:"check"`
+	URL       string   `json:"url"`
+	OK        bool     `json:"ok"`
+	Code      int      `json:"code,omitempty"`
+	LatencyMs int      `json:"latency_ms"`
+	Failures  []string `json:"failures,omitempty"`
+}
+
+type jsonSmokeService struct {
+	Service string           `json:"service"`
+	Passed  bool             `json:"passed"`
+	Checks  []jsonSmokeCheck `json:"checks"`
+	Error   string           `json:"error,omitempty"`
+}
+
+func smokeChecksToJSON(results []smoke.Result) []jsonSmokeCheck {
+	out := make([]jsonSmokeCheck, len(results))
+	for i, res := range results {
+		out[i] = jsonSmokeCheck{res.Check, res.URL, res.OK, res.Code, res.LatencyMs, res.Failures}
+	}
+	return out
+}
+
+func smokeToJSON(r smokeRun) jsonSmokeService {
+	j := jsonSmokeService{Service: r.Entry.Name, Passed: r.passed(), Checks: smokeChecksToJSON(r.Results)}
+	if r.Err != nil {
+		j.Error = r.Err.Error()
+	}
+	return j
+}
+
+// verifyDeploy runs the smoke tests of a service whose deploy went healthy,
+// for orbit watch --verify, and marks the result failed if any fails.
+func verifyDeploy(ctx context.Context, resolved *resolvedService, r *watchResult) {
+	if r.ExitCode != exitSuccess || len(resolved.Entry.Smoke) == 0 {
+		return
+	}
+	run := smokeRun{Entry: resolved.Entry}
+	run.Results, run.Err = smoke.Run(ctx, resolved.Entry)
+	r.Smoke = run.Results
+
+	w := io.Writer(os.Stdout)
+	if machineFormat() {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, "  Smoke tests (%s):\n", r.ServiceName)
+	printSmokeRun(w, run)
+	if !run.passed() {
+		r.ExitCode = exitFailed
+		r.Phase = "verify"
+		r.Error = "smoke tests failed: " + smokeFailure(run)
+	}
+}
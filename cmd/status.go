@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
+	"github.com/humanetools/orbit/internal/cache"
 	"github.com/humanetools/orbit/internal/config"
 	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/probe"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +20,9 @@ import (
 var (
 	statusService string
 	statusFormat  string
+	statusOut     string
+	statusRequire string
+	statusView    string
 )
 
 var statusCmd = &cobra.Command{
@@ -28,7 +36,13 @@ var statusCmd = &cobra.Command{
 
 Flags:
   --format json    Output as JSON
-  --service NAME   Show detail for a specific service`,
+  --out FILE       Also write the JSON result to FILE
+  --service NAME   Show detail for a specific service
+  --require LIST   Exit 1 unless these services (comma-separated) are
+                   healthy or sleeping; other services are shown for
+                   information only (L1)
+  --view NAME      Only show the services of a named view, in its order
+                   (L1; see orbit topology --view)`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStatus,
 }
@@ -36,6 +50,9 @@ Flags:
 func init() {
 	statusCmd.Flags().StringVar(&statusService, "service", "", "Show detail for a specific service")
 	statusCmd.Flags().StringVar(&statusFormat, "format", "", "Output format (json)")
+	statusCmd.Flags().StringVar(&statusOut, "out", "", "Also write the JSON result to this file")
+	statusCmd.Flags().StringVar(&statusRequire, "require", "", "Exit 1 unless these services (comma-separated) are up")
+	statusCmd.Flags().StringVar(&statusView, "view", "", "Only show the services of this view")
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -50,19 +67,32 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("load encryption key: %w", err)
 	}
 
+	if statusRequire != "" && (len(args) == 0 || statusService != "") {
+		return fmt.Errorf("--require needs a project and can't be combined with --service")
+	}
+	if statusView != "" && (len(args) == 0 || statusService != "") {
+		return fmt.Errorf("--view needs a project and can't be combined with --service")
+	}
+
 	switch {
 	case len(args) == 0:
-		return runStatusAllProjects(cfg, key)
+		return runStatusAllProjects(cmd.Context(), cfg, key)
 	case statusService != "":
-		return runStatusService(cfg, key, args[0], statusService)
+		return runStatusService(cmd.Context(), cfg, key, args[0], statusService)
 	default:
-		return runStatusProject(cfg, key, args[0])
+		err := runStatusProject(cmd.Context(), cfg, key, args[0])
+		var exitErr *ExitCodeError
+		if errors.As(err, &exitErr) {
+			// Required services are down; not a usage error.
+			cmd.SilenceUsage = true
+		}
+		return err
 	}
 }
 
 // --- L0: All Projects Overview ---
 
-func runStatusAllProjects(cfg *config.Config, key []byte) error {
+func runStatusAllProjects(ctx context.Context, cfg *config.Config, key []byte) error {
 	if len(cfg.Projects) == 0 {
 		fmt.Println("No projects configured.")
 		fmt.Println("Add projects to ~/.orbit/config.yaml to get started.")
@@ -77,47 +107,108 @@ func runStatusAllProjects(cfg *config.Config, key []byte) error {
 	sort.Strings(names)
 
 	if statusFormat == "json" {
-		return renderAllProjectsJSON(cfg, key, names)
+		return renderAllProjectsJSON(ctx, cfg, key, names)
 	}
 
+	out := make(map[string][]jsonServiceStatus)
 	for i, name := range names {
 		proj := cfg.Projects[name]
-		results := fetchStatuses(proj.Topology, cfg, key)
+		results := fetchStatuses(ctx, proj.Topology, cfg, key)
+		applyScriptHealth(name, results)
+		recordStatuses(name, results)
+		annotateIncidents(name, results)
+		syncRemoteNames(cfg, name, results)
+		out[name] = toJSONServices(results)
 		fmt.Print(ui.RenderOverviewTable(name, results))
+		if inc := ui.RenderIncidents(results); inc != "" {
+			fmt.Print("\n" + inc)
+		}
 		if i < len(names)-1 {
 			fmt.Println()
 		}
 	}
 	fmt.Println()
 
-	return nil
+	return writeStatusOut(out)
 }
 
 // --- L1: Single Project Detail ---
 
-func runStatusProject(cfg *config.Config, key []byte, name string) error {
+func runStatusProject(ctx context.Context, cfg *config.Config, key []byte, name string) error {
 	proj, ok := cfg.Projects[name]
 	if !ok {
 		return fmt.Errorf("project %q not found\nAvailable projects: %s", name, projectNames(cfg))
 	}
+	topology := proj.Topology
+	if statusView != "" {
+		var err error
+		if topology, err = proj.ViewEntries(statusView); err != nil {
+			return err
+		}
+	}
+	var svcNames []string
+	for _, e := range topology {
+		svcNames = append(svcNames, e.Name)
+	}
+	required, err := parseRequired(statusRequire, svcNames)
+	if err != nil {
+		return err
+	}
 
-	results := fetchStatuses(proj.Topology, cfg, key)
+	results := fetchStatuses(ctx, topology, cfg, key)
+	applyScriptHealth(name, results)
+	recordStatuses(name, results)
+	annotateIncidents(name, results)
+	syncRemoteNames(cfg, name, results)
+	if err := writeStatusOut(map[string][]jsonServiceStatus{name: toJSONServices(results)}); err != nil {
+		return err
+	}
 
 	if statusFormat == "json" {
-		return renderProjectJSON(name, results)
+		if err := renderProjectJSON(name, results); err != nil {
+			return err
+		}
+		return requiredServicesError(results, required)
 	}
 
 	output, violations := ui.RenderDetailTable(name, results, cfg.Thresholds)
+	violations = append(violations, scriptViolations(name, results)...)
 	fmt.Println(output)
+	if inc := ui.RenderIncidents(results); inc != "" {
+		fmt.Println(inc)
+	}
 	if warn := ui.RenderViolations(violations); warn != "" {
 		fmt.Println(warn)
 	}
-	return nil
+	notifyStatus(cfg, name, results, violations)
+	return requiredServicesError(results, required)
+}
+
+// requiredServicesError returns an exit code 1 error naming the required
+// services that are neither healthy nor sleeping, or nil when all are up
+// or nothing is required.
+func requiredServicesError(results []ui.ServiceResult, required map[string]bool) error {
+	var down []string
+	for _, r := range results {
+		if !required[r.Entry.Name] {
+			continue
+		}
+		switch {
+		case r.Err != nil:
+			down = append(down, r.Entry.Name+" (error)")
+		case r.Status.Status != "healthy" && r.Status.Status != "sleeping":
+			down = append(down, fmt.Sprintf("%s (%s)", r.Entry.Name, r.Status.Status))
+		}
+	}
+	if len(down) == 0 {
+		return nil
+	}
+	return &ExitCodeError{Code: 1, Msg: "required services not up: " + joinNames(down)}
 }
 
 // --- L2: Single Service Detail ---
 
-func runStatusService(cfg *config.Config, key []byte, projectName, serviceName string) error {
+func runStatusService(ctx context.Context, cfg *config.Config, key []byte, projectName, serviceName string) error {
 	proj, ok := cfg.Projects[projectName]
 	if !ok {
 		return fmt.Errorf("project %q not found\nAvailable projects: %s", projectName, projectNames(cfg))
@@ -140,26 +231,57 @@ func runStatusService(cfg *config.Config, key []byte, projectName, serviceName s
 			serviceName, projectName, joinNames(svcNames))
 	}
 
-	status, err := fetchSingleStatus(*entry, cfg, key)
+	status, caps, cached, err := fetchSingleStatus(ctx, *entry, cfg, key)
 	if err != nil {
 		return fmt.Errorf("fetch status for %s: %w", serviceName, err)
 	}
+	results := []ui.ServiceResult{{Entry: *entry, Status: status, Cached: cached}}
+	applyScriptHealth(projectName, results)
+	recordStatuses(projectName, results)
+	annotateIncidents(projectName, results)
+	syncRemoteNames(cfg, projectName, results)
+	if err := writeStatusOut(toJSONService(results[0])); err != nil {
+		return err
+	}
 
 	if statusFormat == "json" {
 		return renderServiceJSON(*entry, status)
 	}
 
-	output, violations := ui.RenderServiceDetail(projectName, *entry, status, cfg.Thresholds)
+	output, violations := ui.RenderServiceDetail(projectName, *entry, status, caps, listInstances(ctx, cfg, key, *entry), cfg.Thresholds)
+	violations = append(violations, scriptViolations(projectName, results)...)
 	fmt.Println(output)
+	if inc := ui.RenderIncidents(results); inc != "" {
+		fmt.Println(inc)
+	}
 	if warn := ui.RenderViolations(violations); warn != "" {
 		fmt.Println(warn)
 	}
+	notifyStatus(cfg, projectName, results, violations)
 	return nil
 }
 
+// listInstances returns the service's instances for the detail card, or
+// nil when the platform cannot list them or the request fails.
+func listInstances(ctx context.Context, cfg *config.Config, key []byte, e config.ServiceEntry) []platform.Instance {
+	p, err := entryPlatform(cfg, key, e)
+	if err != nil {
+		return nil
+	}
+	il, ok := p.(platform.InstanceLister)
+	if !ok {
+		return nil
+	}
+	instances, err := il.ListInstances(ctx, e.ID)
+	if err != nil {
+		return nil
+	}
+	return instances
+}
+
 // --- Parallel Fetch ---
 
-func fetchStatuses(entries []config.ServiceEntry, cfg *config.Config, key []byte) []ui.ServiceResult {
+func fetchStatuses(ctx context.Context, entries []config.ServiceEntry, cfg *config.Config, key []byte) []ui.ServiceResult {
 	results := make([]ui.ServiceResult, len(entries))
 	var wg sync.WaitGroup
 
@@ -168,8 +290,10 @@ func fetchStatuses(entries []config.ServiceEntry, cfg *config.Config, key []byte
 		wg.Add(1)
 		go func(idx int, e config.ServiceEntry) {
 			defer wg.Done()
-			status, err := fetchSingleStatus(e, cfg, key)
+			status, caps, cached, err := fetchSingleStatus(ctx, e, cfg, key)
 			results[idx].Status = status
+			results[idx].Caps = caps
+			results[idx].Cached = cached
 			results[idx].Err = err
 		}(i, entry)
 	}
@@ -178,26 +302,23 @@ func fetchStatuses(entries []config.ServiceEntry, cfg *config.Config, key []byte
 	return results
 }
 
-func fetchSingleStatus(entry config.ServiceEntry, cfg *config.Config, key []byte) (*platform.ServiceStatus, error) {
+// fetchSingleStatus also returns the platform's capabilities so callers can
+// hide metrics the platform never reports, and whether the status is a
+// cached response that was already seen by an earlier command.
+func fetchSingleStatus(ctx context.Context, entry config.ServiceEntry, cfg *config.Config, key []byte) (status *platform.ServiceStatus, caps platform.Capabilities, cached bool, err error) {
 	pc, ok := cfg.Platforms[entry.Platform]
 	if !ok {
-		return nil, fmt.Errorf("platform %q not connected", entry.Platform)
+		return nil, 0, false, fmt.Errorf("platform %q not connected", entry.Platform)
 	}
 
 	token, err := config.Decrypt(key, pc.Token)
 	if err != nil {
-		return nil, fmt.Errorf("decrypt token: %w", err)
+		return nil, 0, false, fmt.Errorf("decrypt token: %w", err)
 	}
 
-	p, err := platform.Get(entry.Platform, token)
+	p, err := newPlatform(entry.Platform, pc, token)
 	if err != nil {
-		return nil, err
-	}
-
-	if pc.TeamID != "" {
-		if tc, ok := p.(platform.TeamConfigurable); ok {
-			tc.SetTeamID(pc.TeamID)
-		}
+		return nil, 0, false, err
 	}
 
 	if entry.Target != "" {
@@ -206,23 +327,60 @@ func fetchSingleStatus(entry config.ServiceEntry, cfg *config.Config, key []byte
 		}
 	}
 
-	return p.GetServiceStatus(entry.ID)
+	spec, hasProbe, err := probe.FromEntry(entry)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	rc := responseCache()
+	ck := serviceCacheKey("status", entry, pc, token, 0)
+	var stored platform.ServiceStatus
+	if rc.Get(ck, cache.StatusTTL, &stored) {
+		status, cached = &stored, true
+	} else {
+		status, err = p.GetServiceStatus(ctx, entry.ID)
+		if err != nil {
+			return nil, p.Capabilities(), false, err
+		}
+		platform.FillMetrics(ctx, p, entry.ID, status)
+		rc.Put(ck, status)
+	}
+
+	// The probe always runs live, so a cached platform status never hides
+	// an outage. Sleeping services are not probed: a request would wake them.
+	// A probed status is fresh even when the platform's part is cached.
+	if hasProbe && status.Status != "sleeping" {
+		probe.Merge(status, probe.Check(ctx, spec))
+		cached = false
+	}
+	return status, p.Capabilities(), cached, nil
 }
 
 // --- JSON Output ---
 
 type jsonServiceStatus struct {
-	Name     string  `json:"name"`
-	Platform string  `json:"platform"`
-	ID       string  `json:"id"`
-	Status   string  `json:"status,omitempty"`
-	Response int     `json:"response_ms,omitempty"`
-	CPU      float64 `json:"cpu,omitempty"`
-	Memory   float64 `json:"memory,omitempty"`
-	Instance int     `json:"instances,omitempty"`
-	MaxInst  int     `json:"max_instances,omitempty"`
-	Deploy   *jsonDeploy `json:"last_deploy,omitempty"`
-	Error    string  `json:"error,omitempty"`
+	Name      string      `json:"name"`
+	Platform  string      `json:"platform"`
+	ID        string      `json:"id"`
+	URL       string      `json:"url,omitempty"`
+	Status    string      `json:"status,omitempty"`
+	Response  int         `json:"response_ms,omitempty"`
+	CPU       float64     `json:"cpu,omitempty"`
+	Memory    float64     `json:"memory,omitempty"`
+	Instance  int         `json:"instances,omitempty"`
+	MaxInst   int         `json:"max_instances,omitempty"`
+	Deploy    *jsonDeploy `json:"last_deploy,omitempty"`
+	Probe     *jsonProbe  `json:"probe,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	ErrorKind string      `json:"error_kind,omitempty"` // unauthorized, not_found, rate_limited, not_supported
+
+	Incident *jsonIncident `json:"incident,omitempty"` // active incident
+}
+
+type jsonIncident struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title,omitempty"`
+	StartedAt time.Time `json:"started_at"`
 }
 
 type jsonDeploy struct {
@@ -234,16 +392,33 @@ type jsonDeploy struct {
 	URL     string `json:"url,omitempty"`
 }
 
+type jsonProbe struct {
+	Target    string `json:"target"`
+	OK        bool   `json:"ok"`
+	Slow      bool   `json:"slow,omitempty"`
+	LatencyMs int    `json:"latency_ms"`
+	Code      int    `json:"code,omitempty"`
+	Failure   string `json:"failure,omitempty"`
+}
+
 func toJSONService(r ui.ServiceResult) jsonServiceStatus {
 	js := jsonServiceStatus{
 		Name:     r.Entry.Name,
 		Platform: r.Entry.Platform,
 		ID:       r.Entry.ID,
+		URL:      r.Entry.URL,
+	}
+	if inc := r.Incident; inc != nil {
+		js.Incident = &jsonIncident{inc.ID, inc.Title, inc.StartedAt}
 	}
 	if r.Err != nil {
 		js.Error = r.Err.Error()
+		js.ErrorKind = platform.ErrorKind(r.Err)
 		return js
 	}
+	if r.Status.URL != "" {
+		js.URL = r.Status.URL
+	}
 	js.Status = r.Status.Status
 	js.Response = r.Status.ResponseMs
 	js.CPU = r.Status.CPU
@@ -263,29 +438,46 @@ func toJSONService(r ui.ServiceResult) jsonServiceStatus {
 			js.Deploy.Created = d.CreatedAt.Format("2006-01-02T15:04:05Z")
 		}
 	}
+	if pr := r.Status.Probe; pr != nil {
+		js.Probe = &jsonProbe{
+			Target:    pr.Target,
+			OK:        pr.OK,
+			Slow:      pr.Slow,
+			LatencyMs: pr.LatencyMs,
+			Code:      pr.Code,
+			Failure:   pr.Failure,
+		}
+	}
 	return js
 }
 
-func renderAllProjectsJSON(cfg *config.Config, key []byte, names []string) error {
+func toJSONServices(results []ui.ServiceResult) []jsonServiceStatus {
+	services := make([]jsonServiceStatus, len(results))
+	for i, r := range results {
+		services[i] = toJSONService(r)
+	}
+	return services
+}
+
+func renderAllProjectsJSON(ctx context.Context, cfg *config.Config, key []byte, names []string) error {
 	out := make(map[string][]jsonServiceStatus)
 	for _, name := range names {
 		proj := cfg.Projects[name]
-		results := fetchStatuses(proj.Topology, cfg, key)
-		services := make([]jsonServiceStatus, len(results))
-		for i, r := range results {
-			services[i] = toJSONService(r)
-		}
-		out[name] = services
+		results := fetchStatuses(ctx, proj.Topology, cfg, key)
+		applyScriptHealth(name, results)
+		recordStatuses(name, results)
+		annotateIncidents(name, results)
+		syncRemoteNames(cfg, name, results)
+		out[name] = toJSONServices(results)
+	}
+	if err := writeStatusOut(out); err != nil {
+		return err
 	}
 	return printJSON(out)
 }
 
 func renderProjectJSON(name string, results []ui.ServiceResult) error {
-	services := make([]jsonServiceStatus, len(results))
-	for i, r := range results {
-		services[i] = toJSONService(r)
-	}
-	out := map[string][]jsonServiceStatus{name: services}
+	out := map[string][]jsonServiceStatus{name: toJSONServices(results)}
 	return printJSON(out)
 }
 
@@ -294,6 +486,14 @@ func renderServiceJSON(entry config.ServiceEntry, status *platform.ServiceStatus
 	return printJSON(toJSONService(r))
 }
 
+// writeStatusOut writes the JSON result to --out, if set.
+func writeStatusOut(v interface{}) error {
+	if statusOut == "" {
+		return nil
+	}
+	return writeJSONFile(statusOut, v)
+}
+
 func printJSON(v interface{}) error {
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
@@ -306,12 +506,16 @@ func printJSON(v interface{}) error {
 // --- Helpers ---
 
 func projectNames(cfg *config.Config) string {
+	return joinNames(sortedProjectNames(cfg))
+}
+
+func sortedProjectNames(cfg *config.Config) []string {
 	names := make([]string, 0, len(cfg.Projects))
 	for name := range cfg.Projects {
 		names = append(names, name)
 	}
 	sort.Strings(names)
-	return joinNames(names)
+	return names
 }
 
 func joinNames(names []string) string {
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+)
+
+// applyTheme sets the UI palette from config. $ORBIT_THEME picks the
+// preset for a single shell (e.g. ORBIT_THEME=light), overriding config.
+func applyTheme(tc config.ThemeConfig) {
+	preset := tc.Preset
+	if env := os.Getenv("ORBIT_THEME"); env != "" {
+		preset = env
+	}
+	preset = strings.ToLower(preset)
+	if preset == "" {
+		preset = ui.DefaultTheme
+	}
+
+	base, ok := ui.Themes[preset]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s unknown theme %q, using %s (available: %s)\n",
+			ui.IconWarning, preset, ui.DefaultTheme, strings.Join(ui.ThemeNames(), ", "))
+		base = ui.Themes[ui.DefaultTheme]
+	}
+
+	ui.ApplyTheme(base.Override(ui.Theme{
+		Healthy:  tc.Healthy,
+		Warning:  tc.Warning,
+		Error:    tc.Error,
+		Sleeping: tc.Sleeping,
+		Primary:  tc.Primary,
+		Muted:    tc.Muted,
+	}))
+}
+
+// applyIcons sets the status markers from config. $ORBIT_ICONS picks the
+// set for a single shell. "auto" (the default) falls back to ASCII on
+// terminals that cannot draw emoji.
+func applyIcons(ic config.IconsConfig) {
+	set := ic.Set
+	if env := os.Getenv("ORBIT_ICONS"); env != "" {
+		set = env
+	}
+	set = strings.ToLower(set)
+	if set == "" || set == "auto" {
+		set = ui.AutoIconSet(os.Getenv)
+	}
+
+	base, ok := ui.IconSets[set]
+	if !ok {
+		base = ui.IconSets[ui.AutoIconSet(os.Getenv)]
+		ui.ApplyIcons(base)
+		fmt.Fprintf(os.Stderr, "%s unknown icon set %q (available: auto, %s)\n",
+			ui.IconWarning, set, strings.Join(ui.IconSetNames(), ", "))
+	}
+
+	ui.ApplyIcons(base.Override(ui.IconSet{
+		Healthy:  ic.Healthy,
+		Warning:  ic.Warning,
+		Error:    ic.Error,
+		Sleeping: ic.Sleeping,
+		Building: ic.Building,
+		Deploy:   ic.Deploy,
+		Watch:    ic.Watch,
+		Timeout:  ic.Timeout,
+		Success:  ic.Success,
+		Failed:   ic.Failed,
+		Rocket:   ic.Rocket,
+		Health:   ic.Health,
+	}))
+}
@@ -9,7 +9,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var topologySet string
+var (
+	topologySet    string
+	topologyView   string
+	topologyDelete bool
+)
 
 var topologyCmd = &cobra.Command{
 	Use:   "topology <project>",
@@ -18,14 +22,22 @@ var topologyCmd = &cobra.Command{
 
   orbit topology <project>                          Show current topology
   orbit topology <project> --set "frontend → api → db"  Set topology order
+  orbit topology <project> --view user-facing --set "frontend → api"
+                                                    Define a view
+  orbit topology <project> --view user-facing --delete  Delete a view
+
+The --set flag accepts service names separated by "→" or "->".
 
-The --set flag accepts service names separated by "→" or "->".`,
+A view is a named subset of the services, in its own order. status, watch
+and dashboard take --view to show only those services.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTopology,
 }
 
 func init() {
 	topologyCmd.Flags().StringVar(&topologySet, "set", "", `Topology order (e.g. "frontend → api → db")`)
+	topologyCmd.Flags().StringVar(&topologyView, "view", "", "Show, define (with --set) or delete (with --delete) a named view")
+	topologyCmd.Flags().BoolVar(&topologyDelete, "delete", false, "Delete the view given by --view")
 	rootCmd.AddCommand(topologyCmd)
 }
 
@@ -42,6 +54,25 @@ func runTopology(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("project %q not found\nAvailable projects: %s", projectName, projectNames(cfg))
 	}
 
+	if topologyDelete && topologyView == "" {
+		return fmt.Errorf("--delete needs --view")
+	}
+	if topologyView != "" {
+		switch {
+		case topologyDelete:
+			return deleteView(cfg, projectName, &proj)
+		case topologySet != "":
+			return setView(cfg, projectName, &proj)
+		}
+		entries, err := proj.ViewEntries(topologyView)
+		if err != nil {
+			return err
+		}
+		printTopology(projectName, topologyView, entries)
+		fmt.Println()
+		return nil
+	}
+
 	if topologySet != "" {
 		return setTopologyOrder(cfg, projectName, &proj)
 	}
@@ -50,17 +81,31 @@ func runTopology(cmd *cobra.Command, args []string) error {
 }
 
 func showTopology(projectName string, proj *config.ProjectConfig) error {
-	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName), ui.MutedStyle.Render("topology"))
-
 	if len(proj.Topology) == 0 {
+		fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName), ui.MutedStyle.Render("topology"))
 		fmt.Println(ui.MutedStyle.Render("  No services configured."))
 		fmt.Println()
 		return nil
 	}
 
-	for i, svc := range proj.Topology {
+	printTopology(projectName, "topology", proj.Topology)
+	if len(proj.Views) > 0 {
+		fmt.Printf("\n  %s\n", ui.MutedStyle.Render("views"))
+		for _, name := range proj.ViewNames() {
+			fmt.Printf("  %s %s\n", ui.Pad(name, 16), ui.MutedStyle.Render(strings.Join(proj.Views[name], " → ")))
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// printTopology prints services in order, labelled with title.
+func printTopology(projectName, title string, entries []config.ServiceEntry) {
+	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName), ui.MutedStyle.Render(title))
+	for i, svc := range entries {
 		connector := "  "
-		if i < len(proj.Topology)-1 {
+		if i < len(entries)-1 {
 			connector = " →"
 		}
 
@@ -69,15 +114,12 @@ func showTopology(projectName string, proj *config.ProjectConfig) error {
 			ui.MutedStyle.Render(fmt.Sprintf("[%s]", svc.Platform)),
 			ui.MutedStyle.Render(connector))
 	}
-
-	fmt.Println()
-	return nil
 }
 
-func setTopologyOrder(cfg *config.Config, projectName string, proj *config.ProjectConfig) error {
-	// Parse: split by "→" or "->"
-	input := topologySet
-	input = strings.ReplaceAll(input, "→", "->")
+// parseTopologySet splits the --set value on "→" or "->" and checks that
+// every name is a service of the project, listed once.
+func parseTopologySet(projectName string, proj *config.ProjectConfig) ([]string, error) {
+	input := strings.ReplaceAll(topologySet, "→", "->")
 	parts := strings.Split(input, "->")
 
 	names := make([]string, 0, len(parts))
@@ -89,34 +131,45 @@ func setTopologyOrder(cfg *config.Config, projectName string, proj *config.Proje
 	}
 
 	if len(names) == 0 {
-		return fmt.Errorf("no service names provided in --set value")
+		return nil, fmt.Errorf("no service names provided in --set value")
 	}
 
-	// Build lookup from existing services
-	svcMap := make(map[string]config.ServiceEntry)
+	svcMap := make(map[string]bool)
+	var existing []string
 	for _, svc := range proj.Topology {
-		svcMap[svc.Name] = svc
+		svcMap[svc.Name] = true
+		existing = append(existing, svc.Name)
 	}
-
-	// Validate all names exist
+	used := make(map[string]bool)
 	for _, name := range names {
-		if _, ok := svcMap[name]; !ok {
-			var existing []string
-			for _, svc := range proj.Topology {
-				existing = append(existing, svc.Name)
-			}
-			return fmt.Errorf("service %q not found in project %q\nAvailable services: %s",
+		if !svcMap[name] {
+			return nil, fmt.Errorf("service %q not found in project %q\nAvailable services: %s",
 				name, projectName, joinNames(existing))
 		}
+		if used[name] {
+			return nil, fmt.Errorf("duplicate service %q in --set value", name)
+		}
+		used[name] = true
+	}
+	return names, nil
+}
+
+func setTopologyOrder(cfg *config.Config, projectName string, proj *config.ProjectConfig) error {
+	names, err := parseTopologySet(projectName, proj)
+	if err != nil {
+		return err
+	}
+
+	// Build lookup from existing services
+	svcMap := make(map[string]config.ServiceEntry)
+	for _, svc := range proj.Topology {
+		svcMap[svc.Name] = svc
 	}
 
 	// Rebuild topology in the specified order
 	reordered := make([]config.ServiceEntry, 0, len(names))
 	used := make(map[string]bool)
 	for _, name := range names {
-		if used[name] {
-			return fmt.Errorf("duplicate service %q in --set value", name)
-		}
 		reordered = append(reordered, svcMap[name])
 		used[name] = true
 	}
@@ -138,3 +191,42 @@ func setTopologyOrder(cfg *config.Config, projectName string, proj *config.Proje
 	fmt.Printf("  %s Topology updated for %s\n", ui.IconSuccess, ui.ProjectTitleStyle.Render(projectName))
 	return showTopology(projectName, proj)
 }
+
+func setView(cfg *config.Config, projectName string, proj *config.ProjectConfig) error {
+	names, err := parseTopologySet(projectName, proj)
+	if err != nil {
+		return err
+	}
+	if proj.Views == nil {
+		proj.Views = make(map[string][]string)
+	}
+	proj.Views[topologyView] = names
+	cfg.Projects[projectName] = *proj
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	fmt.Printf("  %s View %s saved for %s\n", ui.IconSuccess, topologyView, ui.ProjectTitleStyle.Render(projectName))
+	entries, err := proj.ViewEntries(topologyView)
+	if err != nil {
+		return err
+	}
+	printTopology(projectName, topologyView, entries)
+	fmt.Println()
+	return nil
+}
+
+func deleteView(cfg *config.Config, projectName string, proj *config.ProjectConfig) error {
+	if _, ok := proj.Views[topologyView]; !ok {
+		return fmt.Errorf("view %q not found in project %q", topologyView, projectName)
+	}
+	delete(proj.Views, topologyView)
+	cfg.Projects[projectName] = *proj
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	fmt.Printf("  %s View %s deleted from %s\n", ui.IconSuccess, topologyView, ui.ProjectTitleStyle.Render(projectName))
+	return nil
+}
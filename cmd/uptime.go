@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/history"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	uptimeSince  string
+	uptimeFormat string
+)
+
+var uptimeCmd = &cobra.Command{
+	Use:   "uptime [project]",
+	Short: "Uptime, response time and SLO burn from recorded history",
+	Long: `Compute each service's uptime and mean response time from the status
+polls and heartbeat checks in orbit history, and how much of its error budget
+it has spent against the objectives in config.yaml:
+
+  slo:
+    uptime: 99.9        # percent
+    response_ms: 500    # mean response time
+
+A service can override them with its own slo: block in the topology.
+
+  orbit uptime myshop
+  orbit uptime myshop --since 7d --format json
+
+Each observation counts until the service's next one, for at most an hour;
+coverage is the share of the period orbit has observations for.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUptime,
+}
+
+func init() {
+	uptimeCmd.Flags().StringVar(&uptimeSince, "since", "30d", "Period to compute over (e.g. 24h, 7d)")
+	uptimeCmd.Flags().StringVar(&uptimeFormat, "format", "", "Output format (json)")
+	rootCmd.AddCommand(uptimeCmd)
+}
+
+type jsonUptimeSLO struct {
+	Uptime      float64  `json:"uptime,omitempty"`
+	ResponseMs  int      `json:"response_ms,omitempty"`
+	BudgetUsed  *float64 `json:"budget_used_percent,omitempty"`
+	UptimeMet   *bool    `json:"uptime_met,omitempty"`
+	ResponseMet *bool    `json:"response_met,omitempty"`
+}
+
+type jsonUptime struct {
+	Service        string         `json:"service"`
+	Samples        int            `json:"samples"`
+	UptimePercent  float64        `json:"uptime_percent"`
+	DowntimeS      int64          `json:"downtime_s"`
+	CoveragePct    float64        `json:"coverage_percent"`
+	MeanResponseMs int64          `json:"mean_response_ms,omitempty"`
+	SLO            *jsonUptimeSLO `json:"slo,omitempty"`
+}
+
+func runUptime(cmd *cobra.Command, args []string) error {
+	period, err := parseSince(uptimeSince)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	for _, e := range proj.Topology {
+		if slo := cfg.SLOFor(e); slo.Uptime < 0 || slo.Uptime > 100 {
+			return fmt.Errorf("invalid slo.uptime %v for %s: want a percentage such as 99.9", slo.Uptime, e.Name)
+		}
+	}
+
+	path, err := history.Path()
+	if err != nil {
+		return err
+	}
+	store, err := history.Open(path)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	entries, err := store.Query(history.Query{Project: projectName, Since: now.Add(-period)})
+	store.Close()
+	if err != nil {
+		return err
+	}
+
+	uptimes := make(map[string]history.Uptime)
+	for _, u := range history.ComputeUptime(entries, now) {
+		uptimes[u.Service] = u
+	}
+
+	if uptimeFormat == "json" {
+		out := struct {
+			Project  string       `json:"project"`
+			Since    time.Time    `json:"since"`
+			Services []jsonUptime `json:"services"`
+		}{Project: projectName, Since: now.Add(-period), Services: []jsonUptime{}}
+		for _, e := range proj.Topology {
+			out.Services = append(out.Services, toJSONUptime(uptimes[e.Name], e.Name, cfg.SLOFor(e), period))
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName),
+		ui.MutedStyle.Render("uptime, last "+uptimeSince))
+	fmt.Printf("  %s %s %s %s %s %s\n",
+		ui.Pad(ui.HeaderStyle.Render("Service"), 14),
+		ui.Pad(ui.HeaderStyle.Render("Uptime"), 10),
+		ui.Pad(ui.HeaderStyle.Render("Downtime"), 10),
+		ui.Pad(ui.HeaderStyle.Render("Mean resp"), 11),
+		ui.Pad(ui.HeaderStyle.Render("Coverage"), 10),
+		ui.HeaderStyle.Render("SLO"),
+	)
+	for _, e := range proj.Topology {
+		u, slo := uptimes[e.Name], cfg.SLOFor(e)
+		if u.Known == 0 {
+			fmt.Printf("  %s %s\n", ui.Pad(e.Name, 14), ui.MutedStyle.Render("no data"))
+			continue
+		}
+		resp := ui.Dash
+		if u.MeanResponseMs > 0 {
+			resp = fmt.Sprintf("%dms", u.MeanResponseMs)
+			if slo.ResponseMs > 0 && u.MeanResponseMs > int64(slo.ResponseMs) {
+				resp = ui.ErrorStyle.Render(resp)
+			}
+		}
+		fmt.Printf("  %s %s %s %s %s %s\n",
+			ui.Pad(e.Name, 14),
+			ui.Pad(fmt.Sprintf("%.2f%%", u.Percent()), 10),
+			ui.Pad(formatDowntime(u.Down()), 10),
+			ui.Pad(resp, 11),
+			ui.Pad(formatPercent(coverage(u, period)), 10),
+			formatSLO(u, slo))
+	}
+	fmt.Printf("\n  %s\n\n", ui.MutedStyle.Render("From status polls and heartbeat checks in orbit history."))
+	return nil
+}
+
+func coverage(u history.Uptime, period time.Duration) float64 {
+	return 100 * float64(u.Known) / float64(period)
+}
+
+// formatPercent rounds a percentage for display, e.g. "40%", "<1%".
+func formatPercent(p float64) string {
+	switch {
+	case p > 0 && p < 1:
+		return "<1%"
+	case p > 999:
+		return ">999%"
+	}
+	return fmt.Sprintf("%.0f%%", p)
+}
+
+// round2 rounds a percentage to two decimals for JSON output.
+func round2(p float64) float64 {
+	return math.Round(p*100) / 100
+}
+
+func formatDowntime(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	return ui.FormatGap(d)
+}
+
+// formatSLO describes how a service fares against its objectives, e.g.
+// "✓ 99.9%: 40% of budget used".
+func formatSLO(u history.Uptime, slo config.SLOConfig) string {
+	var text string
+	if slo.Uptime > 0 {
+		used := u.BudgetUsed(slo.Uptime)
+		text = fmt.Sprintf("%g%%: %s of budget used", slo.Uptime, formatPercent(used))
+		switch {
+		case used >= 100:
+			text = ui.ErrorStyle.Render(ui.IconError + " " + text)
+		case used >= 75:
+			text = ui.WarningStyle.Render(ui.IconWarning + " " + text)
+		default:
+			text = ui.HealthyStyle.Render(ui.IconHealthy + " " + text)
+		}
+	}
+	if slo.ResponseMs > 0 && u.MeanResponseMs > int64(slo.ResponseMs) {
+		if text != "" {
+			text += "  "
+		}
+		text += ui.ErrorStyle.Render(fmt.Sprintf("%s response over %dms", ui.IconError, slo.ResponseMs))
+	}
+	if text == "" {
+		return ui.MutedStyle.Render(ui.Dash)
+	}
+	return text
+}
+
+func toJSONUptime(u history.Uptime, name string, slo config.SLOConfig, period time.Duration) jsonUptime {
+	j := jsonUptime{
+		Service:        name,
+		Samples:        u.Samples,
+		UptimePercent:  round2(u.Percent()),
+		DowntimeS:      int64(u.Down() / time.Second),
+		CoveragePct:    round2(coverage(u, period)),
+		MeanResponseMs: u.MeanResponseMs,
+	}
+	if slo == (config.SLOConfig{}) {
+		return j
+	}
+	j.SLO = &jsonUptimeSLO{Uptime: slo.Uptime, ResponseMs: slo.ResponseMs}
+	if slo.Uptime > 0 && u.Known > 0 {
+		used := round2(u.BudgetUsed(slo.Uptime))
+		met := used <= 100
+		j.SLO.BudgetUsed, j.SLO.UptimeMet = &used, &met
+	}
+	if slo.ResponseMs > 0 && u.MeanResponseMs > 0 {
+		met := u.MeanResponseMs <= int64(slo.ResponseMs)
+		j.SLO.ResponseMet = &met
+	}
+	return j
+}
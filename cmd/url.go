@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	urlService string
+	urlCopy    bool
+	urlRefresh bool
+)
+
+var urlCmd = &cobra.Command{
+	Use:   "url [project]",
+	Short: "Print a service's public URL",
+	Long: `Print the canonical public URL of a service: the production alias on
+Vercel (a custom domain when there is one), the app's koyeb.app domain on
+Koyeb.
+
+  orbit url myshop --service web
+  orbit url myshop --service api --copy
+  open "$(orbit url myshop --service web)"
+
+URLs are picked up by project create/sync, service add and status, and kept
+in the config. When none is known yet, or with --refresh, the platform is
+asked.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runURL,
+}
+
+func init() {
+	urlCmd.Flags().StringVar(&urlService, "service", "", "Service name (required)")
+	urlCmd.Flags().BoolVar(&urlCopy, "copy", false, "Copy the URL to the clipboard")
+	urlCmd.Flags().BoolVar(&urlRefresh, "refresh", false, "Ask the platform instead of using the stored URL")
+	urlCmd.MarkFlagRequired("service")
+	rootCmd.AddCommand(urlCmd)
+}
+
+func runURL(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	projectName := cfg.DefaultProject
+	if len(args) > 0 {
+		projectName = args[0]
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	var entry *config.ServiceEntry
+	var svcNames []string
+	for i := range proj.Topology {
+		svcNames = append(svcNames, proj.Topology[i].Name)
+		if proj.Topology[i].Name == urlService {
+			entry = &proj.Topology[i]
+		}
+	}
+	if entry == nil {
+		return fmt.Errorf("service %q not found in project %q\nAvailable services: %s",
+			urlService, projectName, joinNames(svcNames))
+	}
+
+	u := entry.URL
+	if u == "" || urlRefresh {
+		key, err := config.LoadOrCreateKey()
+		if err != nil {
+			return fmt.Errorf("load encryption key: %w", err)
+		}
+		status, _, _, err := fetchSingleStatus(cmd.Context(), *entry, cfg, key)
+		if err != nil {
+			return fmt.Errorf("fetch status for %s: %w", entry.Name, err)
+		}
+		syncRemoteNames(cfg, projectName, []ui.ServiceResult{{Entry: *entry, Status: status}})
+		if status.URL != "" {
+			u = status.URL
+		}
+	}
+	if u == "" {
+		return fmt.Errorf("%s doesn't report a public URL for %s", entry.Platform, entry.Name)
+	}
+
+	fmt.Println(u)
+	if urlCopy {
+		if err := clipboard.WriteAll(u); err != nil {
+			return fmt.Errorf("copy to clipboard: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "%s Copied to clipboard\n", ui.IconSuccess)
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/manifest"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyFile    string
+	verifyProject string
+	verifyFormat  string
+	verifyService string
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [project]",
+	Short: "Check live services against orbit.yaml, or run a project's smoke tests",
+	Long: `Compare the services declared in orbit.yaml with the project's topology
+and what the platforms report, and exit 1 on any drift. Use it as a CI check
+that infrastructure still matches what the repository declares.
+
+orbit.yaml is looked up in the current directory and its parents, up to the
+repository root:
+
+  project: myshop
+  services:
+    - name: api
+      platform: koyeb
+      scale: {min: 1, max: 3, instance_type: nano}
+      env:
+        LOG_LEVEL: info
+        DATABASE_URL: "*"     # only needs to be set
+    - name: web
+      platform: vercel
+
+Drift is a declared service missing from the project or the platform, a
+project service orbit.yaml doesn't declare, a different platform or ID,
+scaling that differs, or an env variable that is unset or has another value.
+Values the platform keeps secret only count as drift when they are unset.
+
+  orbit verify
+  orbit verify --file deploy/orbit.yaml --format json
+
+Given a project, orbit verify instead runs the smoke tests its services
+declare in their topology entries, and exits 1 if any fails. A check is a
+request to a path under the service's url (or a full URL) that must answer
+with the given status, any 2xx by default, and a JSON body whose fields
+have the given values; a field without equals only needs to exist:
+
+  smoke:
+    - path: /health
+      status: 200
+      json:
+        - path: $.status
+          equals: ok
+        - path: $.checks.db
+    - name: list products
+      path: /v1/products?limit=1
+      timeout: 5s
+
+  orbit verify myshop
+  orbit verify myshop --service api --format json
+
+orbit watch --verify runs them after a deploy goes healthy.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runVerify,
+}
+
+func init() {
+	verifyCmd.Flags().StringVarP(&verifyFile, "file", "f", "", "Manifest to check (default: orbit.yaml in this directory or a parent)")
+	verifyCmd.Flags().StringVar(&verifyProject, "project", "", "Project to check (default: the manifest's project)")
+	verifyCmd.Flags().StringVar(&verifyFormat, "format", "", "Output format (json)")
+	verifyCmd.Flags().StringVar(&verifyService, "service", "", "Run only this service's smoke tests")
+	rootCmd.AddCommand(verifyCmd)
+}
+
+// driftFinding is one way a service differs from its declaration.
+type driftFinding struct {
+	Service  string `json:"service"`
+	Check    string `json:"check"` // service, platform, id, scale.min, scale.max, scale.instance_type, env.<KEY>
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 {
+		if verifyFile != "" || verifyProject != "" {
+			return fmt.Errorf("--file and --project check drift; drop them to run %s's smoke tests", args[0])
+		}
+		return runSmokeVerify(cmd, args[0])
+	}
+	if verifyService != "" {
+		return fmt.Errorf("--service needs a project: orbit verify <project> --service %s", verifyService)
+	}
+	path := verifyFile
+	if path == "" {
+		var err error
+		if path, err = manifest.Find("."); err != nil {
+			return fmt.Errorf("%w\nCreate one declaring the project's services, or pass --file", err)
+		}
+	}
+	m, err := manifest.Load(path)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return fmt.Errorf("load encryption key: %w", err)
+	}
+	projectName := verifyProject
+	if projectName == "" {
+		projectName = m.Project
+	}
+	proj, err := resolveProject(cfg, projectName)
+	if err != nil {
+		return err
+	}
+	if projectName == "" {
+		projectName = cfg.DefaultProject
+	}
+	cmd.SilenceUsage = true
+
+	entries := make(map[string]config.ServiceEntry, len(proj.Topology))
+	for _, e := range proj.Topology {
+		entries[e.Name] = e
+	}
+	declared := make(map[string]bool, len(m.Services))
+
+	var findings []driftFinding
+	type liveCheck struct {
+		entry config.ServiceEntry
+		want  manifest.Service
+	}
+	var live []liveCheck
+	for _, want := range m.Services {
+		declared[want.Name] = true
+		e, ok := entries[want.Name]
+		switch {
+		case !ok:
+			findings = append(findings, driftFinding{want.Name, "service", "declared", "not in project " + projectName})
+		case want.Platform != "" && want.Platform != e.Platform:
+			findings = append(findings, driftFinding{want.Name, "platform", want.Platform, e.Platform})
+		case want.ID != "" && want.ID != e.ID:
+			findings = append(findings, driftFinding{want.Name, "id", want.ID, e.ID})
+		default:
+			live = append(live, liveCheck{e, want})
+		}
+	}
+	for _, e := range proj.Topology {
+		if !declared[e.Name] {
+			findings = append(findings, driftFinding{e.Name, "service", "not declared", "in project " + projectName})
+		}
+	}
+
+	liveFindings := make([][]driftFinding, len(live))
+	skipped := make([][]string, len(live))
+	errs := make([]error, len(live))
+	var wg sync.WaitGroup
+	for i, lc := range live {
+		wg.Add(1)
+		go func(i int, lc liveCheck) {
+			defer wg.Done()
+			liveFindings[i], skipped[i], errs[i] = checkDrift(cmd.Context(), cfg, key, lc.entry, lc.want)
+		}(i, lc)
+	}
+	wg.Wait()
+	var notes []string
+	var failed []string
+	for i := range live {
+		findings = append(findings, liveFindings[i]...)
+		notes = append(notes, skipped[i]...)
+		if errs[i] != nil {
+			failed = append(failed, live[i].entry.Name)
+			notes = append(notes, fmt.Sprintf("%s: %v", live[i].entry.Name, errs[i]))
+		}
+	}
+
+	if verifyFormat == "json" {
+		out := struct {
+			Project  string         `json:"project"`
+			Manifest string         `json:"manifest"`
+			Drift    bool           `json:"drift"`
+			Findings []driftFinding `json:"findings"`
+			Notes    []string       `json:"notes,omitempty"`
+		}{projectName, path, len(findings) > 0, findings, notes}
+		if out.Findings == nil {
+			out.Findings = []driftFinding{}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printDrift(projectName, path, m, proj, findings, notes)
+	}
+
+	switch {
+	case len(findings) > 0:
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: exitFailed, Msg: ""}
+	case len(failed) > 0:
+		return fmt.Errorf("could not check %s", joinNames(failed))
+	}
+	return nil
+}
+
+// checkDrift compares one service's live state with its declaration.
+// Expectations the platform can't report are returned as notes.
+func checkDrift(ctx context.Context, cfg *config.Config, key []byte, e config.ServiceEntry, want manifest.Service) ([]driftFinding, []string, error) {
+	p, err := entryPlatform(cfg, key, e)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := p.GetServiceStatus(ctx, e.ID); err != nil {
+		if errors.Is(err, platform.ErrNotFound) {
+			return []driftFinding{{e.Name, "service", "declared", "not found on " + e.Platform}}, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var findings []driftFinding
+	var notes []string
+	if sc := want.Scale; sc != nil {
+		if provider, ok := p.(platform.ScaleInfoProvider); !ok {
+			notes = append(notes, fmt.Sprintf("%s: %s doesn't report scaling, not checked", e.Name, e.Platform))
+		} else {
+			min, max, instanceType, err := provider.GetCurrentScale(ctx, e.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("get scale: %w", err)
+			}
+			if sc.Min != nil && *sc.Min != min {
+				findings = append(findings, driftFinding{e.Name, "scale.min", strconv.Itoa(*sc.Min), strconv.Itoa(min)})
+			}
+			if sc.Max != nil && *sc.Max != max {
+				findings = append(findings, driftFinding{e.Name, "scale.max", strconv.Itoa(*sc.Max), strconv.Itoa(max)})
+			}
+			if sc.InstanceType != "" && sc.InstanceType != instanceType {
+				findings = append(findings, driftFinding{e.Name, "scale.instance_type", sc.InstanceType, instanceType})
+			}
+		}
+	}
+
+	if len(want.Env) > 0 {
+		em, ok := p.(platform.EnvManager)
+		if !ok {
+			notes = append(notes, fmt.Sprintf("%s: %s doesn't expose environment variables, not checked", e.Name, e.Platform))
+			return findings, notes, nil
+		}
+		vars, err := em.GetEnvVars(ctx, e.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get environment: %w", err)
+		}
+		live := make(map[string]platform.EnvVar, len(vars))
+		for _, v := range vars {
+			live[v.Key] = v
+		}
+		keys := make([]string, 0, len(want.Env))
+		for k := range want.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			expected := want.Env[k]
+			v, ok := live[k]
+			switch {
+			case !ok:
+				findings = append(findings, driftFinding{e.Name, "env." + k, expected, "unset"})
+			case expected == manifest.AnyValue:
+			case v.Sensitive || v.SecretRef != "":
+				notes = append(notes, fmt.Sprintf("%s: %s is secret, only checked that it is set", e.Name, k))
+			case v.Value != expected:
+				findings = append(findings, driftFinding{e.Name, "env." + k, expected, v.Value})
+			}
+		}
+	}
+	return findings, notes, nil
+}
+
+func printDrift(projectName, path string, m *manifest.Manifest, proj *config.ProjectConfig, findings []driftFinding, notes []string) {
+	rel := path
+	if wd, err := os.Getwd(); err == nil {
+		if r, err := filepath.Rel(wd, path); err == nil {
+			rel = r
+		}
+	}
+	fmt.Printf("\n  %s %s\n\n", ui.ProjectTitleStyle.Render(projectName), ui.MutedStyle.Render("drift against "+rel))
+
+	byService := make(map[string][]driftFinding)
+	var order []string
+	for _, s := range m.Services {
+		order = append(order, s.Name)
+	}
+	for _, e := range proj.Topology {
+		if !containsString(order, e.Name) {
+			order = append(order, e.Name)
+		}
+	}
+	for _, f := range findings {
+		byService[f.Service] = append(byService[f.Service], f)
+	}
+
+	for _, name := range order {
+		fs := byService[name]
+		if len(fs) == 0 {
+			fmt.Printf("  %s %s\n", ui.HealthyStyle.Render(ui.IconHealthy), name)
+			continue
+		}
+		for i, f := range fs {
+			label := "  " + ui.Pad("", len(name))
+			if i == 0 {
+				label = ui.ErrorStyle.Render(ui.IconError) + " " + name
+			}
+			detail := fmt.Sprintf("%s: expected %s, found %s", f.Check, f.Expected, f.Actual)
+			if f.Check == "service" {
+				detail = fmt.Sprintf("%s, %s", f.Expected, f.Actual)
+			}
+			fmt.Printf("  %s  %s\n", label, ui.ErrorStyle.Render(detail))
+		}
+	}
+	for _, n := range notes {
+		fmt.Printf("  %s %s\n", ui.MutedStyle.Render("-"), ui.MutedStyle.Render(n))
+	}
+
+	fmt.Println()
+	if len(findings) == 0 {
+		fmt.Printf("  %s No drift\n\n", ui.IconSuccess)
+		return
+	}
+	fmt.Printf("  %s %d differences from %s\n\n", ui.IconError, len(findings), filepath.Base(path))
+}
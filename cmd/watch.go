@@ -1,15 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"sync"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/smoke"
 	"github.com/humanetools/orbit/internal/ui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // Watch exit codes
@@ -20,14 +28,44 @@ const (
 	exitTimeout      = 3
 )
 
-// Detection phase timeout — how long to wait for a new deployment before giving up.
-const detectTimeout = 60 * time.Second
+// Defaults for --detect-timeout, how long to wait for a new deployment to
+// appear before giving up, and --poll-interval. watch.detect_timeout and
+// watch.poll_interval in config change them.
+const (
+	defaultDetectTimeout = 60 * time.Second
+	minPollInterval      = time.Second
+)
 
 var (
-	watchService string
-	watchAll     bool
-	watchTimeout int
-	watchFormat  string
+	watchService       string
+	watchAll           bool
+	watchTimeout       int
+	watchFormat        string
+	watchGitHubPR      int
+	watchGitLabStatus  bool
+	watchBBStatus      bool
+	watchDotenv        string
+	watchOut           string
+	watchRequire       string
+	watchView          string
+	watchVerify        bool
+	watchRedeploy      bool
+	watchDeploy        string
+	watchCommit        string
+	watchNotifyURL     string
+	watchLogs          bool
+	watchFailFast      bool
+	watchAutoRollback  bool
+	watchRollbackGrace time.Duration
+
+	// Effective values once applyWatchTimings has applied config defaults.
+	watchDetectTimeout time.Duration
+	watchPollInterval  time.Duration
+
+	// watchProgress posts progress with --notify-url or watch.notify.
+	watchProgress *watchNotifier
+	// watchLive is the live view of a multi-service watch, while it runs.
+	watchLive *tea.Program
 )
 
 var watchCmd = &cobra.Command{
@@ -38,14 +76,90 @@ var watchCmd = &cobra.Command{
   orbit watch myshop --service api
   orbit watch myshop --service api --timeout 300
   orbit watch myshop --service api --format json
+  orbit watch myshop --all --format ndjson
   orbit watch myshop --service api,frontend
   orbit watch myshop --all
+  orbit watch myshop --service web --github-pr 42
+  orbit watch myshop --service api --format gitlab --gitlab-status
+  orbit watch myshop --service api --bitbucket-status
+  orbit watch myshop --all --out result.json
+  orbit watch myshop --all --require api,worker
+  orbit watch myshop --view user-facing
+  orbit watch myshop --service api --verify
+  orbit watch myshop --service api --redeploy
+  orbit watch myshop --service api --deploy 3f2a   # any unique prefix of a recent deploy
+  orbit watch myshop --all --detect-timeout 5m --poll-interval 10s
+  orbit watch myshop --service api --commit HEAD
+  orbit watch myshop --service api --logs
+  orbit watch myshop --all --fail-fast
+  orbit watch myshop --service api --auto-rollback --rollback-grace 5m
+  orbit watch myshop --all --notify-url https://hooks.slack.com/services/T0/B0/xyz
 
 Exit codes:
   0  Deploy successful (healthy)
   1  Build/deploy failed
   2  No new deployment detected
-  3  Timeout (deploy still in progress)`,
+  3  Timeout (deploy still in progress)
+
+With several services on a terminal, each gets a line that updates in
+place as its deploy progresses, and the results follow once all are done.
+The most severe result wins (failed, then timeout,
+then no deployment). Services marked "optional: true" in the topology don't
+fail the watch for lack of a new deployment. Both are configurable under
+"watch" in the config (priority, optional_ignore).
+
+A deploy must appear within --detect-timeout (60s) of the watch starting,
+or the result is "no deployment"; raise it when builds queue before the
+platform lists them. --poll-interval (3s) is how often the platform is
+asked. watch.detect_timeout and watch.poll_interval in the config change
+both defaults.
+
+With --format ndjson, watch prints a JSON line per phase change as it
+happens (detected, building, deploying, healthcheck, done, failed), then a
+result line per service with the fields of --format json.
+
+With --notify-url (or watch.notify_url, and the notification channels
+listed in watch.notify) each deployment phase and the final result are
+posted as they happen: a message for Slack and Discord webhook URLs, the
+event as JSON for any other URL.
+
+With --logs, the build output streams inline while the deploy builds
+(Koyeb, Vercel), and its last lines are kept in the JSON result as
+build_logs. Watching several services only keeps them.
+
+With --require only the listed services decide the exit code; the others
+are watched and reported for information.
+
+With --fail-fast, the first service that fails (and counts toward the exit
+code) cancels the watches still running, and watch exits right away. Their
+result is "cancelled", which doesn't affect the exit code.
+
+With --auto-rollback, a service whose deploy fails, fails its smoke tests
+(--verify) or isn't healthy within --rollback-grace of its build finishing
+is rolled back to its last healthy deployment, and watch waits for that to
+serve again. It runs for exit codes 1 (the deploy or its smoke tests
+failed) and 3 (not healthy by --rollback-grace or --timeout); a deploy still
+in progress is cancelled first, and left alone where the platform can't
+cancel it. The exit code is still the deploy's; the JSON result reports the
+rollback under "rollback". It needs a platform that can roll back.
+
+With --redeploy, watch starts the deploy itself and follows exactly that
+deployment, instead of waiting for one a push started. A service with a
+deploy_hook in the topology (a URL to POST to, or a shell command such as
+"git push koyeb main") is deployed through it, and watch follows the first
+deployment after the one that was live.
+
+With --deploy, watch skips detection and follows a deployment whose ID is
+already known, e.g. from orbit redeploy --format json. One that has already
+finished is reported right away.
+
+With --commit, watch waits for the deployment of that commit (a SHA, or a
+ref such as HEAD resolved in the local git repository) and ignores others,
+so a deploy someone else started on a shared service isn't mistaken for
+yours. The platform has to report commits on its deployments.
+
+With --verify, services that declare smoke tests (see orbit verify --help)
+run them once their deploy is healthy, and fail the watch if any fails.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runWatch,
 }
@@ -54,35 +168,93 @@ func init() {
 	watchCmd.Flags().StringVar(&watchService, "service", "", "Service name(s), comma-separated")
 	watchCmd.Flags().BoolVar(&watchAll, "all", false, "Watch all services in the project")
 	watchCmd.Flags().IntVar(&watchTimeout, "timeout", 300, "Maximum wait time in seconds")
-	watchCmd.Flags().StringVar(&watchFormat, "format", "", "Output format (json)")
+	watchCmd.Flags().StringVar(&watchFormat, "format", "", "Output format (json, ndjson, gitlab)")
+	watchCmd.Flags().IntVar(&watchGitHubPR, "github-pr", 0, "Post or update a comment with the result on this GitHub PR number")
+	watchCmd.Flags().BoolVar(&watchGitLabStatus, "gitlab-status", false, "Set a GitLab commit status for each watched service")
+	watchCmd.Flags().BoolVar(&watchBBStatus, "bitbucket-status", false, "Set a Bitbucket build status for each watched service")
+	watchCmd.Flags().StringVar(&watchDotenv, "dotenv", "orbit.env", "Dotenv report path written with --format gitlab")
+	watchCmd.Flags().StringVar(&watchOut, "out", "", "Also write the JSON result to this file")
+	watchCmd.Flags().BoolVar(&watchAutoRollback, "auto-rollback", false, "Roll back to the last healthy deployment when the deploy fails")
+	watchCmd.Flags().DurationVar(&watchRollbackGrace, "rollback-grace", defaultRollbackGrace, "With --auto-rollback, how long a built deploy may take to become healthy")
+	watchCmd.Flags().BoolVar(&watchFailFast, "fail-fast", false, "Stop watching the other services as soon as one fails")
+	watchCmd.Flags().StringVar(&watchRequire, "require", "", "Only these services (comma-separated) affect the exit code")
+	watchCmd.Flags().StringVar(&watchView, "view", "", "Watch the services of a named view")
+	watchCmd.Flags().BoolVar(&watchVerify, "verify", false, "Run the services' smoke tests after a healthy deploy")
+	watchCmd.Flags().BoolVar(&watchRedeploy, "redeploy", false, "Trigger a deploy (redeploy, or the service's deploy_hook) and watch it")
+	watchCmd.Flags().DurationVar(&watchDetectTimeout, "detect-timeout", defaultDetectTimeout, "How long to wait for a new deployment to appear")
+	watchCmd.Flags().DurationVar(&watchPollInterval, "poll-interval", platform.DefaultPollInterval, "How often to poll the platform")
+	watchCmd.Flags().StringVar(&watchDeploy, "deploy", "", "Follow this deployment (ID or a unique prefix) instead of waiting for a new one")
+	watchCmd.Flags().StringVar(&watchNotifyURL, "notify-url", "", "Post each phase and the result to this Slack, Discord or webhook URL")
+	watchCmd.Flags().BoolVar(&watchLogs, "logs", false, "Stream the build output while the deploy builds")
+	watchCmd.Flags().StringVar(&watchCommit, "commit", "", "Wait for the deployment of this commit (SHA, or a git ref such as HEAD)")
 	rootCmd.AddCommand(watchCmd)
 }
 
 type serviceContext struct {
-	resolved *resolvedService
-	name     string
+	resolved      *resolvedService
+	name          string
+	informational bool // not in --require
 }
 
 // watchResult holds the outcome of watching a single service.
 type watchResult struct {
 	ServiceName string
 	Platform    string
+	Optional    bool // optional in the topology; see watchExitPolicy
+	// Informational is set for services left out of --require; their
+	// result never affects the exit code.
+	Informational bool
+	// Cancelled is set when --fail-fast stopped the watch after another
+	// service failed. ExitCode is then exitTimeout, but the result never
+	// affects the exit code.
+	Cancelled   bool
 	ExitCode    int
 	DeployID    string
 	Commit      string
 	Message     string
+	Branch      string
+	Author      string
+	PullRequest int
 	Duration    time.Duration
 	Status      string
 	Phase       string
 	URL         string
 	Error       string
+	ErrorKind   string // platform.ErrorKind of the error, if classified
 	Logs        []string
+	BuildLogs   []string // build output streamed with --logs, the last buildLogTail lines
 	WaitedSec   int
+
+	PrevDeployID string // deployment that was current when the watch started
+	Size         int64  // build artifact size in bytes, 0 if unknown
+	PrevSize     int64
+
+	Smoke    []smoke.Result // smoke test results with --verify
+	Rollback *watchRollback // set when --auto-rollback rolled the service back
 }
 
 func runWatch(cmd *cobra.Command, args []string) error {
-	if watchService == "" && !watchAll {
-		return fmt.Errorf("specify --service <name> or --all")
+	if watchService == "" && !watchAll && watchView == "" {
+		return fmt.Errorf("specify --service <name>, --view <name> or --all")
+	}
+	if watchView != "" && (watchService != "" || watchAll) {
+		return fmt.Errorf("--view can't be combined with --service or --all")
+	}
+	if watchDeploy != "" && watchRedeploy {
+		return fmt.Errorf("--deploy follows an existing deployment; drop --redeploy")
+	}
+	if watchCommit != "" && (watchDeploy != "" || watchRedeploy) {
+		return fmt.Errorf("--commit picks the deployment to follow; drop --deploy and --redeploy")
+	}
+	if cmd.Flags().Changed("rollback-grace") && !watchAutoRollback {
+		return fmt.Errorf("--rollback-grace needs --auto-rollback")
+	}
+	if watchCommit != "" {
+		sha, err := resolveCommit(cmd.Context(), watchCommit)
+		if err != nil {
+			return err
+		}
+		watchCommit = sha
 	}
 
 	cfg, err := config.Load()
@@ -106,14 +278,34 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	policy, err := parseWatchExitPolicy(cfg.Watch)
+	if err != nil {
+		return err
+	}
+	if err := applyWatchTimings(cmd, cfg.Watch); err != nil {
+		return err
+	}
+	if watchProgress, err = newWatchNotifier(cfg, projectName, watchNotifyURL); err != nil {
+		return err
+	}
+	defer watchProgress.close()
 
 	// Determine which services to watch
 	var serviceNames []string
-	if watchAll {
+	switch {
+	case watchAll:
 		for _, e := range proj.Topology {
 			serviceNames = append(serviceNames, e.Name)
 		}
-	} else {
+	case watchView != "":
+		entries, err := proj.ViewEntries(watchView)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			serviceNames = append(serviceNames, e.Name)
+		}
+	default:
 		serviceNames = strings.Split(watchService, ",")
 		for i := range serviceNames {
 			serviceNames[i] = strings.TrimSpace(serviceNames[i])
@@ -123,6 +315,14 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	if len(serviceNames) == 0 {
 		return fmt.Errorf("no services to watch")
 	}
+	if watchDeploy != "" && len(serviceNames) > 1 {
+		return fmt.Errorf("--deploy follows one deployment; pass a single --service")
+	}
+
+	required, err := parseRequired(watchRequire, serviceNames)
+	if err != nil {
+		return err
+	}
 
 	// Resolve all services upfront
 	var contexts []serviceContext
@@ -131,40 +331,74 @@ func runWatch(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		contexts = append(contexts, serviceContext{resolved: r, name: name})
+		if err := requireCapability(r, platform.CapDeployments|platform.CapWatch); err != nil {
+			return err
+		}
+		setPollInterval(r)
+		if watchRedeploy && r.Entry.DeployHook == "" {
+			if err := requireCapability(r, platform.CapRedeploy); err != nil {
+				return fmt.Errorf("%w\nSet deploy_hook on the service to deploy it another way", err)
+			}
+		}
+		if watchAutoRollback {
+			if err := requireCapability(r, platform.CapRollback); err != nil {
+				return fmt.Errorf("%w\nDrop --auto-rollback to watch it", err)
+			}
+		}
+		contexts = append(contexts, serviceContext{resolved: r, name: name, informational: required != nil && !required[name]})
 	}
+	if watchDeploy != "" {
+		if watchDeploy, err = resolveDeployID(cmd.Context(), contexts[0].resolved, watchDeploy); err != nil {
+			return err
+		}
+	}
+	// From here on results are printed; a failure shouldn't add usage.
+	cmd.SilenceUsage = true
 
 	// Single service — simple path
 	if len(contexts) == 1 {
-		result := watchSingleService(contexts[0].resolved, projectName, time.Duration(watchTimeout)*time.Second)
-		if watchFormat == "json" {
+		result := watchSingleService(cmd.Context(), contexts[0].resolved, projectName, time.Duration(watchTimeout)*time.Second)
+		checkArtifactSize(cmd.Context(), projectName, contexts[0].resolved, &result, cfg.Thresholds.SizeGrowthPercent)
+		if watchVerify {
+			verifyDeploy(cmd.Context(), contexts[0].resolved, &result)
+		}
+		if watchAutoRollback {
+			autoRollback(cmd.Context(), projectName, contexts[0].resolved, &result, time.Duration(watchTimeout)*time.Second)
+		}
+		switch watchFormat {
+		case "json":
 			printWatchJSON(result)
+		case "ndjson":
+			printWatchNDJSON([]watchResult{result})
 		}
+		watchProgress.result([]watchResult{result})
+		reportWatchResults(cfg, key, projectName, []watchResult{result}, result.ExitCode)
+		cmd.SilenceErrors = true
 		return exitCodeFromResult(result)
 	}
 
 	// Multiple services — parallel watch
-	results := watchMultipleServices(contexts, projectName, time.Duration(watchTimeout)*time.Second)
-
-	if watchFormat == "json" {
-		printWatchMultiJSON(results)
-	}
-
-	// Determine overall exit code: failed > timeout > no_deployment > success
-	worstCode := exitSuccess
-	for _, r := range results {
-		if r.ExitCode > worstCode {
-			worstCode = r.ExitCode
+	results := watchMultipleServices(cmd.Context(), contexts, projectName, time.Duration(watchTimeout)*time.Second, policy)
+	for i := range results {
+		checkArtifactSize(cmd.Context(), projectName, contexts[i].resolved, &results[i], cfg.Thresholds.SizeGrowthPercent)
+		if watchVerify {
+			verifyDeploy(cmd.Context(), contexts[i].resolved, &results[i])
 		}
-	}
-	// Spec: if any failed → exit 1 (takes priority)
-	for _, r := range results {
-		if r.ExitCode == exitFailed {
-			worstCode = exitFailed
-			break
+		if watchAutoRollback {
+			autoRollback(cmd.Context(), projectName, contexts[i].resolved, &results[i], time.Duration(watchTimeout)*time.Second)
 		}
 	}
 
+	switch watchFormat {
+	case "json":
+		printWatchMultiJSON(results)
+	case "ndjson":
+		printWatchNDJSON(results)
+	}
+	worstCode := overallExitCode(results, policy)
+	watchProgress.result(results)
+	reportWatchResults(cfg, key, projectName, results, worstCode)
+
 	if worstCode == exitSuccess {
 		return nil
 	}
@@ -173,52 +407,71 @@ func runWatch(cmd *cobra.Command, args []string) error {
 	return &ExitCodeError{Code: worstCode, Msg: ""}
 }
 
-func watchSingleService(resolved *resolvedService, projectName string, timeout time.Duration) watchResult {
+func watchSingleService(ctx context.Context, resolved *resolvedService, projectName string, timeout time.Duration) watchResult {
+	// Stop the platform watcher when we return, whatever the reason.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	result := watchResult{
 		ServiceName: resolved.Entry.Name,
 		Platform:    resolved.Entry.Platform,
+		Optional:    resolved.Entry.Optional,
 	}
 
-	isJSON := watchFormat == "json"
+	isJSON := machineFormat()
+	prog := newProgress(isJSON)
+	sections := newGitLabSections(watchFormat == "gitlab", resolved.Entry.Name)
+	defer sections.close()
 
-	// Get last 2 deployments to handle the race condition where
-	// git push triggers a deployment before watch starts.
-	deploys, err := resolved.Platform.ListDeployments(resolved.Entry.ID, 2)
+	// Get recent deployments to handle the race condition where
+	// git push triggers a deployment before watch starts, and to
+	// estimate how long a build usually takes.
+	deploys, err := resolved.Platform.ListDeployments(ctx, resolved.Entry.ID, etaSamples)
 	if err != nil {
 		result.ExitCode = exitFailed
 		result.Error = fmt.Sprintf("list deployments: %s", err)
+		result.ErrorKind = platform.ErrorKind(err)
 		if !isJSON {
 			fmt.Printf("%s Error: %s\n", ui.IconFailed, result.Error)
 		}
 		return result
 	}
 
-	currentDeployID := ""
-	if len(deploys) > 0 {
-		latest := deploys[0]
-		// If the latest deployment was created recently (within 3 minutes),
-		// it's likely from the push that triggered this watch.
-		// Use the previous deployment as baseline so WatchDeployment detects it as new.
-		if time.Since(latest.CreatedAt) < 3*time.Minute && len(deploys) > 1 {
-			currentDeployID = deploys[1].ID
-		} else {
-			currentDeployID = latest.ID
-		}
+	currentDeployID := baselineDeployID(deploys)
+	result.PrevDeployID = currentDeployID
+
+	prog.printf("%s Watching %s (%s)...", ui.IconWatch, resolved.Entry.Name, resolved.Entry.Platform)
+	switch {
+	case watchDeploy != "":
+		prog.printf(" (deploy: %s)", shortID(watchDeploy))
+	case watchCommit != "":
+		prog.printf(" (commit: %s)", ui.FormatCommit(watchCommit))
+	case currentDeployID != "":
+		prog.printf(" (current: %s)", shortID(currentDeployID))
 	}
-
-	if !isJSON {
-		fmt.Printf("%s Watching %s (%s)...", ui.IconWatch, resolved.Entry.Name, resolved.Entry.Platform)
-		if currentDeployID != "" {
-			fmt.Printf(" (current: %s)", shortID(currentDeployID))
+	prog.printf("\n")
+
+	deployID := watchDeploy
+	if watchRedeploy {
+		prog.printf("%s Triggering a deploy...\n", ui.IconDeploy)
+		if deployID, err = triggerDeploy(ctx, resolved); err != nil {
+			result.ExitCode = exitFailed
+			result.Error = err.Error()
+			result.ErrorKind = platform.ErrorKind(err)
+			if !isJSON {
+				fmt.Printf("%s Error: %s\n", ui.IconFailed, result.Error)
+			}
+			return result
 		}
-		fmt.Println()
 	}
+	sections.enter("detect", "Waiting for new deployment")
 
 	// Start watching
-	ch, err := resolved.Platform.WatchDeployment(resolved.Entry.ID, currentDeployID)
+	ch, err := deployEvents(ctx, resolved, currentDeployID, deployID)
 	if err != nil {
 		result.ExitCode = exitFailed
 		result.Error = fmt.Sprintf("watch: %s", err)
+		result.ErrorKind = platform.ErrorKind(err)
 		if !isJSON {
 			fmt.Printf("%s Error: %s\n", ui.IconFailed, result.Error)
 		}
@@ -226,23 +479,55 @@ func watchSingleService(resolved *resolvedService, projectName string, timeout t
 	}
 
 	overallDeadline := time.After(timeout)
-	detectDeadline := time.After(detectTimeout)
+	detectDeadline := time.After(watchDetectTimeout)
 	detected := false
 	startTime := time.Now()
+	waitReported := 0 // 15s periods of waiting reported so far
+	var buildLogs <-chan platform.LogEntry
+	var healthDeadline <-chan time.Time // --rollback-grace, once built
+	buildLog := func(e platform.LogEntry) {
+		addBuildLog(projectName, &result, e)
+		prog.printf("   %s %s\n", ui.MutedStyle.Render("│"), e.Message)
+	}
+
+	estimate := estimateBuildDuration(deploys)
+	deployStart := startTime
+	progressTicker := time.NewTicker(progressInterval)
+	defer progressTicker.Stop()
+
+	// progressSuffix returns " <bar> ~Xs remaining" while a deploy is running.
+	progressSuffix := func() string {
+		if p := estimate.progress(time.Since(deployStart)); p != "" {
+			return " " + p
+		}
+		return ""
+	}
 
 	for {
 		select {
+		case <-progressTicker.C:
+			if !detected || estimate.samples == 0 {
+				continue
+			}
+			elapsed := int(time.Since(startTime).Seconds())
+			switch result.Phase {
+			case "building":
+				prog.printf("%s Building... (%ds)%s\n", ui.IconBuilding, elapsed, progressSuffix())
+			case "deploying":
+				prog.printf("%s Deploying... (%ds)%s\n", ui.IconDeploy, elapsed, progressSuffix())
+			}
+
 		case <-detectDeadline:
 			if !detected {
 				elapsed := int(time.Since(startTime).Seconds())
 				result.ExitCode = exitNoDeployment
 				result.WaitedSec = elapsed
-				result.Error = "No new deployment detected"
+				result.Error = noDeploymentError()
 				if currentDeployID != "" {
 					result.DeployID = currentDeployID
 				}
 				if !isJSON {
-					fmt.Printf("\n%s No new deployment detected after %ds.\n", ui.IconWarning, elapsed)
+					fmt.Printf("\n%s %s after %ds.\n", ui.IconWarning, noDeploymentError(), elapsed)
 					if currentDeployID != "" {
 						fmt.Printf("\n  Current: %s\n", shortID(currentDeployID))
 					}
@@ -250,6 +535,9 @@ func watchSingleService(resolved *resolvedService, projectName string, timeout t
 					fmt.Printf("  - Push didn't trigger auto-deploy (check branch settings)\n")
 					fmt.Printf("  - Build queue is backed up\n")
 					fmt.Printf("  - Auto-deploy is disabled for this service\n")
+					if watchCommit != "" {
+						fmt.Printf("  - The commit isn't on the branch this service deploys\n")
+					}
 				}
 				return result
 			}
@@ -259,25 +547,56 @@ func watchSingleService(resolved *resolvedService, projectName string, timeout t
 			if !detected {
 				result.ExitCode = exitNoDeployment
 				result.WaitedSec = elapsed
-				result.Error = "No new deployment detected"
+				result.Error = noDeploymentError()
 			} else {
 				result.ExitCode = exitTimeout
 				result.Error = fmt.Sprintf("Deploy still in progress after %ds", elapsed)
 			}
 			if !isJSON {
 				if !detected {
-					fmt.Printf("\n%s No new deployment detected after %ds.\n", ui.IconWarning, elapsed)
+					fmt.Printf("\n%s %s after %ds.\n", ui.IconWarning, noDeploymentError(), elapsed)
 				} else {
-					fmt.Printf("\n%s Timeout! Deploy still in progress after %ds.\n", "⏰", elapsed)
+					fmt.Printf("\n%s Timeout! Deploy still in progress after %ds.\n", ui.IconTimeout, elapsed)
 					if result.DeployID != "" {
 						fmt.Printf("\n  Deploy:  %s\n", shortID(result.DeployID))
 						fmt.Printf("  Phase:   %s (still running)\n", result.Phase)
 						fmt.Printf("\n  Continue watching: orbit watch %s --service %s\n", projectName, resolved.Entry.Name)
+						if resolved.Platform.Capabilities().Has(platform.CapCancel) {
+							fmt.Printf("  Cancel it:         orbit cancel %s --service %s\n", projectName, resolved.Entry.Name)
+						}
 					}
 				}
 			}
 			return result
 
+		case <-healthDeadline:
+			result.ExitCode = exitTimeout
+			result.Duration = time.Since(startTime)
+			result.Error = healthGraceError()
+			drainBuildLogs(buildLogs, buildLog)
+			sections.close()
+			if !isJSON {
+				fmt.Printf("\n%s %s.\n", ui.IconTimeout, result.Error)
+				fmt.Printf("\n  Deploy:  %s\n", shortID(result.DeployID))
+				fmt.Printf("  Phase:   %s\n", result.Phase)
+			}
+			return result
+
+		case <-ctx.Done():
+			result.ExitCode = exitFailed
+			result.Error = "Watch interrupted"
+			if !isJSON {
+				fmt.Printf("\n%s Watch interrupted.\n", ui.IconWarning)
+			}
+			return result
+
+		case e, ok := <-buildLogs:
+			if !ok {
+				buildLogs = nil
+				continue
+			}
+			buildLog(e)
+
 		case event, ok := <-ch:
 			if !ok {
 				// Channel closed — should not happen without a terminal event
@@ -287,61 +606,87 @@ func watchSingleService(resolved *resolvedService, projectName string, timeout t
 				}
 				return result
 			}
+			emitWatchEvent(projectName, resolved.Entry.Name, event, startTime)
 
 			switch event.Phase {
 			case "waiting":
 				elapsed := int(time.Since(startTime).Seconds())
-				if !isJSON && elapsed > 0 && elapsed%15 == 0 {
-					fmt.Printf("%s Waiting... (%ds)\n", ui.IconWatch, elapsed)
+				if elapsed/15 > waitReported {
+					waitReported = elapsed / 15
+					prog.printf("%s Waiting... (%ds)\n", ui.IconWatch, elapsed)
 				}
 
 			case "detected":
 				detected = true
 				if event.Deploy != nil {
+					// Historical durations are measured from creation, so
+					// measure progress the same way when the platform reports it.
+					if created := event.Deploy.CreatedAt; !created.IsZero() && created.Before(deployStart) {
+						deployStart = created
+					}
 					result.DeployID = event.Deploy.ID
 					result.Commit = event.Deploy.Commit
 					result.Message = event.Deploy.Message
+					result.Branch = event.Deploy.Branch
+					result.Author = event.Deploy.Author
+					result.PullRequest = event.Deploy.PullRequest
+					recordDeployDetected(projectName, resolved.Entry.Name, event.Deploy)
 				}
-				if !isJSON {
-					fmt.Printf("%s New deployment detected! (%s)\n", ui.IconBuilding, shortID(result.DeployID))
-					if result.Commit != "" {
-						msg := result.Message
-						if msg == "" {
-							msg = ""
-						}
-						commitStr := ui.FormatCommit(result.Commit)
-						if msg != "" {
-							fmt.Printf("   Commit: %s %q\n", commitStr, msg)
-						} else {
-							fmt.Printf("   Commit: %s\n", commitStr)
-						}
+				switch {
+				case deployID != "":
+					prog.printf("%s Following deployment %s\n", ui.IconBuilding, shortID(result.DeployID))
+				case watchCommit != "":
+					prog.printf("%s Deployment of %s found! (%s)\n", ui.IconBuilding, ui.FormatCommit(watchCommit), shortID(result.DeployID))
+				default:
+					prog.printf("%s New deployment detected! (%s)\n", ui.IconBuilding, shortID(result.DeployID))
+				}
+				if result.Commit != "" {
+					commitStr := ui.FormatCommit(result.Commit)
+					if result.Message != "" {
+						prog.printf("   Commit: %s %q\n", commitStr, result.Message)
+					} else {
+						prog.printf("   Commit: %s\n", commitStr)
+					}
+				}
+				if result.Branch != "" || result.PullRequest != 0 {
+					branch := ui.FormatBranch(result.Branch, result.PullRequest)
+					if result.Author != "" {
+						prog.printf("   Branch: %s by %s\n", branch, result.Author)
+					} else {
+						prog.printf("   Branch: %s\n", branch)
 					}
 				}
+				if buildLogs, err = startBuildLogs(ctx, resolved, result.DeployID); err != nil {
+					prog.printf("   %s\n", ui.MutedStyle.Render(err.Error()))
+				}
 
 			case "building":
 				result.Phase = "building"
-				if !isJSON {
-					elapsed := int(time.Since(startTime).Seconds())
-					fmt.Printf("%s Building... (%ds)\n", ui.IconBuilding, elapsed)
-				}
+				sections.enter("building", "Building")
+				prog.printf("%s Building... (%ds)%s\n", ui.IconBuilding, int(time.Since(startTime).Seconds()), progressSuffix())
 
 			case "deploying":
 				result.Phase = "deploying"
-				if !isJSON {
-					elapsed := int(time.Since(startTime).Seconds())
-					fmt.Printf("%s Deploying... (%ds)\n", ui.IconDeploy, elapsed)
+				if healthDeadline == nil {
+					healthDeadline = healthGrace()
 				}
+				sections.enter("deploying", "Deploying")
+				prog.printf("%s Deploying... (%ds)%s\n", ui.IconDeploy, int(time.Since(startTime).Seconds()), progressSuffix())
 
 			case "healthcheck":
 				result.Phase = "healthcheck"
-				if !isJSON {
-					fmt.Printf("%s Health check...\n", ui.IconHealth)
+				if healthDeadline == nil {
+					healthDeadline = healthGrace()
 				}
+				sections.enter("healthcheck", "Health check")
+				prog.printf("%s Health check...\n", ui.IconHealth)
 
 			case "done":
 				result.ExitCode = exitSuccess
 				result.Phase = "done"
 				result.Duration = time.Since(startTime)
+				drainBuildLogs(buildLogs, buildLog)
+				sections.close()
 				if event.Deploy != nil {
 					result.Status = event.Deploy.Status
 					result.URL = event.Deploy.URL
@@ -356,6 +701,9 @@ func watchSingleService(resolved *resolvedService, projectName string, timeout t
 					if result.Commit != "" {
 						fmt.Printf("  Commit:   %s\n", ui.FormatCommit(result.Commit))
 					}
+					if result.Branch != "" || result.PullRequest != 0 {
+						fmt.Printf("  Branch:   %s\n", ui.FormatBranch(result.Branch, result.PullRequest))
+					}
 					fmt.Printf("  Duration: %ds\n", int(result.Duration.Seconds()))
 					fmt.Printf("  Status:   %s\n", ui.FormatStatus("healthy"))
 					if result.URL != "" {
@@ -370,14 +718,22 @@ func watchSingleService(resolved *resolvedService, projectName string, timeout t
 				result.Duration = time.Since(startTime)
 				if event.Error != nil {
 					result.Error = event.Error.Error()
+					result.ErrorKind = platform.ErrorKind(event.Error)
 				}
 				result.Logs = event.Logs
+				drainBuildLogs(buildLogs, buildLog)
+				sections.close()
 				if event.Deploy != nil {
 					result.Status = event.Deploy.Status
 					if result.DeployID == "" {
 						result.DeployID = event.Deploy.ID
 					}
 				}
+				if !isJSON && result.DeployID == "" {
+					// The platform failed before there was a deployment to report.
+					fmt.Printf("%s Error: %s\n", ui.IconFailed, result.Error)
+					return result
+				}
 				if !isJSON {
 					fmt.Printf("%s Build failed! (%ds)\n", ui.IconFailed, int(result.Duration.Seconds()))
 					fmt.Println()
@@ -402,67 +758,291 @@ func watchSingleService(resolved *resolvedService, projectName string, timeout t
 	}
 }
 
-func watchMultipleServices(contexts []serviceContext, projectName string, timeout time.Duration) []watchResult {
+func watchMultipleServices(ctx context.Context, contexts []serviceContext, projectName string, timeout time.Duration, policy watchExitPolicy) []watchResult {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	// failed cancels the other watches with --fail-fast once r fails.
+	failed := func(r watchResult) {
+		if watchFailFast && r.ExitCode == exitFailed && !r.Cancelled && !policy.ignored(r) {
+			cancel(fmt.Errorf("%w: %s failed", errFailFast, r.ServiceName))
+		}
+	}
+
+	if watchFormat == "" && term.IsTerminal(int(os.Stderr.Fd())) && term.IsTerminal(int(os.Stdin.Fd())) {
+		return watchMultipleLive(ctx, contexts, projectName, timeout, failed)
+	}
 	results := make([]watchResult, len(contexts))
 	var wg sync.WaitGroup
 
-	isJSON := watchFormat == "json"
+	isJSON := machineFormat()
 	var mu sync.Mutex // protects stdout for text mode
 
-	for i, ctx := range contexts {
+	for i, sc := range contexts {
 		wg.Add(1)
-		go func(idx int, r *resolvedService, svcName string) {
+		go func(idx int, sc serviceContext) {
 			defer wg.Done()
-			res := watchSingleServiceQuiet(r, timeout)
+			res := watchSingleServiceQuiet(ctx, sc.resolved, projectName, timeout)
+			res.Informational = sc.informational
 			results[idx] = res
+			failed(res)
 
-			if !isJSON {
-				mu.Lock()
-				printServiceResult(projectName, svcName, res)
-				mu.Unlock()
+			// With JSON the per-service lines are progress; the
+			// result is the JSON printed at the end.
+			w := io.Writer(os.Stdout)
+			if isJSON {
+				w = newProgress(true).w
 			}
-		}(i, ctx.resolved, ctx.name)
+			mu.Lock()
+			printServiceResult(w, projectName, sc.name, res)
+			mu.Unlock()
+		}(i, sc)
 	}
 
 	wg.Wait()
 	return results
 }
 
+// watchMultipleLive watches services in parallel under a live view with a
+// line per service, updated in place, and prints the results once every
+// service has finished. Ctrl+C stops the watchers.
+func watchMultipleLive(ctx context.Context, contexts []serviceContext, projectName string, timeout time.Duration, failed func(watchResult)) []watchResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rows := make([]ui.WatchRow, len(contexts))
+	for i, sc := range contexts {
+		rows[i] = ui.WatchRow{Service: sc.name, Platform: sc.resolved.Entry.Platform, Phase: "waiting"}
+	}
+	p := tea.NewProgram(ui.NewWatchViewModel(projectName, rows, cancel), tea.WithOutput(os.Stderr))
+	watchLive = p
+	defer func() { watchLive = nil }()
+
+	results := make([]watchResult, len(contexts))
+	var wg sync.WaitGroup
+	for i, sc := range contexts {
+		wg.Add(1)
+		go func(idx int, sc serviceContext) {
+			defer wg.Done()
+			res := watchSingleServiceQuiet(ctx, sc.resolved, projectName, timeout)
+			res.Informational = sc.informational
+			results[idx] = res
+			failed(res)
+			p.Send(watchRowResult(sc.name, res))
+		}(i, sc)
+	}
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s live view unavailable: %v\n", ui.IconWarning, err)
+	}
+	wg.Wait()
+
+	for i, sc := range contexts {
+		printServiceResult(os.Stdout, projectName, sc.name, results[i])
+	}
+	return results
+}
+
+// watchRowResult is the final live view row for a service's result.
+func watchRowResult(svcName string, r watchResult) ui.WatchRowMsg {
+	row := ui.WatchRowMsg{
+		Service:  svcName,
+		Phase:    resultToJSON(r).Result,
+		DeployID: r.DeployID,
+		Commit:   r.Commit,
+		Finished: true,
+		Elapsed:  r.Duration,
+	}
+	if r.Duration == 0 {
+		row.Elapsed = time.Duration(r.WaitedSec) * time.Second
+	}
+	if r.ExitCode != exitSuccess {
+		row.Message = r.Error
+	}
+	return row
+}
+
 // watchSingleServiceQuiet watches without printing — for parallel use.
-func watchSingleServiceQuiet(resolved *resolvedService, timeout time.Duration) watchResult {
+func watchSingleServiceQuiet(ctx context.Context, resolved *resolvedService, projectName string, timeout time.Duration) watchResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	result := watchResult{
 		ServiceName: resolved.Entry.Name,
 		Platform:    resolved.Entry.Platform,
+		Optional:    resolved.Entry.Optional,
 	}
 
-	deploys, err := resolved.Platform.ListDeployments(resolved.Entry.ID, 2)
+	deploys, err := resolved.Platform.ListDeployments(ctx, resolved.Entry.ID, 2)
 	if err != nil {
+		if ctx.Err() != nil {
+			return interruptedResult(ctx, result)
+		}
 		result.ExitCode = exitFailed
 		result.Error = fmt.Sprintf("list deployments: %s", err)
+		result.ErrorKind = platform.ErrorKind(err)
 		return result
 	}
 
-	currentDeployID := ""
-	if len(deploys) > 0 {
-		latest := deploys[0]
-		if time.Since(latest.CreatedAt) < 3*time.Minute && len(deploys) > 1 {
-			currentDeployID = deploys[1].ID
-		} else {
-			currentDeployID = latest.ID
+	result.PrevDeployID = baselineDeployID(deploys)
+
+	deployID := ""
+	if watchRedeploy {
+		if deployID, err = triggerDeploy(ctx, resolved); err != nil {
+			result.ExitCode = exitFailed
+			result.Error = err.Error()
+			result.ErrorKind = platform.ErrorKind(err)
+			return result
+		}
+	}
+	return followDeployment(ctx, resolved, projectName, result, deployID, timeout)
+}
+
+// baselineDeployID picks the deployment a watch waits to be replaced, from
+// the latest deployments, newest first. A deploy created in the last three
+// minutes is likely the one the push that started the watch triggered, so
+// the one before it is the baseline and the watch detects it as new. With
+// --redeploy nothing has been triggered yet and the latest is the baseline,
+// and with --deploy it is the deployment before the one followed.
+func baselineDeployID(deploys []platform.Deployment) string {
+	if len(deploys) == 0 {
+		return ""
+	}
+	if watchDeploy != "" {
+		for i, d := range deploys[:len(deploys)-1] {
+			if d.ID == watchDeploy {
+				return deploys[i+1].ID
+			}
+		}
+	}
+	if !watchRedeploy && time.Since(deploys[0].CreatedAt) < 3*time.Minute && len(deploys) > 1 {
+		return deploys[1].ID
+	}
+	return deploys[0].ID
+}
+
+// deployEvents starts following a deployment: deployID when it is known,
+// otherwise the deployment of --commit, or else the first deployment after
+// baseline.
+func deployEvents(ctx context.Context, resolved *resolvedService, baseline, deployID string) (<-chan platform.DeployEvent, error) {
+	if deployID != "" {
+		return platform.TrackDeployment(ctx, resolved.Platform, deployID, watchPollInterval), nil
+	}
+	if watchCommit != "" {
+		return platform.WatchCommit(ctx, resolved.Platform, resolved.Entry.ID, watchCommit, watchPollInterval), nil
+	}
+	return resolved.Platform.WatchDeployment(ctx, resolved.Entry.ID, baseline)
+}
+
+// buildLogTail is how many lines of build output a watch result keeps.
+const buildLogTail = 200
+
+// startBuildLogs starts streaming the build output of deployID with --logs.
+// The channel is nil when --logs is off or the platform can't stream it; the
+// error says why not.
+func startBuildLogs(ctx context.Context, resolved *resolvedService, deployID string) (<-chan platform.LogEntry, error) {
+	if !watchLogs || deployID == "" {
+		return nil, nil
+	}
+	streamer, ok := resolved.Platform.(platform.BuildLogStreamer)
+	if !ok {
+		return nil, fmt.Errorf("%s can't stream build logs", resolved.Entry.Platform)
+	}
+	ch, err := streamer.StreamBuildLogs(ctx, resolved.Entry.ID, deployID)
+	if err != nil {
+		return nil, fmt.Errorf("build logs unavailable: %w", err)
+	}
+	return ch, nil
+}
+
+// buildLogIdle is how long a finished watch waits for more build output
+// before it stops reading.
+const buildLogIdle = time.Second
+
+// drainBuildLogs passes on the build output still arriving once the deploy
+// has ended, until the stream ends or goes quiet for buildLogIdle.
+func drainBuildLogs(ch <-chan platform.LogEntry, fn func(platform.LogEntry)) {
+	if ch == nil {
+		return
+	}
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			fn(e)
+		case <-time.After(buildLogIdle):
+			return
 		}
 	}
+}
+
+// addBuildLog keeps a line of build output in r and prints it as an NDJSON
+// line with --format ndjson.
+func addBuildLog(projectName string, r *watchResult, e platform.LogEntry) {
+	r.BuildLogs = append(r.BuildLogs, e.Message)
+	if n := len(r.BuildLogs); n > buildLogTail {
+		r.BuildLogs = r.BuildLogs[n-buildLogTail:]
+	}
+	if watchFormat == "ndjson" {
+		writeNDJSON(watchLogJSON{
+			Type:     "log",
+			Time:     e.Timestamp.UTC(),
+			Project:  projectName,
+			Service:  r.ServiceName,
+			DeployID: r.DeployID,
+			Level:    entryLevel(e),
+			Message:  e.Message,
+		})
+	}
+}
+
+// noDeploymentError describes a watch that saw no deployment to follow.
+func noDeploymentError() string {
+	if watchCommit != "" {
+		return fmt.Sprintf("No deployment of commit %s detected", ui.FormatCommit(watchCommit))
+	}
+	return "No new deployment detected"
+}
 
-	ch, err := resolved.Platform.WatchDeployment(resolved.Entry.ID, currentDeployID)
+// errFailFast is the cause of the cancellation --fail-fast makes.
+var errFailFast = errors.New("cancelled by --fail-fast")
+
+// interruptedResult finishes result for a watch whose context was
+// cancelled: by --fail-fast after another service failed, or by the user.
+func interruptedResult(ctx context.Context, result watchResult) watchResult {
+	if cause := context.Cause(ctx); errors.Is(cause, errFailFast) {
+		result.ExitCode = exitTimeout
+		result.Cancelled = true
+		_, reason, _ := strings.Cut(cause.Error(), ": ")
+		result.Error = "Cancelled: " + reason
+		return result
+	}
+	result.ExitCode = exitFailed
+	result.Error = "Watch interrupted"
+	return result
+}
+
+// followDeployment follows deployID, or when it is empty waits for a
+// deployment newer than result.PrevDeployID, until it is done or fails,
+// without printing.
+func followDeployment(ctx context.Context, resolved *resolvedService, projectName string, result watchResult, deployID string, timeout time.Duration) watchResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch, err := deployEvents(ctx, resolved, result.PrevDeployID, deployID)
 	if err != nil {
 		result.ExitCode = exitFailed
 		result.Error = fmt.Sprintf("watch: %s", err)
+		result.ErrorKind = platform.ErrorKind(err)
 		return result
 	}
 
 	overallDeadline := time.After(timeout)
-	detectDeadline := time.After(detectTimeout)
+	detectDeadline := time.After(watchDetectTimeout)
 	detected := false
 	startTime := time.Now()
+	var buildLogs <-chan platform.LogEntry
+	var healthDeadline <-chan time.Time // --rollback-grace, once built
 
 	for {
 		select {
@@ -470,7 +1050,7 @@ func watchSingleServiceQuiet(resolved *resolvedService, timeout time.Duration) w
 			if !detected {
 				result.ExitCode = exitNoDeployment
 				result.WaitedSec = int(time.Since(startTime).Seconds())
-				result.Error = "No new deployment detected"
+				result.Error = noDeploymentError()
 				return result
 			}
 
@@ -479,21 +1059,41 @@ func watchSingleServiceQuiet(resolved *resolvedService, timeout time.Duration) w
 			if !detected {
 				result.ExitCode = exitNoDeployment
 				result.WaitedSec = elapsed
-				result.Error = "No new deployment detected"
+				result.Error = noDeploymentError()
 			} else {
 				result.ExitCode = exitTimeout
 				result.Error = fmt.Sprintf("Deploy still in progress after %ds", elapsed)
 			}
 			return result
 
+		case <-healthDeadline:
+			result.ExitCode = exitTimeout
+			result.Duration = time.Since(startTime)
+			result.Error = healthGraceError()
+			return result
+
+		case <-ctx.Done():
+			return interruptedResult(ctx, result)
+
+		case e, ok := <-buildLogs:
+			if !ok {
+				buildLogs = nil
+				continue
+			}
+			addBuildLog(projectName, &result, e)
+
 		case event, ok := <-ch:
 			if !ok {
+				if ctx.Err() != nil {
+					return interruptedResult(ctx, result)
+				}
 				if result.ExitCode == 0 && !detected {
 					result.ExitCode = exitNoDeployment
 					result.Error = "Watch ended unexpectedly"
 				}
 				return result
 			}
+			emitWatchEvent(projectName, resolved.Entry.Name, event, startTime)
 
 			switch event.Phase {
 			case "detected":
@@ -502,13 +1102,19 @@ func watchSingleServiceQuiet(resolved *resolvedService, timeout time.Duration) w
 					result.DeployID = event.Deploy.ID
 					result.Commit = event.Deploy.Commit
 					result.Message = event.Deploy.Message
+					result.Branch = event.Deploy.Branch
+					result.Author = event.Deploy.Author
+					result.PullRequest = event.Deploy.PullRequest
+					recordDeployDetected(projectName, resolved.Entry.Name, event.Deploy)
+					buildLogs, _ = startBuildLogs(ctx, resolved, result.DeployID)
 				}
 			case "building":
 				result.Phase = "building"
-			case "deploying":
-				result.Phase = "deploying"
-			case "healthcheck":
-				result.Phase = "healthcheck"
+			case "deploying", "healthcheck":
+				result.Phase = event.Phase
+				if healthDeadline == nil {
+					healthDeadline = healthGrace()
+				}
 			case "done":
 				result.ExitCode = exitSuccess
 				result.Phase = "done"
@@ -517,75 +1123,123 @@ func watchSingleServiceQuiet(resolved *resolvedService, timeout time.Duration) w
 					result.Status = event.Deploy.Status
 					result.URL = event.Deploy.URL
 				}
+				drainBuildLogs(buildLogs, func(e platform.LogEntry) { addBuildLog(projectName, &result, e) })
 				return result
 			case "failed":
+				if ctx.Err() != nil {
+					// The platform call failed because the watch was stopped.
+					return interruptedResult(ctx, result)
+				}
 				result.ExitCode = exitFailed
 				result.Phase = event.Phase
 				result.Duration = time.Since(startTime)
 				if event.Error != nil {
 					result.Error = event.Error.Error()
+					result.ErrorKind = platform.ErrorKind(event.Error)
 				}
 				result.Logs = event.Logs
+				drainBuildLogs(buildLogs, func(e platform.LogEntry) { addBuildLog(projectName, &result, e) })
 				return result
 			}
 		}
 	}
 }
 
-func printServiceResult(projectName, svcName string, r watchResult) {
-	fmt.Printf("\n── %s/%s (%s) ", projectName, svcName, r.Platform)
+func printServiceResult(w io.Writer, projectName, svcName string, r watchResult) {
+	platformNote := r.Platform
+	if r.Optional {
+		platformNote += ", optional"
+	}
+	if r.Informational {
+		platformNote += ", informational"
+	}
+	fmt.Fprintf(w, "\n── %s/%s (%s) ", projectName, svcName, platformNote)
+	if r.Cancelled {
+		fmt.Fprintln(w, ui.MutedStyle.Render("CANCELLED"))
+		fmt.Fprintf(w, "  %s\n", r.Error)
+		return
+	}
 	switch r.ExitCode {
 	case exitSuccess:
-		fmt.Println(ui.HealthyStyle.Render("SUCCESS"))
-		fmt.Printf("  Deploy: %s  Duration: %ds\n", shortID(r.DeployID), int(r.Duration.Seconds()))
+		fmt.Fprintln(w, ui.HealthyStyle.Render("SUCCESS"))
+		fmt.Fprintf(w, "  Deploy: %s  Duration: %ds", shortID(r.DeployID), int(r.Duration.Seconds()))
+		if r.Branch != "" || r.PullRequest != 0 {
+			fmt.Fprintf(w, "  Branch: %s", ui.FormatBranch(r.Branch, r.PullRequest))
+		}
+		fmt.Fprintln(w)
 	case exitFailed:
-		fmt.Println(ui.ErrorStyle.Render("FAILED"))
+		fmt.Fprintln(w, ui.ErrorStyle.Render("FAILED"))
 		if r.Error != "" {
-			fmt.Printf("  %s\n", r.Error)
+			fmt.Fprintf(w, "  %s\n", r.Error)
 		}
 	case exitNoDeployment:
-		fmt.Println(ui.WarningStyle.Render("NO DEPLOYMENT"))
-		fmt.Printf("  Waited %ds, no new deployment detected\n", r.WaitedSec)
+		fmt.Fprintln(w, ui.WarningStyle.Render("NO DEPLOYMENT"))
+		fmt.Fprintf(w, "  Waited %ds, no new deployment detected\n", r.WaitedSec)
 	case exitTimeout:
-		fmt.Println(ui.WarningStyle.Render("TIMEOUT"))
-		fmt.Printf("  Phase: %s (still running)\n", r.Phase)
+		fmt.Fprintln(w, ui.WarningStyle.Render("TIMEOUT"))
+		fmt.Fprintf(w, "  Phase: %s (still running)\n", r.Phase)
 	}
 }
 
 // --- JSON output ---
 
 type watchJSON struct {
-	Result          string   `json:"result"`
-	Service         string   `json:"service,omitempty"`
-	Platform        string   `json:"platform,omitempty"`
-	DeployID        string   `json:"deploy_id,omitempty"`
-	Commit          string   `json:"commit,omitempty"`
-	DurationSec     int      `json:"duration_sec,omitempty"`
-	Status          string   `json:"status,omitempty"`
-	Phase           string   `json:"phase,omitempty"`
-	URL             string   `json:"url,omitempty"`
-	Error           string   `json:"error,omitempty"`
-	Logs            []string `json:"logs,omitempty"`
-	CurrentDeployID string   `json:"current_deploy_id,omitempty"`
-	WaitedSec       int      `json:"waited_sec,omitempty"`
-	Reason          string   `json:"reason,omitempty"`
-	ElapsedSec      int      `json:"elapsed_sec,omitempty"`
+	Result          string             `json:"result"`
+	Service         string             `json:"service,omitempty"`
+	Platform        string             `json:"platform,omitempty"`
+	Optional        bool               `json:"optional,omitempty"`
+	Informational   bool               `json:"informational,omitempty"`
+	DeployID        string             `json:"deploy_id,omitempty"`
+	Commit          string             `json:"commit,omitempty"`
+	Branch          string             `json:"branch,omitempty"`
+	Author          string             `json:"author,omitempty"`
+	PullRequest     int                `json:"pull_request,omitempty"`
+	DurationSec     int                `json:"duration_sec,omitempty"`
+	Status          string             `json:"status,omitempty"`
+	Phase           string             `json:"phase,omitempty"`
+	URL             string             `json:"url,omitempty"`
+	Error           string             `json:"error,omitempty"`
+	ErrorKind       string             `json:"error_kind,omitempty"`
+	Logs            []string           `json:"logs,omitempty"`
+	BuildLogs       []string           `json:"build_logs,omitempty"`
+	SizeBytes       int64              `json:"size_bytes,omitempty"`
+	SizeChangePct   *float64           `json:"size_change_pct,omitempty"`
+	Smoke           []jsonSmokeCheck   `json:"smoke,omitempty"`
+	CurrentDeployID string             `json:"current_deploy_id,omitempty"`
+	WaitedSec       int                `json:"waited_sec,omitempty"`
+	Reason          string             `json:"reason,omitempty"`
+	ElapsedSec      int                `json:"elapsed_sec,omitempty"`
+	Rollback        *watchRollbackJSON `json:"rollback,omitempty"`
 }
 
 func resultToJSON(r watchResult) watchJSON {
 	j := watchJSON{
-		Service:  r.ServiceName,
-		Platform: r.Platform,
-		DeployID: r.DeployID,
-		Commit:   r.Commit,
-		Status:   r.Status,
-		URL:      r.URL,
+		Service:       r.ServiceName,
+		Platform:      r.Platform,
+		Optional:      r.Optional,
+		Informational: r.Informational,
+		DeployID:      r.DeployID,
+		Commit:        r.Commit,
+		Branch:        r.Branch,
+		Author:        r.Author,
+		PullRequest:   r.PullRequest,
+		Status:        r.Status,
+		URL:           r.URL,
+		BuildLogs:     r.BuildLogs,
+	}
+	if r.Smoke != nil {
+		j.Smoke = smokeChecksToJSON(r.Smoke)
 	}
 
 	switch r.ExitCode {
 	case exitSuccess:
 		j.Result = "success"
 		j.DurationSec = int(r.Duration.Seconds())
+		j.SizeBytes = r.Size
+		if r.Size > 0 && r.PrevSize > 0 {
+			pct := sizeChangePct(r.PrevSize, r.Size)
+			j.SizeChangePct = &pct
+		}
 		if j.Status == "" {
 			j.Status = "healthy"
 		}
@@ -594,6 +1248,7 @@ func resultToJSON(r watchResult) watchJSON {
 		j.DurationSec = int(r.Duration.Seconds())
 		j.Phase = r.Phase
 		j.Error = r.Error
+		j.ErrorKind = r.ErrorKind
 		j.Logs = r.Logs
 	case exitNoDeployment:
 		j.Result = "no_deployment"
@@ -609,6 +1264,11 @@ func resultToJSON(r watchResult) watchJSON {
 			j.ElapsedSec = r.WaitedSec
 		}
 	}
+	if r.Cancelled {
+		j.Result = "cancelled"
+		j.Reason = r.Error
+	}
+	j.Rollback = r.Rollback.toJSON()
 
 	return j
 }
@@ -620,16 +1280,268 @@ func printWatchJSON(r watchResult) {
 }
 
 func printWatchMultiJSON(results []watchResult) {
+	data, _ := json.MarshalIndent(resultsToJSON(results), "", "  ")
+	fmt.Println(string(data))
+}
+
+// watchEventJSON is a --format ndjson line for a phase change of one
+// service's deployment.
+type watchEventJSON struct {
+	Type       string    `json:"type"` // "event"
+	Time       time.Time `json:"time"`
+	Project    string    `json:"project"`
+	Service    string    `json:"service"`
+	Phase      string    `json:"phase"`
+	DeployID   string    `json:"deploy_id,omitempty"`
+	Commit     string    `json:"commit,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	ElapsedSec int       `json:"elapsed_sec"`
+}
+
+// watchLogJSON is a --format ndjson line of build output with --logs.
+type watchLogJSON struct {
+	Type     string    `json:"type"` // "log"
+	Time     time.Time `json:"time"`
+	Project  string    `json:"project"`
+	Service  string    `json:"service"`
+	DeployID string    `json:"deploy_id,omitempty"`
+	Level    string    `json:"level,omitempty"`
+	Message  string    `json:"message"`
+}
+
+// watchResultLine is the --format ndjson line for a service's result.
+type watchResultLine struct {
+	Type string `json:"type"` // "result"
+	watchJSON
+}
+
+// ndjsonMu keeps lines from services watched in parallel whole.
+var ndjsonMu sync.Mutex
+
+// emitWatchEvent reports a phase change as an NDJSON line with --format
+// ndjson, and to watchProgress. Waiting events aren't phase changes and are
+// left out.
+func emitWatchEvent(projectName, svcName string, ev platform.DeployEvent, start time.Time) {
+	if ev.Phase == "waiting" {
+		return
+	}
+	watchProgress.phase(svcName, ev)
+	// The live view shows the end of a deploy with the result.
+	if watchLive != nil && ev.Phase != "done" && ev.Phase != "failed" {
+		row := ui.WatchRowMsg{Service: svcName, Phase: ev.Phase}
+		if ev.Deploy != nil {
+			row.DeployID, row.Commit, row.Message = ev.Deploy.ID, ev.Deploy.Commit, ev.Deploy.Message
+		}
+		watchLive.Send(row)
+	}
+	if watchFormat != "ndjson" {
+		return
+	}
+	line := watchEventJSON{
+		Type:       "event",
+		Time:       time.Now().UTC(),
+		Project:    projectName,
+		Service:    svcName,
+		Phase:      ev.Phase,
+		Message:    ev.Message,
+		ElapsedSec: int(time.Since(start).Seconds()),
+	}
+	if ev.Deploy != nil {
+		line.DeployID = ev.Deploy.ID
+		line.Commit = ev.Deploy.Commit
+		line.Status = ev.Deploy.Status
+	}
+	if ev.Error != nil {
+		line.Error = ev.Error.Error()
+	}
+	writeNDJSON(line)
+}
+
+// printWatchNDJSON prints a result line per service.
+func printWatchNDJSON(results []watchResult) {
+	for _, r := range results {
+		writeNDJSON(watchResultLine{Type: "result", watchJSON: resultToJSON(r)})
+	}
+}
+
+func writeNDJSON(v interface{}) {
+	data, _ := json.Marshal(v)
+	ndjsonMu.Lock()
+	defer ndjsonMu.Unlock()
+	os.Stdout.Write(append(data, '\n'))
+}
+
+// machineFormat reports whether --format makes stdout machine-readable,
+// leaving progress to stderr.
+func machineFormat() bool {
+	return watchFormat == "json" || watchFormat == "ndjson"
+}
+
+func resultsToJSON(results []watchResult) []watchJSON {
 	var out []watchJSON
 	for _, r := range results {
 		out = append(out, resultToJSON(r))
 	}
-	data, _ := json.MarshalIndent(out, "", "  ")
-	fmt.Println(string(data))
+	return out
+}
+
+// writeWatchOut writes the JSON result to --out: a single object for one
+// service, an array otherwise — the same shape as --format json.
+func writeWatchOut(path string, results []watchResult) error {
+	if len(results) == 1 {
+		return writeJSONFile(path, resultToJSON(results[0]))
+	}
+	return writeJSONFile(path, resultsToJSON(results))
 }
 
 // --- Helpers ---
 
+// watchResultCodes maps result names, as used in JSON output and the watch
+// config, to exit codes.
+var watchResultCodes = map[string]int{
+	"failed":        exitFailed,
+	"timeout":       exitTimeout,
+	"no_deployment": exitNoDeployment,
+}
+
+// watchExitPolicy turns per-service results into one exit code.
+type watchExitPolicy struct {
+	priority       []int // most severe first
+	optionalIgnore map[int]bool
+}
+
+// applyWatchTimings sets the detect timeout and poll interval from config
+// where their flags weren't given, and checks them.
+func applyWatchTimings(cmd *cobra.Command, wc config.WatchConfig) error {
+	if wc.DetectTimeout != "" && !cmd.Flags().Changed("detect-timeout") {
+		d, err := time.ParseDuration(wc.DetectTimeout)
+		if err != nil {
+			return fmt.Errorf("watch.detect_timeout: invalid duration %q (e.g. 3m)", wc.DetectTimeout)
+		}
+		watchDetectTimeout = d
+	}
+	if wc.PollInterval != "" && !cmd.Flags().Changed("poll-interval") {
+		d, err := time.ParseDuration(wc.PollInterval)
+		if err != nil {
+			return fmt.Errorf("watch.poll_interval: invalid duration %q (e.g. 10s)", wc.PollInterval)
+		}
+		watchPollInterval = d
+	}
+	if watchDetectTimeout <= 0 {
+		return fmt.Errorf("detect timeout must be positive, got %s", watchDetectTimeout)
+	}
+	if watchPollInterval < minPollInterval {
+		return fmt.Errorf("poll interval must be at least %s, got %s", minPollInterval, watchPollInterval)
+	}
+	return nil
+}
+
+// setPollInterval makes the service's platform poll at watchPollInterval.
+func setPollInterval(r *resolvedService) {
+	if pc, ok := r.Platform.(platform.PollIntervalConfigurable); ok {
+		pc.SetPollInterval(watchPollInterval)
+	}
+}
+
+// parseWatchExitPolicy applies defaults to the watch config: failed >
+// timeout > no_deployment, and no_deployment of optional services ignored.
+func parseWatchExitPolicy(wc config.WatchConfig) (watchExitPolicy, error) {
+	policy := watchExitPolicy{
+		priority:       []int{exitFailed, exitTimeout, exitNoDeployment},
+		optionalIgnore: map[int]bool{exitNoDeployment: true},
+	}
+	if len(wc.Priority) > 0 {
+		policy.priority = nil
+		seen := make(map[int]bool)
+		for _, name := range wc.Priority {
+			code, ok := watchResultCodes[name]
+			if !ok {
+				return policy, fmt.Errorf("watch.priority: unknown result %q (want failed, timeout, no_deployment)", name)
+			}
+			if !seen[code] {
+				seen[code] = true
+				policy.priority = append(policy.priority, code)
+			}
+		}
+		if len(policy.priority) != len(watchResultCodes) {
+			return policy, fmt.Errorf("watch.priority must list failed, timeout and no_deployment")
+		}
+	}
+	if wc.OptionalIgnore != nil {
+		policy.optionalIgnore = make(map[int]bool)
+		for _, name := range wc.OptionalIgnore {
+			code, ok := watchResultCodes[name]
+			if !ok {
+				return policy, fmt.Errorf("watch.optional_ignore: unknown result %q (want failed, timeout, no_deployment)", name)
+			}
+			policy.optionalIgnore[code] = true
+		}
+	}
+	return policy, nil
+}
+
+// ignored reports whether r does not count toward the overall exit code.
+func (p watchExitPolicy) ignored(r watchResult) bool {
+	return r.Informational || r.Cancelled || (r.Optional && p.optionalIgnore[r.ExitCode])
+}
+
+// overallExitCode aggregates per-service results: the most severe result by
+// the policy's priority wins, informational results and results of optional
+// services it ignores don't count, and success only when nothing else is
+// left.
+func overallExitCode(results []watchResult, policy watchExitPolicy) int {
+	worstCode := exitSuccess
+	worstRank := len(policy.priority)
+	for _, r := range results {
+		if r.ExitCode == exitSuccess || policy.ignored(r) {
+			continue
+		}
+		for rank, code := range policy.priority {
+			if code == r.ExitCode && rank < worstRank {
+				worstCode, worstRank = code, rank
+			}
+		}
+	}
+	return worstCode
+}
+
+// reportWatchResults writes the --out file and publishes results to configured
+// CI integrations (GitHub PR comment, GitLab/Bitbucket commit statuses and dotenv report).
+// Failures are reported on stderr and never change the watch exit code.
+func reportWatchResults(cfg *config.Config, key []byte, projectName string, results []watchResult, overall int) {
+	recordWatchIncidents(projectName, results)
+	recordWatchHistory(projectName, results)
+	notifyWatchFailures(cfg, projectName, results)
+	runDeploySuccessHooks(cfg, projectName, results)
+	if watchOut != "" {
+		if err := writeWatchOut(watchOut, results); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+		}
+	}
+	if watchGitHubPR > 0 {
+		if err := postWatchPRComment(cfg, key, projectName, watchGitHubPR, results); err != nil {
+			fmt.Fprintf(os.Stderr, "%s GitHub PR comment failed: %s\n", ui.IconWarning, err)
+		}
+	}
+	if watchGitLabStatus {
+		if err := setWatchGitLabStatus(cfg, key, projectName, results); err != nil {
+			fmt.Fprintf(os.Stderr, "%s GitLab commit status failed: %s\n", ui.IconWarning, err)
+		}
+	}
+	if watchBBStatus {
+		if err := setWatchBitbucketStatus(cfg, key, projectName, results); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Bitbucket build status failed: %s\n", ui.IconWarning, err)
+		}
+	}
+	if watchFormat == "gitlab" && watchDotenv != "" {
+		if err := writeWatchDotenv(watchDotenv, results, overall); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s\n", ui.IconWarning, err)
+		}
+	}
+}
+
 func shortID(id string) string {
 	if len(id) > 12 {
 		return id[:12]
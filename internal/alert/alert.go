@@ -0,0 +1,341 @@
+// Package alert evaluates alert rules against service statuses and keeps
+// track of which alerts are pending, firing or silenced.
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// Metrics a rule can test. MetricDeploy rules fire once per failed
+// deployment.
+const (
+	MetricStatus       = "status"
+	MetricResponseTime = "response_time"
+	MetricCPU          = "cpu"
+	MetricMemory       = "memory"
+	MetricDeploy       = "deploy"
+)
+
+// Rule is a validated config.AlertRule.
+type Rule struct {
+	Name     string
+	Service  string
+	Metric   string
+	Op       string  // ==, != for status; >, >=, <, <= for numeric metrics
+	Value    float64 // numeric metrics
+	Status   string  // status rules
+	For      time.Duration
+	Severity string
+}
+
+// Parse validates r. A numeric metric without a comparison ("cpu") uses
+// the matching global threshold, e.g. cpu > thresholds.cpu_percent.
+func Parse(r config.AlertRule, th config.ThresholdConfig) (Rule, error) {
+	rule := Rule{Name: r.Name, Service: r.Service, Severity: r.Severity}
+	if r.Name == "" {
+		return rule, fmt.Errorf("alert rule has no name")
+	}
+	if r.Service == "" {
+		return rule, fmt.Errorf("alert %q has no service", r.Name)
+	}
+	if r.For != "" {
+		d, err := time.ParseDuration(r.For)
+		if err != nil || d < 0 {
+			return rule, fmt.Errorf("alert %q: invalid for %q", r.Name, r.For)
+		}
+		rule.For = d
+	}
+
+	fields := strings.Fields(r.When)
+	if len(fields) == 0 {
+		return rule, fmt.Errorf("alert %q has no condition", r.Name)
+	}
+	rule.Metric = fields[0]
+	switch rule.Metric {
+	case MetricDeploy:
+		if len(fields) != 2 || fields[1] != "failed" {
+			return rule, fmt.Errorf("alert %q: deploy rules are written %q", r.Name, "deploy failed")
+		}
+	case MetricStatus:
+		if len(fields) != 3 || (fields[1] != "==" && fields[1] != "!=") {
+			return rule, fmt.Errorf("alert %q: status rules are written like %q", r.Name, "status != healthy")
+		}
+		rule.Op, rule.Status = fields[1], fields[2]
+	case MetricResponseTime, MetricCPU, MetricMemory:
+		switch len(fields) {
+		case 1:
+			rule.Op, rule.Value = ">", float64(threshold(rule.Metric, th))
+			if rule.Value <= 0 {
+				return rule, fmt.Errorf("alert %q: no %s threshold configured", r.Name, rule.Metric)
+			}
+		case 3:
+			switch fields[1] {
+			case ">", ">=", "<", "<=":
+			default:
+				return rule, fmt.Errorf("alert %q: unknown comparison %q (use >, >=, < or <=)", r.Name, fields[1])
+			}
+			v, err := strconv.ParseFloat(strings.TrimRight(fields[2], "ms%"), 64)
+			if err != nil {
+				return rule, fmt.Errorf("alert %q: invalid value %q", r.Name, fields[2])
+			}
+			rule.Op, rule.Value = fields[1], v
+		default:
+			return rule, fmt.Errorf("alert %q: conditions are written like %q", r.Name, rule.Metric+" > 90")
+		}
+	default:
+		return rule, fmt.Errorf("alert %q: unknown metric %q (supported: status, response_time, cpu, memory, deploy)", r.Name, rule.Metric)
+	}
+
+	switch rule.Severity {
+	case "":
+		rule.Severity = notify.SeverityWarning
+		if rule.Metric == MetricStatus || rule.Metric == MetricDeploy {
+			rule.Severity = notify.SeverityCritical
+		}
+	case notify.SeverityInfo, notify.SeverityWarning, notify.SeverityCritical:
+	default:
+		return rule, fmt.Errorf("alert %q: invalid severity %q (use info, warning or critical)", r.Name, rule.Severity)
+	}
+	return rule, nil
+}
+
+func threshold(metric string, th config.ThresholdConfig) int {
+	switch metric {
+	case MetricResponseTime:
+		return th.ResponseTimeMs
+	case MetricCPU:
+		return th.CPUPercent
+	default:
+		return th.MemoryPercent
+	}
+}
+
+// Condition returns the rule's condition, e.g. "response_time > 800ms".
+func (r Rule) Condition() string {
+	switch r.Metric {
+	case MetricDeploy:
+		return "deploy failed"
+	case MetricStatus:
+		return fmt.Sprintf("status %s %s", r.Op, r.Status)
+	}
+	return fmt.Sprintf("%s %s %s", r.Metric, r.Op, formatValue(r.Metric, r.Value))
+}
+
+func formatValue(metric string, v float64) string {
+	if metric == MetricResponseTime {
+		return fmt.Sprintf("%.0fms", v)
+	}
+	return fmt.Sprintf("%.0f%%", v)
+}
+
+// Match reports whether st meets the rule's condition and the value it
+// saw. A nil st means the status couldn't be fetched: status rules see it
+// as "unreachable", other rules don't match.
+func (r Rule) Match(st *platform.ServiceStatus) (bool, string) {
+	if st == nil {
+		if r.Metric != MetricStatus {
+			return false, ""
+		}
+		return (r.Status == "unreachable") == (r.Op == "=="), "unreachable"
+	}
+
+	var v float64
+	switch r.Metric {
+	case MetricDeploy:
+		if st.LastDeploy == nil {
+			return false, ""
+		}
+		return st.LastDeploy.Status == "failed", st.LastDeploy.Status
+	case MetricStatus:
+		return (st.Status == r.Status) == (r.Op == "=="), st.Status
+	case MetricResponseTime:
+		v = float64(st.ResponseMs)
+	case MetricCPU:
+		v = st.CPU
+	case MetricMemory:
+		v = st.Memory
+	}
+	var ok bool
+	switch r.Op {
+	case ">":
+		ok = v > r.Value
+	case ">=":
+		ok = v >= r.Value
+	case "<":
+		ok = v < r.Value
+	case "<=":
+		ok = v <= r.Value
+	}
+	return ok, formatValue(r.Metric, v)
+}
+
+// Transition is what an observation changed about an alert.
+type Transition int
+
+const (
+	Unchanged Transition = iota
+	Fired
+	Resolved
+)
+
+// RuleState is what is known about one rule between checks.
+type RuleState struct {
+	PendingSince  *time.Time `json:"pending_since,omitempty"`  // condition first seen holding
+	FiringSince   *time.Time `json:"firing_since,omitempty"`   // nil when not firing
+	DeployID      string     `json:"deploy_id,omitempty"`      // deployment a deploy rule last fired for
+	SilencedUntil *time.Time `json:"silenced_until,omitempty"` // no notifications until then
+	Value         string     `json:"value,omitempty"`          // last observed value
+	CheckedAt     time.Time  `json:"checked_at"`
+}
+
+// Firing reports whether the alert is firing.
+func (s *RuleState) Firing() bool {
+	return s.FiringSince != nil
+}
+
+// Silenced reports whether notifications for the alert are held back at now.
+func (s *RuleState) Silenced(now time.Time) bool {
+	return s.SilencedUntil != nil && now.Before(*s.SilencedUntil)
+}
+
+// State holds every rule's state, keyed by "project/name".
+type State struct {
+	Rules map[string]*RuleState `json:"rules"`
+}
+
+// Path returns the alert state location, ~/.orbit/alerts.json.
+func Path() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "alerts.json"), nil
+}
+
+// Load reads the state at path. A missing file is an empty state.
+func Load(path string) (*State, error) {
+	s := &State{Rules: make(map[string]*RuleState)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read alerts: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if s.Rules == nil {
+		s.Rules = make(map[string]*RuleState)
+	}
+	return s, nil
+}
+
+// Save writes the state to path atomically.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal alerts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".alerts-*.json")
+	if err != nil {
+		return fmt.Errorf("write alerts: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write alerts: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write alerts: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get returns the state of a rule, creating it if needed.
+func (s *State) Get(project, name string) *RuleState {
+	key := project + "/" + name
+	rs, ok := s.Rules[key]
+	if !ok {
+		rs = &RuleState{}
+		s.Rules[key] = rs
+	}
+	return rs
+}
+
+// Lookup returns the state of a rule, or nil if it was never checked or
+// silenced.
+func (s *State) Lookup(project, name string) *RuleState {
+	return s.Rules[project+"/"+name]
+}
+
+// Forget drops a rule's state.
+func (s *State) Forget(project, name string) {
+	delete(s.Rules, project+"/"+name)
+}
+
+// Observe evaluates r against st (nil if the status couldn't be fetched)
+// and updates the rule's state. A rule fires once its condition has held
+// for r.For and resolves on the first check where it no longer holds.
+// Deploy rules fire once per failed deployment and resolve when a later
+// deployment doesn't fail.
+func (s *State) Observe(project string, r Rule, st *platform.ServiceStatus, now time.Time) Transition {
+	rs := s.Get(project, r.Name)
+	matched, value := r.Match(st)
+	rs.CheckedAt = now
+	if value != "" {
+		rs.Value = value
+	}
+
+	// Without a status only status rules can tell anything; the others
+	// keep their state until the next successful check.
+	if st == nil && r.Metric != MetricStatus {
+		return Unchanged
+	}
+	if r.Metric == MetricDeploy {
+		if st.LastDeploy == nil {
+			return Unchanged
+		}
+		switch {
+		case matched && st.LastDeploy.ID != rs.DeployID:
+			rs.DeployID = st.LastDeploy.ID
+			rs.FiringSince = &now
+			return Fired
+		case !matched && rs.Firing():
+			rs.FiringSince = nil
+			return Resolved
+		}
+		return Unchanged
+	}
+
+	if !matched {
+		rs.PendingSince = nil
+		if rs.Firing() {
+			rs.FiringSince = nil
+			return Resolved
+		}
+		return Unchanged
+	}
+	if rs.PendingSince == nil {
+		rs.PendingSince = &now
+	}
+	if rs.Firing() || now.Sub(*rs.PendingSince) < r.For {
+		return Unchanged
+	}
+	rs.FiringSince = &now
+	return Fired
+}
@@ -0,0 +1,133 @@
+package alert
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+var thresholds = config.ThresholdConfig{ResponseTimeMs: 500, CPUPercent: 80, MemoryPercent: 85}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		when    string
+		want    string // Condition() of the parsed rule
+		wantErr bool
+	}{
+		{"status", "status != healthy", "status != healthy", false},
+		{"response time", "response_time > 800ms", "response_time > 800ms", false},
+		{"threshold default", "cpu", "cpu > 80%", false},
+		{"deploy", "deploy failed", "deploy failed", false},
+		{"status comparison", "status > healthy", "", true},
+		{"unknown metric", "disk > 90", "", true},
+		{"bad value", "memory > lots", "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(config.AlertRule{Name: "a", Service: "api", When: tt.when}, thresholds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && r.Condition() != tt.want {
+				t.Errorf("condition = %q, want %q", r.Condition(), tt.want)
+			}
+		})
+	}
+
+	r, err := Parse(config.AlertRule{Name: "a", Service: "api", When: "deploy failed"}, thresholds)
+	if err != nil || r.Severity != "critical" {
+		t.Errorf("deploy rule severity = %q (err %v), want critical", r.Severity, err)
+	}
+	if _, err := Parse(config.AlertRule{Name: "a", Service: "api", When: "cpu", For: "soon"}, thresholds); err == nil {
+		t.Error("expected an error for an invalid for")
+	}
+}
+
+func TestObserveFor(t *testing.T) {
+	r, err := Parse(config.AlertRule{Name: "down", Service: "api", When: "status != healthy", For: "5m"}, thresholds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &State{Rules: map[string]*RuleState{}}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	unhealthy := &platform.ServiceStatus{Status: "unhealthy"}
+	healthy := &platform.ServiceStatus{Status: "healthy"}
+
+	steps := []struct {
+		st      *platform.ServiceStatus
+		advance time.Duration
+		want    Transition
+	}{
+		{unhealthy, 0, Unchanged},
+		{healthy, time.Minute, Unchanged}, // recovering restarts the wait
+		{unhealthy, time.Minute, Unchanged},
+		{nil, 4 * time.Minute, Unchanged}, // unreachable counts as not healthy
+		{unhealthy, time.Minute, Fired},
+		{unhealthy, time.Minute, Unchanged}, // fires once
+		{healthy, time.Minute, Resolved},
+	}
+	for i, step := range steps {
+		now = now.Add(step.advance)
+		if got := s.Observe("shop", r, step.st, now); got != step.want {
+			t.Fatalf("step %d: got %v, want %v", i, got, step.want)
+		}
+	}
+}
+
+func TestObserveDeploy(t *testing.T) {
+	r, err := Parse(config.AlertRule{Name: "deploys", Service: "api", When: "deploy failed"}, thresholds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &State{Rules: map[string]*RuleState{}}
+	now := time.Now()
+	deploy := func(id, status string) *platform.ServiceStatus {
+		return &platform.ServiceStatus{Status: "healthy", LastDeploy: &platform.Deployment{ID: id, Status: status}}
+	}
+
+	steps := []struct {
+		st   *platform.ServiceStatus
+		want Transition
+	}{
+		{deploy("d1", "healthy"), Unchanged},
+		{deploy("d2", "failed"), Fired},
+		{deploy("d2", "failed"), Unchanged}, // same deployment
+		{deploy("d3", "failed"), Fired},
+		{deploy("d4", "healthy"), Resolved},
+	}
+	for i, step := range steps {
+		if got := s.Observe("shop", r, step.st, now); got != step.want {
+			t.Fatalf("step %d: got %v, want %v", i, got, step.want)
+		}
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	s.Get("shop", "slow").SilencedUntil = &until
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs := s.Lookup("shop", "slow")
+	if rs == nil || !rs.Silenced(time.Now()) {
+		t.Fatalf("state not restored: %+v", rs)
+	}
+	if rs.Silenced(until) {
+		t.Error("silence should end at its deadline")
+	}
+}
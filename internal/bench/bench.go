@@ -0,0 +1,70 @@
+// Package bench times repeated platform API calls and summarizes their
+// latency, so slow commands can be traced to Orbit or to the platform.
+package bench
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// Result summarizes the latency of one call measured N times. Percentiles
+// cover successful calls only.
+type Result struct {
+	Call    string
+	N       int
+	Errors  int
+	Min     time.Duration
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+	Max     time.Duration
+	LastErr string
+}
+
+// Measure calls fn n times in sequence and summarizes the timings. It stops
+// early when ctx is cancelled.
+func Measure(ctx context.Context, call string, n int, fn func(context.Context) error) Result {
+	var samples []time.Duration
+	r := Result{Call: call}
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		start := time.Now()
+		err := fn(ctx)
+		d := time.Since(start)
+		r.N++
+		if err != nil {
+			r.Errors++
+			r.LastErr = err.Error()
+			continue
+		}
+		samples = append(samples, d)
+	}
+	r.summarize(samples)
+	return r
+}
+
+func (r *Result) summarize(samples []time.Duration) {
+	if len(samples) == 0 {
+		return
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	r.Min = samples[0]
+	r.Max = samples[len(samples)-1]
+	r.P50 = Percentile(samples, 50)
+	r.P90 = Percentile(samples, 90)
+	r.P99 = Percentile(samples, 99)
+}
+
+// Percentile returns the p-th percentile of sorted by the nearest-rank
+// method. sorted must be in ascending order and non-empty.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
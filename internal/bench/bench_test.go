@@ -0,0 +1,62 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 10; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1 * time.Millisecond},
+		{50, 5 * time.Millisecond},
+		{90, 9 * time.Millisecond},
+		{99, 10 * time.Millisecond},
+		{100, 10 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := Percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestMeasureCountsErrors(t *testing.T) {
+	i := 0
+	r := Measure(context.Background(), "status", 4, func(context.Context) error {
+		i++
+		if i%2 == 0 {
+			return errors.New("rate limited")
+		}
+		return nil
+	})
+	if r.N != 4 || r.Errors != 2 || r.LastErr != "rate limited" {
+		t.Errorf("Measure = %+v, want 4 calls with 2 errors", r)
+	}
+	if r.Max < r.Min || r.P50 < r.Min {
+		t.Errorf("percentiles out of order: %+v", r)
+	}
+}
+
+func TestMeasureStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	r := Measure(ctx, "logs", 10, func(context.Context) error {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if r.N != 3 {
+		t.Errorf("N = %d after cancel on the third call, want 3", r.N)
+	}
+}
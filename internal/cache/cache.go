@@ -0,0 +1,123 @@
+// Package cache stores platform API responses on disk for a short time, so
+// commands run in quick succession don't refetch the same data.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+// How long each kind of response stays fresh.
+const (
+	StatusTTL    = 15 * time.Second
+	DeploysTTL   = 30 * time.Second
+	DiscoveryTTL = 30 * time.Minute
+)
+
+// Cache is a directory of JSON entries keyed by Key. All errors are treated
+// as misses: the cache only ever saves API calls, it never fails a command.
+type Cache struct {
+	dir string
+
+	// Refresh skips reads but still stores fresh responses (--no-cache).
+	Refresh bool
+
+	now func() time.Time
+}
+
+// New returns a cache stored in dir.
+func New(dir string) *Cache {
+	return &Cache{dir: dir, now: time.Now}
+}
+
+// Dir returns the default cache directory, ~/.orbit/cache.
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+// Key derives an entry name from its parts (kind, platform, token, service
+// ID, ...). Parts are hashed so tokens never end up in file names.
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// DiscoveryKey identifies the discovered services of one platform
+// connection. orbit init and the discovering commands share it.
+func DiscoveryKey(platform, token, teamID, endpoint string) string {
+	return Key("discovery", platform, token, teamID, endpoint)
+}
+
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// Get decodes the entry under key into v if it was stored less than ttl
+// ago, and reports whether it did.
+func (c *Cache) Get(key string, ttl time.Duration, v interface{}) bool {
+	if c == nil || c.Refresh {
+		return false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	if age := c.now().Sub(e.StoredAt); age < 0 || age >= ttl {
+		return false
+	}
+	return json.Unmarshal(e.Value, v) == nil
+}
+
+// Put stores v under key.
+func (c *Cache) Put(key string, v interface{}) {
+	if c == nil {
+		return
+	}
+	value, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(entry{StoredAt: c.now(), Value: value})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+	// Write and rename so parallel commands never read a partial entry.
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	_, werr := tmp.Write(data)
+	if cerr := tmp.Close(); werr != nil || cerr != nil {
+		return
+	}
+	os.Rename(tmp.Name(), c.path(key))
+}
+
+// Clear removes every entry.
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+type sample struct {
+	Status string
+	Count  int
+}
+
+func TestGetPut(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := New(t.TempDir())
+	c.now = func() time.Time { return now }
+
+	key := Key("status", "vercel", "token", "prj_1")
+	var got sample
+	if c.Get(key, time.Minute, &got) {
+		t.Fatal("hit on an empty cache")
+	}
+
+	c.Put(key, sample{Status: "healthy", Count: 2})
+	if !c.Get(key, time.Minute, &got) || got != (sample{"healthy", 2}) {
+		t.Fatalf("Get = %+v, want stored value", got)
+	}
+
+	now = now.Add(time.Minute)
+	if c.Get(key, time.Minute, &got) {
+		t.Error("hit on an expired entry")
+	}
+
+	c.Refresh = true
+	now = now.Add(-time.Minute)
+	if c.Get(key, time.Minute, &got) {
+		t.Error("hit while refreshing")
+	}
+}
+
+func TestKeyDistinguishesParts(t *testing.T) {
+	if Key("a", "bc") == Key("ab", "c") {
+		t.Error("keys collide across part boundaries")
+	}
+	if Key("status", "t1") == Key("status", "t2") {
+		t.Error("different tokens share a key")
+	}
+	if DiscoveryKey("vercel", "t1", "team_a", "") == DiscoveryKey("vercel", "t1", "team_b", "") {
+		t.Error("discovery for different teams shares a key")
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	var c *Cache
+	var got sample
+	if c.Get("k", time.Minute, &got) {
+		t.Error("nil cache hit")
+	}
+	c.Put("k", sample{}) // must not panic
+}
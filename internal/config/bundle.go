@@ -0,0 +1,241 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BundleVersion is the bundle format orbit export writes.
+const BundleVersion = 1
+
+// Bundle is a portable copy of the configuration, written by orbit export
+// and restored by orbit import. Tokens and passwords are in plain text,
+// since the key that encrypts them in config.yaml stays on its machine, or
+// left out of token-free bundles. Settings is only set for a full export.
+type Bundle struct {
+	Version        int                       `yaml:"version"`
+	ExportedAt     time.Time                 `yaml:"exported_at"`
+	DefaultProject string                    `yaml:"default_project,omitempty"`
+	Platforms      map[string]PlatformConfig `yaml:"platforms,omitempty"`
+	Projects       map[string]ProjectConfig  `yaml:"projects"`
+	Settings       *BundleSettings           `yaml:"settings,omitempty"`
+}
+
+// BundleSettings are the config-wide settings carried by a full export.
+type BundleSettings struct {
+	Thresholds    ThresholdConfig     `yaml:"thresholds"`
+	SLO           SLOConfig           `yaml:"slo,omitempty"`
+	Watch         WatchConfig         `yaml:"watch,omitempty"`
+	Integrations  IntegrationsConfig  `yaml:"integrations,omitempty"`
+	Notifications NotificationsConfig `yaml:"notifications,omitempty"`
+	Hooks         HooksConfig         `yaml:"hooks,omitempty"`
+}
+
+// NewBundle exports cfg, or only the named projects and the platforms their
+// services use. Secrets are decrypted with key, or left out when withSecrets
+// is false.
+func NewBundle(cfg *Config, key []byte, projects []string, withSecrets bool, now time.Time) (*Bundle, error) {
+	b := &Bundle{
+		Version:    BundleVersion,
+		ExportedAt: now.UTC(),
+		Platforms:  make(map[string]PlatformConfig),
+		Projects:   make(map[string]ProjectConfig),
+	}
+	if len(projects) == 0 {
+		b.DefaultProject = cfg.DefaultProject
+		for name, p := range cfg.Platforms {
+			b.Platforms[name] = p
+		}
+		for name, p := range cfg.Projects {
+			b.Projects[name] = p
+		}
+		b.Settings = &BundleSettings{
+			Thresholds:    cfg.Thresholds,
+			SLO:           cfg.SLO,
+			Watch:         cfg.Watch,
+			Integrations:  cfg.Integrations,
+			Notifications: cfg.Notifications,
+			Hooks:         cfg.Hooks,
+		}
+	} else {
+		for _, name := range projects {
+			p, ok := cfg.Projects[name]
+			if !ok {
+				return nil, fmt.Errorf("project %q not found", name)
+			}
+			b.Projects[name] = p
+			for _, e := range p.Topology {
+				if pc, ok := cfg.Platforms[e.Platform]; ok {
+					b.Platforms[e.Platform] = pc
+				}
+			}
+		}
+	}
+
+	err := b.mapSecrets(func(where, s string) (string, error) {
+		if s == "" || !withSecrets {
+			return "", nil
+		}
+		plain, err := Decrypt(key, s)
+		if err != nil {
+			return "", fmt.Errorf("decrypt %s: %w", where, err)
+		}
+		return plain, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// mapSecrets replaces every token and password in the bundle with f's
+// result. where names the secret for error messages.
+func (b *Bundle) mapSecrets(f func(where, s string) (string, error)) error {
+	var err error
+	for name, p := range b.Platforms {
+		if p.Token, err = f("token of platform "+name, p.Token); err != nil {
+			return err
+		}
+		b.Platforms[name] = p
+	}
+	if b.Settings == nil {
+		return nil
+	}
+	in := &b.Settings.Integrations
+	if in.GitHub.Token, err = f("GitHub token", in.GitHub.Token); err != nil {
+		return err
+	}
+	if in.GitLab.Token, err = f("GitLab token", in.GitLab.Token); err != nil {
+		return err
+	}
+	if in.Bitbucket.Token, err = f("Bitbucket token", in.Bitbucket.Token); err != nil {
+		return err
+	}
+	// Copy the channels so a bundle made from a config doesn't write
+	// through to the config's map.
+	channels := make(map[string]NotifyChannel, len(b.Settings.Notifications.Channels))
+	for name, c := range b.Settings.Notifications.Channels {
+		if c.Password, err = f("password of channel "+name, c.Password); err != nil {
+			return err
+		}
+		channels[name] = c
+	}
+	if len(channels) > 0 {
+		b.Settings.Notifications.Channels = channels
+	}
+	return nil
+}
+
+// ImportSummary lists what Import changed, as "project myshop" or
+// "platform koyeb".
+type ImportSummary struct {
+	Added    []string
+	Replaced []string
+	Skipped  []string // already configured; replaced only with overwrite
+	// NeedToken lists platforms the bundle has no token for that aren't
+	// connected here. They are left out until connected.
+	NeedToken []string
+}
+
+// Import restores a bundle into cfg, encrypting its secrets with key.
+// Projects and platforms that cfg already has are kept unless overwrite is
+// set. Settings, when the bundle has them, replace cfg's on a config with
+// no projects or platforms yet, or with overwrite; a secret missing from
+// the bundle keeps its current value. The caller saves the config.
+func Import(cfg *Config, b *Bundle, key []byte, overwrite bool) (*ImportSummary, error) {
+	if b.Version > BundleVersion {
+		return nil, fmt.Errorf("bundle version %d is newer than this orbit supports (%d); upgrade orbit", b.Version, BundleVersion)
+	}
+	err := b.mapSecrets(func(where, s string) (string, error) {
+		if s == "" {
+			return "", nil
+		}
+		if IsEncrypted(s) {
+			return "", fmt.Errorf("%s is encrypted with another machine's key; export it again", where)
+		}
+		return Encrypt(key, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fresh := len(cfg.Projects) == 0 && len(cfg.Platforms) == 0
+	sum := &ImportSummary{}
+	for _, name := range sortedKeys(b.Platforms) {
+		p := b.Platforms[name]
+		label := "platform " + name
+		local, exists := cfg.Platforms[name]
+		switch {
+		case exists && !overwrite:
+			sum.Skipped = append(sum.Skipped, label)
+			continue
+		case p.Token == "" && !exists:
+			sum.NeedToken = append(sum.NeedToken, name)
+			continue
+		case p.Token == "":
+			p.Token = local.Token
+		}
+		cfg.Platforms[name] = p
+		if exists {
+			sum.Replaced = append(sum.Replaced, label)
+		} else {
+			sum.Added = append(sum.Added, label)
+		}
+	}
+
+	for _, name := range sortedKeys(b.Projects) {
+		label := "project " + name
+		_, exists := cfg.Projects[name]
+		switch {
+		case exists && !overwrite:
+			sum.Skipped = append(sum.Skipped, label)
+			continue
+		case exists:
+			sum.Replaced = append(sum.Replaced, label)
+		default:
+			sum.Added = append(sum.Added, label)
+		}
+		cfg.Projects[name] = b.Projects[name]
+	}
+	if _, ok := cfg.Projects[b.DefaultProject]; ok && (cfg.DefaultProject == "" || overwrite) {
+		cfg.DefaultProject = b.DefaultProject
+	}
+
+	switch s := b.Settings; {
+	case s == nil:
+	case !fresh && !overwrite:
+		sum.Skipped = append(sum.Skipped, "settings")
+	default:
+		keepSecret(&s.Integrations.GitHub.Token, cfg.Integrations.GitHub.Token)
+		keepSecret(&s.Integrations.GitLab.Token, cfg.Integrations.GitLab.Token)
+		keepSecret(&s.Integrations.Bitbucket.Token, cfg.Integrations.Bitbucket.Token)
+		for name, c := range s.Notifications.Channels {
+			keepSecret(&c.Password, cfg.Notifications.Channels[name].Password)
+			s.Notifications.Channels[name] = c
+		}
+		cfg.Thresholds = s.Thresholds
+		cfg.SLO = s.SLO
+		cfg.Watch = s.Watch
+		cfg.Integrations = s.Integrations
+		cfg.Notifications = s.Notifications
+		cfg.Hooks = s.Hooks
+		sum.Replaced = append(sum.Replaced, "settings")
+	}
+	return sum, nil
+}
+
+func keepSecret(s *string, local string) {
+	if *s == "" {
+		*s = local
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
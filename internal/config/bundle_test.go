@@ -0,0 +1,170 @@
+package config
+
+import (
+	"crypto/rand"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func encrypted(t *testing.T, key []byte, s string) string {
+	t.Helper()
+	enc, err := Encrypt(key, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enc
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+	src := &Config{
+		DefaultProject: "myshop",
+		Platforms: map[string]PlatformConfig{
+			"koyeb":  {Token: encrypted(t, oldKey, "koyeb-token")},
+			"vercel": {Token: encrypted(t, oldKey, "vercel-token"), TeamID: "team_1"},
+		},
+		Projects: map[string]ProjectConfig{
+			"myshop": {Topology: []ServiceEntry{{Name: "api", Platform: "koyeb", ID: "svc_1", HeartbeatURL: "https://api.example.com/health"}}},
+			"blog":   {Topology: []ServiceEntry{{Name: "site", Platform: "vercel", ID: "prj_1"}}},
+		},
+		Thresholds: ThresholdConfig{ResponseTimeMs: 300},
+		Notifications: NotificationsConfig{Channels: map[string]NotifyChannel{
+			"ops": {Type: "email", Password: encrypted(t, oldKey, "smtp-pass")},
+		}},
+	}
+
+	b, err := NewBundle(src, oldKey, nil, true, time.Now())
+	if err != nil {
+		t.Fatalf("NewBundle: %v", err)
+	}
+	if b.Platforms["koyeb"].Token != "koyeb-token" {
+		t.Errorf("bundle token = %q, want it decrypted", b.Platforms["koyeb"].Token)
+	}
+	if !IsEncrypted(src.Notifications.Channels["ops"].Password) {
+		t.Error("NewBundle changed the source config's channel password")
+	}
+
+	dst := &Config{Platforms: map[string]PlatformConfig{}, Projects: map[string]ProjectConfig{}}
+	sum, err := Import(dst, b, newKey, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	want := []string{"platform koyeb", "platform vercel", "project blog", "project myshop"}
+	if !reflect.DeepEqual(sum.Added, want) {
+		t.Errorf("Added = %v, want %v", sum.Added, want)
+	}
+	if dst.DefaultProject != "myshop" || dst.Thresholds.ResponseTimeMs != 300 {
+		t.Errorf("default project %q, thresholds %+v not restored", dst.DefaultProject, dst.Thresholds)
+	}
+	if got := dst.Projects["myshop"].Topology[0].HeartbeatURL; got != "https://api.example.com/health" {
+		t.Errorf("heartbeat URL = %q", got)
+	}
+	for name, secret := range map[string]string{
+		"koyeb-token": dst.Platforms["koyeb"].Token,
+		"smtp-pass":   dst.Notifications.Channels["ops"].Password,
+	} {
+		plain, err := Decrypt(newKey, secret)
+		if err != nil || plain != name {
+			t.Errorf("secret %q: got %q, %v under the new key", name, plain, err)
+		}
+	}
+}
+
+func TestBundleWithoutSecrets(t *testing.T) {
+	key := newTestKey(t)
+	src := &Config{
+		Platforms: map[string]PlatformConfig{
+			"koyeb":  {Token: encrypted(t, key, "koyeb-token")},
+			"vercel": {Token: encrypted(t, key, "vercel-token")},
+		},
+		Projects: map[string]ProjectConfig{
+			"myshop": {Topology: []ServiceEntry{{Name: "api", Platform: "koyeb", ID: "svc_1"}}},
+			"blog":   {Topology: []ServiceEntry{{Name: "site", Platform: "vercel", ID: "prj_1"}}},
+		},
+	}
+	b, err := NewBundle(src, key, []string{"myshop"}, false, time.Now())
+	if err != nil {
+		t.Fatalf("NewBundle: %v", err)
+	}
+	if len(b.Projects) != 1 || len(b.Platforms) != 1 || b.Settings != nil {
+		t.Fatalf("project bundle = %+v, want only myshop and koyeb without settings", b)
+	}
+	if b.Platforms["koyeb"].Token != "" {
+		t.Errorf("token-free bundle has token %q", b.Platforms["koyeb"].Token)
+	}
+
+	// A teammate without Koyeb connected gets the project but has to
+	// connect the platform.
+	dst := &Config{Platforms: map[string]PlatformConfig{}, Projects: map[string]ProjectConfig{"other": {}}}
+	sum, err := Import(dst, b, key, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !reflect.DeepEqual(sum.NeedToken, []string{"koyeb"}) {
+		t.Errorf("NeedToken = %v, want [koyeb]", sum.NeedToken)
+	}
+	if _, ok := dst.Projects["myshop"]; !ok {
+		t.Error("project not imported")
+	}
+
+	// With it connected, overwrite keeps the local token.
+	local := encrypted(t, key, "local-token")
+	dst.Platforms["koyeb"] = PlatformConfig{Token: local}
+	if _, err := Import(dst, b, key, true); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if dst.Platforms["koyeb"].Token != local {
+		t.Error("overwrite with a token-free bundle dropped the local token")
+	}
+}
+
+func TestImportKeepsExisting(t *testing.T) {
+	key := newTestKey(t)
+	b := &Bundle{
+		Version:  BundleVersion,
+		Projects: map[string]ProjectConfig{"myshop": {Topology: []ServiceEntry{{Name: "web"}}}},
+		Settings: &BundleSettings{Thresholds: ThresholdConfig{ResponseTimeMs: 100}},
+	}
+	dst := &Config{
+		Platforms:  map[string]PlatformConfig{},
+		Projects:   map[string]ProjectConfig{"myshop": {Topology: []ServiceEntry{{Name: "api"}}}},
+		Thresholds: ThresholdConfig{ResponseTimeMs: 500},
+	}
+	sum, err := Import(dst, b, key, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !reflect.DeepEqual(sum.Skipped, []string{"project myshop", "settings"}) {
+		t.Errorf("Skipped = %v", sum.Skipped)
+	}
+	if dst.Projects["myshop"].Topology[0].Name != "api" || dst.Thresholds.ResponseTimeMs != 500 {
+		t.Error("existing project or settings replaced without overwrite")
+	}
+}
+
+func TestImportRejects(t *testing.T) {
+	key := newTestKey(t)
+	dst := &Config{Platforms: map[string]PlatformConfig{}, Projects: map[string]ProjectConfig{}}
+
+	newer := &Bundle{Version: BundleVersion + 1}
+	if _, err := Import(dst, newer, key, false); err == nil {
+		t.Error("Import accepted a newer bundle version")
+	}
+	copied := &Bundle{Version: BundleVersion, Platforms: map[string]PlatformConfig{
+		"koyeb": {Token: encrypted(t, newTestKey(t), "token")},
+	}}
+	if _, err := Import(dst, copied, key, false); err == nil {
+		t.Error("Import accepted a token encrypted with another key")
+	}
+}
@@ -10,42 +10,364 @@ import (
 
 // ServiceEntry represents a service within a project topology.
 type ServiceEntry struct {
-	Name              string `mapstructure:"name"               yaml:"name"`
-	Platform          string `mapstructure:"platform"           yaml:"platform"`
-	ID                string `mapstructure:"id"                 yaml:"id"`
-	Target            string `mapstructure:"target"             yaml:"target,omitempty"`
-	HeartbeatURL      string `mapstructure:"heartbeat_url"      yaml:"heartbeat_url,omitempty"`
-	HeartbeatInterval string `mapstructure:"heartbeat_interval" yaml:"heartbeat_interval,omitempty"`
+	Name              string   `mapstructure:"name"               yaml:"name"`
+	Platform          string   `mapstructure:"platform"           yaml:"platform"`
+	ID                string   `mapstructure:"id"                 yaml:"id"`
+	Target            string   `mapstructure:"target"             yaml:"target,omitempty"`
+	HeartbeatURL      string   `mapstructure:"heartbeat_url"      yaml:"heartbeat_url,omitempty"`
+	HeartbeatInterval string   `mapstructure:"heartbeat_interval" yaml:"heartbeat_interval,omitempty"`
+	Tags              []string `mapstructure:"tags"               yaml:"tags,omitempty"`
+	RemoteName        string   `mapstructure:"remote_name"        yaml:"remote_name,omitempty"` // last-known name on the platform
+	Optional          bool     `mapstructure:"optional"           yaml:"optional,omitempty"`    // see WatchConfig.OptionalIgnore
+	Owner             string   `mapstructure:"owner"              yaml:"owner,omitempty"`       // team or person, passed to notifications
+	URL               string   `mapstructure:"url"                yaml:"url,omitempty"`         // canonical public URL, as the platform reports it
+	Repo              string   `mapstructure:"repo"               yaml:"repo,omitempty"`        // local git checkout, for commit ranges in orbit diff
+	DeployHook        string   `mapstructure:"deploy_hook"        yaml:"deploy_hook,omitempty"` // URL to POST or shell command that starts a deploy, for watch --redeploy
+
+	Remediation *RemediationPolicy `mapstructure:"remediation" yaml:"remediation,omitempty"`
+	Probe       *ProbeConfig       `mapstructure:"probe"       yaml:"probe,omitempty"`
+	SLO         *SLOConfig         `mapstructure:"slo"         yaml:"slo,omitempty"`   // overrides the global objectives
+	Smoke       []SmokeCheck       `mapstructure:"smoke"       yaml:"smoke,omitempty"` // post-deploy checks for orbit verify
+}
+
+// RemediationPolicy tells the heartbeat daemon how to heal a service that
+// keeps failing its checks.
+type RemediationPolicy struct {
+	OnUnhealthy string `mapstructure:"on_unhealthy" yaml:"on_unhealthy"`           // restart
+	After       int    `mapstructure:"after"        yaml:"after,omitempty"`        // consecutive failed checks before acting; default 3
+	MaxPerHour  int    `mapstructure:"max_per_hour" yaml:"max_per_hour,omitempty"` // default 2
+}
+
+// ProbeConfig describes a health check Orbit runs against the service
+// itself, alongside what the platform reports.
+type ProbeConfig struct {
+	URL          string `mapstructure:"url"           yaml:"url,omitempty"`           // http(s)://... or tcp://host:port; default heartbeat_url
+	ExpectStatus int    `mapstructure:"expect_status" yaml:"expect_status,omitempty"` // default any 2xx or 3xx
+	Budget       string `mapstructure:"budget"        yaml:"budget,omitempty"`        // latency above this marks the service degraded, e.g. "800ms"
+	BodyMatch    string `mapstructure:"body_match"    yaml:"body_match,omitempty"`    // regexp the response body must match
+	Timeout      string `mapstructure:"timeout"       yaml:"timeout,omitempty"`       // default 10s
+}
+
+// SmokeCheck is an HTTP request orbit verify makes against a service after
+// a deploy, with what the response must look like.
+type SmokeCheck struct {
+	Name    string          `mapstructure:"name"    yaml:"name,omitempty"`
+	Path    string          `mapstructure:"path"    yaml:"path"`              // appended to the service url, or a full URL
+	Method  string          `mapstructure:"method"  yaml:"method,omitempty"`  // default GET
+	Status  int             `mapstructure:"status"  yaml:"status,omitempty"`  // expected status; default any 2xx
+	JSON    []JSONAssertion `mapstructure:"json"    yaml:"json,omitempty"`    // fields the JSON response body must have
+	Timeout string          `mapstructure:"timeout" yaml:"timeout,omitempty"` // default 10s
+}
+
+// JSONAssertion requires a field of a JSON response, located by a JSONPath
+// such as "$.checks.db", to exist and, when Equals is set, to have that
+// value.
+type JSONAssertion struct {
+	Path   string `mapstructure:"path"   yaml:"path"`
+	Equals string `mapstructure:"equals" yaml:"equals,omitempty"`
 }
 
 // ProjectConfig represents a project with its service topology.
 type ProjectConfig struct {
 	Topology []ServiceEntry `mapstructure:"topology" yaml:"topology"`
+	// Views are named subsets of the topology, listing service names in
+	// the order they are shown, e.g. user-facing: [web, api].
+	Views map[string][]string `mapstructure:"views" yaml:"views,omitempty"`
+	// Alerts are evaluated by the heartbeat daemon and orbit alerts check.
+	Alerts []AlertRule `mapstructure:"alerts" yaml:"alerts,omitempty"`
+}
+
+// AlertRule raises an alert while a service's status meets a condition,
+// e.g. when: "status != healthy" for: 5m. See internal/alert for the syntax.
+type AlertRule struct {
+	Name     string `mapstructure:"name"     yaml:"name"`
+	Service  string `mapstructure:"service"  yaml:"service"`
+	When     string `mapstructure:"when"     yaml:"when"`               // status != healthy, response_time > 800, cpu, deploy failed
+	For      string `mapstructure:"for"      yaml:"for,omitempty"`      // how long the condition must hold before firing
+	Severity string `mapstructure:"severity" yaml:"severity,omitempty"` // default critical for status and deploy rules, warning otherwise
 }
 
 // PlatformConfig holds credentials for a connected platform.
 type PlatformConfig struct {
-	Token  string `mapstructure:"token"   yaml:"token"`
-	TeamID string `mapstructure:"team_id" yaml:"team_id,omitempty"`
+	Token    string              `mapstructure:"token"    yaml:"token"`
+	TeamID   string              `mapstructure:"team_id"  yaml:"team_id,omitempty"`
+	Endpoint string              `mapstructure:"endpoint" yaml:"endpoint,omitempty"` // API server URL (kubernetes)
+	HTTP     *HTTPPlatformConfig `mapstructure:"http"     yaml:"http,omitempty"`     // custom platform only
+
+	// RateLimit caps API requests per second across all concurrent calls
+	// with this token (0 = default, -1 = unlimited); RateBurst allows short
+	// bursts above it.
+	RateLimit float64 `mapstructure:"rate_limit" yaml:"rate_limit,omitempty"`
+	RateBurst int     `mapstructure:"rate_burst" yaml:"rate_burst,omitempty"`
+}
+
+// HTTPPlatformConfig describes a self-hosted service API for the custom platform.
+// URLs may contain {id} (service ID), {deploy_id} and {limit} placeholders.
+type HTTPPlatformConfig struct {
+	StatusURL   string       `mapstructure:"status_url"   yaml:"status_url"`
+	DeploysURL  string       `mapstructure:"deploys_url"  yaml:"deploys_url,omitempty"`
+	DeployURL   string       `mapstructure:"deploy_url"   yaml:"deploy_url,omitempty"`
+	LogsURL     string       `mapstructure:"logs_url"     yaml:"logs_url,omitempty"`
+	RedeployURL string       `mapstructure:"redeploy_url" yaml:"redeploy_url,omitempty"`
+	AuthHeader  string       `mapstructure:"auth_header"  yaml:"auth_header,omitempty"` // e.g. "X-API-Key: {token}"
+	Mappings    HTTPMappings `mapstructure:"mappings"     yaml:"mappings,omitempty"`
+}
+
+// HTTPMappings holds JSONPath expressions (e.g. "$.health.state") locating
+// Orbit fields in custom platform responses. Field order mirrors platform.HTTPMappings.
+type HTTPMappings struct {
+	Status       string            `mapstructure:"status"        yaml:"status,omitempty"`
+	StatusValues map[string]string `mapstructure:"status_values" yaml:"status_values,omitempty"`
+	ResponseMs   string            `mapstructure:"response_ms"   yaml:"response_ms,omitempty"`
+	CPU          string            `mapstructure:"cpu"           yaml:"cpu,omitempty"`
+	Memory       string            `mapstructure:"memory"        yaml:"memory,omitempty"`
+	Instances    string            `mapstructure:"instances"     yaml:"instances,omitempty"`
+	MaxInstances string            `mapstructure:"max_instances" yaml:"max_instances,omitempty"`
+
+	Deploys            string            `mapstructure:"deploys"              yaml:"deploys,omitempty"`
+	DeployID           string            `mapstructure:"deploy_id"            yaml:"deploy_id,omitempty"`
+	DeployStatus       string            `mapstructure:"deploy_status"        yaml:"deploy_status,omitempty"`
+	DeployStatusValues map[string]string `mapstructure:"deploy_status_values" yaml:"deploy_status_values,omitempty"`
+	DeployCommit       string            `mapstructure:"deploy_commit"        yaml:"deploy_commit,omitempty"`
+	DeployMessage      string            `mapstructure:"deploy_message"       yaml:"deploy_message,omitempty"`
+	DeployCreatedAt    string            `mapstructure:"deploy_created_at"    yaml:"deploy_created_at,omitempty"`
+	DeployURL          string            `mapstructure:"deploy_url"           yaml:"deploy_url,omitempty"`
+
+	Logs         string `mapstructure:"logs"          yaml:"logs,omitempty"`
+	LogTimestamp string `mapstructure:"log_timestamp" yaml:"log_timestamp,omitempty"`
+	LogLevel     string `mapstructure:"log_level"     yaml:"log_level,omitempty"`
+	LogMessage   string `mapstructure:"log_message"   yaml:"log_message,omitempty"`
+}
+
+// PluginConfig declares an external platform adapter: an executable that
+// speaks Orbit's JSON-over-stdio protocol. The plugin's name is used like a
+// built-in platform name (orbit connect <name>, platform: <name>).
+type PluginConfig struct {
+	Command string            `mapstructure:"command" yaml:"command"`
+	Args    []string          `mapstructure:"args"    yaml:"args,omitempty"`
+	Env     map[string]string `mapstructure:"env"     yaml:"env,omitempty"`
+
+	// Capabilities lists what the adapter implements (e.g. [deployments,
+	// logs, watch]); empty means everything.
+	Capabilities []string `mapstructure:"capabilities" yaml:"capabilities,omitempty"`
+}
+
+// ThemeConfig selects the UI color palette: a named preset (dark, light,
+// monochrome, solarized) with optional per-color overrides.
+type ThemeConfig struct {
+	Preset   string `mapstructure:"preset"   yaml:"preset,omitempty"`
+	Healthy  string `mapstructure:"healthy"  yaml:"healthy,omitempty"`
+	Warning  string `mapstructure:"warning"  yaml:"warning,omitempty"`
+	Error    string `mapstructure:"error"    yaml:"error,omitempty"`
+	Sleeping string `mapstructure:"sleeping" yaml:"sleeping,omitempty"`
+	Primary  string `mapstructure:"primary"  yaml:"primary,omitempty"`
+	Muted    string `mapstructure:"muted"    yaml:"muted,omitempty"`
+}
+
+// IconsConfig selects the status markers: a named set (emoji, ascii, or
+// auto to pick by terminal) with optional per-icon overrides.
+type IconsConfig struct {
+	Set      string `mapstructure:"set"      yaml:"set,omitempty"`
+	Healthy  string `mapstructure:"healthy"  yaml:"healthy,omitempty"`
+	Warning  string `mapstructure:"warning"  yaml:"warning,omitempty"`
+	Error    string `mapstructure:"error"    yaml:"error,omitempty"`
+	Sleeping string `mapstructure:"sleeping" yaml:"sleeping,omitempty"`
+	Building string `mapstructure:"building" yaml:"building,omitempty"`
+	Deploy   string `mapstructure:"deploy"   yaml:"deploy,omitempty"`
+	Watch    string `mapstructure:"watch"    yaml:"watch,omitempty"`
+	Timeout  string `mapstructure:"timeout"  yaml:"timeout,omitempty"`
+	Success  string `mapstructure:"success"  yaml:"success,omitempty"`
+	Failed   string `mapstructure:"failed"   yaml:"failed,omitempty"`
+	Rocket   string `mapstructure:"rocket"   yaml:"rocket,omitempty"`
+	Health   string `mapstructure:"health"   yaml:"health,omitempty"`
 }
 
 // ThresholdConfig holds alerting thresholds.
 type ThresholdConfig struct {
-	ResponseTimeMs int `mapstructure:"response_time_ms" yaml:"response_time_ms"`
-	CPUPercent     int `mapstructure:"cpu_percent"      yaml:"cpu_percent"`
-	MemoryPercent  int `mapstructure:"memory_percent"   yaml:"memory_percent"`
+	ResponseTimeMs    int `mapstructure:"response_time_ms"    yaml:"response_time_ms"`
+	CPUPercent        int `mapstructure:"cpu_percent"         yaml:"cpu_percent"`
+	MemoryPercent     int `mapstructure:"memory_percent"      yaml:"memory_percent"`
+	SizeGrowthPercent int `mapstructure:"size_growth_percent" yaml:"size_growth_percent"` // warn when a build artifact grows this much
+}
+
+// SLOConfig sets the objectives orbit uptime measures recorded history
+// against. Zero fields have no objective.
+type SLOConfig struct {
+	Uptime     float64 `mapstructure:"uptime"      yaml:"uptime,omitempty"`      // percent, e.g. 99.9
+	ResponseMs int     `mapstructure:"response_ms" yaml:"response_ms,omitempty"` // mean response time
+}
+
+// WatchConfig customizes how a multi-service watch turns per-service results
+// (failed, timeout, no_deployment) into one exit code, how long and how
+// often it waits, and where it reports progress.
+type WatchConfig struct {
+	Priority       []string `mapstructure:"priority"        yaml:"priority,omitempty"`        // most severe first; default failed, timeout, no_deployment
+	OptionalIgnore []string `mapstructure:"optional_ignore" yaml:"optional_ignore,omitempty"` // results of optional services to ignore; default no_deployment
+	DetectTimeout  string   `mapstructure:"detect_timeout"  yaml:"detect_timeout,omitempty"`  // how long to wait for a deploy to appear, e.g. "3m"; default 60s
+	PollInterval   string   `mapstructure:"poll_interval"   yaml:"poll_interval,omitempty"`   // how often to poll the platform, e.g. "10s"; default 3s
+	Notify         []string `mapstructure:"notify"          yaml:"notify,omitempty"`          // notification channels that get progress and results
+	NotifyURL      string   `mapstructure:"notify_url"      yaml:"notify_url,omitempty"`      // Slack, Discord or generic webhook URL that gets them too
+}
+
+// HooksConfig lists shell commands orbit runs on lifecycle events; see
+// internal/hook. A single command may be given as a plain string.
+type HooksConfig struct {
+	OnDeploySuccess      []string `mapstructure:"on_deploy_success"      yaml:"on_deploy_success,omitempty"`
+	OnDeployFailed       []string `mapstructure:"on_deploy_failed"       yaml:"on_deploy_failed,omitempty"`
+	OnThresholdViolation []string `mapstructure:"on_threshold_violation" yaml:"on_threshold_violation,omitempty"`
+	Timeout              string   `mapstructure:"timeout"                yaml:"timeout,omitempty"` // per command, e.g. "1m"; default 30s
+}
+
+// Empty reports whether no hook is configured.
+func (h HooksConfig) Empty() bool {
+	return len(h.OnDeploySuccess)+len(h.OnDeployFailed)+len(h.OnThresholdViolation) == 0
+}
+
+// GitHubConfig holds credentials for the GitHub integration (PR comments).
+type GitHubConfig struct {
+	Token string `mapstructure:"token" yaml:"token,omitempty"`
+	Repo  string `mapstructure:"repo"  yaml:"repo,omitempty"`
+}
+
+// GitLabConfig holds credentials for the GitLab integration (commit statuses).
+type GitLabConfig struct {
+	Token   string `mapstructure:"token"   yaml:"token,omitempty"`
+	Project string `mapstructure:"project" yaml:"project,omitempty"`
+	URL     string `mapstructure:"url"     yaml:"url,omitempty"`
+}
+
+// BitbucketConfig holds credentials for the Bitbucket Cloud integration (build statuses).
+// Username is only needed when Token is an app password.
+type BitbucketConfig struct {
+	Token    string `mapstructure:"token"    yaml:"token,omitempty"`
+	Username string `mapstructure:"username" yaml:"username,omitempty"`
+	Repo     string `mapstructure:"repo"     yaml:"repo,omitempty"`
+}
+
+// IntegrationsConfig holds settings for CI and source-hosting integrations.
+type IntegrationsConfig struct {
+	GitHub    GitHubConfig    `mapstructure:"github"    yaml:"github,omitempty"`
+	GitLab    GitLabConfig    `mapstructure:"gitlab"    yaml:"gitlab,omitempty"`
+	Bitbucket BitbucketConfig `mapstructure:"bitbucket" yaml:"bitbucket,omitempty"`
+}
+
+// NotifyChannel configures a notification destination.
+type NotifyChannel struct {
+	Type       string      `mapstructure:"type"        yaml:"type"` // slack, discord, webhook, email
+	URL        string      `mapstructure:"url"         yaml:"url,omitempty"`
+	Digest     string      `mapstructure:"digest"      yaml:"digest,omitempty"` // e.g. "1h"; empty sends each event immediately
+	QuietHours *QuietHours `mapstructure:"quiet_hours" yaml:"quiet_hours,omitempty"`
+	Script     string      `mapstructure:"script"      yaml:"script,omitempty"`   // policy script function that builds the payload
+	Template   string      `mapstructure:"template"    yaml:"template,omitempty"` // Go template for the message text or webhook body
+
+	// Email channels only.
+	SMTP     string   `mapstructure:"smtp"     yaml:"smtp,omitempty"` // host:port; port 465 uses implicit TLS
+	From     string   `mapstructure:"from"     yaml:"from,omitempty"`
+	To       []string `mapstructure:"to"       yaml:"to,omitempty"`
+	Username string   `mapstructure:"username" yaml:"username,omitempty"`
+	Password string   `mapstructure:"password" yaml:"password,omitempty"` // encrypted; default $ORBIT_SMTP_PASSWORD
+}
+
+// QuietHours holds back lower-severity events during a daily window and/or
+// on whole days. Held events are delivered once quiet hours end.
+type QuietHours struct {
+	Start    string   `mapstructure:"start"    yaml:"start,omitempty"`    // "22:00"
+	End      string   `mapstructure:"end"      yaml:"end,omitempty"`      // "07:00"; may wrap past midnight
+	Days     []string `mapstructure:"days"     yaml:"days,omitempty"`     // quiet all day, e.g. [sat, sun]
+	Timezone string   `mapstructure:"timezone" yaml:"timezone,omitempty"` // IANA name; default local time
+	Override string   `mapstructure:"override" yaml:"override,omitempty"` // lowest severity still delivered; default critical
+}
+
+// RouteMatch selects events for a route. Empty fields match anything;
+// project, service, tag and event accept glob patterns (e.g. "web-*").
+type RouteMatch struct {
+	Project  string `mapstructure:"project"  yaml:"project,omitempty"`
+	Service  string `mapstructure:"service"  yaml:"service,omitempty"`
+	Tag      string `mapstructure:"tag"      yaml:"tag,omitempty"`
+	Severity string `mapstructure:"severity" yaml:"severity,omitempty"` // minimum severity
+	Event    string `mapstructure:"event"    yaml:"event,omitempty"`    // e.g. deploy_failed, threshold_violation
+}
+
+// NotifyRoute sends matching events to the listed channels.
+// Routes are evaluated in order; the first match wins unless Continue is set.
+type NotifyRoute struct {
+	Match    RouteMatch `mapstructure:"match"    yaml:"match"`
+	Channels []string   `mapstructure:"channels" yaml:"channels"`
+	Continue bool       `mapstructure:"continue" yaml:"continue,omitempty"`
+}
+
+// NotificationsConfig holds the configured notification channels, keyed by name,
+// and the routing rules that decide which channels receive an event.
+// Without routes every event goes to every channel.
+type NotificationsConfig struct {
+	Channels map[string]NotifyChannel `mapstructure:"channels" yaml:"channels,omitempty"`
+	Routes   []NotifyRoute            `mapstructure:"routes"   yaml:"routes,omitempty"`
+	Default  []string                 `mapstructure:"default"  yaml:"default,omitempty"` // channels for events no route matches
+	Reports  []ReportConfig           `mapstructure:"reports"  yaml:"reports,omitempty"`
+}
+
+// ReportConfig schedules a summary report sent by the heartbeat daemon.
+type ReportConfig struct {
+	Every    string   `mapstructure:"every"    yaml:"every"`              // daily, weekly
+	At       string   `mapstructure:"at"       yaml:"at,omitempty"`       // "09:00" (default)
+	Day      string   `mapstructure:"day"      yaml:"day,omitempty"`      // weekly: mon..sun; default mon
+	Timezone string   `mapstructure:"timezone" yaml:"timezone,omitempty"` // IANA name; default local time
+	Project  string   `mapstructure:"project"  yaml:"project,omitempty"`  // default: every project with a running daemon
+	Channels []string `mapstructure:"channels" yaml:"channels,omitempty"` // default: channels selected by routing
 }
 
 // Config is the top-level configuration for Orbit.
 type Config struct {
-	DefaultProject string                   `mapstructure:"default_project" yaml:"default_project"`
-	Platforms      map[string]PlatformConfig `mapstructure:"platforms"       yaml:"platforms"`
-	Projects       map[string]ProjectConfig  `mapstructure:"projects"        yaml:"projects"`
-	Thresholds     ThresholdConfig           `mapstructure:"thresholds"      yaml:"thresholds"`
+	DefaultProject string                        `mapstructure:"default_project" yaml:"default_project"`
+	Platforms      map[string]PlatformConfig     `mapstructure:"platforms"       yaml:"platforms"`
+	Projects       map[string]ProjectConfig      `mapstructure:"projects"        yaml:"projects"`
+	Thresholds     ThresholdConfig               `mapstructure:"thresholds"      yaml:"thresholds"`
+	Watch          WatchConfig                   `mapstructure:"watch"           yaml:"watch,omitempty"`
+	Integrations   IntegrationsConfig            `mapstructure:"integrations"    yaml:"integrations,omitempty"`
+	Notifications  NotificationsConfig           `mapstructure:"notifications"   yaml:"notifications,omitempty"`
+	Hooks          HooksConfig                   `mapstructure:"hooks"           yaml:"hooks,omitempty"`
+	Trash          map[string]TrashedProject     `mapstructure:"trash"           yaml:"trash,omitempty"`
+	Plugins        map[string]PluginConfig       `mapstructure:"plugins"         yaml:"plugins,omitempty"`
+	Theme          ThemeConfig                   `mapstructure:"theme"           yaml:"theme,omitempty"`
+	Icons          IconsConfig                   `mapstructure:"icons"           yaml:"icons,omitempty"`
+	Hints          *bool                         `mapstructure:"hints"           yaml:"hints,omitempty"`  // nil means on
+	Script         string                        `mapstructure:"script"          yaml:"script,omitempty"` // Starlark policy file; see internal/script
+	SLO            SLOConfig                     `mapstructure:"slo"             yaml:"slo,omitempty"`
+	TraceHeader    bool                          `mapstructure:"trace_header"    yaml:"trace_header,omitempty"` // send the trace ID in User-Agent
+	Pricing        map[string]map[string]float64 `mapstructure:"pricing"         yaml:"pricing,omitempty"`      // monthly USD per instance, by platform and instance type
+}
+
+// HintsEnabled reports whether next-step hints are printed after commands
+// like connect and project create. They are on unless set to false.
+func (c *Config) HintsEnabled() bool {
+	return c.Hints == nil || *c.Hints
 }
 
-// Dir returns the path to the Orbit config directory (~/.orbit/).
+// SLOFor returns the objectives for a service: the global ones, with any
+// the service sets itself taking precedence.
+func (c *Config) SLOFor(e ServiceEntry) SLOConfig {
+	slo := c.SLO
+	if e.SLO != nil {
+		if e.SLO.Uptime > 0 {
+			slo.Uptime = e.SLO.Uptime
+		}
+		if e.SLO.ResponseMs > 0 {
+			slo.ResponseMs = e.SLO.ResponseMs
+		}
+	}
+	return slo
+}
+
+// EnvDir names the environment variable that moves the config directory,
+// e.g. for the throwaway config of an orbit demo session.
+const EnvDir = "ORBIT_HOME"
+
+// Dir returns the path to the Orbit config directory (~/.orbit/, or
+// $ORBIT_HOME when set). On Windows the home directory is %USERPROFILE%.
 func Dir() (string, error) {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		return dir, nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("get home dir: %w", err)
@@ -82,6 +404,7 @@ func Load() (*Config, error) {
 	v.SetDefault("thresholds.response_time_ms", 500)
 	v.SetDefault("thresholds.cpu_percent", 80)
 	v.SetDefault("thresholds.memory_percent", 85)
+	v.SetDefault("thresholds.size_growth_percent", 40)
 
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -119,6 +442,42 @@ func Save(cfg *Config) error {
 	v.Set("platforms", cfg.Platforms)
 	v.Set("projects", cfg.Projects)
 	v.Set("thresholds", cfg.Thresholds)
+	if w := cfg.Watch; len(w.Priority) > 0 || len(w.OptionalIgnore) > 0 || w.DetectTimeout != "" || w.PollInterval != "" ||
+		len(w.Notify) > 0 || w.NotifyURL != "" {
+		v.Set("watch", cfg.Watch)
+	}
+	v.Set("integrations", cfg.Integrations)
+	v.Set("notifications", cfg.Notifications)
+	if !cfg.Hooks.Empty() || cfg.Hooks.Timeout != "" {
+		v.Set("hooks", cfg.Hooks)
+	}
+	if len(cfg.Trash) > 0 {
+		v.Set("trash", cfg.Trash)
+	}
+	if len(cfg.Plugins) > 0 {
+		v.Set("plugins", cfg.Plugins)
+	}
+	if cfg.Theme != (ThemeConfig{}) {
+		v.Set("theme", cfg.Theme)
+	}
+	if cfg.Icons != (IconsConfig{}) {
+		v.Set("icons", cfg.Icons)
+	}
+	if cfg.Hints != nil {
+		v.Set("hints", *cfg.Hints)
+	}
+	if cfg.Script != "" {
+		v.Set("script", cfg.Script)
+	}
+	if cfg.SLO != (SLOConfig{}) {
+		v.Set("slo", cfg.SLO)
+	}
+	if cfg.TraceHeader {
+		v.Set("trace_header", true)
+	}
+	if len(cfg.Pricing) > 0 {
+		v.Set("pricing", cfg.Pricing)
+	}
 
 	path := filepath.Join(dir, "config.yaml")
 	return v.WriteConfigAs(path)
@@ -6,9 +6,17 @@ import (
 	"testing"
 )
 
+// setHome points the home directory at dir on every OS; Windows reads
+// %USERPROFILE% rather than $HOME.
+func setHome(t *testing.T, dir string) {
+	t.Helper()
+	t.Setenv("HOME", dir)
+	t.Setenv("USERPROFILE", dir)
+}
+
 func TestLoadDefaultConfig(t *testing.T) {
 	tmpHome := t.TempDir()
-	t.Setenv("HOME", tmpHome)
+	setHome(t, tmpHome)
 
 	cfg, err := Load()
 	if err != nil {
@@ -25,6 +33,9 @@ func TestLoadDefaultConfig(t *testing.T) {
 	if cfg.Thresholds.MemoryPercent != 85 {
 		t.Errorf("MemoryPercent: got %d, want 85", cfg.Thresholds.MemoryPercent)
 	}
+	if cfg.Thresholds.SizeGrowthPercent != 40 {
+		t.Errorf("SizeGrowthPercent: got %d, want 40", cfg.Thresholds.SizeGrowthPercent)
+	}
 
 	// Maps should be initialized
 	if cfg.Platforms == nil {
@@ -37,7 +48,7 @@ func TestLoadDefaultConfig(t *testing.T) {
 
 func TestSaveAndLoad(t *testing.T) {
 	tmpHome := t.TempDir()
-	t.Setenv("HOME", tmpHome)
+	setHome(t, tmpHome)
 
 	original := &Config{
 		DefaultProject: "myshop",
@@ -58,6 +69,9 @@ func TestSaveAndLoad(t *testing.T) {
 			CPUPercent:     70,
 			MemoryPercent:  75,
 		},
+		Integrations: IntegrationsConfig{
+			GitHub: GitHubConfig{Token: "ENC:ghi789", Repo: "acme/myshop"},
+		},
 	}
 
 	if err := Save(original); err != nil {
@@ -98,11 +112,15 @@ func TestSaveAndLoad(t *testing.T) {
 	if loaded.Thresholds.ResponseTimeMs != 300 {
 		t.Errorf("ResponseTimeMs: got %d, want 300", loaded.Thresholds.ResponseTimeMs)
 	}
+
+	if loaded.Integrations.GitHub.Repo != "acme/myshop" {
+		t.Errorf("GitHub repo: got %q, want %q", loaded.Integrations.GitHub.Repo, "acme/myshop")
+	}
 }
 
 func TestEnsureDir(t *testing.T) {
 	tmpHome := t.TempDir()
-	t.Setenv("HOME", tmpHome)
+	setHome(t, tmpHome)
 
 	dir, err := EnsureDir()
 	if err != nil {
@@ -120,3 +138,50 @@ func TestEnsureDir(t *testing.T) {
 		t.Errorf("dir permissions: got %o, want 0700", perm)
 	}
 }
+
+func TestUniqueServiceName(t *testing.T) {
+	taken := map[string]bool{"api": true, "api-koyeb": true, "web": true}
+
+	tests := []struct {
+		name, platform, want string
+	}{
+		{"worker", "koyeb", "worker"},
+		{"api", "flyio", "api-flyio"},
+		{"api", "koyeb", "api-koyeb-2"},
+	}
+	for _, tt := range tests {
+		if got := UniqueServiceName(tt.name, tt.platform, taken); got != tt.want {
+			t.Errorf("UniqueServiceName(%q, %q) = %q, want %q", tt.name, tt.platform, got, tt.want)
+		}
+	}
+
+	dups := DuplicateServiceNames([]ServiceEntry{{Name: "api"}, {Name: "web"}, {Name: "api"}, {Name: "api"}})
+	if len(dups) != 1 || dups[0] != "api" {
+		t.Errorf("DuplicateServiceNames = %v, want [api]", dups)
+	}
+}
+
+func TestHintsSetting(t *testing.T) {
+	setHome(t, t.TempDir())
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.HintsEnabled() {
+		t.Error("hints should be on by default")
+	}
+
+	off := false
+	cfg.Hints = &off
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.HintsEnabled() {
+		t.Error("hints: false did not survive a save")
+	}
+}
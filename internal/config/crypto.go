@@ -17,18 +17,20 @@ const (
 	keySize   = 32 // AES-256
 )
 
-func keyFilePath() (string, error) {
-	home, err := os.UserHomeDir()
+// KeyPath returns the encryption key location, ~/.orbit/key.
+func KeyPath() (string, error) {
+	dir, err := Dir()
 	if err != nil {
-		return "", fmt.Errorf("get home dir: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, ".orbit", "key"), nil
+	return filepath.Join(dir, "key"), nil
 }
 
 // LoadOrCreateKey reads the AES-256 key from ~/.orbit/key.
-// If the file does not exist, a new random key is generated and saved with 0600 permissions.
+// If the file does not exist, a new random key is generated and saved
+// readable by the current user only (see restrictKeyFile).
 func LoadOrCreateKey() ([]byte, error) {
-	path, err := keyFilePath()
+	path, err := KeyPath()
 	if err != nil {
 		return nil, err
 	}
@@ -40,14 +42,10 @@ func LoadOrCreateKey() ([]byte, error) {
 
 	data, err := os.ReadFile(path)
 	if err == nil {
-		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
-		if err != nil {
-			return nil, fmt.Errorf("decode key file: %w", err)
+		if err := restrictKeyFile(path); err != nil {
+			return nil, err
 		}
-		if len(key) != keySize {
-			return nil, fmt.Errorf("invalid key length: got %d, want %d", len(key), keySize)
-		}
-		return key, nil
+		return decodeKey(data)
 	}
 
 	if !os.IsNotExist(err) {
@@ -68,6 +66,31 @@ func LoadOrCreateKey() ([]byte, error) {
 	return key, nil
 }
 
+// ReadKey reads the key saved by LoadOrCreateKey without creating one or
+// changing its permissions. The error wraps os.ErrNotExist if there is none.
+func ReadKey() ([]byte, error) {
+	path, err := KeyPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+	return decodeKey(data)
+}
+
+func decodeKey(data []byte) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decode key file: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid key length: got %d, want %d", len(key), keySize)
+	}
+	return key, nil
+}
+
 // Encrypt encrypts plaintext using AES-256-GCM and returns a string prefixed with "ENC:".
 func Encrypt(key []byte, plaintext string) (string, error) {
 	block, err := aes.NewCipher(key)
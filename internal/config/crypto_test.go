@@ -2,9 +2,11 @@ package config
 
 import (
 	"crypto/rand"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -85,7 +87,7 @@ func TestDecryptWrongKey(t *testing.T) {
 func TestLoadOrCreateKey(t *testing.T) {
 	// Use a temp home dir to avoid touching the real ~/.orbit/
 	tmpHome := t.TempDir()
-	t.Setenv("HOME", tmpHome)
+	setHome(t, tmpHome)
 
 	// First call should create the key
 	key1, err := LoadOrCreateKey()
@@ -96,14 +98,16 @@ func TestLoadOrCreateKey(t *testing.T) {
 		t.Fatalf("key length: got %d, want %d", len(key1), keySize)
 	}
 
-	// Verify file permissions
+	// Verify file permissions (Windows relies on the profile's ACL instead)
 	keyPath := filepath.Join(tmpHome, ".orbit", "key")
-	info, err := os.Stat(keyPath)
-	if err != nil {
-		t.Fatalf("stat key file: %v", err)
-	}
-	if perm := info.Mode().Perm(); perm != 0600 {
-		t.Errorf("key file permissions: got %o, want 0600", perm)
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(keyPath)
+		if err != nil {
+			t.Fatalf("stat key file: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("key file permissions: got %o, want 0600", perm)
+		}
 	}
 
 	// Second call should load the same key
@@ -115,4 +119,46 @@ func TestLoadOrCreateKey(t *testing.T) {
 	if string(key1) != string(key2) {
 		t.Error("loaded key differs from created key")
 	}
+
+	// A key file readable by others is tightened on load.
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(keyPath, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadOrCreateKey(); err != nil {
+			t.Fatalf("LoadOrCreateKey (loose perms): %v", err)
+		}
+		info, err := os.Stat(keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("key file permissions after load: got %o, want 0600", perm)
+		}
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	setHome(t, t.TempDir())
+
+	if _, err := ReadKey(); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("ReadKey before any key: err = %v, want ErrNotExist", err)
+	}
+	created, err := LoadOrCreateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadKey()
+	if err != nil {
+		t.Fatalf("ReadKey: %v", err)
+	}
+	if string(read) != string(created) {
+		t.Error("ReadKey returned a different key")
+	}
+
+	path, _ := KeyPath()
+	os.WriteFile(path, []byte("c2hvcnQ="), 0600)
+	if _, err := ReadKey(); err == nil {
+		t.Error("ReadKey accepted a short key")
+	}
 }
@@ -0,0 +1,30 @@
+package config
+
+import "fmt"
+
+// UniqueServiceName returns name if it is free, otherwise name suffixed with
+// the platform ("api-koyeb"), then with a counter ("api-koyeb-2").
+func UniqueServiceName(name, platform string, taken map[string]bool) string {
+	if !taken[name] {
+		return name
+	}
+	candidate := name + "-" + platform
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%s-%d", name, platform, i)
+	}
+	return candidate
+}
+
+// DuplicateServiceNames returns the names used by more than one entry,
+// in order of first appearance.
+func DuplicateServiceNames(entries []ServiceEntry) []string {
+	count := make(map[string]int)
+	var dups []string
+	for _, e := range entries {
+		count[e.Name]++
+		if count[e.Name] == 2 {
+			dups = append(dups, e.Name)
+		}
+	}
+	return dups
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// restrictKeyFile tightens a key file that group or others can read (e.g.
+// after being copied between machines) back to 0600.
+func restrictKeyFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat key file: %w", err)
+	}
+	if info.Mode().Perm()&0077 == 0 {
+		return nil
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("restrict key file permissions: %w", err)
+	}
+	return nil
+}
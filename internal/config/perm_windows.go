@@ -0,0 +1,10 @@
+//go:build windows
+
+package config
+
+// restrictKeyFile is a no-op on Windows: Unix permission bits don't map to
+// NTFS ACLs, and files under %USERPROFILE% are private to the user by
+// default.
+func restrictKeyFile(path string) error {
+	return nil
+}
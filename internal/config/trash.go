@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// TrashRetention is how long a deleted project can be restored.
+const TrashRetention = 30 * 24 * time.Hour
+
+// TrashedProject is a deleted project kept for TrashRetention.
+type TrashedProject struct {
+	Project   ProjectConfig `mapstructure:"project"    yaml:"project"`
+	DeletedAt time.Time     `mapstructure:"deleted_at" yaml:"deleted_at"`
+	Default   bool          `mapstructure:"default"    yaml:"default,omitempty"` // was the default project
+}
+
+// ExpiresAt returns when the trashed project is purged.
+func (t TrashedProject) ExpiresAt() time.Time {
+	return t.DeletedAt.Add(TrashRetention)
+}
+
+// TrashDir returns the directory holding a trashed project's files,
+// ~/.orbit/trash/<name>/.
+func TrashDir(name string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "trash", name), nil
+}
+
+// TrashProject moves a project from Projects to Trash, replacing any older
+// trashed copy of the same name. The caller saves the config.
+func TrashProject(cfg *Config, name string, now time.Time) error {
+	proj, ok := cfg.Projects[name]
+	if !ok {
+		return fmt.Errorf("project %q not found", name)
+	}
+	if cfg.Trash == nil {
+		cfg.Trash = make(map[string]TrashedProject)
+	}
+	cfg.Trash[name] = TrashedProject{
+		Project:   proj,
+		DeletedAt: now,
+		Default:   cfg.DefaultProject == name,
+	}
+	delete(cfg.Projects, name)
+	if cfg.DefaultProject == name {
+		cfg.DefaultProject = ""
+	}
+	return nil
+}
+
+// RestoreProject moves a project from Trash back to Projects. It fails if
+// a project with the same name has been created since.
+func RestoreProject(cfg *Config, name string) error {
+	t, ok := cfg.Trash[name]
+	if !ok {
+		return fmt.Errorf("project %q is not in the trash", name)
+	}
+	if _, exists := cfg.Projects[name]; exists {
+		return fmt.Errorf("project %q already exists", name)
+	}
+	cfg.Projects[name] = t.Project
+	if t.Default && cfg.DefaultProject == "" {
+		cfg.DefaultProject = name
+	}
+	delete(cfg.Trash, name)
+	return nil
+}
+
+// PurgeTrash removes trashed projects older than TrashRetention, along with
+// their trash directories, and returns the purged names in sorted order.
+// The caller saves the config.
+func PurgeTrash(cfg *Config, now time.Time) []string {
+	var purged []string
+	for name, t := range cfg.Trash {
+		if now.Before(t.ExpiresAt()) {
+			continue
+		}
+		delete(cfg.Trash, name)
+		if dir, err := TrashDir(name); err == nil {
+			os.RemoveAll(dir)
+		}
+		purged = append(purged, name)
+	}
+	sort.Strings(purged)
+	return purged
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrashAndRestoreProject(t *testing.T) {
+	tmpHome := t.TempDir()
+	setHome(t, tmpHome)
+
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &Config{
+		DefaultProject: "myshop",
+		Platforms:      map[string]PlatformConfig{},
+		Projects: map[string]ProjectConfig{
+			"myshop": {Topology: []ServiceEntry{{Name: "api", Platform: "koyeb", ID: "svc_1"}}},
+		},
+	}
+
+	if err := TrashProject(cfg, "myshop", deletedAt); err != nil {
+		t.Fatalf("TrashProject: %v", err)
+	}
+	if _, ok := cfg.Projects["myshop"]; ok || cfg.DefaultProject != "" {
+		t.Fatal("project should be removed and default cleared")
+	}
+
+	// The trash survives a save/load round trip.
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	trashed, ok := loaded.Trash["myshop"]
+	if !ok {
+		t.Fatal("trashed project missing after reload")
+	}
+	if !trashed.DeletedAt.Equal(deletedAt) {
+		t.Errorf("DeletedAt: got %v, want %v", trashed.DeletedAt, deletedAt)
+	}
+
+	if purged := PurgeTrash(loaded, deletedAt.Add(TrashRetention-time.Hour)); len(purged) != 0 {
+		t.Errorf("purged too early: %v", purged)
+	}
+
+	if err := RestoreProject(loaded, "myshop"); err != nil {
+		t.Fatalf("RestoreProject: %v", err)
+	}
+	if got := loaded.Projects["myshop"].Topology; len(got) != 1 || got[0].ID != "svc_1" {
+		t.Errorf("restored topology: %+v", got)
+	}
+	if loaded.DefaultProject != "myshop" {
+		t.Errorf("DefaultProject: got %q, want myshop", loaded.DefaultProject)
+	}
+	if err := RestoreProject(loaded, "myshop"); err == nil {
+		t.Error("expected error restoring a project that is not in the trash")
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	setHome(t, t.TempDir())
+
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	cfg := &Config{Trash: map[string]TrashedProject{
+		"old":    {DeletedAt: now.Add(-TrashRetention - time.Minute)},
+		"recent": {DeletedAt: now.Add(-time.Hour)},
+	}}
+
+	purged := PurgeTrash(cfg, now)
+	if len(purged) != 1 || purged[0] != "old" {
+		t.Errorf("purged: got %v, want [old]", purged)
+	}
+	if _, ok := cfg.Trash["recent"]; !ok {
+		t.Error("recent project should still be in the trash")
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Path returns the config file location, ~/.orbit/config.yaml.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Parse decodes config YAML strictly: unknown keys and values of the wrong
+// type are errors, reported with their line, where Load would silently
+// ignore or misread them. The result is checked with Validate.
+func Parse(data []byte) (*Config, error) {
+	cfg := Config{Thresholds: ThresholdConfig{ResponseTimeMs: 500, CPUPercent: 80, MemoryPercent: 85, SizeGrowthPercent: 40}}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if cfg.Platforms == nil {
+		cfg.Platforms = make(map[string]PlatformConfig)
+	}
+	if cfg.Projects == nil {
+		cfg.Projects = make(map[string]ProjectConfig)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks references within the config: the default project,
+// services, views and notification channels. All problems are returned
+// together.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.DefaultProject != "" {
+		if _, ok := c.Projects[c.DefaultProject]; !ok {
+			errs = append(errs, fmt.Errorf("default_project %q is not a project", c.DefaultProject))
+		}
+	}
+
+	names := make([]string, 0, len(c.Projects))
+	for name := range c.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := c.Projects[name]
+		services := make(map[string]bool, len(p.Topology))
+		for i, e := range p.Topology {
+			switch {
+			case e.Name == "":
+				errs = append(errs, fmt.Errorf("project %q: service #%d has no name", name, i+1))
+			case e.Platform == "":
+				errs = append(errs, fmt.Errorf("project %q: service %q has no platform", name, e.Name))
+			case e.ID == "":
+				errs = append(errs, fmt.Errorf("project %q: service %q has no id", name, e.Name))
+			}
+			services[e.Name] = true
+		}
+		for _, dup := range DuplicateServiceNames(p.Topology) {
+			errs = append(errs, fmt.Errorf("project %q: service name %q is used more than once", name, dup))
+		}
+		for _, view := range p.ViewNames() {
+			for _, s := range p.Views[view] {
+				if !services[s] {
+					errs = append(errs, fmt.Errorf("project %q: view %q lists unknown service %q", name, view, s))
+				}
+			}
+		}
+		for _, a := range p.Alerts {
+			if !services[a.Service] {
+				errs = append(errs, fmt.Errorf("project %q: alert %q watches unknown service %q", name, a.Name, a.Service))
+			}
+		}
+	}
+
+	channel := func(where, ch string) {
+		if _, ok := c.Notifications.Channels[ch]; !ok {
+			errs = append(errs, fmt.Errorf("notifications: %s uses unknown channel %q", where, ch))
+		}
+	}
+	for i, r := range c.Notifications.Routes {
+		for _, ch := range r.Channels {
+			channel(fmt.Sprintf("route #%d", i+1), ch)
+		}
+	}
+	for _, ch := range c.Notifications.Default {
+		channel("default", ch)
+	}
+	for i, r := range c.Notifications.Reports {
+		for _, ch := range r.Channels {
+			channel(fmt.Sprintf("report #%d", i+1), ch)
+		}
+	}
+	return errors.Join(errs...)
+}
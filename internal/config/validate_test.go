@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(`default_project: shop
+projects:
+  shop:
+    topology:
+      - {name: api, platform: koyeb, id: svc-1}
+      - {name: web, platform: vercel, id: prj_1}
+    views:
+      user-facing: [web, api]
+thresholds:
+  cpu_percent: 90
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Thresholds.CPUPercent != 90 || cfg.Thresholds.ResponseTimeMs != 500 {
+		t.Errorf("thresholds = %+v, want cpu 90 and the default response time", cfg.Thresholds)
+	}
+	if cfg.Platforms == nil || len(cfg.Projects["shop"].Topology) != 2 {
+		t.Errorf("got %+v", cfg)
+	}
+
+	if cfg, err := Parse(nil); err != nil || cfg.Projects == nil {
+		t.Errorf("empty file: %+v, %v", cfg, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"syntax", "projects: [oops\n", "line"},
+		{"unknown key", "projects:\n  shop:\n    topolgy: []\n", "topolgy"},
+		{"wrong type", "thresholds:\n  cpu_percent: high\n", "high"},
+		{"default project", "default_project: shop\n", `default_project "shop"`},
+		{"missing id", "projects:\n  shop:\n    topology: [{name: api, platform: koyeb}]\n", `service "api" has no id`},
+		{"duplicate", "projects:\n  shop:\n    topology: [{name: api, platform: koyeb, id: a}, {name: api, platform: koyeb, id: b}]\n", "more than once"},
+		{"view", "projects:\n  shop:\n    topology: []\n    views: {main: [api]}\n", `unknown service "api"`},
+		{"channel", "notifications:\n  default: [ops]\n", `unknown channel "ops"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSavedConfig(t *testing.T) {
+	setHome(t, t.TempDir())
+	hints := false
+	err := Save(&Config{
+		DefaultProject: "shop",
+		Platforms:      map[string]PlatformConfig{"koyeb": {Token: "ENC:abc", RateLimit: 5}},
+		Projects: map[string]ProjectConfig{"shop": {
+			Topology: []ServiceEntry{{Name: "api", Platform: "koyeb", ID: "svc-1", Probe: &ProbeConfig{URL: "https://api.example.com"}}},
+			Alerts:   []AlertRule{{Name: "api-status", Service: "api", When: "status != healthy"}},
+		}},
+		Notifications: NotificationsConfig{Channels: map[string]NotifyChannel{"ops": {Type: "slack", URL: "https://hooks.example.com"}}, Default: []string{"ops"}},
+		Hints:         &hints,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, _ := Path()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(data); err != nil {
+		t.Errorf("a config written by Save doesn't parse: %v\n%s", err, data)
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ViewNames returns the project's view names in order.
+func (p ProjectConfig) ViewNames() []string {
+	names := make([]string, 0, len(p.Views))
+	for name := range p.Views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ViewEntries returns the services of a named view in the view's order.
+func (p ProjectConfig) ViewEntries(view string) ([]ServiceEntry, error) {
+	names, ok := p.Views[view]
+	if !ok {
+		if len(p.Views) == 0 {
+			return nil, fmt.Errorf("view %q not found: the project has no views", view)
+		}
+		return nil, fmt.Errorf("view %q not found\nAvailable views: %s", view, strings.Join(p.ViewNames(), ", "))
+	}
+	byName := make(map[string]ServiceEntry, len(p.Topology))
+	for _, e := range p.Topology {
+		byName[e.Name] = e
+	}
+	entries := make([]ServiceEntry, 0, len(names))
+	for _, name := range names {
+		e, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("view %q lists service %q, which is not in the project", view, name)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// RemoveFromViews drops a service from every view, deleting views left empty.
+func (p *ProjectConfig) RemoveFromViews(service string) {
+	for view, names := range p.Views {
+		kept := names[:0]
+		for _, n := range names {
+			if n != service {
+				kept = append(kept, n)
+			}
+		}
+		if len(kept) == 0 {
+			delete(p.Views, view)
+		} else {
+			p.Views[view] = kept
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestViewEntries(t *testing.T) {
+	p := ProjectConfig{
+		Topology: []ServiceEntry{{Name: "web"}, {Name: "api"}, {Name: "db"}, {Name: "worker"}},
+		Views: map[string][]string{
+			"user-facing": {"api", "web"},
+			"data-plane":  {"db", "worker"},
+			"stale":       {"api", "cache"},
+		},
+	}
+
+	entries, err := p.ViewEntries("user-facing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Name != "api" || entries[1].Name != "web" {
+		t.Errorf("user-facing = %v, want api, web in view order", entries)
+	}
+	if _, err := p.ViewEntries("stale"); err == nil {
+		t.Error("want error for a view listing an unknown service")
+	}
+	if _, err := p.ViewEntries("missing"); err == nil {
+		t.Error("want error for an unknown view")
+	}
+
+	p.RemoveFromViews("db")
+	p.RemoveFromViews("worker")
+	if _, ok := p.Views["data-plane"]; ok {
+		t.Error("emptied view data-plane was not deleted")
+	}
+	if got := p.Views["user-facing"]; len(got) != 2 {
+		t.Errorf("user-facing changed to %v", got)
+	}
+}
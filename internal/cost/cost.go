@@ -0,0 +1,131 @@
+// Package cost estimates what services cost per month from their instance
+// type and count, using a bundled price table that config can override.
+// Estimates cover compute only: bandwidth, storage and plan fees are not
+// included.
+package cost
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PricesAsOf is when the bundled prices were last checked against the
+// platforms' pricing pages.
+const PricesAsOf = "2024-06"
+
+// Prices are the bundled monthly USD prices of one instance, by platform
+// and instance type.
+var Prices = map[string]map[string]float64{
+	"koyeb": {
+		"free":        0,
+		"eco-nano":    1.61,
+		"eco-micro":   3.22,
+		"eco-small":   6.43,
+		"eco-medium":  12.86,
+		"eco-large":   25.71,
+		"eco-xlarge":  51.42,
+		"eco-2xlarge": 102.85,
+		"nano":        2.68,
+		"micro":       5.36,
+		"small":       10.71,
+		"medium":      21.43,
+		"large":       42.85,
+		"xlarge":      85.71,
+		"2xlarge":     171.42,
+	},
+	"render": {
+		"free":      0,
+		"starter":   7,
+		"standard":  25,
+		"pro":       85,
+		"pro_plus":  175,
+		"pro_max":   225,
+		"pro_ultra": 450,
+	},
+}
+
+// Fly.io bills machines by vCPU and memory rather than by named size.
+const (
+	flySharedCPU      = 0.67 // per shared vCPU per month
+	flyPerformanceCPU = 21.0 // per performance vCPU per month
+	flyMemoryGB       = 5.0  // per GB of memory per month
+)
+
+// Overrides are prices from config, by platform and instance type; they
+// take precedence over the bundled ones.
+type Overrides map[string]map[string]float64
+
+// Price returns the monthly price of one instance of the given type.
+func Price(platform, instanceType string, overrides Overrides) (float64, bool) {
+	instanceType = strings.ToLower(instanceType)
+	if p, ok := overrides[platform][instanceType]; ok {
+		return p, true
+	}
+	if p, ok := Prices[platform][instanceType]; ok {
+		return p, true
+	}
+	if platform == "flyio" {
+		return flyPrice(instanceType)
+	}
+	return 0, false
+}
+
+// flyPrice prices a machine size such as shared-cpu-1x:512MB or
+// performance-2x:4096MB (lowercased).
+func flyPrice(size string) (float64, bool) {
+	name, mem, ok := strings.Cut(size, ":")
+	if !ok {
+		return 0, false
+	}
+	kind, n, _ := strings.Cut(strings.Replace(name, "shared-cpu-", "shared-", 1), "-")
+	cpus, err := strconv.Atoi(strings.TrimSuffix(n, "x"))
+	if err != nil {
+		return 0, false
+	}
+	memMB, err := strconv.Atoi(strings.TrimSuffix(mem, "mb"))
+	if err != nil {
+		return 0, false
+	}
+	var perCPU float64
+	switch kind {
+	case "shared":
+		perCPU = flySharedCPU
+	case "performance":
+		perCPU = flyPerformanceCPU
+	default:
+		return 0, false
+	}
+	return round(float64(cpus)*perCPU + float64(memMB)/1024*flyMemoryGB), true
+}
+
+// Estimate is the monthly compute cost of one service.
+type Estimate struct {
+	InstanceType string
+	Min, Max     int     // instance range; equal without autoscaling
+	PerInstance  float64 // monthly
+	Monthly      float64 // at Min instances
+	MonthlyMax   float64 // at Max instances
+}
+
+// EstimateService prices a service running between min and max instances.
+// It returns false if the instance type has no known price.
+func EstimateService(platform, instanceType string, minInstances, maxInstances int, overrides Overrides) (Estimate, bool) {
+	price, ok := Price(platform, instanceType, overrides)
+	if !ok {
+		return Estimate{}, false
+	}
+	maxInstances = max(minInstances, maxInstances)
+	return Estimate{
+		InstanceType: instanceType,
+		Min:          minInstances,
+		Max:          maxInstances,
+		PerInstance:  price,
+		Monthly:      round(price * float64(minInstances)),
+		MonthlyMax:   round(price * float64(maxInstances)),
+	}, true
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,47 @@
+package cost
+
+import "testing"
+
+func TestPrice(t *testing.T) {
+	tests := []struct {
+		platform, instanceType string
+		want                   float64
+		ok                     bool
+	}{
+		{"koyeb", "nano", 2.68, true},
+		{"koyeb", "Small", 10.71, true},
+		{"render", "starter", 7, true},
+		{"flyio", "shared-cpu-1x:256MB", 1.92, true},
+		{"flyio", "performance-2x:4096MB", 62, true},
+		{"flyio", "gpu-a100", 0, false},
+		{"koyeb", "gpu-nvidia-a100", 0, false},
+		{"vercel", "", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Price(tt.platform, tt.instanceType, nil)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Price(%s, %s) = %v, %v; want %v, %v", tt.platform, tt.instanceType, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	overrides := Overrides{"koyeb": {"nano": 3}, "kubernetes": {"": 40}}
+	if got, _ := Price("koyeb", "nano", overrides); got != 3 {
+		t.Errorf("override not applied: %v", got)
+	}
+	if got, ok := Price("kubernetes", "", overrides); !ok || got != 40 {
+		t.Errorf("kubernetes override = %v, %v", got, ok)
+	}
+}
+
+func TestEstimateService(t *testing.T) {
+	e, ok := EstimateService("koyeb", "small", 1, 3, nil)
+	if !ok || e.Monthly != 10.71 || e.MonthlyMax != 32.13 || e.Max != 3 {
+		t.Errorf("estimate = %+v, %v", e, ok)
+	}
+	if e, _ := EstimateService("render", "standard", 2, 0, nil); e.Max != 2 || e.MonthlyMax != 50 {
+		t.Errorf("max below min = %+v", e)
+	}
+	if _, ok := EstimateService("koyeb", "unknown", 1, 1, nil); ok {
+		t.Error("unknown instance type estimated")
+	}
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	from := time.Date(2024, 6, 14, 10, 7, 30, 0, time.UTC) // a Friday
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 6, 14, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 6, 14, 10, 15, 0, 0, time.UTC)},
+		{"0 5 * * *", time.Date(2024, 6, 15, 5, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2024, 6, 17, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, 6, 16, 12, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)}, // day 13 or a Friday
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"5,10 8-9/1 * * *", time.Date(2024, 6, 15, 8, 5, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "MON * * * *"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded", expr)
+		}
+	}
+}
+
+func TestMissed(t *testing.T) {
+	s, _ := Parse("0 * * * *")
+	last := time.Date(2024, 6, 14, 10, 0, 20, 0, time.UTC)
+	due := time.Date(2024, 6, 14, 11, 0, 0, 0, time.UTC)
+	if _, missed := s.Missed(last, due.Add(4*time.Minute)); missed {
+		t.Error("a run 4 minutes late counts as missed")
+	}
+	if got, missed := s.Missed(last, due.Add(7*time.Minute)); !missed || !got.Equal(due) {
+		t.Errorf("Missed = %s, %v; want %s, true", got, missed, due)
+	}
+
+	daily, _ := Parse("0 5 * * *")
+	if _, missed := daily.Missed(last, time.Date(2024, 6, 15, 7, 0, 0, 0, time.UTC)); missed {
+		t.Error("a daily job within its 2.4h grace counts as missed")
+	}
+}
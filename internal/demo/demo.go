@@ -0,0 +1,383 @@
+// Package demo serves a synthetic platform API for orbit demo: two
+// projects whose services have a deploy history, live metrics, a log
+// stream and, every few minutes, a new deployment to watch. It speaks the
+// fake platform protocol (see platform.Fake), so every command that works
+// with a real platform can be tried without an account.
+package demo
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is the API token the demo platform accepts.
+const Token = "demo"
+
+// PushInterval is how often a new deployment of shop/api starts on its
+// own, so orbit watch has something to follow.
+const PushInterval = 90 * time.Second
+
+// Service is a synthetic service.
+type Service struct {
+	Project string
+	Name    string
+	ID      string
+	URL     string
+
+	status     string  // live, degraded or stopped
+	responseMs int     // mean response time
+	cpu        float64 // mean CPU percent
+	memory     float64 // mean memory percent
+	instances  int
+	max        int
+	logs       []string // message templates; %d is replaced with a number
+}
+
+// Services are the demo projects' services, in topology order.
+var Services = []Service{
+	{Project: "shop", Name: "web", ID: "shop-web", URL: "https://shop.demo.orbit.example",
+		status: "live", responseMs: 120, cpu: 22, memory: 41, instances: 2, max: 4,
+		logs: []string{"GET / 200 %dms", "GET /products/%d 200 18ms", "GET /cart 200 %dms", "GET /static/app.js 304 2ms"}},
+	{Project: "shop", Name: "api", ID: "shop-api", URL: "https://api.shop.demo.orbit.example",
+		status: "live", responseMs: 240, cpu: 48, memory: 63, instances: 3, max: 6,
+		logs: []string{"POST /v1/orders 201 %dms", "GET /v1/products?page=%d 200 35ms", "GET /health 200 1ms",
+			"WARN slow query on orders (%dms)", "ERROR payment provider timed out after %dms"}},
+	{Project: "shop", Name: "worker", ID: "shop-worker",
+		status: "degraded", responseMs: 0, cpu: 71, memory: 88, instances: 1, max: 2,
+		logs: []string{"processed email.send job %d", "processed invoice.render job %d", "WARN queue depth %d above 100",
+			"ERROR job %d failed: connection reset by peer"}},
+	{Project: "blog", Name: "site", ID: "blog-site", URL: "https://blog.demo.orbit.example",
+		status: "stopped", responseMs: 0, cpu: 0, memory: 0, instances: 0, max: 1,
+		logs: []string{"GET /posts/%d 200 12ms", "instance scaled to zero after 30m idle"}},
+}
+
+const historyLen = 15
+
+// Deployment lifecycle durations, measured from a deployment's creation.
+const (
+	queuedFor    = 4 * time.Second
+	buildingFor  = 25 * time.Second
+	deployingFor = 35 * time.Second
+)
+
+var (
+	commitMessages = []string{
+		"Fix checkout rounding for multi-currency carts", "Add product search filters", "Bump dependencies",
+		"Cache product images at the edge", "Retry payment webhooks", "Split order emails into a job",
+		"Tune database pool size", "Add health endpoint", "Log slow queries", "Paginate order history",
+		"Drop unused feature flag", "Handle empty carts", "Update copy on pricing page",
+	}
+	authors = []string{"ana", "sam", "lee", "kim"}
+)
+
+type deploy struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	Commit     string    `json:"commit"`
+	Message    string    `json:"message"`
+	Branch     string    `json:"branch"`
+	Author     string    `json:"author"`
+	CreatedAt  time.Time `json:"created_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	URL        string    `json:"url"`
+
+	final string // status once done: live, error or canceled; "" while it runs
+}
+
+// Server is the demo platform API.
+type Server struct {
+	start time.Time
+	now   func() time.Time
+
+	mu      sync.Mutex
+	rnd     *rand.Rand
+	deploys map[string][]*deploy // service ID -> deploys, newest first
+	pushes  int                  // scheduled pushes started so far
+}
+
+// NewServer creates the demo API with a deploy history ending at start.
+// The history is the same on every run.
+func NewServer(start time.Time) *Server {
+	s := &Server{
+		start:   start,
+		now:     time.Now,
+		rnd:     rand.New(rand.NewSource(1)),
+		deploys: make(map[string][]*deploy),
+	}
+	for _, svc := range Services {
+		at := start.Add(-20 * time.Minute)
+		for i := 0; i < historyLen; i++ {
+			d := s.newDeploy(svc, at)
+			d.final = "live"
+			switch {
+			case svc.Name == "worker" && i == 0:
+				d.final = "error"
+			case i%6 == 4:
+				d.final = "error"
+			case i%9 == 7:
+				d.final = "canceled"
+			}
+			s.deploys[svc.ID] = append(s.deploys[svc.ID], d)
+			at = at.Add(-time.Duration(3+s.rnd.Intn(20)) * time.Hour)
+		}
+	}
+	return s
+}
+
+func (s *Server) newDeploy(svc Service, at time.Time) *deploy {
+	return &deploy{
+		ID:        fmt.Sprintf("%s-%08x", svc.Name, s.rnd.Uint32()),
+		Commit:    fmt.Sprintf("%012x", s.rnd.Int63()&0xffffffffffff),
+		Message:   commitMessages[s.rnd.Intn(len(commitMessages))],
+		Branch:    "main",
+		Author:    authors[s.rnd.Intn(len(authors))],
+		CreatedAt: at,
+		URL:       svc.URL,
+	}
+}
+
+// update sets d's status from its age. Caller holds s.mu.
+func (d *deploy) update(now time.Time) {
+	age := now.Sub(d.CreatedAt)
+	switch {
+	case d.final != "" && (age >= deployingFor || d.final == "canceled"):
+		d.Status = d.final
+		if d.final == "live" && d.FinishedAt.IsZero() {
+			d.FinishedAt = d.CreatedAt.Add(deployingFor)
+		}
+		return
+	case age < queuedFor:
+		d.Status = "queued"
+	case age < buildingFor:
+		d.Status = "building"
+	case age < deployingFor:
+		d.Status = "deploying"
+	default:
+		d.Status, d.FinishedAt = "live", d.CreatedAt.Add(deployingFor)
+		d.final = "live"
+	}
+}
+
+// schedule starts the pushes to shop/api that are due. Caller holds s.mu.
+func (s *Server) schedule(now time.Time) {
+	due := int(now.Sub(s.start) / PushInterval)
+	for ; s.pushes < due; s.pushes++ {
+		at := s.start.Add(time.Duration(s.pushes+1) * PushInterval)
+		s.trigger("shop-api", at)
+	}
+}
+
+// trigger starts a deployment of the service. Caller holds s.mu.
+func (s *Server) trigger(id string, at time.Time) *deploy {
+	svc, _ := findService(id)
+	d := s.newDeploy(svc, at)
+	s.deploys[id] = append([]*deploy{d}, s.deploys[id]...)
+	return d
+}
+
+func findService(id string) (Service, bool) {
+	for _, svc := range Services {
+		if svc.ID == id {
+			return svc, true
+		}
+	}
+	return Service{}, false
+}
+
+// ServeHTTP implements the fake platform API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+Token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedule(now)
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/me":
+		writeJSON(w, map[string]string{"user": "demo"})
+	case len(parts) >= 2 && parts[0] == "services":
+		svc, ok := findService(parts[1])
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		switch {
+		case len(parts) == 2:
+			writeJSON(w, s.status(svc, now))
+		case len(parts) == 3 && parts[2] == "deploys":
+			s.serveDeploys(w, r, svc, now)
+		case len(parts) == 3 && parts[2] == "logs":
+			writeJSON(w, map[string]interface{}{"logs": s.logs(svc, r, now)})
+		case len(parts) == 3 && parts[2] == "redeploy" && r.Method == "POST":
+			d := s.trigger(svc.ID, now)
+			d.update(now)
+			writeJSON(w, d)
+		default:
+			http.NotFound(w, r)
+		}
+	case len(parts) >= 2 && parts[0] == "deploys":
+		d := s.findDeploy(parts[1])
+		if d == nil {
+			http.NotFound(w, r)
+			return
+		}
+		d.update(now)
+		if len(parts) == 3 && parts[2] == "cancel" && r.Method == "POST" {
+			if d.final != "" {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			d.final, d.Status = "canceled", "canceled"
+		}
+		writeJSON(w, d)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) findDeploy(id string) *deploy {
+	for _, ds := range s.deploys {
+		for _, d := range ds {
+			if d.ID == id {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// status is a service's current state. Metrics drift slowly around the
+// service's means so repeated polls look alive.
+func (s *Server) status(svc Service, now time.Time) map[string]interface{} {
+	wave := math.Sin(float64(now.Unix()) / 40)
+	st := map[string]interface{}{
+		"name":          svc.Name,
+		"status":        svc.status,
+		"instances":     svc.instances,
+		"max_instances": svc.max,
+		"url":           svc.URL,
+	}
+	if svc.status != "stopped" {
+		st["cpu"] = math.Round(svc.cpu*(1+0.15*wave)*10) / 10
+		st["memory"] = math.Round(svc.memory*(1+0.05*wave)*10) / 10
+		if svc.responseMs > 0 {
+			st["response_ms"] = svc.responseMs + int(float64(svc.responseMs)*0.3*wave)
+		}
+	}
+	if ds := s.deploys[svc.ID]; len(ds) > 0 {
+		ds[0].update(now)
+		if ds[0].final == "" {
+			st["status"] = "deploying"
+		}
+	}
+	return st
+}
+
+func (s *Server) serveDeploys(w http.ResponseWriter, r *http.Request, svc Service, now time.Time) {
+	ds := s.deploys[svc.ID]
+	limit, cursor := 10, 0
+	fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+	fmt.Sscan(r.URL.Query().Get("cursor"), &cursor)
+	cursor = min(cursor, len(ds))
+	end := min(cursor+limit, len(ds))
+	for _, d := range ds[cursor:end] {
+		d.update(now)
+	}
+	page := map[string]interface{}{"deploys": ds[cursor:end]}
+	if end < len(ds) {
+		page["next"] = strconv.Itoa(end)
+	}
+	writeJSON(w, page)
+}
+
+// logLine is one entry of the fake API's log format.
+type logLine struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// logEvery is the spacing of runtime log lines.
+const logEvery = 2 * time.Second
+
+// logs returns the service's runtime log lines within since (seconds) or
+// the last tail lines, or the build log of its latest deployment. Lines
+// are derived from their timestamp, so every poll sees the same stream.
+func (s *Server) logs(svc Service, r *http.Request, now time.Time) []logLine {
+	q := r.URL.Query()
+	if q.Get("type") == "build" {
+		return s.buildLog(svc, now)
+	}
+	tail := 50
+	fmt.Sscan(q.Get("tail"), &tail)
+	from := now.Add(-time.Duration(tail) * logEvery)
+	var since float64
+	if _, err := fmt.Sscan(q.Get("since"), &since); err == nil && since > 0 {
+		from = now.Add(-time.Duration(since * float64(time.Second)))
+	}
+
+	var lines []logLine
+	for t := from.Truncate(logEvery).Add(logEvery); !t.After(now); t = t.Add(logEvery) {
+		h := fnv.New32a()
+		fmt.Fprintf(h, "%s/%d", svc.ID, t.Unix())
+		n := h.Sum32()
+		msg := svc.logs[n%uint32(len(svc.logs))]
+		if strings.Contains(msg, "%d") {
+			msg = fmt.Sprintf(msg, 20+n%900)
+		}
+		level := "info"
+		if rest, ok := strings.CutPrefix(msg, "WARN "); ok {
+			level, msg = "warn", rest
+		} else if rest, ok := strings.CutPrefix(msg, "ERROR "); ok {
+			level, msg = "error", rest
+		}
+		lines = append(lines, logLine{Time: t, Level: level, Message: msg})
+	}
+	return lines
+}
+
+// buildLog is the build output of the latest deployment, up to where it is.
+func (s *Server) buildLog(svc Service, now time.Time) []logLine {
+	ds := s.deploys[svc.ID]
+	if len(ds) == 0 {
+		return nil
+	}
+	d := ds[0]
+	steps := []struct {
+		after time.Duration
+		msg   string
+	}{
+		{queuedFor, "Cloning repository at " + d.Commit[:7]},
+		{queuedFor + 2*time.Second, "Installing dependencies"},
+		{queuedFor + 12*time.Second, "Running build"},
+		{buildingFor - time.Second, "Build finished, pushing image"},
+		{buildingFor + 5*time.Second, "Starting instances"},
+		{deployingFor, "Health checks passed"},
+	}
+	var lines []logLine
+	for _, st := range steps {
+		at := d.CreatedAt.Add(st.after)
+		if at.After(now) {
+			break
+		}
+		lines = append(lines, logLine{Time: at, Level: "info", Message: st.msg})
+	}
+	return lines
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
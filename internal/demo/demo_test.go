@@ -0,0 +1,64 @@
+package demo
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+func TestServer(t *testing.T) {
+	start := time.Now()
+	now := start
+	s := NewServer(start)
+	s.now = func() time.Time { return now }
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	p := platform.NewFake(ts.URL, Token)
+	ctx := context.Background()
+
+	if err := p.Validate(ctx, "wrong"); err == nil {
+		t.Error("Validate accepted a wrong token")
+	}
+
+	st, err := p.GetServiceStatus(ctx, "shop-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Status != "healthy" || st.CPU <= 0 || st.ResponseMs <= 0 || st.LastDeploy == nil {
+		t.Errorf("shop-api status = %+v", st)
+	}
+	if st, _ := p.GetServiceStatus(ctx, "blog-site"); st.Status != "sleeping" || st.CPU != -1 {
+		t.Errorf("blog-site status = %+v, want sleeping without metrics", st)
+	}
+	deploys, err := p.ListDeployments(ctx, "shop-web", 12)
+	if err != nil || len(deploys) != 12 {
+		t.Fatalf("ListDeployments = %d deploys, %v", len(deploys), err)
+	}
+
+	// A scheduled push moves through the lifecycle as time passes.
+	now = start.Add(PushInterval + time.Second)
+	latest, _ := p.ListDeployments(ctx, "shop-api", 1)
+	if latest[0].ID == st.LastDeploy.ID || latest[0].Status != "pending" {
+		t.Fatalf("after the push interval, latest = %+v", latest[0])
+	}
+	now = now.Add(deployingFor)
+	d, err := p.GetDeployment(ctx, latest[0].ID)
+	if err != nil || d.Status != "healthy" {
+		t.Errorf("pushed deploy later = %+v, %v", d, err)
+	}
+
+	// Polls see the same lines, and later polls continue the stream.
+	first, _ := p.GetLogs(ctx, "shop-api", platform.LogOptions{Tail: 5})
+	again, _ := p.GetLogs(ctx, "shop-api", platform.LogOptions{Tail: 5})
+	if len(first) != 5 || first[4] != again[4] {
+		t.Fatalf("logs not stable: %v vs %v", first, again)
+	}
+	now = now.Add(3 * logEvery)
+	next, _ := p.GetLogs(ctx, "shop-api", platform.LogOptions{Since: 3 * logEvery})
+	if len(next) != 3 || !next[0].Timestamp.After(first[4].Timestamp) {
+		t.Errorf("logs since last poll = %v", next)
+	}
+}
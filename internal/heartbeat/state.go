@@ -0,0 +1,125 @@
+// Package heartbeat keeps the heartbeat daemon's record of its checks, so a
+// daemon running in the background can be inspected with orbit heartbeat
+// status.
+package heartbeat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Check is the outcome of one heartbeat ping or status check.
+type Check struct {
+	Time      time.Time `json:"time"`
+	OK        bool      `json:"ok"`
+	LatencyMs int64     `json:"latency_ms,omitempty"` // pings only
+	Detail    string    `json:"detail,omitempty"`     // error, or the platform status
+}
+
+// Service sums up the checks of one service since the daemon started.
+type Service struct {
+	Last   Check     `json:"last"`
+	LastOK time.Time `json:"last_ok,omitzero"`
+	Checks int       `json:"checks"`
+	Failed int       `json:"failed"`
+	Streak int       `json:"failure_streak,omitempty"` // consecutive failed checks
+}
+
+// Uptime returns the share of passed checks in percent.
+func (s *Service) Uptime() float64 {
+	if s.Checks == 0 {
+		return 0
+	}
+	return 100 * float64(s.Checks-s.Failed) / float64(s.Checks)
+}
+
+// State is what a daemon has seen since it started.
+type State struct {
+	PID       int                 `json:"pid"`
+	StartedAt time.Time           `json:"started_at"`
+	Services  map[string]*Service `json:"services"`
+}
+
+// Load reads the state file at path.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if s.Services == nil {
+		s.Services = map[string]*Service{}
+	}
+	return &s, nil
+}
+
+// Recorder updates a daemon's state file after every check. It is safe for
+// concurrent use by the daemon's per-service goroutines.
+type Recorder struct {
+	mu    sync.Mutex
+	path  string
+	state State
+}
+
+// NewRecorder starts a fresh state file at path for the daemon with pid.
+func NewRecorder(path string, pid int, started time.Time) (*Recorder, error) {
+	r := &Recorder{path: path, state: State{PID: pid, StartedAt: started, Services: map[string]*Service{}}}
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Record adds a check for service and writes the state file.
+func (r *Recorder) Record(service string, c Check) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.state.Services[service]
+	if s == nil {
+		s = &Service{}
+		r.state.Services[service] = s
+	}
+	s.Last = c
+	s.Checks++
+	if c.OK {
+		s.LastOK = c.Time
+		s.Streak = 0
+	} else {
+		s.Failed++
+		s.Streak++
+	}
+	return r.save()
+}
+
+// save writes the state and renames it into place, so orbit heartbeat
+// status never reads a partial file.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), ".heartbeat-*")
+	if err != nil {
+		return fmt.Errorf("write heartbeat state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write heartbeat state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write heartbeat state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("write heartbeat state: %w", err)
+	}
+	return nil
+}
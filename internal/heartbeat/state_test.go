@@ -0,0 +1,77 @@
+package heartbeat
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heartbeat-shop.json")
+	started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	r, err := NewRecorder(path, 4242, started)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.PID != 4242 || !s.StartedAt.Equal(started) || len(s.Services) != 0 {
+		t.Fatalf("fresh state = %+v", s)
+	}
+
+	at := started
+	for _, ok := range []bool{true, true, false, false} {
+		at = at.Add(time.Minute)
+		if err := r.Record("api", Check{Time: at, OK: ok, LatencyMs: 80}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := s.Services["api"]
+	if api == nil {
+		t.Fatal("no record for api")
+	}
+	if api.Checks != 4 || api.Failed != 2 || api.Streak != 2 || api.Uptime() != 50 {
+		t.Errorf("api = %+v (uptime %.1f)", api, api.Uptime())
+	}
+	if !api.LastOK.Equal(started.Add(2*time.Minute)) || !api.Last.Time.Equal(at) {
+		t.Errorf("last ok %v, last %v", api.LastOK, api.Last.Time)
+	}
+}
+
+func TestRecorderConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heartbeat-shop.json")
+	r, err := NewRecorder(path, 1, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for _, svc := range []string{"api", "web", "worker"} {
+		wg.Add(1)
+		go func(svc string) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				r.Record(svc, Check{Time: time.Now(), OK: true})
+			}
+		}(svc)
+	}
+	wg.Wait()
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, svc := range []string{"api", "web", "worker"} {
+		if got := s.Services[svc].Checks; got != 20 {
+			t.Errorf("%s: %d checks, want 20", svc, got)
+		}
+	}
+}
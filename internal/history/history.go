@@ -0,0 +1,216 @@
+// Package history keeps a local record of status polls, deployments and
+// heartbeat checks in ~/.orbit/history.db, so orbit can answer what
+// happened while nobody was watching.
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Kinds of entries.
+const (
+	KindStatus    = "status"    // a status poll of one service
+	KindDeploy    = "deploy"    // a deployment seen in a new state
+	KindHeartbeat = "heartbeat" // a heartbeat daemon check
+	KindEvent     = "event"     // a notification event, e.g. a threshold violation
+)
+
+// Retention is how long entries are kept.
+const Retention = 90 * 24 * time.Hour
+
+// lockTimeout is how long to wait for another orbit process (usually a
+// heartbeat daemon) that has the database open.
+const lockTimeout = 5 * time.Second
+
+var (
+	entriesBucket = []byte("entries")
+	deploysBucket = []byte("deploys") // project/service → last recorded deploy state
+)
+
+// Entry is one recorded observation of a service. Status is the service
+// status for polls and heartbeats (healthy, degraded, unhealthy, sleeping,
+// or error when it could not be fetched) and the deployment's status for
+// deploys. Events have a Type, Severity and Title instead.
+type Entry struct {
+	Seq        uint64    `json:"-"` // insertion order, set by Query
+	Time       time.Time `json:"time"`
+	Kind       string    `json:"kind"`
+	Project    string    `json:"project"`
+	Service    string    `json:"service"`
+	Status     string    `json:"status,omitempty"`
+	ResponseMs int64     `json:"response_ms,omitempty"`
+	Instances  *int      `json:"instances,omitempty"` // running instances, for polls of platforms that report them
+	DeployID   string    `json:"deploy_id,omitempty"`
+	Commit     string    `json:"commit,omitempty"`
+	Size       int64     `json:"size,omitempty"` // build artifact bytes, for deploys
+	Type       string    `json:"type,omitempty"` // event type, e.g. threshold_violation
+	Severity   string    `json:"severity,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Detail     string    `json:"detail,omitempty"` // error or message
+}
+
+// Query selects entries. Empty fields match everything.
+type Query struct {
+	Project string
+	Service string
+	Kind    string
+	Since   time.Time
+	Limit   int    // keep only the newest Limit entries; 0 for all
+	After   uint64 // only entries added after the one with this Seq
+}
+
+func (q Query) match(e Entry) bool {
+	return (q.Project == "" || e.Project == q.Project) &&
+		(q.Service == "" || e.Service == q.Service) &&
+		(q.Kind == "" || e.Kind == q.Kind) &&
+		e.Seq > q.After
+}
+
+// Store is an open history database. Only one process can have it open at a
+// time, so open it for each batch of writes or queries and close it again.
+type Store struct {
+	db *bolt.DB
+}
+
+// Path returns the history database location, ~/.orbit/history.db.
+func Path() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.db"), nil
+}
+
+// Open opens or creates the database at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: lockTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("open history: %s is in use by another orbit process", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{entriesBucket, deploysBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add records entries and drops those older than Retention. A deploy entry
+// is skipped when the service's last recorded deploy has the same ID and
+// status, so polling an unchanged deployment records it once.
+func (s *Store) Add(entries ...Entry) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(entriesBucket)
+		deploys := tx.Bucket(deploysBucket)
+		for _, e := range entries {
+			if e.Time.IsZero() {
+				e.Time = time.Now()
+			}
+			if e.Kind == KindDeploy {
+				svc := []byte(e.Project + "/" + e.Service)
+				state := []byte(e.DeployID + "\x00" + e.Status)
+				if bytes.Equal(deploys.Get(svc), state) {
+					continue
+				}
+				if err := deploys.Put(svc, state); err != nil {
+					return err
+				}
+			}
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(entryKey(e.Time, seq), data); err != nil {
+				return err
+			}
+		}
+		return prune(b, time.Now().Add(-Retention))
+	})
+	if err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// entryKey orders entries by time. seq, the bucket's insertion counter,
+// keeps keys of the same instant apart.
+func entryKey(t time.Time, seq uint64) []byte {
+	k := make([]byte, 16)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(k[8:], seq)
+	return k
+}
+
+func prune(b *bolt.Bucket, before time.Time) error {
+	end := entryKey(before, 0)
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.First() {
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query returns the entries q selects, oldest first.
+func (s *Store) Query(q Query) ([]Entry, error) {
+	var out []Entry
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(entriesBucket).Cursor()
+		// Walk backwards from the newest entry so Limit can stop early.
+		var start []byte
+		if !q.Since.IsZero() {
+			start = entryKey(q.Since, 0)
+		}
+		for k, v := c.Last(); k != nil && bytes.Compare(k, start) >= 0; k, v = c.Prev() {
+			var e Entry
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			e.Seq = binary.BigEndian.Uint64(k[8:])
+			if !q.match(e) {
+				continue
+			}
+			out = append(out, e)
+			if q.Limit > 0 && len(out) == q.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
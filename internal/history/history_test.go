@@ -0,0 +1,129 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestQuery(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now()
+	err := s.Add(
+		Entry{Time: now.Add(-3 * time.Hour), Kind: KindStatus, Project: "shop", Service: "api", Status: "healthy"},
+		Entry{Time: now.Add(-2 * time.Hour), Kind: KindStatus, Project: "shop", Service: "web", Status: "degraded"},
+		Entry{Time: now.Add(-time.Hour), Kind: KindHeartbeat, Project: "shop", Service: "api", Status: "unhealthy"},
+		Entry{Time: now.Add(-time.Hour), Kind: KindStatus, Project: "blog", Service: "api", Status: "healthy"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		q    Query
+		want []string // statuses, oldest first
+	}{
+		{"all", Query{}, []string{"healthy", "degraded", "unhealthy", "healthy"}},
+		{"project", Query{Project: "shop"}, []string{"healthy", "degraded", "unhealthy"}},
+		{"service", Query{Project: "shop", Service: "api"}, []string{"healthy", "unhealthy"}},
+		{"kind", Query{Project: "shop", Kind: KindStatus}, []string{"healthy", "degraded"}},
+		{"since", Query{Project: "shop", Since: now.Add(-150 * time.Minute)}, []string{"degraded", "unhealthy"}},
+		{"limit keeps newest", Query{Project: "shop", Limit: 2}, []string{"degraded", "unhealthy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Query(tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var statuses []string
+			for _, e := range got {
+				statuses = append(statuses, e.Status)
+			}
+			if len(statuses) != len(tt.want) {
+				t.Fatalf("got %v, want %v", statuses, tt.want)
+			}
+			for i := range statuses {
+				if statuses[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", statuses, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAddSkipsUnchangedDeploys(t *testing.T) {
+	s := openTestStore(t)
+	deploy := func(id, status string) Entry {
+		return Entry{Kind: KindDeploy, Project: "shop", Service: "api", DeployID: id, Status: status}
+	}
+	for _, e := range []Entry{
+		deploy("d1", "building"), deploy("d1", "building"), deploy("d1", "live"),
+		deploy("d1", "live"), deploy("d2", "live"),
+	} {
+		if err := s.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := s.Query(Query{Kind: KindDeploy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Errorf("recorded %d deploys, want 3: %+v", len(got), got)
+	}
+}
+
+func TestAddPrunesOldEntries(t *testing.T) {
+	s := openTestStore(t)
+	old := Entry{Time: time.Now().Add(-Retention - time.Hour), Kind: KindStatus, Project: "shop", Service: "api", Status: "healthy"}
+	if err := s.Add(old); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add(Entry{Kind: KindStatus, Project: "shop", Service: "api", Status: "healthy"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Query(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Time.Before(old.Time.Add(time.Hour)) {
+		t.Errorf("old entry kept: %+v", got)
+	}
+}
+
+func TestQueryAfterFollowsInsertionOrder(t *testing.T) {
+	s := openTestStore(t)
+	now := time.Now()
+	if err := s.Add(Entry{Time: now, Kind: KindEvent, Project: "shop", Title: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	seen, err := s.Query(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := seen[len(seen)-1].Seq
+
+	// A check that started before the last entry but was written after it.
+	if err := s.Add(Entry{Time: now.Add(-5 * time.Second), Kind: KindHeartbeat, Project: "shop", Status: "unhealthy"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Query(Query{Since: now.Add(-time.Minute), After: last})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Kind != KindHeartbeat {
+		t.Errorf("got %+v, want only the late heartbeat", got)
+	}
+}
@@ -0,0 +1,110 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// MaxGap is how long a recorded status is assumed to hold. Time further
+// from any observation counts as unknown rather than up or down.
+const MaxGap = time.Hour
+
+// Uptime sums up a service's recorded statuses over a period.
+type Uptime struct {
+	Service        string
+	Samples        int           // status polls and heartbeat checks
+	Up             time.Duration // time the service was observed up
+	Known          time.Duration // time covered by observations
+	MeanResponseMs int64         // over samples that reported a response time
+}
+
+// Percent returns the share of known time the service was up, or 0 when
+// nothing is known.
+func (u Uptime) Percent() float64 {
+	if u.Known == 0 {
+		return 0
+	}
+	return 100 * float64(u.Up) / float64(u.Known)
+}
+
+// Down returns the known time the service was down.
+func (u Uptime) Down() time.Duration {
+	return u.Known - u.Up
+}
+
+// BudgetUsed returns how much of the error budget an uptime objective (a
+// percentage such as 99.9) allows over the known time has been spent, as a
+// percentage. Above 100 the objective was missed.
+func (u Uptime) BudgetUsed(objective float64) float64 {
+	budget := float64(u.Known) * (100 - objective) / 100
+	if budget <= 0 {
+		if u.Down() > 0 {
+			return 100
+		}
+		return 0
+	}
+	return 100 * float64(u.Down()) / budget
+}
+
+// up reports whether a recorded status counts as available. The second
+// result is false for statuses that say nothing about the service itself,
+// such as a failed API call.
+func up(status string) (bool, bool) {
+	switch status {
+	case "healthy", "degraded", "sleeping":
+		return true, true
+	case "unhealthy":
+		return false, true
+	}
+	return false, false
+}
+
+// ComputeUptime sums up status and heartbeat entries per service. Each
+// observation holds until the service's next one, for at most MaxGap; the
+// last one holds until to.
+func ComputeUptime(entries []Entry, to time.Time) []Uptime {
+	byService := make(map[string][]Entry)
+	for _, e := range entries {
+		if e.Kind != KindStatus && e.Kind != KindHeartbeat {
+			continue
+		}
+		if _, ok := up(e.Status); !ok {
+			continue
+		}
+		byService[e.Service] = append(byService[e.Service], e)
+	}
+
+	out := make([]Uptime, 0, len(byService))
+	for name, list := range byService {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Time.Before(list[j].Time) })
+		u := Uptime{Service: name, Samples: len(list)}
+		var respSum, respCount int64
+		for i, e := range list {
+			end := to
+			if i+1 < len(list) {
+				end = list[i+1].Time
+			}
+			span := end.Sub(e.Time)
+			if span > MaxGap {
+				span = MaxGap
+			}
+			if span < 0 {
+				span = 0
+			}
+			u.Known += span
+			if ok, _ := up(e.Status); ok {
+				u.Up += span
+			}
+			if e.ResponseMs > 0 {
+				respSum += e.ResponseMs
+				respCount++
+			}
+		}
+		if respCount > 0 {
+			u.MeanResponseMs = respSum / respCount
+		}
+		out = append(out, u)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Service < out[j].Service })
+	return out
+}
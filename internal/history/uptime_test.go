@@ -0,0 +1,53 @@
+package history
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestComputeUptime(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return t0.Add(time.Duration(m) * time.Minute) }
+	entries := []Entry{
+		{Time: at(0), Kind: KindStatus, Service: "api", Status: "healthy", ResponseMs: 100},
+		{Time: at(30), Kind: KindHeartbeat, Service: "api", Status: "unhealthy"},
+		{Time: at(40), Kind: KindStatus, Service: "api", Status: "error"}, // says nothing about the service
+		{Time: at(45), Kind: KindStatus, Service: "api", Status: "degraded", ResponseMs: 300},
+		{Time: at(50), Kind: KindDeploy, Service: "api", Status: "failed"},
+		{Time: at(0), Kind: KindStatus, Service: "web", Status: "healthy"},
+		// A three-hour silence only counts for MaxGap.
+		{Time: at(180), Kind: KindStatus, Service: "web", Status: "healthy"},
+	}
+
+	got := ComputeUptime(entries, at(60))
+	if len(got) != 2 || got[0].Service != "api" || got[1].Service != "web" {
+		t.Fatalf("got %+v", got)
+	}
+
+	api := got[0]
+	if api.Known != time.Hour || api.Up != 45*time.Minute || api.Samples != 3 {
+		t.Errorf("api = %+v", api)
+	}
+	if api.Percent() != 75 || api.MeanResponseMs != 200 {
+		t.Errorf("api percent %v, mean %d", api.Percent(), api.MeanResponseMs)
+	}
+
+	web := got[1]
+	if web.Known != MaxGap || web.Percent() != 100 {
+		t.Errorf("web = %+v", web)
+	}
+}
+
+func TestBudgetUsed(t *testing.T) {
+	u := Uptime{Known: 1000 * time.Minute, Up: 999 * time.Minute}
+	if got := u.BudgetUsed(99.9); math.Abs(got-100) > 1e-9 {
+		t.Errorf("one minute down of a 99.9%% budget over 1000m: %v, want 100", got)
+	}
+	if got := u.BudgetUsed(99.8); math.Abs(got-50) > 1e-9 {
+		t.Errorf("99.8%%: %v, want 50", got)
+	}
+	if got := (Uptime{Known: time.Hour, Up: time.Hour}).BudgetUsed(100); got != 0 {
+		t.Errorf("no downtime against 100%%: %v", got)
+	}
+}
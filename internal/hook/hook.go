@@ -0,0 +1,131 @@
+// Package hook runs the shell commands configured under hooks when orbit
+// sees a lifecycle event: a deploy going healthy or failing, or a threshold
+// violation. Each command gets the event as JSON on stdin and in ORBIT_*
+// environment variables.
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+)
+
+// Event types that have hooks.
+const (
+	DeploySucceeded    = "deploy_succeeded"
+	DeployFailed       = "deploy_failed"
+	ThresholdViolation = "threshold_violation"
+)
+
+// DefaultTimeout bounds a command when hooks.timeout is unset.
+const DefaultTimeout = 30 * time.Second
+
+// Commands returns the commands hc runs for an event type.
+func Commands(hc config.HooksConfig, eventType string) []string {
+	switch eventType {
+	case DeploySucceeded:
+		return hc.OnDeploySuccess
+	case DeployFailed:
+		return hc.OnDeployFailed
+	case ThresholdViolation:
+		return hc.OnThresholdViolation
+	}
+	return nil
+}
+
+// Timeout returns hc's per-command timeout.
+func Timeout(hc config.HooksConfig) (time.Duration, error) {
+	if hc.Timeout == "" {
+		return DefaultTimeout, nil
+	}
+	d, err := time.ParseDuration(hc.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid hooks timeout %q (e.g. 30s)", hc.Timeout)
+	}
+	return d, nil
+}
+
+// Env returns the environment variables describing ev.
+func Env(ev notify.Event) []string {
+	env := []string{
+		"ORBIT_EVENT=" + ev.Type,
+		"ORBIT_SEVERITY=" + ev.Severity,
+		"ORBIT_PROJECT=" + ev.Project,
+		"ORBIT_SERVICE=" + ev.Service,
+		"ORBIT_TITLE=" + ev.Title,
+		"ORBIT_MESSAGE=" + ev.Message,
+		"ORBIT_PHASE=" + ev.Phase,
+		"ORBIT_COMMIT=" + ev.Commit,
+		"ORBIT_DEPLOY_ID=" + ev.DeployID,
+		"ORBIT_URL=" + ev.URL,
+		"ORBIT_OWNER=" + ev.Owner,
+		"ORBIT_TAGS=" + strings.Join(ev.Tags, ","),
+	}
+	if !ev.Time.IsZero() {
+		env = append(env, "ORBIT_TIME="+ev.Time.UTC().Format(time.RFC3339))
+	}
+	return env
+}
+
+// Run runs commands one after another for ev, each through the shell and
+// bounded by timeout, with their output going to out. A command that fails
+// doesn't stop the others; the errors are returned keyed by command.
+func Run(ctx context.Context, commands []string, ev notify.Event, timeout time.Duration, out io.Writer) map[string]error {
+	if len(commands) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return map[string]error{"": fmt.Errorf("marshal event: %w", err)}
+	}
+	env := append(os.Environ(), Env(ev)...)
+
+	errs := make(map[string]error)
+	for _, command := range commands {
+		if err := run(ctx, command, env, payload, timeout, out); err != nil {
+			errs[command] = err
+		}
+	}
+	return errs
+}
+
+func run(ctx context.Context, command string, env []string, payload []byte, timeout time.Duration, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c := Command(ctx, command)
+	c.Env = env
+	c.Stdin = bytes.NewReader(payload)
+	c.Stdout, c.Stderr = out, out
+	// A killed shell's children may keep the output open; don't wait on them.
+	c.WaitDelay = time.Second
+	err := c.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("killed after %s", timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("exit status %d", exitErr.ExitCode())
+	}
+	return err
+}
+
+// Command returns a command running command through the shell: sh -c, or
+// cmd /C on Windows.
+func Command(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "/bin/sh", "-c", command)
+}
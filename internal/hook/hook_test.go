@@ -0,0 +1,56 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test are POSIX shell")
+	}
+	ev := notify.Event{
+		Type: DeployFailed, Severity: notify.SeverityCritical, Project: "shop", Service: "api",
+		Title: "deploy abc1234 failed", DeployID: "abc1234", Tags: []string{"core", "payments"},
+	}
+	var out bytes.Buffer
+	errs := Run(context.Background(), []string{
+		`echo "$ORBIT_EVENT $ORBIT_PROJECT/$ORBIT_SERVICE $ORBIT_DEPLOY_ID $ORBIT_TAGS"`,
+		`grep -o '"title":"[^"]*"'`,
+		`exit 3`,
+		`sleep 5`,
+	}, ev, 200*time.Millisecond, &out)
+
+	want := "deploy_failed shop/api abc1234 core,payments\n" + `"title":"deploy abc1234 failed"` + "\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("errors = %v, want exit 3 and sleep 5 to fail", errs)
+	}
+	if err := errs["exit 3"]; err == nil || err.Error() != "exit status 3" {
+		t.Errorf("exit 3: %v", err)
+	}
+	if err := errs["sleep 5"]; err == nil || !strings.Contains(err.Error(), "killed after") {
+		t.Errorf("sleep 5: %v", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	if d, err := Timeout(config.HooksConfig{}); err != nil || d != DefaultTimeout {
+		t.Errorf("default timeout = %s, %v", d, err)
+	}
+	if d, err := Timeout(config.HooksConfig{Timeout: "2m"}); err != nil || d != 2*time.Minute {
+		t.Errorf("timeout 2m = %s, %v", d, err)
+	}
+	if _, err := Timeout(config.HooksConfig{Timeout: "later"}); err == nil {
+		t.Error("invalid timeout: want error")
+	}
+}
@@ -0,0 +1,232 @@
+// Package incident keeps a local record of service incidents, so deploy
+// history can show which deployments were followed by trouble.
+package incident
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+// Window is how long after a deployment an incident is still attributed to
+// it when no later deployment is known.
+const Window = 24 * time.Hour
+
+// Incident is a period during which a service was failing.
+type Incident struct {
+	ID         int        `json:"id"`
+	Project    string     `json:"project"`
+	Service    string     `json:"service"`
+	Title      string     `json:"title"`
+	DeployID   string     `json:"deploy_id,omitempty"` // deployment the incident was opened for, if any
+	Manual     bool       `json:"manual,omitempty"`    // declared by a user; only closed by one
+	StartedAt  time.Time  `json:"started_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	Resolution string     `json:"resolution,omitempty"` // note given when closing
+}
+
+// Active reports whether the incident is still open.
+func (i Incident) Active() bool {
+	return i.ResolvedAt == nil
+}
+
+// Log is the set of recorded incidents, stored as JSON.
+type Log struct {
+	NextID    int        `json:"next_id"`
+	Incidents []Incident `json:"incidents"`
+}
+
+// Path returns the incident log location, ~/.orbit/incidents.json.
+func Path() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "incidents.json"), nil
+}
+
+// Load reads the incident log at path. A missing file is an empty log.
+func Load(path string) (*Log, error) {
+	l := &Log{NextID: 1}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read incidents: %w", err)
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if l.NextID < 1 {
+		l.NextID = 1
+	}
+	return l, nil
+}
+
+// Save writes the log to path atomically.
+func (l *Log) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal incidents: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".incidents-*.json")
+	if err != nil {
+		return fmt.Errorf("write incidents: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write incidents: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write incidents: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Open records a new incident and returns it. If the service already has an
+// active incident, that one is returned instead.
+func (l *Log) Open(project, service, title, deployID string, now time.Time) Incident {
+	for _, inc := range l.Incidents {
+		if inc.Project == project && inc.Service == service && inc.Active() {
+			return inc
+		}
+	}
+	inc := Incident{
+		ID:        l.NextID,
+		Project:   project,
+		Service:   service,
+		Title:     title,
+		DeployID:  deployID,
+		StartedAt: now,
+	}
+	l.NextID++
+	l.Incidents = append(l.Incidents, inc)
+	return inc
+}
+
+// Declare records an incident a user opened by hand. Unlike those from Open,
+// it stays active until closed with Close. created is false if the service
+// already had an active incident, which is returned instead.
+func (l *Log) Declare(project, service, title string, now time.Time) (inc Incident, created bool) {
+	next := l.NextID
+	inc = l.Open(project, service, title, "", now)
+	if l.NextID == next {
+		return inc, false
+	}
+	l.Incidents[len(l.Incidents)-1].Manual = true
+	inc.Manual = true
+	return inc, true
+}
+
+// Close resolves the incident with the given ID, noting why.
+func (l *Log) Close(id int, resolution string, now time.Time) (Incident, error) {
+	for i := range l.Incidents {
+		inc := &l.Incidents[i]
+		if inc.ID != id {
+			continue
+		}
+		if !inc.Active() {
+			return *inc, fmt.Errorf("incident #%d was already resolved %s", id, inc.ResolvedAt.Local().Format("Jan 2 15:04"))
+		}
+		inc.ResolvedAt = &now
+		inc.Resolution = resolution
+		return *inc, nil
+	}
+	return Incident{}, fmt.Errorf("incident #%d not found", id)
+}
+
+// Resolve closes the active incidents of a service that Orbit opened
+// itself, and returns how many were closed. Declared incidents are left
+// for a user to close.
+func (l *Log) Resolve(project, service string, now time.Time) int {
+	n := 0
+	for i := range l.Incidents {
+		inc := &l.Incidents[i]
+		if inc.Project == project && inc.Service == service && inc.Active() && !inc.Manual {
+			inc.ResolvedAt = &now
+			n++
+		}
+	}
+	return n
+}
+
+// ActiveFor returns the service's active incident, if it has one.
+func (l *Log) ActiveFor(project, service string) (Incident, bool) {
+	for _, inc := range l.Incidents {
+		if inc.Project == project && inc.Service == service && inc.Active() {
+			return inc, true
+		}
+	}
+	return Incident{}, false
+}
+
+// ForService returns the incidents of one service, oldest first.
+func (l *Log) ForService(project, service string) []Incident {
+	var out []Incident
+	for _, inc := range l.Incidents {
+		if inc.Project == project && inc.Service == service {
+			out = append(out, inc)
+		}
+	}
+	return out
+}
+
+// After returns the incidents that started while a deployment made at
+// deployedAt was presumably live: at or after deployedAt and before until,
+// the next deployment's time. A zero until means within Window.
+func After(incidents []Incident, deployedAt, until time.Time) []Incident {
+	if until.IsZero() {
+		until = deployedAt.Add(Window)
+	}
+	var out []Incident
+	for _, inc := range incidents {
+		if !inc.StartedAt.Before(deployedAt) && inc.StartedAt.Before(until) {
+			out = append(out, inc)
+		}
+	}
+	return out
+}
+
+// Downtime returns how much of [from, to) the incidents cover, counting
+// overlapping incidents once. Active incidents last until to.
+func Downtime(incidents []Incident, from, to time.Time) time.Duration {
+	type span struct{ start, end time.Time }
+	var spans []span
+	for _, inc := range incidents {
+		start, end := inc.StartedAt, to
+		if inc.ResolvedAt != nil && inc.ResolvedAt.Before(to) {
+			end = *inc.ResolvedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(start) {
+			spans = append(spans, span{start, end})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	var total time.Duration
+	var covered time.Time // end of the merged spans so far
+	for _, s := range spans {
+		if s.start.Before(covered) {
+			s.start = covered
+		}
+		if s.end.After(s.start) {
+			total += s.end.Sub(s.start)
+			covered = s.end
+		}
+	}
+	return total
+}
@@ -0,0 +1,140 @@
+package incident
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadMissingIsEmpty(t *testing.T) {
+	l, err := Load(filepath.Join(t.TempDir(), "incidents.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if l.NextID != 1 || len(l.Incidents) != 0 {
+		t.Errorf("got %+v, want empty log", l)
+	}
+}
+
+func TestOpenResolveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "incidents.json")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	l, _ := Load(path)
+	first := l.Open("shop", "api", "deploy failed", "d1", now)
+	again := l.Open("shop", "api", "deploy failed again", "d2", now.Add(time.Minute))
+	if again.ID != first.ID {
+		t.Errorf("second Open on an active service created #%d, want #%d", again.ID, first.ID)
+	}
+	other := l.Open("shop", "web", "deploy failed", "d3", now)
+	if other.ID != 2 {
+		t.Errorf("other service got #%d, want #2", other.ID)
+	}
+	if n := l.Resolve("shop", "api", now.Add(time.Hour)); n != 1 {
+		t.Errorf("Resolve closed %d, want 1", n)
+	}
+	if err := l.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	l, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	api := l.ForService("shop", "api")
+	if len(api) != 1 || api[0].Active() {
+		t.Fatalf("api incidents = %+v, want one resolved", api)
+	}
+	if next := l.Open("shop", "api", "down", "", now.Add(2*time.Hour)); next.ID != 3 {
+		t.Errorf("new incident after reload got #%d, want #3", next.ID)
+	}
+}
+
+func TestDeclareClose(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := &Log{NextID: 1}
+
+	inc, created := l.Declare("shop", "api", "checkout errors", now)
+	if !created || !inc.Manual || inc.ID != 1 {
+		t.Fatalf("Declare = %+v, %v", inc, created)
+	}
+	if _, created := l.Declare("shop", "api", "again", now); created {
+		t.Error("Declare on a service with an active incident created another")
+	}
+	if n := l.Resolve("shop", "api", now.Add(time.Minute)); n != 0 {
+		t.Errorf("Resolve closed %d declared incidents, want 0", n)
+	}
+	if active, ok := l.ActiveFor("shop", "api"); !ok || active.ID != 1 {
+		t.Fatalf("ActiveFor = %+v, %v", active, ok)
+	}
+
+	closed, err := l.Close(1, "rolled back", now.Add(time.Hour))
+	if err != nil || closed.Active() || closed.Resolution != "rolled back" {
+		t.Fatalf("Close = %+v, %v", closed, err)
+	}
+	if _, err := l.Close(1, "", now); err == nil {
+		t.Error("closing a resolved incident should fail")
+	}
+	if _, err := l.Close(7, "", now); err == nil {
+		t.Error("closing an unknown incident should fail")
+	}
+	if _, ok := l.ActiveFor("shop", "api"); ok {
+		t.Error("service still has an active incident after Close")
+	}
+}
+
+func TestAfter(t *testing.T) {
+	deployed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	incidents := []Incident{
+		{ID: 1, StartedAt: deployed.Add(-time.Minute)},
+		{ID: 2, StartedAt: deployed.Add(4 * time.Minute)},
+		{ID: 3, StartedAt: deployed.Add(2 * time.Hour)},
+		{ID: 4, StartedAt: deployed.Add(48 * time.Hour)},
+	}
+
+	tests := []struct {
+		name  string
+		until time.Time
+		want  []int
+	}{
+		{"until next deploy", deployed.Add(time.Hour), []int{2}},
+		{"default window", time.Time{}, []int{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := After(incidents, deployed, tt.until)
+			var ids []int
+			for _, inc := range got {
+				ids = append(ids, inc.ID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("got %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDowntime(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	at := func(h int) *time.Time { t := from.Add(time.Duration(h) * time.Hour); return &t }
+
+	incidents := []Incident{
+		{StartedAt: from.Add(-2 * time.Hour), ResolvedAt: at(1)}, // clipped to 1h
+		{StartedAt: *at(4), ResolvedAt: at(6)},                   // 2h
+		{StartedAt: *at(5), ResolvedAt: at(7)},                   // overlaps: +1h
+		{StartedAt: *at(22)},                                     // active: 2h
+		{StartedAt: *at(30), ResolvedAt: at(31)},                 // after the range
+	}
+	if got, want := Downtime(incidents, from, to), 6*time.Hour; got != want {
+		t.Errorf("Downtime = %v, want %v", got, want)
+	}
+	if got := Downtime(nil, from, to); got != 0 {
+		t.Errorf("Downtime(nil) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const bitbucketBaseURL = "https://api.bitbucket.org/2.0"
+
+// Bitbucket updates build statuses using the Bitbucket Cloud REST API.
+type Bitbucket struct {
+	token      string
+	username   string
+	httpClient *http.Client
+}
+
+// NewBitbucket creates a new Bitbucket client.
+// With a username the token is sent as an app password (basic auth);
+// without one it is sent as a repository/workspace access token (bearer auth).
+func NewBitbucket(username, token string) *Bitbucket {
+	return &Bitbucket{
+		token:      token,
+		username:   username,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// BuildStatus describes a Bitbucket build status update.
+type BuildStatus struct {
+	Key         string // unique per status, e.g. orbit-myshop-api
+	Name        string
+	State       string // INPROGRESS, SUCCESSFUL, FAILED, STOPPED
+	Description string
+	URL         string // required by Bitbucket
+}
+
+// SetBuildStatus creates or updates the build status with st.Key on a commit.
+// repo must be in "workspace/slug" form.
+func (b *Bitbucket) SetBuildStatus(repo, sha string, st BuildStatus) error {
+	if !strings.Contains(repo, "/") {
+		return fmt.Errorf("bitbucket repo must be workspace/slug, got: %s", repo)
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"key":         st.Key,
+		"name":        st.Name,
+		"state":       st.State,
+		"description": st.Description,
+		"url":         st.URL,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal body: %w", err)
+	}
+
+	path := fmt.Sprintf("/repositories/%s/commit/%s/statuses/build", repo, sha)
+	req, err := http.NewRequest("POST", bitbucketBaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("set build status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return fmt.Errorf("invalid token: unauthorized")
+	}
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("repository or commit not found: %s@%s", repo, sha)
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		return fmt.Errorf("bitbucket API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
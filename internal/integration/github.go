@@ -0,0 +1,128 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const githubBaseURL = "https://api.github.com"
+
+// GitHub posts deploy results to GitHub pull requests using the REST API.
+type GitHub struct {
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitHub creates a new GitHub client with the given token.
+func NewGitHub(token string) *GitHub {
+	return &GitHub{
+		token:      token,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (g *GitHub) doRequest(method, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, githubBaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	return g.httpClient.Do(req)
+}
+
+type githubComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// UpsertPRComment creates a comment on a pull request, or updates the existing
+// comment containing marker so repeated runs don't flood the PR.
+// repo must be in "owner/name" form.
+func (g *GitHub) UpsertPRComment(repo string, pr int, marker, body string) error {
+	if !strings.Contains(repo, "/") {
+		return fmt.Errorf("github repo must be owner/name, got: %s", repo)
+	}
+
+	body = marker + "\n" + body
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("marshal body: %w", err)
+	}
+
+	existing, err := g.findComment(repo, pr, marker)
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+	if existing != 0 {
+		resp, err = g.doRequest("PATCH", fmt.Sprintf("/repos/%s/issues/comments/%d", repo, existing), payload)
+	} else {
+		resp, err = g.doRequest("POST", fmt.Sprintf("/repos/%s/issues/%d/comments", repo, pr), payload)
+	}
+	if err != nil {
+		return fmt.Errorf("post comment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return fmt.Errorf("invalid token: unauthorized")
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		return fmt.Errorf("github API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// findComment returns the ID of the first PR comment containing marker, or 0.
+// It follows the Link header through every page, since a busy PR can have
+// more comments than fit on one.
+func (g *GitHub) findComment(repo string, pr int, marker string) (int64, error) {
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=100", repo, pr)
+	for path != "" {
+		resp, err := g.doRequest("GET", path, nil)
+		if err != nil {
+			return 0, fmt.Errorf("list comments: %w", err)
+		}
+
+		if resp.StatusCode == 404 {
+			resp.Body.Close()
+			return 0, fmt.Errorf("pull request not found: %s#%d", repo, pr)
+		}
+		if resp.StatusCode != 200 {
+			resp.Body.Close()
+			return 0, fmt.Errorf("github API returned status %d", resp.StatusCode)
+		}
+
+		var comments []githubComment
+		err = json.NewDecoder(resp.Body).Decode(&comments)
+		resp.Body.Close()
+		if err != nil {
+			return 0, fmt.Errorf("decode comments: %w", err)
+		}
+		for _, c := range comments {
+			if strings.Contains(c.Body, marker) {
+				return c.ID, nil
+			}
+		}
+		path = strings.TrimPrefix(nextLink(resp.Header.Get("Link")), githubBaseURL)
+	}
+	return 0, nil
+}
+
+// nextLink returns the rel="next" URL of a Link header, or "".
+func nextLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		url, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if ok && strings.Contains(params, `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(url), "<>")
+		}
+	}
+	return ""
+}
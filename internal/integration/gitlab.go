@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultGitLabURL is the API base URL for gitlab.com.
+const DefaultGitLabURL = "https://gitlab.com/api/v4"
+
+// GitLab updates commit statuses using the GitLab REST API.
+type GitLab struct {
+	token      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewGitLab creates a new GitLab client. baseURL defaults to gitlab.com when empty.
+func NewGitLab(token, baseURL string) *GitLab {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	return &GitLab{
+		token:      token,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// CommitStatus describes a commit status update.
+type CommitStatus struct {
+	State       string // pending, running, success, failed, canceled
+	Name        string // context shown in the pipeline/MR widget
+	Description string
+	TargetURL   string
+}
+
+// SetCommitStatus sets the status of a commit. project is a numeric ID or a "group/name" path.
+func (g *GitLab) SetCommitStatus(project, sha string, st CommitStatus) error {
+	payload, err := json.Marshal(map[string]string{
+		"state":       st.State,
+		"name":        st.Name,
+		"description": st.Description,
+		"target_url":  st.TargetURL,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal body: %w", err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/statuses/%s", url.PathEscape(project), sha)
+	req, err := http.NewRequest("POST", g.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", g.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("set commit status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return fmt.Errorf("invalid token: unauthorized")
+	}
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("project or commit not found: %s@%s", project, sha)
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		return fmt.Errorf("gitlab API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GitLabSectionStart returns the job log marker that opens a collapsible section.
+func GitLabSectionStart(name, header string, collapsed bool) string {
+	opts := ""
+	if collapsed {
+		opts = "[collapsed=true]"
+	}
+	return fmt.Sprintf("\x1b[0Ksection_start:%d:%s%s\r\x1b[0K%s", time.Now().Unix(), name, opts, header)
+}
+
+// GitLabSectionEnd returns the job log marker that closes a section.
+func GitLabSectionEnd(name string) string {
+	return fmt.Sprintf("\x1b[0Ksection_end:%d:%s\r\x1b[0K", time.Now().Unix(), name)
+}
@@ -0,0 +1,110 @@
+// Package manifest reads orbit.yaml, a repo-local declaration of the
+// services a project should have and how they should be configured.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// FileNames are the names Find looks for, in order.
+var FileNames = []string{"orbit.yaml", "orbit.yml"}
+
+// AnyValue as an env value only requires the variable to be set.
+const AnyValue = "*"
+
+// Manifest is the contents of an orbit.yaml.
+type Manifest struct {
+	Project  string    `yaml:"project"`
+	Services []Service `yaml:"services"`
+}
+
+// Service is a service the project should have. Empty fields aren't checked.
+type Service struct {
+	Name     string            `yaml:"name"`
+	Platform string            `yaml:"platform,omitempty"`
+	ID       string            `yaml:"id,omitempty"`
+	Scale    *Scale            `yaml:"scale,omitempty"`
+	Env      map[string]string `yaml:"env,omitempty"` // value, or "*" for any
+}
+
+// Scale is a service's expected scaling configuration.
+type Scale struct {
+	Min          *int   `yaml:"min,omitempty"`
+	Max          *int   `yaml:"max,omitempty"`
+	InstanceType string `yaml:"instance_type,omitempty"`
+}
+
+// Find looks for a manifest in dir and its parents, stopping at the
+// repository root (the first directory holding .git). It returns
+// os.ErrNotExist if there is none.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", fmt.Errorf("no %s found: %w", FileNames[0], os.ErrNotExist)
+}
+
+// Load reads and validates the manifest at path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Validate checks that every service has a unique name and that scale
+// expectations are consistent.
+func (m *Manifest) Validate() error {
+	if len(m.Services) == 0 {
+		return errors.New("no services declared")
+	}
+	seen := make(map[string]bool, len(m.Services))
+	for i, s := range m.Services {
+		if s.Name == "" {
+			return fmt.Errorf("service %d has no name", i+1)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("service %q declared twice", s.Name)
+		}
+		seen[s.Name] = true
+		if sc := s.Scale; sc != nil {
+			if (sc.Min != nil && *sc.Min < 0) || (sc.Max != nil && *sc.Max < 0) {
+				return fmt.Errorf("service %q: scale must not be negative", s.Name)
+			}
+			if sc.Min != nil && sc.Max != nil && *sc.Min > *sc.Max {
+				return fmt.Errorf("service %q: scale min (%d) is above max (%d)", s.Name, *sc.Min, *sc.Max)
+			}
+		}
+	}
+	return nil
+}
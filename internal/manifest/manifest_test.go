@@ -0,0 +1,85 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orbit.yaml")
+	os.WriteFile(path, []byte(`project: myshop
+services:
+  - name: api
+    platform: koyeb
+    scale: {min: 1, max: 3}
+    env:
+      LOG_LEVEL: info
+      DATABASE_URL: "*"
+  - name: web
+    platform: vercel
+`), 0600)
+
+	m, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Project != "myshop" || len(m.Services) != 2 {
+		t.Fatalf("got %+v", m)
+	}
+	api := m.Services[0]
+	if api.Scale == nil || *api.Scale.Min != 1 || *api.Scale.Max != 3 || api.Scale.InstanceType != "" {
+		t.Errorf("scale = %+v", api.Scale)
+	}
+	if api.Env["DATABASE_URL"] != AnyValue || api.Env["LOG_LEVEL"] != "info" {
+		t.Errorf("env = %v", api.Env)
+	}
+	if m.Services[1].Scale != nil {
+		t.Error("web should have no scale expectation")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	one, three := 1, 3
+	tests := []struct {
+		name    string
+		m       Manifest
+		wantErr bool
+	}{
+		{"ok", Manifest{Services: []Service{{Name: "api", Scale: &Scale{Min: &one, Max: &three}}}}, false},
+		{"empty", Manifest{}, true},
+		{"no name", Manifest{Services: []Service{{Platform: "koyeb"}}}, true},
+		{"duplicate", Manifest{Services: []Service{{Name: "api"}, {Name: "api"}}}, true},
+		{"min above max", Manifest{Services: []Service{{Name: "api", Scale: &Scale{Min: &three, Max: &one}}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	repo := t.TempDir()
+	os.Mkdir(filepath.Join(repo, ".git"), 0700)
+	sub := filepath.Join(repo, "services", "api")
+	os.MkdirAll(sub, 0700)
+
+	if _, err := Find(sub); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("err = %v, want ErrNotExist", err)
+	}
+
+	want := filepath.Join(repo, "orbit.yml")
+	os.WriteFile(want, []byte("services: [{name: api}]\n"), 0600)
+	got, err := Find(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Find = %q, want %q", got, want)
+	}
+}
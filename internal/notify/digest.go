@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	bolt "go.etcd.io/bbolt"
+)
+
+// digestSpool holds events queued for a channel's next digest.
+// It lives on disk because each orbit invocation is short-lived.
+type digestSpool struct {
+	Since  time.Time `json:"since"`
+	Events []Event   `json:"events"`
+}
+
+// spoolLockTimeout is how long to wait for another orbit process (orbit
+// status, orbit watch or the heartbeat daemon) that is updating the spool.
+const spoolLockTimeout = 5 * time.Second
+
+var spoolBucket = []byte("spools") // channel → digestSpool
+
+// spoolMu serializes spool updates within this process; bbolt's file lock
+// does across processes, but blocks a second open from the same process.
+var spoolMu sync.Mutex
+
+// updateSpool runs fn on the channel's spool in one transaction of
+// ~/.orbit/digests.db and stores the result, or clears it when fn leaves
+// no events.
+func updateSpool(channel string, fn func(s *digestSpool) error) error {
+	spoolMu.Lock()
+	defer spoolMu.Unlock()
+
+	dir, err := config.EnsureDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "digests.db")
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: spoolLockTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return fmt.Errorf("open digests: %s is in use by another orbit process", path)
+	}
+	if err != nil {
+		return fmt.Errorf("open digests: %w", err)
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(spoolBucket)
+		if err != nil {
+			return fmt.Errorf("open digests: %w", err)
+		}
+		var s digestSpool
+		if data := b.Get([]byte(channel)); data != nil {
+			if err := json.Unmarshal(data, &s); err != nil {
+				return fmt.Errorf("decode digest: %w", err)
+			}
+		}
+		if err := fn(&s); err != nil {
+			return err
+		}
+		if len(s.Events) == 0 {
+			return b.Delete([]byte(channel))
+		}
+		data, err := json.Marshal(s)
+		if err != nil {
+			return fmt.Errorf("encode digest: %w", err)
+		}
+		return b.Put([]byte(channel), data)
+	})
+}
+
+// enqueue appends ev to the channel's pending digest.
+func enqueue(channel string, ev Event) error {
+	return updateSpool(channel, func(s *digestSpool) error {
+		if len(s.Events) == 0 {
+			s.Since = ev.Time
+		}
+		s.Events = append(s.Events, ev)
+		return nil
+	})
+}
+
+// takeDue returns and clears the channel's pending events if the digest
+// interval has elapsed since the first queued event. Nothing is returned
+// while every pending event is still held (e.g. by quiet hours).
+func takeDue(channel string, interval time.Duration, now time.Time, held func(Event) bool) ([]Event, error) {
+	var events []Event
+	err := updateSpool(channel, func(s *digestSpool) error {
+		if len(s.Events) == 0 || now.Sub(s.Since) < interval {
+			return nil
+		}
+		if held != nil {
+			allHeld := true
+			for _, ev := range s.Events {
+				if !held(ev) {
+					allHeld = false
+					break
+				}
+			}
+			if allHeld {
+				return nil
+			}
+		}
+		events, s.Events = s.Events, nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
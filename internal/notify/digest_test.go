@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDigestHoldsUntilIntervalElapses(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // Windows
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		ev := Event{Type: "threshold_violation", Project: "myshop", Service: "api", Title: "response_time over threshold", Time: start.Add(time.Duration(i) * time.Minute)}
+		if err := enqueue("slack", ev); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	events, err := takeDue("slack", time.Hour, start.Add(30*time.Minute), nil)
+	if err != nil {
+		t.Fatalf("takeDue: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no events before interval, got %d", len(events))
+	}
+
+	events, err = takeDue("slack", time.Hour, start.Add(time.Hour), nil)
+	if err != nil {
+		t.Fatalf("takeDue: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+
+	// Spool is cleared after a flush.
+	events, err = takeDue("slack", time.Hour, start.Add(2*time.Hour), nil)
+	if err != nil {
+		t.Fatalf("takeDue: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected empty spool after flush, got %d", len(events))
+	}
+}
+
+func TestFormatTextGroupsRepeats(t *testing.T) {
+	now := time.Now()
+	events := []Event{
+		{Type: "threshold_violation", Project: "myshop", Service: "api", Title: "response_time over threshold", Message: "612ms", Time: now},
+		{Type: "threshold_violation", Project: "myshop", Service: "api", Title: "response_time over threshold", Message: "812ms", Time: now},
+		{Type: "threshold_violation", Project: "myshop", Service: "web", Title: "cpu over threshold", Message: "91%", Time: now},
+	}
+
+	text := FormatText(events)
+	if !strings.Contains(text, "3 events") {
+		t.Errorf("expected event count in header, got:\n%s", text)
+	}
+	if !strings.Contains(text, "myshop/api — response_time over threshold: 812ms ×2") {
+		t.Errorf("expected grouped api line with latest value, got:\n%s", text)
+	}
+	if strings.Count(text, "\n") != 2 {
+		t.Errorf("expected header + 2 lines, got:\n%s", text)
+	}
+}
+
+func TestDigestKeepsEventsEnqueuedWhileFlushing(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // Windows
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ev := Event{Type: "threshold_violation", Service: fmt.Sprintf("svc%d", i), Time: start}
+			if err := enqueue("slack", ev); err != nil {
+				t.Errorf("enqueue: %v", err)
+			}
+		}(i)
+	}
+
+	got := 0
+	for i := 0; i < n; i++ {
+		events, err := takeDue("slack", 0, start, nil)
+		if err != nil {
+			t.Fatalf("takeDue: %v", err)
+		}
+		got += len(events)
+	}
+	wg.Wait()
+	events, err := takeDue("slack", 0, start, nil)
+	if err != nil {
+		t.Fatalf("takeDue: %v", err)
+	}
+	if got += len(events); got != n {
+		t.Errorf("flushed %d events, want %d", got, n)
+	}
+}
@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+// sendMail delivers a message; tests replace it.
+var sendMail = dialAndSend
+
+// sendEmail mails events as one plain-text message. A template, if set,
+// renders the body; if it fails, the built-in text is sent and the error
+// returned.
+func sendEmail(ch config.NotifyChannel, events []Event) error {
+	if ch.SMTP == "" || ch.From == "" || len(ch.To) == 0 {
+		return fmt.Errorf("email channel needs smtp, from and to")
+	}
+	host, _, err := net.SplitHostPort(ch.SMTP)
+	if err != nil {
+		return fmt.Errorf("invalid smtp address %q: want host:port", ch.SMTP)
+	}
+
+	body := FormatText(events)
+	var tmplErr error
+	if ch.Template != "" {
+		out, err := RenderTemplate(ch.Template, events)
+		if err != nil {
+			tmplErr = fmt.Errorf("template: %w (sent the default message)", err)
+		} else {
+			body = out
+		}
+	}
+
+	var auth smtp.Auth
+	if ch.Username != "" {
+		password, err := smtpPassword(ch)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", ch.Username, password, host)
+	}
+
+	msg := emailMessage(ch.From, ch.To, emailSubject(events), body, time.Now())
+	if err := sendMail(ch.SMTP, auth, ch.From, ch.To, msg); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return tmplErr
+}
+
+// smtpPassword decrypts the channel's password, falling back to
+// $ORBIT_SMTP_PASSWORD.
+func smtpPassword(ch config.NotifyChannel) (string, error) {
+	if ch.Password == "" {
+		return os.Getenv("ORBIT_SMTP_PASSWORD"), nil
+	}
+	if !config.IsEncrypted(ch.Password) {
+		return ch.Password, nil
+	}
+	key, err := config.LoadOrCreateKey()
+	if err != nil {
+		return "", fmt.Errorf("load encryption key: %w", err)
+	}
+	password, err := config.Decrypt(key, ch.Password)
+	if err != nil {
+		return "", fmt.Errorf("decrypt smtp password: %w", err)
+	}
+	return password, nil
+}
+
+func emailSubject(events []Event) string {
+	if len(events) > 1 {
+		return fmt.Sprintf("Orbit digest: %d events", len(events))
+	}
+	e := events[0]
+	return fmt.Sprintf("[%s] %s: %s", e.Severity, e.Subject(), e.Title)
+}
+
+// emailMessage builds an RFC 5322 message with a plain-text UTF-8 body.
+func emailMessage(from string, to []string, subject, body string, date time.Time) []byte {
+	// Header values come from config and event titles; never let them
+	// start a new header.
+	clean := strings.NewReplacer("\r", " ", "\n", " ")
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", clean.Replace(from))
+	fmt.Fprintf(&b, "To: %s\r\n", clean.Replace(strings.Join(to, ", ")))
+	fmt.Fprintf(&b, "Subject: %s\r\n", clean.Replace(subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
+
+// dialAndSend is smtp.SendMail, plus implicit TLS for port 465, which
+// SendMail does not speak (it upgrades with STARTTLS when offered).
+func dialAndSend(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	host, port, _ := net.SplitHostPort(addr)
+	if port != "465" {
+		return smtp.SendMail(addr, auth, from, to, msg)
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", addr, &tls.Config{ServerName: host})
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
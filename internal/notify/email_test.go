@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestSendEmail(t *testing.T) {
+	var gotAddr string
+	var gotTo []string
+	var gotMsg string
+	sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, string(msg)
+		return nil
+	}
+	defer func() { sendMail = dialAndSend }()
+
+	ch := config.NotifyChannel{
+		Type: "email",
+		SMTP: "smtp.example.com:587",
+		From: "orbit@example.com",
+		To:   []string{"ops@example.com", "dev@example.com"},
+	}
+	events := []Event{{Severity: SeverityCritical, Project: "shop", Service: "api", Title: "deploy failed\nBcc: evil@example.com"}}
+	if err := Send(ch, events); err != nil {
+		t.Fatal(err)
+	}
+	if gotAddr != "smtp.example.com:587" || len(gotTo) != 2 {
+		t.Errorf("sent to %s %v", gotAddr, gotTo)
+	}
+	for _, want := range []string{
+		"To: ops@example.com, dev@example.com\r\n",
+		"Subject: [critical] shop/api: deploy failed Bcc: evil@example.com\r\n",
+		"\r\n\r\n[critical] shop/api: deploy failed",
+	} {
+		if !strings.Contains(gotMsg, want) {
+			t.Errorf("message lacks %q:\n%s", want, gotMsg)
+		}
+	}
+
+	ch.Template = "{{.Subject}} needs {{.Owner}}"
+	events[0].Owner = "@payments"
+	if err := Send(ch, events); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(gotMsg, "\r\n\r\nshop/api needs @payments\r\n") {
+		t.Errorf("template body not used:\n%s", gotMsg)
+	}
+
+	if err := Send(config.NotifyChannel{Type: "email", From: "orbit@example.com"}, events); err == nil {
+		t.Error("want error for a channel without smtp and to")
+	}
+}
@@ -0,0 +1,310 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+// Severity levels for events.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Event is a single notification-worthy occurrence.
+type Event struct {
+	Type     string    `json:"type"` // threshold_violation, deploy_failed, ...
+	Severity string    `json:"severity"`
+	Project  string    `json:"project,omitempty"`
+	Service  string    `json:"service,omitempty"`
+	Tags     []string  `json:"tags,omitempty"`
+	Owner    string    `json:"owner,omitempty"` // the service's owner from the topology
+	Title    string    `json:"title"`
+	Message  string    `json:"message,omitempty"`
+	Phase    string    `json:"phase,omitempty"`     // deploy phase, e.g. build
+	Commit   string    `json:"commit,omitempty"`    // commit of the deployment concerned
+	DeployID string    `json:"deploy_id,omitempty"` // its ID, for deploy events
+	URL      string    `json:"url,omitempty"`       // deployment or dashboard link
+	Time     time.Time `json:"time"`
+}
+
+// Subject returns "project/service", or whichever part is set.
+func (e Event) Subject() string {
+	switch {
+	case e.Project != "" && e.Service != "":
+		return e.Project + "/" + e.Service
+	case e.Service != "":
+		return e.Service
+	default:
+		return e.Project
+	}
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Notify delivers ev to the channels selected by the routing rules in nc.
+// Channels with a digest interval queue the event and only send once the
+// interval has elapsed; channels in quiet hours queue events below their
+// override severity. Returns any per-channel delivery errors keyed by channel name.
+func Notify(nc config.NotificationsConfig, ev Event) map[string]error {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	errMap := make(map[string]error)
+	for _, name := range Route(nc, ev) {
+		ch, ok := nc.Channels[name]
+		if !ok {
+			errMap[name] = fmt.Errorf("routed to unknown channel")
+			continue
+		}
+		interval, err := digestInterval(ch)
+		if err != nil {
+			errMap[name] = err
+			continue
+		}
+		held, err := heldByQuietHours(ch, ev, ev.Time)
+		if err != nil {
+			// Misconfigured quiet hours shouldn't swallow alerts.
+			errMap[name] = err
+		}
+		if interval == 0 && !held {
+			if err := Send(ch, []Event{ev}); err != nil {
+				errMap[name] = err
+			}
+			continue
+		}
+		if err := enqueue(name, ev); err != nil {
+			errMap[name] = err
+		}
+	}
+
+	for name, err := range FlushDigests(nc.Channels) {
+		errMap[name] = err
+	}
+	return errMap
+}
+
+// FlushDigests sends queued events whose digest interval has elapsed.
+// Events held back by quiet hours are sent once the quiet window ends.
+func FlushDigests(channels map[string]config.NotifyChannel) map[string]error {
+	errMap := make(map[string]error)
+	now := time.Now()
+	for name, ch := range channels {
+		interval, err := digestInterval(ch)
+		if err != nil {
+			continue
+		}
+		held := func(ev Event) bool {
+			h, _ := heldByQuietHours(ch, ev, now)
+			return h
+		}
+		events, err := takeDue(name, interval, now, held)
+		if err != nil {
+			errMap[name] = err
+			continue
+		}
+		if len(events) == 0 {
+			continue
+		}
+		if err := Send(ch, events); err != nil {
+			errMap[name] = err
+		}
+	}
+	return errMap
+}
+
+func digestInterval(ch config.NotifyChannel) (time.Duration, error) {
+	if ch.Digest == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(ch.Digest)
+	if err != nil {
+		return 0, fmt.Errorf("invalid digest interval %q: %w", ch.Digest, err)
+	}
+	return d, nil
+}
+
+// PayloadFunc builds a channel's payload with the named policy script
+// function. A nil payload means the built-in one.
+type PayloadFunc func(fn string, events []Event) (interface{}, error)
+
+var payloadFunc PayloadFunc
+
+// SetPayloadFunc installs the function that runs channels' payload scripts.
+func SetPayloadFunc(f PayloadFunc) {
+	payloadFunc = f
+}
+
+// Send delivers events to a channel immediately as one message.
+// More than one event is rendered as a digest. A channel with a script gets
+// the payload the script builds, and one with a template the rendered
+// template; if either fails, the built-in payload is still sent and the
+// error returned. Email channels use templates but not scripts.
+func Send(ch config.NotifyChannel, events []Event) error {
+	switch ch.Type {
+	case "email":
+		return sendEmail(ch, events)
+	case "slack", "discord", "webhook", "":
+	default:
+		return fmt.Errorf("unknown channel type: %s", ch.Type)
+	}
+	if ch.URL == "" {
+		return fmt.Errorf("channel has no url")
+	}
+
+	if ch.Script != "" || ch.Template != "" {
+		body, err := customBody(ch, events)
+		if err == nil && body != nil {
+			return postBody(ch.URL, body)
+		}
+		if err != nil {
+			if sendErr := Send(config.NotifyChannel{Type: ch.Type, URL: ch.URL}, events); sendErr != nil {
+				return sendErr
+			}
+			return fmt.Errorf("%w (sent the default payload)", err)
+		}
+	}
+
+	var payload interface{}
+	switch ch.Type {
+	case "slack":
+		payload = map[string]string{"text": FormatText(events)}
+	case "discord":
+		payload = discordMessage(FormatText(events))
+	default:
+		if len(events) == 1 {
+			payload = events[0]
+		} else {
+			payload = map[string]interface{}{"digest": true, "events": events}
+		}
+	}
+
+	return postJSON(ch.URL, payload)
+}
+
+// customBody builds the request body from the channel's script, or else its
+// template. A nil body means the script chose the built-in payload.
+func customBody(ch config.NotifyChannel, events []Event) ([]byte, error) {
+	if ch.Script == "" {
+		return templateBody(ch.Type, ch.Template, events)
+	}
+	payload, err := scriptPayload(ch.Script, events)
+	if err != nil {
+		return nil, fmt.Errorf("payload script %s: %w", ch.Script, err)
+	}
+	if payload == nil {
+		return nil, nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("payload script %s: %w", ch.Script, err)
+	}
+	return body, nil
+}
+
+// maxDiscordContent is the longest message Discord accepts.
+const maxDiscordContent = 2000
+
+func discordMessage(text string) map[string]string {
+	if r := []rune(text); len(r) > maxDiscordContent {
+		text = string(r[:maxDiscordContent-1]) + "…"
+	}
+	return map[string]string{"content": text}
+}
+
+func scriptPayload(fn string, events []Event) (interface{}, error) {
+	if payloadFunc == nil {
+		return nil, fmt.Errorf("no script is configured")
+	}
+	return payloadFunc(fn, events)
+}
+
+func postJSON(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	return postBody(url, body)
+}
+
+func postBody(url string, body []byte) error {
+	resp, err := httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("post notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// maxDigestLines caps how many grouped lines a digest message shows.
+const maxDigestLines = 20
+
+// FormatText renders events as a plain-text message. A single event is shown
+// as-is; several are grouped by subject and title with repeat counts.
+func FormatText(events []Event) string {
+	if len(events) == 1 {
+		e := events[0]
+		text := fmt.Sprintf("[%s] %s: %s", e.Severity, e.Subject(), e.Title)
+		if e.Message != "" {
+			text += " — " + e.Message
+		}
+		return text
+	}
+
+	type group struct {
+		event Event
+		count int
+	}
+	groups := make(map[string]*group)
+	var order []string
+	since := events[0].Time
+	for _, e := range events {
+		if e.Time.Before(since) {
+			since = e.Time
+		}
+		k := e.Type + "|" + e.Subject() + "|" + e.Title
+		g, ok := groups[k]
+		if !ok {
+			g = &group{}
+			groups[k] = g
+			order = append(order, k)
+		}
+		g.event = e // keep the latest message
+		g.count++
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return groups[order[i]].count > groups[order[j]].count
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Orbit digest: %d events since %s\n", len(events), since.Format("Jan 2 15:04"))
+	for i, k := range order {
+		if i == maxDigestLines {
+			fmt.Fprintf(&b, "… and %d more\n", len(order)-maxDigestLines)
+			break
+		}
+		g := groups[k]
+		fmt.Fprintf(&b, "• %s — %s", g.event.Subject(), g.event.Title)
+		if g.event.Message != "" {
+			fmt.Fprintf(&b, ": %s", g.event.Message)
+		}
+		if g.count > 1 {
+			fmt.Fprintf(&b, " ×%d", g.count)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestSendDiscord(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	ch := config.NotifyChannel{Type: "discord", URL: srv.URL}
+	if err := Send(ch, []Event{{Severity: SeverityWarning, Service: "api", Title: "cpu over threshold"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got["content"] != "[warning] api: cpu over threshold" {
+		t.Errorf("content = %q", got["content"])
+	}
+
+	long := Event{Severity: SeverityWarning, Service: "api", Title: strings.Repeat("x", 3000)}
+	if err := Send(ch, []Event{long}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len([]rune(got["content"])); n != maxDiscordContent {
+		t.Errorf("long message has %d characters, want %d", n, maxDiscordContent)
+	}
+}
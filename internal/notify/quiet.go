@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+var severityRank = map[string]int{
+	SeverityInfo:     0,
+	SeverityWarning:  1,
+	SeverityCritical: 2,
+}
+
+// severityAtLeast reports whether sev is at or above min.
+// Unknown severities are treated as warnings.
+func severityAtLeast(sev, min string) bool {
+	r, ok := severityRank[sev]
+	if !ok {
+		r = severityRank[SeverityWarning]
+	}
+	m, ok := severityRank[min]
+	if !ok {
+		m = severityRank[SeverityCritical]
+	}
+	return r >= m
+}
+
+// inQuietHours reports whether now falls within q.
+func inQuietHours(q *config.QuietHours, now time.Time) (bool, error) {
+	if q == nil {
+		return false, nil
+	}
+
+	if q.Timezone != "" {
+		loc, err := time.LoadLocation(q.Timezone)
+		if err != nil {
+			return false, fmt.Errorf("invalid quiet hours timezone %q: %w", q.Timezone, err)
+		}
+		now = now.In(loc)
+	}
+
+	day := strings.ToLower(now.Weekday().String()[:3])
+	for _, d := range q.Days {
+		if len(d) >= 3 && strings.ToLower(d[:3]) == day {
+			return true, nil
+		}
+	}
+
+	if q.Start == "" && q.End == "" {
+		return false, nil
+	}
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false, err
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return false, err
+	}
+
+	mins := now.Hour()*60 + now.Minute()
+	if start <= end {
+		return mins >= start && mins < end, nil
+	}
+	// Window wraps past midnight, e.g. 22:00–07:00.
+	return mins >= start || mins < end, nil
+}
+
+// parseClock parses "HH:MM" into minutes after midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// heldByQuietHours reports whether ev must wait because ch is in quiet hours
+// at now. Events at or above the override severity are never held.
+func heldByQuietHours(ch config.NotifyChannel, ev Event, now time.Time) (bool, error) {
+	quiet, err := inQuietHours(ch.QuietHours, now)
+	if err != nil || !quiet {
+		return false, err
+	}
+	return !severityAtLeast(ev.Severity, ch.QuietHours.Override), nil
+}
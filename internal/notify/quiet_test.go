@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestInQuietHours(t *testing.T) {
+	q := &config.QuietHours{Start: "22:00", End: "07:00", Days: []string{"sun"}, Timezone: "UTC"}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2025, 1, 1, 23, 30, 0, 0, time.UTC), true}, // Wed, inside window
+		{time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC), true},   // wraps past midnight
+		{time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC), false},  // window end is exclusive
+		{time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), false}, // Wed midday
+		{time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC), true},  // Sunday, all day
+	}
+	for _, tt := range tests {
+		got, err := inQuietHours(q, tt.at)
+		if err != nil {
+			t.Fatalf("inQuietHours: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("inQuietHours(%s) = %v, want %v", tt.at.Format(time.RFC1123), got, tt.want)
+		}
+	}
+}
+
+func TestCriticalBypassesQuietHours(t *testing.T) {
+	ch := config.NotifyChannel{QuietHours: &config.QuietHours{Start: "00:00", End: "23:59", Timezone: "UTC"}}
+	at := time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	held, _ := heldByQuietHours(ch, Event{Severity: SeverityWarning}, at)
+	if !held {
+		t.Error("warning should be held during quiet hours")
+	}
+	held, _ = heldByQuietHours(ch, Event{Severity: SeverityCritical}, at)
+	if held {
+		t.Error("critical should bypass quiet hours")
+	}
+}
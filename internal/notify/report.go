@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+// Schedule is a parsed config.ReportConfig.
+type Schedule struct {
+	Every string // daily, weekly
+	clock int    // minutes after midnight
+	day   time.Weekday
+	loc   *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseSchedule validates rc and applies defaults (09:00, Monday, local time).
+func ParseSchedule(rc config.ReportConfig) (Schedule, error) {
+	s := Schedule{Every: rc.Every, clock: 9 * 60, day: time.Monday, loc: time.Local}
+	if s.Every != "daily" && s.Every != "weekly" {
+		return s, fmt.Errorf("invalid report schedule %q (want daily or weekly)", rc.Every)
+	}
+	if rc.At != "" {
+		clock, err := parseClock(rc.At)
+		if err != nil {
+			return s, err
+		}
+		s.clock = clock
+	}
+	if rc.Day != "" {
+		d, ok := weekdays[strings.ToLower(rc.Day)[:min(3, len(rc.Day))]]
+		if !ok {
+			return s, fmt.Errorf("invalid report day %q", rc.Day)
+		}
+		s.day = d
+	}
+	if rc.Timezone != "" {
+		loc, err := time.LoadLocation(rc.Timezone)
+		if err != nil {
+			return s, fmt.Errorf("invalid report timezone %q: %w", rc.Timezone, err)
+		}
+		s.loc = loc
+	}
+	return s, nil
+}
+
+// Period is how much time one report covers.
+func (s Schedule) Period() time.Duration {
+	if s.Every == "weekly" {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Next returns the first report time strictly after now.
+func (s Schedule) Next(now time.Time) time.Time {
+	local := now.In(s.loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), s.clock/60, s.clock%60, 0, 0, s.loc)
+	if s.Every == "weekly" {
+		next = next.AddDate(0, 0, (int(s.day)-int(next.Weekday())+7)%7)
+	}
+	for !next.After(now) {
+		if s.Every == "weekly" {
+			next = next.AddDate(0, 0, 7)
+		} else {
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+	return next
+}
+
+// ReportService summarizes one service over a report period.
+type ReportService struct {
+	Name          string
+	Deploys       int
+	FailedDeploys int
+	Checks        int // heartbeat checks run by the daemon
+	FailedChecks  int
+	Violations    []string // thresholds exceeded when the report was made, e.g. "cpu 91.0%"
+	Error         string   // why deploys could not be counted
+}
+
+// Report is a scheduled project summary.
+type Report struct {
+	Project  string
+	Every    string // daily, weekly
+	From, To time.Time
+	Services []ReportService
+}
+
+// Event renders the report as an info event, one line per service under a
+// project-wide total.
+func (r Report) Event() Event {
+	var deploys, failed, checks, failedChecks, violations int
+	var lines []string
+	for _, s := range r.Services {
+		deploys += s.Deploys
+		failed += s.FailedDeploys
+		checks += s.Checks
+		failedChecks += s.FailedChecks
+		violations += len(s.Violations)
+
+		var parts []string
+		if s.Error != "" {
+			parts = append(parts, "deploys unknown ("+s.Error+")")
+		} else {
+			parts = append(parts, deployCount(s.Deploys, s.FailedDeploys))
+		}
+		if s.Checks > 0 {
+			parts = append(parts, "uptime "+uptime(s.Checks, s.FailedChecks))
+		}
+		if len(s.Violations) > 0 {
+			parts = append(parts, "over threshold: "+strings.Join(s.Violations, ", "))
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", s.Name, strings.Join(parts, ", ")))
+	}
+
+	summary := []string{deployCount(deploys, failed)}
+	if checks > 0 {
+		summary = append(summary, "uptime "+uptime(checks, failedChecks))
+	}
+	summary = append(summary, fmt.Sprintf("%d threshold violations", violations))
+
+	return Event{
+		Type:     "report",
+		Severity: SeverityInfo,
+		Project:  r.Project,
+		Title:    fmt.Sprintf("%s report, %s – %s", r.Every, r.From.Format("Jan 2 15:04"), r.To.Format("Jan 2 15:04")),
+		Message:  strings.Join(summary, ", ") + "\n" + strings.Join(lines, "\n"),
+		Time:     r.To,
+	}
+}
+
+func deployCount(n, failed int) string {
+	s := fmt.Sprintf("%d deploys", n)
+	if n == 1 {
+		s = "1 deploy"
+	}
+	if failed > 0 {
+		s += fmt.Sprintf(" (%d failed)", failed)
+	}
+	return s
+}
+
+func uptime(checks, failed int) string {
+	return fmt.Sprintf("%.1f%%", float64(checks-failed)/float64(checks)*100)
+}
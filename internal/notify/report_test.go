@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestScheduleNext(t *testing.T) {
+	utc := "UTC"
+	// Wednesday 2026-03-04 10:30 UTC
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rc   config.ReportConfig
+		want time.Time
+	}{
+		{"daily later today", config.ReportConfig{Every: "daily", At: "18:00", Timezone: utc}, time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)},
+		{"daily already passed", config.ReportConfig{Every: "daily", Timezone: utc}, time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)},
+		{"weekly default monday", config.ReportConfig{Every: "weekly", Timezone: utc}, time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{"weekly same day later", config.ReportConfig{Every: "weekly", Day: "Wednesday", At: "12:00", Timezone: utc}, time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)},
+		{"weekly same day passed", config.ReportConfig{Every: "weekly", Day: "wed", At: "08:00", Timezone: utc}, time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.rc)
+			if err != nil {
+				t.Fatalf("ParseSchedule: %v", err)
+			}
+			if got := s.Next(now); !got.Equal(tt.want) {
+				t.Errorf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, rc := range []config.ReportConfig{
+		{Every: "hourly"},
+		{Every: "daily", At: "9am"},
+		{Every: "weekly", Day: "someday"},
+		{Every: "daily", Timezone: "Mars/Olympus"},
+	} {
+		if _, err := ParseSchedule(rc); err == nil {
+			t.Errorf("ParseSchedule(%+v) succeeded, want error", rc)
+		}
+	}
+}
+
+func TestReportEvent(t *testing.T) {
+	from := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	r := Report{
+		Project: "shop",
+		Every:   "daily",
+		From:    from,
+		To:      from.Add(24 * time.Hour),
+		Services: []ReportService{
+			{Name: "api", Deploys: 3, FailedDeploys: 1, Checks: 200, FailedChecks: 2, Violations: []string{"cpu 91.0%"}},
+			{Name: "web", Deploys: 1},
+		},
+	}
+	ev := r.Event()
+	if ev.Type != "report" || ev.Severity != SeverityInfo || ev.Project != "shop" {
+		t.Errorf("unexpected event header: %+v", ev)
+	}
+	for _, want := range []string{
+		"4 deploys (1 failed), uptime 99.0%, 1 threshold violations",
+		"api: 3 deploys (1 failed), uptime 99.0%, over threshold: cpu 91.0%",
+		"web: 1 deploy",
+	} {
+		if !strings.Contains(ev.Message, want) {
+			t.Errorf("message missing %q:\n%s", want, ev.Message)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"path"
+	"sort"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+// Route returns the names of the channels that should receive ev.
+// With no routes configured every channel receives every event; otherwise
+// routes are evaluated in order and events no route matches go to the
+// default channels (if any).
+func Route(nc config.NotificationsConfig, ev Event) []string {
+	if len(nc.Routes) == 0 {
+		names := make([]string, 0, len(nc.Channels))
+		for name := range nc.Channels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	add := func(chs []string) {
+		for _, name := range chs {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	matched := false
+	for _, r := range nc.Routes {
+		if !routeMatches(r.Match, ev) {
+			continue
+		}
+		matched = true
+		add(r.Channels)
+		if !r.Continue {
+			break
+		}
+	}
+	if !matched {
+		add(nc.Default)
+	}
+	return names
+}
+
+func routeMatches(m config.RouteMatch, ev Event) bool {
+	if !globMatch(m.Project, ev.Project) || !globMatch(m.Service, ev.Service) || !globMatch(m.Event, ev.Type) {
+		return false
+	}
+	if m.Severity != "" && !severityAtLeast(ev.Severity, m.Severity) {
+		return false
+	}
+	if m.Tag != "" {
+		for _, tag := range ev.Tags {
+			if globMatch(m.Tag, tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// globMatch reports whether s matches pattern; an empty pattern matches anything.
+func globMatch(pattern, s string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, err := path.Match(pattern, s)
+	return err == nil && ok
+}
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestRoute(t *testing.T) {
+	nc := config.NotificationsConfig{
+		Channels: map[string]config.NotifyChannel{"web": {}, "data": {}, "pager": {}, "ops": {}},
+		Routes: []config.NotifyRoute{
+			{Match: config.RouteMatch{Severity: "critical"}, Channels: []string{"pager"}, Continue: true},
+			{Match: config.RouteMatch{Service: "frontend*"}, Channels: []string{"web"}},
+			{Match: config.RouteMatch{Tag: "database"}, Channels: []string{"data"}},
+		},
+		Default: []string{"ops"},
+	}
+
+	tests := []struct {
+		name string
+		ev   Event
+		want []string
+	}{
+		{"service glob", Event{Service: "frontend-eu", Severity: SeverityWarning}, []string{"web"}},
+		{"tag", Event{Service: "db", Tags: []string{"database"}, Severity: SeverityWarning}, []string{"data"}},
+		{"continue", Event{Service: "frontend", Severity: SeverityCritical}, []string{"pager", "web"}},
+		{"default", Event{Service: "worker", Severity: SeverityWarning}, []string{"ops"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Route(nc, tt.ev); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Route() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteWithoutRulesSendsEverywhere(t *testing.T) {
+	nc := config.NotificationsConfig{
+		Channels: map[string]config.NotifyChannel{"b": {}, "a": {}},
+	}
+	if got := Route(nc, Event{}); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Route() = %v, want [a b]", got)
+	}
+}
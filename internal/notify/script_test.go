@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestSendScriptPayload(t *testing.T) {
+	var got []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, body)
+	}))
+	defer srv.Close()
+	defer SetPayloadFunc(nil)
+
+	SetPayloadFunc(func(fn string, events []Event) (interface{}, error) {
+		switch fn {
+		case "pager":
+			return map[string]interface{}{"summary": events[0].Subject()}, nil
+		case "builtin":
+			return nil, nil
+		}
+		return nil, errors.New("boom")
+	})
+	events := []Event{{Severity: SeverityCritical, Project: "shop", Service: "api", Title: "service unhealthy"}}
+
+	if err := Send(config.NotifyChannel{Type: "slack", URL: srv.URL, Script: "pager"}, events); err != nil {
+		t.Fatal(err)
+	}
+	if got[0]["summary"] != "shop/api" {
+		t.Errorf("script payload = %v", got[0])
+	}
+
+	if err := Send(config.NotifyChannel{Type: "slack", URL: srv.URL, Script: "builtin"}, events); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got[1]["text"]; !ok {
+		t.Errorf("None should send the built-in payload, got %v", got[1])
+	}
+
+	err := Send(config.NotifyChannel{Type: "slack", URL: srv.URL, Script: "broken"}, events)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("want the script error, got %v", err)
+	}
+	if len(got) != 3 || got[2]["text"] == nil {
+		t.Errorf("failed script should still send the built-in payload, got %v", got)
+	}
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TemplateData is what a channel template is executed with. The first
+// event's fields are available directly ({{.Service}}, {{.Commit}}); Events
+// holds every event of a digest, and just the one otherwise.
+type TemplateData struct {
+	Event
+	Subject string
+	Text    string // the built-in plain-text message
+	Digest  bool
+	Events  []Event
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// RenderTemplate executes a channel template for events.
+func RenderTemplate(text string, events []Event) (string, error) {
+	tmpl, err := template.New("channel").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	data := TemplateData{
+		Event:   events[0],
+		Subject: events[0].Subject(),
+		Text:    FormatText(events),
+		Digest:  len(events) > 1,
+		Events:  events,
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// templateBody renders a channel's template into a request body: the text of
+// a Slack or Discord message, or the whole JSON body of a webhook.
+func templateBody(chType, text string, events []Event) ([]byte, error) {
+	out, err := RenderTemplate(text, events)
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	switch chType {
+	case "slack":
+		return json.Marshal(map[string]string{"text": out})
+	case "discord":
+		return json.Marshal(discordMessage(out))
+	}
+	if !json.Valid([]byte(out)) {
+		return nil, fmt.Errorf("template: webhook body is not valid JSON")
+	}
+	return []byte(out), nil
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	ev := Event{Type: "deploy_failed", Severity: SeverityCritical, Project: "shop", Service: "api",
+		Owner: "@payments", Title: "deploy failed", Phase: "build", Commit: "3f2c9a1e8b7d"}
+
+	got, err := RenderTemplate(`{{upper .Severity}} {{.Subject}} failed in {{.Phase}} at {{slice .Commit 0 7}} — cc {{.Owner}}`, []Event{ev})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "CRITICAL shop/api failed in build at 3f2c9a1 — cc @payments"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	digest := `{{if .Digest}}{{len .Events}} events:{{range .Events}} {{.Service}}{{end}}{{end}}`
+	got, err = RenderTemplate(digest, []Event{ev, {Project: "shop", Service: "web"}})
+	if err != nil || got != "2 events: api web" {
+		t.Errorf("digest = %q, %v", got, err)
+	}
+
+	if _, err := RenderTemplate(`{{.Nope}}`, []Event{ev}); err == nil {
+		t.Error("want error for an unknown field")
+	}
+}
+
+func TestSendTemplate(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+	}))
+	defer srv.Close()
+	events := []Event{{Severity: SeverityWarning, Project: "shop", Service: "api", Title: "cpu over threshold"}}
+
+	slack := config.NotifyChannel{Type: "slack", URL: srv.URL, Template: `:warning: {{.Subject}}: {{.Title}}`}
+	if err := Send(slack, events); err != nil {
+		t.Fatal(err)
+	}
+	var msg map[string]string
+	json.Unmarshal([]byte(bodies[0]), &msg)
+	if msg["text"] != ":warning: shop/api: cpu over threshold" {
+		t.Errorf("slack body = %s", bodies[0])
+	}
+
+	hook := config.NotifyChannel{Type: "webhook", URL: srv.URL, Template: `{"summary": {{json .Title}}, "service": {{json .Service}}}`}
+	if err := Send(hook, events); err != nil {
+		t.Fatal(err)
+	}
+	if bodies[1] != `{"summary": "cpu over threshold", "service": "api"}` {
+		t.Errorf("webhook body = %s", bodies[1])
+	}
+
+	broken := config.NotifyChannel{Type: "webhook", URL: srv.URL, Template: `summary: {{.Title}}`}
+	err := Send(broken, events)
+	if err == nil || !strings.Contains(err.Error(), "not valid JSON") {
+		t.Errorf("want invalid JSON error, got %v", err)
+	}
+	if len(bodies) != 3 || !strings.Contains(bodies[2], `"title":"cpu over threshold"`) {
+		t.Errorf("broken template should still send the built-in payload, got %v", bodies)
+	}
+}
@@ -0,0 +1,72 @@
+package platform
+
+import "strings"
+
+// Capabilities is the set of optional operations a platform supports.
+// Every platform reports service status; everything else is declared here
+// so commands can check support before calling.
+type Capabilities uint16
+
+const (
+	CapDeployments Capabilities = 1 << iota // ListDeployments, GetDeployment
+	CapRedeploy                             // Redeploy
+	CapLogs                                 // GetLogs
+	CapScale                                // Scale
+	CapWatch                                // WatchDeployment
+	CapDiscover                             // DiscoverServices
+	CapMetrics                              // CPU and memory in ServiceStatus
+	CapInstances                            // instance counts in ServiceStatus
+	CapRollback                             // RollbackTo
+	CapCancel                               // CancelDeployment
+
+	CapAll = CapDeployments | CapRedeploy | CapLogs | CapScale | CapWatch | CapDiscover | CapMetrics | CapInstances | CapRollback | CapCancel
+)
+
+var capabilityNames = []struct {
+	cap  Capabilities
+	name string
+}{
+	{CapDeployments, "deployments"},
+	{CapRedeploy, "redeploy"},
+	{CapLogs, "logs"},
+	{CapScale, "scale"},
+	{CapWatch, "watch"},
+	{CapDiscover, "discover"},
+	{CapMetrics, "metrics"},
+	{CapInstances, "instances"},
+	{CapRollback, "rollback"},
+	{CapCancel, "cancel"},
+}
+
+// Has reports whether every capability in want is present.
+func (c Capabilities) Has(want Capabilities) bool {
+	return c&want == want
+}
+
+// Names returns the names of the capabilities in c, in declaration order.
+func (c Capabilities) Names() []string {
+	var names []string
+	for _, cn := range capabilityNames {
+		if c.Has(cn.cap) {
+			names = append(names, cn.name)
+		}
+	}
+	return names
+}
+
+func (c Capabilities) String() string {
+	if c == 0 {
+		return "status only"
+	}
+	return strings.Join(c.Names(), ", ")
+}
+
+// ParseCapability returns the capability with the given name.
+func ParseCapability(name string) (Capabilities, bool) {
+	for _, cn := range capabilityNames {
+		if strings.EqualFold(cn.name, name) {
+			return cn.cap, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,50 @@
+package platform
+
+import "testing"
+
+func TestCapabilities(t *testing.T) {
+	caps := CapDeployments | CapLogs | CapWatch
+	if !caps.Has(CapLogs) || !caps.Has(CapDeployments|CapWatch) {
+		t.Errorf("%v should have logs, deployments and watch", caps)
+	}
+	if caps.Has(CapLogs | CapScale) {
+		t.Errorf("%v should not have scale", caps)
+	}
+	if got := caps.String(); got != "deployments, logs, watch" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Capabilities(0).String(); got != "status only" {
+		t.Errorf("zero String() = %q", got)
+	}
+
+	for _, name := range CapAll.Names() {
+		c, ok := ParseCapability(name)
+		if !ok || c.String() != name {
+			t.Errorf("ParseCapability(%q) = %v, %v", name, c, ok)
+		}
+	}
+	if _, ok := ParseCapability("teleport"); ok {
+		t.Error("ParseCapability(teleport) should fail")
+	}
+}
+
+func TestCustomCapabilities(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  HTTPConfig
+		want Capabilities
+	}{
+		{"status only", HTTPConfig{StatusURL: "http://x"}, 0},
+		{"deploys and logs", HTTPConfig{DeploysURL: "http://x", LogsURL: "http://x"}, CapDeployments | CapWatch | CapLogs},
+		{"metrics", HTTPConfig{Mappings: HTTPMappings{CPU: "cpu", Instances: "replicas"}}, CapMetrics | CapInstances},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Custom{}
+			c.SetHTTPConfig(tt.cfg)
+			if got := c.Capabilities(); got != tt.want {
+				t.Errorf("Capabilities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package platform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// cliCredential is where a vendor CLI keeps its login token.
+type cliCredential struct {
+	cli   string                            // command users log in with
+	paths func(home string) []string        // candidate files, most likely first
+	parse func(data []byte) (string, error) // extracts the token
+}
+
+var cliCredentials = map[string]cliCredential{
+	"vercel": {
+		cli: "vercel login",
+		paths: func(home string) []string {
+			var paths []string
+			if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+				paths = append(paths, filepath.Join(dir, "com.vercel.cli", "auth.json"))
+			}
+			paths = append(paths, filepath.Join(home, ".local", "share", "com.vercel.cli", "auth.json"))
+			if dir, err := os.UserConfigDir(); err == nil {
+				paths = append(paths, filepath.Join(dir, "com.vercel.cli", "auth.json"))
+			}
+			return append(paths, filepath.Join(home, ".vercel", "auth.json"), filepath.Join(home, ".now", "auth.json"))
+		},
+		parse: jsonField("token"),
+	},
+	"koyeb": {
+		cli:   "koyeb login",
+		paths: func(home string) []string { return []string{filepath.Join(home, ".koyeb.yaml")} },
+		parse: yamlField("token"),
+	},
+	"supabase": {
+		cli:   "supabase login",
+		paths: func(home string) []string { return []string{filepath.Join(home, ".supabase", "access-token")} },
+		parse: func(data []byte) (string, error) { return strings.TrimSpace(string(data)), nil },
+	},
+	"flyio": {
+		cli:   "fly auth login",
+		paths: func(home string) []string { return []string{filepath.Join(home, ".fly", "config.yml")} },
+		parse: yamlField("access_token"),
+	},
+}
+
+// CLITokenPlatforms returns the platforms CLIToken can read tokens for.
+func CLITokenPlatforms() []string {
+	return []string{"flyio", "koyeb", "supabase", "vercel"}
+}
+
+// CLIToken returns the token the platform's own CLI saved when the user
+// logged in, and the file it came from. It returns an error wrapping
+// ErrNotFound when the CLI isn't logged in on this machine.
+func CLIToken(name string) (token, path string, err error) {
+	cc, ok := cliCredentials[name]
+	if !ok {
+		return "", "", fmt.Errorf("reading %s CLI credentials is not supported (supported: %s)", name, strings.Join(CLITokenPlatforms(), ", "))
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("get home dir: %w", err)
+	}
+	for _, path := range cc.paths(home) {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", path, fmt.Errorf("read %s: %w", path, err)
+		}
+		token, err := cc.parse(data)
+		if err != nil {
+			return "", path, fmt.Errorf("parse %s: %w", path, err)
+		}
+		if token == "" {
+			continue
+		}
+		return token, path, nil
+	}
+	return "", "", fmt.Errorf("no %s CLI login found (run %q first): %w", name, cc.cli, ErrNotFound)
+}
+
+func jsonField(field string) func([]byte) (string, error) {
+	return func(data []byte) (string, error) {
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return "", err
+		}
+		s, _ := m[field].(string)
+		return s, nil
+	}
+}
+
+func yamlField(field string) func([]byte) (string, error) {
+	return func(data []byte) (string, error) {
+		var m map[string]interface{}
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return "", err
+		}
+		s, _ := m[field].(string)
+		return s, nil
+	}
+}
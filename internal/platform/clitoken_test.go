@@ -0,0 +1,55 @@
+package platform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCLIToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	write := func(rel, content string) string {
+		path := filepath.Join(home, rel)
+		os.MkdirAll(filepath.Dir(path), 0700)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	if _, _, err := CLIToken("koyeb"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound before login", err)
+	}
+
+	tests := []struct {
+		name, rel, content, want string
+	}{
+		{"vercel", ".local/share/com.vercel.cli/auth.json", `{"// Note": "...", "token": "vc_tok"}`, "vc_tok"},
+		{"koyeb", ".koyeb.yaml", "organization: acme\ntoken: ky_tok\n", "ky_tok"},
+		{"supabase", ".supabase/access-token", "sbp_tok\n", "sbp_tok"},
+		{"flyio", ".fly/config.yml", "access_token: fo1_tok\nwire_guard_state: {}\n", "fo1_tok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := write(tt.rel, tt.content)
+			token, path, err := CLIToken(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token != tt.want || path != want {
+				t.Errorf("got %q from %s, want %q from %s", token, path, tt.want, want)
+			}
+		})
+	}
+
+	if _, _, err := CLIToken("render"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("unsupported platform: err = %v", err)
+	}
+	write(".fly/config.yml", "access_token: [oops\n")
+	if _, _, err := CLIToken("flyio"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("malformed config: err = %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package platform
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// CronJob is a scheduled job of a service and its most recent run.
+type CronJob struct {
+	Name       string    // path or command the scheduler invokes
+	Schedule   string    // five-field cron expression, in UTC
+	Enabled    bool      // false while the platform has crons paused
+	LastRun    time.Time // zero if no run was found
+	LastStatus string    // "succeeded", "failed" or "" when unknown
+	Detail     string    // e.g. the HTTP status of the last run
+}
+
+// CronLister is implemented by platforms with scheduled jobs.
+type CronLister interface {
+	ListCronJobs(ctx context.Context, serviceID string) ([]CronJob, error)
+}
+
+// cronLogWindow bounds how long ListCronJobs reads Vercel's runtime log
+// stream, which stays open for new lines.
+const cronLogWindow = 10 * time.Second
+
+// ListCronJobs returns the project's cron jobs from vercel.json as deployed
+// to production. Last runs come from the production deployment's runtime
+// logs, so jobs that last ran before the log retention window show none.
+func (v *Vercel) ListCronJobs(ctx context.Context, serviceID string) ([]CronJob, error) {
+	resp, err := v.doRequest(ctx, "GET", "/v9/projects/"+serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("project %w: %s", ErrNotFound, serviceID)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError("vercel", resp.StatusCode)
+	}
+
+	var project struct {
+		Crons *struct {
+			DisabledAt   *int64 `json:"disabledAt"`
+			DeploymentID string `json:"deploymentId"`
+			Definitions  []struct {
+				Path     string `json:"path"`
+				Schedule string `json:"schedule"`
+			} `json:"definitions"`
+		} `json:"crons"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("decode project: %w", err)
+	}
+	if project.Crons == nil || len(project.Crons.Definitions) == 0 {
+		return nil, nil
+	}
+
+	jobs := make([]CronJob, len(project.Crons.Definitions))
+	for i, d := range project.Crons.Definitions {
+		jobs[i] = CronJob{Name: d.Path, Schedule: d.Schedule, Enabled: project.Crons.DisabledAt == nil}
+	}
+	if project.Crons.DeploymentID != "" {
+		if err := v.cronRuns(ctx, serviceID, project.Crons.DeploymentID, jobs); err != nil {
+			return jobs, err
+		}
+	}
+	return jobs, nil
+}
+
+// cronRuns fills in the last run of each job from requests to its path in
+// the deployment's runtime logs.
+func (v *Vercel) cronRuns(ctx context.Context, projectID, deployID string, jobs []CronJob) error {
+	ctx, cancel := context.WithTimeout(ctx, cronLogWindow)
+	defer cancel()
+	req, err := v.newRequest(ctx, "GET", fmt.Sprintf("/v1/projects/%s/deployments/%s/runtime-logs", projectID, deployID), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := newHTTPClient(0).Do(req)
+	if err != nil {
+		return fmt.Errorf("get runtime logs: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return statusError("vercel runtime logs", resp.StatusCode)
+	}
+
+	// The stream ending at the window is the normal way out.
+	applyCronRuns(resp.Body, jobs)
+	return nil
+}
+
+// applyCronRuns scans Vercel runtime log lines and records, for each job,
+// the latest request to its path.
+func applyCronRuns(r io.Reader, jobs []CronJob) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+	for sc.Scan() {
+		var line struct {
+			TimestampInMs      int64  `json:"timestampInMs"`
+			RequestPath        string `json:"requestPath"`
+			ResponseStatusCode int    `json:"responseStatusCode"`
+		}
+		if json.Unmarshal(sc.Bytes(), &line) != nil || line.RequestPath == "" || line.ResponseStatusCode == 0 {
+			continue
+		}
+		path, _, _ := strings.Cut(line.RequestPath, "?")
+		at := time.UnixMilli(line.TimestampInMs)
+		for i := range jobs {
+			job := &jobs[i]
+			if name, _, _ := strings.Cut(job.Name, "?"); name != path || at.Before(job.LastRun) {
+				continue
+			}
+			job.LastRun = at
+			job.LastStatus = "succeeded"
+			if line.ResponseStatusCode >= 400 {
+				job.LastStatus = "failed"
+			}
+			job.Detail = fmt.Sprintf("HTTP %d", line.ResponseStatusCode)
+		}
+	}
+}
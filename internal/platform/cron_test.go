@@ -0,0 +1,30 @@
+package platform
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyCronRuns(t *testing.T) {
+	logs := strings.Join([]string{
+		`{"timestampInMs":1718359200000,"requestPath":"/api/cleanup","responseStatusCode":200}`,
+		`{"timestampInMs":1718362800000,"requestPath":"/api/cleanup?x=1","responseStatusCode":500}`,
+		`{"timestampInMs":1718366400000,"requestPath":"/api/other","responseStatusCode":200}`,
+		`{"timestampInMs":1718370000000,"requestPath":"/api/digest","message":"sending"}`,
+		`not json`,
+		`{"timestampInMs":1718355600000,"requestPath":"/api/digest","responseStatusCode":204}`,
+	}, "\n")
+	jobs := []CronJob{{Name: "/api/cleanup"}, {Name: "/api/digest"}, {Name: "/api/never"}}
+	applyCronRuns(strings.NewReader(logs), jobs)
+
+	if got := jobs[0]; !got.LastRun.Equal(time.UnixMilli(1718362800000)) || got.LastStatus != "failed" || got.Detail != "HTTP 500" {
+		t.Errorf("cleanup = %+v, want the failed later run", got)
+	}
+	if got := jobs[1]; !got.LastRun.Equal(time.UnixMilli(1718355600000)) || got.LastStatus != "succeeded" {
+		t.Errorf("digest = %+v, want the request with a status", got)
+	}
+	if got := jobs[2]; !got.LastRun.IsZero() || got.LastStatus != "" {
+		t.Errorf("never = %+v, want no run", got)
+	}
+}
@@ -0,0 +1,520 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("custom", func(token string) Platform {
+		return NewCustom(token)
+	})
+}
+
+// HTTPConfig describes a self-hosted service API for the custom platform.
+// URLs may contain {id} (service ID), {deploy_id} and {limit} placeholders.
+type HTTPConfig struct {
+	StatusURL   string
+	DeploysURL  string
+	DeployURL   string
+	LogsURL     string
+	RedeployURL string
+	AuthHeader  string // "Header: value", {token} is replaced; default "Authorization: Bearer {token}"
+	Mappings    HTTPMappings
+}
+
+// HTTPMappings holds JSONPath expressions that locate Orbit fields in API responses.
+// Deploy and log paths are relative to each item of the Deploys/Logs array.
+type HTTPMappings struct {
+	Status       string
+	StatusValues map[string]string // API value → healthy, degraded, unhealthy, sleeping
+	ResponseMs   string
+	CPU          string
+	Memory       string
+	Instances    string
+	MaxInstances string
+
+	Deploys            string
+	DeployID           string
+	DeployStatus       string
+	DeployStatusValues map[string]string // API value → pending, building, deploying, healthy, failed
+	DeployCommit       string
+	DeployMessage      string
+	DeployCreatedAt    string
+	DeployURL          string
+
+	Logs         string
+	LogTimestamp string
+	LogLevel     string
+	LogMessage   string
+}
+
+// HTTPConfigurable is implemented by platforms configured with endpoint definitions.
+type HTTPConfigurable interface {
+	SetHTTPConfig(cfg HTTPConfig)
+}
+
+// Custom implements the Platform interface for any HTTP API described by an HTTPConfig.
+type Custom struct {
+	token      string
+	cfg        HTTPConfig
+	httpClient *http.Client
+
+	poller
+}
+
+// NewCustom creates a new custom platform instance.
+func NewCustom(token string) *Custom {
+	return &Custom{
+		token:      token,
+		httpClient: newHTTPClient(15 * time.Second),
+	}
+}
+
+func (c *Custom) SetHTTPConfig(cfg HTTPConfig) {
+	c.cfg = cfg
+}
+
+func (c *Custom) Name() string {
+	return "custom"
+}
+
+// Capabilities follow the configured endpoints and mappings.
+func (c *Custom) Capabilities() Capabilities {
+	var caps Capabilities
+	if c.cfg.DeploysURL != "" {
+		caps |= CapDeployments | CapWatch
+	}
+	if c.cfg.RedeployURL != "" {
+		caps |= CapRedeploy
+	}
+	if c.cfg.LogsURL != "" {
+		caps |= CapLogs
+	}
+	if c.cfg.Mappings.CPU != "" || c.cfg.Mappings.Memory != "" {
+		caps |= CapMetrics
+	}
+	if c.cfg.Mappings.Instances != "" {
+		caps |= CapInstances
+	}
+	return caps
+}
+
+func (c *Custom) expand(tmpl, serviceID, deployID string, limit int) string {
+	r := strings.NewReplacer(
+		"{id}", url.PathEscape(serviceID),
+		"{deploy_id}", url.PathEscape(deployID),
+		"{limit}", strconv.Itoa(limit),
+	)
+	return r.Replace(tmpl)
+}
+
+func (c *Custom) doRequest(ctx context.Context, method, reqURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	header := c.cfg.AuthHeader
+	if header == "" {
+		header = "Authorization: Bearer {token}"
+	}
+	if name, value, ok := strings.Cut(header, ":"); ok {
+		req.Header.Set(strings.TrimSpace(name), strings.ReplaceAll(strings.TrimSpace(value), "{token}", c.token))
+	}
+	req.Header.Set("Accept", "application/json")
+	return c.httpClient.Do(req)
+}
+
+// getJSON fetches reqURL and decodes the response body into a generic document.
+func (c *Custom) getJSON(ctx context.Context, reqURL string) (interface{}, int, error) {
+	resp, err := c.doRequest(ctx, "GET", reqURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return nil, resp.StatusCode, fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("read response: %w", err)
+	}
+
+	var doc interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &doc); err != nil && resp.StatusCode < 300 {
+			return nil, resp.StatusCode, fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return doc, resp.StatusCode, nil
+}
+
+// Validate checks that the status endpoint is configured and reachable.
+func (c *Custom) Validate(ctx context.Context, token string) error {
+	if c.cfg.StatusURL == "" {
+		return fmt.Errorf("no status_url configured\nAdd platforms.custom.http.status_url to ~/.orbit/config.yaml")
+	}
+	// Validation has no service ID; a 404 still proves the API and token work.
+	_, code, err := c.getJSON(ctx, c.expand(c.cfg.StatusURL, "", "", 1))
+	if err != nil {
+		return err
+	}
+	if code >= 500 {
+		return statusError("custom", code)
+	}
+	return nil
+}
+
+func (c *Custom) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	if c.cfg.StatusURL == "" {
+		return nil, fmt.Errorf("no status_url configured for custom platform")
+	}
+
+	start := time.Now()
+	doc, code, err := c.getJSON(ctx, c.expand(c.cfg.StatusURL, serviceID, "", 1))
+	if err != nil {
+		return nil, fmt.Errorf("get status: %w", err)
+	}
+	elapsed := time.Since(start)
+
+	m := c.cfg.Mappings
+	status := &ServiceStatus{
+		ResponseMs:   int(jsonPathFloat(doc, m.ResponseMs, float64(elapsed.Milliseconds()))),
+		CPU:          jsonPathFloat(doc, m.CPU, -1),
+		Memory:       jsonPathFloat(doc, m.Memory, -1),
+		Instances:    int(jsonPathFloat(doc, m.Instances, -1)),
+		MaxInstances: int(jsonPathFloat(doc, m.MaxInstances, -1)),
+	}
+
+	// Without a status mapping the HTTP status code decides health.
+	if m.Status == "" {
+		status.Status = "healthy"
+		if code >= 400 {
+			status.Status = "unhealthy"
+		}
+	} else {
+		status.Status = mapValue(jsonPathString(doc, m.Status), m.StatusValues)
+	}
+
+	if c.cfg.DeploysURL != "" {
+		if deploys, err := c.ListDeployments(ctx, serviceID, 1); err == nil && len(deploys) > 0 {
+			status.LastDeploy = &deploys[0]
+		}
+	}
+	return status, nil
+}
+
+// mapValue translates an API value through values, falling back to the
+// lower-cased API value itself. Keys match case-insensitively because
+// config map keys are lower-cased on load.
+func mapValue(v string, values map[string]string) string {
+	for k, mapped := range values {
+		if strings.EqualFold(k, v) {
+			return mapped
+		}
+	}
+	return strings.ToLower(v)
+}
+
+func (c *Custom) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	if c.cfg.DeploysURL == "" {
+		return nil, fmt.Errorf("%w: no deploys_url configured for custom platform", ErrNotSupported)
+	}
+
+	doc, code, err := c.getJSON(ctx, c.expand(c.cfg.DeploysURL, serviceID, "", limit))
+	if err != nil {
+		return nil, fmt.Errorf("list deployments: %w", err)
+	}
+	if code != 200 {
+		return nil, statusError("custom", code)
+	}
+
+	items := doc
+	if c.cfg.Mappings.Deploys != "" {
+		if items, err = JSONPath(doc, c.cfg.Mappings.Deploys); err != nil {
+			return nil, fmt.Errorf("deploys mapping: %w", err)
+		}
+	}
+	list, ok := items.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("deploys mapping: expected an array")
+	}
+
+	var deployments []Deployment
+	for _, item := range list {
+		deployments = append(deployments, c.toDeployment(item))
+		if limit > 0 && len(deployments) >= limit {
+			break
+		}
+	}
+	return deployments, nil
+}
+
+func (c *Custom) toDeployment(item interface{}) Deployment {
+	m := c.cfg.Mappings
+	return Deployment{
+		ID:        jsonPathString(item, m.DeployID),
+		Status:    mapValue(jsonPathString(item, m.DeployStatus), m.DeployStatusValues),
+		Commit:    jsonPathString(item, m.DeployCommit),
+		Message:   jsonPathString(item, m.DeployMessage),
+		CreatedAt: jsonPathTime(item, m.DeployCreatedAt),
+		URL:       jsonPathString(item, m.DeployURL),
+	}
+}
+
+func (c *Custom) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	if c.cfg.DeployURL == "" {
+		return nil, fmt.Errorf("%w: no deploy_url configured for custom platform", ErrNotSupported)
+	}
+
+	doc, code, err := c.getJSON(ctx, c.expand(c.cfg.DeployURL, "", deployID, 1))
+	if err != nil {
+		return nil, fmt.Errorf("get deployment: %w", err)
+	}
+	if code == 404 {
+		return nil, fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
+	}
+	if code != 200 {
+		return nil, statusError("custom", code)
+	}
+
+	d := c.toDeployment(doc)
+	if d.ID == "" {
+		d.ID = deployID
+	}
+	return &d, nil
+}
+
+// findDeployment looks up a deployment directly when deploy_url is set,
+// otherwise by scanning the service's recent deployments.
+func (c *Custom) findDeployment(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	if c.cfg.DeployURL != "" {
+		return c.GetDeployment(ctx, deployID)
+	}
+	deploys, err := c.ListDeployments(ctx, serviceID, 20)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deploys {
+		if deploys[i].ID == deployID {
+			return &deploys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
+}
+
+func (c *Custom) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	if c.cfg.RedeployURL == "" {
+		return nil, fmt.Errorf("%w: no redeploy_url configured for custom platform", ErrNotSupported)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", c.expand(c.cfg.RedeployURL, serviceID, "", 1))
+	if err != nil {
+		return nil, fmt.Errorf("redeploy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, statusError("custom", resp.StatusCode)
+	}
+
+	var doc interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err == nil {
+		if d := c.toDeployment(doc); d.ID != "" {
+			return &d, nil
+		}
+	}
+	return &Deployment{Status: "pending", CreatedAt: time.Now()}, nil
+}
+
+func (c *Custom) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: custom platforms have no rollback endpoint", ErrNotSupported)
+}
+
+func (c *Custom) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: custom platforms have no cancel endpoint", ErrNotSupported)
+}
+
+func (c *Custom) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	if c.cfg.LogsURL == "" {
+		return nil, fmt.Errorf("%w: no logs_url configured for custom platform", ErrNotSupported)
+	}
+
+	limit := 100
+	if opts.Tail > 0 {
+		limit = opts.Tail
+	}
+	doc, code, err := c.getJSON(ctx, c.expand(c.cfg.LogsURL, serviceID, "", limit))
+	if err != nil {
+		return nil, fmt.Errorf("get logs: %w", err)
+	}
+	if code != 200 {
+		return nil, statusError("custom", code)
+	}
+
+	m := c.cfg.Mappings
+	items := doc
+	if m.Logs != "" {
+		if items, err = JSONPath(doc, m.Logs); err != nil {
+			return nil, fmt.Errorf("logs mapping: %w", err)
+		}
+	}
+	list, ok := items.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("logs mapping: expected an array")
+	}
+
+	var since time.Time
+	if opts.Since > 0 {
+		since = time.Now().Add(-opts.Since)
+	}
+
+	var entries []LogEntry
+	for _, item := range list {
+		e := LogEntry{
+			Timestamp: jsonPathTime(item, m.LogTimestamp),
+			Level:     strings.ToLower(jsonPathString(item, m.LogLevel)),
+			Message:   jsonPathString(item, m.LogMessage),
+			Source:    serviceID,
+		}
+		if s, ok := item.(string); ok && m.LogMessage == "" {
+			e.Message = s
+		}
+		if e.Level == "" {
+			e.Level = InferLevel(e.Message, "info")
+		}
+		if !since.IsZero() && !e.Timestamp.IsZero() && e.Timestamp.Before(since) {
+			continue
+		}
+		if !MatchesLevel(e.Level, opts.Level) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+func (c *Custom) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: scale the service through its own tooling", ErrNotSupported)
+}
+
+func (c *Custom) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	if c.cfg.DeploysURL == "" {
+		return nil, fmt.Errorf("%w: no deploys_url configured for custom platform", ErrNotSupported)
+	}
+
+	ch := make(chan DeployEvent)
+
+	go func() {
+		defer close(ch)
+
+		pollInterval := c.interval()
+
+		// Check if the latest deployment is already in-progress.
+		deploys, err := c.ListDeployments(ctx, serviceID, 1)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
+			return
+		}
+		if len(deploys) > 0 && IsInProgress(deploys[0].Status) {
+			d := deploys[0]
+			if !sendEvent(ctx, ch, DeployEvent{
+				Phase:   "detected",
+				Message: fmt.Sprintf("In-progress deployment found (%s)", d.ID),
+				Deploy:  &d,
+			}) {
+				return
+			}
+			c.trackDeployment(ctx, ch, serviceID, d.ID)
+			return
+		}
+
+		// Detect a new deployment
+		for {
+			deploys, err := c.ListDeployments(ctx, serviceID, 1)
+			if err != nil {
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
+				return
+			}
+
+			if len(deploys) > 0 {
+				d := deploys[0]
+				if d.ID != currentDeployID {
+					if !sendEvent(ctx, ch, DeployEvent{
+						Phase:   "detected",
+						Message: fmt.Sprintf("New deployment detected! (%s)", d.ID),
+						Deploy:  &d,
+					}) {
+						return
+					}
+					c.trackDeployment(ctx, ch, serviceID, d.ID)
+					return
+				}
+			}
+
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (c *Custom) trackDeployment(ctx context.Context, ch chan<- DeployEvent, serviceID, deployID string) {
+	pollInterval := c.interval()
+	lastPhase := ""
+
+	for {
+		deploy, err := c.findDeployment(ctx, serviceID, deployID)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)})
+			return
+		}
+
+		phase := mapRenderToWatchPhase(deploy.Status)
+		if phase != lastPhase {
+			lastPhase = phase
+
+			event := DeployEvent{Phase: phase, Deploy: deploy}
+			switch phase {
+			case "building":
+				event.Message = "Building..."
+			case "deploying":
+				event.Message = "Deploying..."
+			case "done":
+				event.Message = "Deploy successful!"
+				sendEvent(ctx, ch, event)
+				return
+			case "failed":
+				event.Message = "Deployment failed!"
+				event.Error = fmt.Errorf("deployment %s failed", deployID)
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
+				return
+			}
+		}
+
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package platform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DatabaseInfo is the database-tier view of a service: connection use,
+// storage and the state of its branches and schema changes. Fields a
+// platform can't report are left at their zero value, except Connections,
+// which is -1.
+type DatabaseInfo struct {
+	Engine         string // e.g. "postgres 15.1", "mysql 8.0"
+	Region         string
+	Connections    int   // open connections, -1 if unknown
+	MaxConnections int   // connection limit, 0 if unknown
+	StorageBytes   int64 // data size
+	StorageLimit   int64 // plan limit in bytes, 0 if unknown
+	Branches       []DatabaseBranch
+	Migrations     []Migration // most recent first
+}
+
+// PoolUsage returns open connections as a percentage of the limit, or -1
+// when either is unknown.
+func (d *DatabaseInfo) PoolUsage() float64 {
+	if d.Connections < 0 || d.MaxConnections <= 0 {
+		return -1
+	}
+	return float64(d.Connections) / float64(d.MaxConnections) * 100
+}
+
+// StorageUsage returns storage as a percentage of the plan limit, or -1
+// when the limit is unknown.
+func (d *DatabaseInfo) StorageUsage() float64 {
+	if d.StorageLimit <= 0 {
+		return -1
+	}
+	return float64(d.StorageBytes) / float64(d.StorageLimit) * 100
+}
+
+// DatabaseBranch is a database branch (Neon, PlanetScale, Supabase
+// branching).
+type DatabaseBranch struct {
+	Name    string
+	State   string // normalized: healthy, sleeping, deploying, failed
+	Primary bool   // the production branch
+	Parent  string
+}
+
+// Migration is a schema change: an applied migration (Supabase) or a
+// deploy request (PlanetScale).
+type Migration struct {
+	Name      string
+	Status    string // applied, pending, deploying, failed, ...
+	Branch    string // branch the change comes from, if any
+	CreatedAt time.Time
+}
+
+// DatabaseInspector is implemented by database platforms.
+type DatabaseInspector interface {
+	DatabaseInfo(ctx context.Context, serviceID string) (*DatabaseInfo, error)
+}
+
+// DatabaseInfo reads the project's engine and region from the Management
+// API, and connections, size and applied migrations with a read-only query
+// through its SQL endpoint. Branches are listed when branching is enabled.
+func (s *Supabase) DatabaseInfo(ctx context.Context, serviceID string) (*DatabaseInfo, error) {
+	resp, err := s.doRequest(ctx, "GET", "/v1/projects/"+serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("project %w: %s", ErrNotFound, serviceID)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError("supabase", resp.StatusCode)
+	}
+	var project struct {
+		Region   string `json:"region"`
+		Database struct {
+			Version string `json:"version"`
+		} `json:"database"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("decode project: %w", err)
+	}
+	info := &DatabaseInfo{Engine: "postgres " + project.Database.Version, Region: project.Region, Connections: -1}
+
+	var stats []struct {
+		Connections    int   `json:"connections"`
+		MaxConnections int   `json:"max_connections"`
+		Size           int64 `json:"size"`
+		HasMigrations  bool  `json:"has_migrations"`
+	}
+	if err := s.query(ctx, serviceID, supabaseStatsQuery, &stats); err != nil {
+		return nil, err
+	}
+	if len(stats) != 1 {
+		return nil, fmt.Errorf("query database: %d rows, want 1", len(stats))
+	}
+	info.Connections, info.MaxConnections, info.StorageBytes = stats[0].Connections, stats[0].MaxConnections, stats[0].Size
+	info.Branches = s.branches(ctx, serviceID)
+	if !stats[0].HasMigrations {
+		return info, nil
+	}
+
+	var migrations []struct {
+		Version string `json:"version"`
+		Name    string `json:"name"`
+	}
+	if err := s.query(ctx, serviceID, supabaseMigrationsQuery, &migrations); err != nil {
+		return nil, err
+	}
+	for _, m := range migrations {
+		mig := Migration{Name: m.Version, Status: "applied"}
+		if m.Name != "" {
+			mig.Name += "_" + m.Name
+		}
+		// Versions are timestamps, as the Supabase CLI names migrations.
+		if t, err := time.Parse("20060102150405", m.Version); err == nil {
+			mig.CreatedAt = t
+		}
+		info.Migrations = append(info.Migrations, mig)
+	}
+	return info, nil
+}
+
+const supabaseStatsQuery = `select
+  (select count(*) from pg_stat_activity where backend_type = 'client backend') as connections,
+  current_setting('max_connections')::int as max_connections,
+  pg_database_size(current_database()) as size,
+  to_regclass('supabase_migrations.schema_migrations') is not null as has_migrations`
+
+// The migrations table only exists once the Supabase CLI has pushed one.
+const supabaseMigrationsQuery = `select version, coalesce(name, '') as name
+  from supabase_migrations.schema_migrations
+  order by version desc limit 10`
+
+// query runs a read-only SQL query through the Management API and decodes
+// its rows into out.
+func (s *Supabase) query(ctx context.Context, projectRef, sql string, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": sql, "read_only": true})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", supabaseBaseURL+"/v1/projects/"+projectRef+"/database/query", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("query database: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		return statusError("supabase query", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode query result: %w", err)
+	}
+	return nil
+}
+
+// branches lists preview branches, or none when branching is off.
+func (s *Supabase) branches(ctx context.Context, projectRef string) []DatabaseBranch {
+	resp, err := s.doRequest(ctx, "GET", "/v1/projects/"+projectRef+"/branches")
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil
+	}
+	var list []struct {
+		Name      string `json:"name"`
+		Status    string `json:"status"`
+		IsDefault bool   `json:"is_default"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&list) != nil {
+		return nil
+	}
+	branches := make([]DatabaseBranch, len(list))
+	for i, b := range list {
+		branches[i] = DatabaseBranch{Name: b.Name, State: mapSupabaseBranchStatus(b.Status), Primary: b.IsDefault}
+	}
+	return branches
+}
+
+func mapSupabaseBranchStatus(status string) string {
+	switch status {
+	case "ACTIVE_HEALTHY", "FUNCTIONS_DEPLOYED", "MIGRATIONS_PASSED":
+		return "healthy"
+	case "INACTIVE", "PAUSED":
+		return "sleeping"
+	case "CREATING_PROJECT", "RUNNING_MIGRATIONS", "COMING_UP":
+		return "deploying"
+	case "MIGRATIONS_FAILED", "FUNCTIONS_FAILED", "INIT_FAILED":
+		return "failed"
+	default:
+		return "degraded"
+	}
+}
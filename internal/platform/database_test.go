@@ -0,0 +1,51 @@
+package platform
+
+import "testing"
+
+func TestDatabaseUsage(t *testing.T) {
+	info := DatabaseInfo{Connections: 45, MaxConnections: 60, StorageBytes: 256 << 20, StorageLimit: 512 << 20}
+	if got := info.PoolUsage(); got != 75 {
+		t.Errorf("PoolUsage = %v, want 75", got)
+	}
+	if got := info.StorageUsage(); got != 50 {
+		t.Errorf("StorageUsage = %v, want 50", got)
+	}
+	unknown := DatabaseInfo{Connections: -1, StorageBytes: 1 << 20}
+	if unknown.PoolUsage() != -1 || unknown.StorageUsage() != -1 {
+		t.Errorf("unknown usage = %v, %v; want -1, -1", unknown.PoolUsage(), unknown.StorageUsage())
+	}
+}
+
+func TestNeonBranchState(t *testing.T) {
+	endpoints := []neonEndpoint{
+		{BranchID: "br-main", Type: "read_write", CurrentState: "active"},
+		{BranchID: "br-dev", Type: "read_only", CurrentState: "active"},
+		{BranchID: "br-dev", Type: "read_write", CurrentState: "idle"},
+	}
+	tests := []struct {
+		branch neonBranch
+		want   string
+	}{
+		{neonBranch{ID: "br-main", CurrentState: "ready"}, "healthy"},
+		{neonBranch{ID: "br-dev", CurrentState: "ready"}, "sleeping"},
+		{neonBranch{ID: "br-data", CurrentState: "ready"}, "sleeping"},
+		{neonBranch{ID: "br-new", CurrentState: "init"}, "deploying"},
+		{neonBranch{ID: "br-main", CurrentState: "archived"}, "degraded"},
+	}
+	for _, tt := range tests {
+		if got := neonBranchState(tt.branch, endpoints); got != tt.want {
+			t.Errorf("neonBranchState(%s, %s) = %q, want %q", tt.branch.ID, tt.branch.CurrentState, got, tt.want)
+		}
+	}
+}
+
+func TestPlanetScaleDatabasePath(t *testing.T) {
+	if got, err := planetscaleDatabasePath("acme/shop"); err != nil || got != "/organizations/acme/databases/shop" {
+		t.Errorf("path = %q, %v", got, err)
+	}
+	for _, id := range []string{"shop", "acme/", "/shop"} {
+		if _, err := planetscaleDatabasePath(id); err == nil {
+			t.Errorf("planetscaleDatabasePath(%q) accepted", id)
+		}
+	}
+}
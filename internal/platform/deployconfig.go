@@ -0,0 +1,82 @@
+package platform
+
+import (
+	"context"
+	"sort"
+	"strconv"
+)
+
+// DeploymentConfig is the configuration a deployment ran with.
+type DeploymentConfig struct {
+	Env          []EnvVar
+	MinScale     int
+	MaxScale     int
+	InstanceType string
+}
+
+// DeploymentConfigProvider is implemented by platforms that keep the
+// configuration of past deployments, not just the service's current one.
+type DeploymentConfigProvider interface {
+	DeploymentConfig(ctx context.Context, deployID string) (*DeploymentConfig, error)
+}
+
+// ConfigChange is one difference between two deployment configurations.
+// From is empty for an added setting and To for a removed one.
+type ConfigChange struct {
+	Setting string // env.<KEY>, scale.min, scale.max, instance_type
+	From    string
+	To      string
+}
+
+// secretValue stands in for values the platform doesn't reveal.
+const secretValue = "(secret)"
+
+// Diff returns what changed from c to next: environment variables first,
+// by key, then scaling and instance type. Variables whose value is secret
+// count as changed when they point at another secret.
+func (c *DeploymentConfig) Diff(next *DeploymentConfig) []ConfigChange {
+	envValue := func(v EnvVar) string {
+		switch {
+		case v.SecretRef != "":
+			return "secret " + v.SecretRef
+		case v.Sensitive:
+			return secretValue
+		}
+		return v.Value
+	}
+	from := make(map[string]string, len(c.Env))
+	for _, v := range c.Env {
+		from[v.Key] = envValue(v)
+	}
+	to := make(map[string]string, len(next.Env))
+	for _, v := range next.Env {
+		to[v.Key] = envValue(v)
+	}
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []ConfigChange
+	for _, k := range keys {
+		if from[k] != to[k] {
+			changes = append(changes, ConfigChange{"env." + k, from[k], to[k]})
+		}
+	}
+	if c.MinScale != next.MinScale {
+		changes = append(changes, ConfigChange{"scale.min", strconv.Itoa(c.MinScale), strconv.Itoa(next.MinScale)})
+	}
+	if c.MaxScale != next.MaxScale {
+		changes = append(changes, ConfigChange{"scale.max", strconv.Itoa(c.MaxScale), strconv.Itoa(next.MaxScale)})
+	}
+	if c.InstanceType != next.InstanceType {
+		changes = append(changes, ConfigChange{"instance_type", c.InstanceType, next.InstanceType})
+	}
+	return changes
+}
@@ -0,0 +1,41 @@
+package platform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeploymentConfigDiff(t *testing.T) {
+	from := &DeploymentConfig{
+		Env: []EnvVar{
+			{Key: "LOG_LEVEL", Value: "info"},
+			{Key: "OLD_FLAG", Value: "1"},
+			{Key: "DATABASE_URL", SecretRef: "db-main"},
+			{Key: "API_KEY", Sensitive: true},
+		},
+		MinScale: 1, MaxScale: 2, InstanceType: "nano",
+	}
+	to := &DeploymentConfig{
+		Env: []EnvVar{
+			{Key: "LOG_LEVEL", Value: "debug"},
+			{Key: "NEW_FLAG", Value: "on"},
+			{Key: "DATABASE_URL", SecretRef: "db-replica"},
+			{Key: "API_KEY", Sensitive: true},
+		},
+		MinScale: 1, MaxScale: 4, InstanceType: "small",
+	}
+	want := []ConfigChange{
+		{"env.DATABASE_URL", "secret db-main", "secret db-replica"},
+		{"env.LOG_LEVEL", "info", "debug"},
+		{"env.NEW_FLAG", "", "on"},
+		{"env.OLD_FLAG", "1", ""},
+		{"scale.max", "2", "4"},
+		{"instance_type", "nano", "small"},
+	}
+	if got := from.Diff(to); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff =\n%v\nwant\n%v", got, want)
+	}
+	if got := from.Diff(from); len(got) != 0 {
+		t.Errorf("Diff with itself = %v", got)
+	}
+}
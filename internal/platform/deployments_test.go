@@ -0,0 +1,40 @@
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVercelMetaApply(t *testing.T) {
+	tests := []struct {
+		name, meta string
+		want       Deployment
+	}{
+		{
+			name: "pull request preview",
+			meta: `{"githubCommitSha":"3f2a9c1","githubCommitMessage":"Add cart","githubCommitRef":"feat/cart","githubCommitAuthorLogin":"octocat","githubPrId":"42"}`,
+			want: Deployment{Commit: "3f2a9c1", Message: "Add cart", Branch: "feat/cart", Author: "octocat", PullRequest: 42},
+		},
+		{
+			name: "push to main",
+			meta: `{"githubCommitSha":"9b8e7d6","githubCommitRef":"main","githubCommitAuthorLogin":"octocat"}`,
+			want: Deployment{Commit: "9b8e7d6", Branch: "main", Author: "octocat"},
+		},
+		{
+			name: "cli deploy",
+			meta: `{}`,
+			want: Deployment{},
+		},
+	}
+	for _, tt := range tests {
+		var m vercelMeta
+		if err := json.Unmarshal([]byte(tt.meta), &m); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got Deployment
+		m.apply(&got)
+		if got != tt.want {
+			t.Errorf("%s: apply() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,608 @@
+package platform
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dockerDefaultHost = "unix:///var/run/docker.sock"
+
+func init() {
+	Register("docker", func(token string) Platform {
+		return NewDocker(token)
+	})
+}
+
+// Docker implements the Platform interface against a local Docker daemon.
+// A service is either a container name or a Compose service written as
+// "project/service"; each container created for it counts as a deployment.
+//
+// The token is the daemon address (e.g. "unix:///var/run/docker.sock" or
+// "tcp://localhost:2375"); "local" uses $DOCKER_HOST or the default socket.
+type Docker struct {
+	host       string
+	baseURL    string
+	httpClient *http.Client
+
+	poller
+}
+
+// NewDocker creates a new Docker platform instance.
+func NewDocker(token string) *Docker {
+	host := token
+	if host == "" || host == "local" {
+		host = os.Getenv("DOCKER_HOST")
+	}
+	if host == "" {
+		host = dockerDefaultHost
+	}
+
+	d := &Docker{host: host}
+	if sock, ok := strings.CutPrefix(host, "unix://"); ok {
+		d.baseURL = "http://docker"
+		d.httpClient = &http.Client{
+			Timeout: 15 * time.Second,
+			Transport: withRetry(&http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", sock)
+				},
+			}),
+		}
+	} else {
+		d.baseURL = "http://" + strings.TrimPrefix(strings.TrimPrefix(host, "tcp://"), "http://")
+		d.httpClient = newHTTPClient(15 * time.Second)
+	}
+	return d
+}
+
+func (d *Docker) Name() string {
+	return "docker"
+}
+
+func (d *Docker) Capabilities() Capabilities {
+	return CapDeployments | CapRedeploy | CapLogs | CapWatch | CapDiscover | CapMetrics | CapInstances
+}
+
+func (d *Docker) doRequest(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, d.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	return d.httpClient.Do(req)
+}
+
+func (d *Docker) getJSON(ctx context.Context, path string, out interface{}) error {
+	resp, err := d.doRequest(ctx, "GET", path)
+	if err != nil {
+		return fmt.Errorf("docker daemon error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode != 200 {
+		return statusError("docker", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Validate checks that the daemon is reachable. The token is the daemon
+// address, so there is nothing else to verify.
+func (d *Docker) Validate(ctx context.Context, token string) error {
+	resp, err := NewDocker(token).doRequest(ctx, "GET", "/_ping")
+	if err != nil {
+		return fmt.Errorf("docker daemon not reachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return statusError("docker", resp.StatusCode)
+	}
+	return nil
+}
+
+type dockerContainer struct {
+	ID      string            `json:"Id"`
+	Names   []string          `json:"Names"`
+	Image   string            `json:"Image"`
+	Created int64             `json:"Created"`
+	State   string            `json:"State"`  // created, running, paused, restarting, removing, exited, dead
+	Status  string            `json:"Status"` // e.g. "Up 2 hours (healthy)"
+	Labels  map[string]string `json:"Labels"`
+}
+
+func (c *dockerContainer) name() string {
+	if len(c.Names) == 0 {
+		return shortDockerID(c.ID)
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+// serviceID returns "project/service" for Compose containers, otherwise
+// the container name.
+func (c *dockerContainer) serviceID() string {
+	if svc := c.Labels["com.docker.compose.service"]; svc != "" {
+		return c.Labels["com.docker.compose.project"] + "/" + svc
+	}
+	return c.name()
+}
+
+func (c *dockerContainer) toDeployment() Deployment {
+	return Deployment{
+		ID:        shortDockerID(c.ID),
+		Status:    mapDockerStatus(c.State, c.Status),
+		Message:   c.Image,
+		CreatedAt: time.Unix(c.Created, 0),
+	}
+}
+
+func shortDockerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// mapDockerStatus maps a container state (and health, from the status text)
+// to an Orbit deployment status.
+func mapDockerStatus(state, status string) string {
+	switch state {
+	case "running":
+		switch {
+		case strings.Contains(status, "(health: starting)"):
+			return "deploying"
+		case strings.Contains(status, "(unhealthy)"):
+			return "failed"
+		}
+		return "healthy"
+	case "created", "restarting":
+		return "deploying"
+	case "paused":
+		return "sleeping"
+	case "exited":
+		// "Exited (0)" is a clean stop; anything else crashed.
+		if strings.HasPrefix(status, "Exited (0)") {
+			return "sleeping"
+		}
+		return "failed"
+	default:
+		return "failed"
+	}
+}
+
+// containers lists the service's containers, newest first.
+func (d *Docker) containers(ctx context.Context, serviceID string) ([]dockerContainer, error) {
+	filters := map[string][]string{}
+	if project, svc, ok := strings.Cut(serviceID, "/"); ok {
+		filters["label"] = []string{
+			"com.docker.compose.project=" + project,
+			"com.docker.compose.service=" + svc,
+		}
+	} else {
+		filters["name"] = []string{"^/" + serviceID + "$"}
+	}
+	f, _ := json.Marshal(filters)
+
+	var list []dockerContainer
+	if err := d.getJSON(ctx, "/containers/json?all=1&filters="+url.QueryEscape(string(f)), &list); err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("no containers found for %s", serviceID)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Created > list[j].Created })
+	return list, nil
+}
+
+func (d *Docker) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	list, err := d.containers(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compose services may run several replicas; only the latest
+	// generation of containers counts toward health.
+	running, healthy, total := 0, 0, 0
+	for _, c := range list {
+		if c.State == "exited" && total > 0 {
+			continue
+		}
+		total++
+		if c.State == "running" {
+			running++
+			if mapDockerStatus(c.State, c.Status) == "healthy" {
+				healthy++
+			}
+		}
+	}
+
+	status := &ServiceStatus{
+		CPU:          -1,
+		Memory:       -1,
+		Instances:    running,
+		MaxInstances: total,
+		Name:         list[0].serviceID(),
+	}
+	switch {
+	case running == 0:
+		status.Status = mapDockerStatus(list[0].State, list[0].Status)
+		if status.Status != "sleeping" {
+			status.Status = "unhealthy"
+		}
+	case healthy == total:
+		status.Status = "healthy"
+	default:
+		status.Status = "degraded"
+	}
+
+	latest := list[0].toDeployment()
+	status.LastDeploy = &latest
+
+	if list[0].State == "running" {
+		if cpu, mem, err := d.stats(ctx, list[0].ID); err == nil {
+			status.CPU, status.Memory = cpu, mem
+		}
+	}
+
+	return status, nil
+}
+
+// stats returns CPU and memory usage percentages for a running container.
+func (d *Docker) stats(ctx context.Context, containerID string) (cpu, mem float64, err error) {
+	var s struct {
+		CPUStats struct {
+			CPUUsage struct {
+				TotalUsage uint64 `json:"total_usage"`
+			} `json:"cpu_usage"`
+			SystemUsage uint64 `json:"system_cpu_usage"`
+			OnlineCPUs  uint64 `json:"online_cpus"`
+		} `json:"cpu_stats"`
+		PreCPUStats struct {
+			CPUUsage struct {
+				TotalUsage uint64 `json:"total_usage"`
+			} `json:"cpu_usage"`
+			SystemUsage uint64 `json:"system_cpu_usage"`
+		} `json:"precpu_stats"`
+		MemoryStats struct {
+			Usage uint64 `json:"usage"`
+			Limit uint64 `json:"limit"`
+		} `json:"memory_stats"`
+	}
+	if err := d.getJSON(ctx, "/containers/"+containerID+"/stats?stream=false", &s); err != nil {
+		return -1, -1, err
+	}
+
+	cpu, mem = -1, -1
+	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+	sysDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+	if sysDelta > 0 && cpuDelta >= 0 {
+		cpus := float64(s.CPUStats.OnlineCPUs)
+		if cpus == 0 {
+			cpus = 1
+		}
+		cpu = cpuDelta / sysDelta * cpus * 100
+	}
+	if s.MemoryStats.Limit > 0 {
+		mem = float64(s.MemoryStats.Usage) / float64(s.MemoryStats.Limit) * 100
+	}
+	return cpu, mem, nil
+}
+
+func (d *Docker) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	list, err := d.containers(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	deploys := make([]Deployment, 0, len(list))
+	for _, c := range list {
+		if limit > 0 && len(deploys) >= limit {
+			break
+		}
+		deploys = append(deploys, c.toDeployment())
+	}
+	return deploys, nil
+}
+
+// GetDeployment takes a container ID or name.
+func (d *Docker) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	var c struct {
+		ID      string    `json:"Id"`
+		Created time.Time `json:"Created"`
+		Config  struct {
+			Image string `json:"Image"`
+		} `json:"Config"`
+		State struct {
+			Status     string    `json:"Status"`
+			ExitCode   int       `json:"ExitCode"`
+			StartedAt  time.Time `json:"StartedAt"`
+			FinishedAt time.Time `json:"FinishedAt"`
+			Health     *struct {
+				Status string `json:"Status"` // starting, healthy, unhealthy
+			} `json:"Health"`
+		} `json:"State"`
+	}
+	if err := d.getJSON(ctx, "/containers/"+url.PathEscape(deployID)+"/json", &c); err != nil {
+		return nil, err
+	}
+
+	// Rebuild the status text the list endpoint reports so both share
+	// mapDockerStatus.
+	status := ""
+	switch {
+	case c.State.Status == "exited":
+		status = fmt.Sprintf("Exited (%d)", c.State.ExitCode)
+	case c.State.Health != nil && c.State.Health.Status == "starting":
+		status = "(health: starting)"
+	case c.State.Health != nil && c.State.Health.Status == "unhealthy":
+		status = "(unhealthy)"
+	}
+
+	dep := &Deployment{
+		ID:        shortDockerID(c.ID),
+		Status:    mapDockerStatus(c.State.Status, status),
+		Message:   c.Config.Image,
+		CreatedAt: c.Created,
+	}
+	if !c.State.StartedAt.IsZero() && c.State.StartedAt.After(c.Created) {
+		dep.Duration = c.State.StartedAt.Sub(c.Created)
+	}
+	return dep, nil
+}
+
+// Redeploy restarts the service's newest container.
+func (d *Docker) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	list, err := d.containers(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	c := list[0]
+
+	resp, err := d.doRequest(ctx, "POST", "/containers/"+c.ID+"/restart")
+	if err != nil {
+		return nil, fmt.Errorf("docker daemon error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 204 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", statusError("docker", resp.StatusCode), strings.TrimSpace(string(body)))
+	}
+
+	dep := c.toDeployment()
+	dep.Status = "deploying"
+	return &dep, nil
+}
+
+func (d *Docker) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: docker containers are rolled back by recreating them from the previous image", ErrNotSupported)
+}
+
+func (d *Docker) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: docker containers are recreated in one step", ErrNotSupported)
+}
+
+// GetLogs reads the newest container's stdout and stderr. Levels are
+// inferred from each line, defaulting to error for stderr.
+func (d *Docker) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	list, err := d.containers(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	c := list[0]
+
+	params := url.Values{}
+	params.Set("stdout", "1")
+	params.Set("stderr", "1")
+	params.Set("timestamps", "1")
+	tail := "100"
+	if opts.Tail > 0 {
+		tail = strconv.Itoa(opts.Tail)
+	}
+	params.Set("tail", tail)
+	if opts.Since > 0 {
+		params.Set("since", strconv.FormatInt(time.Now().Add(-opts.Since).Unix(), 10))
+	}
+
+	resp, err := d.doRequest(ctx, "GET", "/containers/"+c.ID+"/logs?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("docker daemon error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError("docker", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read logs: %w", err)
+	}
+
+	var entries []LogEntry
+	for _, l := range demuxDockerLogs(body) {
+		level := "info"
+		if l.stderr {
+			level = "error"
+		}
+		entry := LogEntry{Message: l.text, Source: c.name()}
+		if ts, msg, ok := strings.Cut(l.text, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+				entry.Timestamp, entry.Message = t, msg
+			}
+		}
+		entry.Level = InferLevel(entry.Message, level)
+		if !MatchesLevel(entry.Level, opts.Level) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+type dockerLogLine struct {
+	text   string
+	stderr bool
+}
+
+// demuxDockerLogs splits a container log stream into lines. Containers
+// without a TTY prefix each frame with an 8-byte header (stream type,
+// padding, big-endian length); TTY containers send plain text.
+func demuxDockerLogs(data []byte) []dockerLogLine {
+	var lines []dockerLogLine
+	addText := func(text []byte, stderr bool) {
+		scanner := bufio.NewScanner(bytes.NewReader(text))
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+				lines = append(lines, dockerLogLine{text: line, stderr: stderr})
+			}
+		}
+	}
+
+	multiplexed := len(data) >= 8 && (data[0] == 1 || data[0] == 2) && data[1] == 0 && data[2] == 0 && data[3] == 0
+	if !multiplexed {
+		addText(data, false)
+		return lines
+	}
+
+	for len(data) >= 8 {
+		stream := data[0]
+		size := int(binary.BigEndian.Uint32(data[4:8]))
+		data = data[8:]
+		if size > len(data) {
+			size = len(data)
+		}
+		addText(data[:size], stream == 2)
+		data = data[size:]
+	}
+	return lines
+}
+
+func (d *Docker) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: docker containers are scaled with docker compose up --scale", ErrNotSupported)
+}
+
+// DiscoverServices lists all containers, grouping Compose replicas into
+// one service.
+func (d *Docker) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	var list []dockerContainer
+	if err := d.getJSON(ctx, "/containers/json?all=1", &list); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var services []DiscoveredService
+	for _, c := range list {
+		id := c.serviceID()
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		services = append(services, DiscoveredService{
+			ID:       id,
+			Name:     strings.ReplaceAll(id, "/", "-"),
+			Platform: "docker",
+		})
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+	return services, nil
+}
+
+func (d *Docker) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	ch := make(chan DeployEvent)
+
+	go func() {
+		defer close(ch)
+
+		pollInterval := d.interval()
+
+		// Phase 1: Detect a new container (e.g. after docker compose up)
+		for {
+			deploys, err := d.ListDeployments(ctx, serviceID, 1)
+			if err != nil {
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll containers: %w", err)})
+				return
+			}
+
+			if len(deploys) > 0 {
+				dep := deploys[0]
+				if dep.ID != currentDeployID || IsInProgress(dep.Status) {
+					if !sendEvent(ctx, ch, DeployEvent{
+						Phase:   "detected",
+						Message: fmt.Sprintf("New container detected! (%s)", dep.ID),
+						Deploy:  &dep,
+					}) {
+						return
+					}
+					d.trackDeployment(ctx, ch, dep.ID)
+					return
+				}
+			}
+
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (d *Docker) trackDeployment(ctx context.Context, ch chan<- DeployEvent, containerID string) {
+	pollInterval := d.interval()
+	lastPhase := ""
+
+	for {
+		deploy, err := d.GetDeployment(ctx, containerID)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("get container: %w", err)})
+			return
+		}
+
+		phase := mapRenderToWatchPhase(deploy.Status)
+		if phase != lastPhase {
+			lastPhase = phase
+
+			event := DeployEvent{Phase: phase, Deploy: deploy}
+			switch phase {
+			case "deploying":
+				event.Message = "Starting container..."
+			case "done":
+				event.Message = "Container running!"
+				sendEvent(ctx, ch, event)
+				return
+			case "failed":
+				event.Message = "Container failed!"
+				event.Error = fmt.Errorf("container %s failed", containerID)
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
+				return
+			}
+		}
+
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDemuxDockerLogs(t *testing.T) {
+	frame := func(stream byte, text string) []byte {
+		n := len(text)
+		return append([]byte{stream, 0, 0, 0, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}, text...)
+	}
+
+	var data []byte
+	data = append(data, frame(1, "2025-01-02T03:04:05Z started\n")...)
+	data = append(data, frame(2, "2025-01-02T03:04:06Z boom\n")...)
+
+	lines := demuxDockerLogs(data)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %+v", len(lines), lines)
+	}
+	if lines[0].stderr || lines[0].text != "2025-01-02T03:04:05Z started" {
+		t.Errorf("line 0 = %+v", lines[0])
+	}
+	if !lines[1].stderr || lines[1].text != "2025-01-02T03:04:06Z boom" {
+		t.Errorf("line 1 = %+v", lines[1])
+	}
+
+	// TTY containers send a raw stream.
+	raw := demuxDockerLogs([]byte("hello\r\nworld\n"))
+	if len(raw) != 2 || raw[0].text != "hello" || raw[1].stderr {
+		t.Errorf("raw = %+v", raw)
+	}
+}
+
+func TestMapDockerStatus(t *testing.T) {
+	tests := []struct {
+		state, status, want string
+	}{
+		{"running", "Up 2 hours", "healthy"},
+		{"running", "Up 5 seconds (health: starting)", "deploying"},
+		{"running", "Up 3 minutes (unhealthy)", "failed"},
+		{"exited", "Exited (0) 1 hour ago", "sleeping"},
+		{"exited", "Exited (137) 2 minutes ago", "failed"},
+		{"paused", "Up 1 hour (Paused)", "sleeping"},
+		{"restarting", "Restarting (1) 3 seconds ago", "deploying"},
+	}
+	for _, tt := range tests {
+		if got := mapDockerStatus(tt.state, tt.status); got != tt.want {
+			t.Errorf("mapDockerStatus(%q, %q) = %q, want %q", tt.state, tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestDockerWatchStopsOnCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"Id":"0123456789abcdef","Names":["/web"],"State":"running","Status":"Up 1 hour","Created":1700000000}]`)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := NewDocker("tcp://" + strings.TrimPrefix(srv.URL, "http://"))
+	ch, err := d.WatchDeployment(ctx, "web", "0123456789ab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-ch; ev.Phase != "waiting" {
+		t.Fatalf("first event = %q (%v), want waiting", ev.Phase, ev.Error)
+	}
+
+	// Stop reading and cancel; the watcher must close the channel rather
+	// than block forever on the next send.
+	cancel()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("watcher did not stop after cancel")
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package platform
+
+import "context"
+
+// Domain is a hostname routed to a service.
+type Domain struct {
+	Name     string
+	Custom   bool   // false for names the platform assigns (*.vercel.app, *.koyeb.app, *.onrender.com)
+	Verified bool   // ownership and DNS verified
+	SSL      string // certificate state: active, pending, error; "" when unknown
+	Redirect string // domain this one redirects to
+	Note     string // e.g. the route path or git branch the domain serves
+}
+
+// DomainLister is implemented by platforms that can list the domains
+// routed to a service.
+type DomainLister interface {
+	ListDomains(ctx context.Context, serviceID string) ([]Domain, error)
+}
@@ -0,0 +1,50 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// EnvVar is an environment variable of a service.
+type EnvVar struct {
+	Key       string
+	Value     string   // empty when Sensitive or SecretRef is set
+	SecretRef string   // name of the platform secret the value comes from (Koyeb)
+	Sensitive bool     // the platform does not reveal the value (Vercel)
+	Targets   []string // environments the variable applies to (Vercel)
+}
+
+// EnvManager is implemented by platforms whose service environment can be
+// read and edited. Koyeb applies changes by redeploying the service right
+// away; Vercel applies them from the next deployment.
+type EnvManager interface {
+	GetEnvVars(ctx context.Context, serviceID string) ([]EnvVar, error)
+	// SetEnvVars adds or replaces the given variables, leaving others as is.
+	SetEnvVars(ctx context.Context, serviceID string, vars map[string]string) error
+	// DeleteEnvVars removes the given variables in one update. It fails
+	// without changing anything if one of them is not set.
+	DeleteEnvVars(ctx context.Context, serviceID string, keys []string) error
+}
+
+var envKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnvKey checks that key is a valid environment variable name.
+func ValidateEnvKey(key string) error {
+	if !envKeyRe.MatchString(key) {
+		return fmt.Errorf("invalid variable name %q (letters, digits and _, not starting with a digit)", key)
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of vars in order, so updates are applied
+// deterministically.
+func sortedKeys(vars map[string]string) []string {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
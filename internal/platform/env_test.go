@@ -0,0 +1,16 @@
+package platform
+
+import "testing"
+
+func TestValidateEnvKey(t *testing.T) {
+	for _, key := range []string{"PORT", "_PRIVATE", "api_key2"} {
+		if err := ValidateEnvKey(key); err != nil {
+			t.Errorf("ValidateEnvKey(%q) = %v, want nil", key, err)
+		}
+	}
+	for _, key := range []string{"", "2FA", "MY-VAR", "A B", "KEY="} {
+		if err := ValidateEnvKey(key); err == nil {
+			t.Errorf("ValidateEnvKey(%q) succeeded, want error", key)
+		}
+	}
+}
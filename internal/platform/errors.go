@@ -0,0 +1,76 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors wrapped by adapters so callers can classify failures with
+// errors.Is instead of matching message text.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrNotSupported = errors.New("not supported")
+)
+
+// statusError reports an unexpected HTTP status from a platform API,
+// wrapping the matching error for auth failures, missing resources and
+// rate limiting.
+func statusError(api string, code int) error {
+	msg := fmt.Sprintf("%s API returned status %d", api, code)
+	switch code {
+	case 401, 403:
+		return fmt.Errorf("%s: %w", msg, ErrUnauthorized)
+	case 404:
+		return fmt.Errorf("%s: %w", msg, ErrNotFound)
+	case 429:
+		return fmt.Errorf("%s: %w", msg, ErrRateLimited)
+	}
+	return errors.New(msg)
+}
+
+var errorKinds = []struct {
+	name string
+	err  error
+}{
+	{"unauthorized", ErrUnauthorized},
+	{"not_found", ErrNotFound},
+	{"rate_limited", ErrRateLimited},
+	{"not_supported", ErrNotSupported},
+}
+
+// kindError is an error message from outside Orbit (e.g. an external
+// adapter) tagged with the kind it reported.
+type kindError struct {
+	msg  string
+	kind error
+}
+
+func (e *kindError) Error() string { return e.msg }
+func (e *kindError) Unwrap() error { return e.kind }
+
+// errorWithKind returns an error with message msg that wraps the error
+// named by kind (see ErrorKind); unknown kinds are ignored.
+func errorWithKind(msg, kind string) error {
+	for _, k := range errorKinds {
+		if k.name == kind {
+			return &kindError{msg: msg, kind: k.err}
+		}
+	}
+	return errors.New(msg)
+}
+
+// ErrorKind classifies err for JSON output: "unauthorized", "not_found",
+// "rate_limited", "not_supported", or "" when it is none of these.
+func ErrorKind(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			return k.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		code int
+		want error
+		kind string
+	}{
+		{401, ErrUnauthorized, "unauthorized"},
+		{403, ErrUnauthorized, "unauthorized"},
+		{404, ErrNotFound, "not_found"},
+		{429, ErrRateLimited, "rate_limited"},
+		{500, nil, ""},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("get deployments: %w", statusError("vercel", tt.code))
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("status %d: %v does not wrap %v", tt.code, err, tt.want)
+		}
+		if got := ErrorKind(err); got != tt.kind {
+			t.Errorf("status %d: ErrorKind = %q, want %q", tt.code, got, tt.kind)
+		}
+	}
+}
+
+func TestErrorWithKind(t *testing.T) {
+	err := errorWithKind("site not found", "not_found")
+	if err.Error() != "site not found" || !errors.Is(err, ErrNotFound) {
+		t.Errorf("errorWithKind = %v, want message kept and ErrNotFound wrapped", err)
+	}
+	if ErrorKind(errorWithKind("boom", "exploded")) != "" {
+		t.Error("unknown kinds should not classify")
+	}
+}
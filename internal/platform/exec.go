@@ -0,0 +1,127 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ExecOptions selects where a command runs and what it is.
+type ExecOptions struct {
+	Instance string   // instance ID; "" picks a running instance
+	Command  []string // program and arguments; empty opens a shell
+}
+
+// Executor is implemented by platforms that can run commands inside a
+// service's instances. ExecCommand returns the local process that does so,
+// the platform's own CLI authenticated with the connection's token, for the
+// caller to attach to the terminal and run.
+type Executor interface {
+	ExecCommand(ctx context.Context, serviceID string, opts ExecOptions) (*exec.Cmd, error)
+}
+
+// execCLI describes a vendor CLI that execs into instances.
+type execCLI struct {
+	bin      string // executable name
+	install  string // where to get it
+	tokenEnv string // environment variable the CLI reads its token from
+}
+
+// command returns a Cmd running the CLI with args, authenticated with
+// token through the environment, so the token stays out of process
+// listings.
+func (c execCLI) command(ctx context.Context, token string, args []string) (*exec.Cmd, error) {
+	path, err := exec.LookPath(c.bin)
+	if err != nil {
+		return nil, fmt.Errorf("%s CLI not found on PATH; install it from %s", c.bin, c.install)
+	}
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Env = append(os.Environ(), c.tokenEnv+"="+token)
+	return cmd, nil
+}
+
+var (
+	koyebCLI = execCLI{bin: "koyeb", install: "https://www.koyeb.com/docs/build-and-deploy/cli/installation", tokenEnv: "KOYEB_TOKEN"}
+	flyCLI   = execCLI{bin: "fly", install: "https://fly.io/docs/flyctl/install/", tokenEnv: "FLY_API_TOKEN"}
+)
+
+// errNoRunningInstance is returned when exec has nowhere to run.
+var errNoRunningInstance = errors.New("no running instance")
+
+// runningInstance picks the first healthy instance, or checks that id is
+// one of the service's instances.
+func runningInstance(instances []Instance, id string) (string, error) {
+	for _, inst := range instances {
+		if id != "" && inst.ID == id {
+			return id, nil
+		}
+		if id == "" && inst.State == "healthy" {
+			return inst.ID, nil
+		}
+	}
+	if id != "" {
+		return "", fmt.Errorf("instance %s: %w", id, ErrNotFound)
+	}
+	return "", errNoRunningInstance
+}
+
+// ExecCommand runs the command in one of the service's instances with
+// koyeb instances exec.
+func (k *Koyeb) ExecCommand(ctx context.Context, serviceID string, opts ExecOptions) (*exec.Cmd, error) {
+	instances, err := k.ListInstances(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := runningInstance(instances, opts.Instance)
+	if err != nil {
+		return nil, err
+	}
+	return koyebCLI.command(ctx, k.token, koyebExecArgs(id, opts.Command))
+}
+
+func koyebExecArgs(instance string, command []string) []string {
+	if len(command) == 0 {
+		command = []string{"/bin/sh"}
+	}
+	return append([]string{"instances", "exec", instance, "--"}, command...)
+}
+
+// ExecCommand runs the command on one of the app's machines with fly ssh
+// console.
+func (f *Flyio) ExecCommand(ctx context.Context, serviceID string, opts ExecOptions) (*exec.Cmd, error) {
+	instances, err := f.ListInstances(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := runningInstance(instances, opts.Instance)
+	if err != nil {
+		return nil, err
+	}
+	return flyCLI.command(ctx, f.token, flyExecArgs(serviceID, id, opts.Command))
+}
+
+// flyExecArgs builds fly ssh console arguments. -C takes the command as
+// one string, which the machine splits like a shell, so arguments are
+// quoted.
+func flyExecArgs(app, machine string, command []string) []string {
+	args := []string{"ssh", "console", "--app", app, "--machine", machine}
+	if len(command) == 0 {
+		return args
+	}
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = shellQuote(arg)
+	}
+	return append(args, "--command", strings.Join(quoted, " "))
+}
+
+// shellQuote quotes s for a POSIX shell unless it only has safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,+@%") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
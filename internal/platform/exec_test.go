@@ -0,0 +1,41 @@
+package platform
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExecArgs(t *testing.T) {
+	if got, want := koyebExecArgs("i1", nil), []string{"instances", "exec", "i1", "--", "/bin/sh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("koyeb shell args = %q, want %q", got, want)
+	}
+	if got, want := koyebExecArgs("i1", []string{"ls", "-la"}), []string{"instances", "exec", "i1", "--", "ls", "-la"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("koyeb command args = %q, want %q", got, want)
+	}
+
+	got := flyExecArgs("shop", "m1", []string{"rails", "runner", "puts 'hi'", ""})
+	want := []string{"ssh", "console", "--app", "shop", "--machine", "m1", "--command", `rails runner 'puts '\''hi'\''' ''`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fly args = %q, want %q", got, want)
+	}
+	if got := flyExecArgs("shop", "m1", nil); len(got) != 6 {
+		t.Errorf("fly shell args = %q", got)
+	}
+}
+
+func TestRunningInstance(t *testing.T) {
+	instances := []Instance{{ID: "a", State: "sleeping"}, {ID: "b", State: "healthy"}}
+	if id, err := runningInstance(instances, ""); id != "b" || err != nil {
+		t.Errorf("picked %q, %v; want b", id, err)
+	}
+	if id, err := runningInstance(instances, "a"); id != "a" || err != nil {
+		t.Errorf("explicit instance = %q, %v", id, err)
+	}
+	if _, err := runningInstance(instances, "z"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown instance: err = %v", err)
+	}
+	if _, err := runningInstance(instances[:1], ""); err == nil {
+		t.Error("no healthy instance should fail")
+	}
+}
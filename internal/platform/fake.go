@@ -0,0 +1,412 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Fake implements the Platform interface against the fake platform API
+// served by internal/selftest and internal/demo. It goes through the same
+// HTTP client, retry transport and error classification as the real
+// adapters, so faults the fake API injects exercise the code paths a real
+// platform would.
+//
+// Fake is only in the registry, as "demo", inside orbit demo sessions (see
+// RegisterDemo); users cannot connect it.
+type Fake struct {
+	token        string
+	endpoint     string
+	pollInterval time.Duration
+	httpClient   *http.Client
+}
+
+// NewFake creates a Fake talking to the fake API at endpoint.
+func NewFake(endpoint, token string) *Fake {
+	return &Fake{
+		token:        token,
+		endpoint:     endpoint,
+		pollInterval: DefaultPollInterval,
+		httpClient:   newHTTPClient(15 * time.Second),
+	}
+}
+
+// DemoPlatform is the name orbit demo connects the fake platform under.
+const DemoPlatform = "demo"
+
+// RegisterDemo adds the fake platform to the registry as DemoPlatform. Its
+// endpoint comes from the connection's config.
+func RegisterDemo() {
+	Register(DemoPlatform, func(token string) Platform {
+		return NewFake("", token)
+	})
+}
+
+func (f *Fake) SetEndpoint(endpoint string) {
+	f.endpoint = endpoint
+}
+
+// SetPollInterval sets how often WatchDeployment polls.
+func (f *Fake) SetPollInterval(d time.Duration) {
+	f.pollInterval = d
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Capabilities() Capabilities {
+	return CapDeployments | CapRedeploy | CapLogs | CapWatch | CapCancel
+}
+
+// getJSON fetches path and decodes the response body into v.
+func (f *Fake) getJSON(ctx context.Context, path string, v interface{}) error {
+	return f.doJSON(ctx, f.token, "GET", path, v)
+}
+
+func (f *Fake) doJSON(ctx context.Context, token, method, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, f.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return statusError("fake", resp.StatusCode)
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func (f *Fake) Validate(ctx context.Context, token string) error {
+	return f.doJSON(ctx, token, "GET", "/me", nil)
+}
+
+// fakeDeploy is the JSON shape of a fake API deploy.
+type fakeDeploy struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	Commit     string    `json:"commit"`
+	Message    string    `json:"message"`
+	Branch     string    `json:"branch"`
+	Author     string    `json:"author"`
+	CreatedAt  time.Time `json:"created_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	URL        string    `json:"url"`
+}
+
+func (d *fakeDeploy) toDeployment() Deployment {
+	dep := Deployment{
+		ID:        d.ID,
+		Status:    mapFakeStatus(d.Status),
+		Commit:    d.Commit,
+		Message:   d.Message,
+		Branch:    d.Branch,
+		Author:    d.Author,
+		CreatedAt: d.CreatedAt,
+		URL:       d.URL,
+	}
+	if !d.FinishedAt.IsZero() && d.FinishedAt.After(d.CreatedAt) {
+		dep.Duration = d.FinishedAt.Sub(d.CreatedAt)
+	}
+	return dep
+}
+
+func mapFakeStatus(status string) string {
+	switch status {
+	case "queued":
+		return "pending"
+	case "building":
+		return "building"
+	case "deploying":
+		return "deploying"
+	case "live":
+		return "healthy"
+	case "error", "canceled":
+		return "failed"
+	case "stopped":
+		return "sleeping"
+	default:
+		return status
+	}
+}
+
+func (f *Fake) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	var svc struct {
+		Name         string   `json:"name"`
+		Status       string   `json:"status"`
+		Instances    int      `json:"instances"`
+		MaxInstances int      `json:"max_instances"`
+		URL          string   `json:"url"`
+		CPU          *float64 `json:"cpu"`
+		Memory       *float64 `json:"memory"`
+		ResponseMs   int      `json:"response_ms"`
+	}
+	if err := f.getJSON(ctx, "/services/"+url.PathEscape(serviceID), &svc); err != nil {
+		return nil, fmt.Errorf("get service: %w", err)
+	}
+
+	status := &ServiceStatus{
+		Status:       mapFakeStatus(svc.Status),
+		Name:         svc.Name,
+		URL:          svc.URL,
+		Instances:    svc.Instances,
+		MaxInstances: svc.MaxInstances,
+		ResponseMs:   svc.ResponseMs,
+		CPU:          -1,
+		Memory:       -1,
+	}
+	if svc.CPU != nil {
+		status.CPU = *svc.CPU
+	}
+	if svc.Memory != nil {
+		status.Memory = *svc.Memory
+	}
+	if status.Status == "" {
+		return nil, fmt.Errorf("get service: no status in response")
+	}
+	if deploys, err := f.ListDeployments(ctx, serviceID, 1); err == nil && len(deploys) > 0 {
+		status.LastDeploy = &deploys[0]
+	}
+	return status, nil
+}
+
+// ListDeployments follows the API's cursor until it has limit deployments.
+func (f *Fake) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	deployments := make([]Deployment, 0, limit)
+	cursor := ""
+	for len(deployments) < limit {
+		var page struct {
+			Deploys []fakeDeploy `json:"deploys"`
+			Next    string       `json:"next"`
+		}
+		path := fmt.Sprintf("/services/%s/deploys?limit=%d", url.PathEscape(serviceID), limit-len(deployments))
+		if cursor != "" {
+			path += "&cursor=" + url.QueryEscape(cursor)
+		}
+		if err := f.getJSON(ctx, path, &page); err != nil {
+			return nil, fmt.Errorf("list deployments: %w", err)
+		}
+		for _, d := range page.Deploys {
+			if len(deployments) == limit {
+				break
+			}
+			deployments = append(deployments, d.toDeployment())
+		}
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+	return deployments, nil
+}
+
+func (f *Fake) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	var d fakeDeploy
+	if err := f.getJSON(ctx, "/deploys/"+url.PathEscape(deployID), &d); err != nil {
+		return nil, fmt.Errorf("get deployment: %w", err)
+	}
+	dep := d.toDeployment()
+	return &dep, nil
+}
+
+func (f *Fake) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	var d fakeDeploy
+	if err := f.doJSON(ctx, f.token, "POST", "/services/"+url.PathEscape(serviceID)+"/redeploy", &d); err != nil {
+		return nil, fmt.Errorf("redeploy: %w", err)
+	}
+	dep := d.toDeployment()
+	return &dep, nil
+}
+
+func (f *Fake) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: the fake platform has no rollback", ErrNotSupported)
+}
+
+func (f *Fake) CancelDeployment(ctx context.Context, deployID string) error {
+	if err := f.doJSON(ctx, f.token, "POST", "/deploys/"+url.PathEscape(deployID)+"/cancel", nil); err != nil {
+		return fmt.Errorf("cancel deployment: %w", err)
+	}
+	return nil
+}
+
+func (f *Fake) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	tail := opts.Tail
+	if tail <= 0 {
+		tail = 100
+	}
+	var result struct {
+		Logs []struct {
+			Time    time.Time `json:"time"`
+			Level   string    `json:"level"`
+			Message string    `json:"message"`
+		} `json:"logs"`
+	}
+	path := fmt.Sprintf("/services/%s/logs?tail=%d&type=%s", url.PathEscape(serviceID), tail, opts.logType())
+	if opts.Since > 0 {
+		path += fmt.Sprintf("&since=%.3f", opts.Since.Seconds())
+	}
+	if err := f.getJSON(ctx, path, &result); err != nil {
+		return nil, fmt.Errorf("get logs: %w", err)
+	}
+
+	entries := make([]LogEntry, 0, len(result.Logs))
+	for _, l := range result.Logs {
+		entries = append(entries, LogEntry{Timestamp: l.Time, Level: l.Level, Message: l.Message})
+	}
+	return entries, nil
+}
+
+// StreamBuildLogs polls the build log, since the fake API has no stream.
+// Its build log is always the latest deployment's, so deployID is only used
+// to tell when the build output has ended.
+func (f *Fake) StreamBuildLogs(ctx context.Context, serviceID, deployID string) (<-chan LogEntry, error) {
+	ch := make(chan LogEntry)
+
+	go func() {
+		defer close(ch)
+
+		var last time.Time
+		for {
+			// Check first so the lines of a finished deploy are all sent.
+			d, err := f.GetDeployment(ctx, deployID)
+			if err != nil {
+				return
+			}
+			entries, err := f.GetLogs(ctx, serviceID, LogOptions{Type: LogTypeBuild})
+			if err != nil {
+				return
+			}
+			for _, e := range entries {
+				if !e.Timestamp.After(last) {
+					continue
+				}
+				last = e.Timestamp
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if !IsInProgress(d.Status) {
+				return
+			}
+			if !sleepContext(ctx, f.pollInterval) {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (f *Fake) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: the fake platform has no scaling", ErrNotSupported)
+}
+
+func (f *Fake) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	ch := make(chan DeployEvent)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			deploys, err := f.ListDeployments(ctx, serviceID, 1)
+			if err != nil {
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
+				return
+			}
+
+			if len(deploys) > 0 {
+				d := deploys[0]
+				if d.ID != currentDeployID || IsInProgress(d.Status) {
+					if !sendEvent(ctx, ch, DeployEvent{
+						Phase:   "detected",
+						Message: fmt.Sprintf("New deployment detected! (%s)", d.ID),
+						Deploy:  &d,
+					}) {
+						return
+					}
+					f.trackDeployment(ctx, ch, d.ID)
+					return
+				}
+			}
+
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, f.pollInterval) {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (f *Fake) trackDeployment(ctx context.Context, ch chan<- DeployEvent, deployID string) {
+	lastPhase := ""
+	for {
+		deploy, err := f.GetDeployment(ctx, deployID)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: err})
+			return
+		}
+
+		phase := mapFakeToWatchPhase(deploy.Status)
+		if phase != lastPhase {
+			lastPhase = phase
+
+			event := DeployEvent{Phase: phase, Deploy: deploy}
+			switch phase {
+			case "building":
+				event.Message = "Building..."
+			case "deploying":
+				event.Message = "Deploying..."
+			case "done":
+				event.Message = "Deploy successful!"
+				sendEvent(ctx, ch, event)
+				return
+			case "failed":
+				event.Message = "Deployment failed!"
+				event.Error = fmt.Errorf("deployment %s failed", deployID)
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
+				return
+			}
+		}
+
+		if !sleepContext(ctx, f.pollInterval) {
+			return
+		}
+	}
+}
+
+func mapFakeToWatchPhase(status string) string {
+	switch status {
+	case "deploying":
+		return "deploying"
+	case "healthy":
+		return "done"
+	case "failed", "sleeping":
+		return "failed"
+	default:
+		return "building"
+	}
+}
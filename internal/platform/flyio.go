@@ -2,6 +2,7 @@ package platform
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -22,6 +23,8 @@ type Flyio struct {
 	token      string
 	orgSlug    string
 	httpClient *http.Client
+
+	poller
 }
 
 // NewFlyio creates a new Fly.io platform instance.
@@ -29,7 +32,7 @@ func NewFlyio(token string) *Flyio {
 	return &Flyio{
 		token:      token,
 		orgSlug:    "personal",
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient: newHTTPClient(15 * time.Second),
 	}
 }
 
@@ -41,14 +44,18 @@ func (f *Flyio) Name() string {
 	return "flyio"
 }
 
-func (f *Flyio) doRequest(method, path string, body []byte) (*http.Response, error) {
+func (f *Flyio) Capabilities() Capabilities {
+	return CapDeployments | CapRedeploy | CapLogs | CapWatch | CapDiscover | CapInstances
+}
+
+func (f *Flyio) doRequest(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
 	var reqBody *bytes.Reader
 	if body != nil {
 		reqBody = bytes.NewReader(body)
 	} else {
 		reqBody = bytes.NewReader(nil)
 	}
-	req, err := http.NewRequest(method, flyBaseURL+path, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, flyBaseURL+path, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -57,9 +64,9 @@ func (f *Flyio) doRequest(method, path string, body []byte) (*http.Response, err
 	return f.httpClient.Do(req)
 }
 
-func (f *Flyio) Validate(token string) error {
-	client := &http.Client{Timeout: 15 * time.Second}
-	req, err := http.NewRequest("GET", flyBaseURL+"/v1/apps?org_slug=personal", nil)
+func (f *Flyio) Validate(ctx context.Context, token string) error {
+	client := newHTTPClient(15 * time.Second)
+	req, err := http.NewRequestWithContext(ctx, "GET", flyBaseURL+"/v1/apps?org_slug=personal", nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
@@ -72,10 +79,10 @@ func (f *Flyio) Validate(token string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
-		return fmt.Errorf("invalid token: unauthorized")
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
 	}
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("fly.io API returned status %d", resp.StatusCode)
+		return statusError("fly.io", resp.StatusCode)
 	}
 	return nil
 }
@@ -91,11 +98,32 @@ type flyMachine struct {
 	CreatedAt  string `json:"created_at"`
 	UpdatedAt  string `json:"updated_at"`
 	Config     struct {
-		Image string `json:"image"`
+		Image string   `json:"image"`
+		Guest flyGuest `json:"guest"`
 	} `json:"config"`
 	Events []flyMachineEvent `json:"events"`
 }
 
+// flyGuest is a machine's size.
+type flyGuest struct {
+	CPUKind  string `json:"cpu_kind"` // shared or performance
+	CPUs     int    `json:"cpus"`
+	MemoryMB int    `json:"memory_mb"`
+}
+
+// String names the size like Fly's presets, with the memory appended,
+// e.g. shared-cpu-1x:512MB or performance-2x:4096MB.
+func (g flyGuest) String() string {
+	switch {
+	case g.CPUs == 0:
+		return ""
+	case g.CPUKind == "shared":
+		return fmt.Sprintf("shared-cpu-%dx:%dMB", g.CPUs, g.MemoryMB)
+	default:
+		return fmt.Sprintf("%s-%dx:%dMB", g.CPUKind, g.CPUs, g.MemoryMB)
+	}
+}
+
 type flyMachineEvent struct {
 	Type      string `json:"type"`
 	Status    string `json:"status"`
@@ -120,15 +148,15 @@ func mapFlyState(state string) string {
 	}
 }
 
-func (f *Flyio) listMachines(appName string) ([]flyMachine, error) {
-	resp, err := f.doRequest("GET", fmt.Sprintf("/v1/apps/%s/machines", appName), nil)
+func (f *Flyio) listMachines(ctx context.Context, appName string) ([]flyMachine, error) {
+	resp, err := f.doRequest(ctx, "GET", fmt.Sprintf("/v1/apps/%s/machines", appName), nil)
 	if err != nil {
 		return nil, fmt.Errorf("list machines: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("fly.io API returned status %d", resp.StatusCode)
+		return nil, statusError("fly.io", resp.StatusCode)
 	}
 
 	var machines []flyMachine
@@ -138,8 +166,8 @@ func (f *Flyio) listMachines(appName string) ([]flyMachine, error) {
 	return machines, nil
 }
 
-func (f *Flyio) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
-	machines, err := f.listMachines(serviceID)
+func (f *Flyio) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	machines, err := f.listMachines(ctx, serviceID)
 	if err != nil {
 		return nil, err
 	}
@@ -206,8 +234,77 @@ func (f *Flyio) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
 	return status, nil
 }
 
-func (f *Flyio) ListDeployments(serviceID string, limit int) ([]Deployment, error) {
-	machines, err := f.listMachines(serviceID)
+// ListInstances returns the app's machines; destroyed machines are left out.
+func (f *Flyio) ListInstances(ctx context.Context, serviceID string) ([]Instance, error) {
+	machines, err := f.listMachines(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	var instances []Instance
+	for _, m := range machines {
+		if m.State == "destroyed" || m.State == "destroying" {
+			continue
+		}
+		instances = append(instances, m.instance())
+	}
+	return instances, nil
+}
+
+// GetCurrentScale implements ScaleInfoProvider. Min and max are the number
+// of machines, as Fly.io apps have no scaling range; the instance type is
+// the size of the first machine.
+func (f *Flyio) GetCurrentScale(ctx context.Context, serviceID string) (min, max int, instanceType string, err error) {
+	machines, err := f.listMachines(ctx, serviceID)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	n := 0
+	for _, m := range machines {
+		if m.State == "destroyed" || m.State == "destroying" {
+			continue
+		}
+		if n == 0 {
+			instanceType = m.Config.Guest.String()
+		}
+		n++
+	}
+	return n, n, instanceType, nil
+}
+
+// instance maps a machine to an instance. Machines stopped by autostop
+// count as sleeping, like the service status does.
+func (m flyMachine) instance() Instance {
+	inst := Instance{ID: m.ID, Region: m.Region}
+	switch m.State {
+	case "started":
+		inst.State = "healthy"
+	case "created", "starting", "replacing":
+		inst.State = "starting"
+	case "stopped", "suspended":
+		inst.State = "sleeping"
+	case "stopping", "suspending":
+		inst.State = "stopped"
+	case "failed":
+		inst.State = "unhealthy"
+	default:
+		inst.State = m.State
+	}
+
+	if m.State == "started" {
+		// The most recent start event is when the machine came up.
+		for _, e := range m.Events {
+			if e.Type == "start" && e.Status == "started" {
+				if t := time.UnixMilli(e.Timestamp); t.After(inst.StartedAt) {
+					inst.StartedAt = t
+				}
+			}
+		}
+	}
+	return inst
+}
+
+func (f *Flyio) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	machines, err := f.listMachines(ctx, serviceID)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +342,7 @@ func (f *Flyio) ListDeployments(serviceID string, limit int) ([]Deployment, erro
 	return deployments, nil
 }
 
-func (f *Flyio) GetDeployment(deployID string) (*Deployment, error) {
+func (f *Flyio) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
 	// deployID format: "appName/machineID"
 	parts := strings.SplitN(deployID, "/", 2)
 	if len(parts) != 2 {
@@ -253,17 +350,17 @@ func (f *Flyio) GetDeployment(deployID string) (*Deployment, error) {
 	}
 	appName, machineID := parts[0], parts[1]
 
-	resp, err := f.doRequest("GET", fmt.Sprintf("/v1/apps/%s/machines/%s", appName, machineID), nil)
+	resp, err := f.doRequest(ctx, "GET", fmt.Sprintf("/v1/apps/%s/machines/%s", appName, machineID), nil)
 	if err != nil {
 		return nil, fmt.Errorf("get machine: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("machine not found: %s", deployID)
+		return nil, fmt.Errorf("machine %w: %s", ErrNotFound, deployID)
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("fly.io API returned status %d", resp.StatusCode)
+		return nil, statusError("fly.io", resp.StatusCode)
 	}
 
 	var m flyMachine
@@ -286,8 +383,8 @@ func (f *Flyio) GetDeployment(deployID string) (*Deployment, error) {
 	}, nil
 }
 
-func (f *Flyio) Redeploy(serviceID string) (*Deployment, error) {
-	machines, err := f.listMachines(serviceID)
+func (f *Flyio) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	machines, err := f.listMachines(ctx, serviceID)
 	if err != nil {
 		return nil, err
 	}
@@ -302,14 +399,14 @@ func (f *Flyio) Redeploy(serviceID string) (*Deployment, error) {
 			continue
 		}
 		// Stop
-		resp, err := f.doRequest("POST", fmt.Sprintf("/v1/apps/%s/machines/%s/stop", serviceID, m.ID), nil)
+		resp, err := f.doRequest(ctx, "POST", fmt.Sprintf("/v1/apps/%s/machines/%s/stop", serviceID, m.ID), nil)
 		if err != nil {
 			return nil, fmt.Errorf("stop machine %s: %w", m.ID, err)
 		}
 		resp.Body.Close()
 
 		// Start
-		resp, err = f.doRequest("POST", fmt.Sprintf("/v1/apps/%s/machines/%s/start", serviceID, m.ID), nil)
+		resp, err = f.doRequest(ctx, "POST", fmt.Sprintf("/v1/apps/%s/machines/%s/start", serviceID, m.ID), nil)
 		if err != nil {
 			return nil, fmt.Errorf("start machine %s: %w", m.ID, err)
 		}
@@ -327,18 +424,26 @@ func (f *Flyio) Redeploy(serviceID string) (*Deployment, error) {
 	}, nil
 }
 
-func (f *Flyio) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
+func (f *Flyio) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: use 'fly deploy --image' with the previous release image", ErrNotSupported)
+}
+
+func (f *Flyio) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: interrupt 'fly deploy' to stop a rollout", ErrNotSupported)
+}
+
+func (f *Flyio) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
 	// Fly.io logs use a different path prefix: /api/v1/
 	path := fmt.Sprintf("/api/v1/apps/%s/logs", serviceID)
 
-	req, err := http.NewRequest("GET", flyBaseURL+path, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", flyBaseURL+path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+f.token)
 
 	// Use a longer timeout for logs (NDJSON stream)
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := newHTTPClient(10 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("get logs: %w", err)
@@ -346,7 +451,7 @@ func (f *Flyio) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("fly.io logs API returned status %d", resp.StatusCode)
+		return nil, statusError("fly.io logs", resp.StatusCode)
 	}
 
 	// Response is NDJSON (newline-delimited JSON)
@@ -396,19 +501,19 @@ func (f *Flyio) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
 	return entries, nil
 }
 
-func (f *Flyio) Scale(serviceID string, opts ScaleOptions) error {
-	return fmt.Errorf("not supported: use 'fly scale' CLI or create/destroy machines via Fly.io dashboard")
+func (f *Flyio) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: use 'fly scale' CLI or create/destroy machines via Fly.io dashboard", ErrNotSupported)
 }
 
-func (f *Flyio) DiscoverServices() ([]DiscoveredService, error) {
-	resp, err := f.doRequest("GET", fmt.Sprintf("/v1/apps?org_slug=%s", f.orgSlug), nil)
+func (f *Flyio) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	resp, err := f.doRequest(ctx, "GET", fmt.Sprintf("/v1/apps?org_slug=%s", f.orgSlug), nil)
 	if err != nil {
 		return nil, fmt.Errorf("list apps: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("fly.io API returned status %d", resp.StatusCode)
+		return nil, statusError("fly.io", resp.StatusCode)
 	}
 
 	var result struct {
@@ -432,72 +537,80 @@ func (f *Flyio) DiscoverServices() ([]DiscoveredService, error) {
 	return services, nil
 }
 
-func (f *Flyio) WatchDeployment(serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+func (f *Flyio) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
 	ch := make(chan DeployEvent)
 
 	go func() {
 		defer close(ch)
 
-		const pollInterval = 3 * time.Second
+		pollInterval := f.interval()
 
 		// Get current machine states
-		machines, err := f.listMachines(serviceID)
+		machines, err := f.listMachines(ctx, serviceID)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("list machines: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("list machines: %w", err)})
 			return
 		}
 
 		// Check if any machine is currently updating
 		for _, m := range machines {
-			if m.InstanceID != currentDeployID && isInProgress(mapFlyState(m.State)) {
+			if m.InstanceID != currentDeployID && IsInProgress(mapFlyState(m.State)) {
 				dep := machineToDeployment(m)
-				ch <- DeployEvent{
+				if !sendEvent(ctx, ch, DeployEvent{
 					Phase:   "detected",
 					Message: fmt.Sprintf("In-progress update found (machine %s)", m.ID),
 					Deploy:  &dep,
+				}) {
+					return
 				}
-				f.trackMachines(ch, serviceID)
+				f.trackMachines(ctx, ch, serviceID)
 				return
 			}
 		}
 
 		// Wait for instance_id change (new deployment)
 		for {
-			machines, err := f.listMachines(serviceID)
+			machines, err := f.listMachines(ctx, serviceID)
 			if err != nil {
-				ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("list machines: %w", err)}
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("list machines: %w", err)})
 				return
 			}
 
 			for _, m := range machines {
 				if m.InstanceID != currentDeployID && m.InstanceID != "" {
 					dep := machineToDeployment(m)
-					ch <- DeployEvent{
+					if !sendEvent(ctx, ch, DeployEvent{
 						Phase:   "detected",
 						Message: fmt.Sprintf("New deployment detected (machine %s)", m.ID),
 						Deploy:  &dep,
+					}) {
+						return
 					}
-					f.trackMachines(ch, serviceID)
+					f.trackMachines(ctx, ch, serviceID)
 					return
 				}
 			}
 
-			ch <- DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}
-			time.Sleep(pollInterval)
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
 		}
 	}()
 
 	return ch, nil
 }
 
-func (f *Flyio) trackMachines(ch chan<- DeployEvent, appName string) {
-	const pollInterval = 3 * time.Second
+func (f *Flyio) trackMachines(ctx context.Context, ch chan<- DeployEvent, appName string) {
+	pollInterval := f.interval()
 	lastPhase := ""
 
 	for {
-		machines, err := f.listMachines(appName)
+		machines, err := f.listMachines(ctx, appName)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("list machines: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("list machines: %w", err)})
 			return
 		}
 
@@ -534,18 +647,22 @@ func (f *Flyio) trackMachines(ch chan<- DeployEvent, appName string) {
 				event.Message = "Deploying..."
 			case "done":
 				event.Message = "All machines started!"
-				ch <- event
+				sendEvent(ctx, ch, event)
 				return
 			case "failed":
 				event.Message = "Machine failed!"
 				event.Error = fmt.Errorf("one or more machines failed")
-				ch <- event
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
 				return
 			}
-			ch <- event
 		}
 
-		time.Sleep(pollInterval)
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
 	}
 }
 
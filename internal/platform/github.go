@@ -0,0 +1,582 @@
+package platform
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const githubBaseURL = "https://api.github.com"
+
+func init() {
+	Register("github", func(token string) Platform {
+		return NewGitHub(token)
+	})
+}
+
+// GitHub implements the Platform interface for sites deployed by GitHub
+// Actions (e.g. GitHub Pages). Workflow runs are Orbit deployments.
+//
+// Service IDs are "owner/repo" for every workflow in the repository, or
+// "owner/repo/<workflow file>" (e.g. "acme/site/deploy.yml") for one
+// workflow. Deploy IDs are "owner/repo/<run ID>".
+type GitHub struct {
+	token      string
+	httpClient *http.Client
+
+	poller
+}
+
+// NewGitHub creates a new GitHub Actions platform instance.
+func NewGitHub(token string) *GitHub {
+	return &GitHub{
+		token:      token,
+		httpClient: newHTTPClient(15 * time.Second),
+	}
+}
+
+func (g *GitHub) Name() string {
+	return "github"
+}
+
+func (g *GitHub) Capabilities() Capabilities {
+	return CapDeployments | CapRedeploy | CapLogs | CapWatch | CapCancel
+}
+
+func (g *GitHub) doRequest(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, githubBaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	return g.httpClient.Do(req)
+}
+
+func (g *GitHub) getJSON(ctx context.Context, path string, out interface{}) error {
+	resp, err := g.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return fmt.Errorf("github API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 {
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("%w: %s (check the repository name and token scopes)", ErrNotFound, path)
+	}
+	if resp.StatusCode != 200 {
+		return statusError("github", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Validate checks whether the token is valid by calling GET /user.
+func (g *GitHub) Validate(ctx context.Context, token string) error {
+	resp, err := NewGitHub(token).doRequest(ctx, "GET", "/user", nil)
+	if err != nil {
+		return fmt.Errorf("github API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+	if resp.StatusCode != 200 {
+		return statusError("github", resp.StatusCode)
+	}
+	return nil
+}
+
+// splitGitHubID splits a service ID into repo ("owner/name") and an
+// optional workflow file.
+func splitGitHubID(serviceID string) (repo, workflow string, err error) {
+	parts := strings.SplitN(serviceID, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("github service ID must be owner/repo or owner/repo/<workflow file>, got: %s", serviceID)
+	}
+	repo = parts[0] + "/" + parts[1]
+	if len(parts) == 3 {
+		workflow = parts[2]
+	}
+	return repo, workflow, nil
+}
+
+// splitGitHubRunID splits a deploy ID "owner/repo/<run ID>".
+func splitGitHubRunID(deployID string) (repo string, runID int64, err error) {
+	i := strings.LastIndex(deployID, "/")
+	if i < 0 || strings.Count(deployID[:i], "/") != 1 {
+		return "", 0, fmt.Errorf("github deploy ID must be owner/repo/<run ID>, got: %s", deployID)
+	}
+	runID, err = strconv.ParseInt(deployID[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("github deploy ID must be owner/repo/<run ID>, got: %s", deployID)
+	}
+	return deployID[:i], runID, nil
+}
+
+type githubRun struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	DisplayTitle string    `json:"display_title"`
+	HeadSHA      string    `json:"head_sha"`
+	HeadBranch   string    `json:"head_branch"`
+	Status       string    `json:"status"`     // queued, in_progress, completed, waiting, requested, pending
+	Conclusion   string    `json:"conclusion"` // success, failure, cancelled, skipped, timed_out, ...
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	RunStartedAt time.Time `json:"run_started_at"`
+	Repository   struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func (r *githubRun) toDeployment() Deployment {
+	started := r.RunStartedAt
+	if started.IsZero() {
+		started = r.CreatedAt
+	}
+	msg := r.DisplayTitle
+	if r.Name != "" {
+		msg = r.Name + ": " + msg
+	}
+
+	d := Deployment{
+		ID:        fmt.Sprintf("%s/%d", r.Repository.FullName, r.ID),
+		Status:    mapGitHubRunStatus(r.Status, r.Conclusion),
+		Commit:    r.HeadSHA,
+		Message:   msg,
+		CreatedAt: started,
+		URL:       r.HTMLURL,
+	}
+	if r.Status == "completed" && r.UpdatedAt.After(started) {
+		d.Duration = r.UpdatedAt.Sub(started)
+	}
+	return d
+}
+
+func mapGitHubRunStatus(status, conclusion string) string {
+	switch status {
+	case "queued", "waiting", "requested", "pending":
+		return "pending"
+	case "in_progress":
+		return "building"
+	case "completed":
+		switch conclusion {
+		case "success":
+			return "healthy"
+		case "skipped", "neutral":
+			return "sleeping"
+		default:
+			return "failed"
+		}
+	default:
+		return "pending"
+	}
+}
+
+func (g *GitHub) listRuns(ctx context.Context, serviceID string, limit int) ([]githubRun, error) {
+	repo, workflow, err := splitGitHubID(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	path := fmt.Sprintf("/repos/%s/actions/runs?per_page=%d", repo, limit)
+	if workflow != "" {
+		path = fmt.Sprintf("/repos/%s/actions/workflows/%s/runs?per_page=%d", repo, url.PathEscape(workflow), limit)
+	}
+
+	var result struct {
+		WorkflowRuns []githubRun `json:"workflow_runs"`
+	}
+	if err := g.getJSON(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result.WorkflowRuns, nil
+}
+
+// GetServiceStatus probes the repository's Pages site when there is one;
+// otherwise health follows the latest completed run.
+func (g *GitHub) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	repo, _, err := splitGitHubID(serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := g.listRuns(ctx, serviceID, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ServiceStatus{
+		Status: "healthy",
+		CPU:    -1,
+		Memory: -1,
+		Name:   repo,
+	}
+	if len(runs) > 0 {
+		latest := runs[0].toDeployment()
+		status.LastDeploy = &latest
+	}
+
+	var pages struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := g.getJSON(ctx, "/repos/"+repo+"/pages", &pages); err == nil && pages.HTMLURL != "" {
+		start := time.Now()
+		resp, err := g.httpClient.Get(pages.HTMLURL)
+		if err != nil || resp.StatusCode >= 400 {
+			status.Status = "unhealthy"
+		} else {
+			status.ResponseMs = int(time.Since(start).Milliseconds())
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return status, nil
+	}
+
+	// No Pages site: a failed latest deploy leaves the previous version
+	// serving, so report degraded rather than down.
+	for _, r := range runs {
+		if r.Status != "completed" {
+			continue
+		}
+		if mapGitHubRunStatus(r.Status, r.Conclusion) == "failed" {
+			status.Status = "degraded"
+		}
+		break
+	}
+	return status, nil
+}
+
+func (g *GitHub) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	runs, err := g.listRuns(ctx, serviceID, limit)
+	if err != nil {
+		return nil, err
+	}
+	deploys := make([]Deployment, 0, len(runs))
+	for i := range runs {
+		deploys = append(deploys, runs[i].toDeployment())
+	}
+	return deploys, nil
+}
+
+func (g *GitHub) getRun(ctx context.Context, deployID string) (*githubRun, string, error) {
+	repo, runID, err := splitGitHubRunID(deployID)
+	if err != nil {
+		return nil, "", err
+	}
+	var run githubRun
+	if err := g.getJSON(ctx, fmt.Sprintf("/repos/%s/actions/runs/%d", repo, runID), &run); err != nil {
+		return nil, "", err
+	}
+	return &run, repo, nil
+}
+
+func (g *GitHub) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	run, _, err := g.getRun(ctx, deployID)
+	if err != nil {
+		return nil, err
+	}
+	d := run.toDeployment()
+	return &d, nil
+}
+
+// Redeploy re-runs the most recent workflow run.
+func (g *GitHub) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	runs, err := g.listRuns(ctx, serviceID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no workflow runs found for %s", serviceID)
+	}
+	run := runs[0]
+
+	resp, err := g.doRequest(ctx, "POST", fmt.Sprintf("/repos/%s/actions/runs/%d/rerun", run.Repository.FullName, run.ID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("github API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 201 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", statusError("github", resp.StatusCode), strings.TrimSpace(string(body)))
+	}
+
+	d := run.toDeployment()
+	d.Status = "pending"
+	return &d, nil
+}
+
+func (g *GitHub) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: re-run the previous workflow run on GitHub", ErrNotSupported)
+}
+
+// CancelDeployment cancels a workflow run that is queued or in progress.
+func (g *GitHub) CancelDeployment(ctx context.Context, deployID string) error {
+	repo, runID, err := splitGitHubRunID(deployID)
+	if err != nil {
+		return err
+	}
+	resp, err := g.doRequest(ctx, "POST", fmt.Sprintf("/repos/%s/actions/runs/%d/cancel", repo, runID), nil)
+	if err != nil {
+		return fmt.Errorf("github API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 202 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%w: %s", statusError("github", resp.StatusCode), strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// GetLogs returns the job logs of the most recent run. "##[error]" and
+// "##[warning]" annotations set the level; other lines are inferred.
+func (g *GitHub) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	runs, err := g.listRuns(ctx, serviceID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	run := runs[0]
+
+	all, err := g.runLogs(ctx, run.Repository.FullName, run.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if opts.Since > 0 {
+		since = time.Now().Add(-opts.Since)
+	}
+
+	var entries []LogEntry
+	for _, entry := range all {
+		if !MatchesLevel(entry.Level, opts.Level) {
+			continue
+		}
+		if !since.IsZero() && entry.Timestamp.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if opts.Tail > 0 && len(entries) > opts.Tail {
+		entries = entries[len(entries)-opts.Tail:]
+	}
+	return entries, nil
+}
+
+// DeploymentLogs returns the last tail job log lines of a run.
+func (g *GitHub) DeploymentLogs(ctx context.Context, deployID string, tail int) ([]LogEntry, error) {
+	repo, runID, err := splitGitHubRunID(deployID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := g.runLogs(ctx, repo, runID)
+	if err != nil {
+		return nil, err
+	}
+	if tail > 0 && len(entries) > tail {
+		entries = entries[len(entries)-tail:]
+	}
+	return entries, nil
+}
+
+// runLogs collects the logs of every job in a run, in job order.
+func (g *GitHub) runLogs(ctx context.Context, repo string, runID int64) ([]LogEntry, error) {
+	var jobs struct {
+		Jobs []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"jobs"`
+	}
+	if err := g.getJSON(ctx, fmt.Sprintf("/repos/%s/actions/runs/%d/jobs", repo, runID), &jobs); err != nil {
+		return nil, err
+	}
+
+	var entries []LogEntry
+	for _, job := range jobs.Jobs {
+		// The logs endpoint redirects to a short-lived download URL.
+		resp, err := g.doRequest(ctx, "GET", fmt.Sprintf("/repos/%s/actions/jobs/%d/logs", repo, job.ID), nil)
+		if err != nil {
+			return nil, fmt.Errorf("github API error: %w", err)
+		}
+		if resp.StatusCode != 200 {
+			// Logs aren't available until the job has started.
+			resp.Body.Close()
+			continue
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+			entry := LogEntry{Level: "info", Message: line, Source: job.Name}
+			if ts, msg, ok := strings.Cut(line, " "); ok {
+				if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+					entry.Timestamp, entry.Message = t, msg
+				}
+			}
+			if strings.HasPrefix(entry.Message, "##[error]") {
+				entry.Level = "error"
+				entry.Message = strings.TrimPrefix(entry.Message, "##[error]")
+			} else if strings.HasPrefix(entry.Message, "##[warning]") {
+				entry.Level = "warn"
+				entry.Message = strings.TrimPrefix(entry.Message, "##[warning]")
+			} else {
+				entry.Level = InferLevel(entry.Message, "info")
+			}
+			entries = append(entries, entry)
+		}
+		resp.Body.Close()
+	}
+	return entries, nil
+}
+
+func (g *GitHub) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: sites deployed by GitHub Actions have no instances to scale", ErrNotSupported)
+}
+
+// ArtifactSize sums the sizes of the artifacts uploaded by a run
+// (e.g. the Pages artifact).
+func (g *GitHub) ArtifactSize(ctx context.Context, deployID string) (int64, error) {
+	repo, runID, err := splitGitHubRunID(deployID)
+	if err != nil {
+		return 0, err
+	}
+	var result struct {
+		Artifacts []struct {
+			SizeInBytes int64 `json:"size_in_bytes"`
+		} `json:"artifacts"`
+	}
+	if err := g.getJSON(ctx, fmt.Sprintf("/repos/%s/actions/runs/%d/artifacts", repo, runID), &result); err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, a := range result.Artifacts {
+		total += a.SizeInBytes
+	}
+	return total, nil
+}
+
+func (g *GitHub) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	ch := make(chan DeployEvent)
+
+	go func() {
+		defer close(ch)
+
+		pollInterval := g.interval()
+
+		// Check if the latest run is already in-progress.
+		deploys, err := g.ListDeployments(ctx, serviceID, 1)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll workflow runs: %w", err)})
+			return
+		}
+		if len(deploys) > 0 && IsInProgress(deploys[0].Status) {
+			d := deploys[0]
+			if !sendEvent(ctx, ch, DeployEvent{
+				Phase:   "detected",
+				Message: fmt.Sprintf("In-progress workflow run found (%s)", d.ID),
+				Deploy:  &d,
+			}) {
+				return
+			}
+			g.trackDeployment(ctx, ch, d.ID)
+			return
+		}
+
+		// Phase 1: Detect a new workflow run
+		for {
+			deploys, err := g.ListDeployments(ctx, serviceID, 1)
+			if err != nil {
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll workflow runs: %w", err)})
+				return
+			}
+
+			if len(deploys) > 0 {
+				d := deploys[0]
+				if d.ID != currentDeployID {
+					if !sendEvent(ctx, ch, DeployEvent{
+						Phase:   "detected",
+						Message: fmt.Sprintf("New workflow run detected! (%s)", d.ID),
+						Deploy:  &d,
+					}) {
+						return
+					}
+					g.trackDeployment(ctx, ch, d.ID)
+					return
+				}
+			}
+
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (g *GitHub) trackDeployment(ctx context.Context, ch chan<- DeployEvent, deployID string) {
+	pollInterval := g.interval()
+	lastPhase := ""
+
+	for {
+		deploy, err := g.GetDeployment(ctx, deployID)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("get workflow run: %w", err)})
+			return
+		}
+
+		phase := mapRenderToWatchPhase(deploy.Status)
+		if phase != lastPhase {
+			lastPhase = phase
+
+			event := DeployEvent{Phase: phase, Deploy: deploy}
+			switch phase {
+			case "building":
+				event.Message = "Running workflow..."
+			case "done":
+				event.Message = "Deploy successful!"
+				sendEvent(ctx, ch, event)
+				return
+			case "failed":
+				event.Message = "Workflow run failed!"
+				event.Error = fmt.Errorf("workflow run %s failed", deployID)
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
+				return
+			}
+		}
+
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package platform
+
+import "testing"
+
+func TestSplitGitHubIDs(t *testing.T) {
+	repo, wf, err := splitGitHubID("acme/site/deploy.yml")
+	if err != nil || repo != "acme/site" || wf != "deploy.yml" {
+		t.Errorf("splitGitHubID = %q, %q, %v", repo, wf, err)
+	}
+	if _, _, err := splitGitHubID("acme"); err == nil {
+		t.Error("expected error for ID without repo")
+	}
+
+	repo, runID, err := splitGitHubRunID("acme/site/123456")
+	if err != nil || repo != "acme/site" || runID != 123456 {
+		t.Errorf("splitGitHubRunID = %q, %d, %v", repo, runID, err)
+	}
+	for _, bad := range []string{"123456", "acme/123456", "acme/site/deploy.yml/1", "acme/site/latest"} {
+		if _, _, err := splitGitHubRunID(bad); err == nil {
+			t.Errorf("splitGitHubRunID(%q): expected error", bad)
+		}
+	}
+}
+
+func TestMapGitHubRunStatus(t *testing.T) {
+	tests := []struct {
+		status, conclusion, want string
+	}{
+		{"queued", "", "pending"},
+		{"in_progress", "", "building"},
+		{"completed", "success", "healthy"},
+		{"completed", "failure", "failed"},
+		{"completed", "cancelled", "failed"},
+		{"completed", "skipped", "sleeping"},
+	}
+	for _, tt := range tests {
+		if got := mapGitHubRunStatus(tt.status, tt.conclusion); got != tt.want {
+			t.Errorf("mapGitHubRunStatus(%q, %q) = %q, want %q", tt.status, tt.conclusion, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package platform
+
+import (
+	"context"
+	"time"
+)
+
+// Instance is one running replica of a service.
+type Instance struct {
+	ID        string
+	State     string    // healthy, starting, unhealthy, sleeping or stopped
+	Region    string    // region or zone the instance runs in; "" when unknown
+	StartedAt time.Time // zero when unknown
+	Restarts  int
+	Message   string // latest platform message, e.g. why the instance is unhealthy
+}
+
+// InstanceLister is implemented by platforms that can list the individual
+// instances (replicas) behind a service.
+type InstanceLister interface {
+	ListInstances(ctx context.Context, serviceID string) ([]Instance, error)
+}
@@ -0,0 +1,66 @@
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestKubePodInstance(t *testing.T) {
+	tests := []struct {
+		name, pod string
+		want      Instance
+	}{
+		{
+			name: "ready",
+			pod:  `{"metadata":{"name":"web-1"},"spec":{"nodeName":"node-a"},"status":{"phase":"Running","startTime":"2026-03-01T10:00:00Z","containerStatuses":[{"ready":true,"restartCount":2,"state":{}}]}}`,
+			want: Instance{ID: "web-1", State: "healthy", Region: "node-a", StartedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), Restarts: 2},
+		},
+		{
+			name: "crash loop",
+			pod:  `{"metadata":{"name":"web-2"},"status":{"phase":"Running","containerStatuses":[{"ready":false,"restartCount":7,"state":{"waiting":{"reason":"CrashLoopBackOff","message":"back-off 5m0s"}}}]}}`,
+			want: Instance{ID: "web-2", State: "unhealthy", Restarts: 7, Message: "CrashLoopBackOff: back-off 5m0s"},
+		},
+		{
+			name: "not ready yet",
+			pod:  `{"metadata":{"name":"web-3"},"status":{"phase":"Running","containerStatuses":[{"ready":false,"state":{}}]}}`,
+			want: Instance{ID: "web-3", State: "starting"},
+		},
+		{
+			name: "pending",
+			pod:  `{"metadata":{"name":"web-4"},"status":{"phase":"Pending"}}`,
+			want: Instance{ID: "web-4", State: "starting"},
+		},
+	}
+	for _, tt := range tests {
+		var p kubePod
+		if err := json.Unmarshal([]byte(tt.pod), &p); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := p.instance(); got != tt.want {
+			t.Errorf("%s: instance() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFlyMachineInstance(t *testing.T) {
+	m := flyMachine{
+		ID:     "148ed",
+		State:  "started",
+		Region: "ams",
+		Events: []flyMachineEvent{
+			{Type: "start", Status: "started", Timestamp: 1772359200000},
+			{Type: "exit", Status: "stopped", Timestamp: 1772362800000},
+			{Type: "start", Status: "started", Timestamp: 1772366400000},
+		},
+	}
+	got := m.instance()
+	if got.State != "healthy" || got.Region != "ams" || !got.StartedAt.Equal(time.UnixMilli(1772366400000)) {
+		t.Errorf("instance() = %+v, want healthy in ams started at the last start event", got)
+	}
+
+	m.State = "stopped"
+	if got := m.instance(); got.State != "sleeping" || !got.StartedAt.IsZero() {
+		t.Errorf("stopped machine: instance() = %+v, want sleeping without a start time", got)
+	}
+}
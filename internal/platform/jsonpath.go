@@ -0,0 +1,148 @@
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONPath evaluates a small JSONPath subset against a decoded JSON document:
+// "$" for the root, ".key" or "['key']" for object fields and "[n]" for array
+// indexes, e.g. "$.data.items[0].state". The leading "$" is optional.
+func JSONPath(doc interface{}, path string) (interface{}, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+
+	cur := doc
+	for path != "" {
+		switch {
+		case path[0] == '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			key := path[:end]
+			path = path[end:]
+			if key == "" {
+				return nil, fmt.Errorf("empty field name")
+			}
+			obj, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%q: not an object", key)
+			}
+			if cur, ok = obj[key]; !ok {
+				return nil, fmt.Errorf("%q: field not found", key)
+			}
+
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [")
+			}
+			sel := path[1:end]
+			path = path[end+1:]
+
+			if len(sel) >= 2 && (sel[0] == '\'' || sel[0] == '"') {
+				key := sel[1 : len(sel)-1]
+				obj, ok := cur.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("%q: not an object", key)
+				}
+				if cur, ok = obj[key]; !ok {
+					return nil, fmt.Errorf("%q: field not found", key)
+				}
+				continue
+			}
+
+			idx, err := strconv.Atoi(sel)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q", sel)
+			}
+			arr, ok := cur.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("[%d]: not an array", idx)
+			}
+			if idx < 0 {
+				idx += len(arr)
+			}
+			if idx < 0 || idx >= len(arr) {
+				return nil, fmt.Errorf("[%d]: index out of range", idx)
+			}
+			cur = arr[idx]
+
+		default:
+			// Allow paths without a leading "$." such as "status.state".
+			path = "." + path
+		}
+	}
+	return cur, nil
+}
+
+// jsonPathString returns the value at path as a string, or "" if path is
+// empty or doesn't resolve.
+func jsonPathString(doc interface{}, path string) string {
+	if path == "" {
+		return ""
+	}
+	v, err := JSONPath(doc, path)
+	if err != nil || v == nil {
+		return ""
+	}
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// jsonPathFloat returns the numeric value at path, or def if it is missing
+// or not a number.
+func jsonPathFloat(doc interface{}, path string, def float64) float64 {
+	if path == "" {
+		return def
+	}
+	v, err := JSONPath(doc, path)
+	if err != nil {
+		return def
+	}
+	switch t := v.(type) {
+	case float64:
+		return t
+	case string:
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
+// jsonPathTime returns the timestamp at path. RFC 3339 strings and Unix
+// timestamps in seconds or milliseconds are accepted.
+func jsonPathTime(doc interface{}, path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	v, err := JSONPath(doc, path)
+	if err != nil {
+		return time.Time{}
+	}
+	switch t := v.(type) {
+	case string:
+		if ts, err := time.Parse(time.RFC3339Nano, t); err == nil {
+			return ts
+		}
+	case float64:
+		if t > 1e12 {
+			return time.UnixMilli(int64(t))
+		}
+		return time.Unix(int64(t), 0)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,56 @@
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONPath(t *testing.T) {
+	var doc interface{}
+	raw := `{"health": {"state": "ok", "latency_ms": 42}, "items": [{"id": "d1"}, {"id": "d2"}], "weird key": true}`
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"$.health.state", "ok"},
+		{"health.state", "ok"},
+		{"$.health.latency_ms", "42"},
+		{"$.items[1].id", "d2"},
+		{"$.items[-1].id", "d2"},
+		{"$['weird key']", "true"},
+		{"$.missing", ""},
+		{"$.items[5].id", ""},
+	}
+	for _, tt := range tests {
+		if got := jsonPathString(doc, tt.path); got != tt.want {
+			t.Errorf("jsonPathString(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if got := jsonPathFloat(doc, "$.health.latency_ms", -1); got != 42 {
+		t.Errorf("jsonPathFloat = %v, want 42", got)
+	}
+	if got := jsonPathFloat(doc, "$.health.state", -1); got != -1 {
+		t.Errorf("jsonPathFloat on non-number = %v, want default -1", got)
+	}
+}
+
+func TestJSONPathTime(t *testing.T) {
+	var doc interface{}
+	raw := `{"iso": "2025-01-02T03:04:05Z", "sec": 1735787045, "ms": 1735787045000}`
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, path := range []string{"$.iso", "$.sec", "$.ms"} {
+		if got := jsonPathTime(doc, path); !got.Equal(want) {
+			t.Errorf("jsonPathTime(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	koyeb "github.com/koyeb/koyeb-api-client-go/api/v1/koyeb"
@@ -23,18 +26,19 @@ func init() {
 type Koyeb struct {
 	token  string
 	client *koyeb.APIClient
-	ctx    context.Context
+
+	poller
 }
 
 // NewKoyeb creates a new Koyeb platform instance.
 func NewKoyeb(token string) *Koyeb {
 	cfg := koyeb.NewConfiguration()
 	cfg.AddDefaultHeader("Authorization", "Bearer "+token)
+	cfg.HTTPClient = newHTTPClient(15 * time.Second)
 
 	return &Koyeb{
 		token:  token,
 		client: koyeb.NewAPIClient(cfg),
-		ctx:    context.Background(),
 	}
 }
 
@@ -42,22 +46,55 @@ func (k *Koyeb) Name() string {
 	return "koyeb"
 }
 
+func (k *Koyeb) Capabilities() Capabilities {
+	return CapDeployments | CapRedeploy | CapLogs | CapScale | CapWatch | CapDiscover | CapRollback | CapMetrics | CapCancel
+}
+
 // Validate checks whether the token is valid by listing services.
-func (k *Koyeb) Validate(token string) error {
+func (k *Koyeb) Validate(ctx context.Context, token string) error {
 	cfg := koyeb.NewConfiguration()
 	cfg.AddDefaultHeader("Authorization", "Bearer "+token)
+	cfg.HTTPClient = newHTTPClient(15 * time.Second)
 	client := koyeb.NewAPIClient(cfg)
 
-	_, resp, err := client.ServicesApi.ListServices(k.ctx).Limit("1").Execute()
+	_, resp, err := client.ServicesApi.ListServices(ctx).Limit("1").Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == 401 {
-			return fmt.Errorf("invalid token: unauthorized")
+			return fmt.Errorf("invalid token: %w", ErrUnauthorized)
 		}
 		return fmt.Errorf("koyeb API error: %w", err)
 	}
 	return nil
 }
 
+// ListTeams returns the organization the token belongs to. Koyeb tokens
+// are scoped to a single organization, so there is nothing to pass on
+// later calls; this only lets connect check the token against --team.
+func (k *Koyeb) ListTeams(ctx context.Context) ([]Team, error) {
+	reply, httpResp, err := k.client.ProfileApi.GetCurrentOrganization(ctx).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("get organization: %w", koyebError(httpResp, err))
+	}
+	org := reply.GetOrganization()
+	return []Team{{ID: org.GetId(), Slug: org.GetName(), Name: org.GetName()}}, nil
+}
+
+// koyebError wraps an SDK error with the error matching its HTTP status.
+func koyebError(resp *http.Response, err error) error {
+	if resp == nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case 401, 403:
+		return fmt.Errorf("%w (%w)", err, ErrUnauthorized)
+	case 404:
+		return fmt.Errorf("%w (%w)", err, ErrNotFound)
+	case 429:
+		return fmt.Errorf("%w (%w)", err, ErrRateLimited)
+	}
+	return err
+}
+
 // mapKoyebStatus converts a Koyeb service status to an Orbit status string.
 func mapKoyebStatus(status string) string {
 	switch status {
@@ -98,22 +135,28 @@ func mapKoyebDeployStatus(status string) string {
 	}
 }
 
-func (k *Koyeb) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
-	svc, resp, err := k.client.ServicesApi.GetService(k.ctx, serviceID).Execute()
+func (k *Koyeb) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	svc, resp, err := k.client.ServicesApi.GetService(ctx, serviceID).Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == 404 {
-			return nil, fmt.Errorf("service not found: %s", serviceID)
+			return nil, fmt.Errorf("service %w: %s", ErrNotFound, serviceID)
 		}
-		return nil, fmt.Errorf("get service: %w", err)
+		return nil, fmt.Errorf("get service: %w", koyebError(resp, err))
 	}
 
 	service := svc.GetService()
 	status := &ServiceStatus{
 		Status: mapKoyebStatus(string(service.GetStatus())),
+		CPU:    -1, // filled in by GetMetrics
+		Memory: -1,
+	}
+	if app, _, err := k.client.AppsApi.GetApp(ctx, service.GetAppId()).Execute(); err == nil {
+		a := app.GetApp()
+		status.URL = koyebAppURL(a.GetDomains())
 	}
 
 	// Get latest deployment for additional context
-	deploys, _, err := k.client.DeploymentsApi.ListDeployments(k.ctx).
+	deploys, _, err := k.client.DeploymentsApi.ListDeployments(ctx).
 		ServiceId(serviceID).Limit("1").Execute()
 	if err == nil {
 		deployList := deploys.GetDeployments()
@@ -124,22 +167,18 @@ func (k *Koyeb) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
 				Status:    mapKoyebDeployStatus(string(d.GetStatus())),
 				CreatedAt: d.GetCreatedAt(),
 			}
-			def := d.GetDefinition()
-			if def.HasGit() {
-				git := def.GetGit()
-				status.LastDeploy.Commit = git.GetSha()
-			}
+			applyKoyebGit(status.LastDeploy, d.GetDefinition(), d.GetMetadata())
 		}
 	}
 
 	return status, nil
 }
 
-func (k *Koyeb) ListDeployments(serviceID string, limit int) ([]Deployment, error) {
-	reply, _, err := k.client.DeploymentsApi.ListDeployments(k.ctx).
+func (k *Koyeb) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	reply, httpResp, err := k.client.DeploymentsApi.ListDeployments(ctx).
 		ServiceId(serviceID).Limit(strconv.Itoa(limit)).Execute()
 	if err != nil {
-		return nil, fmt.Errorf("list deployments: %w", err)
+		return nil, fmt.Errorf("list deployments: %w", koyebError(httpResp, err))
 	}
 
 	var deployments []Deployment
@@ -149,21 +188,46 @@ func (k *Koyeb) ListDeployments(serviceID string, limit int) ([]Deployment, erro
 			Status:    mapKoyebDeployStatus(string(d.GetStatus())),
 			CreatedAt: d.GetCreatedAt(),
 		}
-		def := d.GetDefinition()
-		if def.HasGit() {
-			git := def.GetGit()
-			dep.Commit = git.GetSha()
-			dep.Message = git.GetRepository()
+		if succeeded, ok := d.GetSucceededAtOk(); ok && succeeded.After(dep.CreatedAt) {
+			dep.Duration = succeeded.Sub(dep.CreatedAt)
 		}
+		applyKoyebGit(&dep, d.GetDefinition(), d.GetMetadata())
 		deployments = append(deployments, dep)
 	}
 	return deployments, nil
 }
 
-func (k *Koyeb) GetDeployment(deployID string) (*Deployment, error) {
-	reply, _, err := k.client.DeploymentsApi.GetDeployment(k.ctx, deployID).Execute()
+// applyKoyebGit fills in the git details of a deployment. The definition
+// names the repository and branch; the trigger metadata, present when a push
+// started the deployment, adds the commit message and the pusher.
+func applyKoyebGit(dep *Deployment, def koyeb.DeploymentDefinition, md koyeb.DeploymentMetadata) {
+	if def.HasGit() {
+		git := def.GetGit()
+		dep.Commit = git.GetSha()
+		dep.Message = git.GetRepository()
+		dep.Branch = git.GetBranch()
+	}
+	trigger := md.GetTrigger()
+	if !trigger.HasGit() {
+		return
+	}
+	push := trigger.GetGit()
+	if sha := push.GetSha(); sha != "" {
+		dep.Commit = sha
+	}
+	if msg := push.GetMessage(); msg != "" {
+		dep.Message = msg
+	}
+	if branch := push.GetBranch(); branch != "" {
+		dep.Branch = branch
+	}
+	dep.Author = push.GetSenderUsername()
+}
+
+func (k *Koyeb) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	reply, httpResp, err := k.client.DeploymentsApi.GetDeployment(ctx, deployID).Execute()
 	if err != nil {
-		return nil, fmt.Errorf("get deployment: %w", err)
+		return nil, fmt.Errorf("get deployment: %w", koyebError(httpResp, err))
 	}
 
 	d := reply.GetDeployment()
@@ -172,14 +236,62 @@ func (k *Koyeb) GetDeployment(deployID string) (*Deployment, error) {
 		Status:    mapKoyebDeployStatus(string(d.GetStatus())),
 		CreatedAt: d.GetCreatedAt(),
 	}
+	applyKoyebGit(dep, d.GetDefinition(), d.GetMetadata())
 	return dep, nil
 }
 
-func (k *Koyeb) Redeploy(serviceID string) (*Deployment, error) {
-	reply, _, err := k.client.ServicesApi.ReDeploy(k.ctx, serviceID).
+// DeploymentConfig returns the environment, scaling and instance type a
+// deployment's definition asked for.
+func (k *Koyeb) DeploymentConfig(ctx context.Context, deployID string) (*DeploymentConfig, error) {
+	reply, httpResp, err := k.client.DeploymentsApi.GetDeployment(ctx, deployID).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("get deployment: %w", koyebError(httpResp, err))
+	}
+
+	d := reply.GetDeployment()
+	def := d.GetDefinition()
+	dc := &DeploymentConfig{}
+	for _, e := range def.GetEnv() {
+		dc.Env = append(dc.Env, EnvVar{Key: e.GetKey(), Value: e.GetValue(), SecretRef: e.GetSecret()})
+	}
+	if scalings := def.GetScalings(); len(scalings) > 0 {
+		dc.MinScale = int(scalings[0].GetMin())
+		dc.MaxScale = int(scalings[0].GetMax())
+	}
+	if instanceTypes := def.GetInstanceTypes(); len(instanceTypes) > 0 {
+		dc.InstanceType = instanceTypes[0].GetType()
+	}
+	return dc, nil
+}
+
+// ArtifactSize returns the size of the image a deployment runs, read from
+// the image's registry. Images the registry won't serve anonymously can't be
+// sized and return an error.
+func (k *Koyeb) ArtifactSize(ctx context.Context, deployID string) (int64, error) {
+	reply, httpResp, err := k.client.DeploymentsApi.GetDeployment(ctx, deployID).Execute()
+	if err != nil {
+		return 0, fmt.Errorf("get deployment: %w", koyebError(httpResp, err))
+	}
+
+	d := reply.GetDeployment()
+	info := d.GetProvisioningInfo()
+	image := info.GetImage()
+	if image == "" {
+		def := d.GetDefinition()
+		docker := def.GetDocker()
+		image = docker.GetImage()
+	}
+	if image == "" {
+		return 0, nil
+	}
+	return imageSize(ctx, newHTTPClient(30*time.Second), image)
+}
+
+func (k *Koyeb) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	reply, httpResp, err := k.client.ServicesApi.ReDeploy(ctx, serviceID).
 		Info(*koyeb.NewRedeployRequestInfo()).Execute()
 	if err != nil {
-		return nil, fmt.Errorf("redeploy: %w", err)
+		return nil, fmt.Errorf("redeploy: %w", koyebError(httpResp, err))
 	}
 
 	d := reply.GetDeployment()
@@ -190,25 +302,119 @@ func (k *Koyeb) Redeploy(serviceID string) (*Deployment, error) {
 	}, nil
 }
 
-func (k *Koyeb) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
+// RollbackTo redeploys the service with the definition of a previous
+// deployment: the same commit (or image), env, instance type and scaling.
+func (k *Koyeb) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	reply, httpResp, err := k.client.DeploymentsApi.GetDeployment(ctx, deployID).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("get deployment: %w", koyebError(httpResp, err))
+	}
+	target := reply.GetDeployment()
+	if target.GetServiceId() != serviceID {
+		return nil, fmt.Errorf("deployment %s does not belong to service %s", deployID, serviceID)
+	}
+
+	updateReq := koyeb.NewUpdateService()
+	updateReq.SetDefinition(target.GetDefinition())
+
+	svcReply, httpResp, err := k.client.ServicesApi.UpdateService(ctx, serviceID).Service(*updateReq).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("update service: %w", koyebError(httpResp, err))
+	}
+
+	svc := svcReply.GetService()
+	dep := &Deployment{
+		ID:        svc.GetLatestDeploymentId(),
+		Status:    "building",
+		CreatedAt: time.Now(),
+	}
+	if def := target.GetDefinition(); def.HasGit() {
+		git := def.GetGit()
+		dep.Commit = git.GetSha()
+	}
+	return dep, nil
+}
+
+// CancelDeployment cancels a deployment that has not finished yet.
+func (k *Koyeb) CancelDeployment(ctx context.Context, deployID string) error {
+	_, httpResp, err := k.client.DeploymentsApi.CancelDeployment(ctx, deployID).Execute()
+	if err != nil {
+		return fmt.Errorf("cancel deployment: %w", koyebError(httpResp, err))
+	}
+	return nil
+}
+
+// Pause stops every instance of the service; its configuration and
+// domains are kept.
+func (k *Koyeb) Pause(ctx context.Context, serviceID string) error {
+	_, httpResp, err := k.client.ServicesApi.PauseService(ctx, serviceID).Execute()
+	if err != nil {
+		return fmt.Errorf("pause service: %w", koyebError(httpResp, err))
+	}
+	return nil
+}
+
+// Resume starts a paused service again from its last deployment, without
+// rebuilding.
+func (k *Koyeb) Resume(ctx context.Context, serviceID string) error {
+	_, httpResp, err := k.client.ServicesApi.ResumeService(ctx, serviceID).SkipBuild(true).Execute()
+	if err != nil {
+		return fmt.Errorf("resume service: %w", koyebError(httpResp, err))
+	}
+	return nil
+}
+
+func (k *Koyeb) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
 	limit := 100
 	if opts.Tail > 0 {
 		limit = opts.Tail
 	}
 
-	url := fmt.Sprintf("%s/v1/streams/logs/query?type=runtime&service_id=%s&limit=%d&order=asc", koyebBaseURL, serviceID, limit)
+	url := fmt.Sprintf("%s/v1/streams/logs/query?type=%s&service_id=%s&limit=%d&order=asc", koyebBaseURL, opts.logType(), serviceID, limit)
 	if opts.Since > 0 {
 		start := time.Now().UTC().Add(-opts.Since).Format(time.RFC3339)
 		url += "&start=" + start
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	all, err := k.queryLogs(ctx, url, opts.logType())
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LogEntry
+	for _, e := range all {
+		if MatchesLevel(e.Level, opts.Level) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// DeploymentLogs returns the last tail build log lines of a deployment.
+func (k *Koyeb) DeploymentLogs(ctx context.Context, deployID string, tail int) ([]LogEntry, error) {
+	if tail <= 0 {
+		tail = 100
+	}
+	// Query newest-first so the limit keeps the tail, then restore order.
+	url := fmt.Sprintf("%s/v1/streams/logs/query?type=build&deployment_id=%s&limit=%d&order=desc", koyebBaseURL, deployID, tail)
+	entries, err := k.queryLogs(ctx, url, "build")
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// queryLogs runs a log query and converts the result to log entries tagged
+// with source.
+func (k *Koyeb) queryLogs(ctx context.Context, url, source string) ([]LogEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+k.token)
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := newHTTPClient(10 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("koyeb logs API error: %w", err)
@@ -216,20 +422,14 @@ func (k *Koyeb) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
-		return nil, fmt.Errorf("invalid token: unauthorized")
+		return nil, fmt.Errorf("invalid token: %w", ErrUnauthorized)
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("koyeb logs API returned status %d", resp.StatusCode)
+		return nil, statusError("koyeb logs", resp.StatusCode)
 	}
 
 	var result struct {
-		Data []struct {
-			Msg       string `json:"msg"`
-			CreatedAt string `json:"created_at"`
-			Labels    struct {
-				Stream string `json:"stream"`
-			} `json:"labels"`
-		} `json:"data"`
+		Data []koyebLogLine `json:"data"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("decode logs response: %w", err)
@@ -237,47 +437,90 @@ func (k *Koyeb) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
 
 	var entries []LogEntry
 	for _, item := range result.Data {
-		if item.Msg == "" {
-			continue
+		if e, ok := item.entry(source); ok {
+			entries = append(entries, e)
 		}
+	}
 
-		level := "info"
-		if item.Labels.Stream == "stderr" {
-			level = "error"
-		}
-		if opts.Level != "" && level != opts.Level {
-			continue
-		}
+	return entries, nil
+}
 
-		ts, _ := time.Parse(time.RFC3339Nano, item.CreatedAt)
-		entries = append(entries, LogEntry{
-			Timestamp: ts,
-			Level:     level,
-			Message:   item.Msg,
-			Source:    "runtime",
-		})
+// StreamLogs tails the service's runtime or build logs.
+func (k *Koyeb) StreamLogs(ctx context.Context, serviceID string, opts LogOptions) (<-chan LogEntry, error) {
+	logType := opts.logType()
+	q := url.Values{"type": {logType}, "service_id": {serviceID}}
+	if opts.Tail > 0 {
+		q.Set("limit", strconv.Itoa(opts.Tail))
+	}
+	if opts.Since > 0 {
+		q.Set("start", time.Now().UTC().Add(-opts.Since).Format(time.RFC3339))
 	}
+	return k.tailLogs(ctx, q, logType)
+}
 
-	return entries, nil
+// StreamBuildLogs tails the build output of one deployment.
+func (k *Koyeb) StreamBuildLogs(ctx context.Context, serviceID, deployID string) (<-chan LogEntry, error) {
+	return k.tailLogs(ctx, url.Values{"type": {LogTypeBuild}, "deployment_id": {deployID}}, LogTypeBuild)
 }
 
-func (k *Koyeb) Scale(serviceID string, opts ScaleOptions) error {
-	// Get current service definition to preserve existing settings
-	svc, _, err := k.client.ServicesApi.GetService(k.ctx, serviceID).Execute()
+// tailLogs opens Koyeb's tail endpoint, a server stream that yields one
+// {"result": ...} JSON object per line, with the query q.
+func (k *Koyeb) tailLogs(ctx context.Context, q url.Values, logType string) (<-chan LogEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", koyebBaseURL+"/v1/streams/logs/tail?"+q.Encode(), nil)
 	if err != nil {
-		return fmt.Errorf("get service: %w", err)
+		return nil, fmt.Errorf("create request: %w", err)
 	}
+	req.Header.Set("Authorization", "Bearer "+k.token)
 
-	service := svc.GetService()
-	latestDeployID := service.GetLatestDeploymentId()
+	return streamLines(req, "koyeb", func(line []byte) (LogEntry, bool) {
+		var msg struct {
+			Result *koyebLogLine `json:"result"`
+		}
+		if json.Unmarshal(line, &msg) != nil || msg.Result == nil {
+			return LogEntry{}, false
+		}
+		return msg.Result.entry(logType)
+	})
+}
+
+// koyebLogLine is a log line as returned by the logs query and tail APIs.
+type koyebLogLine struct {
+	Msg       string `json:"msg"`
+	CreatedAt string `json:"created_at"`
+	Labels    struct {
+		Stream string `json:"stream"`
+	} `json:"labels"`
+}
 
-	// Get the current deployment definition
-	deployReply, _, err := k.client.DeploymentsApi.GetDeployment(k.ctx, latestDeployID).Execute()
+// entry converts the line to a log entry tagged with source. ok is false for
+// empty lines.
+func (l koyebLogLine) entry(source string) (LogEntry, bool) {
+	if l.Msg == "" {
+		return LogEntry{}, false
+	}
+
+	// Koyeb only reports the stream; infer the level from the message.
+	level := "info"
+	if l.Labels.Stream == "stderr" {
+		level = "error"
+	}
+	level = InferLevel(l.Msg, level)
+
+	ts, _ := time.Parse(time.RFC3339Nano, l.CreatedAt)
+	return LogEntry{
+		Timestamp: ts,
+		Level:     level,
+		Message:   l.Msg,
+		Source:    source,
+	}, true
+}
+
+func (k *Koyeb) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	// Get current service definition to preserve existing settings
+	currentDef, err := k.currentDefinition(ctx, serviceID)
 	if err != nil {
-		return fmt.Errorf("get deployment: %w", err)
+		return err
 	}
-	deploy := deployReply.GetDeployment()
-	currentDef := deploy.GetDefinition()
 
 	// Build updated definition
 	def := koyeb.NewDeploymentDefinition()
@@ -329,30 +572,281 @@ func (k *Koyeb) Scale(serviceID string, opts ScaleOptions) error {
 	def.SetRoutes(currentDef.GetRoutes())
 	def.SetRegions(currentDef.GetRegions())
 
+	return k.updateDefinition(ctx, serviceID, def)
+}
+
+// currentDefinition returns the definition of the service's latest deployment.
+func (k *Koyeb) currentDefinition(ctx context.Context, serviceID string) (*koyeb.DeploymentDefinition, error) {
+	svc, httpResp, err := k.client.ServicesApi.GetService(ctx, serviceID).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("get service: %w", koyebError(httpResp, err))
+	}
+
+	service := svc.GetService()
+	deployReply, httpResp, err := k.client.DeploymentsApi.GetDeployment(ctx, service.GetLatestDeploymentId()).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("get deployment: %w", koyebError(httpResp, err))
+	}
+	deploy := deployReply.GetDeployment()
+	def := deploy.GetDefinition()
+	return &def, nil
+}
+
+// GetEnvVars returns the environment of the service's latest deployment.
+func (k *Koyeb) GetEnvVars(ctx context.Context, serviceID string) ([]EnvVar, error) {
+	def, err := k.currentDefinition(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	var vars []EnvVar
+	for _, e := range def.GetEnv() {
+		vars = append(vars, EnvVar{
+			Key:       e.GetKey(),
+			Value:     e.GetValue(),
+			SecretRef: e.GetSecret(),
+		})
+	}
+	return vars, nil
+}
+
+// SetEnvVars updates the service definition, which redeploys the service.
+// A variable that referenced a secret is replaced by the plain value.
+func (k *Koyeb) SetEnvVars(ctx context.Context, serviceID string, vars map[string]string) error {
+	def, err := k.currentDefinition(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	env := def.GetEnv()
+	for _, key := range sortedKeys(vars) {
+		value := vars[key]
+		found := false
+		for i := range env {
+			if env[i].GetKey() == key {
+				env[i].SetValue(value)
+				env[i].Secret = nil
+				found = true
+			}
+		}
+		if !found {
+			e := koyeb.NewDeploymentEnv()
+			e.SetKey(key)
+			e.SetValue(value)
+			env = append(env, *e)
+		}
+	}
+	def.SetEnv(env)
+	return k.updateDefinition(ctx, serviceID, def)
+}
+
+// DeleteEnvVars removes keys from the service definition in a single update,
+// which redeploys the service once.
+func (k *Koyeb) DeleteEnvVars(ctx context.Context, serviceID string, keys []string) error {
+	def, err := k.currentDefinition(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	env := def.GetEnv()
+	kept := make([]koyeb.DeploymentEnv, 0, len(env))
+	found := make(map[string]bool)
+	for _, e := range env {
+		if slices.Contains(keys, e.GetKey()) {
+			found[e.GetKey()] = true
+			continue
+		}
+		kept = append(kept, e)
+	}
+	for _, key := range keys {
+		if !found[key] {
+			return fmt.Errorf("variable %s: %w", key, ErrNotFound)
+		}
+	}
+	def.SetEnv(kept)
+	return k.updateDefinition(ctx, serviceID, def)
+}
+
+func (k *Koyeb) updateDefinition(ctx context.Context, serviceID string, def *koyeb.DeploymentDefinition) error {
 	updateReq := koyeb.NewUpdateService()
 	updateReq.SetDefinition(*def)
+	_, httpResp, err := k.client.ServicesApi.UpdateService(ctx, serviceID).Service(*updateReq).Execute()
+	if err != nil {
+		return fmt.Errorf("update service: %w", koyebError(httpResp, err))
+	}
+	return nil
+}
 
-	_, _, err = k.client.ServicesApi.UpdateService(k.ctx, serviceID).Service(*updateReq).Execute()
+// ListDomains returns the domains of the service's app. Koyeb routes a
+// domain to the services of its app by path, so each domain is noted with
+// the paths this service serves.
+func (k *Koyeb) ListDomains(ctx context.Context, serviceID string) ([]Domain, error) {
+	svc, httpResp, err := k.client.ServicesApi.GetService(ctx, serviceID).Execute()
 	if err != nil {
-		return fmt.Errorf("update service: %w", err)
+		return nil, fmt.Errorf("get service: %w", koyebError(httpResp, err))
 	}
+	service := svc.GetService()
 
-	return nil
+	def, err := k.currentDefinition(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, r := range def.GetRoutes() {
+		paths = append(paths, r.GetPath())
+	}
+
+	reply, httpResp, err := k.client.DomainsApi.ListDomains(ctx).AppIds([]string{service.GetAppId()}).Limit("100").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("list domains: %w", koyebError(httpResp, err))
+	}
+
+	var domains []Domain
+	for _, d := range reply.GetDomains() {
+		domain := Domain{
+			Name:     d.GetName(),
+			Custom:   d.GetType() == koyeb.DOMAINTYPE_CUSTOM,
+			Verified: d.HasVerifiedAt() || d.GetType() == koyeb.DOMAINTYPE_AUTOASSIGNED,
+		}
+		switch d.GetStatus() {
+		case koyeb.DOMAINSTATUS_ACTIVE:
+			domain.SSL = "active"
+		case koyeb.DOMAINSTATUS_PENDING:
+			domain.SSL = "pending"
+		case koyeb.DOMAINSTATUS_ERROR:
+			domain.SSL = "error"
+		default:
+			continue // being deleted
+		}
+		if len(paths) > 0 {
+			domain.Note = "path " + strings.Join(paths, ", ")
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// koyebLiveInstances are the instance statuses of replicas that are part of
+// the running service; stopped and errored instances of earlier
+// deployments stay listed by the API for a while.
+var koyebLiveInstances = []string{"ALLOCATING", "STARTING", "HEALTHY", "UNHEALTHY", "SLEEPING", "STOPPING"}
+
+// ListInstances returns the service's live instances across all regions.
+func (k *Koyeb) ListInstances(ctx context.Context, serviceID string) ([]Instance, error) {
+	reply, httpResp, err := k.client.InstancesApi.ListInstances(ctx).
+		ServiceId(serviceID).Statuses(koyebLiveInstances).Limit("100").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("list instances: %w", koyebError(httpResp, err))
+	}
+
+	var instances []Instance
+	for _, in := range reply.GetInstances() {
+		inst := Instance{
+			ID:        in.GetId(),
+			State:     mapKoyebInstanceStatus(string(in.GetStatus())),
+			Region:    in.GetRegion(),
+			StartedAt: in.GetCreatedAt(),
+		}
+		if msgs := in.GetMessages(); len(msgs) > 0 {
+			inst.Message = msgs[len(msgs)-1]
+		}
+		instances = append(instances, inst)
+	}
+	return instances, nil
+}
+
+// mapKoyebInstanceStatus converts a Koyeb instance status to an instance state.
+func mapKoyebInstanceStatus(status string) string {
+	switch status {
+	case "HEALTHY":
+		return "healthy"
+	case "ALLOCATING", "STARTING":
+		return "starting"
+	case "UNHEALTHY", "ERROR":
+		return "unhealthy"
+	case "SLEEPING":
+		return "sleeping"
+	case "STOPPING", "STOPPED":
+		return "stopped"
+	default:
+		return strings.ToLower(status)
+	}
+}
+
+// koyebMemoryMB is the memory limit of each Koyeb instance type, used to
+// turn MEM_RSS bytes into a percentage.
+var koyebMemoryMB = map[string]float64{
+	"free": 512, "nano": 256, "micro": 512, "small": 1024, "medium": 2048,
+	"large": 4096, "xlarge": 8192, "2xlarge": 16384,
+	"eco-nano": 256, "eco-micro": 512, "eco-small": 1024, "eco-medium": 2048,
+	"eco-large": 4096, "eco-xlarge": 8192, "eco-2xlarge": 16384,
+}
+
+// GetMetrics reads CPU, memory and median response time from the metrics
+// API, averaging the latest sample of each instance.
+func (k *Koyeb) GetMetrics(ctx context.Context, serviceID string) (*Metrics, error) {
+	m := &Metrics{CPU: -1, Memory: -1}
+
+	cpu, err := k.queryMetric(ctx, serviceID, koyeb.METRICNAME_CPU_TOTAL_PERCENT)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := latestMean(cpu); ok {
+		m.CPU = v
+	}
+
+	if p50, err := k.queryMetric(ctx, serviceID, koyeb.METRICNAME_HTTP_RESPONSE_TIME_50_P); err == nil {
+		if v, ok := latestMean(p50); ok {
+			m.ResponseMs = int(v)
+		}
+	}
+
+	if rss, err := k.queryMetric(ctx, serviceID, koyeb.METRICNAME_MEM_RSS); err == nil {
+		if v, ok := latestMean(rss); ok {
+			_, _, instanceType, err := k.GetCurrentScale(ctx, serviceID)
+			if limit := koyebMemoryMB[instanceType]; err == nil && limit > 0 {
+				m.Memory = v / (limit * 1024 * 1024) * 100
+			}
+		}
+	}
+	return m, nil
+}
+
+// queryMetric returns the recent samples of one metric, one series per instance.
+func (k *Koyeb) queryMetric(ctx context.Context, serviceID string, name koyeb.MetricName) ([][]metricSample, error) {
+	end := time.Now().UTC()
+	reply, httpResp, err := k.client.MetricsApi.GetMetrics(ctx).
+		ServiceId(serviceID).Name(string(name)).
+		Start(end.Add(-metricsWindow)).End(end).Step("1m").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("get %s metrics: %w", name, koyebError(httpResp, err))
+	}
+
+	var series [][]metricSample
+	for _, metric := range reply.GetMetrics() {
+		var samples []metricSample
+		for _, s := range metric.GetSamples() {
+			at, err := time.Parse(time.RFC3339, s.GetTimestamp())
+			if err != nil || s.Value == nil {
+				continue
+			}
+			samples = append(samples, metricSample{At: at, Value: s.GetValue()})
+		}
+		series = append(series, samples)
+	}
+	return series, nil
 }
 
 // GetCurrentScale retrieves the current scaling configuration for a Koyeb service.
-func (k *Koyeb) GetCurrentScale(serviceID string) (min, max int, instanceType string, err error) {
-	svc, _, err := k.client.ServicesApi.GetService(k.ctx, serviceID).Execute()
+func (k *Koyeb) GetCurrentScale(ctx context.Context, serviceID string) (min, max int, instanceType string, err error) {
+	svc, httpResp, err := k.client.ServicesApi.GetService(ctx, serviceID).Execute()
 	if err != nil {
-		return 0, 0, "", fmt.Errorf("get service: %w", err)
+		return 0, 0, "", fmt.Errorf("get service: %w", koyebError(httpResp, err))
 	}
 
 	service := svc.GetService()
 	latestDeployID := service.GetLatestDeploymentId()
 
-	deployReply, _, err := k.client.DeploymentsApi.GetDeployment(k.ctx, latestDeployID).Execute()
+	deployReply, httpResp, err := k.client.DeploymentsApi.GetDeployment(ctx, latestDeployID).Execute()
 	if err != nil {
-		return 0, 0, "", fmt.Errorf("get deployment: %w", err)
+		return 0, 0, "", fmt.Errorf("get deployment: %w", koyebError(httpResp, err))
 	}
 	deploy := deployReply.GetDeployment()
 	def := deploy.GetDefinition()
@@ -371,10 +865,19 @@ func (k *Koyeb) GetCurrentScale(serviceID string) (min, max int, instanceType st
 	return min, max, instanceType, nil
 }
 
-func (k *Koyeb) DiscoverServices() ([]DiscoveredService, error) {
-	reply, _, err := k.client.ServicesApi.ListServices(k.ctx).Limit("100").Execute()
+func (k *Koyeb) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	reply, httpResp, err := k.client.ServicesApi.ListServices(ctx).Limit("100").Execute()
 	if err != nil {
-		return nil, fmt.Errorf("list services: %w", err)
+		return nil, fmt.Errorf("list services: %w", koyebError(httpResp, err))
+	}
+
+	// App domains give each service its URL; without them services are
+	// still listed.
+	appURLs := make(map[string]string)
+	if apps, _, err := k.client.AppsApi.ListApps(ctx).Limit("100").Execute(); err == nil {
+		for _, a := range apps.GetApps() {
+			appURLs[a.GetId()] = koyebAppURL(a.GetDomains())
+		}
 	}
 
 	var services []DiscoveredService
@@ -383,75 +886,94 @@ func (k *Koyeb) DiscoverServices() ([]DiscoveredService, error) {
 			ID:       s.GetId(),
 			Name:     s.GetName(),
 			Platform: "koyeb",
+			URL:      appURLs[s.GetAppId()],
 		})
 	}
 	return services, nil
 }
 
-func (k *Koyeb) WatchDeployment(serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+// koyebAppURL returns the URL of an app's auto-assigned koyeb.app domain.
+func koyebAppURL(domains []koyeb.Domain) string {
+	for _, d := range domains {
+		if d.GetType() == koyeb.DOMAINTYPE_AUTOASSIGNED {
+			return "https://" + d.GetName()
+		}
+	}
+	return ""
+}
+
+func (k *Koyeb) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
 	ch := make(chan DeployEvent)
 
 	go func() {
 		defer close(ch)
 
-		const pollInterval = 3 * time.Second
+		pollInterval := k.interval()
 
 		// Check if the latest deployment is already in-progress.
 		// This handles the race where git push triggers a deployment before watch starts,
 		// so currentDeployID already points to the new (building) deployment.
-		deploys, err := k.ListDeployments(serviceID, 1)
+		deploys, err := k.ListDeployments(ctx, serviceID, 1)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
 			return
 		}
-		if len(deploys) > 0 && isInProgress(deploys[0].Status) {
+		if len(deploys) > 0 && IsInProgress(deploys[0].Status) {
 			d := deploys[0]
-			ch <- DeployEvent{
+			if !sendEvent(ctx, ch, DeployEvent{
 				Phase:   "detected",
 				Message: fmt.Sprintf("In-progress deployment found (%s)", d.ID),
 				Deploy:  &d,
+			}) {
+				return
 			}
-			k.trackDeployment(ch, d.ID)
+			k.trackDeployment(ctx, ch, d.ID)
 			return
 		}
 
 		// Phase 1: Detect a new deployment
 		for {
-			deploys, err := k.ListDeployments(serviceID, 1)
+			deploys, err := k.ListDeployments(ctx, serviceID, 1)
 			if err != nil {
-				ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)}
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
 				return
 			}
 
 			if len(deploys) > 0 {
 				d := deploys[0]
 				if d.ID != currentDeployID {
-					ch <- DeployEvent{
+					if !sendEvent(ctx, ch, DeployEvent{
 						Phase:   "detected",
 						Message: fmt.Sprintf("New deployment detected! (%s)", d.ID),
 						Deploy:  &d,
+					}) {
+						return
 					}
-					k.trackDeployment(ch, d.ID)
+					k.trackDeployment(ctx, ch, d.ID)
 					return
 				}
 			}
 
-			ch <- DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}
-			time.Sleep(pollInterval)
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
 		}
 	}()
 
 	return ch, nil
 }
 
-func (k *Koyeb) trackDeployment(ch chan<- DeployEvent, deployID string) {
-	const pollInterval = 3 * time.Second
+func (k *Koyeb) trackDeployment(ctx context.Context, ch chan<- DeployEvent, deployID string) {
+	pollInterval := k.interval()
 	lastPhase := ""
 
 	for {
-		deploy, err := k.GetDeployment(deployID)
+		deploy, err := k.GetDeployment(ctx, deployID)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)})
 			return
 		}
 
@@ -469,22 +991,26 @@ func (k *Koyeb) trackDeployment(ch chan<- DeployEvent, deployID string) {
 				event.Message = "Health check..."
 			case "done":
 				event.Message = "Deploy successful!"
-				ch <- event
+				sendEvent(ctx, ch, event)
 				return
 			case "failed":
 				event.Message = "Deployment failed!"
 				event.Error = fmt.Errorf("deployment %s failed", deployID)
 				// Try to get error logs from runtime
-				if logs, err := k.getDeploymentErrors(deployID); err == nil {
+				if logs, err := k.getDeploymentErrors(ctx, deployID); err == nil {
 					event.Logs = logs
 				}
-				ch <- event
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
 				return
 			}
-			ch <- event
 		}
 
-		time.Sleep(pollInterval)
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
 	}
 }
 
@@ -509,15 +1035,15 @@ func mapKoyebToWatchPhase(status string) string {
 	}
 }
 
-func (k *Koyeb) getDeploymentErrors(deployID string) ([]string, error) {
+func (k *Koyeb) getDeploymentErrors(ctx context.Context, deployID string) ([]string, error) {
 	url := fmt.Sprintf("%s/v1/streams/logs/query?type=runtime&deployment_id=%s&limit=20&order=desc", koyebBaseURL, deployID)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+k.token)
 
-	client := &http.Client{Timeout: 5 * time.Second}
+	client := newHTTPClient(5 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -548,4 +1074,3 @@ func (k *Koyeb) getDeploymentErrors(deployID string) ([]string, error) {
 	}
 	return errLogs, nil
 }
-
@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testKubeconfig = `
+current-context: prod
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com:6443/
+    insecure-skip-tls-verify: true
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com
+contexts:
+- name: prod
+  context: {cluster: prod-cluster, user: deployer, namespace: web}
+- name: dev
+  context: {cluster: dev-cluster, user: sso}
+users:
+- name: deployer
+  user:
+    token: abc123
+- name: sso
+  user:
+    exec:
+      command: aws
+`
+
+func TestLoadKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cl, err := loadKubeconfig(path, "")
+	if err != nil {
+		t.Fatalf("current context: %v", err)
+	}
+	if cl.server != "https://prod.example.com:6443" || cl.token != "abc123" || cl.namespace != "web" {
+		t.Errorf("got server=%q token=%q namespace=%q", cl.server, cl.token, cl.namespace)
+	}
+	if !cl.tls.InsecureSkipVerify {
+		t.Error("expected insecure-skip-tls-verify to be applied")
+	}
+
+	if _, err := loadKubeconfig(path, "dev"); err == nil || !strings.Contains(err.Error(), "exec") {
+		t.Errorf("exec user: err = %v, want exec plugin error", err)
+	}
+	if _, err := loadKubeconfig(path, "staging"); err == nil {
+		t.Error("expected error for unknown context")
+	}
+}
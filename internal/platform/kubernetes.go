@@ -0,0 +1,799 @@
+package platform
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	Register("kubernetes", func(token string) Platform {
+		return NewKubernetes(token)
+	})
+}
+
+// EndpointConfigurable is implemented by platforms whose API server address
+// is configured per connection (e.g. a self-hosted Kubernetes cluster).
+type EndpointConfigurable interface {
+	SetEndpoint(endpoint string)
+}
+
+// Kubernetes implements the Platform interface against the Kubernetes API.
+// Services are Deployments identified as "namespace/name"; Orbit deployments
+// are the Deployment's ReplicaSets (one per rollout revision).
+//
+// The token is either a bearer token (used with SetEndpoint) or
+// "kubeconfig[:context]" to read the cluster and credentials from
+// $KUBECONFIG or ~/.kube/config.
+type Kubernetes struct {
+	token    string
+	endpoint string
+
+	once       sync.Once
+	cluster    *kubeCluster
+	clusterErr error
+	httpClient *http.Client
+
+	poller
+}
+
+// NewKubernetes creates a new Kubernetes platform instance.
+func NewKubernetes(token string) *Kubernetes {
+	return &Kubernetes{token: token}
+}
+
+func (k *Kubernetes) Name() string {
+	return "kubernetes"
+}
+
+func (k *Kubernetes) Capabilities() Capabilities {
+	return CapDeployments | CapRedeploy | CapLogs | CapScale | CapWatch | CapDiscover | CapInstances
+}
+
+func (k *Kubernetes) SetEndpoint(endpoint string) {
+	k.endpoint = strings.TrimSuffix(endpoint, "/")
+}
+
+// resolveCluster works out the API server and credentials for a token.
+func (k *Kubernetes) resolveCluster(token string) (*kubeCluster, error) {
+	if token == "kubeconfig" || strings.HasPrefix(token, "kubeconfig:") {
+		path, err := kubeconfigPath()
+		if err != nil {
+			return nil, err
+		}
+		cl, err := loadKubeconfig(path, strings.TrimPrefix(strings.TrimPrefix(token, "kubeconfig"), ":"))
+		if err != nil {
+			return nil, err
+		}
+		if k.endpoint != "" {
+			cl.server = k.endpoint
+		}
+		return cl, nil
+	}
+
+	if k.endpoint == "" {
+		return nil, fmt.Errorf("no API server endpoint configured\nRun: orbit connect kubernetes --token <token> --endpoint https://<api-server>")
+	}
+	return &kubeCluster{server: k.endpoint, token: token, tls: &tls.Config{}}, nil
+}
+
+func newKubeHTTPClient(cl *kubeCluster) *http.Client {
+	return &http.Client{
+		Timeout:   15 * time.Second,
+		Transport: withRetry(&http.Transport{TLSClientConfig: cl.tls, Proxy: http.ProxyFromEnvironment}),
+	}
+}
+
+func (k *Kubernetes) ensureCluster() error {
+	k.once.Do(func() {
+		k.cluster, k.clusterErr = k.resolveCluster(k.token)
+		if k.clusterErr == nil {
+			k.httpClient = newKubeHTTPClient(k.cluster)
+		}
+	})
+	return k.clusterErr
+}
+
+func (k *Kubernetes) doRequest(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, error) {
+	if err := k.ensureCluster(); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, k.cluster.server+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if k.cluster.token != "" {
+		req.Header.Set("Authorization", "Bearer "+k.cluster.token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	return k.httpClient.Do(req)
+}
+
+// getJSON performs a GET and decodes a 200 response into out.
+func (k *Kubernetes) getJSON(ctx context.Context, path string, out interface{}) error {
+	resp, err := k.doRequest(ctx, "GET", path, "", nil)
+	if err != nil {
+		return fmt.Errorf("kubernetes API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode != 200 {
+		return statusError("kubernetes", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Validate checks whether the credentials can list Deployments.
+func (k *Kubernetes) Validate(ctx context.Context, token string) error {
+	cl, err := k.resolveCluster(token)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", cl.server+"/apis/apps/v1/deployments?limit=1", nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if cl.token != "" {
+		req.Header.Set("Authorization", "Bearer "+cl.token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := newKubeHTTPClient(cl).Do(req)
+	if err != nil {
+		return fmt.Errorf("kubernetes API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+	if resp.StatusCode != 200 {
+		return statusError("kubernetes", resp.StatusCode)
+	}
+	return nil
+}
+
+// splitKubeID splits "namespace/name"; a bare name uses the kubeconfig
+// context namespace, or "default".
+func (k *Kubernetes) splitKubeID(id string) (string, string) {
+	if ns, name, ok := strings.Cut(id, "/"); ok {
+		return ns, name
+	}
+	ns := "default"
+	if k.ensureCluster() == nil && k.cluster.namespace != "" {
+		ns = k.cluster.namespace
+	}
+	return ns, id
+}
+
+type kubeMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	UID               string            `json:"uid"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+	Annotations       map[string]string `json:"annotations"`
+	OwnerReferences   []struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	} `json:"ownerReferences"`
+}
+
+type kubePodTemplate struct {
+	Spec struct {
+		Containers []struct {
+			Name  string `json:"name"`
+			Image string `json:"image"`
+		} `json:"containers"`
+	} `json:"spec"`
+}
+
+type kubeDeployment struct {
+	Metadata kubeMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int `json:"replicas"`
+		Selector struct {
+			MatchLabels map[string]string `json:"matchLabels"`
+		} `json:"selector"`
+		Template kubePodTemplate `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		Replicas          int `json:"replicas"`
+		ReadyReplicas     int `json:"readyReplicas"`
+		UpdatedReplicas   int `json:"updatedReplicas"`
+		AvailableReplicas int `json:"availableReplicas"`
+		Conditions        []struct {
+			Type           string    `json:"type"`
+			Status         string    `json:"status"`
+			Reason         string    `json:"reason"`
+			LastUpdateTime time.Time `json:"lastUpdateTime"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+func (d *kubeDeployment) desiredReplicas() int {
+	if d.Spec.Replicas == nil {
+		return 1
+	}
+	return *d.Spec.Replicas
+}
+
+func (d *kubeDeployment) revision() string {
+	return d.Metadata.Annotations["deployment.kubernetes.io/revision"]
+}
+
+// rolloutState reports whether the current rollout has finished or failed,
+// based on the Progressing condition.
+func (d *kubeDeployment) rolloutState() (status string, finished time.Time) {
+	for _, c := range d.Status.Conditions {
+		if c.Type != "Progressing" {
+			continue
+		}
+		switch {
+		case c.Reason == "ProgressDeadlineExceeded":
+			return "failed", c.LastUpdateTime
+		case c.Reason == "NewReplicaSetAvailable" && d.Status.UpdatedReplicas >= d.desiredReplicas():
+			return "healthy", c.LastUpdateTime
+		}
+	}
+	if d.desiredReplicas() == 0 {
+		return "sleeping", time.Time{}
+	}
+	return "deploying", time.Time{}
+}
+
+func (d *kubeDeployment) labelSelector() string {
+	keys := make([]string, 0, len(d.Spec.Selector.MatchLabels))
+	for key := range d.Spec.Selector.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+d.Spec.Selector.MatchLabels[key])
+	}
+	return strings.Join(parts, ",")
+}
+
+type kubeReplicaSet struct {
+	Metadata kubeMeta `json:"metadata"`
+	Spec     struct {
+		Template kubePodTemplate `json:"template"`
+	} `json:"spec"`
+}
+
+func (rs *kubeReplicaSet) revision() int {
+	n, _ := strconv.Atoi(rs.Metadata.Annotations["deployment.kubernetes.io/revision"])
+	return n
+}
+
+func (rs *kubeReplicaSet) ownedBy(deployment string) bool {
+	for _, o := range rs.Metadata.OwnerReferences {
+		if o.Kind == "Deployment" && o.Name == deployment {
+			return true
+		}
+	}
+	return false
+}
+
+// toDeployment maps a ReplicaSet to an Orbit deployment. Only the current
+// revision reflects the rollout state; older revisions were superseded.
+func (rs *kubeReplicaSet) toDeployment(d *kubeDeployment) Deployment {
+	images := make([]string, 0, len(rs.Spec.Template.Spec.Containers))
+	for _, c := range rs.Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+
+	dep := Deployment{
+		ID:        rs.Metadata.Namespace + "/" + rs.Metadata.Name,
+		Status:    "healthy",
+		Message:   strings.Join(images, ", "),
+		CreatedAt: rs.Metadata.CreationTimestamp,
+	}
+	if strconv.Itoa(rs.revision()) == d.revision() {
+		status, finished := d.rolloutState()
+		dep.Status = status
+		if !finished.IsZero() && finished.After(dep.CreatedAt) {
+			dep.Duration = finished.Sub(dep.CreatedAt)
+		}
+	}
+	return dep
+}
+
+func (k *Kubernetes) getDeployment(ctx context.Context, ns, name string) (*kubeDeployment, error) {
+	var d kubeDeployment
+	path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", url.PathEscape(ns), url.PathEscape(name))
+	if err := k.getJSON(ctx, path, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// replicaSets returns the Deployment's ReplicaSets, newest revision first.
+func (k *Kubernetes) replicaSets(ctx context.Context, d *kubeDeployment) ([]kubeReplicaSet, error) {
+	var list struct {
+		Items []kubeReplicaSet `json:"items"`
+	}
+	path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/replicasets?labelSelector=%s",
+		url.PathEscape(d.Metadata.Namespace), url.QueryEscape(d.labelSelector()))
+	if err := k.getJSON(ctx, path, &list); err != nil {
+		return nil, err
+	}
+
+	owned := list.Items[:0]
+	for _, rs := range list.Items {
+		if rs.ownedBy(d.Metadata.Name) {
+			owned = append(owned, rs)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].revision() > owned[j].revision()
+	})
+	return owned, nil
+}
+
+func (k *Kubernetes) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	ns, name := k.splitKubeID(serviceID)
+	d, err := k.getDeployment(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := d.desiredReplicas()
+	ready := d.Status.ReadyReplicas
+
+	status := &ServiceStatus{
+		CPU:          -1,
+		Memory:       -1,
+		Instances:    ready,
+		MaxInstances: desired,
+		Name:         d.Metadata.Name,
+	}
+	switch {
+	case desired == 0:
+		status.Status = "sleeping"
+	case ready >= desired:
+		status.Status = "healthy"
+	case ready > 0:
+		status.Status = "degraded"
+	default:
+		status.Status = "unhealthy"
+	}
+
+	if sets, err := k.replicaSets(ctx, d); err == nil && len(sets) > 0 {
+		latest := sets[0].toDeployment(d)
+		status.LastDeploy = &latest
+	}
+
+	return status, nil
+}
+
+type kubePod struct {
+	Metadata kubeMeta `json:"metadata"`
+	Spec     struct {
+		NodeName string `json:"nodeName"`
+	} `json:"spec"`
+	Status struct {
+		Phase             string     `json:"phase"`
+		StartTime         *time.Time `json:"startTime"`
+		ContainerStatuses []struct {
+			Ready        bool `json:"ready"`
+			RestartCount int  `json:"restartCount"`
+			State        struct {
+				Waiting *struct {
+					Reason  string `json:"reason"`
+					Message string `json:"message"`
+				} `json:"waiting"`
+			} `json:"state"`
+		} `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// instance maps a pod to an instance. A running pod is healthy once all
+// of its containers are ready.
+func (p *kubePod) instance() Instance {
+	inst := Instance{ID: p.Metadata.Name, Region: p.Spec.NodeName}
+	if p.Status.StartTime != nil {
+		inst.StartedAt = *p.Status.StartTime
+	}
+
+	ready := len(p.Status.ContainerStatuses) > 0
+	for _, c := range p.Status.ContainerStatuses {
+		inst.Restarts += c.RestartCount
+		ready = ready && c.Ready
+		if w := c.State.Waiting; w != nil && inst.Message == "" {
+			inst.Message = w.Reason
+			if w.Message != "" {
+				inst.Message += ": " + w.Message
+			}
+		}
+	}
+
+	switch {
+	case p.Status.Phase == "Running" && ready:
+		inst.State = "healthy"
+	case p.Status.Phase == "Pending", p.Status.Phase == "Running" && inst.Message == "":
+		inst.State = "starting"
+	case p.Status.Phase == "Succeeded":
+		inst.State = "stopped"
+	default:
+		inst.State = "unhealthy"
+	}
+	return inst
+}
+
+// ListInstances returns the Deployment's pods. The region column holds the
+// node each pod is scheduled on.
+func (k *Kubernetes) ListInstances(ctx context.Context, serviceID string) ([]Instance, error) {
+	ns, name := k.splitKubeID(serviceID)
+	d, err := k.getDeployment(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods struct {
+		Items []kubePod `json:"items"`
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods?labelSelector=%s", url.PathEscape(ns), url.QueryEscape(d.labelSelector()))
+	if err := k.getJSON(ctx, path, &pods); err != nil {
+		return nil, err
+	}
+
+	instances := make([]Instance, 0, len(pods.Items))
+	for i := range pods.Items {
+		instances = append(instances, pods.Items[i].instance())
+	}
+	return instances, nil
+}
+
+func (k *Kubernetes) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	ns, name := k.splitKubeID(serviceID)
+	d, err := k.getDeployment(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := k.replicaSets(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	deploys := make([]Deployment, 0, len(sets))
+	for i := range sets {
+		if limit > 0 && len(deploys) >= limit {
+			break
+		}
+		deploys = append(deploys, sets[i].toDeployment(d))
+	}
+	return deploys, nil
+}
+
+// GetDeployment takes a "namespace/replicaset" ID.
+func (k *Kubernetes) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	ns, name := k.splitKubeID(deployID)
+
+	var rs kubeReplicaSet
+	path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/replicasets/%s", url.PathEscape(ns), url.PathEscape(name))
+	if err := k.getJSON(ctx, path, &rs); err != nil {
+		return nil, err
+	}
+
+	for _, o := range rs.Metadata.OwnerReferences {
+		if o.Kind != "Deployment" {
+			continue
+		}
+		d, err := k.getDeployment(ctx, ns, o.Name)
+		if err != nil {
+			return nil, err
+		}
+		dep := rs.toDeployment(d)
+		return &dep, nil
+	}
+	return nil, fmt.Errorf("replicaset %s is not owned by a deployment", deployID)
+}
+
+// Redeploy performs a rollout restart, which creates a new ReplicaSet
+// revision with the same spec.
+func (k *Kubernetes) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	ns, name := k.splitKubeID(serviceID)
+
+	patch, _ := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						"kubectl.kubernetes.io/restartedAt": time.Now().UTC().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+	path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", url.PathEscape(ns), url.PathEscape(name))
+	resp, err := k.doRequest(ctx, "PATCH", path, "application/strategic-merge-patch+json", patch)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", statusError("kubernetes", resp.StatusCode), strings.TrimSpace(string(body)))
+	}
+
+	// The controller creates the new ReplicaSet asynchronously.
+	return &Deployment{
+		ID:        serviceID,
+		Status:    "deploying",
+		Message:   "rollout restart",
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (k *Kubernetes) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: use 'kubectl rollout undo' to roll back a Deployment", ErrNotSupported)
+}
+
+func (k *Kubernetes) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: use 'kubectl rollout undo' to abort a rollout", ErrNotSupported)
+}
+
+// GetLogs reads container logs from every pod of the Deployment.
+func (k *Kubernetes) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	ns, name := k.splitKubeID(serviceID)
+	d, err := k.getDeployment(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods struct {
+		Items []struct {
+			Metadata kubeMeta `json:"metadata"`
+		} `json:"items"`
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods?labelSelector=%s", url.PathEscape(ns), url.QueryEscape(d.labelSelector()))
+	if err := k.getJSON(ctx, path, &pods); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("timestamps", "true")
+	if opts.Tail > 0 {
+		params.Set("tailLines", strconv.Itoa(opts.Tail))
+	}
+	if opts.Since > 0 {
+		secs := int(opts.Since.Seconds())
+		if secs < 1 {
+			secs = 1
+		}
+		params.Set("sinceSeconds", strconv.Itoa(secs))
+	}
+
+	var entries []LogEntry
+	for _, pod := range pods.Items {
+		logPath := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/log?%s",
+			url.PathEscape(ns), url.PathEscape(pod.Metadata.Name), params.Encode())
+		resp, err := k.doRequest(ctx, "GET", logPath, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes API error: %w", err)
+		}
+		if resp.StatusCode != 200 {
+			// Pods that are still starting have no logs yet.
+			resp.Body.Close()
+			continue
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			entry := LogEntry{Message: line, Source: pod.Metadata.Name}
+			if ts, msg, ok := strings.Cut(line, " "); ok {
+				if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+					entry.Timestamp = t
+					entry.Message = msg
+				}
+			}
+			// The log API merges stdout and stderr, so the level comes
+			// from the message alone.
+			entry.Level = InferLevel(entry.Message, "info")
+			if !MatchesLevel(entry.Level, opts.Level) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		resp.Body.Close()
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	if opts.Tail > 0 && len(entries) > opts.Tail {
+		entries = entries[len(entries)-opts.Tail:]
+	}
+	return entries, nil
+}
+
+// Scale sets the Deployment's replica count from MinInstances.
+func (k *Kubernetes) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	if opts.InstanceType != "" {
+		return fmt.Errorf("%w: kubernetes instance types are set by resource requests in the pod spec", ErrNotSupported)
+	}
+	if opts.MaxInstances > 0 && opts.MaxInstances != opts.MinInstances {
+		return fmt.Errorf("%w: kubernetes autoscaling is managed by a HorizontalPodAutoscaler; use --min to set replicas", ErrNotSupported)
+	}
+	if opts.MinInstances < 0 {
+		return fmt.Errorf("replicas must be >= 0")
+	}
+
+	ns, name := k.splitKubeID(serviceID)
+	patch, _ := json.Marshal(map[string]interface{}{
+		"spec": map[string]int{"replicas": opts.MinInstances},
+	})
+	path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s/scale", url.PathEscape(ns), url.PathEscape(name))
+	resp, err := k.doRequest(ctx, "PATCH", path, "application/merge-patch+json", patch)
+	if err != nil {
+		return fmt.Errorf("kubernetes API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%w: %s", statusError("kubernetes", resp.StatusCode), strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// GetCurrentScale implements ScaleInfoProvider. Replicas are reported as
+// both min and max since a Deployment has a single replica count.
+func (k *Kubernetes) GetCurrentScale(ctx context.Context, serviceID string) (min, max int, instanceType string, err error) {
+	ns, name := k.splitKubeID(serviceID)
+	d, err := k.getDeployment(ctx, ns, name)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	replicas := d.desiredReplicas()
+	return replicas, replicas, "", nil
+}
+
+// DiscoverServices lists Deployments in all namespaces.
+func (k *Kubernetes) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	var list struct {
+		Items []kubeDeployment `json:"items"`
+	}
+	if err := k.getJSON(ctx, "/apis/apps/v1/deployments", &list); err != nil {
+		return nil, err
+	}
+
+	services := make([]DiscoveredService, 0, len(list.Items))
+	for _, d := range list.Items {
+		if d.Metadata.Namespace == "kube-system" {
+			continue
+		}
+		services = append(services, DiscoveredService{
+			ID:       d.Metadata.Namespace + "/" + d.Metadata.Name,
+			Name:     d.Metadata.Name,
+			Platform: "kubernetes",
+		})
+	}
+	return services, nil
+}
+
+func (k *Kubernetes) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	ch := make(chan DeployEvent)
+
+	go func() {
+		defer close(ch)
+
+		pollInterval := k.interval()
+
+		// Check if the latest rollout is already in-progress.
+		deploys, err := k.ListDeployments(ctx, serviceID, 1)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
+			return
+		}
+		if len(deploys) > 0 && IsInProgress(deploys[0].Status) {
+			d := deploys[0]
+			if !sendEvent(ctx, ch, DeployEvent{
+				Phase:   "detected",
+				Message: fmt.Sprintf("In-progress rollout found (%s)", d.ID),
+				Deploy:  &d,
+			}) {
+				return
+			}
+			k.trackDeployment(ctx, ch, d.ID)
+			return
+		}
+
+		// Phase 1: Detect a new ReplicaSet revision
+		for {
+			deploys, err := k.ListDeployments(ctx, serviceID, 1)
+			if err != nil {
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
+				return
+			}
+
+			if len(deploys) > 0 {
+				d := deploys[0]
+				if d.ID != currentDeployID {
+					if !sendEvent(ctx, ch, DeployEvent{
+						Phase:   "detected",
+						Message: fmt.Sprintf("New rollout detected! (%s)", d.ID),
+						Deploy:  &d,
+					}) {
+						return
+					}
+					k.trackDeployment(ctx, ch, d.ID)
+					return
+				}
+			}
+
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (k *Kubernetes) trackDeployment(ctx context.Context, ch chan<- DeployEvent, deployID string) {
+	pollInterval := k.interval()
+	lastPhase := ""
+
+	for {
+		deploy, err := k.GetDeployment(ctx, deployID)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)})
+			return
+		}
+
+		phase := mapRenderToWatchPhase(deploy.Status)
+		if phase != lastPhase {
+			lastPhase = phase
+
+			event := DeployEvent{Phase: phase, Deploy: deploy}
+			switch phase {
+			case "deploying":
+				event.Message = "Rolling out..."
+			case "done":
+				event.Message = "Rollout complete!"
+				sendEvent(ctx, ch, event)
+				return
+			case "failed":
+				event.Message = "Rollout failed!"
+				event.Error = fmt.Errorf("rollout %s failed: progress deadline exceeded", deployID)
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
+				return
+			}
+		}
+
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
+	}
+}
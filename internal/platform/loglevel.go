@@ -0,0 +1,88 @@
+package platform
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// "level=error", "\"level\":\"warn\"", "severity: ERROR", ...
+	levelFieldRe = regexp.MustCompile(`(?i)"?(?:level|lvl|severity)"?\s*[:=]\s*"?([a-z]+)`)
+	// An upper-case level keyword near the start of the line: "ERROR ...",
+	// "[WARN] ...", "2025-01-02 03:04:05 INFO ...".
+	levelWordRe = regexp.MustCompile(`^(?:\S+\s+){0,3}?[\[(<]?(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|ERR|FATAL|PANIC|CRITICAL|CRIT|SEVERE)[\])>:]?(?:\s|$)`)
+	// An HTTP status in an access log line: `"GET / HTTP/1.1" 503` or "status=503".
+	httpStatusRe = regexp.MustCompile(`(?:HTTP/[\d.]+"?\s+|\bstatus[=: ]+)([1-5]\d\d)\b`)
+	// Stack traces and exceptions.
+	exceptionRe = regexp.MustCompile(`^(?:Traceback \(most recent call last\)|panic: |Exception in thread|Unhandled(?:Promise)?Rejection|(?:[\w.]+\.)?\w*(?:Error|Exception):\s)`)
+)
+
+// InferLevel derives a log level from a message for platforms that only
+// report the output stream. It looks for an explicit level (structured
+// field or leading keyword), then exceptions, then HTTP status codes in
+// access logs. fallback is returned when nothing matches, typically
+// "error" for stderr and "info" for stdout.
+func InferLevel(message, fallback string) string {
+	msg := strings.TrimSpace(message)
+	if msg == "" {
+		return fallback
+	}
+
+	if m := levelFieldRe.FindStringSubmatch(msg); m != nil {
+		if level := normalizeLevel(m[1]); level != "" {
+			return level
+		}
+	}
+	if m := levelWordRe.FindStringSubmatch(msg); m != nil {
+		if level := normalizeLevel(m[1]); level != "" {
+			return level
+		}
+	}
+	if exceptionRe.MatchString(msg) {
+		return "error"
+	}
+	if m := httpStatusRe.FindStringSubmatch(msg); m != nil {
+		switch m[1][0] {
+		case '5':
+			return "error"
+		case '4':
+			return "warn"
+		default:
+			return "info"
+		}
+	}
+	return fallback
+}
+
+// normalizeLevel maps level spellings to debug, info, warn or error.
+func normalizeLevel(s string) string {
+	switch strings.ToLower(s) {
+	case "trace", "debug", "dbg":
+		return "debug"
+	case "info", "information", "notice":
+		return "info"
+	case "warn", "warning", "wrn":
+		return "warn"
+	case "error", "err", "fatal", "panic", "critical", "crit", "severe", "alert", "emerg", "emergency":
+		return "error"
+	default:
+		return ""
+	}
+}
+
+// MatchesLevel reports whether an entry at level passes a --level filter.
+// Spellings are normalized, so "warning" matches "warn".
+func MatchesLevel(level, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	want := normalizeLevel(filter)
+	if want == "" {
+		want = strings.ToLower(filter)
+	}
+	got := normalizeLevel(level)
+	if got == "" {
+		got = strings.ToLower(level)
+	}
+	return got == want
+}
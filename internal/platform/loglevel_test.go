@@ -0,0 +1,38 @@
+package platform
+
+import "testing"
+
+func TestInferLevel(t *testing.T) {
+	tests := []struct {
+		msg, fallback, want string
+	}{
+		{"ERROR: connection refused", "info", "error"},
+		{"[WARN] slow query (1200ms)", "error", "warn"},
+		{"2025-01-02 03:04:05,123 INFO uvicorn started", "error", "info"},
+		{`{"level":"warn","msg":"retrying"}`, "info", "warn"},
+		{"time=2025-01-02T03:04:05Z level=debug msg=tick", "error", "debug"},
+		{"Traceback (most recent call last):", "info", "error"},
+		{"TypeError: cannot read property 'x' of undefined", "info", "error"},
+		{"panic: runtime error: index out of range", "info", "error"},
+		{`10.0.0.1 - - "GET /api HTTP/1.1" 503 12`, "info", "error"},
+		{`10.0.0.1 - - "GET /missing HTTP/1.1" 404 0`, "info", "warn"},
+		{"method=GET path=/ status=200 duration=3ms", "error", "info"},
+		{"listening on :8080", "error", "error"},
+		{"Informational message about errors", "info", "info"},
+		{"", "info", "info"},
+	}
+	for _, tt := range tests {
+		if got := InferLevel(tt.msg, tt.fallback); got != tt.want {
+			t.Errorf("InferLevel(%q, %q) = %q, want %q", tt.msg, tt.fallback, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesLevel(t *testing.T) {
+	if !MatchesLevel("warning", "warn") || !MatchesLevel("ERROR", "error") || !MatchesLevel("info", "") {
+		t.Error("expected level to match")
+	}
+	if MatchesLevel("info", "error") {
+		t.Error("info should not match error")
+	}
+}
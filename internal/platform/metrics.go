@@ -0,0 +1,72 @@
+package platform
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Metrics is a recent resource usage reading for a service, averaged over
+// its running instances. Negative CPU/Memory and zero ResponseMs mean the
+// platform had no data.
+type Metrics struct {
+	CPU        float64 // percent of the instance's CPU allotment
+	Memory     float64 // percent of the instance's memory limit
+	ResponseMs int     // median response time
+}
+
+// MetricsProvider is implemented by platforms whose usage metrics come from
+// a separate API rather than the service status.
+type MetricsProvider interface {
+	GetMetrics(ctx context.Context, serviceID string) (*Metrics, error)
+}
+
+// metricsWindow is how far back metric queries look for the latest sample.
+const metricsWindow = 10 * time.Minute
+
+// FillMetrics adds the platform's metrics to status when it is a
+// MetricsProvider. Metrics are best effort: on error status is left as is.
+func FillMetrics(ctx context.Context, p Platform, serviceID string, status *ServiceStatus) {
+	mp, ok := p.(MetricsProvider)
+	if !ok || status == nil {
+		return
+	}
+	m, err := mp.GetMetrics(ctx, serviceID)
+	if err != nil {
+		return
+	}
+	if m.CPU >= 0 {
+		status.CPU = m.CPU
+	}
+	if m.Memory >= 0 {
+		status.Memory = m.Memory
+	}
+	if m.ResponseMs > 0 {
+		status.ResponseMs = m.ResponseMs
+	}
+}
+
+// metricSample is one point of a metric time series.
+type metricSample struct {
+	At    time.Time
+	Value float64
+}
+
+// latestMean averages the most recent sample of each series (one series per
+// instance). ok is false when no series has samples.
+func latestMean(series [][]metricSample) (mean float64, ok bool) {
+	var sum float64
+	n := 0
+	for _, s := range series {
+		if len(s) == 0 {
+			continue
+		}
+		sort.Slice(s, func(i, j int) bool { return s[i].At.Before(s[j].At) })
+		sum += s[len(s)-1].Value
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
@@ -0,0 +1,48 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLatestMean(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	series := [][]metricSample{
+		{{t0.Add(time.Minute), 40}, {t0, 90}}, // unordered; latest is 40
+		{{t0, 10}, {t0.Add(time.Minute), 20}},
+		nil, // instance without samples is ignored
+	}
+	got, ok := latestMean(series)
+	if !ok || got != 30 {
+		t.Errorf("latestMean = %v, %v; want 30, true", got, ok)
+	}
+	if _, ok := latestMean([][]metricSample{nil}); ok {
+		t.Error("latestMean of empty series reported ok")
+	}
+}
+
+type metricsStub struct {
+	Platform
+	m   *Metrics
+	err error
+}
+
+func (s metricsStub) GetMetrics(ctx context.Context, serviceID string) (*Metrics, error) {
+	return s.m, s.err
+}
+
+func TestFillMetrics(t *testing.T) {
+	status := &ServiceStatus{CPU: -1, Memory: -1, ResponseMs: 120}
+	FillMetrics(context.Background(), metricsStub{m: &Metrics{CPU: 35, Memory: -1}}, "svc", status)
+	if status.CPU != 35 || status.Memory != -1 || status.ResponseMs != 120 {
+		t.Errorf("got %+v; want CPU filled, memory and response time kept", status)
+	}
+
+	status = &ServiceStatus{CPU: -1, Memory: -1}
+	FillMetrics(context.Background(), metricsStub{err: errors.New("boom")}, "svc", status)
+	if status.CPU != -1 {
+		t.Errorf("failed metrics changed status: %+v", status)
+	}
+}
@@ -0,0 +1,245 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const neonBaseURL = "https://console.neon.tech/api/v2"
+
+func init() {
+	Register("neon", func(token string) Platform {
+		return NewNeon(token)
+	})
+}
+
+// Neon implements the Platform interface using net/http (API v2). Services
+// are Neon projects; their status is that of the default branch's compute.
+type Neon struct {
+	token      string
+	httpClient *http.Client
+}
+
+// NewNeon creates a new Neon platform instance.
+func NewNeon(token string) *Neon {
+	return &Neon{
+		token:      token,
+		httpClient: newHTTPClient(15 * time.Second),
+	}
+}
+
+func (n *Neon) Name() string {
+	return "neon"
+}
+
+func (n *Neon) Capabilities() Capabilities {
+	return CapDiscover
+}
+
+func (n *Neon) doRequest(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, neonBaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+n.token)
+	req.Header.Set("Accept", "application/json")
+	return n.httpClient.Do(req)
+}
+
+// getJSON fetches path and decodes the response into out.
+func (n *Neon) getJSON(ctx context.Context, path string, out interface{}) error {
+	resp, err := n.doRequest(ctx, "GET", path)
+	if err != nil {
+		return fmt.Errorf("neon API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == 401 || resp.StatusCode == 403:
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	case resp.StatusCode == 404:
+		return fmt.Errorf("%w: %s", ErrNotFound, strings.TrimPrefix(path, "/"))
+	case resp.StatusCode != 200:
+		return statusError("neon", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// Validate checks whether the token is valid by listing projects.
+func (n *Neon) Validate(ctx context.Context, token string) error {
+	v := &Neon{token: token, httpClient: n.httpClient}
+	var out struct{}
+	return v.getJSON(ctx, "/projects?limit=1", &out)
+}
+
+type neonProject struct {
+	ID                          string `json:"id"`
+	Name                        string `json:"name"`
+	RegionID                    string `json:"region_id"`
+	PgVersion                   int    `json:"pg_version"`
+	SyntheticStorageSize        int64  `json:"synthetic_storage_size"`
+	BranchLogicalSizeLimitBytes int64  `json:"branch_logical_size_limit_bytes"`
+}
+
+type neonBranch struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ParentID     string `json:"parent_id"`
+	CurrentState string `json:"current_state"`
+	Default      bool   `json:"default"`
+	LogicalSize  int64  `json:"logical_size"`
+}
+
+type neonEndpoint struct {
+	BranchID     string `json:"branch_id"`
+	Type         string `json:"type"` // read_write or read_only
+	CurrentState string `json:"current_state"`
+}
+
+func (n *Neon) branchesAndEndpoints(ctx context.Context, projectID string) ([]neonBranch, []neonEndpoint, error) {
+	var branches struct {
+		Branches []neonBranch `json:"branches"`
+	}
+	if err := n.getJSON(ctx, "/projects/"+projectID+"/branches", &branches); err != nil {
+		return nil, nil, err
+	}
+	var endpoints struct {
+		Endpoints []neonEndpoint `json:"endpoints"`
+	}
+	if err := n.getJSON(ctx, "/projects/"+projectID+"/endpoints", &endpoints); err != nil {
+		return nil, nil, err
+	}
+	return branches.Branches, endpoints.Endpoints, nil
+}
+
+// neonBranchState normalizes a branch's state, taking its read-write
+// compute into account: an idle compute is suspended until the next
+// connection.
+func neonBranchState(b neonBranch, endpoints []neonEndpoint) string {
+	if b.CurrentState == "init" {
+		return "deploying"
+	}
+	if b.CurrentState != "ready" {
+		return "degraded"
+	}
+	for _, e := range endpoints {
+		if e.BranchID != b.ID || e.Type != "read_write" {
+			continue
+		}
+		switch e.CurrentState {
+		case "active":
+			return "healthy"
+		case "idle":
+			return "sleeping"
+		case "init":
+			return "deploying"
+		}
+	}
+	// No compute: the branch only holds data.
+	return "sleeping"
+}
+
+func (n *Neon) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	branches, endpoints, err := n.branchesAndEndpoints(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		if b.Default {
+			return &ServiceStatus{Status: neonBranchState(b, endpoints)}, nil
+		}
+	}
+	return nil, fmt.Errorf("project %s has no default branch", serviceID)
+}
+
+// DatabaseInfo reports storage against the branch size limit and every
+// branch with its compute state. Neon's API doesn't expose connection
+// counts or migrations.
+func (n *Neon) DatabaseInfo(ctx context.Context, serviceID string) (*DatabaseInfo, error) {
+	var project struct {
+		Project neonProject `json:"project"`
+	}
+	if err := n.getJSON(ctx, "/projects/"+serviceID, &project); err != nil {
+		return nil, err
+	}
+	branches, endpoints, err := n.branchesAndEndpoints(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	p := project.Project
+	info := &DatabaseInfo{
+		Engine:       fmt.Sprintf("postgres %d", p.PgVersion),
+		Region:       p.RegionID,
+		Connections:  -1,
+		StorageBytes: p.SyntheticStorageSize,
+	}
+	names := make(map[string]string, len(branches))
+	for _, b := range branches {
+		names[b.ID] = b.Name
+	}
+	for _, b := range branches {
+		if b.Default {
+			info.StorageLimit = p.BranchLogicalSizeLimitBytes
+			if b.LogicalSize > 0 {
+				info.StorageBytes = b.LogicalSize
+			}
+		}
+		info.Branches = append(info.Branches, DatabaseBranch{
+			Name: b.Name, State: neonBranchState(b, endpoints), Primary: b.Default, Parent: names[b.ParentID],
+		})
+	}
+	return info, nil
+}
+
+func (n *Neon) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	var out struct {
+		Projects []neonProject `json:"projects"`
+	}
+	if err := n.getJSON(ctx, "/projects?limit=400", &out); err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	services := make([]DiscoveredService, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		services = append(services, DiscoveredService{ID: p.ID, Name: p.Name, Platform: "neon"})
+	}
+	return services, nil
+}
+
+func (n *Neon) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	return nil, fmt.Errorf("%w: neon does not track deployments", ErrNotSupported)
+}
+
+func (n *Neon) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: neon does not track deployments", ErrNotSupported)
+}
+
+func (n *Neon) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: use the Neon console to manage projects", ErrNotSupported)
+}
+
+func (n *Neon) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: neon does not track deployments", ErrNotSupported)
+}
+
+func (n *Neon) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: neon does not track deployments", ErrNotSupported)
+}
+
+func (n *Neon) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	return nil, fmt.Errorf("%w: neon logs are only available in the Neon console", ErrNotSupported)
+}
+
+func (n *Neon) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: use the Neon console to change compute size", ErrNotSupported)
+}
+
+func (n *Neon) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	return nil, fmt.Errorf("%w: neon does not support deployment watching", ErrNotSupported)
+}
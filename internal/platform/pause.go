@@ -0,0 +1,10 @@
+package platform
+
+import "context"
+
+// Pauser is implemented by platforms that can stop a service without
+// deleting it, so it stops billing for compute until it is resumed.
+type Pauser interface {
+	Pause(ctx context.Context, serviceID string) error
+	Resume(ctx context.Context, serviceID string) error
+}
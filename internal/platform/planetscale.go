@@ -0,0 +1,235 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const planetscaleBaseURL = "https://api.planetscale.com/v1"
+
+func init() {
+	Register("planetscale", func(token string) Platform {
+		return NewPlanetScale(token)
+	})
+}
+
+// PlanetScale implements the Platform interface using net/http. Services
+// are databases, referenced as organization/database. The token is a
+// service token in the form <id>:<token>, as PlanetScale's Authorization
+// header takes it.
+type PlanetScale struct {
+	token      string
+	httpClient *http.Client
+}
+
+// NewPlanetScale creates a new PlanetScale platform instance.
+func NewPlanetScale(token string) *PlanetScale {
+	return &PlanetScale{
+		token:      token,
+		httpClient: newHTTPClient(15 * time.Second),
+	}
+}
+
+func (p *PlanetScale) Name() string {
+	return "planetscale"
+}
+
+func (p *PlanetScale) Capabilities() Capabilities {
+	return CapDiscover
+}
+
+// getJSON fetches path and decodes the response into out.
+func (p *PlanetScale) getJSON(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", planetscaleBaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", p.token)
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("planetscale API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == 401 || resp.StatusCode == 403:
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	case resp.StatusCode == 404:
+		return fmt.Errorf("%w: %s", ErrNotFound, strings.TrimPrefix(path, "/"))
+	case resp.StatusCode != 200:
+		return statusError("planetscale", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// planetscaleDatabasePath turns organization/database into its API path.
+func planetscaleDatabasePath(serviceID string) (string, error) {
+	org, db, ok := strings.Cut(serviceID, "/")
+	if !ok || org == "" || db == "" {
+		return "", fmt.Errorf("planetscale service ID %q: want organization/database", serviceID)
+	}
+	return "/organizations/" + org + "/databases/" + db, nil
+}
+
+// Validate checks whether the token is valid by listing organizations.
+func (p *PlanetScale) Validate(ctx context.Context, token string) error {
+	v := &PlanetScale{token: token, httpClient: p.httpClient}
+	var out struct{}
+	return v.getJSON(ctx, "/organizations", &out)
+}
+
+type planetscaleDatabase struct {
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	Kind   string `json:"kind"` // mysql or postgresql
+	Region struct {
+		Slug string `json:"slug"`
+	} `json:"region"`
+}
+
+func mapPlanetScaleState(state string) string {
+	switch state {
+	case "ready":
+		return "healthy"
+	case "sleeping", "sleep_in_progress", "awakening":
+		return "sleeping"
+	case "pending", "importing":
+		return "deploying"
+	default:
+		return "degraded"
+	}
+}
+
+func (p *PlanetScale) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	path, err := planetscaleDatabasePath(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	var db planetscaleDatabase
+	if err := p.getJSON(ctx, path, &db); err != nil {
+		return nil, err
+	}
+	return &ServiceStatus{Status: mapPlanetScaleState(db.State), Name: db.Name}, nil
+}
+
+// DatabaseInfo lists branches and open deploy requests, PlanetScale's
+// schema changes. Connection counts and storage aren't in the API.
+func (p *PlanetScale) DatabaseInfo(ctx context.Context, serviceID string) (*DatabaseInfo, error) {
+	path, err := planetscaleDatabasePath(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	var db planetscaleDatabase
+	if err := p.getJSON(ctx, path, &db); err != nil {
+		return nil, err
+	}
+	info := &DatabaseInfo{Engine: db.Kind, Region: db.Region.Slug, Connections: -1}
+	if info.Engine == "" {
+		info.Engine = "mysql"
+	}
+
+	var branches struct {
+		Data []struct {
+			Name         string `json:"name"`
+			Production   bool   `json:"production"`
+			Ready        bool   `json:"ready"`
+			ParentBranch string `json:"parent_branch"`
+		} `json:"data"`
+	}
+	if err := p.getJSON(ctx, path+"/branches", &branches); err != nil {
+		return nil, err
+	}
+	for _, b := range branches.Data {
+		state := "healthy"
+		if !b.Ready {
+			state = "deploying"
+		}
+		info.Branches = append(info.Branches, DatabaseBranch{Name: b.Name, State: state, Primary: b.Production, Parent: b.ParentBranch})
+	}
+
+	var requests struct {
+		Data []struct {
+			Number          int       `json:"number"`
+			Branch          string    `json:"branch"`
+			IntoBranch      string    `json:"into_branch"`
+			DeploymentState string    `json:"deployment_state"` // pending, ready, queued, in_progress, complete, ...
+			CreatedAt       time.Time `json:"created_at"`
+		} `json:"data"`
+	}
+	if err := p.getJSON(ctx, path+"/deploy-requests?state=open", &requests); err != nil {
+		return nil, err
+	}
+	for _, r := range requests.Data {
+		info.Migrations = append(info.Migrations, Migration{
+			Name:      fmt.Sprintf("deploy request #%d into %s", r.Number, r.IntoBranch),
+			Status:    strings.ReplaceAll(r.DeploymentState, "_", " "),
+			Branch:    r.Branch,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return info, nil
+}
+
+func (p *PlanetScale) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	var orgs struct {
+		Data []struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := p.getJSON(ctx, "/organizations", &orgs); err != nil {
+		return nil, fmt.Errorf("list organizations: %w", err)
+	}
+	var services []DiscoveredService
+	for _, org := range orgs.Data {
+		var dbs struct {
+			Data []planetscaleDatabase `json:"data"`
+		}
+		if err := p.getJSON(ctx, "/organizations/"+org.Name+"/databases", &dbs); err != nil {
+			return nil, fmt.Errorf("list databases of %s: %w", org.Name, err)
+		}
+		for _, db := range dbs.Data {
+			services = append(services, DiscoveredService{ID: org.Name + "/" + db.Name, Name: db.Name, Platform: "planetscale"})
+		}
+	}
+	return services, nil
+}
+
+func (p *PlanetScale) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	return nil, fmt.Errorf("%w: planetscale does not track deployments", ErrNotSupported)
+}
+
+func (p *PlanetScale) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: planetscale does not track deployments", ErrNotSupported)
+}
+
+func (p *PlanetScale) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: use the PlanetScale dashboard to manage databases", ErrNotSupported)
+}
+
+func (p *PlanetScale) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: planetscale does not track deployments", ErrNotSupported)
+}
+
+func (p *PlanetScale) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: planetscale does not track deployments", ErrNotSupported)
+}
+
+func (p *PlanetScale) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	return nil, fmt.Errorf("%w: planetscale logs are only available in the PlanetScale dashboard", ErrNotSupported)
+}
+
+func (p *PlanetScale) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: use the PlanetScale dashboard to change cluster size", ErrNotSupported)
+}
+
+func (p *PlanetScale) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	return nil, fmt.Errorf("%w: planetscale does not support deployment watching", ErrNotSupported)
+}
@@ -1,30 +1,48 @@
 package platform
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 // ServiceStatus represents the normalized status of a service.
 type ServiceStatus struct {
-	Status       string        // healthy, degraded, unhealthy, sleeping
-	ResponseMs   int           // average response time in ms
-	CPU          float64       // CPU usage percentage
-	Memory       float64       // Memory usage percentage
-	Instances    int           // current running instances
-	MaxInstances int           // maximum configured instances
-	LastDeploy   *Deployment   // most recent deployment
+	Status       string       // healthy, degraded, unhealthy, sleeping
+	ResponseMs   int          // average response time in ms
+	CPU          float64      // CPU usage percentage
+	Memory       float64      // Memory usage percentage
+	Instances    int          // current running instances
+	MaxInstances int          // maximum configured instances
+	LastDeploy   *Deployment  // most recent deployment
+	Name         string       // service name on the platform, if reported
+	URL          string       // canonical public URL, if reported
+	Probe        *ProbeResult // Orbit's own health probe, if one is configured
+}
+
+// ProbeResult is the outcome of a health probe Orbit ran against the
+// service directly, independent of the platform API.
+type ProbeResult struct {
+	Target    string // URL or tcp://host:port that was probed
+	OK        bool   // reachable and passed every expectation
+	Slow      bool   // passed, but took longer than the latency budget
+	LatencyMs int
+	Code      int    // HTTP status code, 0 for TCP probes or no response
+	Failure   string // why the probe failed, if it did
 }
 
 // Deployment represents a single deployment event.
 type Deployment struct {
-	ID        string
-	Status    string // pending, building, deploying, healthy, failed, sleeping
-	Commit    string
-	Message   string
-	CreatedAt time.Time
-	Duration  time.Duration
-	URL       string
+	ID          string
+	Status      string // pending, building, deploying, healthy, failed, sleeping
+	Commit      string
+	Message     string
+	Branch      string // git branch the deployment was built from, if known
+	Author      string // login of the commit author or whoever triggered it
+	PullRequest int    // pull request number for preview deploys, 0 if none
+	CreatedAt   time.Time
+	Duration    time.Duration
+	URL         string
 }
 
 // DeployEvent represents a real-time deployment state change.
@@ -50,6 +68,24 @@ type LogOptions struct {
 	Level  string
 	Tail   int
 	Since  time.Duration
+	// Type selects runtime (the default) or build logs on platforms that
+	// keep both. Platforms whose logs are build output to begin with, such
+	// as Vercel and GitHub, ignore it.
+	Type string
+}
+
+// Log types for LogOptions.Type.
+const (
+	LogTypeRuntime = "runtime"
+	LogTypeBuild   = "build"
+)
+
+// logType returns the requested log type, defaulting to runtime.
+func (o LogOptions) logType() string {
+	if o.Type == "" {
+		return LogTypeRuntime
+	}
+	return o.Type
 }
 
 // ScaleOptions controls scaling parameters.
@@ -61,20 +97,50 @@ type ScaleOptions struct {
 
 // ScaleInfoProvider is implemented by platforms that can report current scaling config.
 type ScaleInfoProvider interface {
-	GetCurrentScale(serviceID string) (min, max int, instanceType string, err error)
+	GetCurrentScale(ctx context.Context, serviceID string) (min, max int, instanceType string, err error)
 }
 
 // Platform defines the interface all cloud platform adapters must implement.
+//
+// Every call that reaches the platform API takes a context; cancelling it
+// aborts in-flight requests. WatchDeployment stops polling and closes its
+// channel once ctx is done, so callers may stop reading at any time.
 type Platform interface {
 	Name() string
-	Validate(token string) error
-	GetServiceStatus(serviceID string) (*ServiceStatus, error)
-	ListDeployments(serviceID string, limit int) ([]Deployment, error)
-	GetDeployment(deployID string) (*Deployment, error)
-	Redeploy(serviceID string) (*Deployment, error)
-	GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error)
-	Scale(serviceID string, opts ScaleOptions) error
-	WatchDeployment(serviceID string, currentDeployID string) (<-chan DeployEvent, error)
+	Capabilities() Capabilities
+	Validate(ctx context.Context, token string) error
+	GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error)
+	ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error)
+	GetDeployment(ctx context.Context, deployID string) (*Deployment, error)
+	Redeploy(ctx context.Context, serviceID string) (*Deployment, error)
+	// RollbackTo makes a previous deployment live again, returning the
+	// deployment now serving (new on some platforms, the target on others).
+	RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error)
+	// CancelDeployment stops a deployment that is still building or
+	// deploying; the previous deployment keeps serving.
+	CancelDeployment(ctx context.Context, deployID string) error
+	GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error)
+	Scale(ctx context.Context, serviceID string, opts ScaleOptions) error
+	WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error)
+}
+
+// ServiceIdentifier is implemented by platforms whose services can be looked
+// up by either a stable ID or a (renameable) name.
+type ServiceIdentifier interface {
+	LookupService(ctx context.Context, idOrName string) (*DiscoveredService, error)
+}
+
+// ArtifactSizer is implemented by platforms that report the size of a
+// deployment's build output (bundle, image, ...). It returns 0 when the
+// size is unknown.
+type ArtifactSizer interface {
+	ArtifactSize(ctx context.Context, deployID string) (int64, error)
+}
+
+// DeploymentLogger is implemented by platforms that keep the build output of
+// each deployment. DeploymentLogs returns the last tail lines, oldest first.
+type DeploymentLogger interface {
+	DeploymentLogs(ctx context.Context, deployID string, tail int) ([]LogEntry, error)
 }
 
 // TeamConfigurable is implemented by platforms that support team/org scoping.
@@ -87,6 +153,12 @@ type TargetConfigurable interface {
 	SetTarget(target string)
 }
 
+// PollIntervalConfigurable is implemented by platforms whose
+// WatchDeployment polls the API, to change how often it does.
+type PollIntervalConfigurable interface {
+	SetPollInterval(d time.Duration)
+}
+
 // Constructor creates a new Platform instance with the given API token.
 type Constructor func(token string) Platform
 
@@ -122,9 +194,9 @@ func IsSupported(name string) bool {
 	return ok
 }
 
-// isInProgress returns true if the deployment status indicates a non-terminal state.
+// IsInProgress returns true if the deployment status indicates a non-terminal state.
 // Used by WatchDeployment to detect in-progress deployments that started before watch began.
-func isInProgress(status string) bool {
+func IsInProgress(status string) bool {
 	switch status {
 	case "building", "deploying", "pending":
 		return true
@@ -133,7 +205,81 @@ func isInProgress(status string) bool {
 	}
 }
 
-// TokenURL returns the URL where users can obtain an API token for a platform.
+// sendEvent delivers ev to a watch channel. It returns false without
+// sending if ctx is cancelled first, so the watcher can exit instead of
+// blocking on a reader that has gone away.
+func sendEvent(ctx context.Context, ch chan<- DeployEvent, ev DeployEvent) bool {
+	select {
+	case ch <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sleepContext pauses between polls. It returns false if ctx is cancelled
+// before d elapses.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// DefaultPollInterval is how often WatchDeployment polls unless
+// SetPollInterval changed it.
+const DefaultPollInterval = 3 * time.Second
+
+// poller is embedded by adapters whose WatchDeployment polls, making them
+// PollIntervalConfigurable.
+type poller struct {
+	pollInterval time.Duration
+}
+
+// SetPollInterval sets how often WatchDeployment polls.
+func (p *poller) SetPollInterval(d time.Duration) {
+	p.pollInterval = d
+}
+
+func (p *poller) interval() time.Duration {
+	if p.pollInterval <= 0 {
+		return DefaultPollInterval
+	}
+	return p.pollInterval
+}
+
+// APIURL returns the base URL of a platform's hosted API, or "" for
+// platforms whose API server is configured per connection.
+func APIURL(name string) string {
+	switch name {
+	case "vercel":
+		return vercelBaseURL
+	case "koyeb":
+		return koyebBaseURL
+	case "supabase":
+		return supabaseBaseURL
+	case "neon":
+		return neonBaseURL
+	case "planetscale":
+		return planetscaleBaseURL
+	case "render":
+		return renderBaseURL
+	case "flyio":
+		return flyBaseURL
+	case "github":
+		return githubBaseURL
+	default:
+		return ""
+	}
+}
+
+// TokenURL returns the URL where users can obtain an API token for a
+// platform, with the recommended scopes preselected where the page allows
+// it. TokenSteps says what to choose there.
 func TokenURL(name string) string {
 	switch name {
 	case "vercel":
@@ -142,10 +288,18 @@ func TokenURL(name string) string {
 		return "https://app.koyeb.com/account/api"
 	case "supabase":
 		return "https://supabase.com/dashboard/account/tokens"
+	case "neon":
+		return "https://console.neon.tech/app/settings/api-keys"
+	case "planetscale":
+		return "https://app.planetscale.com/~/settings/service-tokens"
 	case "render":
 		return "https://dashboard.render.com/u/settings#api-keys"
 	case "flyio":
 		return "https://fly.io/docs/security/tokens/"
+	case "github":
+		return "https://github.com/settings/tokens/new?scopes=repo&description=orbit"
+	case "kubernetes":
+		return "https://kubernetes.io/docs/reference/access-authn-authz/service-accounts-admin/"
 	default:
 		return ""
 	}
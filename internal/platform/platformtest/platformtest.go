@@ -0,0 +1,434 @@
+// Package platformtest is a conformance suite for platform adapters. Given
+// a Platform and a few fixtures about the account it talks to, it checks
+// the contract commands rely on: statuses use Orbit's vocabulary,
+// deployment lists honor their limit across pages, failures carry the right
+// error kind, and watches report phases in order and stop when cancelled.
+//
+// In-tree adapters call Run from their tests; external adapters (plugins)
+// run the same checks with `orbit conformance`.
+package platformtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// Fixture describes the account an adapter under test talks to.
+type Fixture struct {
+	// ServiceID is a service with at least one deployment.
+	ServiceID string
+	// MinDeployments is how many deployments ServiceID is known to have;
+	// when it exceeds the platform's page size the pagination check
+	// covers several pages. 0 or 1 checks the limit only.
+	MinDeployments int
+	// WantStatus is the service's expected status; "" accepts any.
+	WantStatus string
+	// MissingServiceID and MissingDeployID must not exist; the not-found
+	// checks are skipped when they are empty.
+	MissingServiceID string
+	MissingDeployID  string
+	// BadToken is rejected by the platform; "" uses an obviously invalid one.
+	BadToken string
+	// Redeploy lets the watch check start a deployment of ServiceID and
+	// follow it to the end. Without it, only an idle watch is checked.
+	Redeploy bool
+	// WatchIdle is how long the idle watch runs (default 3s), WatchTimeout
+	// how long a redeploy may take (default 10m).
+	WatchIdle    time.Duration
+	WatchTimeout time.Duration
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Check   string
+	Skipped bool
+	Err     error // nil when the adapter passed or the check was skipped
+	Detail  string
+}
+
+// errSkip marks a check that does not apply to the adapter or fixture.
+type errSkip string
+
+func (e errSkip) Error() string { return string(e) }
+
+type check struct {
+	name string
+	run  func(ctx context.Context, p platform.Platform, f Fixture) (string, error)
+}
+
+var checks = []check{
+	{"status", checkStatus},
+	{"deployments", checkDeployments},
+	{"get-deployment", checkGetDeployment},
+	{"unauthorized", checkUnauthorized},
+	{"not-found", checkNotFound},
+	{"not-supported", checkNotSupported},
+	{"watch-idle", checkWatchIdle},
+	{"watch-deploy", checkWatchDeploy},
+}
+
+// Checks returns the names of the checks, in the order they run.
+func Checks() []string {
+	names := make([]string, len(checks))
+	for i, c := range checks {
+		names[i] = c.name
+	}
+	return names
+}
+
+// Check runs every check against p, one after another.
+func Check(ctx context.Context, p platform.Platform, f Fixture) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		detail, err := c.run(ctx, p, f)
+		r := Result{Check: c.name, Detail: detail, Err: err}
+		var skip errSkip
+		if errors.As(err, &skip) {
+			r.Skipped, r.Detail, r.Err = true, string(skip), nil
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Run runs every check against p as a subtest of t.
+func Run(t *testing.T, p platform.Platform, f Fixture) {
+	t.Helper()
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.run(context.Background(), p, f)
+			var skip errSkip
+			switch {
+			case errors.As(err, &skip):
+				t.Skip(string(skip))
+			case err != nil:
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Statuses an adapter may report. Services that are mid-deploy may report
+// the deployment's status.
+var (
+	serviceStatuses = map[string]bool{
+		"healthy": true, "degraded": true, "unhealthy": true, "sleeping": true,
+		"pending": true, "building": true, "deploying": true, "failed": true,
+	}
+	deployStatuses = map[string]bool{
+		"pending": true, "building": true, "deploying": true, "healthy": true, "failed": true, "sleeping": true,
+	}
+)
+
+func checkStatus(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	st, err := p.GetServiceStatus(ctx, f.ServiceID)
+	if err != nil {
+		return "", err
+	}
+	if st == nil {
+		return "", fmt.Errorf("GetServiceStatus returned no status and no error")
+	}
+	if !serviceStatuses[st.Status] {
+		return "", fmt.Errorf("status %q is not one of Orbit's statuses", st.Status)
+	}
+	if f.WantStatus != "" && st.Status != f.WantStatus {
+		return "", fmt.Errorf("status %q, want %q", st.Status, f.WantStatus)
+	}
+	if d := st.LastDeploy; d != nil && !deployStatuses[d.Status] {
+		return "", fmt.Errorf("last deployment status %q is not one of Orbit's statuses", d.Status)
+	}
+	if !p.Capabilities().Has(platform.CapMetrics) && (st.CPU > 0 || st.Memory > 0) {
+		return "", fmt.Errorf("reports CPU or memory without the metrics capability")
+	}
+	return st.Status, nil
+}
+
+func checkDeployments(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	if !p.Capabilities().Has(platform.CapDeployments) {
+		return "", errSkip("no deployments capability")
+	}
+	one, err := p.ListDeployments(ctx, f.ServiceID, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(one) != 1 {
+		return "", fmt.Errorf("limit 1 returned %d deployments", len(one))
+	}
+
+	want := max(f.MinDeployments, 1)
+	deploys, err := p.ListDeployments(ctx, f.ServiceID, want)
+	if err != nil {
+		return "", err
+	}
+	if len(deploys) != want {
+		return "", fmt.Errorf("limit %d returned %d deployments; the service has at least %d", want, len(deploys), want)
+	}
+	if deploys[0].ID != one[0].ID {
+		return "", fmt.Errorf("limit %d starts with %s, limit 1 with %s", want, deploys[0].ID, one[0].ID)
+	}
+	seen := make(map[string]bool, len(deploys))
+	for i, d := range deploys {
+		if d.ID == "" {
+			return "", fmt.Errorf("deployment %d has no ID", i)
+		}
+		if seen[d.ID] {
+			return "", fmt.Errorf("deployment %s listed twice", d.ID)
+		}
+		seen[d.ID] = true
+		if !deployStatuses[d.Status] {
+			return "", fmt.Errorf("deployment %s status %q is not one of Orbit's statuses", d.ID, d.Status)
+		}
+		if i > 0 && !d.CreatedAt.IsZero() && !deploys[i-1].CreatedAt.IsZero() && d.CreatedAt.After(deploys[i-1].CreatedAt) {
+			return "", fmt.Errorf("deployment %s is newer than %s before it; want newest first", d.ID, deploys[i-1].ID)
+		}
+		if d.Duration < 0 {
+			return "", fmt.Errorf("deployment %s has a negative duration", d.ID)
+		}
+	}
+	return fmt.Sprintf("%d deployments", len(deploys)), nil
+}
+
+// checkGetDeployment checks that IDs from ListDeployments can be fetched.
+func checkGetDeployment(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	if !p.Capabilities().Has(platform.CapDeployments) {
+		return "", errSkip("no deployments capability")
+	}
+	deploys, err := p.ListDeployments(ctx, f.ServiceID, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(deploys) == 0 {
+		return "", fmt.Errorf("no deployments to fetch")
+	}
+	d, err := p.GetDeployment(ctx, deploys[0].ID)
+	if err != nil {
+		return "", fmt.Errorf("GetDeployment(%s) with an ID from ListDeployments: %w", deploys[0].ID, err)
+	}
+	if d.ID != deploys[0].ID {
+		return "", fmt.Errorf("GetDeployment(%s) returned %s", deploys[0].ID, d.ID)
+	}
+	if !deployStatuses[d.Status] {
+		return "", fmt.Errorf("deployment %s status %q is not one of Orbit's statuses", d.ID, d.Status)
+	}
+	return d.ID, nil
+}
+
+func checkUnauthorized(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	token := f.BadToken
+	if token == "" {
+		token = "orbit-conformance-invalid-token"
+	}
+	return wantKind(p.Validate(ctx, token), platform.ErrUnauthorized, "Validate with a bad token")
+}
+
+func checkNotFound(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	if f.MissingServiceID == "" && f.MissingDeployID == "" {
+		return "", errSkip("no missing service or deployment ID in the fixture")
+	}
+	if f.MissingServiceID != "" {
+		_, err := p.GetServiceStatus(ctx, f.MissingServiceID)
+		if _, err := wantKind(err, platform.ErrNotFound, "GetServiceStatus of a missing service"); err != nil {
+			return "", err
+		}
+	}
+	if f.MissingDeployID != "" && p.Capabilities().Has(platform.CapDeployments) {
+		_, err := p.GetDeployment(ctx, f.MissingDeployID)
+		if _, err := wantKind(err, platform.ErrNotFound, "GetDeployment of a missing deployment"); err != nil {
+			return "", err
+		}
+	}
+	return "not_found", nil
+}
+
+// checkNotSupported checks that calls outside the declared capabilities
+// fail with ErrNotSupported. Only calls that cannot change anything even
+// if the adapter wrongly goes ahead are made.
+func checkNotSupported(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	caps := p.Capabilities()
+	const missingDeploy = "orbit-conformance-missing"
+	calls := []struct {
+		cap  platform.Capabilities
+		name string
+		call func() error
+	}{
+		{platform.CapDeployments, "ListDeployments", func() error {
+			_, err := p.ListDeployments(ctx, f.ServiceID, 1)
+			return err
+		}},
+		{platform.CapLogs, "GetLogs", func() error {
+			_, err := p.GetLogs(ctx, f.ServiceID, platform.LogOptions{Tail: 1})
+			return err
+		}},
+		{platform.CapRollback, "RollbackTo", func() error {
+			_, err := p.RollbackTo(ctx, f.ServiceID, missingDeploy)
+			return err
+		}},
+		{platform.CapCancel, "CancelDeployment", func() error {
+			return p.CancelDeployment(ctx, missingDeploy)
+		}},
+	}
+	n := 0
+	for _, c := range calls {
+		if caps.Has(c.cap) {
+			continue
+		}
+		n++
+		if _, err := wantKind(c.call(), platform.ErrNotSupported, c.name+" without the capability"); err != nil {
+			return "", err
+		}
+	}
+	if n == 0 {
+		return "", errSkip("every probed capability is supported")
+	}
+	return fmt.Sprintf("%d unsupported calls refused", n), nil
+}
+
+// watchPhases is the order watch phases must arrive in; phases may be
+// skipped but never go backwards.
+var watchPhases = map[string]int{
+	"waiting": 0, "detected": 1, "building": 2, "deploying": 3, "healthcheck": 4, "done": 5, "failed": 5,
+}
+
+// collectPhases reads events until the channel closes, checking their
+// order. It fails if the channel stays open more than a second after ctx
+// is done.
+func collectPhases(ctx context.Context, events <-chan platform.DeployEvent) ([]string, *platform.DeployEvent, error) {
+	var phases []string
+	var final *platform.DeployEvent
+	last := -1
+	var grace <-chan time.Time
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			grace, done = time.After(time.Second), nil
+			continue
+		case <-grace:
+			return phases, final, fmt.Errorf("watch kept its channel open after the context was cancelled")
+		case ev, ok := <-events:
+			if !ok {
+				return phases, final, nil
+			}
+			if final != nil {
+				return phases, final, fmt.Errorf("%s event after %s", ev.Phase, final.Phase)
+			}
+			order, known := watchPhases[ev.Phase]
+			if !known {
+				return phases, final, fmt.Errorf("unknown phase %q", ev.Phase)
+			}
+			if order < last {
+				return phases, final, fmt.Errorf("phase %s after %s", ev.Phase, phases[len(phases)-1])
+			}
+			last = order
+			if len(phases) == 0 || phases[len(phases)-1] != ev.Phase {
+				phases = append(phases, ev.Phase)
+			}
+			if ev.Phase == "done" || ev.Phase == "failed" {
+				ev := ev
+				final = &ev
+			}
+		}
+	}
+}
+
+// latestDeployID returns the newest deployment of the service, or "".
+func latestDeployID(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	if !p.Capabilities().Has(platform.CapDeployments) {
+		return "", nil
+	}
+	deploys, err := p.ListDeployments(ctx, f.ServiceID, 1)
+	if err != nil || len(deploys) == 0 {
+		return "", err
+	}
+	return deploys[0].ID, nil
+}
+
+// checkWatchIdle watches a service with nothing deploying and cancels.
+func checkWatchIdle(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	if !p.Capabilities().Has(platform.CapWatch) {
+		return "", errSkip("no watch capability")
+	}
+	current, err := latestDeployID(ctx, p, f)
+	if err != nil {
+		return "", err
+	}
+	idle := f.WatchIdle
+	if idle == 0 {
+		idle = 3 * time.Second
+	}
+	wctx, cancel := context.WithTimeout(ctx, idle)
+	defer cancel()
+	events, err := p.WatchDeployment(wctx, f.ServiceID, current)
+	if err != nil {
+		return "", err
+	}
+	phases, final, err := collectPhases(wctx, events)
+	if err != nil {
+		return "", err
+	}
+	if final != nil && final.Phase == "failed" && final.Error == nil {
+		return "", fmt.Errorf("failed event without an error")
+	}
+	return fmt.Sprint(phases), nil
+}
+
+// checkWatchDeploy redeploys the service and follows the deployment.
+func checkWatchDeploy(ctx context.Context, p platform.Platform, f Fixture) (string, error) {
+	caps := p.Capabilities()
+	switch {
+	case !f.Redeploy:
+		return "", errSkip("redeploy not allowed by the fixture")
+	case !caps.Has(platform.CapWatch | platform.CapRedeploy):
+		return "", errSkip("no watch or redeploy capability")
+	}
+	current, err := latestDeployID(ctx, p, f)
+	if err != nil {
+		return "", err
+	}
+	timeout := f.WatchTimeout
+	if timeout == 0 {
+		timeout = 10 * time.Minute
+	}
+	wctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if _, err := p.Redeploy(wctx, f.ServiceID); err != nil {
+		return "", fmt.Errorf("redeploy: %w", err)
+	}
+	events, err := p.WatchDeployment(wctx, f.ServiceID, current)
+	if err != nil {
+		return "", err
+	}
+	phases, final, err := collectPhases(wctx, events)
+	switch {
+	case err != nil:
+		return "", err
+	case final == nil:
+		return "", fmt.Errorf("watch ended without done or failed after %v", phases)
+	case final.Phase == "failed" && final.Error == nil:
+		return "", fmt.Errorf("failed event without an error")
+	case phases[0] != "detected" && phases[0] != "waiting":
+		return "", fmt.Errorf("first phase %s, want detected", phases[0])
+	}
+	return fmt.Sprint(phases), nil
+}
+
+// wantKind checks that err wraps want.
+func wantKind(err, want error, what string) (string, error) {
+	if err == nil {
+		return "", fmt.Errorf("%s succeeded, want error kind %s", what, platform.ErrorKind(want))
+	}
+	if !errors.Is(err, want) {
+		kind := platform.ErrorKind(err)
+		if kind == "" {
+			kind = "unclassified"
+		}
+		return "", fmt.Errorf("%s: %s error %q, want error kind %s", what, kind, err, platform.ErrorKind(want))
+	}
+	return platform.ErrorKind(want), nil
+}
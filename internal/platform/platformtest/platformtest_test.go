@@ -0,0 +1,103 @@
+package platformtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// sloppy is an adapter that breaks the contract in the ways the suite
+// looks for: a raw API status, an ignored limit, unclassified errors and a
+// watch that never closes its channel.
+type sloppy struct{}
+
+func (sloppy) Name() string { return "sloppy" }
+func (sloppy) Capabilities() platform.Capabilities {
+	return platform.CapDeployments | platform.CapWatch
+}
+func (sloppy) Validate(ctx context.Context, token string) error {
+	return errors.New("HTTP 401")
+}
+func (sloppy) GetServiceStatus(ctx context.Context, serviceID string) (*platform.ServiceStatus, error) {
+	return &platform.ServiceStatus{Status: "RUNNING"}, nil
+}
+func (sloppy) ListDeployments(ctx context.Context, serviceID string, limit int) ([]platform.Deployment, error) {
+	now := time.Now()
+	var deploys []platform.Deployment
+	for i := 0; i < 3; i++ {
+		deploys = append(deploys, platform.Deployment{ID: fmt.Sprint("d", i), Status: "healthy", CreatedAt: now.Add(-time.Duration(i) * time.Hour)})
+	}
+	return deploys, nil
+}
+func (sloppy) GetDeployment(ctx context.Context, deployID string) (*platform.Deployment, error) {
+	return &platform.Deployment{ID: deployID, Status: "healthy"}, nil
+}
+func (sloppy) Redeploy(ctx context.Context, serviceID string) (*platform.Deployment, error) {
+	return nil, errors.New("no")
+}
+func (sloppy) RollbackTo(ctx context.Context, serviceID, deployID string) (*platform.Deployment, error) {
+	return nil, fmt.Errorf("%w: no rollback", platform.ErrNotSupported)
+}
+func (sloppy) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: no cancel", platform.ErrNotSupported)
+}
+func (sloppy) GetLogs(ctx context.Context, serviceID string, opts platform.LogOptions) ([]platform.LogEntry, error) {
+	return nil, nil
+}
+func (sloppy) Scale(ctx context.Context, serviceID string, opts platform.ScaleOptions) error {
+	return platform.ErrNotSupported
+}
+func (sloppy) WatchDeployment(ctx context.Context, serviceID, currentDeployID string) (<-chan platform.DeployEvent, error) {
+	return make(chan platform.DeployEvent), nil
+}
+
+func TestCheckFindsViolations(t *testing.T) {
+	results := Check(context.Background(), sloppy{}, Fixture{
+		ServiceID:      "web",
+		MinDeployments: 2,
+		WatchIdle:      50 * time.Millisecond,
+	})
+
+	want := map[string]string{
+		"status":         `status "RUNNING" is not one of Orbit's statuses`,
+		"deployments":    "limit 1 returned 3 deployments",
+		"get-deployment": "",
+		"unauthorized":   "unclassified error",
+		"not-found":      "skipped",
+		"not-supported":  "GetLogs without the capability succeeded, want error kind not_supported",
+		"watch-idle":     "kept its channel open",
+		"watch-deploy":   "skipped",
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for _, r := range results {
+		w, ok := want[r.Check]
+		switch {
+		case !ok:
+			t.Errorf("unexpected check %s", r.Check)
+		case w == "skipped":
+			if !r.Skipped {
+				t.Errorf("%s: got %v, want skipped", r.Check, r.Err)
+			}
+		case w == "":
+			if r.Err != nil || r.Skipped {
+				t.Errorf("%s: got %v (skipped %v), want pass", r.Check, r.Err, r.Skipped)
+			}
+		case r.Err == nil || !strings.Contains(r.Err.Error(), w):
+			t.Errorf("%s: got %v, want error containing %q", r.Check, r.Err, w)
+		}
+	}
+}
+
+func TestChecksOrder(t *testing.T) {
+	names := Checks()
+	if len(names) == 0 || names[0] != "status" || names[len(names)-1] != "watch-deploy" {
+		t.Errorf("Checks() = %v", names)
+	}
+}
@@ -0,0 +1,460 @@
+package platform
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// PluginProtocol is the version of the external adapter protocol. It is
+// sent with every request so adapters can reject versions they don't know.
+const PluginProtocol = 1
+
+// PluginConfig describes an external adapter executable.
+type PluginConfig struct {
+	Command string
+	Args    []string
+	Env     map[string]string // added to Orbit's environment
+
+	// Capabilities the adapter implements; zero means all of them.
+	Capabilities Capabilities
+}
+
+// RegisterPlugin registers an external adapter under name. Built-in
+// platforms can't be replaced.
+func RegisterPlugin(name string, cfg PluginConfig) error {
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("platform %q is already registered", name)
+	}
+	if cfg.Command == "" {
+		return fmt.Errorf("plugin %q has no command", name)
+	}
+	Register(name, func(token string) Platform {
+		return NewPlugin(name, cfg, token)
+	})
+	return nil
+}
+
+// Plugin implements the Platform interface by running an external adapter.
+//
+// Each call starts the command, writes one JSON request to its stdin and
+// reads JSON lines from its stdout:
+//
+//	{"result": ...}            the call's return value
+//	{"error": "message"}       the call failed
+//	{"event": {...}}           watch only: one line per deploy event
+//
+// Cancelling the context kills the process. Anything the adapter writes
+// to stderr is reported if it exits without a result.
+type Plugin struct {
+	name  string
+	cfg   PluginConfig
+	token string
+}
+
+// NewPlugin creates a platform backed by an external adapter.
+func NewPlugin(name string, cfg PluginConfig, token string) *Plugin {
+	return &Plugin{name: name, cfg: cfg, token: token}
+}
+
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+func (p *Plugin) Capabilities() Capabilities {
+	if p.cfg.Capabilities == 0 {
+		return CapAll
+	}
+	return p.cfg.Capabilities
+}
+
+type pluginRequest struct {
+	Protocol int          `json:"protocol"`
+	Platform string       `json:"platform"`
+	Method   string       `json:"method"`
+	Token    string       `json:"token"`
+	Params   pluginParams `json:"params"`
+}
+
+type pluginParams struct {
+	ServiceID       string         `json:"service_id,omitempty"`
+	DeployID        string         `json:"deploy_id,omitempty"`
+	CurrentDeployID string         `json:"current_deploy_id,omitempty"`
+	Limit           int            `json:"limit,omitempty"`
+	Logs            *pluginLogOpts `json:"logs,omitempty"`
+	Scale           *pluginScale   `json:"scale,omitempty"`
+}
+
+type pluginLogOpts struct {
+	Level        string `json:"level,omitempty"`
+	Tail         int    `json:"tail,omitempty"`
+	SinceSeconds int    `json:"since_seconds,omitempty"`
+}
+
+type pluginScale struct {
+	MinInstances int    `json:"min_instances,omitempty"`
+	MaxInstances int    `json:"max_instances,omitempty"`
+	InstanceType string `json:"instance_type,omitempty"`
+}
+
+type pluginResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+	Code   string          `json:"code"` // error kind, see ErrorKind
+	Event  *pluginEvent    `json:"event"`
+}
+
+type pluginStatus struct {
+	Status       string            `json:"status"`
+	ResponseMs   int               `json:"response_ms"`
+	CPU          *float64          `json:"cpu"`
+	Memory       *float64          `json:"memory"`
+	Instances    int               `json:"instances"`
+	MaxInstances int               `json:"max_instances"`
+	LastDeploy   *pluginDeployment `json:"last_deploy"`
+	Name         string            `json:"name"`
+}
+
+type pluginDeployment struct {
+	ID              string    `json:"id"`
+	Status          string    `json:"status"`
+	Commit          string    `json:"commit"`
+	Message         string    `json:"message"`
+	CreatedAt       time.Time `json:"created_at"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	URL             string    `json:"url"`
+}
+
+func (d *pluginDeployment) toDeployment() Deployment {
+	return Deployment{
+		ID:        d.ID,
+		Status:    d.Status,
+		Commit:    d.Commit,
+		Message:   d.Message,
+		CreatedAt: d.CreatedAt,
+		Duration:  time.Duration(d.DurationSeconds * float64(time.Second)),
+		URL:       d.URL,
+	}
+}
+
+type pluginLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	Source    string    `json:"source"`
+}
+
+type pluginEvent struct {
+	Phase   string            `json:"phase"`
+	Message string            `json:"message"`
+	Deploy  *pluginDeployment `json:"deploy"`
+	Error   string            `json:"error"`
+	Logs    []string          `json:"logs"`
+}
+
+type pluginScaleInfo struct {
+	MinInstances int    `json:"min_instances"`
+	MaxInstances int    `json:"max_instances"`
+	InstanceType string `json:"instance_type"`
+}
+
+type pluginService struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// start launches the adapter for one call and returns its stdout. The
+// caller must call wait when done reading.
+func (p *Plugin) start(ctx context.Context, token, method string, params pluginParams) (io.Reader, func() error, error) {
+	req, err := json.Marshal(pluginRequest{
+		Protocol: PluginProtocol,
+		Platform: p.name,
+		Method:   method,
+		Token:    token,
+		Params:   params,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, p.cfg.Command, p.cfg.Args...)
+	cmd.Stdin = bytes.NewReader(append(req, '\n'))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if len(p.cfg.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range p.cfg.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("start %s adapter: %w", p.name, err)
+	}
+
+	wait := func() error {
+		err := cmd.Wait()
+		if err == nil || ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s adapter: %s", p.name, msg)
+		}
+		return fmt.Errorf("%s adapter: %w", p.name, err)
+	}
+	return stdout, wait, nil
+}
+
+// call runs a request/response method and decodes the result into out.
+func (p *Plugin) call(ctx context.Context, method string, params pluginParams, out interface{}) error {
+	return p.callToken(ctx, p.token, method, params, out)
+}
+
+func (p *Plugin) callToken(ctx context.Context, token, method string, params pluginParams, out interface{}) error {
+	stdout, wait, err := p.start(ctx, token, method, params)
+	if err != nil {
+		return err
+	}
+
+	var resp *pluginResponse
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var r pluginResponse
+		if err := json.Unmarshal(line, &r); err != nil {
+			continue // adapters may log to stdout; only JSON lines count
+		}
+		if r.Result != nil || r.Error != "" {
+			resp = &r
+			break
+		}
+	}
+	io.Copy(io.Discard, stdout)
+	waitErr := wait()
+
+	if resp == nil {
+		if waitErr != nil {
+			return waitErr
+		}
+		return fmt.Errorf("%s adapter returned no result for %s", p.name, method)
+	}
+	if resp.Error != "" {
+		return errorWithKind(resp.Error, resp.Code)
+	}
+	if out == nil || string(resp.Result) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// Validate asks the adapter to check the token.
+func (p *Plugin) Validate(ctx context.Context, token string) error {
+	return p.callToken(ctx, token, "validate", pluginParams{}, nil)
+}
+
+func (p *Plugin) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	var s pluginStatus
+	if err := p.call(ctx, "status", pluginParams{ServiceID: serviceID}, &s); err != nil {
+		return nil, err
+	}
+	status := &ServiceStatus{
+		Status:       s.Status,
+		ResponseMs:   s.ResponseMs,
+		CPU:          -1,
+		Memory:       -1,
+		Instances:    s.Instances,
+		MaxInstances: s.MaxInstances,
+		Name:         s.Name,
+	}
+	if s.CPU != nil {
+		status.CPU = *s.CPU
+	}
+	if s.Memory != nil {
+		status.Memory = *s.Memory
+	}
+	if s.LastDeploy != nil {
+		d := s.LastDeploy.toDeployment()
+		status.LastDeploy = &d
+	}
+	return status, nil
+}
+
+func (p *Plugin) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	var list []pluginDeployment
+	if err := p.call(ctx, "list_deployments", pluginParams{ServiceID: serviceID, Limit: limit}, &list); err != nil {
+		return nil, err
+	}
+	deploys := make([]Deployment, len(list))
+	for i := range list {
+		deploys[i] = list[i].toDeployment()
+	}
+	return deploys, nil
+}
+
+func (p *Plugin) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	var d pluginDeployment
+	if err := p.call(ctx, "get_deployment", pluginParams{DeployID: deployID}, &d); err != nil {
+		return nil, err
+	}
+	deploy := d.toDeployment()
+	return &deploy, nil
+}
+
+func (p *Plugin) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	var d pluginDeployment
+	if err := p.call(ctx, "redeploy", pluginParams{ServiceID: serviceID}, &d); err != nil {
+		return nil, err
+	}
+	deploy := d.toDeployment()
+	return &deploy, nil
+}
+
+func (p *Plugin) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	var d pluginDeployment
+	if err := p.call(ctx, "rollback", pluginParams{ServiceID: serviceID, DeployID: deployID}, &d); err != nil {
+		return nil, err
+	}
+	deploy := d.toDeployment()
+	return &deploy, nil
+}
+
+func (p *Plugin) CancelDeployment(ctx context.Context, deployID string) error {
+	return p.call(ctx, "cancel", pluginParams{DeployID: deployID}, nil)
+}
+
+// GetLogs fetches logs from the adapter. Entries without a level are
+// inferred from the message, as for built-in platforms.
+func (p *Plugin) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	var list []pluginLogEntry
+	params := pluginParams{
+		ServiceID: serviceID,
+		Logs: &pluginLogOpts{
+			Level:        opts.Level,
+			Tail:         opts.Tail,
+			SinceSeconds: int(opts.Since.Seconds()),
+		},
+	}
+	if err := p.call(ctx, "logs", params, &list); err != nil {
+		return nil, err
+	}
+
+	var entries []LogEntry
+	for _, l := range list {
+		level := l.Level
+		if level == "" {
+			level = InferLevel(l.Message, "info")
+		}
+		if !MatchesLevel(level, opts.Level) {
+			continue
+		}
+		entries = append(entries, LogEntry{Timestamp: l.Timestamp, Level: level, Message: l.Message, Source: l.Source})
+	}
+	return entries, nil
+}
+
+func (p *Plugin) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return p.call(ctx, "scale", pluginParams{
+		ServiceID: serviceID,
+		Scale: &pluginScale{
+			MinInstances: opts.MinInstances,
+			MaxInstances: opts.MaxInstances,
+			InstanceType: opts.InstanceType,
+		},
+	}, nil)
+}
+
+func (p *Plugin) GetCurrentScale(ctx context.Context, serviceID string) (min, max int, instanceType string, err error) {
+	var s pluginScaleInfo
+	if err := p.call(ctx, "current_scale", pluginParams{ServiceID: serviceID}, &s); err != nil {
+		return 0, 0, "", err
+	}
+	return s.MinInstances, s.MaxInstances, s.InstanceType, nil
+}
+
+func (p *Plugin) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	var list []pluginService
+	if err := p.call(ctx, "discover", pluginParams{}, &list); err != nil {
+		return nil, err
+	}
+	services := make([]DiscoveredService, len(list))
+	for i, s := range list {
+		services[i] = DiscoveredService{ID: s.ID, Name: s.Name, Platform: p.name}
+	}
+	return services, nil
+}
+
+// WatchDeployment keeps the adapter running and forwards each event line.
+// The adapter does its own polling and should exit after a done or failed
+// event.
+func (p *Plugin) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stdout, wait, err := p.start(ctx, p.token, "watch", pluginParams{ServiceID: serviceID, CurrentDeployID: currentDeployID})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ch := make(chan DeployEvent)
+	go func() {
+		defer close(ch)
+		defer cancel()
+
+		// Stop the adapter if we quit reading before it exits on its own.
+		stopped := false
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			var r pluginResponse
+			if err := json.Unmarshal(bytes.TrimSpace(scanner.Bytes()), &r); err != nil {
+				continue
+			}
+			if r.Error != "" {
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: errorWithKind(r.Error, r.Code)})
+				stopped = true
+				break
+			}
+			if r.Event == nil {
+				continue
+			}
+
+			ev := DeployEvent{Phase: r.Event.Phase, Message: r.Event.Message, Logs: r.Event.Logs}
+			if r.Event.Deploy != nil {
+				d := r.Event.Deploy.toDeployment()
+				ev.Deploy = &d
+			}
+			if r.Event.Error != "" {
+				ev.Error = fmt.Errorf("%s", r.Event.Error)
+			}
+			if !sendEvent(ctx, ch, ev) {
+				stopped = true
+				break
+			}
+		}
+		if stopped {
+			cancel()
+		}
+		io.Copy(io.Discard, stdout)
+		if err := wait(); err != nil && !stopped && ctx.Err() == nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: err})
+		}
+	}()
+	return ch, nil
+}
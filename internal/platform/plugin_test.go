@@ -0,0 +1,154 @@
+package platform
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestPluginHelper is not a real test: it acts as an external adapter when
+// run by the tests below.
+func TestPluginHelper(t *testing.T) {
+	if os.Getenv("ORBIT_TEST_PLUGIN") != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	var req pluginRequest
+	line, _ := bufio.NewReader(os.Stdin).ReadBytes('\n')
+	if err := json.Unmarshal(line, &req); err != nil {
+		fmt.Fprintln(os.Stderr, "bad request:", err)
+		os.Exit(2)
+	}
+
+	fmt.Println("starting adapter") // non-JSON output is ignored
+	switch req.Method {
+	case "validate":
+		if req.Token != "good" {
+			fmt.Println(`{"error":"invalid token: unauthorized","code":"unauthorized"}`)
+			return
+		}
+		fmt.Println(`{"result":true}`)
+	case "status":
+		fmt.Printf(`{"result":{"status":"healthy","cpu":12.5,"instances":2,"name":%q,"last_deploy":{"id":"d1","status":"healthy","duration_seconds":42}}}`+"\n", req.Params.ServiceID)
+	case "logs":
+		fmt.Println(`{"result":[{"message":"ERROR boom"},{"message":"GET / 200","level":"info"}]}`)
+	case "rollback":
+		fmt.Printf(`{"result":{"id":%q,"status":"healthy"}}`+"\n", req.Params.DeployID)
+	case "cancel":
+		if req.Params.DeployID != "d2" {
+			fmt.Println(`{"error":"deployment not found","code":"not_found"}`)
+			return
+		}
+		fmt.Println(`{"result":null}`)
+	case "watch":
+		fmt.Println(`{"event":{"phase":"detected","deploy":{"id":"d2"}}}`)
+		if req.Params.ServiceID == "hang" {
+			time.Sleep(time.Minute)
+		}
+		fmt.Println(`{"event":{"phase":"done","message":"Deploy successful!"}}`)
+	case "crash":
+		fmt.Fprintln(os.Stderr, "something broke")
+		os.Exit(1)
+	}
+}
+
+func newTestPlugin(t *testing.T, token string) *Plugin {
+	t.Helper()
+	return NewPlugin("acme", PluginConfig{
+		Command: os.Args[0],
+		Args:    []string{"-test.run=TestPluginHelper"},
+		Env:     map[string]string{"ORBIT_TEST_PLUGIN": "1"},
+	}, token)
+}
+
+func TestPluginCalls(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPlugin(t, "good")
+
+	if err := p.Validate(ctx, "good"); err != nil {
+		t.Errorf("Validate(good) = %v", err)
+	}
+	if err := p.Validate(ctx, "bad"); err == nil || err.Error() != "invalid token: unauthorized" || !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Validate(bad) = %v, want invalid token", err)
+	}
+
+	status, err := p.GetServiceStatus(ctx, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != "healthy" || status.CPU != 12.5 || status.Memory != -1 || status.Name != "web" {
+		t.Errorf("status = %+v", status)
+	}
+	if status.LastDeploy == nil || status.LastDeploy.Duration != 42*time.Second {
+		t.Errorf("last deploy = %+v", status.LastDeploy)
+	}
+
+	logs, err := p.GetLogs(ctx, "web", LogOptions{Level: "error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].Level != "error" {
+		t.Errorf("logs = %+v, want the inferred error line only", logs)
+	}
+
+	deploy, err := p.RollbackTo(ctx, "web", "d0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deploy.ID != "d0" {
+		t.Errorf("rollback deploy = %+v, want the target", deploy)
+	}
+
+	if err := p.CancelDeployment(ctx, "d2"); err != nil {
+		t.Errorf("CancelDeployment(d2) = %v", err)
+	}
+	if err := p.CancelDeployment(ctx, "d9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("CancelDeployment(d9) = %v, want not found", err)
+	}
+
+	if err := p.call(ctx, "crash", pluginParams{}, nil); err == nil || err.Error() != "acme adapter: something broke" {
+		t.Errorf("crash = %v, want stderr in error", err)
+	}
+}
+
+func TestPluginWatch(t *testing.T) {
+	p := newTestPlugin(t, "good")
+
+	ch, err := p.WatchDeployment(context.Background(), "web", "d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var phases []string
+	for ev := range ch {
+		phases = append(phases, ev.Phase)
+	}
+	if len(phases) != 2 || phases[0] != "detected" || phases[1] != "done" {
+		t.Errorf("phases = %v", phases)
+	}
+
+	// A cancelled watch kills the adapter and closes the channel.
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err = p.WatchDeployment(ctx, "hang", "d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	cancel()
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("watch did not stop after cancel")
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package platform
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit caps the request rate to a platform API. Requests are allowed in
+// bursts of up to Burst, refilling at PerSecond.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// DefaultRateLimit applies to hosted platforms without a configured limit.
+// It stays well under the published limits of Vercel, Koyeb and GitHub while
+// letting `orbit status --all` fan out freely for small setups.
+var DefaultRateLimit = RateLimit{PerSecond: 10, Burst: 20}
+
+// rateLimitHosts maps hosted platform API hosts to platform names. Self-hosted
+// platforms (custom, docker, kubernetes, plugins) are not limited.
+var rateLimitHosts = map[string]string{
+	"api.vercel.com":      "vercel",
+	"app.koyeb.com":       "koyeb",
+	"api.supabase.com":    "supabase",
+	"console.neon.tech":   "neon",
+	"api.planetscale.com": "planetscale",
+	"api.render.com":      "render",
+	"api.machines.dev":    "flyio",
+	"api.github.com":      "github",
+}
+
+var (
+	rateMu      sync.Mutex
+	rateLimits  = map[string]RateLimit{}    // platform name -> configured limit
+	rateBuckets = map[string]*tokenBucket{} // host + credentials -> bucket
+)
+
+// SetRateLimit configures the limit for a platform. A zero PerSecond restores
+// the default; a negative one disables limiting. It must be called before
+// the platform's first request to take effect.
+func SetRateLimit(name string, rl RateLimit) {
+	rateMu.Lock()
+	defer rateMu.Unlock()
+	if rl.PerSecond == 0 {
+		delete(rateLimits, name)
+		return
+	}
+	rateLimits[name] = rl
+}
+
+// bucketFor returns the bucket shared by every request to req's host with
+// req's credentials, so concurrent clients using the same token draw from
+// one budget. It returns nil when the request is not limited.
+func bucketFor(req *http.Request) *tokenBucket {
+	name, ok := rateLimitHosts[req.URL.Hostname()]
+	if !ok {
+		return nil
+	}
+	key := req.URL.Host + "\x00" + req.Header.Get("Authorization")
+
+	rateMu.Lock()
+	defer rateMu.Unlock()
+	if b, ok := rateBuckets[key]; ok {
+		return b
+	}
+	rl, ok := rateLimits[name]
+	if !ok {
+		rl = DefaultRateLimit
+	}
+	if rl.PerSecond < 0 {
+		return nil
+	}
+	b := newTokenBucket(rl, time.Now)
+	rateBuckets[key] = b
+	return b
+}
+
+// tokenBucket is a token bucket safe for concurrent use. Callers reserve a
+// token and wait out the returned delay, so waiters are served in order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rl RateLimit, now func() time.Time) *tokenBucket {
+	burst := float64(max(rl.Burst, 1))
+	return &tokenBucket{rate: rl.PerSecond, burst: burst, tokens: burst, last: now(), now: now}
+}
+
+// reserve takes a token and returns how long to wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// wait blocks until a token is available. It returns false if ctx is
+// cancelled first.
+func (b *tokenBucket) wait(ctx context.Context, sleep func(context.Context, time.Duration) bool) bool {
+	d := b.reserve()
+	if d <= 0 {
+		return true
+	}
+	return sleep(ctx, d)
+}
@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(RateLimit{PerSecond: 2, Burst: 2}, func() time.Time { return now })
+
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(); d != 0 {
+			t.Fatalf("burst request %d waited %v", i+1, d)
+		}
+	}
+	if d := b.reserve(); d != 500*time.Millisecond {
+		t.Errorf("third request wait = %v, want 500ms", d)
+	}
+	// Queued waiters are spaced out rather than all released at once.
+	if d := b.reserve(); d != time.Second {
+		t.Errorf("fourth request wait = %v, want 1s", d)
+	}
+
+	now = now.Add(10 * time.Second)
+	if d := b.reserve(); d != 0 {
+		t.Errorf("after refill wait = %v, want 0", d)
+	}
+}
+
+func TestBucketFor(t *testing.T) {
+	req := func(url, token string) *http.Request {
+		r, _ := http.NewRequest("GET", url, nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return r
+	}
+
+	a := bucketFor(req("https://api.vercel.com/v6/deployments", "t1"))
+	if a == nil {
+		t.Fatal("vercel request not rate limited")
+	}
+	if b := bucketFor(req("https://api.vercel.com/v9/projects", "t1")); b != a {
+		t.Error("same token should share a bucket")
+	}
+	if b := bucketFor(req("https://api.vercel.com/v9/projects", "t2")); b == a {
+		t.Error("different tokens should not share a bucket")
+	}
+	if b := bucketFor(req("http://localhost:8080/status", "t1")); b != nil {
+		t.Error("self-hosted request should not be rate limited")
+	}
+
+	SetRateLimit("render", RateLimit{PerSecond: -1})
+	defer SetRateLimit("render", RateLimit{})
+	if b := bucketFor(req("https://api.render.com/v1/services", "t1")); b != nil {
+		t.Error("disabled limit still returned a bucket")
+	}
+}
@@ -0,0 +1,204 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// registryScheme is replaced in tests, which serve a registry over plain HTTP.
+var registryScheme = "https"
+
+// manifestAccept lists the manifest formats imageSize understands.
+var manifestAccept = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+type imageManifest struct {
+	Config struct {
+		Size int64 `json:"size"`
+	} `json:"config"`
+	Layers []struct {
+		Size int64 `json:"size"`
+	} `json:"layers"`
+	// Manifests is set for multi-platform images.
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			OS           string `json:"os"`
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+// imageSize returns the compressed size of a container image, its config
+// plus layers, as listed in the registry manifest. Multi-platform images
+// count their linux/amd64 variant. Registries are queried anonymously, so
+// private images yield an error.
+func imageSize(ctx context.Context, client *http.Client, ref string) (int64, error) {
+	host, repo, reference := parseImageRef(ref)
+	r := &registryClient{client: client, host: host, repo: repo}
+
+	m, err := r.manifest(ctx, reference)
+	if err != nil {
+		return 0, err
+	}
+	if len(m.Manifests) > 0 {
+		digest := m.Manifests[0].Digest
+		for _, sub := range m.Manifests {
+			if sub.Platform.OS == "linux" && sub.Platform.Architecture == "amd64" {
+				digest = sub.Digest
+				break
+			}
+		}
+		if m, err = r.manifest(ctx, digest); err != nil {
+			return 0, err
+		}
+	}
+
+	size := m.Config.Size
+	for _, l := range m.Layers {
+		size += l.Size
+	}
+	return size, nil
+}
+
+// parseImageRef splits an image reference such as "nginx:1.25" or
+// "ghcr.io/acme/api@sha256:..." into registry host, repository and tag or
+// digest, applying Docker Hub's defaults.
+func parseImageRef(ref string) (host, repo, reference string) {
+	reference = "latest"
+	if name, digest, ok := strings.Cut(ref, "@"); ok {
+		ref, reference = name, digest
+	} else if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref, reference = ref[:i], ref[i+1:]
+	}
+
+	host = "registry-1.docker.io"
+	if first, rest, ok := strings.Cut(ref, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		host, ref = first, rest
+	} else if !ok {
+		ref = "library/" + ref
+	}
+	if host == "docker.io" || host == "index.docker.io" {
+		host = "registry-1.docker.io"
+	}
+	return host, ref, reference
+}
+
+type registryClient struct {
+	client     *http.Client
+	host, repo string
+	token      string
+}
+
+// manifest fetches a manifest by tag or digest, getting an anonymous pull
+// token first if the registry asks for one.
+func (r *registryClient) manifest(ctx context.Context, reference string) (*imageManifest, error) {
+	u := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", registryScheme, r.host, r.repo, reference)
+	resp, err := r.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if r.token, err = r.anonymousToken(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = r.get(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("image %s/%s: %w", r.host, r.repo, ErrUnauthorized)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("image %w: %s/%s:%s", ErrNotFound, r.host, r.repo, reference)
+	default:
+		return nil, fmt.Errorf("registry %s returned status %d", r.host, resp.StatusCode)
+	}
+
+	var m imageManifest
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	return &m, nil
+}
+
+func (r *registryClient) get(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", manifestAccept)
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get manifest: %w", err)
+	}
+	return resp, nil
+}
+
+// anonymousToken answers a Bearer challenge such as
+// `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"`.
+func (r *registryClient) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	params, ok := strings.CutPrefix(challenge, "Bearer ")
+	if !ok {
+		return "", fmt.Errorf("image %s/%s: %w", r.host, r.repo, ErrUnauthorized)
+	}
+	var realm string
+	q := url.Values{}
+	for _, p := range strings.Split(params, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+		v = strings.Trim(v, `"`)
+		switch k {
+		case "realm":
+			realm = v
+		case "service", "scope":
+			q.Set(k, v)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("registry %s: no token realm in challenge", r.host)
+	}
+	if q.Get("scope") == "" {
+		q.Set("scope", "repository:"+r.repo+":pull")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("image %s/%s: %w", r.host, r.repo, ErrUnauthorized)
+	}
+
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("decode registry token: %w", err)
+	}
+	if tok.Token == "" {
+		tok.Token = tok.AccessToken
+	}
+	return tok.Token, nil
+}
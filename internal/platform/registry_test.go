@@ -0,0 +1,71 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseImageRef(t *testing.T) {
+	tests := []struct {
+		ref, host, repo, reference string
+	}{
+		{"nginx", "registry-1.docker.io", "library/nginx", "latest"},
+		{"nginx:1.25", "registry-1.docker.io", "library/nginx", "1.25"},
+		{"acme/api:v2", "registry-1.docker.io", "acme/api", "v2"},
+		{"docker.io/acme/api", "registry-1.docker.io", "acme/api", "latest"},
+		{"ghcr.io/acme/api@sha256:abc", "ghcr.io", "acme/api", "sha256:abc"},
+		{"localhost:5000/api:dev", "localhost:5000", "api", "dev"},
+	}
+	for _, tt := range tests {
+		host, repo, reference := parseImageRef(tt.ref)
+		if host != tt.host || repo != tt.repo || reference != tt.reference {
+			t.Errorf("parseImageRef(%q) = %q, %q, %q", tt.ref, host, repo, reference)
+		}
+	}
+}
+
+func TestImageSize(t *testing.T) {
+	registryScheme = "http"
+	defer func() { registryScheme = "https" }()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			if r.URL.Query().Get("scope") != "repository:acme/api:pull" {
+				t.Errorf("token scope = %q", r.URL.Query().Get("scope"))
+			}
+			fmt.Fprint(w, `{"token":"anon"}`)
+		case r.Header.Get("Authorization") != "Bearer anon":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:acme/api:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/acme/api/manifests/v2":
+			fmt.Fprint(w, `{"manifests":[
+				{"digest":"sha256:arm","platform":{"os":"linux","architecture":"arm64"}},
+				{"digest":"sha256:amd","platform":{"os":"linux","architecture":"amd64"}}]}`)
+		case r.URL.Path == "/v2/acme/api/manifests/sha256:amd":
+			fmt.Fprint(w, `{"config":{"size":1000},"layers":[{"size":30000},{"size":5000}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	size, err := imageSize(context.Background(), srv.Client(), host+"/acme/api:v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 36000 {
+		t.Errorf("size = %d, want 36000", size)
+	}
+
+	if _, err := imageSize(context.Background(), srv.Client(), host+"/acme/api:missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing tag: err = %v, want ErrNotFound", err)
+	}
+}
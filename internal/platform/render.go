@@ -2,6 +2,7 @@ package platform
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,13 +25,15 @@ type Render struct {
 	token      string
 	ownerID    string
 	httpClient *http.Client
+
+	poller
 }
 
 // NewRender creates a new Render platform instance.
 func NewRender(token string) *Render {
 	return &Render{
 		token:      token,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient: newHTTPClient(15 * time.Second),
 	}
 }
 
@@ -38,18 +41,22 @@ func (r *Render) Name() string {
 	return "render"
 }
 
-func (r *Render) doRequest(method, path string, body []byte) (*http.Response, error) {
-	return r.doRequestRaw(method, renderBaseURL+path, body)
+func (r *Render) Capabilities() Capabilities {
+	return CapDeployments | CapRedeploy | CapLogs | CapScale | CapWatch | CapDiscover | CapMetrics | CapCancel
+}
+
+func (r *Render) doRequest(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	return r.doRequestRaw(ctx, method, renderBaseURL+path, body)
 }
 
-func (r *Render) doRequestRaw(method, url string, body []byte) (*http.Response, error) {
+func (r *Render) doRequestRaw(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
 	var reqBody *bytes.Reader
 	if body != nil {
 		reqBody = bytes.NewReader(body)
 	} else {
 		reqBody = bytes.NewReader(nil)
 	}
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +67,9 @@ func (r *Render) doRequestRaw(method, url string, body []byte) (*http.Response,
 }
 
 // Validate checks whether the token is valid by calling GET /owners.
-func (r *Render) Validate(token string) error {
-	client := &http.Client{Timeout: 15 * time.Second}
-	req, err := http.NewRequest("GET", renderBaseURL+"/owners", nil)
+func (r *Render) Validate(ctx context.Context, token string) error {
+	client := newHTTPClient(15 * time.Second)
+	req, err := http.NewRequestWithContext(ctx, "GET", renderBaseURL+"/owners", nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
@@ -76,26 +83,26 @@ func (r *Render) Validate(token string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
-		return fmt.Errorf("invalid token: unauthorized")
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
 	}
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return statusError("render", resp.StatusCode)
 	}
 	return nil
 }
 
-func (r *Render) getOwnerID() (string, error) {
+func (r *Render) getOwnerID(ctx context.Context) (string, error) {
 	if r.ownerID != "" {
 		return r.ownerID, nil
 	}
-	resp, err := r.doRequest("GET", "/owners", nil)
+	resp, err := r.doRequest(ctx, "GET", "/owners", nil)
 	if err != nil {
 		return "", fmt.Errorf("get owners: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return "", statusError("render", resp.StatusCode)
 	}
 
 	var owners []struct {
@@ -157,20 +164,21 @@ func (d *renderDeploy) toDeployment() Deployment {
 	return dep
 }
 
-func (r *Render) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
+func (r *Render) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
 	// Get service info
-	resp, err := r.doRequest("GET", "/services/"+serviceID, nil)
+	resp, err := r.doRequest(ctx, "GET", "/services/"+serviceID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("get service: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return nil, statusError("render", resp.StatusCode)
 	}
 
 	var svc struct {
 		Suspended string `json:"suspended"` // not_suspended / suspended
+		Branch    string `json:"branch"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&svc); err != nil {
 		return nil, fmt.Errorf("decode service: %w", err)
@@ -178,6 +186,8 @@ func (r *Render) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
 
 	status := &ServiceStatus{
 		Status: "healthy",
+		CPU:    -1, // filled in by GetMetrics
+		Memory: -1,
 	}
 
 	if svc.Suspended == "suspended" {
@@ -185,9 +195,10 @@ func (r *Render) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
 	}
 
 	// Get latest deploy
-	deploys, err := r.ListDeployments(serviceID, 1)
+	deploys, err := r.listDeploys(ctx, serviceID, 1)
 	if err == nil && len(deploys) > 0 {
 		d := deploys[0]
+		d.Branch = svc.Branch
 		status.LastDeploy = &d
 		if svc.Suspended != "suspended" {
 			status.Status = d.Status
@@ -197,15 +208,114 @@ func (r *Render) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
 	return status, nil
 }
 
-func (r *Render) ListDeployments(serviceID string, limit int) ([]Deployment, error) {
-	resp, err := r.doRequest("GET", fmt.Sprintf("/services/%s/deploys?limit=%d", serviceID, limit), nil)
+// ListDomains returns the service's onrender.com URL and custom domains.
+// Render issues certificates for verified domains automatically.
+func (r *Render) ListDomains(ctx context.Context, serviceID string) ([]Domain, error) {
+	resp, err := r.doRequest(ctx, "GET", "/services/"+serviceID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, statusError("render", resp.StatusCode)
+	}
+	var svc struct {
+		ServiceDetails struct {
+			URL string `json:"url"`
+		} `json:"serviceDetails"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&svc); err != nil {
+		return nil, fmt.Errorf("decode service: %w", err)
+	}
+
+	var domains []Domain
+	if u, err := url.Parse(svc.ServiceDetails.URL); err == nil && u.Host != "" {
+		domains = append(domains, Domain{Name: u.Host, Verified: true, SSL: "active"})
+	}
+
+	resp, err = r.doRequest(ctx, "GET", fmt.Sprintf("/services/%s/custom-domains?limit=100", serviceID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("list custom domains: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, statusError("render", resp.StatusCode)
+	}
+
+	// Wrapped in cursor objects like deploys: [{"customDomain": {...}}, ...]
+	var items []struct {
+		CustomDomain struct {
+			Name               string `json:"name"`
+			VerificationStatus string `json:"verificationStatus"`
+			RedirectForName    string `json:"redirectForName"`
+		} `json:"customDomain"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("decode custom domains: %w", err)
+	}
+	for _, item := range items {
+		d := item.CustomDomain
+		domain := Domain{
+			Name:     d.Name,
+			Custom:   true,
+			Verified: d.VerificationStatus == "verified",
+			SSL:      "pending",
+		}
+		if domain.Verified {
+			domain.SSL = "active"
+		}
+		if d.RedirectForName != "" {
+			domain.Note = "redirect for " + d.RedirectForName
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// ListDeployments lists recent deploys. Render's deploy objects carry the
+// commit but not the branch, so the branch is the service's configured one.
+func (r *Render) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	deployments, err := r.listDeploys(ctx, serviceID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if branch := r.serviceBranch(ctx, serviceID); branch != "" {
+		for i := range deployments {
+			deployments[i].Branch = branch
+		}
+	}
+	return deployments, nil
+}
+
+// serviceBranch returns the branch a service deploys from, or "" for image
+// services and on error.
+func (r *Render) serviceBranch(ctx context.Context, serviceID string) string {
+	resp, err := r.doRequest(ctx, "GET", "/services/"+serviceID, nil)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return ""
+	}
+	var svc struct {
+		Branch string `json:"branch"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&svc); err != nil {
+		return ""
+	}
+	return svc.Branch
+}
+
+func (r *Render) listDeploys(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	resp, err := r.doRequest(ctx, "GET", fmt.Sprintf("/services/%s/deploys?limit=%d", serviceID, limit), nil)
 	if err != nil {
 		return nil, fmt.Errorf("list deployments: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return nil, statusError("render", resp.StatusCode)
 	}
 
 	// Render wraps each deploy in a cursor object: [{"deploy": {...}}, ...]
@@ -225,24 +335,24 @@ func (r *Render) ListDeployments(serviceID string, limit int) ([]Deployment, err
 
 // GetDeployment retrieves a single deployment.
 // deployID should be "serviceID/deployID" since Render requires both.
-func (r *Render) GetDeployment(deployID string) (*Deployment, error) {
+func (r *Render) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
 	parts := strings.SplitN(deployID, "/", 2)
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("render deploy ID must be serviceID/deployID, got: %s", deployID)
 	}
 	svcID, dID := parts[0], parts[1]
 
-	resp, err := r.doRequest("GET", fmt.Sprintf("/services/%s/deploys/%s", svcID, dID), nil)
+	resp, err := r.doRequest(ctx, "GET", fmt.Sprintf("/services/%s/deploys/%s", svcID, dID), nil)
 	if err != nil {
 		return nil, fmt.Errorf("get deployment: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("deployment not found: %s", deployID)
+		return nil, fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return nil, statusError("render", resp.StatusCode)
 	}
 
 	var d renderDeploy
@@ -254,15 +364,15 @@ func (r *Render) GetDeployment(deployID string) (*Deployment, error) {
 	return &dep, nil
 }
 
-func (r *Render) Redeploy(serviceID string) (*Deployment, error) {
-	resp, err := r.doRequest("POST", fmt.Sprintf("/services/%s/deploys", serviceID), []byte("{}"))
+func (r *Render) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	resp, err := r.doRequest(ctx, "POST", fmt.Sprintf("/services/%s/deploys", serviceID), []byte("{}"))
 	if err != nil {
 		return nil, fmt.Errorf("trigger deploy: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 201 && resp.StatusCode != 200 {
-		return nil, fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return nil, statusError("render", resp.StatusCode)
 	}
 
 	var d renderDeploy
@@ -274,13 +384,67 @@ func (r *Render) Redeploy(serviceID string) (*Deployment, error) {
 	return &dep, nil
 }
 
-func (r *Render) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
+func (r *Render) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: roll back from the Render dashboard", ErrNotSupported)
+}
+
+// CancelDeployment cancels an in-progress deploy. Render deploy IDs are
+// "serviceID/deployID".
+func (r *Render) CancelDeployment(ctx context.Context, deployID string) error {
+	svcID, dID, ok := strings.Cut(deployID, "/")
+	if !ok {
+		return fmt.Errorf("render deploy ID must be serviceID/deployID, got: %s", deployID)
+	}
+
+	resp, err := r.doRequest(ctx, "POST", fmt.Sprintf("/services/%s/deploys/%s/cancel", svcID, dID), nil)
+	if err != nil {
+		return fmt.Errorf("cancel deployment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
+	}
+	if resp.StatusCode != 200 {
+		return statusError("render", resp.StatusCode)
+	}
+	return nil
+}
+
+// Pause suspends the service. A suspended service is not billed and
+// serves no traffic.
+func (r *Render) Pause(ctx context.Context, serviceID string) error {
+	return r.serviceAction(ctx, serviceID, "suspend")
+}
+
+// Resume resumes a suspended service.
+func (r *Render) Resume(ctx context.Context, serviceID string) error {
+	return r.serviceAction(ctx, serviceID, "resume")
+}
+
+func (r *Render) serviceAction(ctx context.Context, serviceID, action string) error {
+	resp, err := r.doRequest(ctx, "POST", fmt.Sprintf("/services/%s/%s", serviceID, action), nil)
+	if err != nil {
+		return fmt.Errorf("%s service: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("service %w: %s", ErrNotFound, serviceID)
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 202 {
+		return statusError("render", resp.StatusCode)
+	}
+	return nil
+}
+
+func (r *Render) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
 	limit := 100
 	if opts.Tail > 0 {
 		limit = opts.Tail
 	}
 
-	ownerID, err := r.getOwnerID()
+	ownerID, err := r.getOwnerID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get owner: %w", err)
 	}
@@ -298,7 +462,7 @@ func (r *Render) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error)
 
 	reqURL := renderBaseURL + "/logs?" + params.Encode()
 
-	resp, err := r.doRequestRaw("GET", reqURL, nil)
+	resp, err := r.doRequestRaw(ctx, "GET", reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("get logs: %w", err)
 	}
@@ -306,7 +470,7 @@ func (r *Render) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error)
 
 	if resp.StatusCode != 200 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("render API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("%w: %s", statusError("render", resp.StatusCode), string(bodyBytes))
 	}
 
 	var logsResp struct {
@@ -348,33 +512,132 @@ func (r *Render) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error)
 	return entries, nil
 }
 
-func (r *Render) Scale(serviceID string, opts ScaleOptions) error {
+// GetMetrics reads CPU and memory usage and divides them by the
+// instance's limits, averaging the latest sample of each instance.
+func (r *Render) GetMetrics(ctx context.Context, serviceID string) (*Metrics, error) {
+	m := &Metrics{CPU: -1, Memory: -1}
+	for _, q := range []struct {
+		used, limit string
+		out         *float64
+	}{
+		{"cpu", "cpu-limit", &m.CPU},
+		{"memory", "memory-limit", &m.Memory},
+	} {
+		used, err := r.queryMetric(ctx, serviceID, q.used)
+		if err != nil {
+			return nil, err
+		}
+		limit, err := r.queryMetric(ctx, serviceID, q.limit)
+		if err != nil {
+			return nil, err
+		}
+		u, okU := latestMean(used)
+		l, okL := latestMean(limit)
+		if okU && okL && l > 0 {
+			*q.out = u / l * 100
+		}
+	}
+	return m, nil
+}
+
+// queryMetric returns the recent samples of one metric, one series per instance.
+func (r *Render) queryMetric(ctx context.Context, serviceID, metric string) ([][]metricSample, error) {
+	end := time.Now().UTC()
+	q := url.Values{}
+	q.Set("resource", serviceID)
+	q.Set("startTime", end.Add(-metricsWindow).Format(time.RFC3339))
+	q.Set("endTime", end.Format(time.RFC3339))
+	q.Set("resolutionSeconds", "60")
+
+	resp, err := r.doRequest(ctx, "GET", "/metrics/"+metric+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get %s metrics: %w", metric, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError("render metrics", resp.StatusCode)
+	}
+
+	var result []struct {
+		Values []struct {
+			Timestamp time.Time `json:"timestamp"`
+			Value     float64   `json:"value"`
+		} `json:"values"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode %s metrics: %w", metric, err)
+	}
+
+	series := make([][]metricSample, len(result))
+	for i, s := range result {
+		for _, v := range s.Values {
+			series[i] = append(series[i], metricSample{At: v.Timestamp, Value: v.Value})
+		}
+	}
+	return series, nil
+}
+
+func (r *Render) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
 	body, err := json.Marshal(map[string]int{"numInstances": opts.MinInstances})
 	if err != nil {
 		return fmt.Errorf("marshal body: %w", err)
 	}
 
-	resp, err := r.doRequest("POST", fmt.Sprintf("/services/%s/scale", serviceID), body)
+	resp, err := r.doRequest(ctx, "POST", fmt.Sprintf("/services/%s/scale", serviceID), body)
 	if err != nil {
 		return fmt.Errorf("scale service: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 && resp.StatusCode != 202 {
-		return fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return statusError("render", resp.StatusCode)
 	}
 	return nil
 }
 
-func (r *Render) DiscoverServices() ([]DiscoveredService, error) {
-	resp, err := r.doRequest("GET", "/services?limit=100", nil)
+// GetCurrentScale implements ScaleInfoProvider. The instance type is the
+// service's plan; without autoscaling, min and max are the instance count.
+func (r *Render) GetCurrentScale(ctx context.Context, serviceID string) (min, max int, instanceType string, err error) {
+	resp, err := r.doRequest(ctx, "GET", "/services/"+serviceID, nil)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("get service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return 0, 0, "", statusError("render", resp.StatusCode)
+	}
+	var svc struct {
+		ServiceDetails struct {
+			Plan         string `json:"plan"`
+			NumInstances int    `json:"numInstances"`
+			Autoscaling  *struct {
+				Enabled bool `json:"enabled"`
+				Min     int  `json:"min"`
+				Max     int  `json:"max"`
+			} `json:"autoscaling"`
+		} `json:"serviceDetails"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&svc); err != nil {
+		return 0, 0, "", fmt.Errorf("decode service: %w", err)
+	}
+	d := svc.ServiceDetails
+	if a := d.Autoscaling; a != nil && a.Enabled {
+		return a.Min, a.Max, d.Plan, nil
+	}
+	return d.NumInstances, d.NumInstances, d.Plan, nil
+}
+
+func (r *Render) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	resp, err := r.doRequest(ctx, "GET", "/services?limit=100", nil)
 	if err != nil {
 		return nil, fmt.Errorf("list services: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("render API returned status %d", resp.StatusCode)
+		return nil, statusError("render", resp.StatusCode)
 	}
 
 	// Render wraps each service: [{"service": {...}}, ...]
@@ -399,69 +662,79 @@ func (r *Render) DiscoverServices() ([]DiscoveredService, error) {
 	return services, nil
 }
 
-func (r *Render) WatchDeployment(serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+func (r *Render) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
 	ch := make(chan DeployEvent)
 
 	go func() {
 		defer close(ch)
 
-		const pollInterval = 3 * time.Second
+		pollInterval := r.interval()
 
 		// Check if the latest deployment is already in-progress.
-		deploys, err := r.ListDeployments(serviceID, 1)
+		deploys, err := r.listDeploys(ctx, serviceID, 1)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
 			return
 		}
-		if len(deploys) > 0 && isInProgress(deploys[0].Status) {
+		if len(deploys) > 0 && IsInProgress(deploys[0].Status) {
 			d := deploys[0]
-			ch <- DeployEvent{
+			d.Branch = r.serviceBranch(ctx, serviceID)
+			if !sendEvent(ctx, ch, DeployEvent{
 				Phase:   "detected",
 				Message: fmt.Sprintf("In-progress deployment found (%s)", d.ID),
 				Deploy:  &d,
+			}) {
+				return
 			}
-			r.trackDeployment(ch, serviceID, d.ID)
+			r.trackDeployment(ctx, ch, serviceID, d.ID)
 			return
 		}
 
 		// Phase 1: Detect a new deployment
 		for {
-			deploys, err := r.ListDeployments(serviceID, 1)
+			deploys, err := r.listDeploys(ctx, serviceID, 1)
 			if err != nil {
-				ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)}
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
 				return
 			}
 
 			if len(deploys) > 0 {
 				d := deploys[0]
 				if d.ID != currentDeployID {
-					ch <- DeployEvent{
+					d.Branch = r.serviceBranch(ctx, serviceID)
+					if !sendEvent(ctx, ch, DeployEvent{
 						Phase:   "detected",
 						Message: fmt.Sprintf("New deployment detected! (%s)", d.ID),
 						Deploy:  &d,
+					}) {
+						return
 					}
-					r.trackDeployment(ch, serviceID, d.ID)
+					r.trackDeployment(ctx, ch, serviceID, d.ID)
 					return
 				}
 			}
 
-			ch <- DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}
-			time.Sleep(pollInterval)
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
 		}
 	}()
 
 	return ch, nil
 }
 
-func (r *Render) trackDeployment(ch chan<- DeployEvent, serviceID, deployID string) {
-	const pollInterval = 3 * time.Second
+func (r *Render) trackDeployment(ctx context.Context, ch chan<- DeployEvent, serviceID, deployID string) {
+	pollInterval := r.interval()
 	lastPhase := ""
 	compositeID := serviceID + "/" + deployID
 
 	for {
-		deploy, err := r.GetDeployment(compositeID)
+		deploy, err := r.GetDeployment(ctx, compositeID)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)})
 			return
 		}
 
@@ -477,18 +750,22 @@ func (r *Render) trackDeployment(ch chan<- DeployEvent, serviceID, deployID stri
 				event.Message = "Deploying..."
 			case "done":
 				event.Message = "Deploy successful!"
-				ch <- event
+				sendEvent(ctx, ch, event)
 				return
 			case "failed":
 				event.Message = "Deployment failed!"
 				event.Error = fmt.Errorf("deployment %s failed", deployID)
-				ch <- event
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
 				return
 			}
-			ch <- event
 		}
 
-		time.Sleep(pollInterval)
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
 	}
 }
 
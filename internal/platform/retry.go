@@ -0,0 +1,140 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retry policy for platform API calls. A single flaky response should not
+// fail a status check or abort a watch, but retries must stay well inside
+// the client timeout.
+const (
+	retryAttempts  = 3
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second // longer Retry-After waits are not honored
+)
+
+// newHTTPClient returns a client for platform APIs that retries transient
+// failures (see retryTransport). The timeout covers all attempts.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: withRetry(nil)}
+}
+
+// withRetry wraps base (http.DefaultTransport if nil) in a retryTransport.
+// Each attempt goes through a traceTransport.
+func withRetry(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: &traceTransport{base: base}, sleep: sleepContext}
+}
+
+// retryTransport retries requests that failed transiently, with jittered
+// exponential backoff. 429 responses are retried for every method, after
+// Retry-After when the server sends one. 5xx responses and network errors
+// are retried only for idempotent methods, since a failed POST may still
+// have triggered a deployment. Every attempt first waits for the platform's
+// rate limit (see bucketFor).
+type retryTransport struct {
+	base  http.RoundTripper
+	sleep func(ctx context.Context, d time.Duration) bool
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	bucket := bucketFor(req)
+	for attempt := 1; ; attempt++ {
+		if bucket != nil && !bucket.wait(req.Context(), t.sleep) {
+			return nil, req.Context().Err()
+		}
+		resp, err := t.base.RoundTrip(req)
+		if attempt == retryAttempts || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if ra, ok := retryAfter(resp); ok {
+				if ra > retryMaxDelay {
+					return resp, nil
+				}
+				delay = ra
+			}
+			// Drain so the connection can be reused.
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if !t.sleep(req.Context(), delay) {
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return false // body can't be replayed
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !idempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		var uerr interface{ Timeout() bool }
+		// Client timeouts are final; connection resets and refusals are not.
+		return !errors.As(err, &uerr) || !uerr.Timeout()
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before retry n (1-based): the base delay
+// doubled per attempt, with up to 50% jitter so parallel watches spread out.
+func backoff(n int) time.Duration {
+	d := retryBaseDelay << (n - 1)
+	return d + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
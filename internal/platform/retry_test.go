@@ -0,0 +1,105 @@
+package platform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		responses []int
+		wantCode  int
+		wantHits  int
+	}{
+		{"GET retries 503", "GET", []int{503, 200}, 200, 2},
+		{"GET gives up after attempts", "GET", []int{502, 502, 502, 200}, 502, 3},
+		{"POST does not retry 500", "POST", []int{500, 200}, 500, 1},
+		{"POST retries 429", "POST", []int{429, 201}, 201, 2},
+		{"GET does not retry 404", "GET", []int{404, 200}, 404, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "POST" {
+					buf := make([]byte, 16)
+					n, _ := r.Body.Read(buf)
+					if string(buf[:n]) != "payload" {
+						t.Errorf("attempt %d body = %q, want replayed payload", hits+1, buf[:n])
+					}
+				}
+				w.WriteHeader(tt.responses[hits])
+				hits++
+			}))
+			defer srv.Close()
+
+			var waits []time.Duration
+			client := &http.Client{Transport: &retryTransport{
+				base:  http.DefaultTransport,
+				sleep: func(_ context.Context, d time.Duration) bool { waits = append(waits, d); return true },
+			}}
+			req, _ := http.NewRequest(tt.method, srv.URL, strings.NewReader("payload"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantCode || hits != tt.wantHits {
+				t.Errorf("got status %d after %d hits, want %d after %d", resp.StatusCode, hits, tt.wantCode, tt.wantHits)
+			}
+			if len(waits) != tt.wantHits-1 {
+				t.Errorf("waited %d times, want %d", len(waits), tt.wantHits-1)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", r.URL.Query().Get("after"))
+		w.WriteHeader(429)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: &retryTransport{
+		base:  http.DefaultTransport,
+		sleep: func(_ context.Context, d time.Duration) bool { waits = append(waits, d); return true },
+	}}
+
+	resp, err := client.Get(srv.URL + "?after=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(waits) != 2 || waits[0] != 2*time.Second {
+		t.Errorf("waits = %v, want Retry-After honored", waits)
+	}
+
+	// Waits beyond retryMaxDelay return the 429 straight away.
+	waits = nil
+	resp, err = client.Get(srv.URL + "?after=3600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 429 || len(waits) != 0 {
+		t.Errorf("status %d after %d waits, want immediate 429", resp.StatusCode, len(waits))
+	}
+}
+
+func TestBackoffGrows(t *testing.T) {
+	for n := 1; n <= 3; n++ {
+		d := backoff(n)
+		lo := retryBaseDelay << (n - 1)
+		if d < lo || d > lo+lo/2 {
+			t.Errorf("backoff(%d) = %v, want within [%v, %v]", n, d, lo, lo+lo/2)
+		}
+	}
+}
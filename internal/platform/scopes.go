@@ -0,0 +1,189 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// TokenSteps returns what to choose on the TokenURL page so the token has
+// no more access than Orbit needs. It returns nil if the platform offers no
+// choice.
+func TokenSteps(name string) []string {
+	switch name {
+	case "vercel":
+		return []string{
+			`Scope: the team that owns your projects, not "Full Account"`,
+			"Expiration: the shortest you're willing to renew",
+		}
+	case "koyeb":
+		return []string{
+			"Create the token in the organization that owns your services",
+			"Koyeb tokens can't be narrowed further; Orbit only changes services when you ask it to",
+		}
+	case "supabase":
+		return []string{
+			"Personal access tokens reach every organization you belong to",
+			"Prefer an account that only belongs to your projects' organization",
+		}
+	case "neon":
+		return []string{
+			"Prefer an organization API key over a personal one",
+		}
+	case "planetscale":
+		return []string{
+			"Create a service token in the organization and paste it as <id>:<token>",
+			"Grant it read_database and read_branch, plus read_deploy_request on each database",
+		}
+	case "render":
+		return []string{
+			"API keys reach every workspace of the account that creates them",
+		}
+	case "flyio":
+		return []string{
+			"Run: fly tokens create org --name orbit --expiry 8760h",
+			"For a single app, a deploy token is narrower: fly tokens create deploy -a <app>",
+		}
+	case "github":
+		return []string{
+			"Classic token: the link preselects the repo scope, nothing else is needed",
+			"Or a fine-grained token limited to your repositories, with Actions read and write,",
+			"  Contents read-only and Metadata read-only",
+		}
+	case "kubernetes":
+		return []string{
+			"Bind the service account to the view ClusterRole in your namespaces",
+			"Add get and patch on deployments for redeploys and scaling",
+		}
+	default:
+		return nil
+	}
+}
+
+// TokenScopes describes what a token may access, compared with the
+// minimum Orbit needs.
+type TokenScopes struct {
+	Granted []string // as the platform names them; empty if it can't tell
+	Excess  []string // access beyond what Orbit needs
+	Missing []string // access Orbit needs but the token lacks
+}
+
+// OverPrivileged reports whether the token has more access than needed.
+func (s *TokenScopes) OverPrivileged() bool {
+	return len(s.Excess) > 0
+}
+
+// ScopeChecker is implemented by platforms that can report what the
+// connected token is allowed to do.
+type ScopeChecker interface {
+	TokenScopes(ctx context.Context) (*TokenScopes, error)
+}
+
+// githubNeededScopes are classic token scopes that cover what Orbit does:
+// reading workflow runs and logs, re-running and cancelling them.
+var githubNeededScopes = []string{"repo", "public_repo", "repo:status", "repo_deployment"}
+
+// TokenScopes reads the scopes of a classic token from the X-OAuth-Scopes
+// header. Fine-grained tokens don't report their permissions, so Granted
+// is empty for them.
+func (g *GitHub) TokenScopes(ctx context.Context) (*TokenScopes, error) {
+	resp, err := g.doRequest(ctx, "GET", "/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("github API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return nil, fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError("github", resp.StatusCode)
+	}
+	header, ok := resp.Header["X-Oauth-Scopes"]
+	if !ok {
+		return &TokenScopes{}, nil
+	}
+	return githubTokenScopes(strings.Join(header, ",")), nil
+}
+
+func githubTokenScopes(header string) *TokenScopes {
+	s := &TokenScopes{}
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope == "" {
+			continue
+		}
+		s.Granted = append(s.Granted, scope)
+		if !slices.Contains(githubNeededScopes, scope) {
+			s.Excess = append(s.Excess, scope)
+		}
+	}
+	if !slices.Contains(s.Granted, "repo") && !slices.Contains(s.Granted, "public_repo") {
+		s.Missing = []string{"repo"}
+	}
+	return s
+}
+
+// vercelTokenScope is one entry of a Vercel token's scopes.
+type vercelTokenScope struct {
+	Type   string `json:"type"` // "user" or "team"
+	TeamID string `json:"teamId"`
+}
+
+// TokenScopes reports whether the token reaches the whole account or only
+// some teams. A full-account token is flagged when Orbit is scoped to a
+// team, or when the token reaches more teams than that one.
+func (v *Vercel) TokenScopes(ctx context.Context) (*TokenScopes, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", vercelBaseURL+"/v5/user/tokens/current", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+v.token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vercel API error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		return nil, fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError("vercel", resp.StatusCode)
+	}
+	var body struct {
+		Token struct {
+			Scopes []vercelTokenScope `json:"scopes"`
+		} `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode token: %w", err)
+	}
+	return vercelTokenScopes(body.Token.Scopes, v.teamID), nil
+}
+
+func vercelTokenScopes(scopes []vercelTokenScope, teamID string) *TokenScopes {
+	s := &TokenScopes{}
+	hasTeam := false
+	for _, sc := range scopes {
+		switch sc.Type {
+		case "user":
+			s.Granted = append(s.Granted, "full account")
+			s.Excess = append(s.Excess, "full account")
+		case "team":
+			s.Granted = append(s.Granted, "team "+sc.TeamID)
+			if sc.TeamID == teamID {
+				hasTeam = true
+			} else if teamID != "" {
+				s.Excess = append(s.Excess, "team "+sc.TeamID)
+			}
+		}
+	}
+	if teamID != "" && !hasTeam && !slices.Contains(s.Granted, "full account") {
+		s.Missing = []string{"team " + teamID}
+	}
+	return s
+}
@@ -0,0 +1,51 @@
+package platform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGitHubTokenScopes(t *testing.T) {
+	tests := []struct {
+		header          string
+		excess, missing []string
+	}{
+		{"repo", nil, nil},
+		{"public_repo, repo:status", nil, nil},
+		{"repo, workflow, admin:org, delete_repo", []string{"workflow", "admin:org", "delete_repo"}, nil},
+		{"read:user", []string{"read:user"}, []string{"repo"}},
+		{"", nil, []string{"repo"}},
+	}
+	for _, tt := range tests {
+		s := githubTokenScopes(tt.header)
+		if !reflect.DeepEqual(s.Excess, tt.excess) || !reflect.DeepEqual(s.Missing, tt.missing) {
+			t.Errorf("githubTokenScopes(%q) = excess %v, missing %v; want %v, %v", tt.header, s.Excess, s.Missing, tt.excess, tt.missing)
+		}
+	}
+}
+
+func TestVercelTokenScopes(t *testing.T) {
+	user := vercelTokenScope{Type: "user"}
+	acme := vercelTokenScope{Type: "team", TeamID: "team_acme"}
+	other := vercelTokenScope{Type: "team", TeamID: "team_other"}
+	tests := []struct {
+		name            string
+		scopes          []vercelTokenScope
+		teamID          string
+		excess, missing []string
+	}{
+		{"team token", []vercelTokenScope{acme}, "team_acme", nil, nil},
+		{"full account", []vercelTokenScope{user, acme}, "team_acme", []string{"full account"}, nil},
+		{"full account without team", []vercelTokenScope{user}, "", []string{"full account"}, nil},
+		{"extra team", []vercelTokenScope{acme, other}, "team_acme", []string{"team team_other"}, nil},
+		{"wrong team", []vercelTokenScope{other}, "team_acme", []string{"team team_other"}, []string{"team team_acme"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := vercelTokenScopes(tt.scopes, tt.teamID)
+			if !reflect.DeepEqual(s.Excess, tt.excess) || !reflect.DeepEqual(s.Missing, tt.missing) {
+				t.Errorf("excess %v, missing %v; want %v, %v", s.Excess, s.Missing, tt.excess, tt.missing)
+			}
+		})
+	}
+}
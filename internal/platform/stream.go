@@ -0,0 +1,67 @@
+package platform
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// LogStreamer is implemented by platforms that push new log entries as they
+// are written, instead of being re-polled through GetLogs.
+type LogStreamer interface {
+	// StreamLogs streams the service's logs, starting with the entries
+	// selected by opts.Tail or opts.Since. The channel is closed when ctx is
+	// done or the platform ends the stream.
+	StreamLogs(ctx context.Context, serviceID string, opts LogOptions) (<-chan LogEntry, error)
+}
+
+// BuildLogStreamer is implemented by platforms that can follow the build
+// output of one deployment as it is written, for watch --logs.
+type BuildLogStreamer interface {
+	// StreamBuildLogs streams deployID's build output from the start. The
+	// channel is closed when ctx is done or the build output ends.
+	StreamBuildLogs(ctx context.Context, serviceID, deployID string) (<-chan LogEntry, error)
+}
+
+// maxStreamLine bounds a single line of a log stream.
+const maxStreamLine = 1 << 20
+
+// streamLines sends req and parses each line of the response body with
+// parse, which returns false for lines that carry no log entry. The request
+// is made without a client timeout; cancel its context to stop the stream.
+func streamLines(req *http.Request, name string, parse func(line []byte) (LogEntry, bool)) (<-chan LogEntry, error) {
+	resp, err := newHTTPClient(0).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s log stream: %w", name, err)
+	}
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("invalid token: %w", ErrUnauthorized)
+	}
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, statusError(name+" log stream", resp.StatusCode)
+	}
+
+	ch := make(chan LogEntry)
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+		ctx := req.Context()
+		sc := bufio.NewScanner(resp.Body)
+		sc.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+		for sc.Scan() {
+			e, ok := parse(sc.Bytes())
+			if !ok {
+				continue
+			}
+			select {
+			case ch <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,103 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStreamLinesKoyebTail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"result":{"msg":"listening on :8080","created_at":"2026-03-04T10:00:00Z","labels":{"stream":"stdout"}}}`)
+		fmt.Fprintln(w, `{"result":{"msg":"","created_at":"2026-03-04T10:00:01Z"}}`)
+		fmt.Fprintln(w, `not json`)
+		fmt.Fprintln(w, `{"result":{"msg":"panic: nil map","created_at":"2026-03-04T10:00:02Z","labels":{"stream":"stderr"}}}`)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	ch, err := streamLines(req, "koyeb", func(line []byte) (LogEntry, bool) {
+		var msg struct {
+			Result *koyebLogLine `json:"result"`
+		}
+		if json.Unmarshal(line, &msg) != nil || msg.Result == nil {
+			return LogEntry{}, false
+		}
+		return msg.Result.entry("runtime")
+	})
+	if err != nil {
+		t.Fatalf("streamLines: %v", err)
+	}
+
+	var got []LogEntry
+	for e := range ch {
+		got = append(got, e)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d entries, want 2: %+v", len(got), got)
+	}
+	if got[0].Message != "listening on :8080" || got[0].Level != "info" || got[0].Source != "runtime" {
+		t.Errorf("entry 0 = %+v", got[0])
+	}
+	if got[1].Level != "error" {
+		t.Errorf("stderr entry level = %q, want error", got[1].Level)
+	}
+}
+
+func TestStreamLinesUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	_, err := streamLines(req, "koyeb", func([]byte) (LogEntry, bool) { return LogEntry{}, false })
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestStreamLinesCancel(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type":"stdout","created":1772618400000,"text":"Building..."}`)
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	ch, err := streamLines(req, "vercel", func(line []byte) (LogEntry, bool) {
+		var e vercelEvent
+		if json.Unmarshal(line, &e) != nil {
+			return LogEntry{}, false
+		}
+		return e.entry()
+	})
+	if err != nil {
+		t.Fatalf("streamLines: %v", err)
+	}
+
+	if e := <-ch; e.Message != "Building..." || e.Source != "build" {
+		t.Fatalf("first entry = %+v", e)
+	}
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("received entry after cancel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream not closed after cancel")
+	}
+}
@@ -1,6 +1,7 @@
 package platform
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -25,7 +26,7 @@ type Supabase struct {
 func NewSupabase(token string) *Supabase {
 	return &Supabase{
 		token:      token,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient: newHTTPClient(15 * time.Second),
 	}
 }
 
@@ -33,8 +34,12 @@ func (s *Supabase) Name() string {
 	return "supabase"
 }
 
-func (s *Supabase) doRequest(method, path string) (*http.Response, error) {
-	req, err := http.NewRequest(method, supabaseBaseURL+path, nil)
+func (s *Supabase) Capabilities() Capabilities {
+	return CapDiscover
+}
+
+func (s *Supabase) doRequest(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, supabaseBaseURL+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -44,9 +49,9 @@ func (s *Supabase) doRequest(method, path string) (*http.Response, error) {
 }
 
 // Validate checks whether the token is valid by calling GET /v1/projects.
-func (s *Supabase) Validate(token string) error {
-	client := &http.Client{Timeout: 15 * time.Second}
-	req, err := http.NewRequest("GET", supabaseBaseURL+"/v1/projects", nil)
+func (s *Supabase) Validate(ctx context.Context, token string) error {
+	client := newHTTPClient(15 * time.Second)
+	req, err := http.NewRequestWithContext(ctx, "GET", supabaseBaseURL+"/v1/projects", nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
@@ -59,26 +64,26 @@ func (s *Supabase) Validate(token string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
-		return fmt.Errorf("invalid token: unauthorized")
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
 	}
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("supabase API returned status %d", resp.StatusCode)
+		return statusError("supabase", resp.StatusCode)
 	}
 	return nil
 }
 
-func (s *Supabase) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
-	resp, err := s.doRequest("GET", fmt.Sprintf("/v1/projects/%s/health?services=auth&services=db&services=realtime&services=rest&services=storage", serviceID))
+func (s *Supabase) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	resp, err := s.doRequest(ctx, "GET", fmt.Sprintf("/v1/projects/%s/health?services=auth&services=db&services=realtime&services=rest&services=storage", serviceID))
 	if err != nil {
 		return nil, fmt.Errorf("get health: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("project not found: %s", serviceID)
+		return nil, fmt.Errorf("project %w: %s", ErrNotFound, serviceID)
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("supabase API returned status %d", resp.StatusCode)
+		return nil, statusError("supabase", resp.StatusCode)
 	}
 
 	var health []struct {
@@ -104,36 +109,44 @@ func (s *Supabase) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
 	return status, nil
 }
 
-func (s *Supabase) ListDeployments(serviceID string, limit int) ([]Deployment, error) {
+func (s *Supabase) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
 	// Supabase doesn't have a traditional deployment concept
-	return nil, fmt.Errorf("not supported: supabase does not track deployments")
+	return nil, fmt.Errorf("%w: supabase does not track deployments", ErrNotSupported)
+}
+
+func (s *Supabase) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: supabase does not track deployments", ErrNotSupported)
+}
+
+func (s *Supabase) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: use supabase dashboard to manage projects", ErrNotSupported)
 }
 
-func (s *Supabase) GetDeployment(deployID string) (*Deployment, error) {
-	return nil, fmt.Errorf("not supported: supabase does not track deployments")
+func (s *Supabase) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: supabase does not track deployments", ErrNotSupported)
 }
 
-func (s *Supabase) Redeploy(serviceID string) (*Deployment, error) {
-	return nil, fmt.Errorf("not supported: use supabase dashboard to manage projects")
+func (s *Supabase) CancelDeployment(ctx context.Context, deployID string) error {
+	return fmt.Errorf("%w: supabase does not track deployments", ErrNotSupported)
 }
 
-func (s *Supabase) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
-	return nil, fmt.Errorf("not supported: supabase logs are only available via the Supabase dashboard")
+func (s *Supabase) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	return nil, fmt.Errorf("%w: supabase logs are only available via the Supabase dashboard", ErrNotSupported)
 }
 
-func (s *Supabase) Scale(serviceID string, opts ScaleOptions) error {
-	return fmt.Errorf("not supported: use the Supabase dashboard to change project plans")
+func (s *Supabase) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: use the Supabase dashboard to change project plans", ErrNotSupported)
 }
 
-func (s *Supabase) DiscoverServices() ([]DiscoveredService, error) {
-	resp, err := s.doRequest("GET", "/v1/projects")
+func (s *Supabase) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	resp, err := s.doRequest(ctx, "GET", "/v1/projects")
 	if err != nil {
 		return nil, fmt.Errorf("list projects: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("supabase API returned status %d", resp.StatusCode)
+		return nil, statusError("supabase", resp.StatusCode)
 	}
 
 	var projects []struct {
@@ -155,6 +168,6 @@ func (s *Supabase) DiscoverServices() ([]DiscoveredService, error) {
 	return services, nil
 }
 
-func (s *Supabase) WatchDeployment(serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
-	return nil, fmt.Errorf("not supported: supabase does not support deployment watching")
+func (s *Supabase) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+	return nil, fmt.Errorf("%w: supabase does not support deployment watching", ErrNotSupported)
 }
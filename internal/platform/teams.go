@@ -0,0 +1,31 @@
+package platform
+
+import (
+	"context"
+	"strings"
+)
+
+// Team is a team or organization a token has access to.
+type Team struct {
+	ID   string
+	Slug string
+	Name string
+}
+
+// TeamLister is implemented by platforms whose tokens can reach several
+// teams or organizations. Platforms that also implement TeamConfigurable
+// scope every call to the team set with SetTeamID.
+type TeamLister interface {
+	ListTeams(ctx context.Context) ([]Team, error)
+}
+
+// FindTeam returns the team whose ID or slug is ref. Slugs compare
+// case-insensitively.
+func FindTeam(teams []Team, ref string) (Team, bool) {
+	for _, t := range teams {
+		if t.ID == ref || strings.EqualFold(t.Slug, ref) {
+			return t, true
+		}
+	}
+	return Team{}, false
+}
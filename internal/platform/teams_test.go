@@ -0,0 +1,25 @@
+package platform
+
+import "testing"
+
+func TestFindTeam(t *testing.T) {
+	teams := []Team{
+		{ID: "team_a1b2", Slug: "acme", Name: "Acme"},
+		{ID: "team_c3d4", Slug: "acme-staging", Name: "Acme Staging"},
+	}
+	tests := []struct {
+		ref    string
+		wantID string
+	}{
+		{"acme", "team_a1b2"},
+		{"ACME-Staging", "team_c3d4"},
+		{"team_c3d4", "team_c3d4"},
+		{"acm", ""},
+	}
+	for _, tt := range tests {
+		got, ok := FindTeam(teams, tt.ref)
+		if ok != (tt.wantID != "") || got.ID != tt.wantID {
+			t.Errorf("FindTeam(%q) = %+v, %v; want ID %q", tt.ref, got, ok, tt.wantID)
+		}
+	}
+}
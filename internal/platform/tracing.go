@@ -0,0 +1,54 @@
+package platform
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/humanetools/orbit/internal/trace"
+	"github.com/humanetools/orbit/internal/version"
+)
+
+var traceHeader atomic.Bool
+
+// SetTraceHeader makes platform API requests carry the invocation's trace
+// ID in their User-Agent, so a platform's support team can find them.
+func SetTraceHeader(on bool) {
+	traceHeader.Store(on)
+}
+
+// userAgent returns the User-Agent sent to platform APIs.
+func userAgent() string {
+	ua := "orbit/" + version.Version
+	if traceHeader.Load() {
+		ua += " (trace " + trace.ID() + ")"
+	}
+	return ua
+}
+
+// traceTransport identifies orbit in the User-Agent of every request,
+// counts requests for the trace, and logs them in verbose mode. Only the
+// method, URL, status and duration are logged, never headers or bodies,
+// which carry tokens.
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if ua := req.Header.Get("User-Agent"); ua != "" {
+		req.Header.Set("User-Agent", ua+" "+userAgent()) // SDK clients set their own
+	} else {
+		req.Header.Set("User-Agent", userAgent())
+	}
+	trace.CountCall()
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		trace.Logf("%s %s: %v (%s)", req.Method, req.URL.Redacted(), err, elapsed)
+	} else {
+		trace.Logf("%s %s: %d (%s)", req.Method, req.URL.Redacted(), resp.StatusCode, elapsed)
+	}
+	return resp, err
+}
@@ -0,0 +1,40 @@
+package platform
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/humanetools/orbit/internal/trace"
+)
+
+func TestTraceTransportUserAgent(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: &traceTransport{base: http.DefaultTransport}}
+
+	before := trace.Calls()
+	for _, on := range []bool{false, true} {
+		SetTraceHeader(on)
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	SetTraceHeader(false)
+
+	if len(got) != 2 || !strings.HasPrefix(got[0], "orbit/") || strings.Contains(got[0], "trace") {
+		t.Fatalf("User-Agent without trace header = %q", got)
+	}
+	if want := "(trace " + trace.ID() + ")"; !strings.HasSuffix(got[1], want) {
+		t.Errorf("User-Agent = %q, want suffix %q", got[1], want)
+	}
+	if n := trace.Calls() - before; n != 2 {
+		t.Errorf("counted %d calls, want 2", n)
+	}
+}
@@ -0,0 +1,148 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TrackDeployment follows a deployment whose ID is already known, e.g. one
+// Redeploy returned, by polling GetDeployment every interval. It sends the
+// events WatchDeployment sends once it has detected a deployment: detected,
+// then each phase until done or failed. Unlike WatchDeployment it can't
+// pick up a different deployment that happens to start at the same time.
+func TrackDeployment(ctx context.Context, p Platform, deployID string, interval time.Duration) <-chan DeployEvent {
+	ch := make(chan DeployEvent)
+
+	go func() {
+		defer close(ch)
+		track(ctx, p, ch, deployID, interval)
+	}()
+
+	return ch
+}
+
+// commitSearchDepth is how many of the latest deployments WatchCommit
+// looks through for the commit.
+const commitSearchDepth = 10
+
+// WatchCommit waits for a deployment of commit to show up among a
+// service's latest deployments, sending waiting events every interval
+// until it does, and then follows it like TrackDeployment. Deployments of
+// other commits are ignored, so one someone else started on a shared
+// service isn't taken for the one being watched. When the commit has been
+// deployed more than once the newest deployment is followed.
+func WatchCommit(ctx context.Context, p Platform, serviceID, commit string, interval time.Duration) <-chan DeployEvent {
+	ch := make(chan DeployEvent)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			deploys, err := p.ListDeployments(ctx, serviceID, commitSearchDepth)
+			if err != nil {
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
+				return
+			}
+			for _, d := range deploys {
+				if CommitMatches(d.Commit, commit) {
+					track(ctx, p, ch, d.ID, interval)
+					return
+				}
+			}
+
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for a deployment of " + commit + "..."}) {
+				return
+			}
+			if !sleepContext(ctx, interval) {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// CommitMatches reports whether two commit SHAs name the same commit,
+// either of which may be abbreviated: the shorter is a prefix of the
+// longer, ignoring case. An empty SHA matches nothing.
+func CommitMatches(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return strings.EqualFold(a, b[:len(a)])
+}
+
+// track sends the events of deployment deployID to ch until it is done or
+// failed.
+func track(ctx context.Context, p Platform, ch chan<- DeployEvent, deployID string, interval time.Duration) {
+	lastPhase := ""
+	for {
+		d, err := p.GetDeployment(ctx, deployID)
+		if err != nil {
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("deployment %s: %w", deployID, err)})
+			return
+		}
+
+		if lastPhase == "" {
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "detected", Message: fmt.Sprintf("Tracking deployment %s", d.ID), Deploy: d}) {
+				return
+			}
+		}
+		phase := trackPhase(d.Status)
+		if phase != lastPhase {
+			lastPhase = phase
+
+			event := DeployEvent{Phase: phase, Deploy: d}
+			switch phase {
+			case "building":
+				event.Message = "Building..."
+			case "deploying":
+				event.Message = "Deploying..."
+			case "done":
+				event.Message = "Deploy successful!"
+				sendEvent(ctx, ch, event)
+				return
+			case "failed":
+				event.Message = "Deployment failed!"
+				event.Error = fmt.Errorf("deployment %s %s", deployID, d.Status)
+				if logger, ok := p.(DeploymentLogger); ok {
+					if entries, err := logger.DeploymentLogs(ctx, deployID, 20); err == nil {
+						for _, e := range entries {
+							event.Logs = append(event.Logs, e.Message)
+						}
+					}
+				}
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
+				return
+			}
+		}
+
+		if !sleepContext(ctx, interval) {
+			return
+		}
+	}
+}
+
+// trackPhase maps a Deployment status to a DeployEvent phase. Statuses
+// other than those Deployment documents are taken as the deployment having
+// ended without going healthy, e.g. cancelled.
+func trackPhase(status string) string {
+	switch status {
+	case "pending", "building":
+		return "building"
+	case "deploying":
+		return "deploying"
+	case "healthy", "sleeping":
+		return "done"
+	default:
+		return "failed"
+	}
+}
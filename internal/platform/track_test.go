@@ -0,0 +1,116 @@
+package platform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// trackStub answers GetDeployment with the next status in statuses, and
+// keeps repeating the last one.
+type trackStub struct {
+	Platform
+	statuses []string
+}
+
+func (s *trackStub) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	status := s.statuses[0]
+	if len(s.statuses) > 1 {
+		s.statuses = s.statuses[1:]
+	}
+	return &Deployment{ID: deployID, Status: status}, nil
+}
+
+func TestTrackDeployment(t *testing.T) {
+	tests := []struct {
+		statuses []string
+		want     []string
+	}{
+		{[]string{"pending", "building", "building", "deploying", "healthy"}, []string{"detected", "building", "deploying", "done"}},
+		{[]string{"deploying", "sleeping"}, []string{"detected", "deploying", "done"}},
+		{[]string{"building", "failed"}, []string{"detected", "building", "failed"}},
+		{[]string{"cancelled"}, []string{"detected", "failed"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		var last DeployEvent
+		for ev := range TrackDeployment(context.Background(), &trackStub{statuses: tt.statuses}, "dep_1", time.Millisecond) {
+			if ev.Deploy == nil || ev.Deploy.ID != "dep_1" {
+				t.Errorf("%v: event %q without the tracked deployment", tt.statuses, ev.Phase)
+			}
+			got = append(got, ev.Phase)
+			last = ev
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: phases = %v, want %v", tt.statuses, got, tt.want)
+		}
+		if last.Phase == "failed" && last.Error == nil {
+			t.Errorf("%v: failed event without an error", tt.statuses)
+		}
+	}
+}
+
+// commitStub lists deployments of the commits in lists, one list per
+// call, keeping the last, and reports every deployment healthy.
+type commitStub struct {
+	Platform
+	lists [][]string
+}
+
+func (s *commitStub) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	commits := s.lists[0]
+	if len(s.lists) > 1 {
+		s.lists = s.lists[1:]
+	}
+	var deploys []Deployment
+	for i, c := range commits {
+		deploys = append(deploys, Deployment{ID: "dep_" + c + "_" + string(rune('a'+i)), Commit: c})
+	}
+	return deploys, nil
+}
+
+func (s *commitStub) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	return &Deployment{ID: deployID, Status: "healthy"}, nil
+}
+
+func TestWatchCommit(t *testing.T) {
+	stub := &commitStub{lists: [][]string{
+		{"1111111", "0000000"},
+		{"2222222", "1111111", "0000000"},
+		{"3f2a9c1e", "2222222", "3f2a9c1e", "1111111"},
+	}}
+	var got []string
+	var detected *Deployment
+	for ev := range WatchCommit(context.Background(), stub, "svc", "3F2A9C1", time.Millisecond) {
+		got = append(got, ev.Phase)
+		if ev.Phase == "detected" {
+			detected = ev.Deploy
+		}
+	}
+	want := []string{"waiting", "waiting", "detected", "done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("phases = %v, want %v", got, want)
+	}
+	if detected == nil || detected.ID != "dep_3f2a9c1e_a" {
+		t.Errorf("detected %+v, want the newest deployment of the commit", detected)
+	}
+}
+
+func TestCommitMatches(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"3f2a9c1", "3f2a9c1e8b7d", true},
+		{"3F2A9C1E8B7D", "3f2a9c1", true},
+		{"3f2a9c1", "3f2a9c2", false},
+		{"", "3f2a9c1", false},
+		{"3f2a9c1", "", false},
+	}
+	for _, tt := range tests {
+		if got := CommitMatches(tt.a, tt.b); got != tt.want {
+			t.Errorf("CommitMatches(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,15 @@
 package platform
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -22,6 +28,8 @@ type Vercel struct {
 	teamID     string
 	target     string // "production" or "preview"
 	httpClient *http.Client
+
+	poller
 }
 
 func (v *Vercel) SetTeamID(id string) {
@@ -36,7 +44,7 @@ func (v *Vercel) SetTarget(target string) {
 func NewVercel(token string) *Vercel {
 	return &Vercel{
 		token:      token,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient: newHTTPClient(15 * time.Second),
 	}
 }
 
@@ -44,7 +52,33 @@ func (v *Vercel) Name() string {
 	return "vercel"
 }
 
-func (v *Vercel) doRequest(method, path string) (*http.Response, error) {
+func (v *Vercel) Capabilities() Capabilities {
+	return CapDeployments | CapLogs | CapWatch | CapDiscover | CapRollback | CapCancel
+}
+
+func (v *Vercel) doRequest(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := v.newRequest(ctx, method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return v.httpClient.Do(req)
+}
+
+// doJSON is doRequest with body sent as JSON.
+func (v *Vercel) doJSON(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	req, err := v.newRequest(ctx, method, path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return v.httpClient.Do(req)
+}
+
+// newRequest builds an authenticated, team-scoped API request.
+func (v *Vercel) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
 	reqURL := vercelBaseURL + path
 	if v.teamID != "" {
 		if strings.Contains(path, "?") {
@@ -53,19 +87,19 @@ func (v *Vercel) doRequest(method, path string) (*http.Response, error) {
 			reqURL += "?teamId=" + v.teamID
 		}
 	}
-	req, err := http.NewRequest(method, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+v.token)
 	req.Header.Set("Content-Type", "application/json")
-	return v.httpClient.Do(req)
+	return req, nil
 }
 
 // Validate checks whether the token is valid by calling GET /v2/user.
-func (v *Vercel) Validate(token string) error {
-	client := &http.Client{Timeout: 15 * time.Second}
-	req, err := http.NewRequest("GET", vercelBaseURL+"/v2/user", nil)
+func (v *Vercel) Validate(ctx context.Context, token string) error {
+	client := newHTTPClient(15 * time.Second)
+	req, err := http.NewRequestWithContext(ctx, "GET", vercelBaseURL+"/v2/user", nil)
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
@@ -78,14 +112,64 @@ func (v *Vercel) Validate(token string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
-		return fmt.Errorf("invalid token: unauthorized")
+		return fmt.Errorf("invalid token: %w", ErrUnauthorized)
 	}
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("vercel API returned status %d", resp.StatusCode)
+		return statusError("vercel", resp.StatusCode)
 	}
 	return nil
 }
 
+// ListTeams returns the teams the token is a member of. The request is not
+// team-scoped, so it lists every team regardless of SetTeamID.
+func (v *Vercel) ListTeams(ctx context.Context) ([]Team, error) {
+	var teams []Team
+	until := ""
+	for {
+		reqURL := vercelBaseURL + "/v2/teams?limit=100"
+		if until != "" {
+			reqURL += "&until=" + until
+		}
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+v.token)
+
+		resp, err := v.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("list teams: %w", err)
+		}
+		var page struct {
+			Teams []struct {
+				ID   string `json:"id"`
+				Slug string `json:"slug"`
+				Name string `json:"name"`
+			} `json:"teams"`
+			Pagination struct {
+				Next *int64 `json:"next"`
+			} `json:"pagination"`
+		}
+		if resp.StatusCode != 200 {
+			resp.Body.Close()
+			return nil, statusError("vercel", resp.StatusCode)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode teams: %w", err)
+		}
+
+		for _, t := range page.Teams {
+			teams = append(teams, Team{ID: t.ID, Slug: t.Slug, Name: t.Name})
+		}
+		if page.Pagination.Next == nil || len(page.Teams) == 0 {
+			return teams, nil
+		}
+		until = strconv.FormatInt(*page.Pagination.Next, 10)
+	}
+}
+
 func (v *Vercel) deployQuery(base string) string {
 	if v.target != "" {
 		return base + "&target=" + v.target
@@ -93,27 +177,25 @@ func (v *Vercel) deployQuery(base string) string {
 	return base
 }
 
-func (v *Vercel) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
-	resp, err := v.doRequest("GET", v.deployQuery(fmt.Sprintf("/v6/deployments?projectId=%s&limit=1&state=READY", serviceID)))
+func (v *Vercel) GetServiceStatus(ctx context.Context, serviceID string) (*ServiceStatus, error) {
+	resp, err := v.doRequest(ctx, "GET", v.deployQuery(fmt.Sprintf("/v6/deployments?projectId=%s&limit=1&state=READY", serviceID)))
 	if err != nil {
 		return nil, fmt.Errorf("get deployments: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("vercel API returned status %d", resp.StatusCode)
+		return nil, statusError("vercel", resp.StatusCode)
 	}
 
 	var result struct {
 		Deployments []struct {
-			UID     string `json:"uid"`
-			State   string `json:"state"`
-			Created int64  `json:"created"`
-			URL     string `json:"url"`
-			Meta    struct {
-				GitCommitSha     string `json:"githubCommitSha"`
-				GitCommitMessage string `json:"githubCommitMessage"`
-			} `json:"meta"`
+			UID     string     `json:"uid"`
+			Name    string     `json:"name"` // project name
+			State   string     `json:"state"`
+			Created int64      `json:"created"`
+			URL     string     `json:"url"`
+			Meta    vercelMeta `json:"meta"`
 		} `json:"deployments"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -125,19 +207,63 @@ func (v *Vercel) GetServiceStatus(serviceID string) (*ServiceStatus, error) {
 	}
 	if len(result.Deployments) > 0 {
 		d := result.Deployments[0]
+		status.Name = d.Name
 		status.Status = mapVercelState(d.State)
 		status.LastDeploy = &Deployment{
 			ID:        d.UID,
 			Status:    mapVercelState(d.State),
-			Commit:    d.Meta.GitCommitSha,
-			Message:   d.Meta.GitCommitMessage,
 			CreatedAt: time.UnixMilli(d.Created),
 			URL:       "https://" + d.URL,
 		}
+		d.Meta.apply(status.LastDeploy)
+	}
+	if p, err := v.LookupService(ctx, serviceID); err == nil {
+		status.URL = p.URL
 	}
 	return status, nil
 }
 
+// vercelTargets holds a project's production aliases: its custom domains
+// and <project>.vercel.app.
+type vercelTargets struct {
+	Production struct {
+		Alias []string `json:"alias"`
+	} `json:"production"`
+}
+
+// url returns the canonical production URL, preferring a custom domain, or
+// "" before the first production deployment.
+func (t vercelTargets) url() string {
+	aliases := t.Production.Alias
+	for _, a := range aliases {
+		if !strings.HasSuffix(a, ".vercel.app") {
+			return "https://" + a
+		}
+	}
+	if len(aliases) > 0 {
+		return "https://" + aliases[0]
+	}
+	return ""
+}
+
+// vercelMeta is the git metadata Vercel attaches to deployments created
+// from a GitHub push or pull request.
+type vercelMeta struct {
+	CommitSha     string `json:"githubCommitSha"`
+	CommitMessage string `json:"githubCommitMessage"`
+	CommitRef     string `json:"githubCommitRef"`
+	AuthorLogin   string `json:"githubCommitAuthorLogin"`
+	PrID          string `json:"githubPrId"`
+}
+
+func (m vercelMeta) apply(dep *Deployment) {
+	dep.Commit = m.CommitSha
+	dep.Message = m.CommitMessage
+	dep.Branch = m.CommitRef
+	dep.Author = m.AuthorLogin
+	dep.PullRequest, _ = strconv.Atoi(m.PrID)
+}
+
 func mapVercelState(state string) string {
 	switch state {
 	case "READY":
@@ -153,27 +279,25 @@ func mapVercelState(state string) string {
 	}
 }
 
-func (v *Vercel) ListDeployments(serviceID string, limit int) ([]Deployment, error) {
-	resp, err := v.doRequest("GET", v.deployQuery(fmt.Sprintf("/v6/deployments?projectId=%s&limit=%d", serviceID, limit)))
+func (v *Vercel) ListDeployments(ctx context.Context, serviceID string, limit int) ([]Deployment, error) {
+	resp, err := v.doRequest(ctx, "GET", v.deployQuery(fmt.Sprintf("/v6/deployments?projectId=%s&limit=%d", serviceID, limit)))
 	if err != nil {
 		return nil, fmt.Errorf("list deployments: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("vercel API returned status %d", resp.StatusCode)
+		return nil, statusError("vercel", resp.StatusCode)
 	}
 
 	var result struct {
 		Deployments []struct {
-			UID     string `json:"uid"`
-			State   string `json:"state"`
-			Created int64  `json:"created"`
-			URL     string `json:"url"`
-			Meta    struct {
-				GitCommitSha     string `json:"githubCommitSha"`
-				GitCommitMessage string `json:"githubCommitMessage"`
-			} `json:"meta"`
+			UID     string     `json:"uid"`
+			State   string     `json:"state"`
+			Created int64      `json:"created"`
+			Ready   int64      `json:"ready"`
+			URL     string     `json:"url"`
+			Meta    vercelMeta `json:"meta"`
 		} `json:"deployments"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -182,42 +306,42 @@ func (v *Vercel) ListDeployments(serviceID string, limit int) ([]Deployment, err
 
 	var deployments []Deployment
 	for _, d := range result.Deployments {
-		deployments = append(deployments, Deployment{
+		dep := Deployment{
 			ID:        d.UID,
 			Status:    mapVercelState(d.State),
-			Commit:    d.Meta.GitCommitSha,
-			Message:   d.Meta.GitCommitMessage,
 			CreatedAt: time.UnixMilli(d.Created),
 			URL:       "https://" + d.URL,
-		})
+		}
+		d.Meta.apply(&dep)
+		if d.Ready > d.Created {
+			dep.Duration = time.UnixMilli(d.Ready).Sub(dep.CreatedAt)
+		}
+		deployments = append(deployments, dep)
 	}
 	return deployments, nil
 }
 
-func (v *Vercel) GetDeployment(deployID string) (*Deployment, error) {
-	resp, err := v.doRequest("GET", "/v6/deployments/"+deployID)
+func (v *Vercel) GetDeployment(ctx context.Context, deployID string) (*Deployment, error) {
+	resp, err := v.doRequest(ctx, "GET", "/v6/deployments/"+deployID)
 	if err != nil {
 		return nil, fmt.Errorf("get deployment: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("deployment not found: %s", deployID)
+		return nil, fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("vercel API returned status %d", resp.StatusCode)
+		return nil, statusError("vercel", resp.StatusCode)
 	}
 
 	var d struct {
-		UID        string `json:"uid"`
-		State      string `json:"state"`
-		ReadyState string `json:"readyState"`
-		Created    int64  `json:"created"`
-		URL        string `json:"url"`
-		Meta       struct {
-			GitCommitSha     string `json:"githubCommitSha"`
-			GitCommitMessage string `json:"githubCommitMessage"`
-		} `json:"meta"`
+		UID        string     `json:"uid"`
+		State      string     `json:"state"`
+		ReadyState string     `json:"readyState"`
+		Created    int64      `json:"created"`
+		URL        string     `json:"url"`
+		Meta       vercelMeta `json:"meta"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
@@ -232,84 +356,145 @@ func (v *Vercel) GetDeployment(deployID string) (*Deployment, error) {
 	dep := &Deployment{
 		ID:        d.UID,
 		Status:    mapVercelState(state),
-		Commit:    d.Meta.GitCommitSha,
-		Message:   d.Meta.GitCommitMessage,
 		CreatedAt: time.UnixMilli(d.Created),
 	}
+	d.Meta.apply(dep)
 	if d.URL != "" {
 		dep.URL = "https://" + d.URL
 	}
 	return dep, nil
 }
 
-func (v *Vercel) Redeploy(serviceID string) (*Deployment, error) {
-	return nil, fmt.Errorf("not supported: push to git to trigger a new Vercel deployment")
+// ArtifactSize returns the total size of a deployment's build outputs.
+func (v *Vercel) ArtifactSize(ctx context.Context, deployID string) (int64, error) {
+	resp, err := v.doRequest(ctx, "GET", "/v11/deployments/"+deployID+"/builds")
+	if err != nil {
+		return 0, fmt.Errorf("get deployment builds: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return 0, fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
+	}
+	if resp.StatusCode != 200 {
+		return 0, statusError("vercel", resp.StatusCode)
+	}
+
+	var result struct {
+		Builds []struct {
+			Output []struct {
+				Size int64 `json:"size"`
+			} `json:"output"`
+		} `json:"builds"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decode response: %w", err)
+	}
+
+	var total int64
+	for _, b := range result.Builds {
+		for _, o := range b.Output {
+			total += o.Size
+		}
+	}
+	return total, nil
+}
+
+func (v *Vercel) Redeploy(ctx context.Context, serviceID string) (*Deployment, error) {
+	return nil, fmt.Errorf("%w: push to git to trigger a new Vercel deployment", ErrNotSupported)
 }
 
-func (v *Vercel) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error) {
-	// Get the latest deployment for this project
-	resp, err := v.doRequest("GET", v.deployQuery(fmt.Sprintf("/v6/deployments?projectId=%s&limit=1", serviceID)))
+// RollbackTo promotes a previous deployment to production. Vercel serves
+// the existing build again, so no new deployment is created.
+func (v *Vercel) RollbackTo(ctx context.Context, serviceID, deployID string) (*Deployment, error) {
+	if v.target == "preview" {
+		return nil, fmt.Errorf("%w: only production deployments can be promoted", ErrNotSupported)
+	}
+
+	resp, err := v.doRequest(ctx, "POST", fmt.Sprintf("/v10/projects/%s/promote/%s", serviceID, deployID))
 	if err != nil {
-		return nil, fmt.Errorf("get deployments: %w", err)
+		return nil, fmt.Errorf("promote deployment: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("vercel API returned status %d", resp.StatusCode)
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
+	}
+	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 {
+		var e struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&e) == nil && e.Error.Message != "" {
+			return nil, fmt.Errorf("promote deployment: %s", e.Error.Message)
+		}
+		return nil, statusError("vercel", resp.StatusCode)
 	}
 
-	var deploys struct {
-		Deployments []struct {
-			UID string `json:"uid"`
-		} `json:"deployments"`
+	return v.GetDeployment(ctx, deployID)
+}
+
+// CancelDeployment cancels a deployment that is queued or building.
+func (v *Vercel) CancelDeployment(ctx context.Context, deployID string) error {
+	resp, err := v.doRequest(ctx, "PATCH", "/v12/deployments/"+deployID+"/cancel")
+	if err != nil {
+		return fmt.Errorf("cancel deployment: %w", err)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&deploys); err != nil {
-		return nil, fmt.Errorf("decode deployments: %w", err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("deployment %w: %s", ErrNotFound, deployID)
 	}
-	if len(deploys.Deployments) == 0 {
-		return nil, nil
+	if resp.StatusCode != 200 {
+		return statusError("vercel", resp.StatusCode)
 	}
+	return nil
+}
 
-	deployID := deploys.Deployments[0].UID
+// Pause pauses the project: its deployments stop serving traffic until it
+// is unpaused.
+func (v *Vercel) Pause(ctx context.Context, serviceID string) error {
+	return v.setPaused(ctx, serviceID, "pause")
+}
+
+// Resume unpauses the project.
+func (v *Vercel) Resume(ctx context.Context, serviceID string) error {
+	return v.setPaused(ctx, serviceID, "unpause")
+}
 
-	// Fetch build events for this deployment
-	eventsResp, err := v.doRequest("GET", fmt.Sprintf("/v2/deployments/%s/events", deployID))
+func (v *Vercel) setPaused(ctx context.Context, projectID, action string) error {
+	resp, err := v.doRequest(ctx, "POST", "/v1/projects/"+projectID+"/"+action)
 	if err != nil {
-		return nil, fmt.Errorf("get events: %w", err)
+		return fmt.Errorf("%s project: %w", action, err)
 	}
-	defer eventsResp.Body.Close()
+	defer resp.Body.Close()
 
-	if eventsResp.StatusCode != 200 {
-		return nil, fmt.Errorf("vercel events API returned status %d", eventsResp.StatusCode)
+	if resp.StatusCode == 404 {
+		return fmt.Errorf("project %w: %s", ErrNotFound, projectID)
+	}
+	if resp.StatusCode != 200 {
+		return statusError("vercel", resp.StatusCode)
 	}
+	return nil
+}
 
-	var events []struct {
-		Type    string `json:"type"`
-		Created int64  `json:"created"`
-		Text    string `json:"text"`
+func (v *Vercel) GetLogs(ctx context.Context, serviceID string, opts LogOptions) ([]LogEntry, error) {
+	deployID, err := v.latestDeploymentID(ctx, serviceID)
+	if err != nil || deployID == "" {
+		return nil, err
 	}
-	if err := json.NewDecoder(eventsResp.Body).Decode(&events); err != nil {
-		return nil, fmt.Errorf("decode events: %w", err)
+
+	all, err := v.deploymentEvents(ctx, deployID)
+	if err != nil {
+		return nil, err
 	}
 
 	var entries []LogEntry
-	for _, e := range events {
-		if e.Text == "" {
-			continue
-		}
-		level := "info"
-		if e.Type == "stderr" || e.Type == "error" {
-			level = "error"
-		}
-		if opts.Level != "" && level != opts.Level {
-			continue
+	for _, e := range all {
+		if MatchesLevel(e.Level, opts.Level) {
+			entries = append(entries, e)
 		}
-		entries = append(entries, LogEntry{
-			Timestamp: time.UnixMilli(e.Created),
-			Level:     level,
-			Message:   e.Text,
-			Source:    "build",
-		})
 	}
 
 	if opts.Since > 0 {
@@ -330,25 +515,343 @@ func (v *Vercel) GetLogs(serviceID string, opts LogOptions) ([]LogEntry, error)
 	return entries, nil
 }
 
-func (v *Vercel) Scale(serviceID string, opts ScaleOptions) error {
-	return fmt.Errorf("not supported: Vercel uses automatic scaling that cannot be controlled via API")
+// StreamLogs follows the event stream of the project's latest deployment.
+// Vercel ends the stream once the deployment stops producing events.
+func (v *Vercel) StreamLogs(ctx context.Context, serviceID string, opts LogOptions) (<-chan LogEntry, error) {
+	deployID, err := v.latestDeploymentID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if deployID == "" {
+		return nil, fmt.Errorf("no deployments for project %s", serviceID)
+	}
+
+	return v.streamEvents(ctx, deployID, opts)
+}
+
+// StreamBuildLogs follows the event stream of one deployment, which Vercel
+// ends once the build is done.
+func (v *Vercel) StreamBuildLogs(ctx context.Context, serviceID, deployID string) (<-chan LogEntry, error) {
+	return v.streamEvents(ctx, deployID, LogOptions{})
+}
+
+// streamEvents follows a deployment's event stream, starting with the
+// events selected by opts.Tail or opts.Since.
+func (v *Vercel) streamEvents(ctx context.Context, deployID string, opts LogOptions) (<-chan LogEntry, error) {
+	path := fmt.Sprintf("/v2/deployments/%s/events?follow=1", deployID)
+	if opts.Tail > 0 {
+		path += "&limit=" + strconv.Itoa(opts.Tail)
+	}
+	if opts.Since > 0 {
+		path += "&since=" + strconv.FormatInt(time.Now().Add(-opts.Since).UnixMilli(), 10)
+	}
+	req, err := v.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamLines(req, "vercel", func(line []byte) (LogEntry, bool) {
+		var e vercelEvent
+		if json.Unmarshal(line, &e) != nil {
+			return LogEntry{}, false
+		}
+		return e.entry()
+	})
+}
+
+// latestDeploymentID returns the project's most recent deployment, or ""
+// when it has none.
+func (v *Vercel) latestDeploymentID(ctx context.Context, projectID string) (string, error) {
+	resp, err := v.doRequest(ctx, "GET", v.deployQuery(fmt.Sprintf("/v6/deployments?projectId=%s&limit=1", projectID)))
+	if err != nil {
+		return "", fmt.Errorf("get deployments: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", statusError("vercel", resp.StatusCode)
+	}
+
+	var deploys struct {
+		Deployments []struct {
+			UID string `json:"uid"`
+		} `json:"deployments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&deploys); err != nil {
+		return "", fmt.Errorf("decode deployments: %w", err)
+	}
+	if len(deploys.Deployments) == 0 {
+		return "", nil
+	}
+	return deploys.Deployments[0].UID, nil
+}
+
+// DeploymentLogs returns the last tail build events of a deployment.
+func (v *Vercel) DeploymentLogs(ctx context.Context, deployID string, tail int) ([]LogEntry, error) {
+	entries, err := v.deploymentEvents(ctx, deployID)
+	if err != nil {
+		return nil, err
+	}
+	if tail > 0 && len(entries) > tail {
+		entries = entries[len(entries)-tail:]
+	}
+	return entries, nil
+}
+
+// deploymentEvents fetches the build events of a deployment as log entries.
+func (v *Vercel) deploymentEvents(ctx context.Context, deployID string) ([]LogEntry, error) {
+	resp, err := v.doRequest(ctx, "GET", fmt.Sprintf("/v2/deployments/%s/events", deployID))
+	if err != nil {
+		return nil, fmt.Errorf("get events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError("vercel events", resp.StatusCode)
+	}
+
+	var events []vercelEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("decode events: %w", err)
+	}
+
+	var entries []LogEntry
+	for _, e := range events {
+		if entry, ok := e.entry(); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// vercelEvent is a deployment event from the events API.
+type vercelEvent struct {
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Text    string `json:"text"`
+}
+
+// entry converts the event to a log entry. ok is false for events without
+// text.
+func (e vercelEvent) entry() (LogEntry, bool) {
+	if e.Text == "" {
+		return LogEntry{}, false
+	}
+	level := "info"
+	if e.Type == "stderr" || e.Type == "error" {
+		level = "error"
+	}
+	if e.Type != "error" {
+		level = InferLevel(e.Text, level)
+	}
+	return LogEntry{
+		Timestamp: time.UnixMilli(e.Created),
+		Level:     level,
+		Message:   e.Text,
+		Source:    "build",
+	}, true
+}
+
+// ListDomains returns the project's domains, including its *.vercel.app
+// names. Vercel issues certificates for verified domains automatically.
+func (v *Vercel) ListDomains(ctx context.Context, serviceID string) ([]Domain, error) {
+	resp, err := v.doRequest(ctx, "GET", fmt.Sprintf("/v9/projects/%s/domains?limit=100", url.PathEscape(serviceID)))
+	if err != nil {
+		return nil, fmt.Errorf("list domains: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("project %w: %s", ErrNotFound, serviceID)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError("vercel domains", resp.StatusCode)
+	}
+
+	var result struct {
+		Domains []struct {
+			Name      string `json:"name"`
+			Verified  bool   `json:"verified"`
+			Redirect  string `json:"redirect"`
+			GitBranch string `json:"gitBranch"`
+		} `json:"domains"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode domains: %w", err)
+	}
+
+	var domains []Domain
+	for _, d := range result.Domains {
+		domain := Domain{
+			Name:     d.Name,
+			Custom:   !strings.HasSuffix(d.Name, ".vercel.app"),
+			Verified: d.Verified,
+			SSL:      "pending",
+			Redirect: d.Redirect,
+		}
+		if d.Verified {
+			domain.SSL = "active"
+		}
+		if d.GitBranch != "" {
+			domain.Note = "branch " + d.GitBranch
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// vercelEnvTargets are the environments a variable is set for when no
+// deployment target is configured.
+var vercelEnvTargets = []string{"production", "preview", "development"}
+
+type vercelEnv struct {
+	ID     string   `json:"id"`
+	Key    string   `json:"key"`
+	Value  string   `json:"value"`
+	Type   string   `json:"type"` // plain, encrypted, sensitive, secret, system
+	Target []string `json:"target"`
+}
+
+// projectEnvs lists the project's variables that apply to the configured
+// deployment target, or all of them without one.
+func (v *Vercel) projectEnvs(ctx context.Context, projectID string) ([]vercelEnv, error) {
+	resp, err := v.doRequest(ctx, "GET", fmt.Sprintf("/v9/projects/%s/env?decrypt=true", url.PathEscape(projectID)))
+	if err != nil {
+		return nil, fmt.Errorf("get env: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("project %w: %s", ErrNotFound, projectID)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError("vercel env", resp.StatusCode)
+	}
+
+	var result struct {
+		Envs []vercelEnv `json:"envs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode env: %w", err)
+	}
+	if v.target == "" {
+		return result.Envs, nil
+	}
+	var envs []vercelEnv
+	for _, e := range result.Envs {
+		if slices.Contains(e.Target, v.target) {
+			envs = append(envs, e)
+		}
+	}
+	return envs, nil
+}
+
+// GetEnvVars returns the project's environment variables. Sensitive values
+// are write-only on Vercel and come back empty.
+func (v *Vercel) GetEnvVars(ctx context.Context, serviceID string) ([]EnvVar, error) {
+	envs, err := v.projectEnvs(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	var vars []EnvVar
+	for _, e := range envs {
+		ev := EnvVar{Key: e.Key, Targets: e.Target}
+		if e.Type == "sensitive" || e.Type == "secret" {
+			ev.Sensitive = true
+		} else {
+			ev.Value = e.Value
+		}
+		vars = append(vars, ev)
+	}
+	return vars, nil
+}
+
+// SetEnvVars upserts encrypted variables for the configured deployment
+// target, or every environment without one.
+func (v *Vercel) SetEnvVars(ctx context.Context, serviceID string, vars map[string]string) error {
+	targets := vercelEnvTargets
+	if v.target != "" {
+		targets = []string{v.target}
+	}
+	type newEnv struct {
+		Key    string   `json:"key"`
+		Value  string   `json:"value"`
+		Type   string   `json:"type"`
+		Target []string `json:"target"`
+	}
+	var body []newEnv
+	for _, key := range sortedKeys(vars) {
+		body = append(body, newEnv{Key: key, Value: vars[key], Type: "encrypted", Target: targets})
+	}
+
+	resp, err := v.doJSON(ctx, "POST", fmt.Sprintf("/v10/projects/%s/env?upsert=true", url.PathEscape(serviceID)), body)
+	if err != nil {
+		return fmt.Errorf("set env: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		var e struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&e) == nil && e.Error.Message != "" {
+			return fmt.Errorf("set env: %s", e.Error.Message)
+		}
+		return statusError("vercel env", resp.StatusCode)
+	}
+	return nil
 }
 
-func (v *Vercel) DiscoverServices() ([]DiscoveredService, error) {
-	resp, err := v.doRequest("GET", "/v9/projects?limit=100")
+// DeleteEnvVars removes every entry of keys for the configured deployment
+// target, or in every environment without one.
+func (v *Vercel) DeleteEnvVars(ctx context.Context, serviceID string, keys []string) error {
+	envs, err := v.projectEnvs(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if !slices.ContainsFunc(envs, func(e vercelEnv) bool { return e.Key == key }) {
+			return fmt.Errorf("variable %s: %w", key, ErrNotFound)
+		}
+	}
+	for _, e := range envs {
+		if !slices.Contains(keys, e.Key) {
+			continue
+		}
+		resp, err := v.doRequest(ctx, "DELETE", fmt.Sprintf("/v9/projects/%s/env/%s", url.PathEscape(serviceID), e.ID))
+		if err != nil {
+			return fmt.Errorf("delete env: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			return statusError("vercel env", resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+func (v *Vercel) Scale(ctx context.Context, serviceID string, opts ScaleOptions) error {
+	return fmt.Errorf("%w: Vercel uses automatic scaling that cannot be controlled via API", ErrNotSupported)
+}
+
+func (v *Vercel) DiscoverServices(ctx context.Context) ([]DiscoveredService, error) {
+	resp, err := v.doRequest(ctx, "GET", "/v9/projects?limit=100")
 	if err != nil {
 		return nil, fmt.Errorf("list projects: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("vercel API returned status %d", resp.StatusCode)
+		return nil, statusError("vercel", resp.StatusCode)
 	}
 
 	var result struct {
 		Projects []struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
+			ID      string        `json:"id"`
+			Name    string        `json:"name"`
+			Targets vercelTargets `json:"targets"`
 		} `json:"projects"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -361,75 +864,110 @@ func (v *Vercel) DiscoverServices() ([]DiscoveredService, error) {
 			ID:       p.ID,
 			Name:     p.Name,
 			Platform: "vercel",
+			URL:      p.Targets.url(),
 		})
 	}
 	return services, nil
 }
 
-func (v *Vercel) WatchDeployment(serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
+// LookupService resolves a Vercel project by ID or name.
+func (v *Vercel) LookupService(ctx context.Context, idOrName string) (*DiscoveredService, error) {
+	resp, err := v.doRequest(ctx, "GET", "/v9/projects/"+url.PathEscape(idOrName))
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("project %w: %s", ErrNotFound, idOrName)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError("vercel", resp.StatusCode)
+	}
+
+	var p struct {
+		ID      string        `json:"id"`
+		Name    string        `json:"name"`
+		Targets vercelTargets `json:"targets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &DiscoveredService{ID: p.ID, Name: p.Name, Platform: "vercel", URL: p.Targets.url()}, nil
+}
+
+func (v *Vercel) WatchDeployment(ctx context.Context, serviceID string, currentDeployID string) (<-chan DeployEvent, error) {
 	ch := make(chan DeployEvent)
 
 	go func() {
 		defer close(ch)
 
-		const pollInterval = 3 * time.Second
+		pollInterval := v.interval()
 
 		// Check if the latest deployment is already in-progress.
 		// This handles the race where git push triggers a deployment before watch starts,
 		// so currentDeployID already points to the new (building) deployment.
-		deploys, err := v.ListDeployments(serviceID, 1)
+		deploys, err := v.ListDeployments(ctx, serviceID, 1)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
 			return
 		}
-		if len(deploys) > 0 && isInProgress(deploys[0].Status) {
+		if len(deploys) > 0 && IsInProgress(deploys[0].Status) {
 			d := deploys[0]
-			ch <- DeployEvent{
+			if !sendEvent(ctx, ch, DeployEvent{
 				Phase:   "detected",
 				Message: fmt.Sprintf("In-progress deployment found (%s)", d.ID),
 				Deploy:  &d,
+			}) {
+				return
 			}
-			v.trackDeployment(ch, d.ID)
+			v.trackDeployment(ctx, ch, d.ID)
 			return
 		}
 
 		// Phase 1: Detect a new deployment
 		for {
-			deploys, err := v.ListDeployments(serviceID, 1)
+			deploys, err := v.ListDeployments(ctx, serviceID, 1)
 			if err != nil {
-				ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)}
+				sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("poll deployments: %w", err)})
 				return
 			}
 
 			if len(deploys) > 0 {
 				d := deploys[0]
 				if d.ID != currentDeployID {
-					ch <- DeployEvent{
+					if !sendEvent(ctx, ch, DeployEvent{
 						Phase:   "detected",
 						Message: fmt.Sprintf("New deployment detected! (%s)", d.ID),
 						Deploy:  &d,
+					}) {
+						return
 					}
-					v.trackDeployment(ch, d.ID)
+					v.trackDeployment(ctx, ch, d.ID)
 					return
 				}
 			}
 
-			ch <- DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}
-			time.Sleep(pollInterval)
+			if !sendEvent(ctx, ch, DeployEvent{Phase: "waiting", Message: "Waiting for new deployment..."}) {
+				return
+			}
+			if !sleepContext(ctx, pollInterval) {
+				return
+			}
 		}
 	}()
 
 	return ch, nil
 }
 
-func (v *Vercel) trackDeployment(ch chan<- DeployEvent, deployID string) {
-	const pollInterval = 3 * time.Second
+func (v *Vercel) trackDeployment(ctx context.Context, ch chan<- DeployEvent, deployID string) {
+	pollInterval := v.interval()
 	lastPhase := ""
 
 	for {
-		deploy, err := v.GetDeployment(deployID)
+		deploy, err := v.GetDeployment(ctx, deployID)
 		if err != nil {
-			ch <- DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)}
+			sendEvent(ctx, ch, DeployEvent{Phase: "failed", Error: fmt.Errorf("get deployment: %w", err)})
 			return
 		}
 
@@ -447,22 +985,26 @@ func (v *Vercel) trackDeployment(ch chan<- DeployEvent, deployID string) {
 				event.Message = "Health check..."
 			case "done":
 				event.Message = "Deploy successful!"
-				ch <- event
+				sendEvent(ctx, ch, event)
 				return
 			case "failed":
 				event.Message = "Deployment failed!"
 				event.Error = fmt.Errorf("deployment %s failed", deployID)
 				// Try to get error logs
-				if logs, err := v.getDeploymentErrors(deployID); err == nil {
+				if logs, err := v.getDeploymentErrors(ctx, deployID); err == nil {
 					event.Logs = logs
 				}
-				ch <- event
+				sendEvent(ctx, ch, event)
+				return
+			}
+			if !sendEvent(ctx, ch, event) {
 				return
 			}
-			ch <- event
 		}
 
-		time.Sleep(pollInterval)
+		if !sleepContext(ctx, pollInterval) {
+			return
+		}
 	}
 }
 
@@ -481,8 +1023,8 @@ func mapVercelToWatchPhase(status string) string {
 	}
 }
 
-func (v *Vercel) getDeploymentErrors(deployID string) ([]string, error) {
-	resp, err := v.doRequest("GET", fmt.Sprintf("/v2/deployments/%s/events", deployID))
+func (v *Vercel) getDeploymentErrors(ctx context.Context, deployID string) ([]string, error) {
+	resp, err := v.doRequest(ctx, "GET", fmt.Sprintf("/v2/deployments/%s/events", deployID))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,203 @@
+// Package probe checks that a service answers on its own URL, so a status
+// does not rest only on what the platform reports.
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// DefaultTimeout bounds a probe whose config sets no timeout.
+const DefaultTimeout = 10 * time.Second
+
+// maxBody is how much of a response body body_match looks at.
+const maxBody = 1 << 20
+
+// Spec is a validated config.ProbeConfig with defaults applied.
+type Spec struct {
+	URL          string // http(s) URL, or tcp://host:port
+	TCP          bool
+	ExpectStatus int            // 0 accepts any 2xx or 3xx
+	Budget       time.Duration  // 0 = no latency budget
+	BodyMatch    *regexp.Regexp // nil = body not checked
+	Timeout      time.Duration
+}
+
+// FromEntry returns the probe spec for a service, or false if the service
+// has no probe configured. A probe without a url uses the heartbeat URL.
+func FromEntry(e config.ServiceEntry) (Spec, bool, error) {
+	if e.Probe == nil {
+		return Spec{}, false, nil
+	}
+	c := *e.Probe
+	if c.URL == "" {
+		c.URL = e.HeartbeatURL
+	}
+	spec, err := ParseSpec(c)
+	if err != nil {
+		return Spec{}, false, fmt.Errorf("service %q probe: %w", e.Name, err)
+	}
+	return spec, true, nil
+}
+
+// ParseSpec validates c and fills in defaults.
+func ParseSpec(c config.ProbeConfig) (Spec, error) {
+	if c.URL == "" {
+		return Spec{}, errors.New("no url (set probe.url or heartbeat_url)")
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return Spec{}, fmt.Errorf("invalid url %q: %w", c.URL, err)
+	}
+	spec := Spec{URL: c.URL, ExpectStatus: c.ExpectStatus, Timeout: DefaultTimeout}
+	switch u.Scheme {
+	case "http", "https":
+	case "tcp":
+		if u.Port() == "" {
+			return Spec{}, fmt.Errorf("tcp url %q needs a port", c.URL)
+		}
+		spec.TCP = true
+	default:
+		return Spec{}, fmt.Errorf("unsupported url %q (use http, https or tcp)", c.URL)
+	}
+	if u.Host == "" {
+		return Spec{}, fmt.Errorf("url %q has no host", c.URL)
+	}
+
+	if c.ExpectStatus != 0 && (c.ExpectStatus < 100 || c.ExpectStatus > 599) {
+		return Spec{}, fmt.Errorf("expect_status %d is not an HTTP status", c.ExpectStatus)
+	}
+	if c.Budget != "" {
+		if spec.Budget, err = time.ParseDuration(c.Budget); err != nil || spec.Budget <= 0 {
+			return Spec{}, fmt.Errorf("invalid budget %q (e.g. 800ms)", c.Budget)
+		}
+	}
+	if c.Timeout != "" {
+		if spec.Timeout, err = time.ParseDuration(c.Timeout); err != nil || spec.Timeout <= 0 {
+			return Spec{}, fmt.Errorf("invalid timeout %q (e.g. 5s)", c.Timeout)
+		}
+	}
+	if c.BodyMatch != "" {
+		if spec.BodyMatch, err = regexp.Compile(c.BodyMatch); err != nil {
+			return Spec{}, fmt.Errorf("invalid body_match: %w", err)
+		}
+	}
+	if spec.TCP && (c.ExpectStatus != 0 || c.BodyMatch != "") {
+		return Spec{}, errors.New("expect_status and body_match need an http url")
+	}
+	return spec, nil
+}
+
+// Check runs the probe once. Failures are reported in the result, not as an
+// error: an unreachable service is an answer, not a fault in Orbit.
+func Check(ctx context.Context, spec Spec) platform.ProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, spec.Timeout)
+	defer cancel()
+
+	var r platform.ProbeResult
+	if spec.TCP {
+		r = checkTCP(ctx, spec)
+	} else {
+		r = checkHTTP(ctx, spec)
+	}
+	if r.OK && spec.Budget > 0 && time.Duration(r.LatencyMs)*time.Millisecond > spec.Budget {
+		r.Slow = true
+	}
+	return r
+}
+
+func checkTCP(ctx context.Context, spec Spec) platform.ProbeResult {
+	r := platform.ProbeResult{Target: spec.URL}
+	u, _ := url.Parse(spec.URL)
+	var d net.Dialer
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", u.Host)
+	r.LatencyMs = int(time.Since(start).Milliseconds())
+	if err != nil {
+		r.Failure = failure(ctx, spec, err)
+		return r
+	}
+	conn.Close()
+	r.OK = true
+	return r
+}
+
+func checkHTTP(ctx context.Context, spec Spec) platform.ProbeResult {
+	r := platform.ProbeResult{Target: spec.URL}
+	req, err := http.NewRequestWithContext(ctx, "GET", spec.URL, nil)
+	if err != nil {
+		r.Failure = err.Error()
+		return r
+	}
+	req.Header.Set("User-Agent", "orbit-probe")
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	r.LatencyMs = int(time.Since(start).Milliseconds())
+	if err != nil {
+		r.Failure = failure(ctx, spec, err)
+		return r
+	}
+	defer resp.Body.Close()
+	r.Code = resp.StatusCode
+
+	switch {
+	case spec.ExpectStatus != 0 && resp.StatusCode != spec.ExpectStatus:
+		r.Failure = fmt.Sprintf("HTTP %d, want %d", resp.StatusCode, spec.ExpectStatus)
+		return r
+	case spec.ExpectStatus == 0 && resp.StatusCode >= 400:
+		r.Failure = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		return r
+	}
+
+	if spec.BodyMatch != nil {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+		if err != nil {
+			r.Failure = failure(ctx, spec, err)
+			return r
+		}
+		if !spec.BodyMatch.Match(body) {
+			r.Failure = fmt.Sprintf("body does not match /%s/", spec.BodyMatch)
+			return r
+		}
+	}
+	r.OK = true
+	return r
+}
+
+func failure(ctx context.Context, spec Spec, err error) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Sprintf("no answer within %s", spec.Timeout)
+	}
+	return "unreachable: " + err.Error()
+}
+
+// Merge records r on status and lets it override a platform status that is
+// too optimistic: a failed probe makes the service unhealthy, and a slow one
+// turns healthy into degraded. A sleeping service is left as it is; it is
+// not expected to answer.
+func Merge(status *platform.ServiceStatus, r platform.ProbeResult) {
+	status.Probe = &r
+	if status.Status == "sleeping" {
+		return
+	}
+	switch {
+	case !r.OK:
+		status.Status = "unhealthy"
+	case r.Slow && status.Status == "healthy":
+		status.Status = "degraded"
+	}
+	if r.OK && status.ResponseMs == 0 {
+		status.ResponseMs = r.LatencyMs
+	}
+}
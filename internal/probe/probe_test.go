@@ -0,0 +1,139 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec(config.ProbeConfig{URL: "https://example.com/health", Budget: "800ms", BodyMatch: `"ok"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.TCP || spec.Budget != 800*time.Millisecond || spec.Timeout != DefaultTimeout || spec.BodyMatch == nil {
+		t.Errorf("spec = %+v", spec)
+	}
+
+	bad := []config.ProbeConfig{
+		{},
+		{URL: "ftp://example.com"},
+		{URL: "tcp://db.internal"},
+		{URL: "https://example.com", ExpectStatus: 42},
+		{URL: "https://example.com", Budget: "fast"},
+		{URL: "https://example.com", Timeout: "-1s"},
+		{URL: "https://example.com", BodyMatch: "("},
+		{URL: "tcp://db.internal:5432", ExpectStatus: 200},
+	}
+	for _, c := range bad {
+		if _, err := ParseSpec(c); err == nil {
+			t.Errorf("ParseSpec(%+v) succeeded, want error", c)
+		}
+	}
+}
+
+func TestFromEntry(t *testing.T) {
+	if _, ok, err := FromEntry(config.ServiceEntry{Name: "api"}); ok || err != nil {
+		t.Errorf("no probe: ok=%v err=%v", ok, err)
+	}
+	e := config.ServiceEntry{Name: "api", HeartbeatURL: "https://api.example.com/health", Probe: &config.ProbeConfig{}}
+	spec, ok, err := FromEntry(e)
+	if !ok || err != nil || spec.URL != e.HeartbeatURL {
+		t.Errorf("heartbeat fallback: spec=%+v ok=%v err=%v", spec, ok, err)
+	}
+}
+
+func TestCheckHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Write([]byte(`{"status":"ok"}`))
+		case "/slow":
+			time.Sleep(30 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		cfg     config.ProbeConfig
+		ok      bool
+		slow    bool
+		failure string
+	}{
+		{"ok", config.ProbeConfig{URL: srv.URL + "/health", BodyMatch: `"status":"ok"`}, true, false, ""},
+		{"down", config.ProbeConfig{URL: srv.URL + "/down"}, false, false, "HTTP 503"},
+		{"wrong status", config.ProbeConfig{URL: srv.URL + "/health", ExpectStatus: 204}, false, false, "HTTP 200, want 204"},
+		{"body", config.ProbeConfig{URL: srv.URL + "/health", BodyMatch: "healthy"}, false, false, "body does not match"},
+		{"slow", config.ProbeConfig{URL: srv.URL + "/slow", Budget: "10ms"}, true, true, ""},
+		{"timeout", config.ProbeConfig{URL: srv.URL + "/slow", Timeout: "5ms"}, false, false, "no answer within 5ms"},
+	}
+	for _, tt := range tests {
+		spec, err := ParseSpec(tt.cfg)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		r := Check(context.Background(), spec)
+		if r.OK != tt.ok || r.Slow != tt.slow || !strings.Contains(r.Failure, tt.failure) || (tt.failure == "" && r.Failure != "") {
+			t.Errorf("%s: got %+v", tt.name, r)
+		}
+	}
+}
+
+func TestCheckTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+
+	spec, err := ParseSpec(config.ProbeConfig{URL: "tcp://" + addr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := Check(context.Background(), spec); !r.OK {
+		t.Errorf("listening: got %+v", r)
+	}
+
+	ln.Close()
+	if r := Check(context.Background(), spec); r.OK || !strings.HasPrefix(r.Failure, "unreachable") {
+		t.Errorf("closed: got %+v", r)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		platform string
+		result   platform.ProbeResult
+		want     string
+	}{
+		{"healthy", platform.ProbeResult{OK: true}, "healthy"},
+		{"healthy", platform.ProbeResult{OK: true, Slow: true}, "degraded"},
+		{"healthy", platform.ProbeResult{Failure: "HTTP 502"}, "unhealthy"},
+		{"degraded", platform.ProbeResult{Failure: "HTTP 502"}, "unhealthy"},
+		{"unhealthy", platform.ProbeResult{OK: true}, "unhealthy"},
+		{"sleeping", platform.ProbeResult{Failure: "unreachable"}, "sleeping"},
+	}
+	for _, tt := range tests {
+		s := &platform.ServiceStatus{Status: tt.platform}
+		Merge(s, tt.result)
+		if s.Status != tt.want || s.Probe == nil {
+			t.Errorf("Merge(%s, %+v) = %s, want %s", tt.platform, tt.result, s.Status, tt.want)
+		}
+	}
+
+	s := &platform.ServiceStatus{Status: "healthy"}
+	Merge(s, platform.ProbeResult{OK: true, LatencyMs: 120})
+	if s.ResponseMs != 120 {
+		t.Errorf("ResponseMs = %d, want probe latency 120", s.ResponseMs)
+	}
+}
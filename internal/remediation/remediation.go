@@ -0,0 +1,183 @@
+// Package remediation decides when a failing service should be healed
+// automatically and keeps an audit log of every attempt.
+package remediation
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+// ActionRestart redeploys the service's current configuration.
+const ActionRestart = "restart"
+
+// Defaults for unset policy fields.
+const (
+	DefaultAfter      = 3
+	DefaultMaxPerHour = 2
+)
+
+// Policy is a validated config.RemediationPolicy with defaults applied.
+type Policy struct {
+	Action     string
+	After      int
+	MaxPerHour int
+}
+
+// ParsePolicy validates p and fills in defaults.
+func ParsePolicy(p config.RemediationPolicy) (Policy, error) {
+	if p.OnUnhealthy != ActionRestart {
+		return Policy{}, fmt.Errorf("unknown on_unhealthy action %q (supported: %s)", p.OnUnhealthy, ActionRestart)
+	}
+	if p.After < 0 || p.MaxPerHour < 0 {
+		return Policy{}, fmt.Errorf("after and max_per_hour must not be negative")
+	}
+	pol := Policy{Action: p.OnUnhealthy, After: p.After, MaxPerHour: p.MaxPerHour}
+	if pol.After == 0 {
+		pol.After = DefaultAfter
+	}
+	if pol.MaxPerHour == 0 {
+		pol.MaxPerHour = DefaultMaxPerHour
+	}
+	return pol, nil
+}
+
+// Decision is the outcome of a check.
+type Decision int
+
+const (
+	Wait    Decision = iota // healthy, or not failing long enough yet
+	Act                     // run the policy's action now
+	Limited                 // should act, but max_per_hour is used up
+)
+
+// Tracker follows one service's checks and applies its policy.
+type Tracker struct {
+	policy   Policy
+	failures int
+	actions  []time.Time // within the last hour
+	limited  bool        // already reported Limited for this failure streak
+}
+
+// NewTracker returns a tracker for policy. past holds earlier action times
+// (from the audit log) so a restarted daemon keeps counting toward the
+// hourly limit.
+func NewTracker(policy Policy, past []time.Time) *Tracker {
+	return &Tracker{policy: policy, actions: past}
+}
+
+// Observe records a check result and decides what to do. After Act the
+// failure count starts over, giving the service time to come back. Limited
+// is returned once per failure streak so callers notify once, not every check.
+func (t *Tracker) Observe(healthy bool, now time.Time) Decision {
+	if healthy {
+		t.failures = 0
+		t.limited = false
+		return Wait
+	}
+	t.failures++
+	if t.failures < t.policy.After {
+		return Wait
+	}
+
+	recent := t.actions[:0]
+	for _, at := range t.actions {
+		if now.Sub(at) < time.Hour {
+			recent = append(recent, at)
+		}
+	}
+	t.actions = recent
+	if len(t.actions) >= t.policy.MaxPerHour {
+		if t.limited {
+			return Wait
+		}
+		t.limited = true
+		return Limited
+	}
+
+	t.failures = 0
+	t.actions = append(t.actions, now)
+	return Act
+}
+
+// Record is one audit log entry.
+type Record struct {
+	Time     time.Time `json:"time"`
+	Project  string    `json:"project"`
+	Service  string    `json:"service"`
+	Action   string    `json:"action"`
+	Reason   string    `json:"reason"`
+	Result   string    `json:"result"` // triggered, failed, limited
+	DeployID string    `json:"deploy_id,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// AuditPath returns the audit log location, ~/.orbit/remediation.log.
+func AuditPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "remediation.log"), nil
+}
+
+// Append adds r to the audit log at path, one JSON object per line.
+func Append(path string, r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// Load reads the audit log at path. A missing file is an empty log;
+// malformed lines are skipped.
+func Load(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if json.Unmarshal(scanner.Bytes(), &r) == nil {
+			records = append(records, r)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// ActionTimes returns when actions were taken for a service since since,
+// for seeding a Tracker.
+func ActionTimes(records []Record, project, service string, since time.Time) []time.Time {
+	var times []time.Time
+	for _, r := range records {
+		if r.Project == project && r.Service == service && r.Result != "limited" && !r.Time.Before(since) {
+			times = append(times, r.Time)
+		}
+	}
+	return times
+}
@@ -0,0 +1,97 @@
+package remediation
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      config.RemediationPolicy
+		want    Policy
+		wantErr bool
+	}{
+		{"defaults", config.RemediationPolicy{OnUnhealthy: "restart"}, Policy{"restart", DefaultAfter, DefaultMaxPerHour}, false},
+		{"explicit", config.RemediationPolicy{OnUnhealthy: "restart", After: 1, MaxPerHour: 5}, Policy{"restart", 1, 5}, false},
+		{"unknown action", config.RemediationPolicy{OnUnhealthy: "reboot"}, Policy{}, true},
+		{"negative", config.RemediationPolicy{OnUnhealthy: "restart", After: -1}, Policy{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolicy(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracker(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker(Policy{Action: "restart", After: 2, MaxPerHour: 1}, nil)
+
+	steps := []struct {
+		healthy bool
+		advance time.Duration
+		want    Decision
+	}{
+		{false, 0, Wait},
+		{true, time.Minute, Wait}, // a healthy check resets the streak
+		{false, time.Minute, Wait},
+		{false, time.Minute, Act},
+		{false, time.Minute, Wait},
+		{false, time.Minute, Limited}, // hourly budget used up
+		{false, time.Minute, Wait},    // reported once per streak
+		{false, time.Minute, Wait},
+		{false, time.Hour, Act}, // budget refilled
+	}
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		if got := tr.Observe(s.healthy, now); got != s.want {
+			t.Fatalf("step %d: got %v, want %v", i, got, s.want)
+		}
+	}
+}
+
+func TestTrackerSeededFromAudit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "remediation.log")
+	for _, r := range []Record{
+		{Time: now.Add(-10 * time.Minute), Project: "shop", Service: "api", Result: "triggered"},
+		{Time: now.Add(-5 * time.Minute), Project: "shop", Service: "api", Result: "limited"},
+		{Time: now.Add(-5 * time.Minute), Project: "shop", Service: "web", Result: "triggered"},
+		{Time: now.Add(-2 * time.Hour), Project: "shop", Service: "api", Result: "triggered"},
+	} {
+		if err := Append(path, r); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	records, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	past := ActionTimes(records, "shop", "api", now.Add(-time.Hour))
+	if len(past) != 1 {
+		t.Fatalf("ActionTimes = %v, want one recent action", past)
+	}
+
+	tr := NewTracker(Policy{Action: "restart", After: 1, MaxPerHour: 1}, past)
+	if got := tr.Observe(false, now); got != Limited {
+		t.Errorf("got %v, want Limited after a restart 10m ago", got)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	records, err := Load(filepath.Join(t.TempDir(), "none.log"))
+	if err != nil || records != nil {
+		t.Errorf("Load = %v, %v; want empty", records, err)
+	}
+}
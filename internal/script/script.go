@@ -0,0 +1,334 @@
+// Package script runs a user's Starlark policy file: optional hooks that
+// derive a service's health, add threshold checks the fixed thresholds
+// can't express, and build notification payloads.
+package script
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// Hooks the policy file may define.
+const (
+	HookHealth     = "health"     // health(svc) -> status or None
+	HookThresholds = "thresholds" // thresholds(svc) -> list of violations
+)
+
+// maxSteps bounds each call so a runaway loop can't hang a command.
+const maxSteps = 1000000
+
+// statuses a health hook may return.
+var statuses = map[string]bool{"healthy": true, "degraded": true, "unhealthy": true, "sleeping": true}
+
+// Violation is a threshold violation reported by the thresholds hook.
+type Violation struct {
+	Metric    string
+	Value     string
+	Threshold string
+}
+
+// Policy is a loaded policy file. Its globals are frozen, so hooks can be
+// called from several goroutines at once.
+type Policy struct {
+	path    string
+	globals starlark.StringDict
+}
+
+// Load runs the policy file at path and checks its hooks.
+func Load(path string) (*Policy, error) {
+	globals, err := starlark.ExecFile(newThread(path), path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("load script %s: %s", path, explain(err))
+	}
+	globals.Freeze()
+	for _, hook := range []string{HookHealth, HookThresholds} {
+		if v, ok := globals[hook]; ok {
+			if _, ok := v.(starlark.Callable); !ok {
+				return nil, fmt.Errorf("load script %s: %s is a %s, want a function", path, hook, v.Type())
+			}
+		}
+	}
+	return &Policy{path: path, globals: globals}, nil
+}
+
+// Hooks returns the names of the functions the policy defines.
+func (p *Policy) Hooks() []string {
+	var names []string
+	for name, v := range p.globals {
+		if _, ok := v.(starlark.Callable); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newThread(path string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: path,
+		Print: func(_ *starlark.Thread, msg string) {
+			fmt.Fprintf(os.Stderr, "script: %s\n", msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+	return thread
+}
+
+// explain includes the Starlark backtrace for errors raised by the script.
+func explain(err error) string {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return evalErr.Backtrace()
+	}
+	return err.Error()
+}
+
+// call runs the global function fn, reporting false if the policy does not
+// define it.
+func (p *Policy) call(fn string, args ...starlark.Value) (starlark.Value, bool, error) {
+	v, ok := p.globals[fn]
+	if !ok {
+		return nil, false, nil
+	}
+	callable, ok := v.(starlark.Callable)
+	if !ok {
+		return nil, true, fmt.Errorf("%s is a %s, want a function", fn, v.Type())
+	}
+	res, err := starlark.Call(newThread(p.path), callable, args, nil)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s: %s", fn, explain(err))
+	}
+	return res, true, nil
+}
+
+// Health runs the health hook for a service. It returns "" when the policy
+// has no health hook or the hook returns None, leaving the status as is.
+func (p *Policy) Health(project string, e config.ServiceEntry, s *platform.ServiceStatus) (string, error) {
+	res, ok, err := p.call(HookHealth, serviceValue(project, e, s))
+	if !ok || err != nil || res == starlark.None {
+		return "", err
+	}
+	str, ok := starlark.AsString(res)
+	if !ok || !statuses[str] {
+		return "", fmt.Errorf("health returned %s, want healthy, degraded, unhealthy, sleeping or None", res)
+	}
+	return str, nil
+}
+
+// Violations runs the thresholds hook for a service. Each item it returns
+// is a dict with a metric and optionally a value and threshold.
+func (p *Policy) Violations(project string, e config.ServiceEntry, s *platform.ServiceStatus) ([]Violation, error) {
+	res, ok, err := p.call(HookThresholds, serviceValue(project, e, s))
+	if !ok || err != nil || res == starlark.None {
+		return nil, err
+	}
+	iter, ok := res.(starlark.Iterable)
+	if !ok {
+		return nil, fmt.Errorf("thresholds returned a %s, want a list", res.Type())
+	}
+	it := iter.Iterate()
+	defer it.Done()
+
+	var violations []Violation
+	var item starlark.Value
+	for it.Next(&item) {
+		d, ok := item.(starlark.Mapping)
+		if !ok {
+			return nil, fmt.Errorf("thresholds returned a %s item, want a dict", item.Type())
+		}
+		v := Violation{
+			Metric:    field(d, "metric"),
+			Value:     field(d, "value"),
+			Threshold: field(d, "threshold"),
+		}
+		if v.Metric == "" {
+			return nil, errors.New("thresholds returned a violation without a metric")
+		}
+		violations = append(violations, v)
+	}
+	return violations, nil
+}
+
+// field returns d[key] as text: strings as they are, other values as
+// Starlark prints them.
+func field(d starlark.Mapping, key string) string {
+	v, found, err := d.Get(starlark.String(key))
+	if err != nil || !found || v == starlark.None {
+		return ""
+	}
+	if s, ok := starlark.AsString(v); ok {
+		return s
+	}
+	return v.String()
+}
+
+// Payload calls fn with a list of events and returns the JSON payload to
+// send: a dict or list as is, or a string as {"text": ...}. It returns nil
+// when fn returns None, so the channel's built-in payload is used.
+func (p *Policy) Payload(fn string, events []notify.Event) (interface{}, error) {
+	list := make([]starlark.Value, len(events))
+	for i, ev := range events {
+		list[i] = eventValue(ev)
+	}
+	res, ok, err := p.call(fn, starlark.NewList(list))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("script has no function %s", fn)
+	}
+	if s, ok := starlark.AsString(res); ok {
+		return map[string]interface{}{"text": s}, nil
+	}
+	return toGo(res)
+}
+
+func serviceValue(project string, e config.ServiceEntry, s *platform.ServiceStatus) starlark.Value {
+	d := starlark.StringDict{
+		"project":       starlark.String(project),
+		"name":          starlark.String(e.Name),
+		"platform":      starlark.String(e.Platform),
+		"id":            starlark.String(e.ID),
+		"tags":          stringList(e.Tags),
+		"status":        starlark.String(s.Status),
+		"response_ms":   starlark.MakeInt(s.ResponseMs),
+		"cpu":           starlark.Float(s.CPU), // -1 when not reported
+		"memory":        starlark.Float(s.Memory),
+		"instances":     starlark.MakeInt(s.Instances),
+		"max_instances": starlark.MakeInt(s.MaxInstances),
+		"last_deploy":   starlark.None,
+		"probe":         starlark.None,
+	}
+	if dep := s.LastDeploy; dep != nil {
+		d["last_deploy"] = starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"id":         starlark.String(dep.ID),
+			"status":     starlark.String(dep.Status),
+			"commit":     starlark.String(dep.Commit),
+			"message":    starlark.String(dep.Message),
+			"branch":     starlark.String(dep.Branch),
+			"author":     starlark.String(dep.Author),
+			"created_at": timeValue(dep.CreatedAt),
+			"age_s":      starlark.MakeInt64(ageSeconds(dep.CreatedAt)),
+		})
+	}
+	if pr := s.Probe; pr != nil {
+		d["probe"] = starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"ok":         starlark.Bool(pr.OK),
+			"slow":       starlark.Bool(pr.Slow),
+			"latency_ms": starlark.MakeInt(pr.LatencyMs),
+			"code":       starlark.MakeInt(pr.Code),
+			"failure":    starlark.String(pr.Failure),
+		})
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, d)
+}
+
+func eventValue(ev notify.Event) starlark.Value {
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"type":     starlark.String(ev.Type),
+		"severity": starlark.String(ev.Severity),
+		"project":  starlark.String(ev.Project),
+		"service":  starlark.String(ev.Service),
+		"subject":  starlark.String(ev.Subject()),
+		"tags":     stringList(ev.Tags),
+		"owner":    starlark.String(ev.Owner),
+		"title":    starlark.String(ev.Title),
+		"message":  starlark.String(ev.Message),
+		"phase":    starlark.String(ev.Phase),
+		"commit":   starlark.String(ev.Commit),
+		"url":      starlark.String(ev.URL),
+		"time":     timeValue(ev.Time),
+	})
+}
+
+func stringList(ss []string) *starlark.List {
+	list := make([]starlark.Value, len(ss))
+	for i, s := range ss {
+		list[i] = starlark.String(s)
+	}
+	return starlark.NewList(list)
+}
+
+// timeValue formats t as RFC 3339, or "" for the zero time.
+func timeValue(t time.Time) starlark.Value {
+	if t.IsZero() {
+		return starlark.String("")
+	}
+	return starlark.String(t.UTC().Format(time.RFC3339))
+}
+
+func ageSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return -1
+	}
+	return int64(time.Since(t) / time.Second)
+}
+
+// toGo converts a Starlark value returned by a payload function into
+// something encoding/json can marshal.
+func toGo(v starlark.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		if i, ok := v.Int64(); ok {
+			return i, nil
+		}
+		return v.String(), nil
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.String:
+		return string(v), nil
+	case *starlark.List, starlark.Tuple:
+		var out []interface{}
+		it := v.(starlark.Iterable).Iterate()
+		defer it.Done()
+		var item starlark.Value
+		for it.Next(&item) {
+			g, err := toGo(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, g)
+		}
+		return out, nil
+	case *starlark.Dict:
+		out := make(map[string]interface{}, v.Len())
+		for _, kv := range v.Items() {
+			key, ok := starlark.AsString(kv[0])
+			if !ok {
+				return nil, fmt.Errorf("payload dict key %s is not a string", kv[0])
+			}
+			g, err := toGo(kv[1])
+			if err != nil {
+				return nil, err
+			}
+			out[key] = g
+		}
+		return out, nil
+	case *starlarkstruct.Struct:
+		d := starlark.StringDict{}
+		v.ToStringDict(d)
+		out := make(map[string]interface{}, len(d))
+		for key, val := range d {
+			g, err := toGo(val)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = g
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("payload contains a %s, which has no JSON form", v.Type())
+}
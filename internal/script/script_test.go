@@ -0,0 +1,154 @@
+package script
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/notify"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+const testPolicy = `
+def health(svc):
+    # A worker with no instances is down whatever the platform says.
+    if "worker" in svc.tags and svc.instances == 0:
+        return "unhealthy"
+    if svc.probe and svc.probe.slow:
+        return "degraded"
+    return None
+
+def thresholds(svc):
+    out = []
+    if svc.platform == "vercel" and svc.response_ms > 300:
+        out.append({"metric": "edge_latency", "value": "%dms" % svc.response_ms, "threshold": "300ms"})
+    if svc.last_deploy and svc.last_deploy.status == "failed":
+        out.append({"metric": "last_deploy_failed"})
+    return out
+
+def pager(events):
+    return {
+        "summary": "%d events" % len(events),
+        "critical": [e.subject for e in events if e.severity == "critical"],
+    }
+
+def chat(events):
+    return events[0].title
+
+def default(events):
+    return None
+
+def spin(events):
+    for i in range(1000000000):
+        pass
+`
+
+func loadTestPolicy(t *testing.T, src string) *Policy {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.star")
+	if err := os.WriteFile(path, []byte(src), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestHealth(t *testing.T) {
+	p := loadTestPolicy(t, testPolicy)
+
+	worker := config.ServiceEntry{Name: "jobs", Tags: []string{"worker"}}
+	got, err := p.Health("shop", worker, &platform.ServiceStatus{Status: "healthy"})
+	if err != nil || got != "unhealthy" {
+		t.Errorf("worker without instances: %q, %v", got, err)
+	}
+
+	api := config.ServiceEntry{Name: "api"}
+	got, err = p.Health("shop", api, &platform.ServiceStatus{Status: "healthy", Instances: 2})
+	if err != nil || got != "" {
+		t.Errorf("None should keep the status: %q, %v", got, err)
+	}
+
+	got, err = p.Health("shop", api, &platform.ServiceStatus{Status: "healthy", Probe: &platform.ProbeResult{OK: true, Slow: true}})
+	if err != nil || got != "degraded" {
+		t.Errorf("slow probe: %q, %v", got, err)
+	}
+}
+
+func TestHealthRejectsUnknownStatus(t *testing.T) {
+	p := loadTestPolicy(t, `def health(svc): return "fine"`)
+	if _, err := p.Health("shop", config.ServiceEntry{}, &platform.ServiceStatus{}); err == nil {
+		t.Error("want error for an unknown status")
+	}
+}
+
+func TestViolations(t *testing.T) {
+	p := loadTestPolicy(t, testPolicy)
+	e := config.ServiceEntry{Name: "web", Platform: "vercel"}
+	s := &platform.ServiceStatus{ResponseMs: 450, LastDeploy: &platform.Deployment{Status: "failed", CreatedAt: time.Now()}}
+
+	got, err := p.Violations("shop", e, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Violation{
+		{Metric: "edge_latency", Value: "450ms", Threshold: "300ms"},
+		{Metric: "last_deploy_failed"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Violations = %+v, want %+v", got, want)
+	}
+}
+
+func TestPayload(t *testing.T) {
+	p := loadTestPolicy(t, testPolicy)
+	events := []notify.Event{
+		{Severity: notify.SeverityCritical, Project: "shop", Service: "api", Title: "service unhealthy"},
+		{Severity: notify.SeverityWarning, Project: "shop", Service: "web", Title: "cpu over threshold"},
+	}
+
+	got, err := p.Payload("pager", events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"summary": "2 events", "critical": []interface{}{"shop/api"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pager payload = %#v", got)
+	}
+
+	got, err = p.Payload("chat", events)
+	if err != nil || !reflect.DeepEqual(got, map[string]interface{}{"text": "service unhealthy"}) {
+		t.Errorf("chat payload = %#v, %v", got, err)
+	}
+
+	if got, err := p.Payload("default", events); got != nil || err != nil {
+		t.Errorf("None should mean the built-in payload: %#v, %v", got, err)
+	}
+	if _, err := p.Payload("missing", events); err == nil {
+		t.Error("want error for an undefined function")
+	}
+	if _, err := p.Payload("spin", events); err == nil || !strings.Contains(err.Error(), "spin") {
+		t.Errorf("runaway loop: %v", err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"syntax":  "def health(svc)\n    return None\n",
+		"runtime": "x = 1 // 0\n",
+		"hook":    "health = 3\n",
+	} {
+		path := filepath.Join(dir, name+".star")
+		os.WriteFile(path, []byte(src), 0600)
+		if _, err := Load(path); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
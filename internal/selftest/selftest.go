@@ -0,0 +1,278 @@
+// Package selftest runs Orbit's status, deployment, log and watch code
+// paths against a fake platform API that injects errors — rate limits,
+// timeouts, malformed JSON — and reports the paths that mishandled them.
+// It guards the adapter contract every platform relies on: retry what is
+// transient, classify what is not, and never hang or report success on a
+// broken response.
+package selftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// DefaultDeadline bounds each check.
+const DefaultDeadline = 10 * time.Second
+
+// timeoutSlack is how long a call may overrun its deadline before it
+// counts as hanging.
+const timeoutSlack = time.Second
+
+// Scenario is a fault and how every code path must react to it.
+type Scenario struct {
+	Name    string
+	Fault   Fault
+	Summary string
+	// Outcome is what each path must do: "ok" to succeed, "timeout" to
+	// give up at the deadline, "error" to fail, or an error kind
+	// (platform.ErrorKind) to fail with that kind.
+	Outcome  string
+	Deadline time.Duration
+}
+
+// Scenarios is the built-in fault matrix.
+var Scenarios = []Scenario{
+	{Name: "baseline", Fault: FaultNone, Summary: "No faults", Outcome: "ok"},
+	{Name: "rate-limit-once", Fault: FaultRateLimitOnce, Summary: "One 429 per endpoint is retried", Outcome: "ok"},
+	{Name: "server-error-once", Fault: FaultServerErrorOnce, Summary: "One 503 per endpoint is retried", Outcome: "ok"},
+	{Name: "rate-limited", Fault: FaultRateLimited, Summary: "Persistent 429 fails as rate_limited", Outcome: "rate_limited"},
+	{Name: "unauthorized", Fault: FaultUnauthorized, Summary: "401 fails as unauthorized", Outcome: "unauthorized"},
+	{Name: "not-found", Fault: FaultNotFound, Summary: "404 fails as not_found", Outcome: "not_found"},
+	{Name: "malformed-json", Fault: FaultMalformedJSON, Summary: "Truncated JSON fails instead of reading as empty", Outcome: "error"},
+	{Name: "timeout", Fault: FaultTimeout, Summary: "An API that never answers gives up at the deadline", Outcome: "timeout", Deadline: 2 * time.Second},
+}
+
+// Paths are the code paths each scenario runs.
+var Paths = []string{"status", "deploys", "logs", "watch"}
+
+// Result is the outcome of one path under one scenario.
+type Result struct {
+	Scenario string
+	Path     string
+	OK       bool
+	Detail   string // what went wrong, or what happened when OK
+	Elapsed  time.Duration
+}
+
+// Run runs every path under every scenario in parallel, each against its
+// own fake API. Results are in scenario, then Paths, order.
+func Run(ctx context.Context, scenarios []Scenario) []Result {
+	results := make([]Result, len(scenarios)*len(Paths))
+	var wg sync.WaitGroup
+	for i, sc := range scenarios {
+		for j, path := range Paths {
+			wg.Add(1)
+			go func(idx int, sc Scenario, path string) {
+				defer wg.Done()
+				results[idx] = check(ctx, sc, path)
+			}(i*len(Paths)+j, sc, path)
+		}
+	}
+	wg.Wait()
+	return results
+}
+
+// Find returns the scenario with the given name.
+func Find(name string) (Scenario, bool) {
+	for _, sc := range Scenarios {
+		if sc.Name == name {
+			return sc, true
+		}
+	}
+	return Scenario{}, false
+}
+
+func check(ctx context.Context, sc Scenario, path string) Result {
+	r := Result{Scenario: sc.Name, Path: path}
+
+	srv := newServer(sc.Fault)
+	defer srv.Close()
+	p := platform.NewFake(srv.URL(), Token)
+	p.SetPollInterval(50 * time.Millisecond)
+
+	deadline := sc.Deadline
+	if deadline == 0 {
+		deadline = DefaultDeadline
+	}
+	ctx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	type outcome struct {
+		detail string
+		err    error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", v)}
+			}
+		}()
+		var o outcome
+		switch path {
+		case "status":
+			o.detail, o.err = checkStatus(ctx, p)
+		case "deploys":
+			o.detail, o.err = checkDeploys(ctx, p)
+		case "logs":
+			o.detail, o.err = checkLogs(ctx, p)
+		case "watch":
+			srv.trigger()
+			o.detail, o.err = checkWatch(ctx, p)
+		}
+		done <- o
+	}()
+
+	// A path that ignores ctx would block forever; give up on it after
+	// the slack and report it as hung.
+	hung := time.NewTimer(deadline + timeoutSlack)
+	defer hung.Stop()
+	select {
+	case o := <-done:
+		r.Elapsed = time.Since(start)
+		r.OK, r.Detail = judge(sc, o.err, o.detail, r.Elapsed, deadline)
+	case <-hung.C:
+		r.Elapsed = time.Since(start)
+		r.Detail = fmt.Sprintf("hung past the %s deadline", deadline)
+	}
+	return r
+}
+
+// judge compares what a path did with the scenario's expected outcome.
+func judge(sc Scenario, err error, detail string, elapsed, deadline time.Duration) (bool, string) {
+	if elapsed > deadline+timeoutSlack {
+		return false, fmt.Sprintf("hung for %s, past the %s deadline", elapsed.Round(time.Millisecond), deadline)
+	}
+	switch sc.Outcome {
+	case "ok":
+		if err != nil {
+			return false, err.Error()
+		}
+		return true, detail
+	case "timeout":
+		if err == nil {
+			return false, "succeeded against an API that never answers: " + detail
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return false, "failed with something other than the deadline: " + err.Error()
+		}
+		return true, "gave up after " + elapsed.Round(100*time.Millisecond).String()
+	case "error":
+		if err == nil {
+			return false, "succeeded on a broken response: " + detail
+		}
+		return true, err.Error()
+	default:
+		if err == nil {
+			return false, "succeeded, want a " + sc.Outcome + " error: " + detail
+		}
+		if kind := platform.ErrorKind(err); kind != sc.Outcome {
+			if kind == "" {
+				kind = "unclassified"
+			}
+			return false, fmt.Sprintf("%s error, want %s: %v", kind, sc.Outcome, err)
+		}
+		return true, err.Error()
+	}
+}
+
+func checkStatus(ctx context.Context, p platform.Platform) (string, error) {
+	st, err := p.GetServiceStatus(ctx, "web")
+	if err != nil {
+		return "", err
+	}
+	if st.Status != "healthy" {
+		return "", fmt.Errorf("status %q, want healthy", st.Status)
+	}
+	if st.LastDeploy == nil || st.LastDeploy.ID != "d1" {
+		return "", fmt.Errorf("last deploy missing")
+	}
+	return "healthy, last deploy d1", nil
+}
+
+// checkDeploys lists more deployments than fit on one page of the API.
+func checkDeploys(ctx context.Context, p platform.Platform) (string, error) {
+	const limit = pageSize + pageSize/2
+	deploys, err := p.ListDeployments(ctx, "web", limit)
+	if err != nil {
+		return "", err
+	}
+	if len(deploys) != limit {
+		return "", fmt.Errorf("got %d deployments, want %d", len(deploys), limit)
+	}
+	if d := deploys[0]; d.ID != "d1" || d.Status != "healthy" || d.Duration <= 0 {
+		return "", fmt.Errorf("newest deployment = %s %s, want d1 healthy with a duration", d.ID, d.Status)
+	}
+	return fmt.Sprintf("%d deployments over 2 pages", limit), nil
+}
+
+func checkLogs(ctx context.Context, p platform.Platform) (string, error) {
+	logs, err := p.GetLogs(ctx, "web", platform.LogOptions{Tail: 10})
+	if err != nil {
+		return "", err
+	}
+	if len(logs) != 2 || logs[1].Level != "error" {
+		return "", fmt.Errorf("got %d log lines, want 2", len(logs))
+	}
+	return "2 lines", nil
+}
+
+// watchPhases is the order watch phases must arrive in; phases may be
+// skipped but never go backwards.
+var watchPhases = map[string]int{
+	"waiting": 0, "detected": 1, "building": 2, "deploying": 3, "healthcheck": 4, "done": 5, "failed": 5,
+}
+
+// checkWatch watches for d2 and checks the events arrive in order, end in
+// done or failed, and stop when ctx is done.
+func checkWatch(ctx context.Context, p platform.Platform) (string, error) {
+	events, err := p.WatchDeployment(ctx, "web", "d1")
+	if err != nil {
+		return "", err
+	}
+
+	var seen []string
+	last := -1
+	var final *platform.DeployEvent
+	for ev := range events {
+		if final != nil {
+			return "", fmt.Errorf("%s event after %s", ev.Phase, final.Phase)
+		}
+		order, ok := watchPhases[ev.Phase]
+		if !ok {
+			return "", fmt.Errorf("unknown phase %q", ev.Phase)
+		}
+		if order < last {
+			return "", fmt.Errorf("phase %s after %s", ev.Phase, seen[len(seen)-1])
+		}
+		last = order
+		if len(seen) == 0 || seen[len(seen)-1] != ev.Phase {
+			seen = append(seen, ev.Phase)
+		}
+		if ev.Phase == "done" || ev.Phase == "failed" {
+			ev := ev
+			final = &ev
+		}
+	}
+
+	switch {
+	case final == nil && ctx.Err() != nil:
+		return "", ctx.Err()
+	case final == nil:
+		return "", fmt.Errorf("events ended without done or failed")
+	case final.Phase == "failed":
+		if final.Error == nil {
+			return "", fmt.Errorf("failed event without an error")
+		}
+		return "", final.Error
+	case final.Deploy == nil || final.Deploy.ID != "d2":
+		return "", fmt.Errorf("done event without deployment d2")
+	}
+	return fmt.Sprint(seen), nil
+}
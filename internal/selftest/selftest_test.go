@@ -0,0 +1,63 @@
+package selftest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/platform"
+	"github.com/humanetools/orbit/internal/platform/platformtest"
+)
+
+func TestScenarios(t *testing.T) {
+	for _, r := range Run(context.Background(), Scenarios) {
+		if !r.OK {
+			t.Errorf("%s/%s mishandled: %s", r.Scenario, r.Path, r.Detail)
+		}
+	}
+}
+
+func TestRunReportsMishandledPaths(t *testing.T) {
+	// A 401 must not be mistaken for a rate limit.
+	sc := Scenario{Name: "wrong-kind", Fault: FaultUnauthorized, Outcome: "rate_limited"}
+	results := Run(context.Background(), []Scenario{sc})
+	if len(results) != len(Paths) {
+		t.Fatalf("got %d results, want %d", len(results), len(Paths))
+	}
+	for _, r := range results {
+		if r.OK {
+			t.Errorf("%s: OK, want mishandled", r.Path)
+		}
+		if !strings.HasPrefix(r.Detail, "unauthorized error, want rate_limited") {
+			t.Errorf("%s: detail = %q", r.Path, r.Detail)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	if _, ok := Find("timeout"); !ok {
+		t.Error(`Find("timeout") not found`)
+	}
+	if _, ok := Find("nope"); ok {
+		t.Error(`Find("nope") found`)
+	}
+}
+
+func TestFakeConformance(t *testing.T) {
+	srv := newServer(FaultNone)
+	defer srv.Close()
+	p := platform.NewFake(srv.URL(), Token)
+	p.SetPollInterval(20 * time.Millisecond)
+
+	platformtest.Run(t, p, platformtest.Fixture{
+		ServiceID:        "web",
+		MinDeployments:   historyLen,
+		WantStatus:       "healthy",
+		MissingServiceID: "nope",
+		MissingDeployID:  "nope",
+		Redeploy:         true,
+		WatchIdle:        200 * time.Millisecond,
+		WatchTimeout:     5 * time.Second,
+	})
+}
@@ -0,0 +1,246 @@
+package selftest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fault is an error the fake platform API injects into its responses.
+type Fault string
+
+const (
+	FaultNone            Fault = "none"
+	FaultRateLimitOnce   Fault = "rate-limit-once"   // 429 on the first request to each endpoint
+	FaultRateLimited     Fault = "rate-limited"      // 429 on every request
+	FaultServerErrorOnce Fault = "server-error-once" // 503 on the first request to each endpoint
+	FaultTimeout         Fault = "timeout"           // never respond
+	FaultMalformedJSON   Fault = "malformed-json"    // truncated JSON bodies
+	FaultUnauthorized    Fault = "unauthorized"      // 401 on every request
+	FaultNotFound        Fault = "not-found"         // 404 on every request
+)
+
+// server is a fake platform API holding one service, "web", whose live
+// deployment d1 is the newest of historyLen. trigger queues d2, which moves
+// one step towards live each time it is fetched. Deploy lists come in pages
+// of pageSize, and every request must carry Token.
+type server struct {
+	fault Fault
+	ts    *httptest.Server
+	done  chan struct{}
+
+	mu      sync.Mutex
+	faulted map[string]bool // endpoints a *-once fault has hit
+	deploys []*fakeDeploy   // newest first
+}
+
+// Token is the API token the fake platform accepts.
+const Token = "selftest"
+
+const (
+	historyLen = 25
+	pageSize   = 10
+)
+
+type fakeDeploy struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	Commit     string    `json:"commit"`
+	Message    string    `json:"message"`
+	Branch     string    `json:"branch"`
+	Author     string    `json:"author"`
+	CreatedAt  time.Time `json:"created_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	URL        string    `json:"url"`
+}
+
+// deployLifecycle is the order a fetched deployment moves through.
+var deployLifecycle = []string{"queued", "building", "deploying", "live"}
+
+func newServer(fault Fault) *server {
+	now := time.Now()
+	s := &server{
+		fault:   fault,
+		done:    make(chan struct{}),
+		faulted: make(map[string]bool),
+	}
+	for i := 0; i < historyLen; i++ {
+		d := &fakeDeploy{
+			ID:         fmt.Sprintf("h%02d", i),
+			Status:     "live",
+			Commit:     fmt.Sprintf("%012x", 0x1a2b3c4d5e00+i),
+			Message:    fmt.Sprintf("Release %d", historyLen-i),
+			Branch:     "main",
+			Author:     "orbit",
+			CreatedAt:  now.Add(-time.Duration(i+1) * time.Hour),
+			FinishedAt: now.Add(-time.Duration(i+1)*time.Hour + 90*time.Second),
+			URL:        "https://web.fake.example",
+		}
+		switch {
+		case i == 0:
+			d.ID = "d1"
+		case i%5 == 2:
+			d.Status, d.FinishedAt = "error", time.Time{}
+		case i%7 == 3:
+			d.Status, d.FinishedAt = "canceled", time.Time{}
+		}
+		s.deploys = append(s.deploys, d)
+	}
+	s.ts = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// URL returns the API endpoint.
+func (s *server) URL() string {
+	return s.ts.URL
+}
+
+// Close stops the server, releasing requests held by FaultTimeout.
+func (s *server) Close() {
+	close(s.done)
+	s.ts.Close()
+}
+
+// trigger queues a new deployment, d2, and returns it.
+func (s *server) trigger() fakeDeploy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := &fakeDeploy{
+		ID:        "d2",
+		Status:    "queued",
+		Commit:    "9f8e7d6c5b4a",
+		Message:   "Fix checkout",
+		Branch:    "main",
+		Author:    "orbit",
+		CreatedAt: time.Now(),
+		URL:       "https://web.fake.example",
+	}
+	s.deploys = append([]*fakeDeploy{d}, s.deploys...)
+	return *d
+}
+
+func (s *server) serve(w http.ResponseWriter, r *http.Request) {
+	if s.inject(w, r) {
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+Token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.Method == "POST" && r.URL.Path == "/services/web/redeploy" {
+		writeJSON(w, s.trigger())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/me":
+		writeJSON(w, map[string]string{"user": "orbit"})
+	case len(parts) == 2 && parts[0] == "services" && parts[1] == "web":
+		writeJSON(w, map[string]interface{}{"name": "web", "status": "live", "instances": 1})
+	case len(parts) == 3 && parts[0] == "services" && parts[1] == "web" && parts[2] == "deploys":
+		limit, cursor := pageSize, 0
+		fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+		fmt.Sscan(r.URL.Query().Get("cursor"), &cursor)
+		cursor = min(cursor, len(s.deploys))
+		end := min(cursor+min(limit, pageSize), len(s.deploys))
+		page := map[string]interface{}{"deploys": s.deploys[cursor:end]}
+		if end < len(s.deploys) {
+			page["next"] = fmt.Sprint(end)
+		}
+		writeJSON(w, page)
+	case len(parts) == 3 && parts[0] == "services" && parts[1] == "web" && parts[2] == "logs":
+		writeJSON(w, map[string]interface{}{"logs": []map[string]interface{}{
+			{"time": time.Now().Add(-time.Second), "level": "info", "message": "GET /health 200"},
+			{"time": time.Now(), "level": "error", "message": "payment provider timed out"},
+		}})
+	case r.Method == "POST" && len(parts) == 3 && parts[0] == "deploys" && parts[2] == "cancel":
+		for _, d := range s.deploys {
+			if d.ID == parts[1] {
+				if d.Status == "live" || d.Status == "error" || d.Status == "canceled" {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				d.Status = "canceled"
+				writeJSON(w, d)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	case len(parts) == 2 && parts[0] == "deploys":
+		for _, d := range s.deploys {
+			if d.ID == parts[1] {
+				s.advance(d)
+				writeJSON(w, d)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// advance moves d one step along deployLifecycle.
+func (s *server) advance(d *fakeDeploy) {
+	for i, st := range deployLifecycle[:len(deployLifecycle)-1] {
+		if d.Status == st {
+			d.Status = deployLifecycle[i+1]
+			if d.Status == "live" {
+				d.FinishedAt = time.Now()
+			}
+			return
+		}
+	}
+}
+
+// inject writes the configured fault for r and reports whether it did.
+func (s *server) inject(w http.ResponseWriter, r *http.Request) bool {
+	switch s.fault {
+	case FaultRateLimitOnce, FaultServerErrorOnce:
+		s.mu.Lock()
+		key := r.Method + " " + r.URL.Path
+		hit := s.faulted[key]
+		s.faulted[key] = true
+		s.mu.Unlock()
+		if hit {
+			return false
+		}
+		if s.fault == FaultRateLimitOnce {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	case FaultRateLimited:
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	case FaultTimeout:
+		select {
+		case <-r.Context().Done():
+		case <-s.done:
+		}
+	case FaultMalformedJSON:
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"deploys": [{"id": "d2", "status": `)
+	case FaultUnauthorized:
+		w.WriteHeader(http.StatusUnauthorized)
+	case FaultNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,192 @@
+// Package smoke runs the post-deploy HTTP checks a service declares under
+// smoke in its topology entry, for orbit verify and orbit watch --verify.
+package smoke
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// DefaultTimeout bounds a check whose config sets no timeout.
+const DefaultTimeout = 10 * time.Second
+
+// maxBody is how much of a response body JSON assertions read.
+const maxBody = 1 << 20
+
+// Result is the outcome of one check.
+type Result struct {
+	Check     string // the check's name, or its method and path
+	URL       string // the URL requested
+	OK        bool   // every expectation held
+	Code      int    // HTTP status, 0 if there was no response
+	LatencyMs int
+	Failures  []string // what didn't hold, e.g. "HTTP 503, want 200"
+}
+
+// Run runs a service's checks one after another, stopping early only when
+// ctx is done. It returns an error when a check can't be run as configured,
+// e.g. a relative path on a service without a url.
+func Run(ctx context.Context, e config.ServiceEntry) ([]Result, error) {
+	results := make([]Result, 0, len(e.Smoke))
+	for i, c := range e.Smoke {
+		target, err := resolveURL(e.URL, c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("service %q smoke check #%d: %w", e.Name, i+1, err)
+		}
+		timeout := DefaultTimeout
+		if c.Timeout != "" {
+			if timeout, err = time.ParseDuration(c.Timeout); err != nil || timeout <= 0 {
+				return nil, fmt.Errorf("service %q smoke check #%d: invalid timeout %q (e.g. 5s)", e.Name, i+1, c.Timeout)
+			}
+		}
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+		results = append(results, Check(ctx, c, target, timeout))
+	}
+	return results, nil
+}
+
+// Passed reports whether every result is OK.
+func Passed(results []Result) bool {
+	for _, r := range results {
+		if !r.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveURL joins a check's path to the service URL. A path that is a
+// full URL is used as it is.
+func resolveURL(base, path string) (string, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path, nil
+	}
+	if base == "" {
+		return "", errors.New("no url for the service (set url, or use a full URL as the path)")
+	}
+	u, err := url.Parse(base)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid service url %q", base)
+	}
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+	ref, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + strings.TrimPrefix(ref.Path, "/")
+	u.RawQuery = ref.RawQuery
+	return u.String(), nil
+}
+
+// Check makes one check's request against target.
+func Check(ctx context.Context, c config.SmokeCheck, target string, timeout time.Duration) Result {
+	method := strings.ToUpper(c.Method)
+	if method == "" {
+		method = "GET"
+	}
+	r := Result{Check: c.Name, URL: target}
+	if r.Check == "" {
+		r.Check = method + " " + c.Path
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		r.Failures = []string{err.Error()}
+		return r
+	}
+	req.Header.Set("User-Agent", "orbit-verify")
+	if len(c.JSON) > 0 {
+		req.Header.Set("Accept", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	r.LatencyMs = int(time.Since(start).Milliseconds())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			r.Failures = []string{fmt.Sprintf("no answer within %s", timeout)}
+		} else {
+			r.Failures = []string{"unreachable: " + err.Error()}
+		}
+		return r
+	}
+	defer resp.Body.Close()
+	r.Code = resp.StatusCode
+
+	switch {
+	case c.Status != 0 && resp.StatusCode != c.Status:
+		r.Failures = append(r.Failures, fmt.Sprintf("HTTP %d, want %d", resp.StatusCode, c.Status))
+	case c.Status == 0 && (resp.StatusCode < 200 || resp.StatusCode > 299):
+		r.Failures = append(r.Failures, fmt.Sprintf("HTTP %d, want 2xx", resp.StatusCode))
+	}
+
+	if len(c.JSON) > 0 {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+		if err != nil {
+			r.Failures = append(r.Failures, "read body: "+err.Error())
+			return r
+		}
+		r.Failures = append(r.Failures, assertJSON(body, c.JSON)...)
+	}
+	r.OK = len(r.Failures) == 0
+	return r
+}
+
+// assertJSON checks each assertion against the document in body,
+// returning what didn't hold.
+func assertJSON(body []byte, assertions []config.JSONAssertion) []string {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return []string{"body is not JSON"}
+	}
+	var failures []string
+	for _, a := range assertions {
+		v, err := platform.JSONPath(doc, a.Path)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", a.Path, err))
+			continue
+		}
+		if a.Equals == "" {
+			continue
+		}
+		if got := formatValue(v); got != a.Equals {
+			failures = append(failures, fmt.Sprintf("%s is %s, want %s", a.Path, got, a.Equals))
+		}
+	}
+	return failures
+}
+
+// formatValue renders a JSON value the way it is written in config:
+// strings bare, numbers without a trailing .0, null as "null".
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		data, _ := json.Marshal(t)
+		return string(data)
+	}
+}
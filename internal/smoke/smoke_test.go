@@ -0,0 +1,83 @@
+package smoke
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/humanetools/orbit/internal/config"
+)
+
+func TestResolveURL(t *testing.T) {
+	tests := []struct {
+		base, path, want string
+	}{
+		{"https://api.example.com", "/health", "https://api.example.com/health"},
+		{"https://api.example.com/", "health", "https://api.example.com/health"},
+		{"https://example.com/api", "/v1/ping?full=1", "https://example.com/api/v1/ping?full=1"},
+		{"", "https://other.example.com/ok", "https://other.example.com/ok"},
+	}
+	for _, tt := range tests {
+		got, err := resolveURL(tt.base, tt.path)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveURL(%q, %q) = %q, %v; want %q", tt.base, tt.path, got, err, tt.want)
+		}
+	}
+	if _, err := resolveURL("", "/health"); err == nil {
+		t.Error("relative path without a service url: want error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Write([]byte(`{"status": "ok", "checks": {"db": true}, "replicas": 3}`))
+		case "/orders":
+			if r.Method != "POST" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	e := config.ServiceEntry{Name: "api", URL: srv.URL, Smoke: []config.SmokeCheck{
+		{Path: "/health", JSON: []config.JSONAssertion{{Path: "$.status", Equals: "ok"}, {Path: "$.checks.db", Equals: "true"}, {Path: "$.replicas"}}},
+		{Name: "create order", Path: "/orders", Method: "post", Status: 201},
+		{Path: "/health", JSON: []config.JSONAssertion{{Path: "$.status", Equals: "degraded"}, {Path: "$.version"}}},
+		{Path: "/missing"},
+	}}
+	results, err := Run(context.Background(), e)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	if !results[0].OK || !results[1].OK {
+		t.Errorf("passing checks failed: %+v, %+v", results[0], results[1])
+	}
+	if results[1].Check != "create order" || results[0].Check != "GET /health" {
+		t.Errorf("check names = %q, %q", results[0].Check, results[1].Check)
+	}
+	if r := results[2]; r.OK || len(r.Failures) != 2 || !strings.Contains(r.Failures[0], "is ok, want degraded") {
+		t.Errorf("JSON mismatch result = %+v", r)
+	}
+	if r := results[3]; r.OK || r.Code != 404 {
+		t.Errorf("404 result = %+v", r)
+	}
+	if Passed(results) {
+		t.Error("Passed = true with failing checks")
+	}
+
+	e.Smoke = []config.SmokeCheck{{Path: "/health", Timeout: "soon"}}
+	if _, err := Run(context.Background(), e); err == nil {
+		t.Error("invalid timeout: want error")
+	}
+}
@@ -0,0 +1,103 @@
+// Package trace identifies one orbit invocation, so the platform API calls
+// and errors it produced can be correlated, e.g. with a platform's support
+// team.
+package trace
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EnvID names the environment variable that sets the trace ID, so several
+// orbit invocations (e.g. the steps of a CI job) can share one.
+const EnvID = "ORBIT_TRACE_ID"
+
+var (
+	id      = initialID()
+	verbose atomic.Bool
+	calls   atomic.Int64
+
+	logMu  sync.Mutex
+	output io.Writer = os.Stderr
+)
+
+func initialID() string {
+	if v := os.Getenv(EnvID); v != "" {
+		return v
+	}
+	return NewID(time.Now(), rand.Reader)
+}
+
+// ID returns this invocation's trace ID.
+func ID() string {
+	return id
+}
+
+// crockford is the base32 alphabet of ULIDs: no I, L, O or U.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewID returns a ULID: 26 characters, a millisecond timestamp followed by
+// 80 random bits, so IDs sort by time.
+func NewID(now time.Time, random io.Reader) string {
+	var b [16]byte
+	ms := uint64(now.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+	io.ReadFull(random, b[6:])
+
+	// 128 bits as 26 base32 digits, the first holding the top 3 bits.
+	var out [26]byte
+	var acc uint64
+	var bits uint
+	pos := 25
+	for i := 15; i >= 0; i-- {
+		acc |= uint64(b[i]) << bits
+		bits += 8
+		for bits >= 5 {
+			out[pos] = crockford[acc&31]
+			pos--
+			acc >>= 5
+			bits -= 5
+		}
+	}
+	out[0] = crockford[acc&31]
+	return string(out[:])
+}
+
+// SetVerbose turns logging with Logf on or off.
+func SetVerbose(on bool) {
+	verbose.Store(on)
+}
+
+// Verbose reports whether Logf prints anything.
+func Verbose() bool {
+	return verbose.Load()
+}
+
+// Logf writes a line prefixed with the trace ID to stderr when verbose
+// logging is on.
+func Logf(format string, args ...interface{}) {
+	if !verbose.Load() {
+		return
+	}
+	logMu.Lock()
+	defer logMu.Unlock()
+	fmt.Fprintf(output, "[trace %s] %s\n", id, fmt.Sprintf(format, args...))
+}
+
+// CountCall records a platform API request.
+func CountCall() {
+	calls.Add(1)
+}
+
+// Calls returns how many platform API requests this invocation made.
+func Calls() int64 {
+	return calls.Load()
+}
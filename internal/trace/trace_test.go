@@ -0,0 +1,33 @@
+package trace
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewID(t *testing.T) {
+	at := time.UnixMilli(1469918176385) // the ULID spec's example time
+	got := NewID(at, bytes.NewReader(make([]byte, 10)))
+	if want := "01ARYZ6S410000000000000000"; got != want {
+		t.Errorf("NewID = %q, want %q", got, want)
+	}
+	later := NewID(at.Add(time.Millisecond), bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)))
+	if len(later) != 26 || later <= got || !strings.HasSuffix(later, "ZZZZZZZZZZZZZZZZ") {
+		t.Errorf("later ID %q", later)
+	}
+}
+
+func TestLogf(t *testing.T) {
+	var buf bytes.Buffer
+	output = &buf
+	defer SetVerbose(false)
+
+	Logf("hidden")
+	SetVerbose(true)
+	Logf("GET %s", "/v2/user")
+	if got, want := buf.String(), "[trace "+ID()+"] GET /v2/user\n"; got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,336 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// Dashboard limits.
+const (
+	dashDeploys   = 5  // recent deploys shown for the selected service
+	dashLogTail   = 50 // log lines fetched; as many as fit are shown
+	dashListWidth = 34 // width of the service list pane, border included
+)
+
+// DashboardSource loads what the dashboard shows. Its functions run in
+// Bubbletea commands, off the UI goroutine.
+type DashboardSource struct {
+	Statuses func(ctx context.Context) []ServiceResult
+	Deploys  func(ctx context.Context, entry config.ServiceEntry, limit int) ([]platform.Deployment, error)
+	Logs     func(ctx context.Context, entry config.ServiceEntry, tail int) ([]platform.LogEntry, error)
+}
+
+// --- Messages ---
+
+type dashStatusesMsg struct {
+	results []ServiceResult
+	at      time.Time
+}
+
+type dashTickMsg struct {
+	gen int
+}
+
+type dashDetailMsg struct {
+	service    string
+	deploys    []platform.Deployment
+	deploysErr error
+	logs       []platform.LogEntry
+	logsErr    error
+}
+
+// --- Model ---
+
+// DashboardModel is the Bubbletea model for orbit dashboard.
+type DashboardModel struct {
+	ctx      context.Context
+	project  string
+	src      DashboardSource
+	interval time.Duration
+
+	services []ServiceResult
+	cursor   int
+	loading  bool
+	updated  time.Time
+	gen      int // bumped per refresh so ticks from earlier refreshes are dropped
+
+	// Deploys and logs of the selected service, once loaded.
+	detailFor  string
+	deploys    []platform.Deployment
+	deploysErr error
+	logs       []platform.LogEntry
+	logsErr    error
+
+	width  int
+	height int
+}
+
+// NewDashboardModel creates a dashboard for project that refreshes every
+// interval.
+func NewDashboardModel(ctx context.Context, project string, src DashboardSource, interval time.Duration) DashboardModel {
+	return DashboardModel{
+		ctx:      ctx,
+		project:  project,
+		src:      src,
+		interval: interval,
+		loading:  true,
+	}
+}
+
+// Init satisfies tea.Model.
+func (m DashboardModel) Init() tea.Cmd {
+	return m.fetchStatuses()
+}
+
+func (m DashboardModel) fetchStatuses() tea.Cmd {
+	ctx, src := m.ctx, m.src
+	return func() tea.Msg {
+		return dashStatusesMsg{results: src.Statuses(ctx), at: time.Now()}
+	}
+}
+
+// fetchDetail loads deploys and logs of the selected service, skipping
+// whatever its platform cannot provide.
+func (m DashboardModel) fetchDetail() tea.Cmd {
+	r, ok := m.selected()
+	if !ok || r.Err != nil {
+		return nil
+	}
+	ctx, src := m.ctx, m.src
+	return func() tea.Msg {
+		msg := dashDetailMsg{service: r.Entry.Name}
+		if r.Caps.Has(platform.CapDeployments) {
+			msg.deploys, msg.deploysErr = src.Deploys(ctx, r.Entry, dashDeploys)
+		}
+		if r.Caps.Has(platform.CapLogs) {
+			msg.logs, msg.logsErr = src.Logs(ctx, r.Entry, dashLogTail)
+		}
+		return msg
+	}
+}
+
+func (m DashboardModel) selected() (ServiceResult, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.services) {
+		return ServiceResult{}, false
+	}
+	return m.services[m.cursor], true
+}
+
+// Update satisfies tea.Model.
+func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case dashStatusesMsg:
+		m.services = msg.results
+		m.updated = msg.at
+		m.loading = false
+		if m.cursor >= len(m.services) {
+			m.cursor = max(len(m.services)-1, 0)
+		}
+		m.gen++
+		gen := m.gen
+		tick := tea.Tick(m.interval, func(time.Time) tea.Msg { return dashTickMsg{gen: gen} })
+		return m, tea.Batch(tick, m.fetchDetail())
+
+	case dashTickMsg:
+		if msg.gen != m.gen || m.loading {
+			return m, nil
+		}
+		m.loading = true
+		return m, m.fetchStatuses()
+
+	case dashDetailMsg:
+		// Drop answers for a service that is no longer selected.
+		if r, ok := m.selected(); !ok || r.Entry.Name != msg.service {
+			return m, nil
+		}
+		m.detailFor = msg.service
+		m.deploys, m.deploysErr = msg.deploys, msg.deploysErr
+		m.logs, m.logsErr = msg.logs, msg.logsErr
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.updateKey(msg)
+	}
+	return m, nil
+}
+
+func (m DashboardModel) updateKey(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+	case "up", "k", "shift+tab":
+		if m.cursor > 0 {
+			m.cursor--
+			return m, m.fetchDetail()
+		}
+	case "down", "j", "tab":
+		if m.cursor < len(m.services)-1 {
+			m.cursor++
+			return m, m.fetchDetail()
+		}
+	case "r":
+		if !m.loading {
+			m.loading = true
+			return m, m.fetchStatuses()
+		}
+	}
+	return m, nil
+}
+
+// --- View ---
+
+var dashPaneStyle lipgloss.Style
+
+// buildDashboardStyles is called from buildStyles when the theme changes.
+func buildDashboardStyles() {
+	dashPaneStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorMuted)
+}
+
+// View satisfies tea.Model.
+func (m DashboardModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	header := ProjectTitleStyle.Render(m.project)
+	state := "updated " + m.updated.Format("15:04:05")
+	switch {
+	case m.updated.IsZero():
+		state = "loading..."
+	case m.loading:
+		state += " · refreshing..."
+	}
+	header += "  " + dimStyle.Render(fmt.Sprintf("%s · every %s", state, m.interval))
+
+	help := dimStyle.Render("↑/↓ select • r refresh • q quit")
+
+	// Header, help and the panes' borders take four lines.
+	paneHeight := max(m.height-4, 3)
+	detailWidth := max(m.width-dashListWidth-1, 20)
+
+	list := m.viewList(dashListWidth-2, paneHeight)
+	detail := m.viewDetail(detailWidth-4, paneHeight)
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		dashPaneStyle.Width(dashListWidth-2).Height(paneHeight).Render(list),
+		" ",
+		dashPaneStyle.Width(detailWidth-2).Height(paneHeight).Padding(0, 1).Render(detail),
+	)
+	return header + "\n" + panes + "\n" + help
+}
+
+func (m DashboardModel) viewList(width, height int) string {
+	if len(m.services) == 0 {
+		if m.loading {
+			return dimStyle.Render("Fetching statuses...")
+		}
+		return dimStyle.Render("No services in this project.")
+	}
+
+	var lines []string
+	for i, r := range m.services {
+		cursor := "  "
+		name := Pad(r.Entry.Name, width-13)
+		if i == m.cursor {
+			cursor = cursorStyle.Render("> ")
+			name = cursorStyle.Render(name)
+		}
+		status := ErrorStyle.Render(IconError + " error")
+		if r.Err == nil {
+			status = FormatStatus(r.Status.Status)
+		}
+		lines = append(lines, cursor+name+" "+status)
+	}
+	// Keep the cursor in view on long topologies.
+	if start := m.cursor - height + 1; start > 0 {
+		lines = lines[start:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m DashboardModel) viewDetail(width, height int) string {
+	r, ok := m.selected()
+	if !ok {
+		return ""
+	}
+
+	var lines []string
+	lines = append(lines, ProjectTitleStyle.Render(r.Entry.Name)+"  "+dimStyle.Render(r.Entry.Platform+" · "+r.Entry.ID))
+	if r.Err != nil {
+		lines = append(lines, "", ErrorStyle.Render(Truncate(r.Err.Error(), width)))
+		return strings.Join(lines, "\n")
+	}
+
+	s := r.Status
+	facts := []string{
+		"Status " + FormatStatus(s.Status),
+		"Response " + FormatResponseTime(s.ResponseMs),
+	}
+	if r.Caps.Has(platform.CapMetrics) {
+		facts = append(facts, "CPU "+FormatCPU(s.CPU), "Memory "+FormatMemory(s.Memory))
+	}
+	if r.Caps.Has(platform.CapInstances) {
+		facts = append(facts, "Instances "+FormatInstances(s.Instances, s.MaxInstances))
+	}
+	lines = append(lines, strings.Join(facts, "   "))
+	if s.Probe != nil {
+		lines = append(lines, "Probe "+formatProbe(s.Probe))
+	}
+
+	loaded := m.detailFor == r.Entry.Name
+
+	lines = append(lines, "", HeaderStyle.Render("Recent deploys"))
+	switch {
+	case !r.Caps.Has(platform.CapDeployments):
+		lines = append(lines, dimStyle.Render(r.Entry.Platform+" does not report deployments"))
+	case !loaded:
+		lines = append(lines, dimStyle.Render("Loading..."))
+	case m.deploysErr != nil:
+		lines = append(lines, ErrorStyle.Render(Truncate(m.deploysErr.Error(), width)))
+	case len(m.deploys) == 0:
+		lines = append(lines, dimStyle.Render("No deployments yet"))
+	}
+	if loaded {
+		for _, d := range m.deploys {
+			line := Pad(FormatStatus(d.Status), 12) + " " + Pad(FormatCommit(d.Commit), 8) + " " +
+				Pad(FormatBranch(d.Branch, d.PullRequest), 16) + " " + Pad(TimeAgo(d.CreatedAt), 10) + " " + d.Message
+			lines = append(lines, Truncate(line, width))
+		}
+	}
+
+	lines = append(lines, "", HeaderStyle.Render("Logs"))
+	switch {
+	case !r.Caps.Has(platform.CapLogs):
+		lines = append(lines, dimStyle.Render(r.Entry.Platform+" does not provide logs"))
+	case !loaded:
+		lines = append(lines, dimStyle.Render("Loading..."))
+	case m.logsErr != nil:
+		lines = append(lines, ErrorStyle.Render(Truncate(m.logsErr.Error(), width)))
+	case len(m.logs) == 0:
+		lines = append(lines, dimStyle.Render("No recent logs"))
+	default:
+		// Show the newest lines that fit.
+		logs := m.logs
+		if room := height - len(lines); len(logs) > room {
+			logs = logs[len(logs)-max(room, 0):]
+		}
+		for _, l := range logs {
+			line := l.Timestamp.Local().Format("15:04:05") + " " + Pad(l.Level, 5) + " " + l.Message
+			lines = append(lines, Truncate(strings.ReplaceAll(line, "\n", " "), width))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
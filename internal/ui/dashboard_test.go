@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+func dashboardFixture() DashboardModel {
+	results := []ServiceResult{
+		{Entry: config.ServiceEntry{Name: "web", Platform: "vercel"}, Status: &platform.ServiceStatus{Status: "healthy"}, Caps: platform.CapDeployments},
+		{Entry: config.ServiceEntry{Name: "api", Platform: "koyeb"}, Status: &platform.ServiceStatus{Status: "unhealthy"}, Caps: platform.CapDeployments | platform.CapLogs},
+		{Entry: config.ServiceEntry{Name: "db", Platform: "supabase"}, Err: errors.New("HTTP 500")},
+	}
+	src := DashboardSource{
+		Statuses: func(ctx context.Context) []ServiceResult { return results },
+		Deploys: func(ctx context.Context, e config.ServiceEntry, limit int) ([]platform.Deployment, error) {
+			return []platform.Deployment{{ID: e.Name + "-1", Status: "healthy", Commit: "abc1234def", Message: "deploy " + e.Name}}, nil
+		},
+		Logs: func(ctx context.Context, e config.ServiceEntry, tail int) ([]platform.LogEntry, error) {
+			return []platform.LogEntry{{Level: "info", Message: e.Name + " started"}}, nil
+		},
+	}
+	return NewDashboardModel(context.Background(), "shop", src, time.Millisecond)
+}
+
+// run feeds msg to m and then every message its commands produce, skipping
+// ticks so the loop ends. The fixture refreshes every millisecond, so its
+// ticks fire at once.
+func run(m DashboardModel, msg tea.Msg) DashboardModel {
+	queue := []tea.Msg{msg}
+	for len(queue) > 0 {
+		next, cmd := m.Update(queue[0])
+		m = next.(DashboardModel)
+		queue = append(queue[1:], drain(cmd)...)
+	}
+	return m
+}
+
+func drain(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var out []tea.Msg
+		for _, c := range msg {
+			if c != nil {
+				out = append(out, drain(c)...)
+			}
+		}
+		return out
+	case dashTickMsg, nil:
+		return nil
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+func TestDashboardNavigation(t *testing.T) {
+	m := dashboardFixture()
+	m = run(m, tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = run(m, m.Init()())
+
+	if len(m.services) != 3 || m.loading {
+		t.Fatalf("services = %d, loading = %v", len(m.services), m.loading)
+	}
+	view := m.View()
+	for _, want := range []string{"shop", "web", "api", "db", "deploy web"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q", want)
+		}
+	}
+	if !strings.Contains(view, "vercel does not provide logs") {
+		t.Error("view should say web has no logs")
+	}
+
+	m = run(m, tea.KeyMsg{Type: tea.KeyDown})
+	if m.cursor != 1 || m.detailFor != "api" {
+		t.Fatalf("cursor = %d, detail for %q", m.cursor, m.detailFor)
+	}
+	if view := m.View(); !strings.Contains(view, "api started") || !strings.Contains(view, "deploy api") {
+		t.Errorf("view is missing api's deploys or logs:\n%s", view)
+	}
+
+	// A late answer for a service that is no longer selected is dropped.
+	m = run(m, dashDetailMsg{service: "web", deploys: []platform.Deployment{{Message: "stale"}}})
+	if strings.Contains(m.View(), "stale") {
+		t.Error("stale detail shown")
+	}
+
+	m = run(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = run(m, tea.KeyMsg{Type: tea.KeyDown})
+	if m.cursor != 2 {
+		t.Errorf("cursor = %d, want it to stop at the last service", m.cursor)
+	}
+	if !strings.Contains(m.View(), "HTTP 500") {
+		t.Error("view should show db's error")
+	}
+}
+
+func TestDashboardIgnoresStaleTicks(t *testing.T) {
+	m := dashboardFixture()
+	m = run(m, m.Init()())
+	gen := m.gen
+
+	next, cmd := m.Update(dashTickMsg{gen: gen - 1})
+	if cmd != nil || next.(DashboardModel).loading {
+		t.Error("tick from an earlier refresh started a fetch")
+	}
+	next, cmd = m.Update(dashTickMsg{gen: gen})
+	if cmd == nil || !next.(DashboardModel).loading {
+		t.Error("current tick did not refresh")
+	}
+}
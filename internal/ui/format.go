@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -27,6 +28,30 @@ func TimeAgo(t time.Time) string {
 	}
 }
 
+// FormatGap returns a short span such as "4m" or "2h" for the time between
+// two events.
+func FormatGap(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// FormatUptime returns how long an instance has been up, or Dash when its
+// start time is unknown.
+func FormatUptime(started time.Time) string {
+	if started.IsZero() {
+		return Dash
+	}
+	return FormatGap(time.Since(started))
+}
+
 // FormatCommit returns the first 7 characters of a commit SHA, or Dash if empty.
 func FormatCommit(sha string) string {
 	if sha == "" {
@@ -38,6 +63,20 @@ func FormatCommit(sha string) string {
 	return sha
 }
 
+// FormatBranch formats a deployment's branch with its pull request number,
+// e.g. "feat/cart (#42)".
+func FormatBranch(branch string, pr int) string {
+	switch {
+	case branch == "" && pr == 0:
+		return Dash
+	case pr == 0:
+		return branch
+	case branch == "":
+		return fmt.Sprintf("#%d", pr)
+	}
+	return fmt.Sprintf("%s (#%d)", branch, pr)
+}
+
 // FormatResponseTime formats a response time in milliseconds.
 func FormatResponseTime(ms int) string {
 	if ms <= 0 {
@@ -72,3 +111,32 @@ func FormatInstances(current, max int) string {
 	}
 	return fmt.Sprintf("%d/%d", current, max)
 }
+
+// ProgressBar renders a fixed-width bar for a fraction between 0 and 1.
+func ProgressBar(fraction float64, width int) string {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	filled := int(fraction * float64(width))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// FormatBytes formats a byte count using binary units (KB, MB, GB).
+func FormatBytes(n int64) string {
+	if n <= 0 {
+		return Dash
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
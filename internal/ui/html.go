@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+// HTMLReport is a project status report rendered as a standalone HTML page.
+type HTMLReport struct {
+	Project     string
+	GeneratedAt time.Time
+	UptimeDays  int // period Uptime and Incidents cover
+	Services    []HTMLService
+	Thresholds  config.ThresholdConfig
+
+	// Set when the page is served live (orbit serve) rather than written once.
+	Refresh int        // seconds between automatic reloads
+	Nav     []HTMLLink // links shown above the title, e.g. other projects
+}
+
+// HTMLLink is a link in an HTMLReport's navigation.
+type HTMLLink struct {
+	Label, URL string
+	Current    bool
+}
+
+// HTMLService is one service of an HTMLReport.
+type HTMLService struct {
+	Result    ServiceResult
+	Uptime    float64 // percent of the period without an open incident
+	Incidents int     // incidents that overlapped the period
+	Deploys   []platform.Deployment
+	DeployErr error
+}
+
+type htmlRow struct {
+	Name, Platform, StatusClass, Status      string
+	Response, CPU, Memory, Instances, Uptime string
+	Incidents                                int
+	Err                                      string
+	Deploys                                  []htmlDeploy
+	DeployErr                                string
+}
+
+type htmlDeploy struct {
+	StatusClass, Status, When, Commit, Message string
+}
+
+type htmlPage struct {
+	Project, Generated string
+	UptimeDays         int
+	Healthy, Total     int
+	ShowMetrics        bool
+	ShowInstances      bool
+	Rows               []htmlRow
+	Violations         []ThresholdViolation
+	Colors             Theme
+	Refresh            int
+	Nav                []HTMLLink
+}
+
+// RenderHTMLReport writes r as a self-contained HTML page with inline
+// styles, so it can be mailed or archived as a single file. It uses the
+// light palette regardless of the terminal theme.
+func RenderHTMLReport(w io.Writer, r HTMLReport) error {
+	page := htmlPage{
+		Project:    r.Project,
+		Generated:  r.GeneratedAt.Format("Mon Jan 2, 2006 15:04 MST"),
+		UptimeDays: r.UptimeDays,
+		Total:      len(r.Services),
+		Colors:     Themes["light"],
+		Refresh:    r.Refresh,
+		Nav:        r.Nav,
+	}
+
+	var caps platform.Capabilities
+	for _, s := range r.Services {
+		if s.Result.Err == nil {
+			caps |= s.Result.Caps
+		}
+	}
+	page.ShowMetrics = caps.Has(platform.CapMetrics)
+	page.ShowInstances = caps.Has(platform.CapInstances)
+
+	for _, s := range r.Services {
+		res := s.Result
+		row := htmlRow{
+			Name:      res.Entry.Name,
+			Platform:  res.Entry.Platform,
+			Response:  Dash,
+			CPU:       Dash,
+			Memory:    Dash,
+			Instances: Dash,
+			Uptime:    fmt.Sprintf("%.2f%%", s.Uptime),
+			Incidents: s.Incidents,
+		}
+		if res.Err != nil {
+			row.StatusClass, row.Status = "error", "error"
+			row.Err = res.Err.Error()
+		} else {
+			row.StatusClass, row.Status = htmlStatus(res.Status.Status)
+			if row.StatusClass == "healthy" {
+				page.Healthy++
+			}
+			row.Response = FormatResponseTime(res.Status.ResponseMs)
+			if res.Caps.Has(platform.CapMetrics) {
+				row.CPU, row.Memory = FormatCPU(res.Status.CPU), FormatMemory(res.Status.Memory)
+			}
+			if res.Caps.Has(platform.CapInstances) {
+				row.Instances = FormatInstances(res.Status.Instances, res.Status.MaxInstances)
+			}
+			page.Violations = append(page.Violations, CheckThresholds(res.Entry.Name, res.Status, r.Thresholds)...)
+		}
+
+		if s.DeployErr != nil {
+			row.DeployErr = s.DeployErr.Error()
+		}
+		for _, d := range s.Deploys {
+			hd := htmlDeploy{
+				When:    d.CreatedAt.Format("Jan 2 15:04"),
+				Commit:  FormatCommit(d.Commit),
+				Message: Truncate(d.Message, 60),
+			}
+			hd.StatusClass, hd.Status = htmlStatus(d.Status)
+			row.Deploys = append(row.Deploys, hd)
+		}
+		page.Rows = append(page.Rows, row)
+	}
+
+	return htmlReportTmpl.Execute(w, page)
+}
+
+// htmlStatus returns the CSS class and label for a service or deployment
+// status, grouped like FormatStatus.
+func htmlStatus(status string) (class, label string) {
+	switch status {
+	case "healthy", "ready", "success":
+		return "healthy", status
+	case "warning", "degraded", "warn":
+		return "warn", status
+	case "unhealthy", "error", "failed":
+		return "error", status
+	case "sleeping", "paused":
+		return "sleep", status
+	case "":
+		return "muted", Dash
+	default:
+		return "muted", status
+	}
+}
+
+var htmlReportTmpl = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+{{- if .Refresh}}
+<meta http-equiv="refresh" content="{{.Refresh}}">
+{{- end}}
+<title>{{.Project}} status report</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #111827; margin: 2rem auto; max-width: 960px; padding: 0 1rem; }
+  h1 { color: {{.Colors.Primary}}; margin-bottom: 0.25rem; }
+  h2 { margin-top: 2rem; border-bottom: 1px solid #e5e7eb; padding-bottom: 0.25rem; }
+  .muted { color: {{.Colors.Muted}}; }
+  .healthy { color: {{.Colors.Healthy}}; }
+  .warn { color: {{.Colors.Warning}}; }
+  .error { color: {{.Colors.Error}}; }
+  .sleep { color: {{.Colors.Sleeping}}; }
+  .summary { font-size: 1.1rem; margin: 1rem 0; }
+  table { border-collapse: collapse; width: 100%; font-size: 0.9rem; }
+  th { text-align: left; color: {{.Colors.Muted}}; font-weight: 600; border-bottom: 2px solid #e5e7eb; padding: 0.4rem 0.6rem; }
+  td { border-bottom: 1px solid #f3f4f6; padding: 0.4rem 0.6rem; vertical-align: top; }
+  td.status { font-weight: 600; }
+  nav { margin-bottom: 1rem; font-size: 0.9rem; }
+  nav a { color: {{.Colors.Muted}}; margin-right: 1rem; text-decoration: none; }
+  nav a.current { color: {{.Colors.Primary}}; font-weight: 600; }
+  code { font-family: ui-monospace, Menlo, Consolas, monospace; font-size: 0.85rem; }
+</style>
+</head>
+<body>
+{{- if .Nav}}
+<nav>{{range .Nav}}<a href="{{.URL}}"{{if .Current}} class="current"{{end}}>{{.Label}}</a>{{end}}</nav>
+{{- end}}
+<h1>{{.Project}}</h1>
+<div class="muted">Generated {{.Generated}} by orbit</div>
+
+<p class="summary"><span class="{{if eq .Healthy .Total}}healthy{{else}}warn{{end}}">{{.Healthy}} of {{.Total}} services healthy</span>{{if .Violations}}, <span class="warn">{{len .Violations}} threshold violations</span>{{end}}</p>
+
+<h2>Status</h2>
+<table>
+<tr><th>Service</th><th>Platform</th><th>Status</th><th>Response</th>{{if .ShowMetrics}}<th>CPU</th><th>Memory</th>{{end}}{{if .ShowInstances}}<th>Instances</th>{{end}}<th>Uptime ({{.UptimeDays}}d)</th><th>Incidents</th></tr>
+{{- range .Rows}}
+<tr><td>{{.Name}}</td><td>{{.Platform}}</td><td class="status {{.StatusClass}}">{{.Status}}{{if .Err}}<div class="muted">{{.Err}}</div>{{end}}</td><td>{{.Response}}</td>{{if $.ShowMetrics}}<td>{{.CPU}}</td><td>{{.Memory}}</td>{{end}}{{if $.ShowInstances}}<td>{{.Instances}}</td>{{end}}<td>{{.Uptime}}</td><td>{{.Incidents}}</td></tr>
+{{- end}}
+</table>
+<p class="muted">Uptime is the share of the last {{.UptimeDays}} days without an open incident in orbit's incident log.</p>
+
+{{- if .Violations}}
+<h2>Threshold violations</h2>
+<table>
+<tr><th>Service</th><th>Metric</th><th>Value</th><th>Threshold</th></tr>
+{{- range .Violations}}
+<tr><td>{{.ServiceName}}</td><td>{{.Metric}}</td><td class="warn">{{.Value}}</td><td>{{.Threshold}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<h2>Recent deployments</h2>
+{{- range .Rows}}
+<h3>{{.Name}}</h3>
+{{- if .DeployErr}}
+<p class="muted">{{.DeployErr}}</p>
+{{- else if not .Deploys}}
+<p class="muted">No deployments found.</p>
+{{- else}}
+<table>
+<tr><th>Status</th><th>Deployed</th><th>Commit</th><th>Message</th></tr>
+{{- range .Deploys}}
+<tr><td class="status {{.StatusClass}}">{{.Status}}</td><td>{{.When}}</td><td><code>{{.Commit}}</code></td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/platform"
+)
+
+func TestRenderHTMLReport(t *testing.T) {
+	deployed := time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)
+	r := HTMLReport{
+		Project:     "shop",
+		GeneratedAt: deployed.Add(time.Hour),
+		UptimeDays:  7,
+		Thresholds:  config.ThresholdConfig{CPUPercent: 80},
+		Services: []HTMLService{
+			{
+				Result: ServiceResult{
+					Entry:  config.ServiceEntry{Name: "api", Platform: "koyeb"},
+					Status: &platform.ServiceStatus{Status: "healthy", ResponseMs: 120, CPU: 91, Memory: 40},
+					Caps:   platform.CapMetrics,
+				},
+				Uptime:    99.5,
+				Incidents: 1,
+				Deploys:   []platform.Deployment{{Status: "failed", CreatedAt: deployed, Commit: "abc1234def", Message: "<b>fix</b> retries"}},
+			},
+			{
+				Result:    ServiceResult{Entry: config.ServiceEntry{Name: "web", Platform: "vercel"}, Err: errors.New("invalid token")},
+				Uptime:    100,
+				DeployErr: errors.New("platform \"vercel\" not connected"),
+			},
+		},
+	}
+
+	var b strings.Builder
+	if err := RenderHTMLReport(&b, r); err != nil {
+		t.Fatalf("RenderHTMLReport: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"<h1>shop</h1>",
+		"1 of 2 services healthy",
+		"1 threshold violations",
+		`<td class="status healthy">healthy`,
+		"<th>CPU</th>",
+		"<td>99.50%</td>",
+		"invalid token",
+		`<td class="status error">failed</td>`,
+		"&lt;b&gt;fix&lt;/b&gt; retries",
+		"Uptime (7d)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Error("report contains terminal escape sequences")
+	}
+}
+
+func TestRenderHTMLReportServed(t *testing.T) {
+	r := HTMLReport{
+		Project:     "shop",
+		GeneratedAt: time.Now(),
+		UptimeDays:  7,
+		Refresh:     30,
+		Nav:         []HTMLLink{{Label: "blog", URL: "/projects/blog"}, {Label: "shop", URL: "/projects/shop", Current: true}},
+	}
+	var b strings.Builder
+	if err := RenderHTMLReport(&b, r); err != nil {
+		t.Fatalf("RenderHTMLReport: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		`<meta http-equiv="refresh" content="30">`,
+		`<a href="/projects/blog">blog</a>`,
+		`<a href="/projects/shop" class="current">shop</a>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+
+	b.Reset()
+	RenderHTMLReport(&b, HTMLReport{Project: "shop"})
+	if strings.Contains(b.String(), "refresh") || strings.Contains(b.String(), "<nav>") {
+		t.Error("a written report should not refresh or link elsewhere")
+	}
+}
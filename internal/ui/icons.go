@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// IconSet is the set of status markers printed before messages.
+type IconSet struct {
+	Healthy  string
+	Warning  string
+	Error    string
+	Sleeping string
+	Building string
+	Deploy   string
+	Watch    string
+	Timeout  string
+	Success  string
+	Failed   string
+	Rocket   string
+	Health   string
+}
+
+// IconSets holds the built-in icon sets.
+var IconSets = map[string]IconSet{
+	"emoji": {
+		Healthy:  "✓",
+		Warning:  "⚠",
+		Error:    "✗",
+		Sleeping: "⏳",
+		Building: "🔨",
+		Deploy:   "📦",
+		Watch:    "⏳",
+		Timeout:  "⏰",
+		Success:  "✅",
+		Failed:   "❌",
+		Rocket:   "🚀",
+		Health:   "🏥",
+	},
+	// Plain ASCII for terminals that cannot draw emoji.
+	"ascii": {
+		Healthy:  "[OK]",
+		Warning:  "[!!]",
+		Error:    "[XX]",
+		Sleeping: "[..]",
+		Building: "[..]",
+		Deploy:   "[>>]",
+		Watch:    "[..]",
+		Timeout:  "[!!]",
+		Success:  "[OK]",
+		Failed:   "[XX]",
+		Rocket:   "[>>]",
+		Health:   "[+]",
+	},
+}
+
+// IconSetNames returns the icon set names in alphabetical order.
+func IconSetNames() []string {
+	names := make([]string, 0, len(IconSets))
+	for name := range IconSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Override returns s with every non-empty icon of o applied on top.
+func (s IconSet) Override(o IconSet) IconSet {
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&s.Healthy, o.Healthy)
+	set(&s.Warning, o.Warning)
+	set(&s.Error, o.Error)
+	set(&s.Sleeping, o.Sleeping)
+	set(&s.Building, o.Building)
+	set(&s.Deploy, o.Deploy)
+	set(&s.Watch, o.Watch)
+	set(&s.Timeout, o.Timeout)
+	set(&s.Success, o.Success)
+	set(&s.Failed, o.Failed)
+	set(&s.Rocket, o.Rocket)
+	set(&s.Health, o.Health)
+	return s
+}
+
+// ApplyIcons sets the package icons.
+func ApplyIcons(s IconSet) {
+	IconHealthy = s.Healthy
+	IconWarning = s.Warning
+	IconError = s.Error
+	IconSleeping = s.Sleeping
+	IconBuilding = s.Building
+	IconDeploy = s.Deploy
+	IconWatch = s.Watch
+	IconTimeout = s.Timeout
+	IconSuccess = s.Success
+	IconFailed = s.Failed
+	IconRocket = s.Rocket
+	IconHealth = s.Health
+}
+
+// AutoIconSet picks "ascii" for terminals known to draw emoji as mojibake
+// (the legacy Windows console, the Linux virtual console, non-UTF-8
+// locales) and "emoji" everywhere else.
+func AutoIconSet(getenv func(string) string) string {
+	if getenv("TERM") == "linux" {
+		return "ascii"
+	}
+	if runtime.GOOS == "windows" {
+		// Windows Terminal, VS Code and ConEmu render emoji; conhost does not.
+		if getenv("WT_SESSION") != "" || getenv("TERM_PROGRAM") == "vscode" || getenv("ConEmuANSI") == "ON" {
+			return "emoji"
+		}
+		return "ascii"
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := getenv(key); v != "" {
+			v = strings.ToLower(v)
+			if strings.Contains(v, "utf-8") || strings.Contains(v, "utf8") {
+				return "emoji"
+			}
+			return "ascii"
+		}
+	}
+	return "emoji"
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestAutoIconSet(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("locale detection does not apply on Windows")
+	}
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"utf-8 locale", map[string]string{"LANG": "en_US.UTF-8"}, "emoji"},
+		{"lc_all wins", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, "ascii"},
+		{"posix locale", map[string]string{"LANG": "C"}, "ascii"},
+		{"linux console", map[string]string{"TERM": "linux", "LANG": "en_US.UTF-8"}, "ascii"},
+		{"no locale", map[string]string{}, "emoji"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			if got := AutoIconSet(getenv); got != tt.want {
+				t.Errorf("AutoIconSet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyIcons(t *testing.T) {
+	defer ApplyIcons(IconSets["emoji"])
+
+	ApplyIcons(IconSets["ascii"].Override(IconSet{Healthy: "OK"}))
+	if IconHealthy != "OK" || IconWarning != "[!!]" {
+		t.Errorf("icons = %q, %q", IconHealthy, IconWarning)
+	}
+	if got := FormatStatus("sleeping"); got != SleepingStyle.Render("[..] sleep") {
+		t.Errorf("FormatStatus(sleeping) = %q", got)
+	}
+}
@@ -2,65 +2,97 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
-// Status icons
-const (
-	IconHealthy  = "✓"
-	IconWarning  = "⚠"
-	IconError    = "✗"
-	IconSleeping = "⏳"
-	IconBuilding = "🔨"
-	IconDeploy   = "📦"
-	IconWatch    = "⏳"
-	IconSuccess  = "✅"
-	IconFailed   = "❌"
-	IconRocket   = "🚀"
-	IconHealth   = "🏥"
+// Status icons. These are set by ApplyIcons; the default is the emoji set.
+var (
+	IconHealthy  string
+	IconWarning  string
+	IconError    string
+	IconSleeping string
+	IconBuilding string
+	IconDeploy   string
+	IconWatch    string
+	IconTimeout  string
+	IconSuccess  string
+	IconFailed   string
+	IconRocket   string
+	IconHealth   string
 )
 
-// Status colors
+// Status colors. These and the styles below are set by ApplyTheme; the
+// default is the dark theme.
 var (
-	ColorHealthy  = lipgloss.Color("#22c55e") // green
-	ColorWarning  = lipgloss.Color("#eab308") // yellow
-	ColorError    = lipgloss.Color("#ef4444") // red
-	ColorSleeping = lipgloss.Color("#6b7280") // gray
-	ColorPrimary  = lipgloss.Color("#818cf8") // indigo
-	ColorMuted    = lipgloss.Color("#9ca3af") // gray-400
+	ColorHealthy  lipgloss.Color
+	ColorWarning  lipgloss.Color
+	ColorError    lipgloss.Color
+	ColorSleeping lipgloss.Color
+	ColorPrimary  lipgloss.Color
+	ColorMuted    lipgloss.Color
 )
 
 // Status text styles
 var (
-	HealthyStyle  = lipgloss.NewStyle().Foreground(ColorHealthy).Bold(true)
-	WarningStyle  = lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
-	ErrorStyle    = lipgloss.NewStyle().Foreground(ColorError).Bold(true)
-	SleepingStyle = lipgloss.NewStyle().Foreground(ColorSleeping)
-	MutedStyle    = lipgloss.NewStyle().Foreground(ColorMuted)
+	HealthyStyle  lipgloss.Style
+	WarningStyle  lipgloss.Style
+	ErrorStyle    lipgloss.Style
+	SleepingStyle lipgloss.Style
+	MutedStyle    lipgloss.Style
 )
 
 // Table styles
 var (
+	HeaderStyle    lipgloss.Style
+	CellStyle      lipgloss.Style
+	ViolationStyle lipgloss.Style
+)
+
+// ProjectBoxStyle frames project groups; ProjectTitleStyle renders project names.
+var (
+	ProjectBoxStyle   lipgloss.Style
+	ProjectTitleStyle lipgloss.Style
+)
+
+func init() {
+	ApplyIcons(IconSets["emoji"])
+	ApplyTheme(Themes[DefaultTheme])
+}
+
+// buildStyles derives every style from the current colors. A theme
+// without a muted color (monochrome) dims muted text instead.
+func buildStyles() {
+	HealthyStyle = lipgloss.NewStyle().Foreground(ColorHealthy).Bold(true)
+	WarningStyle = lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+	ErrorStyle = lipgloss.NewStyle().Foreground(ColorError).Bold(true)
+	SleepingStyle = lipgloss.NewStyle().Foreground(ColorSleeping)
+	MutedStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	if ColorMuted == "" {
+		SleepingStyle = SleepingStyle.Faint(true)
+		MutedStyle = MutedStyle.Faint(true)
+	}
+
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			PaddingRight(2)
+		Bold(true).
+		Foreground(ColorPrimary).
+		PaddingRight(2)
 
 	CellStyle = lipgloss.NewStyle().
-			PaddingRight(2)
+		PaddingRight(2)
 
 	ViolationStyle = lipgloss.NewStyle().
-			Foreground(ColorWarning).
-			PaddingLeft(1)
-)
+		Foreground(ColorWarning).
+		PaddingLeft(1)
+
+	ProjectBoxStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(0, 1)
 
-// Box style for project groups
-var ProjectBoxStyle = lipgloss.NewStyle().
-	Border(lipgloss.RoundedBorder()).
-	BorderForeground(ColorPrimary).
-	Padding(0, 1)
+	ProjectTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary)
 
-// Title style for project names
-var ProjectTitleStyle = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(ColorPrimary)
+	buildWizardStyles()
+	buildDashboardStyles()
+}
 
 // FormatStatus returns a styled status string with icon.
 func FormatStatus(status string) string {
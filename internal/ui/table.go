@@ -3,9 +3,12 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/incident"
 	"github.com/humanetools/orbit/internal/platform"
 )
 
@@ -13,7 +16,11 @@ import (
 type ServiceResult struct {
 	Entry  config.ServiceEntry
 	Status *platform.ServiceStatus
+	Caps   platform.Capabilities
 	Err    error
+	Cached bool // Status is a response cached by an earlier command
+
+	Incident *incident.Incident // the service's active incident, if any
 }
 
 // ThresholdViolation describes a metric that exceeds its threshold.
@@ -37,11 +44,25 @@ const (
 	colInst     = 10
 )
 
-func pad(s string, width int) string {
-	if len(s) >= width {
-		return s[:width]
+// Pad truncates or right-pads s to width terminal columns. Width is
+// measured as displayed: ANSI styling is ignored, and emoji and CJK
+// characters count as two columns.
+func Pad(s string, width int) string {
+	w := ansi.StringWidth(s)
+	if w > width {
+		s = ansi.Truncate(s, width, "")
+		w = ansi.StringWidth(s)
+	}
+	return s + strings.Repeat(" ", width-w)
+}
+
+// Truncate shortens s to at most width display columns, ending in "..."
+// when cut. Multi-byte characters are never split.
+func Truncate(s string, width int) string {
+	if ansi.StringWidth(s) <= width {
+		return s
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	return ansi.Truncate(s, width, "...")
 }
 
 func headerRow(cols ...string) string {
@@ -52,7 +73,7 @@ func headerRow(cols ...string) string {
 		if i < len(widths) {
 			w = widths[i]
 		}
-		parts = append(parts, HeaderStyle.Render(pad(c, w)))
+		parts = append(parts, HeaderStyle.Render(Pad(c, w)))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
 }
@@ -64,7 +85,7 @@ func cellRow(widths []int, cells ...string) string {
 		if i < len(widths) {
 			w = widths[i]
 		}
-		parts = append(parts, CellStyle.Render(pad(c, w)))
+		parts = append(parts, CellStyle.Render(Pad(c, w)))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
 }
@@ -115,43 +136,90 @@ func RenderOverviewTable(projectName string, results []ServiceResult) string {
 	return title + "\n" + box
 }
 
+// RenderIncidents lists the active incidents of results below a status
+// table, or returns "" when there are none.
+func RenderIncidents(results []ServiceResult) string {
+	var lines []string
+	for _, r := range results {
+		inc := r.Incident
+		if inc == nil {
+			continue
+		}
+		line := fmt.Sprintf("  %s %s incident #%d open %s", IconWarning, Pad(r.Entry.Name, colName), inc.ID, FormatGap(time.Since(inc.StartedAt)))
+		if inc.Title != "" {
+			line += ": " + inc.Title
+		}
+		lines = append(lines, WarningStyle.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // RenderDetailTable renders the L1 detail: single project with metrics.
 func RenderDetailTable(projectName string, results []ServiceResult, t config.ThresholdConfig) (string, []ThresholdViolation) {
 	var rows []string
 	var violations []ThresholdViolation
 
-	header := headerRow("Service", "Platform", "Status", "Response", "CPU", "Memory", "Instances")
-	rows = append(rows, header)
+	// Metric columns are shown only when at least one service's platform
+	// reports them; otherwise they would be a column of dashes.
+	var caps platform.Capabilities
+	for _, r := range results {
+		if r.Err == nil {
+			caps |= r.Caps
+		}
+	}
+	showMetrics := caps.Has(platform.CapMetrics)
+	showInst := caps.Has(platform.CapInstances)
+
+	widths := []int{colName, colPlatform, colStatus, colResp}
+	cols := []string{"Service", "Platform", "Status", "Response"}
+	if showMetrics {
+		widths = append(widths, colCPU, colMem)
+		cols = append(cols, "CPU", "Memory")
+	}
+	if showInst {
+		widths = append(widths, colInst)
+		cols = append(cols, "Instances")
+	}
+
+	var header []string
+	for i, c := range cols {
+		header = append(header, HeaderStyle.Render(Pad(c, widths[i])))
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, header...))
 
 	for _, r := range results {
 		if r.Err != nil {
-			row := cellRow(
-				[]int{colName, colPlatform, colStatus, colResp, colCPU, colMem, colInst},
-				r.Entry.Name,
-				r.Entry.Platform,
-				ErrorStyle.Render(IconError+" error"),
-				Dash, Dash, Dash, Dash,
-			)
-			rows = append(rows, row)
+			cells := []string{r.Entry.Name, r.Entry.Platform, ErrorStyle.Render(IconError + " error")}
+			for len(cells) < len(cols) {
+				cells = append(cells, Dash)
+			}
+			rows = append(rows, cellRow(widths, cells...))
 			continue
 		}
 
-		violations = append(violations, checkThresholds(r.Entry.Name, r.Status, t)...)
-
-		status := FormatStatus(r.Status.Status)
-		resp := FormatResponseTime(r.Status.ResponseMs)
-		cpu := FormatCPU(r.Status.CPU)
-		mem := FormatMemory(r.Status.Memory)
-		inst := FormatInstances(r.Status.Instances, r.Status.MaxInstances)
+		violations = append(violations, CheckThresholds(r.Entry.Name, r.Status, t)...)
 
-		row := cellRow(
-			[]int{colName, colPlatform, colStatus, colResp, colCPU, colMem, colInst},
+		cells := []string{
 			r.Entry.Name,
 			r.Entry.Platform,
-			status,
-			resp, cpu, mem, inst,
-		)
-		rows = append(rows, row)
+			FormatStatus(r.Status.Status),
+			FormatResponseTime(r.Status.ResponseMs),
+		}
+		if showMetrics {
+			cpu, mem := Dash, Dash
+			if r.Caps.Has(platform.CapMetrics) {
+				cpu, mem = FormatCPU(r.Status.CPU), FormatMemory(r.Status.Memory)
+			}
+			cells = append(cells, cpu, mem)
+		}
+		if showInst {
+			inst := Dash
+			if r.Caps.Has(platform.CapInstances) {
+				inst = FormatInstances(r.Status.Instances, r.Status.MaxInstances)
+			}
+			cells = append(cells, inst)
+		}
+		rows = append(rows, cellRow(widths, cells...))
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
@@ -162,11 +230,13 @@ func RenderDetailTable(projectName string, results []ServiceResult, t config.Thr
 }
 
 // RenderServiceDetail renders the L2 detail card for a single service.
-func RenderServiceDetail(projectName string, entry config.ServiceEntry, status *platform.ServiceStatus, t config.ThresholdConfig) (string, []ThresholdViolation) {
-	violations := checkThresholds(entry.Name, status, t)
+// Metrics the platform does not report (per caps) are left out, and the
+// instance list only appears when the platform could list instances.
+func RenderServiceDetail(projectName string, entry config.ServiceEntry, status *platform.ServiceStatus, caps platform.Capabilities, instances []platform.Instance, t config.ThresholdConfig) (string, []ThresholdViolation) {
+	violations := CheckThresholds(entry.Name, status, t)
 
 	kv := func(key, value string) string {
-		return HeaderStyle.Render(pad(key, 16)) + CellStyle.Render(value)
+		return HeaderStyle.Render(Pad(key, 16)) + CellStyle.Render(value)
 	}
 
 	var rows []string
@@ -175,9 +245,20 @@ func RenderServiceDetail(projectName string, entry config.ServiceEntry, status *
 	rows = append(rows, kv("ID", entry.ID))
 	rows = append(rows, kv("Status", FormatStatus(status.Status)))
 	rows = append(rows, kv("Response", FormatResponseTime(status.ResponseMs)))
-	rows = append(rows, kv("CPU", FormatCPU(status.CPU)))
-	rows = append(rows, kv("Memory", FormatMemory(status.Memory)))
-	rows = append(rows, kv("Instances", FormatInstances(status.Instances, status.MaxInstances)))
+	if status.Probe != nil {
+		rows = append(rows, kv("Probe", formatProbe(status.Probe)))
+	}
+	if caps.Has(platform.CapMetrics) {
+		rows = append(rows, kv("CPU", FormatCPU(status.CPU)))
+		rows = append(rows, kv("Memory", FormatMemory(status.Memory)))
+	}
+	if caps.Has(platform.CapInstances) {
+		rows = append(rows, kv("Instances", FormatInstances(status.Instances, status.MaxInstances)))
+	}
+	for _, in := range instances {
+		line := "  " + Pad(Truncate(in.ID, 22), 22) + " " + Pad(FormatStatus(in.State), 10) + " " + Pad(in.Region, 8) + " up " + FormatUptime(in.StartedAt)
+		rows = append(rows, CellStyle.Render(line))
+	}
 
 	if status.LastDeploy != nil {
 		d := status.LastDeploy
@@ -201,6 +282,21 @@ func RenderServiceDetail(projectName string, entry config.ServiceEntry, status *
 	return title + "\n" + box, violations
 }
 
+// formatProbe summarizes a health probe, e.g. "✓ HTTP 200 in 84ms".
+func formatProbe(r *platform.ProbeResult) string {
+	if !r.OK {
+		return ErrorStyle.Render(IconError + " " + r.Failure)
+	}
+	answer := "open"
+	if r.Code != 0 {
+		answer = fmt.Sprintf("HTTP %d", r.Code)
+	}
+	if r.Slow {
+		return WarningStyle.Render(fmt.Sprintf("%s %s in %dms, over budget", IconWarning, answer, r.LatencyMs))
+	}
+	return fmt.Sprintf("%s %s in %dms", IconSuccess, answer, r.LatencyMs)
+}
+
 // RenderViolations renders threshold violation warnings.
 func RenderViolations(violations []ThresholdViolation) string {
 	if len(violations) == 0 {
@@ -210,15 +306,21 @@ func RenderViolations(violations []ThresholdViolation) string {
 	var lines []string
 	lines = append(lines, WarningStyle.Render(IconWarning+" Threshold Warnings"))
 	for _, v := range violations {
-		line := fmt.Sprintf("  %s %s: %s (threshold: %s)",
-			IconWarning, v.ServiceName, v.Metric+" = "+v.Value, v.Threshold)
+		// Script thresholds may leave out the value or threshold.
+		line := fmt.Sprintf("  %s %s: %s", IconWarning, v.ServiceName, v.Metric)
+		if v.Value != "" {
+			line += " = " + v.Value
+		}
+		if v.Threshold != "" {
+			line += " (threshold: " + v.Threshold + ")"
+		}
 		lines = append(lines, ViolationStyle.Render(line))
 	}
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
-// checkThresholds compares service metrics against configured thresholds.
-func checkThresholds(name string, status *platform.ServiceStatus, t config.ThresholdConfig) []ThresholdViolation {
+// CheckThresholds compares service metrics against configured thresholds.
+func CheckThresholds(name string, status *platform.ServiceStatus, t config.ThresholdConfig) []ThresholdViolation {
 	var violations []ThresholdViolation
 
 	if t.ResponseTimeMs > 0 && status.ResponseMs > t.ResponseTimeMs {
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/humanetools/orbit/internal/config"
+	"github.com/humanetools/orbit/internal/incident"
+)
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"api", 6, "api   "},
+		{"checkout-service", 8, "checkout"},
+		{"결제", 6, "결제  "},                // two double-width runes
+		{"🟢 healthy", 12, "🟢 healthy  "}, // emoji is two columns
+		{"\x1b[32mok\x1b[0m", 4, "\x1b[32mok\x1b[0m  "},
+		{"서비스명", 5, "서비 "}, // a wide rune never straddles the edge
+	}
+	for _, tt := range tests {
+		got := Pad(tt.in, tt.width)
+		if got != tt.want {
+			t.Errorf("Pad(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if w := ansi.StringWidth(got); w != tt.width {
+			t.Errorf("Pad(%q, %d) is %d columns wide", tt.in, tt.width, w)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := Truncate("short", 10); got != "short" {
+		t.Errorf("Truncate kept %q", got)
+	}
+	if got := Truncate("fix: 결제 모듈 재시도 로직", 12); ansi.StringWidth(got) > 12 {
+		t.Errorf("Truncate = %q (%d columns), want at most 12", got, ansi.StringWidth(got))
+	}
+}
+
+func TestRenderIncidents(t *testing.T) {
+	if got := RenderIncidents([]ServiceResult{{Entry: config.ServiceEntry{Name: "api"}}}); got != "" {
+		t.Errorf("no incidents: got %q", got)
+	}
+	inc := &incident.Incident{ID: 12, Title: "api unhealthy", StartedAt: time.Now().Add(-5 * time.Minute)}
+	got := ansi.Strip(RenderIncidents([]ServiceResult{
+		{Entry: config.ServiceEntry{Name: "web"}},
+		{Entry: config.ServiceEntry{Name: "api"}, Incident: inc},
+	}))
+	if !strings.Contains(got, "api") || !strings.Contains(got, "incident #12 open 5m: api unhealthy") || strings.Contains(got, "web") {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultTheme is used when no theme is configured.
+const DefaultTheme = "dark"
+
+// Theme is a color palette. Colors are hex ("#22c55e") or ANSI numbers
+// ("2"); an empty color leaves text in the terminal's default color.
+type Theme struct {
+	Healthy  string
+	Warning  string
+	Error    string
+	Sleeping string
+	Primary  string
+	Muted    string
+}
+
+// Themes holds the built-in presets.
+var Themes = map[string]Theme{
+	// Bright tones for dark backgrounds.
+	"dark": {
+		Healthy:  "#22c55e", // green
+		Warning:  "#eab308", // yellow
+		Error:    "#ef4444", // red
+		Sleeping: "#6b7280", // gray
+		Primary:  "#818cf8", // indigo
+		Muted:    "#9ca3af", // gray-400
+	},
+	// Deeper tones that keep contrast on white backgrounds.
+	"light": {
+		Healthy:  "#15803d", // green-700
+		Warning:  "#a16207", // yellow-700
+		Error:    "#b91c1c", // red-700
+		Sleeping: "#4b5563", // gray-600
+		Primary:  "#4338ca", // indigo-700
+		Muted:    "#6b7280", // gray-500
+	},
+	// No colors; emphasis comes from bold and dim text only.
+	"monochrome": {},
+	// Solarized accents, readable on both solarized backgrounds.
+	"solarized": {
+		Healthy:  "#859900", // green
+		Warning:  "#b58900", // yellow
+		Error:    "#dc322f", // red
+		Sleeping: "#586e75", // base01
+		Primary:  "#268bd2", // blue
+		Muted:    "#839496", // base0
+	},
+}
+
+// ThemeNames returns the preset names in alphabetical order.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Override returns t with every non-empty color of o applied on top.
+func (t Theme) Override(o Theme) Theme {
+	set := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	set(&t.Healthy, o.Healthy)
+	set(&t.Warning, o.Warning)
+	set(&t.Error, o.Error)
+	set(&t.Sleeping, o.Sleeping)
+	set(&t.Primary, o.Primary)
+	set(&t.Muted, o.Muted)
+	return t
+}
+
+// ApplyTheme sets the package colors and rebuilds all styles from them.
+func ApplyTheme(t Theme) {
+	ColorHealthy = lipgloss.Color(t.Healthy)
+	ColorWarning = lipgloss.Color(t.Warning)
+	ColorError = lipgloss.Color(t.Error)
+	ColorSleeping = lipgloss.Color(t.Sleeping)
+	ColorPrimary = lipgloss.Color(t.Primary)
+	ColorMuted = lipgloss.Color(t.Muted)
+	buildStyles()
+}
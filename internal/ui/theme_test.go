@@ -0,0 +1,30 @@
+package ui
+
+import "testing"
+
+func TestThemeOverride(t *testing.T) {
+	got := Themes["light"].Override(Theme{Primary: "#0369a1"})
+	if got.Primary != "#0369a1" {
+		t.Errorf("Primary = %q, want override", got.Primary)
+	}
+	if got.Healthy != Themes["light"].Healthy {
+		t.Errorf("Healthy = %q, want preset color", got.Healthy)
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	defer ApplyTheme(Themes[DefaultTheme])
+
+	ApplyTheme(Themes["solarized"])
+	if ColorPrimary != "#268bd2" {
+		t.Errorf("ColorPrimary = %q", ColorPrimary)
+	}
+	if got := HeaderStyle.GetForeground(); got != ColorPrimary {
+		t.Errorf("HeaderStyle foreground = %v, want rebuilt style", got)
+	}
+
+	ApplyTheme(Themes["monochrome"])
+	if !MutedStyle.GetFaint() {
+		t.Error("monochrome muted text should be faint")
+	}
+}
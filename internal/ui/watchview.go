@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WatchRow is one service's line in the live view of a multi-service watch.
+type WatchRow struct {
+	Service  string
+	Platform string
+	// Phase is a deploy phase (waiting, detected, building, deploying,
+	// healthcheck) while the watch runs, and its result (success, failed,
+	// timeout, no_deployment, cancelled) once Finished.
+	Phase    string
+	DeployID string
+	Commit   string
+	Message  string // commit message while running, the outcome once finished
+	Finished bool
+	Elapsed  time.Duration // set when finished
+}
+
+// WatchRowMsg updates the row of the same service. Empty fields keep their
+// previous value.
+type WatchRowMsg WatchRow
+
+type watchTickMsg struct{}
+
+// WatchViewModel is the Bubbletea model that shows every watched service
+// on a line of its own, updated in place. It quits once every row has
+// finished.
+type WatchViewModel struct {
+	project string
+	rows    []WatchRow
+	start   time.Time
+	now     time.Time
+	// cancel stops the watchers on Ctrl+C; their rows then finish as
+	// interrupted and the view quits as usual.
+	cancel      func()
+	interrupted bool
+	width       int
+}
+
+// NewWatchViewModel creates the live view for rows, which start waiting.
+func NewWatchViewModel(project string, rows []WatchRow, cancel func()) WatchViewModel {
+	now := time.Now()
+	return WatchViewModel{project: project, rows: rows, start: now, now: now, cancel: cancel}
+}
+
+func watchTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return watchTickMsg{} })
+}
+
+// Init satisfies tea.Model.
+func (m WatchViewModel) Init() tea.Cmd {
+	return watchTick()
+}
+
+// Update satisfies tea.Model.
+func (m WatchViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case watchTickMsg:
+		m.now = time.Now()
+		return m, watchTick()
+
+	case WatchRowMsg:
+		m.now = time.Now()
+		for i := range m.rows {
+			if m.rows[i].Service == msg.Service {
+				m.rows[i] = mergeWatchRow(m.rows[i], WatchRow(msg))
+			}
+		}
+		if m.finished() {
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" && !m.interrupted {
+			m.interrupted = true
+			if m.cancel != nil {
+				m.cancel()
+			}
+		}
+	}
+	return m, nil
+}
+
+func mergeWatchRow(row, update WatchRow) WatchRow {
+	if update.Phase != "" {
+		row.Phase = update.Phase
+	}
+	if update.DeployID != "" {
+		row.DeployID = update.DeployID
+	}
+	if update.Commit != "" {
+		row.Commit = update.Commit
+	}
+	if update.Message != "" {
+		row.Message = update.Message
+	}
+	if update.Finished {
+		row.Finished = true
+		row.Elapsed = update.Elapsed
+	}
+	return row
+}
+
+func (m WatchViewModel) finished() bool {
+	for _, r := range m.rows {
+		if !r.Finished {
+			return false
+		}
+	}
+	return true
+}
+
+// View satisfies tea.Model.
+func (m WatchViewModel) View() string {
+	width := m.width
+	if width == 0 {
+		width = 100
+	}
+
+	done := 0
+	nameWidth := 8
+	for _, r := range m.rows {
+		if r.Finished {
+			done++
+		}
+		nameWidth = max(nameWidth, len(r.Service))
+	}
+
+	state := fmt.Sprintf("%d/%d done · %s", done, len(m.rows), formatElapsed(m.now.Sub(m.start)))
+	if m.interrupted {
+		state += " · stopping..."
+	}
+	lines := []string{fmt.Sprintf("%s Watching %s  %s", IconWatch, ProjectTitleStyle.Render(m.project), dimStyle.Render(state))}
+
+	for _, r := range m.rows {
+		elapsed := r.Elapsed
+		if !r.Finished {
+			elapsed = m.now.Sub(m.start)
+		}
+		line := fmt.Sprintf("  %s %s %s %s %s",
+			watchRowIcon(r), Pad(r.Service, nameWidth), dimStyle.Render(Pad(r.Platform, 10)),
+			Pad(watchRowPhase(r), 16), dimStyle.Render(Pad(formatElapsed(elapsed), 6)))
+		if r.Commit != "" {
+			line += " " + FormatCommit(r.Commit)
+		}
+		if r.Message != "" {
+			line += " " + dimStyle.Render(strings.ReplaceAll(r.Message, "\n", " "))
+		}
+		lines = append(lines, Truncate(line, width))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func watchRowIcon(r WatchRow) string {
+	switch r.Phase {
+	case "detected", "building":
+		return IconBuilding
+	case "deploying":
+		return IconDeploy
+	case "healthcheck":
+		return IconHealth
+	case "success":
+		return IconSuccess
+	case "failed":
+		return IconFailed
+	case "timeout":
+		return IconTimeout
+	case "no_deployment", "cancelled":
+		return IconWarning
+	}
+	return IconWatch
+}
+
+func watchRowPhase(r WatchRow) string {
+	switch r.Phase {
+	case "", "waiting":
+		return dimStyle.Render("waiting")
+	case "detected":
+		return "detected"
+	case "healthcheck":
+		return "health check"
+	case "success":
+		return HealthyStyle.Render("healthy")
+	case "failed":
+		return ErrorStyle.Render("failed")
+	case "timeout":
+		return WarningStyle.Render("timed out")
+	case "no_deployment":
+		return WarningStyle.Render("no deployment")
+	case "cancelled":
+		return dimStyle.Render("cancelled")
+	}
+	return r.Phase
+}
+
+// formatElapsed renders d as 42s or 3m05s.
+func formatElapsed(d time.Duration) string {
+	s := int(d.Seconds())
+	if s < 60 {
+		return fmt.Sprintf("%ds", s)
+	}
+	return fmt.Sprintf("%dm%02ds", s/60, s%60)
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWatchViewUpdates(t *testing.T) {
+	m := NewWatchViewModel("shop", []WatchRow{
+		{Service: "api", Platform: "koyeb", Phase: "waiting"},
+		{Service: "web", Platform: "vercel", Phase: "waiting"},
+	}, nil)
+
+	next, cmd := m.Update(WatchRowMsg{Service: "api", Phase: "building", DeployID: "dep_1", Commit: "3f2a9c1e", Message: "Add cart"})
+	m = next.(WatchViewModel)
+	if cmd != nil {
+		t.Fatal("view quit with services still running")
+	}
+	next, _ = m.Update(WatchRowMsg{Service: "api", Phase: "deploying"})
+	m = next.(WatchViewModel)
+	if r := m.rows[0]; r.Phase != "deploying" || r.DeployID != "dep_1" || r.Message != "Add cart" {
+		t.Errorf("api row = %+v, want deploying with the deployment kept", r)
+	}
+	view := m.View()
+	for _, want := range []string{"api", "deploying", "3f2a9c1", "web", "waiting", "0/2 done"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view lacks %q:\n%s", want, view)
+		}
+	}
+
+	next, cmd = m.Update(WatchRowMsg{Service: "api", Phase: "success", Finished: true, Elapsed: 42 * time.Second})
+	m = next.(WatchViewModel)
+	if cmd != nil {
+		t.Fatal("view quit with web still running")
+	}
+	next, cmd = m.Update(WatchRowMsg{Service: "web", Phase: "failed", Message: "build failed", Finished: true})
+	m = next.(WatchViewModel)
+	if cmd == nil {
+		t.Fatal("view didn't quit once every service finished")
+	}
+	view = m.View()
+	for _, want := range []string{"healthy", "42s", "failed", "build failed", "2/2 done"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view lacks %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestWatchViewInterrupt(t *testing.T) {
+	cancelled := false
+	m := NewWatchViewModel("shop", []WatchRow{{Service: "api"}}, func() { cancelled = true })
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m = next.(WatchViewModel)
+	if !cancelled {
+		t.Error("Ctrl+C didn't stop the watchers")
+	}
+	if !strings.Contains(m.View(), "stopping") {
+		t.Errorf("view doesn't show it is stopping:\n%s", m.View())
+	}
+}
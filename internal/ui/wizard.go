@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/humanetools/orbit/internal/cache"
 	"github.com/humanetools/orbit/internal/config"
 	"github.com/humanetools/orbit/internal/platform"
 )
@@ -18,6 +20,7 @@ const (
 	phasePlatformSelect // multi-select platforms to connect
 	phaseTokenInput     // enter token for current platform
 	phaseTokenValidate  // async validation + discovery
+	phaseTeamSelect     // pick the team to scope the current platform to
 	phaseProjectName    // enter project name
 	phaseServiceSelect  // multi-select discovered services
 	phaseSaving         // async save
@@ -30,6 +33,7 @@ type tokenValidatedMsg struct {
 	platform string
 	err      error
 	services []platform.DiscoveredService
+	teams    []platform.Team // set instead of services when a team must be picked first
 }
 
 type configSavedMsg struct {
@@ -54,6 +58,11 @@ type WizardModel struct {
 	rawTokens         map[string]string // platform → plaintext token (in memory only)
 	validationErr     string            // error from last validation
 
+	// Team selection — for tokens that reach several teams
+	teams      []platform.Team
+	teamCursor int               // 0 is the personal account, i is teams[i-1]
+	teamIDs    map[string]string // platform → selected team ID
+
 	// Project name
 	projectInput textinput.Model
 
@@ -68,13 +77,16 @@ type WizardModel struct {
 	saveErr      string
 
 	// General
+	ctx      context.Context // cancels token validation and discovery
+	refresh  bool            // skip cached discovery results
 	quitting bool
 	width    int
 	height   int
 }
 
-// NewWizardModel creates the initial wizard model.
-func NewWizardModel() WizardModel {
+// NewWizardModel creates the initial wizard model. Discovery reuses results
+// cached by earlier runs and commands unless refresh is set.
+func NewWizardModel(ctx context.Context, refresh bool) WizardModel {
 	names := platform.Names()
 	sort.Strings(names)
 
@@ -90,10 +102,13 @@ func NewWizardModel() WizardModel {
 	pi.Width = 40
 
 	return WizardModel{
+		ctx:              ctx,
+		refresh:          refresh,
 		phase:            phaseWelcome,
 		platforms:        names,
 		platformSelected: make(map[int]bool),
 		rawTokens:        make(map[string]string),
+		teamIDs:          make(map[string]string),
 		tokenInput:       ti,
 		projectInput:     pi,
 		serviceSelected:  make(map[int]bool),
@@ -138,6 +153,8 @@ func (m WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case phaseTokenValidate:
 		// Ignore key events while validating (except ctrl+c handled above)
 		return m, nil
+	case phaseTeamSelect:
+		return m.updateTeamSelect(msg)
 	case phaseProjectName:
 		return m.updateProjectName(msg)
 	case phaseServiceSelect:
@@ -215,7 +232,7 @@ func (m WizardModel) updateTokenInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.rawTokens[currentPlat] = token
 		m.phase = phaseTokenValidate
 		m.validationErr = ""
-		return m, validateTokenCmd(currentPlat, token)
+		return m, validateTokenCmd(m.ctx, currentPlat, token, m.refresh)
 	}
 
 	// Forward to textinput
@@ -224,6 +241,35 @@ func (m WizardModel) updateTokenInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m WizardModel) updateTeamSelect(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.Type {
+	case tea.KeyUp, tea.KeyShiftTab:
+		if m.teamCursor > 0 {
+			m.teamCursor--
+		}
+	case tea.KeyDown, tea.KeyTab:
+		if m.teamCursor < len(m.teams) {
+			m.teamCursor++
+		}
+	case tea.KeyEnter:
+		currentPlat := m.selectedPlatforms[m.currentPlatIdx]
+		teamID := ""
+		if m.teamCursor > 0 {
+			teamID = m.teams[m.teamCursor-1].ID
+		}
+		m.teamIDs[currentPlat] = teamID
+		m.phase = phaseTokenValidate
+		return m, discoverCmd(m.ctx, currentPlat, m.rawTokens[currentPlat], teamID, m.refresh)
+	}
+
+	return m, nil
+}
+
 func (m WizardModel) updateProjectName(msg tea.Msg) (tea.Model, tea.Cmd) {
 	key, ok := msg.(tea.KeyMsg)
 	if ok && key.Type == tea.KeyEnter {
@@ -236,7 +282,7 @@ func (m WizardModel) updateProjectName(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(m.allServices) == 0 {
 			// No services discovered — skip to saving
 			m.phase = phaseSaving
-			return m, saveConfigCmd(m.savedProject, m.rawTokens, nil)
+			return m, saveConfigCmd(m.savedProject, m.rawTokens, m.teamIDs, nil)
 		}
 
 		// Pre-select all services
@@ -282,7 +328,7 @@ func (m WizardModel) updateServiceSelect(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.phase = phaseSaving
-		return m, saveConfigCmd(m.savedProject, m.rawTokens, selected)
+		return m, saveConfigCmd(m.savedProject, m.rawTokens, m.teamIDs, selected)
 	}
 
 	return m, nil
@@ -307,6 +353,13 @@ func (m WizardModel) handleTokenValidated(msg tokenValidatedMsg) (tea.Model, tea
 		return m, m.tokenInput.Cursor.BlinkCmd()
 	}
 
+	if len(msg.teams) > 0 {
+		m.teams = msg.teams
+		m.teamCursor = 0
+		m.phase = phaseTeamSelect
+		return m, nil
+	}
+
 	// Accumulate discovered services
 	m.allServices = append(m.allServices, msg.services...)
 
@@ -336,28 +389,71 @@ func (m WizardModel) handleConfigSaved(msg configSavedMsg) (tea.Model, tea.Cmd)
 
 // --- Async commands ---
 
-func validateTokenCmd(name, token string) tea.Cmd {
+func validateTokenCmd(ctx context.Context, name, token string, refresh bool) tea.Cmd {
 	return func() tea.Msg {
 		p, err := platform.Get(name, token)
 		if err != nil {
 			return tokenValidatedMsg{platform: name, err: err}
 		}
 
-		if err := p.Validate(token); err != nil {
+		if err := p.Validate(ctx, token); err != nil {
 			return tokenValidatedMsg{platform: name, err: err}
 		}
 
-		// Also discover services if supported
-		var services []platform.DiscoveredService
-		if disc, ok := p.(platform.Discoverer); ok {
-			services, _ = disc.DiscoverServices()
+		// Tokens that reach teams pick one before discovery, which is
+		// team-scoped.
+		if _, ok := p.(platform.TeamConfigurable); ok {
+			if tl, ok := p.(platform.TeamLister); ok {
+				if teams, err := tl.ListTeams(ctx); err == nil && len(teams) > 0 {
+					return tokenValidatedMsg{platform: name, teams: teams}
+				}
+			}
 		}
 
-		return tokenValidatedMsg{platform: name, services: services}
+		return tokenValidatedMsg{platform: name, services: discover(ctx, p, name, token, "", refresh)}
 	}
 }
 
-func saveConfigCmd(projectName string, rawTokens map[string]string, services []platform.DiscoveredService) tea.Cmd {
+// discoverCmd discovers services once a team has been picked.
+func discoverCmd(ctx context.Context, name, token, teamID string, refresh bool) tea.Cmd {
+	return func() tea.Msg {
+		p, err := platform.Get(name, token)
+		if err != nil {
+			return tokenValidatedMsg{platform: name, err: err}
+		}
+		if tc, ok := p.(platform.TeamConfigurable); ok && teamID != "" {
+			tc.SetTeamID(teamID)
+		}
+		return tokenValidatedMsg{platform: name, services: discover(ctx, p, name, token, teamID, refresh)}
+	}
+}
+
+// discover lists p's services if it supports discovery, sharing the
+// response cache with orbit project create --auto and service add.
+func discover(ctx context.Context, p platform.Platform, name, token, teamID string, refresh bool) []platform.DiscoveredService {
+	disc, ok := p.(platform.Discoverer)
+	if !ok {
+		return nil
+	}
+
+	var rc *cache.Cache
+	if dir, err := cache.Dir(); err == nil {
+		rc = cache.New(dir)
+		rc.Refresh = refresh
+	}
+	ck := cache.DiscoveryKey(name, token, teamID, "")
+	var services []platform.DiscoveredService
+	if rc.Get(ck, cache.DiscoveryTTL, &services) {
+		return services
+	}
+	services, err := disc.DiscoverServices(ctx)
+	if err == nil {
+		rc.Put(ck, services)
+	}
+	return services
+}
+
+func saveConfigCmd(projectName string, rawTokens, teamIDs map[string]string, services []platform.DiscoveredService) tea.Cmd {
 	return func() tea.Msg {
 		key, err := config.LoadOrCreateKey()
 		if err != nil {
@@ -375,16 +471,18 @@ func saveConfigCmd(projectName string, rawTokens map[string]string, services []p
 			if err != nil {
 				return configSavedMsg{err: fmt.Errorf("encrypt %s token: %w", name, err)}
 			}
-			cfg.Platforms[name] = config.PlatformConfig{Token: enc}
+			cfg.Platforms[name] = config.PlatformConfig{Token: enc, TeamID: teamIDs[name]}
 		}
 
 		// Build topology
 		var topology []config.ServiceEntry
-		for _, svc := range services {
+		names := uniqueNames(services)
+		for i, svc := range services {
 			topology = append(topology, config.ServiceEntry{
-				Name:     svc.Name,
-				Platform: svc.Platform,
-				ID:       svc.ID,
+				Name:       names[i],
+				Platform:   svc.Platform,
+				ID:         svc.ID,
+				RemoteName: svc.Name,
 			})
 		}
 
@@ -399,30 +497,61 @@ func saveConfigCmd(projectName string, rawTokens map[string]string, services []p
 	}
 }
 
+// uniqueNames returns topology names for services, suffixing names shared
+// by several services with their platform (api-koyeb, api-flyio).
+func uniqueNames(services []platform.DiscoveredService) []string {
+	count := make(map[string]int)
+	for _, svc := range services {
+		count[svc.Name]++
+	}
+	taken := make(map[string]bool)
+	for name := range count {
+		taken[name] = true
+	}
+
+	names := make([]string, len(services))
+	for i, svc := range services {
+		names[i] = svc.Name
+		if count[svc.Name] > 1 {
+			names[i] = config.UniqueServiceName(svc.Name, svc.Platform, taken)
+			taken[names[i]] = true
+		}
+	}
+	return names
+}
+
 // --- View ---
 
 var (
+	wizardTitleStyle lipgloss.Style
+	wizardBoxStyle   lipgloss.Style
+	selectedStyle    lipgloss.Style
+	cursorStyle      lipgloss.Style
+	dimStyle         lipgloss.Style
+)
+
+// buildWizardStyles is called from buildStyles when the theme changes.
+func buildWizardStyles() {
 	wizardTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(ColorPrimary).
-				MarginBottom(1)
+		Bold(true).
+		Foreground(ColorPrimary).
+		MarginBottom(1)
 
 	wizardBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(1, 2)
 
 	selectedStyle = lipgloss.NewStyle().
-			Foreground(ColorHealthy).
-			Bold(true)
+		Foreground(ColorHealthy).
+		Bold(true)
 
 	cursorStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
+		Foreground(ColorPrimary).
+		Bold(true)
 
-	dimStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
-)
+	dimStyle = MutedStyle
+}
 
 func (m WizardModel) View() string {
 	if m.quitting {
@@ -440,6 +569,8 @@ func (m WizardModel) View() string {
 		s.WriteString(m.viewTokenInput())
 	case phaseTokenValidate:
 		s.WriteString(m.viewTokenValidate())
+	case phaseTeamSelect:
+		s.WriteString(m.viewTeamSelect())
 	case phaseProjectName:
 		s.WriteString(m.viewProjectName())
 	case phaseServiceSelect:
@@ -496,7 +627,11 @@ func (m WizardModel) viewTokenInput() string {
 	tokenURL := platform.TokenURL(name)
 	urlLine := ""
 	if tokenURL != "" {
-		urlLine = dimStyle.Render("Get your token at: "+tokenURL) + "\n\n"
+		urlLine = dimStyle.Render("Get your token at: "+tokenURL) + "\n"
+		for _, step := range platform.TokenSteps(name) {
+			urlLine += dimStyle.Render("  "+step) + "\n"
+		}
+		urlLine += "\n"
 	}
 
 	errLine := ""
@@ -522,6 +657,28 @@ func (m WizardModel) viewTokenValidate() string {
 	return wizardBoxStyle.Render(body)
 }
 
+func (m WizardModel) viewTeamSelect() string {
+	name := m.selectedPlatforms[m.currentPlatIdx]
+	title := wizardTitleStyle.Render(fmt.Sprintf("Select a %s team", name))
+
+	options := []string{"Personal account"}
+	for _, t := range m.teams {
+		options = append(options, fmt.Sprintf("%s %s", t.Name, dimStyle.Render("("+t.Slug+")")))
+	}
+
+	var items strings.Builder
+	for i, label := range options {
+		cursor := "  "
+		if i == m.teamCursor {
+			cursor = cursorStyle.Render("> ")
+		}
+		items.WriteString(cursor + label + "\n")
+	}
+	help := dimStyle.Render("↑/↓ move • Enter confirm")
+	body := fmt.Sprintf("%s\n\n%s\n%s", title, items.String(), help)
+	return wizardBoxStyle.Render(body)
+}
+
 func (m WizardModel) viewProjectName() string {
 	title := wizardTitleStyle.Render("Name your project")
 
@@ -549,6 +706,22 @@ func (m WizardModel) viewProjectName() string {
 func (m WizardModel) viewServiceSelect() string {
 	title := wizardTitleStyle.Render("Select services to monitor")
 
+	// Preview the names duplicates will be saved under.
+	var selected []platform.DiscoveredService
+	var selectedIdx []int
+	for i, svc := range m.allServices {
+		if m.serviceSelected[i] {
+			selected = append(selected, svc)
+			selectedIdx = append(selectedIdx, i)
+		}
+	}
+	renamed := make(map[int]string)
+	for j, name := range uniqueNames(selected) {
+		if name != selected[j].Name {
+			renamed[selectedIdx[j]] = name
+		}
+	}
+
 	var items strings.Builder
 	for i, svc := range m.allServices {
 		cursor := "  "
@@ -563,6 +736,12 @@ func (m WizardModel) viewServiceSelect() string {
 		if i == m.serviceCursor {
 			label = fmt.Sprintf("%s %s", cursorStyle.Render(svc.Name), dimStyle.Render("("+svc.Platform+")"))
 		}
+		if name, ok := renamed[i]; ok {
+			label += dimStyle.Render(" → " + name)
+		}
+		if name, ok := renamed[i]; ok {
+			label += dimStyle.Render(" → " + name)
+		}
 		items.WriteString(fmt.Sprintf("%s%s%s\n", cursor, check, label))
 	}
 